@@ -64,8 +64,84 @@ type BackupSpec struct {
 
 	// VolumeSnapshotLocations is a list containing names of VolumeSnapshotLocations associated with this backup.
 	VolumeSnapshotLocations []string `json:"volumeSnapshotLocations"`
+
+	// ServiceAccountName is the name of a service account to impersonate
+	// when reading objects to back up, so that the backup only includes
+	// objects the service account is allowed to get and list. The service
+	// account must be in the backup's namespace or, for self-service backups,
+	// in the namespace the backup was created in. Optional.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// FilterProfile is the name of a built-in filter profile, such as
+	// "cluster-migration", whose included and excluded resources are
+	// added to the backup's. Optional.
+	FilterProfile string `json:"filterProfile,omitempty"`
+
+	// ClientQPS is the maximum number of requests per second to make to
+	// the API server while reading objects to back up. It can only lower
+	// the limit configured on the Ark server. Optional.
+	ClientQPS int `json:"clientQPS,omitempty"`
+
+	// ClientBurst is the maximum burst of requests to make to the API
+	// server while reading objects to back up. It can only lower the
+	// limit configured on the Ark server. Optional.
+	ClientBurst int `json:"clientBurst,omitempty"`
+
+	// PageSize is the maximum number of objects to list from the API
+	// server in each request. If zero, the page size configured on the
+	// Ark server is used. Optional.
+	PageSize int `json:"pageSize,omitempty"`
+
+	// ArchiveFormat is the format of the archive the backed-up items
+	// are written to. Defaults to "tar.gz". Optional.
+	ArchiveFormat ArchiveFormat `json:"archiveFormat,omitempty"`
+
+	// Hold keeps the backup from being deleted, including when it
+	// expires, until it's set back to false. Optional.
+	Hold bool `json:"hold,omitempty"`
+
+	// FollowReferences, if true, also backs up namespace-scoped items
+	// outside the included namespaces that are directly referenced by
+	// backed-up items. Referenced items are backed up as-is, and are
+	// recorded in the backup's status as extra items. Optional.
+	FollowReferences bool `json:"followReferences,omitempty"`
+
+	// ResticHostPathVolumes, if true, backs up hostPath volumes listed in
+	// a pod's restic backup annotation from the node the pod runs on,
+	// instead of skipping them. The restic daemonset must mount the
+	// node's root filesystem for this to work. Optional.
+	ResticHostPathVolumes bool `json:"resticHostPathVolumes,omitempty"`
+
+	// AdditionalItems is a list of items, as resource[.group]/namespace/name,
+	// that are backed up even if they don't match the label selector or
+	// Helm releases. Their namespaces must be included in the backup.
+	// Optional.
+	AdditionalItems []string `json:"additionalItems,omitempty"`
+
+	// HelmReleases is a list of names of Helm releases to back up. If
+	// specified, only items labeled as belonging to one of the releases,
+	// with the app.kubernetes.io/instance or release label, are backed
+	// up, along with the releases' records in the Tiller namespace.
+	// Optional.
+	HelmReleases []string `json:"helmReleases,omitempty"`
+
+	// TillerNamespace is the namespace that Tiller stores the records
+	// of Helm releases in. Defaults to kube-system. Optional.
+	TillerNamespace string `json:"tillerNamespace,omitempty"`
 }
 
+// ArchiveFormat is the format of a backup's archive of items.
+type ArchiveFormat string
+
+const (
+	// ArchiveFormatTarGzip means the backup's items are written to a
+	// gzip-compressed tarball.
+	ArchiveFormatTarGzip ArchiveFormat = "tar.gz"
+
+	// ArchiveFormatZip means the backup's items are written to a zip file.
+	ArchiveFormatZip ArchiveFormat = "zip"
+)
+
 // BackupHooks contains custom behaviors that should be executed at different phases of the backup.
 type BackupHooks struct {
 	// Resources are hooks that should be executed when backing up individual instances of a resource.
@@ -101,8 +177,10 @@ type BackupResourceHookSpec struct {
 
 // BackupResourceHook defines a hook for a resource.
 type BackupResourceHook struct {
-	// Exec defines an exec hook.
+	// Exec defines an exec hook. Exec hooks only run for pods.
 	Exec *ExecHook `json:"exec"`
+	// HTTP defines an HTTP hook. HTTP hooks run for items of any resource.
+	HTTP *HTTPHook `json:"http,omitempty"`
 }
 
 // ExecHook is a hook that uses the pod exec API to execute a command in a container in a pod.
@@ -119,6 +197,23 @@ type ExecHook struct {
 	Timeout metav1.Duration `json:"timeout"`
 }
 
+// HTTPHook is a hook that sends an HTTP request, whose body is a JSON description of the
+// item, to a URL.
+type HTTPHook struct {
+	// URL is the http or https URL to send the request to.
+	URL string `json:"url"`
+	// Method is the request's method. If not specified, POST is used.
+	Method string `json:"method,omitempty"`
+	// Headers are additional headers to send with the request.
+	Headers map[string]string `json:"headers,omitempty"`
+	// OnError specifies how Ark should behave if the request fails or its response's status
+	// isn't 2xx. Defaults to Fail.
+	OnError HookErrorMode `json:"onError"`
+	// Timeout defines the maximum amount of time Ark should wait for the response before
+	// considering the request a failure.
+	Timeout metav1.Duration `json:"timeout"`
+}
+
 // HookErrorMode defines how Ark should treat an error from a hook.
 type HookErrorMode string
 
@@ -140,6 +235,10 @@ const (
 	// yet processed by the BackupController.
 	BackupPhaseNew BackupPhase = "New"
 
+	// BackupPhaseQueued means the backup is waiting for the
+	// BackupController to finish the backups ahead of it.
+	BackupPhaseQueued BackupPhase = "Queued"
+
 	// BackupPhaseFailedValidation means the backup has failed
 	// the controller's validations and therefore will not run.
 	BackupPhaseFailedValidation BackupPhase = "FailedValidation"
@@ -164,12 +263,26 @@ type BackupStatus struct {
 	// Version is the backup format version.
 	Version int `json:"version"`
 
+	// ServerVersion is the version of the Ark server that created the
+	// backup. Servers refuse to restore backups created by newer servers
+	// whose versions are incompatible with theirs.
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// ClusterUID identifies the cluster the backup was taken in, by the
+	// UID of its kube-system namespace. It's empty if the server couldn't
+	// get the namespace.
+	ClusterUID string `json:"clusterUID,omitempty"`
+
 	// Expiration is when this Backup is eligible for garbage-collection.
 	Expiration metav1.Time `json:"expiration"`
 
 	// Phase is the current state of the Backup.
 	Phase BackupPhase `json:"phase"`
 
+	// QueuePosition is the backup's position, starting at 1, among the
+	// backups waiting to run while it's Queued.
+	QueuePosition int `json:"queuePosition,omitempty"`
+
 	// VolumeBackups is a map of PersistentVolume names to
 	// information about the backed-up volume in the cloud
 	// provider API.
@@ -203,6 +316,48 @@ type BackupStatus struct {
 	// VolumeSnapshotsCompleted is the total number of successfully
 	// completed volume snapshots for this backup.
 	VolumeSnapshotsCompleted int `json:"volumeSnapshotsCompleted"`
+
+	// FailureReason is an error that caused the entire backup to fail.
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// TarballSizeBytes is the size of the backup's tarball in backup storage.
+	TarballSizeBytes int64 `json:"tarballSizeBytes,omitempty"`
+
+	// TarballSHA256 is the hex-encoded SHA-256 checksum of the backup's tarball.
+	TarballSHA256 string `json:"tarballSHA256,omitempty"`
+
+	// ExtraItems lists the items, as "resource/namespace/name", that were
+	// backed up because they're referenced by other backed-up items and
+	// the backup's spec.followReferences is true.
+	ExtraItems []string `json:"extraItems,omitempty"`
+
+	// TruncatedStatus is true if lists in this status were too long to be
+	// stored in full, in which case they were truncated and the complete
+	// lists were uploaded to backup storage as the backup's status details
+	// file.
+	TruncatedStatus bool `json:"truncatedStatus,omitempty"`
+
+	// HelmReleases lists the Helm releases whose records were backed up,
+	// with the revision and status of each release's newest record.
+	HelmReleases []HelmReleaseInfo `json:"helmReleases,omitempty"`
+}
+
+// HelmReleaseInfo captures the information about a backed-up Helm
+// release that's needed to restore it later.
+type HelmReleaseInfo struct {
+	// Name is the name of the release.
+	Name string `json:"name"`
+
+	// TillerNamespace is the namespace the release's records were
+	// backed up from.
+	TillerNamespace string `json:"tillerNamespace"`
+
+	// Revision is the release's newest revision.
+	Revision int `json:"revision"`
+
+	// Status is the status of the release's newest revision, such
+	// as DEPLOYED or FAILED.
+	Status string `json:"status"`
 }
 
 // VolumeBackupInfo captures the required information about
@@ -0,0 +1,119 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// BackupEstimateSpec is the specification for a BackupEstimate.
+type BackupEstimateSpec struct {
+	// Backup is the specification of the backup to estimate.
+	Backup BackupSpec `json:"backup"`
+}
+
+// BackupEstimatePhase is a string representation of the lifecycle phase
+// of a BackupEstimate.
+type BackupEstimatePhase string
+
+const (
+	// BackupEstimatePhaseNew means the estimate has been created but not
+	// yet processed by the BackupEstimateController.
+	BackupEstimatePhaseNew BackupEstimatePhase = "New"
+
+	// BackupEstimatePhaseInProgress means the items that the backup would
+	// include are being collected.
+	BackupEstimatePhaseInProgress BackupEstimatePhase = "InProgress"
+
+	// BackupEstimatePhaseCompleted means the estimate has been computed.
+	BackupEstimatePhaseCompleted BackupEstimatePhase = "Completed"
+
+	// BackupEstimatePhaseFailed means the estimate couldn't be computed.
+	BackupEstimatePhaseFailed BackupEstimatePhase = "Failed"
+)
+
+// ResourceEstimate is the number and size of the items of a resource that
+// a backup would include.
+type ResourceEstimate struct {
+	// Resource is the group-resource of the items, e.g. "deployments.apps".
+	Resource string `json:"resource"`
+
+	// Items is the number of items.
+	Items int `json:"items"`
+
+	// Size is the total size in bytes of the items' uncompressed JSON.
+	Size int64 `json:"size"`
+}
+
+// BackupEstimateStatus captures the current status of a BackupEstimate.
+type BackupEstimateStatus struct {
+	// Phase is the current state of the BackupEstimate.
+	Phase BackupEstimatePhase `json:"phase"`
+
+	// FailureReason is the reason the estimate couldn't be computed.
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// Resources lists the number and size of the items of each resource
+	// that the backup would include, sorted by resource.
+	Resources []ResourceEstimate `json:"resources,omitempty"`
+
+	// TotalItems is the number of items the backup would include.
+	TotalItems int `json:"totalItems"`
+
+	// TotalSize is the total size in bytes of the items' uncompressed JSON.
+	// Backup tarballs are compressed, so they're usually much smaller.
+	TotalSize int64 `json:"totalSize"`
+
+	// Volumes is the number of persistent volumes whose data the backup
+	// would snapshot or back up with restic.
+	Volumes int `json:"volumes"`
+
+	// VolumesSize is the total capacity in bytes of those volumes, which is
+	// an upper bound of the data that would be backed up.
+	VolumesSize int64 `json:"volumesSize"`
+
+	// Errors is the number of items that couldn't be collected. They aren't
+	// included in the estimate.
+	Errors int `json:"errors"`
+
+	// CompletionTimestamp records the time the estimate was computed.
+	CompletionTimestamp metav1.Time `json:"completionTimestamp"`
+
+	// Expiration is when this BackupEstimate expires and can be deleted
+	// by the system.
+	Expiration metav1.Time `json:"expiration"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackupEstimate is a request to estimate how many items a backup would
+// include and how much data it would store, without running the backup.
+type BackupEstimate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   BackupEstimateSpec   `json:"spec"`
+	Status BackupEstimateStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackupEstimateList is a list of BackupEstimates.
+type BackupEstimateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []BackupEstimate `json:"items"`
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// BackupPolicySpec is the specification for a BackupPolicy.
+type BackupPolicySpec struct {
+	// NamespaceSelector selects the namespaces that must be covered by
+	// a schedule. If empty, all namespaces must be covered.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// MinimumTTL is the shortest TTL that a schedule's backups may have
+	// for the schedule to cover a namespace. Schedules whose backups
+	// never expire always satisfy it. Optional.
+	MinimumTTL metav1.Duration `json:"minimumTTL,omitempty"`
+
+	// ScheduleTemplate, if set, is used to create a schedule for each
+	// selected namespace that isn't covered by any other schedule. The
+	// created schedules back up only their namespace. Optional.
+	ScheduleTemplate *ScheduleSpec `json:"scheduleTemplate,omitempty"`
+}
+
+// BackupPolicyPhase is a string representation of the lifecycle phase
+// of a BackupPolicy.
+type BackupPolicyPhase string
+
+const (
+	// BackupPolicyPhaseNew means the policy has been created but not
+	// yet evaluated by the BackupPolicyController.
+	BackupPolicyPhaseNew BackupPolicyPhase = "New"
+
+	// BackupPolicyPhaseCompliant means every namespace that the policy
+	// selects is covered by a schedule.
+	BackupPolicyPhaseCompliant BackupPolicyPhase = "Compliant"
+
+	// BackupPolicyPhaseNonCompliant means at least one namespace that the
+	// policy selects isn't covered by a schedule.
+	BackupPolicyPhaseNonCompliant BackupPolicyPhase = "NonCompliant"
+
+	// BackupPolicyPhaseFailedValidation means the policy has failed the
+	// controller's validations and isn't evaluated.
+	BackupPolicyPhaseFailedValidation BackupPolicyPhase = "FailedValidation"
+)
+
+// BackupPolicyStatus captures the current state of a BackupPolicy.
+type BackupPolicyStatus struct {
+	// Phase is the current phase of the BackupPolicy.
+	Phase BackupPolicyPhase `json:"phase"`
+
+	// ValidationErrors is a slice of all validation errors (if
+	// applicable).
+	ValidationErrors []string `json:"validationErrors"`
+
+	// CoveredNamespaces is the number of selected namespaces that are
+	// covered by a schedule.
+	CoveredNamespaces int `json:"coveredNamespaces"`
+
+	// NonCompliantNamespaces is the sorted list of the selected
+	// namespaces that aren't covered by a schedule.
+	NonCompliantNamespaces []string `json:"nonCompliantNamespaces,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackupPolicy is an Ark resource that requires namespaces to be covered
+// by a schedule whose backups are retained for at least a minimum time,
+// and optionally creates schedules for the namespaces that aren't.
+type BackupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   BackupPolicySpec   `json:"spec"`
+	Status BackupPolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackupPolicyList is a list of BackupPolicies.
+type BackupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []BackupPolicy `json:"items"`
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -66,6 +68,74 @@ type BackupStorageLocationSpec struct {
 	Config map[string]string `json:"config"`
 
 	StorageType `json:",inline"`
+
+	// Tenants configures where within the location each tenant's backups are
+	// stored, and how much storage each tenant can use. Optional.
+	Tenants []TenantStorage `json:"tenants,omitempty"`
+
+	// MaxObjectSize is the size of the largest object to store in the
+	// location. Backup tarballs larger than it are split into parts of at
+	// most this size. Optional.
+	MaxObjectSize *resource.Quantity `json:"maxObjectSize,omitempty"`
+
+	// SignedURLTTL is how long the signed URLs created to download files
+	// from the location are valid for. Defaults to 10 minutes. Optional.
+	SignedURLTTL *metav1.Duration `json:"signedURLTTL,omitempty"`
+
+	// MaxDownloadSize is the size of the largest file that signed URLs are
+	// created for. Optional.
+	MaxDownloadSize *resource.Quantity `json:"maxDownloadSize,omitempty"`
+
+	// ObjectOptions are provider-specific options, such as a storage class,
+	// to set on the objects uploaded for each kind of file. Backup metadata
+	// is always uploaded without options. Optional.
+	ObjectOptions map[DownloadTargetKind]map[string]string `json:"objectOptions,omitempty"`
+
+	// DeletionProtection prevents Ark from removing files from the location.
+	// Deleting a backup stored in it, including when the backup expires, only
+	// deletes the backup from the cluster; its files and volume snapshots are
+	// retained. Optional.
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// SourceClusters lists other clusters that store their backups in the
+	// location's bucket under their own prefixes. Their backups are synced
+	// into this cluster so they can be listed and restored, but can only be
+	// deleted by the cluster that created them. Optional.
+	SourceClusters []SourceCluster `json:"sourceClusters,omitempty"`
+
+	// ResticCredentials selects the key of a secret in the Ark server's
+	// namespace that holds the password of the location's restic
+	// repositories. Defaults to the repository-password key of the
+	// ark-restic-credentials secret. Optional.
+	ResticCredentials *corev1api.SecretKeySelector `json:"resticCredentials,omitempty"`
+}
+
+// SourceCluster identifies another cluster's backups within a backup storage
+// location's bucket.
+type SourceCluster struct {
+	// Name identifies the cluster. Backups synced from the cluster are
+	// labeled with it.
+	Name string `json:"name"`
+
+	// Prefix is the path inside the location's bucket that the cluster
+	// stores its backups under.
+	Prefix string `json:"prefix"`
+}
+
+// TenantStorage configures the storage used by a single tenant's backups within
+// a backup storage location. A tenant is identified by the namespace of its
+// self-service backups.
+type TenantStorage struct {
+	// Namespace is the tenant's namespace.
+	Namespace string `json:"namespace"`
+
+	// Prefix is the path under the location's tenants directory to use for the
+	// tenant's backups. Defaults to the tenant's namespace.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Quota is the maximum total size of the tenant's backup tarballs in the
+	// location. Optional.
+	Quota *resource.Quantity `json:"quota,omitempty"`
 }
 
 // BackupStorageLocationPhase is the lifecyle phase of an Ark BackupStorageLocation.
@@ -40,4 +40,26 @@ const (
 	// NamespaceScopedDir is the name of the directory containing namespace-scoped
 	// resource within an Ark backup.
 	NamespaceScopedDir = "namespaces"
+
+	// ArchiveIndexFile is the name of the file at the end of an Ark backup
+	// that lists the path, size, checksum, and offset of each item in it.
+	ArchiveIndexFile = "index.json"
+
+	// ArchiveVersionFile is the name of the file at the start of an Ark
+	// backup that holds the format version of the backup's layout.
+	ArchiveVersionFile = "metadata/version"
+
+	// ServerVersionConfigMap is the name of the config map in the Ark
+	// namespace that the Ark server records its version in, under the
+	// ServerVersionKey and ServerGitCommitKey keys, so that clients can
+	// check that they're compatible with it.
+	ServerVersionConfigMap = "ark-server-version"
+
+	// ServerVersionKey is the key of the Ark server's version in the
+	// ServerVersionConfigMap config map.
+	ServerVersionKey = "version"
+
+	// ServerGitCommitKey is the key of the Ark server's git commit in the
+	// ServerVersionConfigMap config map.
+	ServerGitCommitKey = "gitCommit"
 )
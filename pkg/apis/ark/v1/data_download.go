@@ -0,0 +1,88 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// DataDownloadSpec is the specification for a DataDownload.
+type DataDownloadSpec struct {
+	// PodVolumeBackup is the name of the pod volume backup, in the
+	// DataDownload's namespace, whose data is restored.
+	PodVolumeBackup string `json:"podVolumeBackup"`
+
+	// TargetNamespace is the namespace of the persistent volume claim
+	// that the data is restored into.
+	TargetNamespace string `json:"targetNamespace"`
+
+	// TargetPersistentVolumeClaim is the name of the existing persistent
+	// volume claim that the data is restored into.
+	TargetPersistentVolumeClaim string `json:"targetPersistentVolumeClaim"`
+}
+
+// DataDownloadPhase represents the lifecycle phase of a DataDownload.
+type DataDownloadPhase string
+
+const (
+	DataDownloadPhaseNew              DataDownloadPhase = "New"
+	DataDownloadPhaseFailedValidation DataDownloadPhase = "FailedValidation"
+	DataDownloadPhaseInProgress       DataDownloadPhase = "InProgress"
+	DataDownloadPhaseCompleted        DataDownloadPhase = "Completed"
+	DataDownloadPhaseFailed           DataDownloadPhase = "Failed"
+)
+
+// DataDownloadStatus is the current status of a DataDownload.
+type DataDownloadStatus struct {
+	// Phase is the current state of the DataDownload.
+	Phase DataDownloadPhase `json:"phase"`
+
+	// ValidationErrors is a slice of all validation errors (if
+	// applicable).
+	ValidationErrors []string `json:"validationErrors"`
+
+	// Message is a message about the data download's status.
+	Message string `json:"message"`
+
+	// DataMoverPod is the name of the pod, in the target namespace, that
+	// mounts the persistent volume claim while its data is restored.
+	DataMoverPod string `json:"dataMoverPod"`
+
+	// PodVolumeRestore is the name of the pod volume restore that restores
+	// the data into the data mover pod's volume.
+	PodVolumeRestore string `json:"podVolumeRestore"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DataDownload restores the data of a pod volume backup into an existing
+// persistent volume claim, without restoring the pod the volume belonged to.
+type DataDownload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   DataDownloadSpec   `json:"spec"`
+	Status DataDownloadStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DataDownloadList is a list of DataDownloads.
+type DataDownloadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []DataDownload `json:"items"`
+}
@@ -28,11 +28,15 @@ type DownloadRequestSpec struct {
 type DownloadTargetKind string
 
 const (
-	DownloadTargetKindBackupLog             DownloadTargetKind = "BackupLog"
-	DownloadTargetKindBackupContents        DownloadTargetKind = "BackupContents"
-	DownloadTargetKindBackupVolumeSnapshots DownloadTargetKind = "BackupVolumeSnapshots"
-	DownloadTargetKindRestoreLog            DownloadTargetKind = "RestoreLog"
-	DownloadTargetKindRestoreResults        DownloadTargetKind = "RestoreResults"
+	DownloadTargetKindBackupLog                DownloadTargetKind = "BackupLog"
+	DownloadTargetKindBackupContents           DownloadTargetKind = "BackupContents"
+	DownloadTargetKindBackupVolumeSnapshots    DownloadTargetKind = "BackupVolumeSnapshots"
+	DownloadTargetKindBackupPodVolumeSnapshots DownloadTargetKind = "BackupPodVolumeSnapshots"
+	DownloadTargetKindBackupIndex              DownloadTargetKind = "BackupIndex"
+	DownloadTargetKindBackupStatusDetails      DownloadTargetKind = "BackupStatusDetails"
+	DownloadTargetKindRestoreLog               DownloadTargetKind = "RestoreLog"
+	DownloadTargetKindRestoreResults           DownloadTargetKind = "RestoreResults"
+	DownloadTargetKindRestoreManifest          DownloadTargetKind = "RestoreManifest"
 )
 
 // DownloadTarget is the specification for what kind of file to download, and the name of the
@@ -29,6 +29,14 @@ const (
 	// RestoreUIDLabel is the label key used to identify a restore by uid.
 	RestoreUIDLabel = "ark.heptio.com/restore-uid"
 
+	// ScheduleNameLabel is the label key used to identify the schedule that
+	// created a backup, and the schedule of the backup that a restore is from.
+	ScheduleNameLabel = "ark-schedule"
+
+	// DataDownloadNameLabel is the label key used to identify a data
+	// download by name.
+	DataDownloadNameLabel = "ark.heptio.com/data-download-name"
+
 	// PodUIDLabel is the label key used to identify a pod by uid.
 	PodUIDLabel = "ark.heptio.com/pod-uid"
 
@@ -37,6 +45,12 @@ const (
 	// restic backups/restores).
 	PodVolumeOperationTimeoutAnnotation = "ark.heptio.com/pod-volume-timeout"
 
+	// ResetRouteHostsAnnotation is the annotation key used to request that a
+	// restore remove the hosts of all restored OpenShift routes, so that the
+	// cluster they're restored into generates new ones, rather than only the
+	// hosts that were generated by the cluster they were backed up from.
+	ResetRouteHostsAnnotation = "ark.heptio.com/reset-route-hosts"
+
 	// StorageLocationLabel is the label key used to identify the storage
 	// location of a backup.
 	StorageLocationLabel = "ark.heptio.com/storage-location"
@@ -44,4 +58,69 @@ const (
 	// ResticVolumeNamespaceLabel is the label key used to identify which
 	// namespace a restic repository stores pod volume backups for.
 	ResticVolumeNamespaceLabel = "ark.heptio.com/volume-namespace"
+
+	// SelfServiceNamespaceLabel is the label key used to identify the namespace
+	// of the self-service backup or restore that an Ark backup or restore was
+	// created for.
+	SelfServiceNamespaceLabel = "ark.heptio.com/self-service-namespace"
+
+	// SelfServiceNameLabel is the label key used to identify the name of the
+	// self-service backup or restore that an Ark backup or restore was created
+	// for.
+	SelfServiceNameLabel = "ark.heptio.com/self-service-name"
+
+	// SelfServiceNamespacesAnnotation is the annotation key used on a backup
+	// storage location to list the namespaces (comma-separated, or "*" for all
+	// namespaces) whose self-service backups may be stored in it.
+	SelfServiceNamespacesAnnotation = "ark.heptio.com/self-service-namespaces"
+
+	// NeverExpireAnnotation is the annotation key used to keep a backup from
+	// being garbage collected when it expires. Backups are kept when the
+	// annotation's value is "true".
+	NeverExpireAnnotation = "ark.heptio.com/never-expire"
+
+	// LatestScheduledBackupAnnotation is the annotation key used to mark a
+	// backup that the Ark server annotated to never expire because it was
+	// the latest completed backup of its schedule, so that the annotations
+	// can be removed once a newer backup of the schedule completes.
+	LatestScheduledBackupAnnotation = "ark.heptio.com/latest-scheduled-backup"
+
+	// ScheduleUIDAnnotation is the annotation key used to record the uid of
+	// the schedule that created a backup.
+	ScheduleUIDAnnotation = "ark.heptio.com/schedule-uid"
+
+	// ScheduleSequenceAnnotation is the annotation key used to record which
+	// run of its schedule a backup was created by. The first backup a
+	// schedule creates has sequence number 1.
+	ScheduleSequenceAnnotation = "ark.heptio.com/schedule-sequence"
+
+	// PreviousBackupAnnotation is the annotation key used to record the name
+	// of the backup created by the previous run of a backup's schedule.
+	PreviousBackupAnnotation = "ark.heptio.com/previous-backup"
+
+	// BackupScheduleAnnotation is the annotation key used on a namespace to
+	// have Ark back it up on a schedule. The annotation's value is a cron
+	// expression, e.g. "0 2 * * *".
+	BackupScheduleAnnotation = "ark.heptio.com/backup-schedule"
+
+	// ScheduleNamespaceLabel is the label key used to identify the namespace
+	// that a schedule was created for from its BackupScheduleAnnotation.
+	ScheduleNamespaceLabel = "ark.heptio.com/schedule-namespace"
+
+	// ProtectedApplicationLabel is the label key used to identify the
+	// protected application that a schedule was created for, and that the
+	// schedule's backups back up.
+	ProtectedApplicationLabel = "ark.heptio.com/protected-application"
+
+	// BackupPolicyLabel is the label key used to identify the backup policy
+	// that a schedule was created for from its schedule template.
+	BackupPolicyLabel = "ark.heptio.com/backup-policy"
+
+	// RestoreVerificationLabel is the label key used to identify the restore
+	// verification that a restore or verification job was created for.
+	RestoreVerificationLabel = "ark.heptio.com/restore-verification"
+
+	// SourceClusterLabel is the label key used to identify the cluster that
+	// a backup synced from another cluster's storage was created by.
+	SourceClusterLabel = "ark.heptio.com/source-cluster"
 )
@@ -43,6 +43,23 @@ type PodVolumeBackupSpec struct {
 	// Tags are a map of key-value pairs that should be applied to the
 	// volume backup as tags.
 	Tags map[string]string `json:"tags"`
+
+	// HostPath is the path on the node of a hostPath volume. If set, it's
+	// backed up from the node's root filesystem rather than from the
+	// pod's volume directory.
+	HostPath string `json:"hostPath,omitempty"`
+
+	// IncludePaths are the paths within the volume to back up. If empty,
+	// the whole volume is backed up.
+	IncludePaths []string `json:"includePaths,omitempty"`
+
+	// ExcludePatterns are restic patterns for files within the volume
+	// that shouldn't be backed up.
+	ExcludePatterns []string `json:"excludePatterns,omitempty"`
+
+	// Timeout is how long the restic backup of the volume is allowed
+	// to run. If zero, it isn't limited.
+	Timeout metav1.Duration `json:"timeout"`
 }
 
 // PodVolumeBackupPhase represents the lifecycle phase of a PodVolumeBackup.
@@ -53,6 +70,7 @@ const (
 	PodVolumeBackupPhaseInProgress PodVolumeBackupPhase = "InProgress"
 	PodVolumeBackupPhaseCompleted  PodVolumeBackupPhase = "Completed"
 	PodVolumeBackupPhaseFailed     PodVolumeBackupPhase = "Failed"
+	PodVolumeBackupPhaseTimedOut   PodVolumeBackupPhase = "TimedOut"
 )
 
 // PodVolumeBackupStatus is the current status of a PodVolumeBackup.
@@ -68,6 +86,10 @@ type PodVolumeBackupStatus struct {
 
 	// Message is a message about the pod volume backup's status.
 	Message string `json:"message"`
+
+	// Attempts is the number of times restic backup has been run for
+	// the volume, including retries after transient failures.
+	Attempts int `json:"attempts,omitempty"`
 }
 
 // +genclient
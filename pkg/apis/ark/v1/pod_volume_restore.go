@@ -38,6 +38,14 @@ type PodVolumeRestoreSpec struct {
 
 	// SnapshotID is the ID of the volume snapshot to be restored.
 	SnapshotID string `json:"snapshotID"`
+
+	// Verify indicates whether the restored files should be checked
+	// against the snapshot's contents once the restore completes.
+	Verify bool `json:"verify,omitempty"`
+
+	// Timeout is how long the restic restore of the volume is allowed
+	// to run. If zero, it isn't limited.
+	Timeout metav1.Duration `json:"timeout"`
 }
 
 // PodVolumeRestorePhase represents the lifecycle phase of a PodVolumeRestore.
@@ -48,6 +56,7 @@ const (
 	PodVolumeRestorePhaseInProgress PodVolumeRestorePhase = "InProgress"
 	PodVolumeRestorePhaseCompleted  PodVolumeRestorePhase = "Completed"
 	PodVolumeRestorePhaseFailed     PodVolumeRestorePhase = "Failed"
+	PodVolumeRestorePhaseTimedOut   PodVolumeRestorePhase = "TimedOut"
 )
 
 // PodVolumeRestoreStatus is the current status of a PodVolumeRestore.
@@ -57,6 +66,35 @@ type PodVolumeRestoreStatus struct {
 
 	// Message is a message about the pod volume restore's status.
 	Message string `json:"message"`
+
+	// Attempts is the number of times restic restore has been run for
+	// the volume, including retries after transient failures.
+	Attempts int `json:"attempts,omitempty"`
+
+	// Verification is the result of checking the restored files against
+	// the snapshot. It's only set if verification was requested.
+	Verification *PodVolumeRestoreVerification `json:"verification,omitempty"`
+}
+
+// PodVolumeRestoreVerification is the result of comparing the files
+// restored into a volume with the contents of the restic snapshot.
+type PodVolumeRestoreVerification struct {
+	// SnapshotFiles is the number of regular files in the snapshot.
+	SnapshotFiles int `json:"snapshotFiles"`
+
+	// SnapshotBytes is the total size of the regular files in the snapshot.
+	SnapshotBytes int64 `json:"snapshotBytes"`
+
+	// VerifiedFiles is the number of snapshot files found in the restored
+	// volume with the expected size.
+	VerifiedFiles int `json:"verifiedFiles"`
+
+	// VerifiedBytes is the total size of the verified files.
+	VerifiedBytes int64 `json:"verifiedBytes"`
+
+	// Mismatches lists (up to a limit) the files that are missing from the
+	// restored volume or whose size doesn't match the snapshot.
+	Mismatches []string `json:"mismatches,omitempty"`
 }
 
 // +genclient
@@ -0,0 +1,123 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ProtectedApplicationSpec is the specification for a ProtectedApplication.
+type ProtectedApplicationSpec struct {
+	// IncludedNamespaces is a slice of the names of the namespaces the
+	// application runs in.
+	IncludedNamespaces []string `json:"includedNamespaces"`
+
+	// LabelSelector selects the application's objects in its namespaces.
+	// If empty or nil, all objects in the namespaces belong to the
+	// application. Optional.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// PersistentVolumeClaims is a list of persistent volume claims, as
+	// namespace/name, that are backed up with the application even if
+	// they don't match its label selector. Optional.
+	PersistentVolumeClaims []string `json:"persistentVolumeClaims,omitempty"`
+
+	// Hooks are the hooks to run when backing up the application.
+	// Optional.
+	Hooks BackupHooks `json:"hooks,omitempty"`
+
+	// Schedule is a Cron expression defining when to back up the
+	// application.
+	Schedule string `json:"schedule"`
+
+	// TTL is a time.Duration-parseable string describing how long the
+	// application's backups should be retained for. Optional.
+	TTL metav1.Duration `json:"ttl,omitempty"`
+
+	// SnapshotVolumes specifies whether to take cloud snapshots of the
+	// application's persistent volumes. Optional.
+	SnapshotVolumes *bool `json:"snapshotVolumes,omitempty"`
+
+	// StorageLocation is the name of the BackupStorageLocation to store
+	// the application's backups in. Optional.
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// VolumeSnapshotLocations is a list of the names of the
+	// VolumeSnapshotLocations to store the application's volume
+	// snapshots in. Optional.
+	VolumeSnapshotLocations []string `json:"volumeSnapshotLocations,omitempty"`
+}
+
+// ProtectedApplicationPhase is a string representation of the lifecycle
+// phase of a ProtectedApplication.
+type ProtectedApplicationPhase string
+
+const (
+	// ProtectedApplicationPhaseNew means the application has been created
+	// but not yet processed by the ProtectedApplicationController.
+	ProtectedApplicationPhaseNew ProtectedApplicationPhase = "New"
+
+	// ProtectedApplicationPhaseEnabled means the application has been
+	// validated and its schedule is backing it up.
+	ProtectedApplicationPhaseEnabled ProtectedApplicationPhase = "Enabled"
+
+	// ProtectedApplicationPhaseFailedValidation means the application has
+	// failed the controller's validations and isn't being backed up.
+	ProtectedApplicationPhaseFailedValidation ProtectedApplicationPhase = "FailedValidation"
+)
+
+// ProtectedApplicationStatus captures the current state of a
+// ProtectedApplication.
+type ProtectedApplicationStatus struct {
+	// Phase is the current phase of the ProtectedApplication.
+	Phase ProtectedApplicationPhase `json:"phase"`
+
+	// ValidationErrors is a slice of all validation errors (if
+	// applicable).
+	ValidationErrors []string `json:"validationErrors"`
+
+	// ScheduleName is the name of the Schedule generated from the
+	// application, which creates its backups.
+	ScheduleName string `json:"scheduleName,omitempty"`
+
+	// LastBackupName is the name of the last Backup of the application.
+	LastBackupName string `json:"lastBackupName,omitempty"`
+
+	// LastBackupPhase is the phase of the last Backup of the application.
+	LastBackupPhase BackupPhase `json:"lastBackupPhase,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProtectedApplication is an Ark resource that defines an application as a
+// set of namespaces, objects, and persistent volume claims that are backed
+// up together on a schedule, and can be restored by name.
+type ProtectedApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   ProtectedApplicationSpec   `json:"spec"`
+	Status ProtectedApplicationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProtectedApplicationList is a list of ProtectedApplications.
+type ProtectedApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ProtectedApplication `json:"items"`
+}
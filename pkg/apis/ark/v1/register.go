@@ -60,14 +60,23 @@ func newTypeInfo(pluralName string, itemType, itemListType runtime.Object) typeI
 func CustomResources() map[string]typeInfo {
 	return map[string]typeInfo{
 		"Backup":                 newTypeInfo("backups", &Backup{}, &BackupList{}),
+		"BackupEstimate":         newTypeInfo("backupestimates", &BackupEstimate{}, &BackupEstimateList{}),
+		"BackupPolicy":           newTypeInfo("backuppolicies", &BackupPolicy{}, &BackupPolicyList{}),
 		"Restore":                newTypeInfo("restores", &Restore{}, &RestoreList{}),
+		"RestorePriority":        newTypeInfo("restorepriorities", &RestorePriority{}, &RestorePriorityList{}),
+		"RestoreVerification":    newTypeInfo("restoreverifications", &RestoreVerification{}, &RestoreVerificationList{}),
+		"ResticDaemonSetConfig":  newTypeInfo("resticdaemonsetconfigs", &ResticDaemonSetConfig{}, &ResticDaemonSetConfigList{}),
 		"Schedule":               newTypeInfo("schedules", &Schedule{}, &ScheduleList{}),
+		"UndoRestoreRequest":     newTypeInfo("undorestorerequests", &UndoRestoreRequest{}, &UndoRestoreRequestList{}),
 		"DownloadRequest":        newTypeInfo("downloadrequests", &DownloadRequest{}, &DownloadRequestList{}),
 		"DeleteBackupRequest":    newTypeInfo("deletebackuprequests", &DeleteBackupRequest{}, &DeleteBackupRequestList{}),
 		"PodVolumeBackup":        newTypeInfo("podvolumebackups", &PodVolumeBackup{}, &PodVolumeBackupList{}),
 		"PodVolumeRestore":       newTypeInfo("podvolumerestores", &PodVolumeRestore{}, &PodVolumeRestoreList{}),
+		"DataDownload":           newTypeInfo("datadownloads", &DataDownload{}, &DataDownloadList{}),
+		"ProtectedApplication":   newTypeInfo("protectedapplications", &ProtectedApplication{}, &ProtectedApplicationList{}),
 		"ResticRepository":       newTypeInfo("resticrepositories", &ResticRepository{}, &ResticRepositoryList{}),
 		"BackupStorageLocation":  newTypeInfo("backupstoragelocations", &BackupStorageLocation{}, &BackupStorageLocationList{}),
+		"VolumeSnapshot":         newTypeInfo("volumesnapshots", &VolumeSnapshot{}, &VolumeSnapshotList{}),
 		"VolumeSnapshotLocation": newTypeInfo("volumesnapshotlocations", &VolumeSnapshotLocation{}, &VolumeSnapshotLocationList{}),
 	}
 }
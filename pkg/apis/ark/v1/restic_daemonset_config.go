@@ -0,0 +1,63 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultResticDaemonSetConfigName is the name of the ResticDaemonSetConfig
+// that the Ark server applies to the restic daemonset.
+const DefaultResticDaemonSetConfigName = "default"
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResticDaemonSetConfig defines where the restic daemonset's pods run
+// and the resources they're allowed to use.
+type ResticDaemonSetConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec ResticDaemonSetConfigSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResticDaemonSetConfigList is a list of ResticDaemonSetConfigs.
+type ResticDaemonSetConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ResticDaemonSetConfig `json:"items"`
+}
+
+// ResticDaemonSetConfigSpec defines the specification for an Ark
+// ResticDaemonSetConfig.
+type ResticDaemonSetConfigSpec struct {
+	// NodeSelector restricts the restic pods to nodes with matching
+	// labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the restic pods to run on tainted nodes.
+	Tolerations []corev1api.Toleration `json:"tolerations,omitempty"`
+
+	// Resources are the CPU and memory requests and limits of the restic
+	// container.
+	Resources corev1api.ResourceRequirements `json:"resources,omitempty"`
+}
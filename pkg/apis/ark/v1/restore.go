@@ -29,6 +29,18 @@ type RestoreSpec struct {
 	// from the most recent successful backup created from this schedule.
 	ScheduleName string `json:"scheduleName,omitempty"`
 
+	// ApplicationName is the name of the Ark protected application to
+	// restore. If specified, and BackupName and ScheduleName are empty,
+	// Ark will restore from the most recent successful backup of the
+	// application.
+	ApplicationName string `json:"applicationName,omitempty"`
+
+	// AsOf is a point in time to restore to. If specified along with
+	// ScheduleName or ApplicationName, Ark will restore from the most recent
+	// successful backup created from the schedule or of the application that
+	// started at or before this time. Optional.
+	AsOf *metav1.Time `json:"asOf,omitempty"`
+
 	// IncludedNamespaces is a slice of namespace names to include objects
 	// from. If empty, all namespaces are included.
 	IncludedNamespaces []string `json:"includedNamespaces"`
@@ -48,9 +60,25 @@ type RestoreSpec struct {
 	// NamespaceMapping is a map of source namespace names
 	// to target namespace names to restore into. Any source
 	// namespaces not included in the map will be restored into
-	// namespaces of the same name.
+	// namespaces of the same name. Target names may be Go
+	// templates using {{ .Restore.Name }}, {{ .Backup.Name }} and
+	// {{ .Timestamp }}, which are expanded when the restore is
+	// validated.
 	NamespaceMapping map[string]string `json:"namespaceMapping"`
 
+	// NamespaceOrder is a list of namespace names in the backup whose
+	// objects are restored first, one namespace at a time in the given
+	// order, so that namespaces that others depend on are complete before
+	// those are restored. Cluster-scoped objects are restored before any
+	// namespace, and the objects of namespaces that aren't listed are
+	// restored last, resource by resource. Optional.
+	NamespaceOrder []string `json:"namespaceOrder,omitempty"`
+
+	// IterationMode specifies whether the restore's namespaced objects are
+	// restored resource by resource across all namespaces, or namespace by
+	// namespace. If empty, defaults to ByResource. Optional.
+	IterationMode RestoreIterationMode `json:"iterationMode,omitempty"`
+
 	// LabelSelector is a metav1.LabelSelector to filter with
 	// when restoring individual objects from the backup. If empty
 	// or nil, all objects are included. Optional.
@@ -60,12 +88,320 @@ type RestoreSpec struct {
 	// PVs from snapshot (via the cloudprovider).
 	RestorePVs *bool `json:"restorePVs,omitempty"`
 
+	// VolumeIDMapping maps the names of backed-up PVs to the IDs of
+	// existing volumes in the cloud provider, such as disks created
+	// before migrating. Mapped PVs are restored with the existing volume
+	// instead of one created from their snapshot, and with a reclaim
+	// policy of Retain. Optional.
+	VolumeIDMapping map[string]string `json:"volumeIDMapping,omitempty"`
+
+	// VolumeOverrides change the type and IOPS of the volumes created
+	// from the snapshots of PVs, e.g. to use cheaper volumes outside of
+	// production. Optional.
+	VolumeOverrides []RestoreVolumeOverride `json:"volumeOverrides,omitempty"`
+
 	// IncludeClusterResources specifies whether cluster-scoped resources
 	// should be included for consideration in the restore. If null, defaults
 	// to true.
 	IncludeClusterResources *bool `json:"includeClusterResources,omitempty"`
+
+	// IncludeArkResources specifies whether objects in the Ark server's own
+	// namespace, such as its backup storage locations and credentials,
+	// may be restored. If false, the Ark server's namespace is excluded
+	// from restores of all namespaces, and restores that explicitly include
+	// or map a namespace into it fail validation. Optional.
+	IncludeArkResources bool `json:"includeArkResources,omitempty"`
+
+	// ServiceAccountName is the name of a service account to impersonate
+	// when creating and patching restored objects and namespaces, so that the
+	// restore can only modify objects the service account is allowed to. The service
+	// account must be in the restore's namespace or, for self-service
+	// restores, in the namespace the restore was created in. Optional.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// RestorePriorityName is the name of the RestorePriority that defines
+	// the order in which resources are restored. If empty, the RestorePriority
+	// named "default" is used if it exists. Optional.
+	RestorePriorityName string `json:"restorePriorityName,omitempty"`
+
+	// ConflictPolicy specifies what to do with objects in the backup that
+	// already exist in the cluster and are different from the backed up
+	// version. If empty, defaults to Skip. Optional.
+	ConflictPolicy RestoreConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// RecreateResources lists the resources, e.g. deployments.apps, whose
+	// existing objects are deleted and recreated from the backup when they're
+	// different from the backed up version, if ConflictPolicy is Recreate.
+	// Objects of other resources are handled like with the Skip policy.
+	// Required if ConflictPolicy is Recreate.
+	RecreateResources []string `json:"recreateResources,omitempty"`
+
+	// RecreateTimeout is how long to wait for an existing object to be
+	// deleted, including by its finalizers, before recreating it. If the
+	// object isn't gone in time, it isn't recreated. If empty, defaults to
+	// 1m. Optional.
+	RecreateTimeout *metav1.Duration `json:"recreateTimeout,omitempty"`
+
+	// ConfirmSourceCluster must be true for a restore whose ConflictPolicy
+	// changes existing objects, i.e. ThreeWayMerge or Recreate, to restore
+	// a backup into the cluster it was taken in, whose objects may still be
+	// in use by the application that was backed up. Optional.
+	ConfirmSourceCluster bool `json:"confirmSourceCluster,omitempty"`
+
+	// CreateNamespaces specifies which of the namespaces that objects are
+	// restored into may be created by the restore if they don't exist. If
+	// empty, defaults to Always. Optional.
+	CreateNamespaces RestoreNamespaceCreationPolicy `json:"createNamespaces,omitempty"`
+
+	// MergeNamespaceMetadata specifies whether the labels and annotations
+	// of backed-up namespaces should be added to the namespaces they're
+	// restored into when those namespaces already exist. Optional.
+	MergeNamespaceMetadata bool `json:"mergeNamespaceMetadata,omitempty"`
+
+	// ClientQPS is the maximum number of requests per second to make to
+	// the API server while restoring objects. It can only lower the limit
+	// configured on the Ark server. Optional.
+	ClientQPS int `json:"clientQPS,omitempty"`
+
+	// ClientBurst is the maximum burst of requests to make to the API
+	// server while restoring objects. It can only lower the limit
+	// configured on the Ark server. Optional.
+	ClientBurst int `json:"clientBurst,omitempty"`
+
+	// VerifyPodVolumes specifies whether the files restored into pod
+	// volumes by restic should be checked against the contents of their
+	// snapshots, failing the pod volume restore if any are missing or
+	// have a different size. Optional.
+	VerifyPodVolumes bool `json:"verifyPodVolumes,omitempty"`
+
+	// Strict specifies whether the restore should fail, before restoring
+	// anything, if a preflight check finds a problem that would otherwise
+	// only be reported as a warning, such as items that would exceed a
+	// target namespace's resource quota. Optional.
+	Strict bool `json:"strict,omitempty"`
+
+	// WaitForNamespaceLock specifies whether the restore should be queued
+	// until other in-progress restores into any of the same namespaces have
+	// finished, rather than failing validation. Optional.
+	WaitForNamespaceLock bool `json:"waitForNamespaceLock,omitempty"`
+
+	// WaitForReady specifies whether the restore should wait for the
+	// restored items of each resource that has a readiness check, such as
+	// deployments, jobs and custom resource definitions, to become ready
+	// before restoring the next resource. Items that don't become ready
+	// in time are reported as warnings. Optional.
+	WaitForReady bool `json:"waitForReady,omitempty"`
+
+	// WaitForReadyTimeout is how long to wait for the restored items of
+	// each resource to become ready, if WaitForReady is set. If empty,
+	// defaults to 5m. Optional.
+	WaitForReadyTimeout *metav1.Duration `json:"waitForReadyTimeout,omitempty"`
+
+	// ResourceTimeout is how long the restore may spend restoring the
+	// items of each resource, across all namespaces, before the rest of
+	// them are skipped with an error. It applies to the resources that
+	// don't have a timeout of their own in the server's --resource-timeouts.
+	// If empty, only those resources have a timeout. Optional.
+	ResourceTimeout *metav1.Duration `json:"resourceTimeout,omitempty"`
+
+	// HostnameRewrites is a list of rules for rewriting the hostnames of
+	// restored ingresses and OpenShift routes, so that restoring into another
+	// cluster doesn't claim the original hostnames, for example in DNS
+	// records created by external-dns. Each hostname is rewritten by the
+	// first rule that matches it. Optional.
+	HostnameRewrites []HostnameRewrite `json:"hostnameRewrites,omitempty"`
+
+	// StripAnnotations is a list of annotations to remove from restored
+	// objects, such as annotations that make cloud providers provision load
+	// balancers or make external-dns create DNS records. Each entry is an
+	// annotation key, a key prefix ending in "*", or "default", which stands
+	// for Ark's list of known cloud load balancer and external-dns
+	// annotations. Optional.
+	StripAnnotations []string `json:"stripAnnotations,omitempty"`
+
+	// SecurityPolicyTranslation specifies whether the pod security policies
+	// in the backup are restored as OpenShift security context constraints,
+	// or the security context constraints in the backup as pod security
+	// policies, for migrating between Kubernetes and OpenShift clusters. If
+	// empty, policies are restored as they are. Optional.
+	SecurityPolicyTranslation RestoreSecurityPolicyTranslation `json:"securityPolicyTranslation,omitempty"`
+
+	// SecurityPolicyMapping is a map of the names of policies in the backup
+	// to the names they're restored as when they're translated. Policies
+	// that aren't in the map keep their names. Optional.
+	SecurityPolicyMapping map[string]string `json:"securityPolicyMapping,omitempty"`
+
+	// Hooks represent custom behaviors that should be executed while
+	// items are restored. Optional.
+	Hooks RestoreHooks `json:"hooks,omitempty"`
+
+	// AdditionalLabels are labels that are added to every restored
+	// object, in addition to the labels that identify the restore and
+	// backup, so that policies such as network policies can select
+	// restored workloads. Values may be Go templates, like namespace
+	// mapping targets. Optional.
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+
+	// AdditionalAnnotations are annotations that are added to every
+	// restored object. Values may be Go templates, like namespace mapping
+	// targets. Optional.
+	AdditionalAnnotations map[string]string `json:"additionalAnnotations,omitempty"`
+
+	// OmitLegacyRestoreLabel specifies whether restored objects aren't
+	// labeled with the deprecated ark-restore label, which is otherwise
+	// added alongside ark.heptio.com/restore-name. Optional.
+	OmitLegacyRestoreLabel bool `json:"omitLegacyRestoreLabel,omitempty"`
+}
+
+// RestoreHooks contains custom behaviors that should be executed while items are restored.
+type RestoreHooks struct {
+	// Resources are hooks that should be executed when restoring individual instances of a resource.
+	Resources []RestoreResourceHookSpec `json:"resources"`
 }
 
+// RestoreResourceHookSpec defines one or more RestoreResourceHooks that should be executed based
+// on the rules defined for namespaces, resources, and label selector.
+type RestoreResourceHookSpec struct {
+	// Name is the name of this hook.
+	Name string `json:"name"`
+	// IncludedNamespaces specifies the namespaces, as they're named in the backup, to which this
+	// hook spec applies. If empty, it applies to all namespaces.
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	// ExcludedNamespaces specifies the namespaces, as they're named in the backup, to which this
+	// hook spec does not apply.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	// IncludedResources specifies the resources to which this hook spec applies. If empty, it
+	// applies to all resources.
+	IncludedResources []string `json:"includedResources,omitempty"`
+	// ExcludedResources specifies the resources to which this hook spec does not apply.
+	ExcludedResources []string `json:"excludedResources,omitempty"`
+	// LabelSelector, if specified, filters the resources to which this hook spec applies.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// PreHooks is a list of RestoreResourceHooks to execute before creating the item.
+	PreHooks []RestoreResourceHook `json:"pre,omitempty"`
+	// PostHooks is a list of RestoreResourceHooks to execute after creating the item.
+	PostHooks []RestoreResourceHook `json:"post,omitempty"`
+}
+
+// RestoreResourceHook defines a restore hook for a resource.
+type RestoreResourceHook struct {
+	// HTTP defines an HTTP hook.
+	HTTP *HTTPHook `json:"http,omitempty"`
+}
+
+// HostnameRewrite is a rule for rewriting the hostnames of restored ingresses
+// and routes. Exactly one of Suffix and Regex must be specified.
+type HostnameRewrite struct {
+	// Suffix is a hostname suffix, such as example.com. Hostnames that are
+	// equal to Suffix or end in . followed by Suffix have it replaced with
+	// Replacement.
+	Suffix string `json:"suffix,omitempty"`
+
+	// Regex is a regular expression, in RE2 syntax, that hostnames are
+	// matched against. The matches of hostnames that it matches are replaced
+	// with Replacement, which may refer to submatches as $1, $2 and so on.
+	Regex string `json:"regex,omitempty"`
+
+	// Replacement is what the matched suffix or regular expression is
+	// replaced with. Like namespace mapping targets, it may be a Go
+	// template.
+	Replacement string `json:"replacement"`
+}
+
+// RestoreVolumeOverride changes the type and IOPS of the volumes that a
+// restore creates from snapshots. At least one of VolumeType and IOPS must
+// be specified.
+type RestoreVolumeOverride struct {
+	// StorageClassName selects the PVs, by their storage class in the
+	// backup, whose volumes the override applies to. If empty, it applies
+	// to the volumes of PVs that no other override selects.
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// VolumeType is the cloud provider's type for the created volumes,
+	// such as gp2. If it's specified and IOPS isn't, the volumes are
+	// created without the backed-up volumes' IOPS.
+	VolumeType string `json:"volumeType,omitempty"`
+
+	// IOPS is the provisioned IOPS of the created volumes.
+	IOPS *int64 `json:"iops,omitempty"`
+}
+
+// RestoreConflictPolicy is a policy for restoring objects that already
+// exist in the cluster.
+type RestoreConflictPolicy string
+
+const (
+	// RestoreConflictPolicySkip means objects that already exist are merged
+	// if there's a merge strategy for their resource, and otherwise aren't
+	// restored.
+	RestoreConflictPolicySkip RestoreConflictPolicy = "Skip"
+
+	// RestoreConflictPolicyThreeWayMerge means objects that already exist are
+	// merged if there's a merge strategy for their resource, and otherwise are
+	// updated with a three-way merge of the backed up version, the in-cluster
+	// version, and the in-cluster version's last applied configuration, similar
+	// to kubectl apply.
+	RestoreConflictPolicyThreeWayMerge RestoreConflictPolicy = "ThreeWayMerge"
+
+	// RestoreConflictPolicyRecreate means existing objects of the restore's
+	// RecreateResources are deleted, waiting for their dependents and
+	// finalizers, and recreated from the backup. Objects of other resources
+	// are handled like with RestoreConflictPolicySkip.
+	RestoreConflictPolicyRecreate RestoreConflictPolicy = "Recreate"
+)
+
+// RestoreIterationMode is the order in which a restore iterates over the
+// namespaces and resources of the objects it restores.
+type RestoreIterationMode string
+
+const (
+	// RestoreIterationModeByResource means the objects of each resource are
+	// restored in all namespaces before the objects of the next resource.
+	RestoreIterationModeByResource RestoreIterationMode = "ByResource"
+
+	// RestoreIterationModeByNamespace means all of the objects of each
+	// namespace are restored before the objects of the next namespace, so
+	// that a restore that fails partway leaves whole namespaces restored
+	// rather than every namespace partially restored. Cluster-scoped objects
+	// are restored before any namespace.
+	RestoreIterationModeByNamespace RestoreIterationMode = "ByNamespace"
+)
+
+// RestoreSecurityPolicyTranslation is a translation of the pod security
+// policies or security context constraints in a backup into the other.
+type RestoreSecurityPolicyTranslation string
+
+const (
+	// RestoreSecurityPolicyTranslationPSPToSCC means pod security policies are
+	// restored as OpenShift security context constraints.
+	RestoreSecurityPolicyTranslationPSPToSCC RestoreSecurityPolicyTranslation = "PSPToSCC"
+
+	// RestoreSecurityPolicyTranslationSCCToPSP means OpenShift security context
+	// constraints are restored as pod security policies.
+	RestoreSecurityPolicyTranslationSCCToPSP RestoreSecurityPolicyTranslation = "SCCToPSP"
+)
+
+// RestoreNamespaceCreationPolicy is a policy for creating the namespaces that
+// a restore restores objects into.
+type RestoreNamespaceCreationPolicy string
+
+const (
+	// RestoreNamespaceCreationPolicyAlways means namespaces that don't exist
+	// are created.
+	RestoreNamespaceCreationPolicyAlways RestoreNamespaceCreationPolicy = "Always"
+
+	// RestoreNamespaceCreationPolicyNever means the restore fails, before
+	// restoring anything, if any namespace it restores objects into doesn't
+	// exist.
+	RestoreNamespaceCreationPolicyNever RestoreNamespaceCreationPolicy = "Never"
+
+	// RestoreNamespaceCreationPolicyIfMappedOnly means only namespaces that
+	// are the target of a namespace mapping are created. The restore fails,
+	// before restoring anything, if any other namespace it restores objects
+	// into doesn't exist.
+	RestoreNamespaceCreationPolicyIfMappedOnly RestoreNamespaceCreationPolicy = "IfMappedOnly"
+)
+
 // RestorePhase is a string representation of the lifecycle phase
 // of an Ark restore
 type RestorePhase string
@@ -110,6 +446,50 @@ type RestoreStatus struct {
 
 	// FailureReason is an error that caused the entire restore to fail.
 	FailureReason string `json:"failureReason"`
+
+	// StartTimestamp records the time the restore operation was started.
+	// The server's time is used for StartTimestamps.
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Progress records the item the restore is working on. It's updated
+	// periodically while the restore is in progress, and cleared when it
+	// finishes.
+	Progress *RestoreProgress `json:"progress,omitempty"`
+
+	// ResourceTimings records how long the restore spent on each resource,
+	// slowest first. It's set when the restore finishes.
+	ResourceTimings []RestoreResourceTiming `json:"resourceTimings,omitempty"`
+}
+
+// RestoreProgress records the item a restore is working on.
+type RestoreProgress struct {
+	// Resource is the group-qualified resource of the item, e.g.
+	// deployments.apps.
+	Resource string `json:"resource"`
+
+	// Namespace is the namespace the item is restored into, or empty
+	// for a cluster-scoped item.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the item.
+	Name string `json:"name"`
+
+	// ItemsRestored is a count of the items that have been created or
+	// updated so far.
+	ItemsRestored int `json:"itemsRestored"`
+
+	// UpdateTimestamp records when the progress was last updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp,omitempty"`
+}
+
+// RestoreResourceTiming records how long a restore spent on a resource.
+type RestoreResourceTiming struct {
+	// Resource is the group-qualified resource, e.g. deployments.apps.
+	Resource string `json:"resource"`
+
+	// Duration is the time spent restoring the resource's items, added up
+	// across all namespaces.
+	Duration metav1.Duration `json:"duration"`
 }
 
 // RestoreResult is a collection of messages that were generated
@@ -128,6 +508,129 @@ type RestoreResult struct {
 	// Namespaces is a map of namespace name to slice of messages
 	// related to restoring namespace-scoped resources.
 	Namespaces map[string][]string `json:"namespaces"`
+
+	// Conflicts is a slice of objects that weren't restored because
+	// a different version of them already exists in the cluster.
+	Conflicts []RestoreConflict `json:"conflicts,omitempty"`
+
+	// Items is a slice of structured messages about individual
+	// objects. Each of these is also included, as a string, in
+	// Cluster or Namespaces.
+	Items []RestoreResultItem `json:"items,omitempty"`
+
+	// Summaries is a slice of messages that were repeated for many
+	// objects. Once a message has been recorded for a few objects of
+	// the same resource in the same namespace, it's only counted in a
+	// summary rather than recorded for each of the remaining objects.
+	Summaries []RestoreResultSummary `json:"summaries,omitempty"`
+}
+
+// RestoreResultCategory describes the step of restoring an object
+// that a RestoreResultItem was generated by.
+type RestoreResultCategory string
+
+const (
+	// RestoreResultCategoryDecode means the object couldn't be read
+	// from the backup.
+	RestoreResultCategoryDecode RestoreResultCategory = "Decode"
+
+	// RestoreResultCategoryPrepare means the object couldn't be prepared
+	// for restore, for example because a restore item action or a
+	// persistent volume snapshot restore failed.
+	RestoreResultCategoryPrepare RestoreResultCategory = "Prepare"
+
+	// RestoreResultCategoryCreate means creating the object in the
+	// cluster failed.
+	RestoreResultCategoryCreate RestoreResultCategory = "Create"
+
+	// RestoreResultCategoryConflict means the object already exists in
+	// the cluster and couldn't be reconciled with the backed up version.
+	RestoreResultCategoryConflict RestoreResultCategory = "Conflict"
+)
+
+// RestoreResultItem is a message about an individual object that was
+// generated during execution of a restore.
+type RestoreResultItem struct {
+	// Resource is the group-resource of the object.
+	Resource string `json:"resource"`
+
+	// Namespace is the namespace the object was restored into, or
+	// empty if it's cluster-scoped.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the object.
+	Name string `json:"name"`
+
+	// Category is the step of restoring the object that generated the
+	// message.
+	Category RestoreResultCategory `json:"category"`
+
+	// Message is the warning or error message.
+	Message string `json:"message"`
+
+	// Attempts is the number of times the step was attempted, if it's
+	// one that's retried when the API server returns a transient error.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// RestoreResultSummary counts the objects whose messages were omitted from a
+// RestoreResult because they repeated a message already recorded for other
+// objects of the same resource in the same namespace.
+type RestoreResultSummary struct {
+	// Resource is the group-resource of the objects.
+	Resource string `json:"resource"`
+
+	// Namespace is the namespace the objects were restored into, or
+	// empty if they're cluster-scoped.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Category is the step of restoring the objects that generated the
+	// message.
+	Category RestoreResultCategory `json:"category"`
+
+	// Message is the repeated message, with each object's name replaced
+	// by "<name>".
+	Message string `json:"message"`
+
+	// Count is the number of objects whose messages were omitted.
+	Count int `json:"count"`
+
+	// SampleNames is a slice of the names of some of the objects whose
+	// messages were omitted.
+	SampleNames []string `json:"sampleNames,omitempty"`
+}
+
+// RestoreConflict describes an object that wasn't restored because
+// a different version of it already exists in the cluster.
+type RestoreConflict struct {
+	// Resource is the group-resource of the object.
+	Resource string `json:"resource"`
+
+	// Namespace is the namespace of the object, or empty if it's
+	// cluster-scoped.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the object.
+	Name string `json:"name"`
+
+	// Fields is a slice of the fields whose values differ between
+	// the backed up and in-cluster versions of the object.
+	Fields []FieldDiff `json:"fields"`
+}
+
+// FieldDiff describes a field whose value differs between the backed
+// up and in-cluster versions of an object.
+type FieldDiff struct {
+	// Path is the dot-separated path of the field, e.g. "spec.replicas".
+	Path string `json:"path"`
+
+	// Backup is the JSON-encoded value of the field in the backup, or
+	// empty if the field isn't set in the backup.
+	Backup string `json:"backup,omitempty"`
+
+	// Cluster is the JSON-encoded value of the field in the cluster, or
+	// empty if the field isn't set in the cluster.
+	Cluster string `json:"cluster,omitempty"`
 }
 
 // +genclient
@@ -0,0 +1,55 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// DefaultRestorePriorityName is the name of the RestorePriority that's used
+// for restores that don't specify one.
+const DefaultRestorePriorityName = "default"
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RestorePriority defines the order in which resources are restored.
+type RestorePriority struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec RestorePrioritySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RestorePriorityList is a list of RestorePriorities.
+type RestorePriorityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []RestorePriority `json:"items"`
+}
+
+// RestorePrioritySpec defines the specification for an Ark RestorePriority.
+type RestorePrioritySpec struct {
+	// RestoreFirst is a list of resources that are restored before all
+	// others, in the order listed.
+	RestoreFirst []string `json:"restoreFirst,omitempty"`
+
+	// RestoreLast is a list of resources that are restored after all
+	// others, in the order listed.
+	RestoreLast []string `json:"restoreLast,omitempty"`
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	batchv1api "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreVerificationSpec is the specification for a RestoreVerification.
+type RestoreVerificationSpec struct {
+	// Schedule is a Cron expression defining when to verify the
+	// schedule's backups.
+	Schedule string `json:"schedule"`
+
+	// ScheduleName is the name of the Schedule whose most recent
+	// completed backup is restored by each verification.
+	ScheduleName string `json:"scheduleName"`
+
+	// IncludedNamespaces is a slice of the names of the backup's
+	// namespaces to restore. Each is restored into its own sandbox
+	// namespace. Restoring a sample of the backup's namespaces makes
+	// verifications quicker; restoring all of them verifies the
+	// whole backup.
+	IncludedNamespaces []string `json:"includedNamespaces"`
+
+	// LabelSelector is a metav1.LabelSelector to filter with when
+	// restoring individual objects from the backup. Optional.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// RestorePVs specifies whether to restore all included PVs from
+	// snapshot (via the cloudprovider). Optional.
+	RestorePVs *bool `json:"restorePVs,omitempty"`
+
+	// Jobs are the jobs that verify the restored objects. A
+	// verification passes when its restore completes without errors
+	// and all of its jobs succeed. Optional.
+	Jobs []RestoreVerificationJob `json:"jobs,omitempty"`
+
+	// Timeout is how long a verification's restore and jobs have to
+	// complete before the verification fails. Defaults to 1 hour.
+	// Optional.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// RestoreVerificationJob is a job that verifies the objects restored
+// into one of a verification's sandbox namespaces.
+type RestoreVerificationJob struct {
+	// Name is the name of the job.
+	Name string `json:"name"`
+
+	// Namespace is the name of the backup's namespace whose sandbox
+	// namespace the job runs in.
+	Namespace string `json:"namespace"`
+
+	// Template is the spec of the job.
+	Template batchv1api.JobSpec `json:"template"`
+}
+
+// RestoreVerificationPhase is a string representation of the lifecycle
+// phase of a RestoreVerification.
+type RestoreVerificationPhase string
+
+const (
+	// RestoreVerificationPhaseNew means the verification has been
+	// created but not yet processed by the RestoreVerificationController.
+	RestoreVerificationPhaseNew RestoreVerificationPhase = "New"
+
+	// RestoreVerificationPhaseEnabled means the verification has been
+	// validated and will run on its schedule.
+	RestoreVerificationPhaseEnabled RestoreVerificationPhase = "Enabled"
+
+	// RestoreVerificationPhaseFailedValidation means the verification
+	// has failed the controller's validations and won't run.
+	RestoreVerificationPhaseFailedValidation RestoreVerificationPhase = "FailedValidation"
+)
+
+// RestoreVerificationRunPhase is a string representation of the stage
+// that a run of a RestoreVerification is in.
+type RestoreVerificationRunPhase string
+
+const (
+	// RestoreVerificationRunPhaseRestoring means the run's restore
+	// hasn't completed yet.
+	RestoreVerificationRunPhaseRestoring RestoreVerificationRunPhase = "Restoring"
+
+	// RestoreVerificationRunPhaseVerifying means the run's jobs haven't
+	// completed yet.
+	RestoreVerificationRunPhaseVerifying RestoreVerificationRunPhase = "Verifying"
+
+	// RestoreVerificationRunPhasePassed means the run's restore
+	// completed without errors and all of its jobs succeeded.
+	RestoreVerificationRunPhasePassed RestoreVerificationRunPhase = "Passed"
+
+	// RestoreVerificationRunPhaseFailed means the run's restore or one
+	// of its jobs failed, or the run timed out.
+	RestoreVerificationRunPhaseFailed RestoreVerificationRunPhase = "Failed"
+)
+
+// RestoreVerificationRun captures the state of a run of a
+// RestoreVerification.
+type RestoreVerificationRun struct {
+	// Phase is the current phase of the run.
+	Phase RestoreVerificationRunPhase `json:"phase"`
+
+	// RestoreName is the name of the Restore that the run created.
+	RestoreName string `json:"restoreName"`
+
+	// BackupName is the name of the Backup that the run restored.
+	BackupName string `json:"backupName,omitempty"`
+
+	// SandboxNamespaces maps the backup's namespaces to the sandbox
+	// namespaces that the run restored them into.
+	SandboxNamespaces map[string]string `json:"sandboxNamespaces"`
+
+	// StartTimestamp records the time the run was started.
+	StartTimestamp metav1.Time `json:"startTimestamp"`
+
+	// CompletionTimestamp records the time the run passed or failed.
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// FailureReason is an error that caused the run to fail.
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// RestoreVerificationStatus captures the current state of a
+// RestoreVerification.
+type RestoreVerificationStatus struct {
+	// Phase is the current phase of the RestoreVerification.
+	Phase RestoreVerificationPhase `json:"phase"`
+
+	// ValidationErrors is a slice of all validation errors (if
+	// applicable).
+	ValidationErrors []string `json:"validationErrors"`
+
+	// CurrentRun is the run that's in progress, if any.
+	CurrentRun *RestoreVerificationRun `json:"currentRun,omitempty"`
+
+	// LastRun is the last run that passed or failed.
+	LastRun *RestoreVerificationRun `json:"lastRun,omitempty"`
+
+	// LastPassedTimestamp records the completion time of the last run
+	// that passed.
+	LastPassedTimestamp metav1.Time `json:"lastPassedTimestamp,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RestoreVerification is an Ark resource that periodically restores a
+// schedule's most recent backup into sandbox namespaces, runs jobs that
+// verify the restored objects, records whether they passed, and deletes
+// the sandbox namespaces.
+type RestoreVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   RestoreVerificationSpec   `json:"spec"`
+	Status RestoreVerificationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RestoreVerificationList is a list of RestoreVerifications.
+type RestoreVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []RestoreVerification `json:"items"`
+}
@@ -56,6 +56,28 @@ type ScheduleStatus struct {
 	// Schedule schedule
 	LastBackup metav1.Time `json:"lastBackup"`
 
+	// LastBackupName is the name of the last Backup that was run
+	// for this Schedule.
+	LastBackupName string `json:"lastBackupName,omitempty"`
+
+	// LastBackupSequence is the sequence number of the last Backup
+	// that was run for this Schedule. Sequence numbers start at 1
+	// and increase by 1 for each Backup the Schedule runs.
+	LastBackupSequence int64 `json:"lastBackupSequence,omitempty"`
+
+	// LastBackupPhase is the phase of the last Backup that was run
+	// for this Schedule, as last seen by the ScheduleController.
+	LastBackupPhase BackupPhase `json:"lastBackupPhase,omitempty"`
+
+	// ConsecutiveFailures is the number of Backups run for this
+	// Schedule, up to and including the last one, that have failed
+	// since the last one that completed.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// NextRunTime is the next time a Backup is due to be run for
+	// this Schedule.
+	NextRunTime metav1.Time `json:"nextRunTime,omitempty"`
+
 	// ValidationErrors is a slice of all validation errors (if
 	// applicable)
 	ValidationErrors []string `json:"validationErrors"`
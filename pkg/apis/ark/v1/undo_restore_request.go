@@ -0,0 +1,77 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// UndoRestoreRequestSpec is the specification for which restore to undo.
+type UndoRestoreRequestSpec struct {
+	// RestoreName is the name of the restore whose created objects are deleted.
+	RestoreName string `json:"restoreName"`
+
+	// DeleteNamespaces specifies whether the namespaces that the restore
+	// created are deleted too, along with anything else in them.
+	DeleteNamespaces bool `json:"deleteNamespaces,omitempty"`
+}
+
+// UndoRestoreRequestPhase represents the lifecycle phase of an UndoRestoreRequest.
+type UndoRestoreRequestPhase string
+
+const (
+	// UndoRestoreRequestPhaseNew means the UndoRestoreRequest has not been processed yet.
+	UndoRestoreRequestPhaseNew UndoRestoreRequestPhase = "New"
+	// UndoRestoreRequestPhaseInProgress means the UndoRestoreRequest is being processed.
+	UndoRestoreRequestPhaseInProgress UndoRestoreRequestPhase = "InProgress"
+	// UndoRestoreRequestPhaseProcessed means the UndoRestoreRequest has been processed.
+	UndoRestoreRequestPhaseProcessed UndoRestoreRequestPhase = "Processed"
+)
+
+// UndoRestoreRequestStatus is the current status of an UndoRestoreRequest.
+type UndoRestoreRequestStatus struct {
+	// Phase is the current state of the UndoRestoreRequest.
+	Phase UndoRestoreRequestPhase `json:"phase"`
+	// DeletedItems is the number of objects created by the restore that were deleted.
+	DeletedItems int `json:"deletedItems"`
+	// SkippedItems is the number of objects created by the restore that had already
+	// been deleted, or deleted and recreated, and so were left alone.
+	SkippedItems int `json:"skippedItems"`
+	// DeletedNamespaces is the number of namespaces created by the restore that were deleted.
+	DeletedNamespaces int `json:"deletedNamespaces"`
+	// Errors contains any errors that were encountered while undoing the restore.
+	Errors []string `json:"errors"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// UndoRestoreRequest is a request to delete the objects that a restore created.
+type UndoRestoreRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   UndoRestoreRequestSpec   `json:"spec"`
+	Status UndoRestoreRequestStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// UndoRestoreRequestList is a list of UndoRestoreRequests.
+type UndoRestoreRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []UndoRestoreRequest `json:"items"`
+}
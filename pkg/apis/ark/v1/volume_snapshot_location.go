@@ -62,4 +62,8 @@ const (
 // VolumeSnapshotLocationStatus describes the current status of an Ark VolumeSnapshotLocation.
 type VolumeSnapshotLocationStatus struct {
 	Phase VolumeSnapshotLocationPhase `json:"phase,omitempty"`
+
+	// Message is a human-readable explanation of why the location
+	// is unavailable, if it is.
+	Message string `json:"message,omitempty"`
 }
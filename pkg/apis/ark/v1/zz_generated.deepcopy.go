@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -53,6 +54,107 @@ func (in *Backup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEstimate) DeepCopyInto(out *BackupEstimate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEstimate.
+func (in *BackupEstimate) DeepCopy() *BackupEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupEstimate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEstimateList) DeepCopyInto(out *BackupEstimateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupEstimate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEstimateList.
+func (in *BackupEstimateList) DeepCopy() *BackupEstimateList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEstimateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupEstimateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEstimateSpec) DeepCopyInto(out *BackupEstimateSpec) {
+	*out = *in
+	in.Backup.DeepCopyInto(&out.Backup)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEstimateSpec.
+func (in *BackupEstimateSpec) DeepCopy() *BackupEstimateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEstimateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEstimateStatus) DeepCopyInto(out *BackupEstimateStatus) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceEstimate, len(*in))
+		copy(*out, *in)
+	}
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+	in.Expiration.DeepCopyInto(&out.Expiration)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEstimateStatus.
+func (in *BackupEstimateStatus) DeepCopy() *BackupEstimateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEstimateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupHooks) DeepCopyInto(out *BackupHooks) {
 	*out = *in
@@ -109,6 +211,120 @@ func (in *BackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPolicy) DeepCopyInto(out *BackupPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicy.
+func (in *BackupPolicy) DeepCopy() *BackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPolicyList) DeepCopyInto(out *BackupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicyList.
+func (in *BackupPolicyList) DeepCopy() *BackupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPolicySpec) DeepCopyInto(out *BackupPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(meta_v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.MinimumTTL = in.MinimumTTL
+	if in.ScheduleTemplate != nil {
+		in, out := &in.ScheduleTemplate, &out.ScheduleTemplate
+		*out = new(ScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicySpec.
+func (in *BackupPolicySpec) DeepCopy() *BackupPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPolicyStatus) DeepCopyInto(out *BackupPolicyStatus) {
+	*out = *in
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonCompliantNamespaces != nil {
+		in, out := &in.NonCompliantNamespaces, &out.NonCompliantNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicyStatus.
+func (in *BackupPolicyStatus) DeepCopy() *BackupPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupResourceHook) DeepCopyInto(out *BackupResourceHook) {
 	*out = *in
@@ -121,6 +337,15 @@ func (in *BackupResourceHook) DeepCopyInto(out *BackupResourceHook) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(HTTPHook)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -257,6 +482,16 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalItems != nil {
+		in, out := &in.AdditionalItems, &out.AdditionalItems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HelmReleases != nil {
+		in, out := &in.HelmReleases, &out.HelmReleases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -293,6 +528,16 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	}
 	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
 	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+	if in.ExtraItems != nil {
+		in, out := &in.ExtraItems, &out.ExtraItems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HelmReleases != nil {
+		in, out := &in.HelmReleases, &out.HelmReleases
+		*out = make([]HelmReleaseInfo, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -378,6 +623,55 @@ func (in *BackupStorageLocationSpec) DeepCopyInto(out *BackupStorageLocationSpec
 		}
 	}
 	in.StorageType.DeepCopyInto(&out.StorageType)
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]TenantStorage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxObjectSize != nil {
+		in, out := &in.MaxObjectSize, &out.MaxObjectSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.SignedURLTTL != nil {
+		in, out := &in.SignedURLTTL, &out.SignedURLTTL
+		*out = new(meta_v1.Duration)
+		**out = **in
+	}
+	if in.MaxDownloadSize != nil {
+		in, out := &in.MaxDownloadSize, &out.MaxDownloadSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ObjectOptions != nil {
+		in, out := &in.ObjectOptions, &out.ObjectOptions
+		*out = make(map[DownloadTargetKind]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.SourceClusters != nil {
+		in, out := &in.SourceClusters, &out.SourceClusters
+		*out = make([]SourceCluster, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResticCredentials != nil {
+		in, out := &in.ResticCredentials, &out.ResticCredentials
+		*out = new(core_v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -409,7 +703,7 @@ func (in *BackupStorageLocationStatus) DeepCopy() *BackupStorageLocationStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeleteBackupRequest) DeepCopyInto(out *DeleteBackupRequest) {
+func (in *DataDownload) DeepCopyInto(out *DataDownload) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -418,18 +712,18 @@ func (in *DeleteBackupRequest) DeepCopyInto(out *DeleteBackupRequest) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequest.
-func (in *DeleteBackupRequest) DeepCopy() *DeleteBackupRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDownload.
+func (in *DataDownload) DeepCopy() *DataDownload {
 	if in == nil {
 		return nil
 	}
-	out := new(DeleteBackupRequest)
+	out := new(DataDownload)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DeleteBackupRequest) DeepCopyObject() runtime.Object {
+func (in *DataDownload) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -437,13 +731,13 @@ func (in *DeleteBackupRequest) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeleteBackupRequestList) DeepCopyInto(out *DeleteBackupRequestList) {
+func (in *DataDownloadList) DeepCopyInto(out *DataDownloadList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DeleteBackupRequest, len(*in))
+		*out = make([]DataDownload, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -451,18 +745,18 @@ func (in *DeleteBackupRequestList) DeepCopyInto(out *DeleteBackupRequestList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequestList.
-func (in *DeleteBackupRequestList) DeepCopy() *DeleteBackupRequestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDownloadList.
+func (in *DataDownloadList) DeepCopy() *DataDownloadList {
 	if in == nil {
 		return nil
 	}
-	out := new(DeleteBackupRequestList)
+	out := new(DataDownloadList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DeleteBackupRequestList) DeepCopyObject() runtime.Object {
+func (in *DataDownloadList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -470,44 +764,44 @@ func (in *DeleteBackupRequestList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeleteBackupRequestSpec) DeepCopyInto(out *DeleteBackupRequestSpec) {
+func (in *DataDownloadSpec) DeepCopyInto(out *DataDownloadSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequestSpec.
-func (in *DeleteBackupRequestSpec) DeepCopy() *DeleteBackupRequestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDownloadSpec.
+func (in *DataDownloadSpec) DeepCopy() *DataDownloadSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeleteBackupRequestSpec)
+	out := new(DataDownloadSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeleteBackupRequestStatus) DeepCopyInto(out *DeleteBackupRequestStatus) {
+func (in *DataDownloadStatus) DeepCopyInto(out *DataDownloadStatus) {
 	*out = *in
-	if in.Errors != nil {
-		in, out := &in.Errors, &out.Errors
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequestStatus.
-func (in *DeleteBackupRequestStatus) DeepCopy() *DeleteBackupRequestStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataDownloadStatus.
+func (in *DataDownloadStatus) DeepCopy() *DataDownloadStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DeleteBackupRequestStatus)
+	out := new(DataDownloadStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DownloadRequest) DeepCopyInto(out *DownloadRequest) {
+func (in *DeleteBackupRequest) DeepCopyInto(out *DeleteBackupRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -516,18 +810,18 @@ func (in *DownloadRequest) DeepCopyInto(out *DownloadRequest) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadRequest.
-func (in *DownloadRequest) DeepCopy() *DownloadRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequest.
+func (in *DeleteBackupRequest) DeepCopy() *DeleteBackupRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(DownloadRequest)
+	out := new(DeleteBackupRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DownloadRequest) DeepCopyObject() runtime.Object {
+func (in *DeleteBackupRequest) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -535,13 +829,13 @@ func (in *DownloadRequest) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DownloadRequestList) DeepCopyInto(out *DownloadRequestList) {
+func (in *DeleteBackupRequestList) DeepCopyInto(out *DeleteBackupRequestList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DownloadRequest, len(*in))
+		*out = make([]DeleteBackupRequest, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -549,18 +843,18 @@ func (in *DownloadRequestList) DeepCopyInto(out *DownloadRequestList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadRequestList.
-func (in *DownloadRequestList) DeepCopy() *DownloadRequestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequestList.
+func (in *DeleteBackupRequestList) DeepCopy() *DeleteBackupRequestList {
 	if in == nil {
 		return nil
 	}
-	out := new(DownloadRequestList)
+	out := new(DeleteBackupRequestList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DownloadRequestList) DeepCopyObject() runtime.Object {
+func (in *DeleteBackupRequestList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -568,27 +862,125 @@ func (in *DownloadRequestList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DownloadRequestSpec) DeepCopyInto(out *DownloadRequestSpec) {
+func (in *DeleteBackupRequestSpec) DeepCopyInto(out *DeleteBackupRequestSpec) {
 	*out = *in
-	out.Target = in.Target
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadRequestSpec.
-func (in *DownloadRequestSpec) DeepCopy() *DownloadRequestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequestSpec.
+func (in *DeleteBackupRequestSpec) DeepCopy() *DeleteBackupRequestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DownloadRequestSpec)
+	out := new(DeleteBackupRequestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DownloadRequestStatus) DeepCopyInto(out *DownloadRequestStatus) {
+func (in *DeleteBackupRequestStatus) DeepCopyInto(out *DeleteBackupRequestStatus) {
 	*out = *in
-	in.Expiration.DeepCopyInto(&out.Expiration)
-	return
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteBackupRequestStatus.
+func (in *DeleteBackupRequestStatus) DeepCopy() *DeleteBackupRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteBackupRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownloadRequest) DeepCopyInto(out *DownloadRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadRequest.
+func (in *DownloadRequest) DeepCopy() *DownloadRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DownloadRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DownloadRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownloadRequestList) DeepCopyInto(out *DownloadRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DownloadRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadRequestList.
+func (in *DownloadRequestList) DeepCopy() *DownloadRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(DownloadRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DownloadRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownloadRequestSpec) DeepCopyInto(out *DownloadRequestSpec) {
+	*out = *in
+	out.Target = in.Target
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadRequestSpec.
+func (in *DownloadRequestSpec) DeepCopy() *DownloadRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DownloadRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownloadRequestStatus) DeepCopyInto(out *DownloadRequestStatus) {
+	*out = *in
+	in.Expiration.DeepCopyInto(&out.Expiration)
+	return
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadRequestStatus.
@@ -639,6 +1031,78 @@ func (in *ExecHook) DeepCopy() *ExecHook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDiff) DeepCopyInto(out *FieldDiff) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDiff.
+func (in *FieldDiff) DeepCopy() *FieldDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHook) DeepCopyInto(out *HTTPHook) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Timeout = in.Timeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHook.
+func (in *HTTPHook) DeepCopy() *HTTPHook {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmReleaseInfo) DeepCopyInto(out *HelmReleaseInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmReleaseInfo.
+func (in *HelmReleaseInfo) DeepCopy() *HelmReleaseInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmReleaseInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostnameRewrite) DeepCopyInto(out *HostnameRewrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnameRewrite.
+func (in *HostnameRewrite) DeepCopy() *HostnameRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(HostnameRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStorageLocation) DeepCopyInto(out *ObjectStorageLocation) {
 	*out = *in
@@ -727,6 +1191,17 @@ func (in *PodVolumeBackupSpec) DeepCopyInto(out *PodVolumeBackupSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.IncludePaths != nil {
+		in, out := &in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePatterns != nil {
+		in, out := &in.ExcludePatterns, &out.ExcludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
 	return
 }
 
@@ -762,7 +1237,7 @@ func (in *PodVolumeRestore) DeepCopyInto(out *PodVolumeRestore) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -821,6 +1296,7 @@ func (in *PodVolumeRestoreList) DeepCopyObject() runtime.Object {
 func (in *PodVolumeRestoreSpec) DeepCopyInto(out *PodVolumeRestoreSpec) {
 	*out = *in
 	out.Pod = in.Pod
+	out.Timeout = in.Timeout
 	return
 }
 
@@ -837,6 +1313,15 @@ func (in *PodVolumeRestoreSpec) DeepCopy() *PodVolumeRestoreSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodVolumeRestoreStatus) DeepCopyInto(out *PodVolumeRestoreStatus) {
 	*out = *in
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodVolumeRestoreVerification)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -851,27 +1336,48 @@ func (in *PodVolumeRestoreStatus) DeepCopy() *PodVolumeRestoreStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticRepository) DeepCopyInto(out *ResticRepository) {
+func (in *PodVolumeRestoreVerification) DeepCopyInto(out *PodVolumeRestoreVerification) {
+	*out = *in
+	if in.Mismatches != nil {
+		in, out := &in.Mismatches, &out.Mismatches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodVolumeRestoreVerification.
+func (in *PodVolumeRestoreVerification) DeepCopy() *PodVolumeRestoreVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(PodVolumeRestoreVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedApplication) DeepCopyInto(out *ProtectedApplication) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepository.
-func (in *ResticRepository) DeepCopy() *ResticRepository {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedApplication.
+func (in *ProtectedApplication) DeepCopy() *ProtectedApplication {
 	if in == nil {
 		return nil
 	}
-	out := new(ResticRepository)
+	out := new(ProtectedApplication)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ResticRepository) DeepCopyObject() runtime.Object {
+func (in *ProtectedApplication) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -879,13 +1385,13 @@ func (in *ResticRepository) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticRepositoryList) DeepCopyInto(out *ResticRepositoryList) {
+func (in *ProtectedApplicationList) DeepCopyInto(out *ProtectedApplicationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ResticRepository, len(*in))
+		*out = make([]ProtectedApplication, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -893,18 +1399,18 @@ func (in *ResticRepositoryList) DeepCopyInto(out *ResticRepositoryList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepositoryList.
-func (in *ResticRepositoryList) DeepCopy() *ResticRepositoryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedApplicationList.
+func (in *ProtectedApplicationList) DeepCopy() *ProtectedApplicationList {
 	if in == nil {
 		return nil
 	}
-	out := new(ResticRepositoryList)
+	out := new(ProtectedApplicationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ResticRepositoryList) DeepCopyObject() runtime.Object {
+func (in *ProtectedApplicationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -912,60 +1418,300 @@ func (in *ResticRepositoryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticRepositorySpec) DeepCopyInto(out *ResticRepositorySpec) {
+func (in *ProtectedApplicationSpec) DeepCopyInto(out *ProtectedApplicationSpec) {
 	*out = *in
-	out.MaintenanceFrequency = in.MaintenanceFrequency
+	if in.IncludedNamespaces != nil {
+		in, out := &in.IncludedNamespaces, &out.IncludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.LabelSelector)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PersistentVolumeClaims != nil {
+		in, out := &in.PersistentVolumeClaims, &out.PersistentVolumeClaims
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Hooks.DeepCopyInto(&out.Hooks)
+	out.TTL = in.TTL
+	if in.SnapshotVolumes != nil {
+		in, out := &in.SnapshotVolumes, &out.SnapshotVolumes
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	if in.VolumeSnapshotLocations != nil {
+		in, out := &in.VolumeSnapshotLocations, &out.VolumeSnapshotLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepositorySpec.
-func (in *ResticRepositorySpec) DeepCopy() *ResticRepositorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedApplicationSpec.
+func (in *ProtectedApplicationSpec) DeepCopy() *ProtectedApplicationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ResticRepositorySpec)
+	out := new(ProtectedApplicationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticRepositoryStatus) DeepCopyInto(out *ResticRepositoryStatus) {
+func (in *ProtectedApplicationStatus) DeepCopyInto(out *ProtectedApplicationStatus) {
 	*out = *in
-	in.LastMaintenanceTime.DeepCopyInto(&out.LastMaintenanceTime)
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepositoryStatus.
-func (in *ResticRepositoryStatus) DeepCopy() *ResticRepositoryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedApplicationStatus.
+func (in *ProtectedApplicationStatus) DeepCopy() *ProtectedApplicationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResticRepositoryStatus)
+	out := new(ProtectedApplicationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Restore) DeepCopyInto(out *Restore) {
+func (in *ResourceEstimate) DeepCopyInto(out *ResourceEstimate) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
-func (in *Restore) DeepCopy() *Restore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceEstimate.
+func (in *ResourceEstimate) DeepCopy() *ResourceEstimate {
 	if in == nil {
 		return nil
 	}
-	out := new(Restore)
+	out := new(ResourceEstimate)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticDaemonSetConfig) DeepCopyInto(out *ResticDaemonSetConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticDaemonSetConfig.
+func (in *ResticDaemonSetConfig) DeepCopy() *ResticDaemonSetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticDaemonSetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResticDaemonSetConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticDaemonSetConfigList) DeepCopyInto(out *ResticDaemonSetConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResticDaemonSetConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticDaemonSetConfigList.
+func (in *ResticDaemonSetConfigList) DeepCopy() *ResticDaemonSetConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticDaemonSetConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResticDaemonSetConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticDaemonSetConfigSpec) DeepCopyInto(out *ResticDaemonSetConfigSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]core_v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticDaemonSetConfigSpec.
+func (in *ResticDaemonSetConfigSpec) DeepCopy() *ResticDaemonSetConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticDaemonSetConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticRepository) DeepCopyInto(out *ResticRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepository.
+func (in *ResticRepository) DeepCopy() *ResticRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResticRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticRepositoryList) DeepCopyInto(out *ResticRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResticRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepositoryList.
+func (in *ResticRepositoryList) DeepCopy() *ResticRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResticRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticRepositorySpec) DeepCopyInto(out *ResticRepositorySpec) {
+	*out = *in
+	out.MaintenanceFrequency = in.MaintenanceFrequency
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepositorySpec.
+func (in *ResticRepositorySpec) DeepCopy() *ResticRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticRepositoryStatus) DeepCopyInto(out *ResticRepositoryStatus) {
+	*out = *in
+	in.LastMaintenanceTime.DeepCopyInto(&out.LastMaintenanceTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticRepositoryStatus.
+func (in *ResticRepositoryStatus) DeepCopy() *ResticRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Restore) DeepCopyInto(out *Restore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
+func (in *Restore) DeepCopy() *Restore {
+	if in == nil {
+		return nil
+	}
+	out := new(Restore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
 func (in *Restore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
@@ -973,6 +1719,50 @@ func (in *Restore) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreConflict) DeepCopyInto(out *RestoreConflict) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]FieldDiff, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreConflict.
+func (in *RestoreConflict) DeepCopy() *RestoreConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreHooks) DeepCopyInto(out *RestoreHooks) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]RestoreResourceHookSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreHooks.
+func (in *RestoreHooks) DeepCopy() *RestoreHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreList) DeepCopyInto(out *RestoreList) {
 	*out = *in
@@ -1007,130 +1797,676 @@ func (in *RestoreList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreResult) DeepCopyInto(out *RestoreResult) {
+func (in *RestorePriority) DeepCopyInto(out *RestorePriority) {
 	*out = *in
-	if in.Ark != nil {
-		in, out := &in.Ark, &out.Ark
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Cluster != nil {
-		in, out := &in.Cluster, &out.Cluster
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePriority.
+func (in *RestorePriority) DeepCopy() *RestorePriority {
+	if in == nil {
+		return nil
 	}
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				(*out)[key] = make([]string, len(val))
-				copy((*out)[key], val)
-			}
-		}
+	out := new(RestorePriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestorePriority) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorePriorityList) DeepCopyInto(out *RestorePriorityList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestorePriority, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePriorityList.
+func (in *RestorePriorityList) DeepCopy() *RestorePriorityList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorePriorityList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestorePriorityList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestorePrioritySpec) DeepCopyInto(out *RestorePrioritySpec) {
+	*out = *in
+	if in.RestoreFirst != nil {
+		in, out := &in.RestoreFirst, &out.RestoreFirst
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestoreLast != nil {
+		in, out := &in.RestoreLast, &out.RestoreLast
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestorePrioritySpec.
+func (in *RestorePrioritySpec) DeepCopy() *RestorePrioritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestorePrioritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreProgress) DeepCopyInto(out *RestoreProgress) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreProgress.
+func (in *RestoreProgress) DeepCopy() *RestoreProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResourceHook) DeepCopyInto(out *RestoreResourceHook) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(HTTPHook)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResourceHook.
+func (in *RestoreResourceHook) DeepCopy() *RestoreResourceHook {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResourceHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResourceHookSpec) DeepCopyInto(out *RestoreResourceHookSpec) {
+	*out = *in
+	if in.IncludedNamespaces != nil {
+		in, out := &in.IncludedNamespaces, &out.IncludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludedResources != nil {
+		in, out := &in.IncludedResources, &out.IncludedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedResources != nil {
+		in, out := &in.ExcludedResources, &out.ExcludedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.LabelSelector)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PreHooks != nil {
+		in, out := &in.PreHooks, &out.PreHooks
+		*out = make([]RestoreResourceHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostHooks != nil {
+		in, out := &in.PostHooks, &out.PostHooks
+		*out = make([]RestoreResourceHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResourceHookSpec.
+func (in *RestoreResourceHookSpec) DeepCopy() *RestoreResourceHookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResourceHookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResourceTiming) DeepCopyInto(out *RestoreResourceTiming) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResourceTiming.
+func (in *RestoreResourceTiming) DeepCopy() *RestoreResourceTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResourceTiming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResult) DeepCopyInto(out *RestoreResult) {
+	*out = *in
+	if in.Ark != nil {
+		in, out := &in.Ark, &out.Ark
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = make([]string, len(val))
+				copy((*out)[key], val)
+			}
+		}
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]RestoreConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestoreResultItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.Summaries != nil {
+		in, out := &in.Summaries, &out.Summaries
+		*out = make([]RestoreResultSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResult.
+func (in *RestoreResult) DeepCopy() *RestoreResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResultItem) DeepCopyInto(out *RestoreResultItem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResultItem.
+func (in *RestoreResultItem) DeepCopy() *RestoreResultItem {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResultItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreResultSummary) DeepCopyInto(out *RestoreResultSummary) {
+	*out = *in
+	if in.SampleNames != nil {
+		in, out := &in.SampleNames, &out.SampleNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResultSummary.
+func (in *RestoreResultSummary) DeepCopy() *RestoreResultSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreResultSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
+	*out = *in
+	if in.AsOf != nil {
+		in, out := &in.AsOf, &out.AsOf
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.IncludedNamespaces != nil {
+		in, out := &in.IncludedNamespaces, &out.IncludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludedResources != nil {
+		in, out := &in.IncludedResources, &out.IncludedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedResources != nil {
+		in, out := &in.ExcludedResources, &out.ExcludedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceMapping != nil {
+		in, out := &in.NamespaceMapping, &out.NamespaceMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NamespaceOrder != nil {
+		in, out := &in.NamespaceOrder, &out.NamespaceOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.LabelSelector)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.RestorePVs != nil {
+		in, out := &in.RestorePVs, &out.RestorePVs
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	if in.VolumeIDMapping != nil {
+		in, out := &in.VolumeIDMapping, &out.VolumeIDMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VolumeOverrides != nil {
+		in, out := &in.VolumeOverrides, &out.VolumeOverrides
+		*out = make([]RestoreVolumeOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IncludeClusterResources != nil {
+		in, out := &in.IncludeClusterResources, &out.IncludeClusterResources
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	if in.RecreateResources != nil {
+		in, out := &in.RecreateResources, &out.RecreateResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecreateTimeout != nil {
+		in, out := &in.RecreateTimeout, &out.RecreateTimeout
+		*out = new(meta_v1.Duration)
+		**out = **in
+	}
+	if in.WaitForReadyTimeout != nil {
+		in, out := &in.WaitForReadyTimeout, &out.WaitForReadyTimeout
+		*out = new(meta_v1.Duration)
+		**out = **in
+	}
+	if in.ResourceTimeout != nil {
+		in, out := &in.ResourceTimeout, &out.ResourceTimeout
+		*out = new(meta_v1.Duration)
+		**out = **in
+	}
+	if in.HostnameRewrites != nil {
+		in, out := &in.HostnameRewrites, &out.HostnameRewrites
+		*out = make([]HostnameRewrite, len(*in))
+		copy(*out, *in)
+	}
+	if in.StripAnnotations != nil {
+		in, out := &in.StripAnnotations, &out.StripAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityPolicyMapping != nil {
+		in, out := &in.SecurityPolicyMapping, &out.SecurityPolicyMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Hooks.DeepCopyInto(&out.Hooks)
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AdditionalAnnotations != nil {
+		in, out := &in.AdditionalAnnotations, &out.AdditionalAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSpec.
+func (in *RestoreSpec) DeepCopy() *RestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
+	*out = *in
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(RestoreProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceTimings != nil {
+		in, out := &in.ResourceTimings, &out.ResourceTimings
+		*out = make([]RestoreResourceTiming, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
+func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerification) DeepCopyInto(out *RestoreVerification) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerification.
+func (in *RestoreVerification) DeepCopy() *RestoreVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreVerification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationJob) DeepCopyInto(out *RestoreVerificationJob) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationJob.
+func (in *RestoreVerificationJob) DeepCopy() *RestoreVerificationJob {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationList) DeepCopyInto(out *RestoreVerificationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestoreVerification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationList.
+func (in *RestoreVerificationList) DeepCopy() *RestoreVerificationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreVerificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationRun) DeepCopyInto(out *RestoreVerificationRun) {
+	*out = *in
+	if in.SandboxNamespaces != nil {
+		in, out := &in.SandboxNamespaces, &out.SandboxNamespaces
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreResult.
-func (in *RestoreResult) DeepCopy() *RestoreResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationRun.
+func (in *RestoreVerificationRun) DeepCopy() *RestoreVerificationRun {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreResult)
+	out := new(RestoreVerificationRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
+func (in *RestoreVerificationSpec) DeepCopyInto(out *RestoreVerificationSpec) {
 	*out = *in
 	if in.IncludedNamespaces != nil {
 		in, out := &in.IncludedNamespaces, &out.IncludedNamespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ExcludedNamespaces != nil {
-		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.IncludedResources != nil {
-		in, out := &in.IncludedResources, &out.IncludedResources
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ExcludedResources != nil {
-		in, out := &in.ExcludedResources, &out.ExcludedResources
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NamespaceMapping != nil {
-		in, out := &in.NamespaceMapping, &out.NamespaceMapping
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 	if in.LabelSelector != nil {
 		in, out := &in.LabelSelector, &out.LabelSelector
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(meta_v1.LabelSelector)
-			(*in).DeepCopyInto(*out)
-		}
+		*out = new(meta_v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RestorePVs != nil {
 		in, out := &in.RestorePVs, &out.RestorePVs
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(bool)
-			**out = **in
-		}
+		*out = new(bool)
+		**out = **in
 	}
-	if in.IncludeClusterResources != nil {
-		in, out := &in.IncludeClusterResources, &out.IncludeClusterResources
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(bool)
-			**out = **in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]RestoreVerificationJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.Timeout = in.Timeout
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSpec.
-func (in *RestoreSpec) DeepCopy() *RestoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationSpec.
+func (in *RestoreVerificationSpec) DeepCopy() *RestoreVerificationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreSpec)
+	out := new(RestoreVerificationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
+func (in *RestoreVerificationStatus) DeepCopyInto(out *RestoreVerificationStatus) {
 	*out = *in
 	if in.ValidationErrors != nil {
 		in, out := &in.ValidationErrors, &out.ValidationErrors
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CurrentRun != nil {
+		in, out := &in.CurrentRun, &out.CurrentRun
+		*out = new(RestoreVerificationRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastRun != nil {
+		in, out := &in.LastRun, &out.LastRun
+		*out = new(RestoreVerificationRun)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LastPassedTimestamp.DeepCopyInto(&out.LastPassedTimestamp)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
-func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationStatus.
+func (in *RestoreVerificationStatus) DeepCopy() *RestoreVerificationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreStatus)
+	out := new(RestoreVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVolumeOverride) DeepCopyInto(out *RestoreVolumeOverride) {
+	*out = *in
+	if in.IOPS != nil {
+		in, out := &in.IOPS, &out.IOPS
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVolumeOverride.
+func (in *RestoreVolumeOverride) DeepCopy() *RestoreVolumeOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVolumeOverride)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1217,6 +2553,7 @@ func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
 func (in *ScheduleStatus) DeepCopyInto(out *ScheduleStatus) {
 	*out = *in
 	in.LastBackup.DeepCopyInto(&out.LastBackup)
+	in.NextRunTime.DeepCopyInto(&out.NextRunTime)
 	if in.ValidationErrors != nil {
 		in, out := &in.ValidationErrors, &out.ValidationErrors
 		*out = make([]string, len(*in))
@@ -1235,6 +2572,22 @@ func (in *ScheduleStatus) DeepCopy() *ScheduleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceCluster) DeepCopyInto(out *SourceCluster) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceCluster.
+func (in *SourceCluster) DeepCopy() *SourceCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageType) DeepCopyInto(out *StorageType) {
 	*out = *in
@@ -1260,6 +2613,125 @@ func (in *StorageType) DeepCopy() *StorageType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantStorage) DeepCopyInto(out *TenantStorage) {
+	*out = *in
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStorage.
+func (in *TenantStorage) DeepCopy() *TenantStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UndoRestoreRequest) DeepCopyInto(out *UndoRestoreRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UndoRestoreRequest.
+func (in *UndoRestoreRequest) DeepCopy() *UndoRestoreRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(UndoRestoreRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UndoRestoreRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UndoRestoreRequestList) DeepCopyInto(out *UndoRestoreRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UndoRestoreRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UndoRestoreRequestList.
+func (in *UndoRestoreRequestList) DeepCopy() *UndoRestoreRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(UndoRestoreRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UndoRestoreRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UndoRestoreRequestSpec) DeepCopyInto(out *UndoRestoreRequestSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UndoRestoreRequestSpec.
+func (in *UndoRestoreRequestSpec) DeepCopy() *UndoRestoreRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UndoRestoreRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UndoRestoreRequestStatus) DeepCopyInto(out *UndoRestoreRequestStatus) {
+	*out = *in
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UndoRestoreRequestStatus.
+func (in *UndoRestoreRequestStatus) DeepCopy() *UndoRestoreRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UndoRestoreRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeBackupInfo) DeepCopyInto(out *VolumeBackupInfo) {
 	*out = *in
@@ -1285,6 +2757,67 @@ func (in *VolumeBackupInfo) DeepCopy() *VolumeBackupInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshot) DeepCopyInto(out *VolumeSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshot.
+func (in *VolumeSnapshot) DeepCopy() *VolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotList) DeepCopyInto(out *VolumeSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotList.
+func (in *VolumeSnapshotList) DeepCopy() *VolumeSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotLocation) DeepCopyInto(out *VolumeSnapshotLocation) {
 	*out = *in
@@ -1384,3 +2917,44 @@ func (in *VolumeSnapshotLocationStatus) DeepCopy() *VolumeSnapshotLocationStatus
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotSpec) DeepCopyInto(out *VolumeSnapshotSpec) {
+	*out = *in
+	if in.VolumeIOPS != nil {
+		in, out := &in.VolumeIOPS, &out.VolumeIOPS
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int64)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotSpec.
+func (in *VolumeSnapshotSpec) DeepCopy() *VolumeSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotStatus.
+func (in *VolumeSnapshotStatus) DeepCopy() *VolumeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package archive reads and writes backup archives. Archives are gzip-compressed
+// tarballs or zip files; both are accessed through tar-style interfaces so that
+// code using them doesn't depend on the format.
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// Writer writes files to an archive. Each file is started with WriteHeader and
+// its contents are written with Write. *tar.Writer implements Writer.
+type Writer interface {
+	io.Closer
+	Write([]byte) (int, error)
+	WriteHeader(*tar.Header) error
+}
+
+// ItemHashRecorder is implemented by Writers whose archives have an index that can
+// store the content hashes of the items in them.
+type ItemHashRecorder interface {
+	// RecordItemHash records hash as the item hash of the file at path. It's
+	// written to the index when the archive is closed.
+	RecordItemHash(path, hash string)
+}
+
+// Reader reads the files in an archive. Next advances to the next file, returning
+// io.EOF at the end of the archive, and Read reads the current file's contents.
+// *tar.Reader implements Reader.
+type Reader interface {
+	Next() (*tar.Header, error)
+	Read([]byte) (int, error)
+}
+
+// zipMagic is the signature at the start of a zip file's first local file header.
+var zipMagic = []byte("PK\x03\x04")
+
+// ValidateFormat returns an error if format isn't a supported archive format.
+func ValidateFormat(format api.ArchiveFormat) error {
+	switch format {
+	case api.ArchiveFormatTarGzip, api.ArchiveFormatZip:
+		return nil
+	default:
+		return errors.Errorf("invalid archive format %q, valid formats are %s and %s", format, api.ArchiveFormatTarGzip, api.ArchiveFormatZip)
+	}
+}
+
+// NewWriter returns a Writer that writes an archive in format to w, starting with
+// a version file holding FormatVersion. Archives with no format are written as
+// gzip-compressed tarballs.
+func NewWriter(format api.ArchiveFormat, w io.Writer) (Writer, error) {
+	var writer Writer
+	switch format {
+	case "", api.ArchiveFormatTarGzip:
+		writer = NewGzipTarWriter(w)
+	case api.ArchiveFormatZip:
+		writer = NewZipWriter(w)
+	default:
+		return nil, ValidateFormat(format)
+	}
+
+	if err := writeFormatVersion(writer); err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// NewReader returns a Reader for the archive read from r, detecting whether it's a
+// gzip-compressed tarball or a zip file. Zip files are read using r's ReadAt
+// method if it has one along with Seek, and are otherwise read into memory. Items
+// in backups written by older versions of Ark are renamed to their paths in the
+// current layout.
+func NewReader(r io.Reader) (Reader, error) {
+	buffered := bufio.NewReader(r)
+
+	magic, err := buffered.Peek(len(zipMagic))
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "error reading archive")
+	}
+
+	if !bytes.Equal(magic, zipMagic) {
+		gzr, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating gzip reader")
+		}
+		return newLayoutReader(tar.NewReader(gzr)), nil
+	}
+
+	readerAt, size, err := zipReaderAt(r, buffered)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := newZipReader(readerAt, size)
+	if err != nil {
+		return nil, err
+	}
+	return newLayoutReader(zr), nil
+}
+
+// zipReaderAt returns an io.ReaderAt for the zip file read from r, and its size.
+func zipReaderAt(r io.Reader, buffered io.Reader) (io.ReaderAt, int64, error) {
+	if file, ok := r.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok {
+		size, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "error getting size of zip file")
+		}
+		return file, size, nil
+	}
+
+	data, err := ioutil.ReadAll(buffered)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "error reading zip file")
+	}
+	return bytes.NewReader(data), int64(len(data)), nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+type testFile struct {
+	name string
+	data string
+}
+
+var testFiles = []testFile{
+	{name: "resources/pods/namespaces/ns-1/pod-1.json", data: `{"kind":"Pod"}`},
+	{name: "resources/persistentvolumes/cluster/pv-1.json", data: `{"kind":"PersistentVolume"}`},
+}
+
+func writeFiles(t *testing.T, w Writer, files []testFile) {
+	for _, file := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Size:     int64(len(file.data)),
+			Typeflag: tar.TypeReg,
+			Mode:     0755,
+			ModTime:  time.Now(),
+		}))
+		_, err := w.Write([]byte(file.data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func readFiles(t *testing.T, r Reader) []testFile {
+	var files []testFile
+	for {
+		hdr, err := r.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), hdr.Size)
+		assert.Equal(t, byte(tar.TypeReg), hdr.Typeflag)
+
+		files = append(files, testFile{name: hdr.Name, data: string(data)})
+	}
+}
+
+func TestNewReaderZip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeFiles(t, NewZipWriter(buf), testFiles)
+
+	// a reader without ReadAt is read into memory
+	r, err := NewReader(bytes.NewBufferString(buf.String()))
+	require.NoError(t, err)
+	assert.Equal(t, testFiles, readFiles(t, r))
+
+	// a file is read in place
+	file, err := ioutil.TempFile("", "")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	_, err = file.Write(buf.Bytes())
+	require.NoError(t, err)
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	r, err = NewReader(file)
+	require.NoError(t, err)
+	assert.Equal(t, testFiles, readFiles(t, r))
+}
+
+func TestNewReaderTarGzip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	writeFiles(t, tar.NewWriter(gzw), testFiles)
+	require.NoError(t, gzw.Close())
+
+	r, err := NewReader(buf)
+	require.NoError(t, err)
+	assert.Equal(t, testFiles, readFiles(t, r))
+}
+
+func TestNewReaderInvalidArchive(t *testing.T) {
+	_, err := NewReader(bytes.NewBufferString("not an archive"))
+	assert.Error(t, err)
+}
+
+func TestValidateFormat(t *testing.T) {
+	assert.NoError(t, ValidateFormat(api.ArchiveFormatTarGzip))
+	assert.NoError(t, ValidateFormat(api.ArchiveFormatZip))
+	assert.EqualError(t, ValidateFormat("rar"), `invalid archive format "rar", valid formats are tar.gz and zip`)
+}
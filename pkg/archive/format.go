@@ -0,0 +1,191 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+const (
+	// FormatVersion is the format version of the layout of the backup
+	// archives that Ark writes. Version 1 archives have no version file;
+	// version 2 archives start with one.
+	FormatVersion = 2
+
+	// MinReadableFormatVersion is the oldest format version that Ark can
+	// read, so that backups written by the previous version of Ark can be
+	// restored.
+	MinReadableFormatVersion = FormatVersion - 1
+
+	// maxVersionFileSize is the largest version file that's read.
+	maxVersionFileSize = 64
+)
+
+// ReadFormatVersion reads the format version from the contents of an archive's
+// version file, and returns an error if it isn't one that Ark can read.
+func ReadFormatVersion(r io.Reader) (int, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxVersionFileSize))
+	if err != nil {
+		return 0, errors.Wrapf(err, "error reading %s", api.ArchiveVersionFile)
+	}
+
+	version, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, errors.Errorf("invalid format version %q in %s", string(data), api.ArchiveVersionFile)
+	}
+
+	if err := ValidateFormatVersion(version); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// ValidateFormatVersion returns an error if archives of format version can't be
+// read by this version of Ark.
+func ValidateFormatVersion(version int) error {
+	switch {
+	case version > FormatVersion:
+		return errors.Errorf("backup archive has format version %d, which is newer than the newest version this version of Ark can read (%d)", version, FormatVersion)
+	case version < MinReadableFormatVersion:
+		return errors.Errorf("backup archive has format version %d, which is older than the oldest version this version of Ark can read (%d); migrate it with an earlier version of ark backup migrate-format", version, MinReadableFormatVersion)
+	default:
+		return nil
+	}
+}
+
+// writeFormatVersion writes the version file holding FormatVersion to w.
+func writeFormatVersion(w Writer) error {
+	data := []byte(strconv.Itoa(FormatVersion) + "\n")
+
+	hdr := &tar.Header{
+		Name:     api.ArchiveVersionFile,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+		Mode:     0755,
+		ModTime:  time.Now(),
+	}
+
+	if err := w.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+	return errors.WithStack(err)
+}
+
+// Migrate rewrites the backup archive read from r to w in the current format
+// version, in the same archive format it's read in, and returns the format
+// version it was read in. The archive's index, if it has one, is rebuilt, and
+// items in older layouts are moved to their paths in the current layout.
+func Migrate(r io.Reader, w io.Writer) (int, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	format := api.ArchiveFormatTarGzip
+	if isZipReader(reader) {
+		format = api.ArchiveFormatZip
+	}
+
+	writer, err := NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 1
+	for {
+		hdr, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writer.Close()
+			return 0, errors.Wrap(err, "error reading backup archive")
+		}
+
+		switch hdr.Name {
+		case api.ArchiveVersionFile:
+			if version, err = ReadFormatVersion(reader); err != nil {
+				writer.Close()
+				return 0, err
+			}
+			continue
+		case api.ArchiveIndexFile:
+			// the writer writes a new index
+			continue
+		}
+
+		if err := copyEntry(writer, hdr, reader); err != nil {
+			writer.Close()
+			return 0, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// copyEntry writes the archive entry with header hdr, whose contents are read
+// from r, to w. Entries other than directories and regular files are skipped.
+func copyEntry(w Writer, hdr *tar.Header, r io.Reader) error {
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return w.WriteHeader(&tar.Header{
+			Name:     hdr.Name,
+			Mode:     hdr.Mode,
+			ModTime:  hdr.ModTime,
+			Typeflag: tar.TypeDir,
+		})
+	case tar.TypeReg, tar.TypeRegA:
+		// read the contents first, since the sizes of some zip entries
+		// aren't known until they're read
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return errors.Wrapf(err, "error reading %s", hdr.Name)
+		}
+
+		if err := w.WriteHeader(&tar.Header{
+			Name:     hdr.Name,
+			Size:     int64(len(data)),
+			Mode:     hdr.Mode,
+			ModTime:  hdr.ModTime,
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+
+		_, err = io.Copy(w, bytes.NewReader(data))
+		return errors.WithStack(err)
+	default:
+		return nil
+	}
+}
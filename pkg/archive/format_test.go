@@ -0,0 +1,133 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+func TestReadFormatVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		expectedVersion int
+		expectedErr     bool
+	}{
+		{
+			name:            "current version",
+			data:            "2\n",
+			expectedVersion: 2,
+		},
+		{
+			name:            "previous version",
+			data:            "1",
+			expectedVersion: 1,
+		},
+		{
+			name:        "newer version",
+			data:        "3\n",
+			expectedErr: true,
+		},
+		{
+			name:        "not a number",
+			data:        "v2",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := ReadFormatVersion(strings.NewReader(test.data))
+
+			assert.Equal(t, test.expectedErr, err != nil)
+			assert.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	versionFile := testFile{name: api.ArchiveVersionFile, data: "2\n"}
+
+	t.Run("version 1 tarball gets a version file and an index", func(t *testing.T) {
+		old := new(bytes.Buffer)
+		writeFiles(t, NewGzipTarWriter(old), testFiles)
+
+		migrated := new(bytes.Buffer)
+		version, err := Migrate(old, migrated)
+		require.NoError(t, err)
+		assert.Equal(t, 1, version)
+
+		data := migrated.Bytes()
+
+		r, err := NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		files := readFiles(t, r)
+		require.Len(t, files, 4)
+		assert.Equal(t, append([]testFile{versionFile}, testFiles...), files[:3])
+		assert.Equal(t, api.ArchiveIndexFile, files[3].name)
+
+		index, err := ReadIndex(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Len(t, index.Items, 3)
+	})
+
+	t.Run("version 1 zip file stays a zip file", func(t *testing.T) {
+		old := new(bytes.Buffer)
+		writeFiles(t, NewZipWriter(old), testFiles)
+
+		migrated := new(bytes.Buffer)
+		version, err := Migrate(old, migrated)
+		require.NoError(t, err)
+		assert.Equal(t, 1, version)
+
+		r, err := NewReader(migrated)
+		require.NoError(t, err)
+		assert.True(t, isZipReader(r))
+		assert.Equal(t, append([]testFile{versionFile}, testFiles...), readFiles(t, r))
+	})
+
+	t.Run("current version is rewritten unchanged", func(t *testing.T) {
+		old := new(bytes.Buffer)
+		w, err := NewWriter(api.ArchiveFormatZip, old)
+		require.NoError(t, err)
+		writeFiles(t, w, testFiles)
+
+		migrated := new(bytes.Buffer)
+		version, err := Migrate(old, migrated)
+		require.NoError(t, err)
+		assert.Equal(t, FormatVersion, version)
+
+		r, err := NewReader(migrated)
+		require.NoError(t, err)
+		assert.Equal(t, append([]testFile{versionFile}, testFiles...), readFiles(t, r))
+	})
+
+	t.Run("newer version is rejected", func(t *testing.T) {
+		old := new(bytes.Buffer)
+		writeFiles(t, NewZipWriter(old), []testFile{{name: api.ArchiveVersionFile, data: "3"}})
+
+		_, err := Migrate(old, new(bytes.Buffer))
+		assert.Error(t, err)
+	})
+}
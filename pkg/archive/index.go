@@ -0,0 +1,137 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+const indexVersion = 1
+
+// Index lists the files in a gzip-compressed tarball written by NewGzipTarWriter.
+// It's written as the last file in the tarball.
+type Index struct {
+	Version int          `json:"version"`
+	Items   []IndexEntry `json:"items"`
+
+	byPath map[string]int
+}
+
+// IndexEntry describes a file in a tarball.
+type IndexEntry struct {
+	// Path is the file's path within the tarball.
+	Path string `json:"path"`
+
+	// Size is the file's size in bytes.
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the file's contents.
+	SHA256 string `json:"sha256"`
+
+	// Offset is the position of the file's contents in the uncompressed
+	// tarball.
+	Offset int64 `json:"offset"`
+
+	// ItemHash is the content hash of the item in the file, computed by the
+	// itemhash package, if one was recorded when the backup was written.
+	ItemHash string `json:"itemHash,omitempty"`
+}
+
+// Lookup returns the index entry for the file at path, and whether there is one.
+func (i *Index) Lookup(path string) (IndexEntry, bool) {
+	if i.byPath == nil {
+		i.byPath = make(map[string]int, len(i.Items))
+		for idx, item := range i.Items {
+			i.byPath[item.Path] = idx
+		}
+	}
+
+	idx, ok := i.byPath[path]
+	if !ok {
+		return IndexEntry{}, false
+	}
+	return i.Items[idx], true
+}
+
+// ReadIndex reads the index from a gzip-compressed backup tarball. The files'
+// contents are skipped rather than extracted. Backups created by versions of Ark
+// that didn't write an index return an error.
+func ReadIndex(backupFile io.Reader) (*Index, error) {
+	gzr, err := gzip.NewReader(backupFile)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("backup doesn't contain an %s file", api.ArchiveIndexFile)
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if hdr.Name != api.ArchiveIndexFile {
+			continue
+		}
+
+		index := new(Index)
+		if err := json.NewDecoder(tr).Decode(index); err != nil {
+			return nil, errors.Wrapf(err, "error decoding %s", api.ArchiveIndexFile)
+		}
+		return index, nil
+	}
+}
+
+// ReadFile reads the contents of the file described by entry from a
+// gzip-compressed backup tarball, using the entry's offset rather than scanning
+// the tarball's headers, and verifies them against the entry's checksum.
+func ReadFile(backupFile io.Reader, entry IndexEntry) ([]byte, error) {
+	gzr, err := gzip.NewReader(backupFile)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer gzr.Close()
+
+	if _, err := io.CopyN(ioutil.Discard, gzr, entry.Offset); err != nil {
+		return nil, errors.Wrapf(err, "error seeking to %s", entry.Path)
+	}
+
+	data := make([]byte, entry.Size)
+	if _, err := io.ReadFull(gzr, data); err != nil {
+		return nil, errors.Wrapf(err, "error reading %s", entry.Path)
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != entry.SHA256 {
+		return nil, errors.Errorf("checksum mismatch for %s: expected %s, got %s", entry.Path, entry.SHA256, actual)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeFiles(t, NewGzipTarWriter(buf), testFiles)
+
+	index, err := ReadIndex(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, index.Version)
+	require.Len(t, index.Items, len(testFiles))
+
+	for _, file := range testFiles {
+		entry, ok := index.Lookup(file.name)
+		require.True(t, ok, "%s not found", file.name)
+		assert.Equal(t, int64(len(file.data)), entry.Size)
+
+		data, err := ReadFile(bytes.NewReader(buf.Bytes()), entry)
+		require.NoError(t, err)
+		assert.Equal(t, file.data, string(data))
+	}
+
+	_, ok := index.Lookup("resources/pods/namespaces/ns-1/nonexistent.json")
+	assert.False(t, ok)
+
+	entry, _ := index.Lookup(testFiles[0].name)
+	entry.SHA256 = strings.Repeat("0", 64)
+	_, err = ReadFile(bytes.NewReader(buf.Bytes()), entry)
+	assert.Error(t, err)
+}
+
+func TestIndexItemHashes(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewGzipTarWriter(buf)
+
+	recorder, ok := w.(ItemHashRecorder)
+	require.True(t, ok)
+	recorder.RecordItemHash(testFiles[0].name, "sha256:abc")
+
+	writeFiles(t, w, testFiles)
+
+	index, err := ReadIndex(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	entry, ok := index.Lookup(testFiles[0].name)
+	require.True(t, ok)
+	assert.Equal(t, "sha256:abc", entry.ItemHash)
+
+	entry, ok = index.Lookup(testFiles[1].name)
+	require.True(t, ok)
+	assert.Equal(t, "", entry.ItemHash)
+}
+
+func TestReadIndexWithoutIndex(t *testing.T) {
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	writeFiles(t, tar.NewWriter(gzw), testFiles)
+	require.NoError(t, gzw.Close())
+
+	_, err := ReadIndex(buf)
+	assert.EqualError(t, err, "backup doesn't contain an index.json file")
+}
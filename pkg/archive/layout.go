@@ -0,0 +1,83 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"path"
+	"strings"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// layoutReader is a Reader that renames the items of backups written by older
+// versions of Ark to the paths they have in the current layout,
+// resources/<resource>/cluster/<name>.json for cluster-scoped items and
+// resources/<resource>/namespaces/<namespace>/<name>.json for namespaced ones.
+type layoutReader struct {
+	Reader
+}
+
+// newLayoutReader returns a Reader for r that renames items in older layouts.
+func newLayoutReader(r Reader) Reader {
+	return &layoutReader{Reader: r}
+}
+
+// isZipReader returns whether r reads a zip file.
+func isZipReader(r Reader) bool {
+	if lr, ok := r.(*layoutReader); ok {
+		r = lr.Reader
+	}
+	_, ok := r.(*zipReader)
+	return ok
+}
+
+func (r *layoutReader) Next() (*tar.Header, error) {
+	header, err := r.Reader.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeRegA {
+		header.Name = currentLayoutPath(header.Name)
+	}
+	return header, nil
+}
+
+// currentLayoutPath returns the path in the current layout of the item at name. Two
+// older layouts are recognized:
+//
+//   - Before the resources directory was added, items were stored as
+//     cluster/<resource>/<name>.json and namespaces/<namespace>/<resource>/<name>.json.
+//   - Before namespaced items were stored in a namespaces directory, they were stored as
+//     resources/<resource>/<namespace>/<name>.json.
+//
+// Other paths are returned unchanged.
+func currentLayoutPath(name string) string {
+	parts := strings.Split(path.Clean(strings.TrimPrefix(name, "/")), "/")
+
+	switch {
+	case len(parts) == 3 && parts[0] == api.ClusterScopedDir:
+		return path.Join(api.ResourcesDir, parts[1], api.ClusterScopedDir, parts[2])
+	case len(parts) == 4 && parts[0] == api.NamespaceScopedDir:
+		return path.Join(api.ResourcesDir, parts[2], api.NamespaceScopedDir, parts[1], parts[3])
+	case len(parts) == 4 && parts[0] == api.ResourcesDir && parts[2] != api.ClusterScopedDir:
+		return path.Join(api.ResourcesDir, parts[1], api.NamespaceScopedDir, parts[2], parts[3])
+	default:
+		return name
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrentLayoutPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "current cluster-scoped item is unchanged",
+			path:     "resources/persistentvolumes/cluster/pv-1.json",
+			expected: "resources/persistentvolumes/cluster/pv-1.json",
+		},
+		{
+			name:     "current namespaced item is unchanged",
+			path:     "resources/pods/namespaces/ns-1/pod-1.json",
+			expected: "resources/pods/namespaces/ns-1/pod-1.json",
+		},
+		{
+			name:     "metadata is unchanged",
+			path:     "metadata/index.json",
+			expected: "metadata/index.json",
+		},
+		{
+			name:     "cluster-scoped item without resources dir is moved",
+			path:     "cluster/persistentvolumes/pv-1.json",
+			expected: "resources/persistentvolumes/cluster/pv-1.json",
+		},
+		{
+			name:     "namespaced item without resources dir is moved",
+			path:     "namespaces/ns-1/pods/pod-1.json",
+			expected: "resources/pods/namespaces/ns-1/pod-1.json",
+		},
+		{
+			name:     "namespaced item without namespaces dir is moved",
+			path:     "resources/pods/ns-1/pod-1.json",
+			expected: "resources/pods/namespaces/ns-1/pod-1.json",
+		},
+		{
+			name:     "leading slash is ignored",
+			path:     "/cluster/namespaces/ns-1.json",
+			expected: "resources/namespaces/cluster/ns-1.json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, currentLayoutPath(test.path))
+		})
+	}
+}
+
+func TestNewReaderLegacyLayout(t *testing.T) {
+	legacyFiles := []testFile{
+		{name: "namespaces/ns-1/pods/pod-1.json", data: `{"kind":"Pod"}`},
+		{name: "cluster/persistentvolumes/pv-1.json", data: `{"kind":"PersistentVolume"}`},
+	}
+
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	writeFiles(t, tar.NewWriter(gzw), legacyFiles)
+	require.NoError(t, gzw.Close())
+
+	r, err := NewReader(buf)
+	require.NoError(t, err)
+	assert.Equal(t, testFiles, readFiles(t, r))
+
+	buf.Reset()
+	writeFiles(t, NewZipWriter(buf), legacyFiles)
+
+	r, err = NewReader(buf)
+	require.NoError(t, err)
+	assert.Equal(t, testFiles, readFiles(t, r))
+}
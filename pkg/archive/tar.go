@@ -0,0 +1,168 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// gzipTarWriter implements Writer for gzip-compressed tarballs. It adds an index
+// of the tarball's regular files as the last file in the tarball.
+type gzipTarWriter struct {
+	tw          *tar.Writer
+	gzippedData *gzip.Writer
+	tarData     *countingWriter
+	index       Index
+
+	// current is the index entry for the file being written, if it's a
+	// regular file, and currentHash is the checksum of its contents so far.
+	current     *IndexEntry
+	currentHash hash.Hash
+
+	// itemHashes are the recorded item hashes, keyed by file path.
+	itemHashes map[string]string
+}
+
+// NewGzipTarWriter returns a Writer that writes a gzip-compressed tarball to w.
+func NewGzipTarWriter(w io.Writer) Writer {
+	gzippedData := gzip.NewWriter(w)
+	tarData := &countingWriter{w: gzippedData}
+
+	return &gzipTarWriter{
+		tw:          tar.NewWriter(tarData),
+		gzippedData: gzippedData,
+		tarData:     tarData,
+		index:       Index{Version: indexVersion},
+		itemHashes:  make(map[string]string),
+	}
+}
+
+func (w *gzipTarWriter) WriteHeader(hdr *tar.Header) error {
+	w.finishEntry()
+
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if hdr.Typeflag == tar.TypeReg {
+		// the tar writer doesn't buffer, so the file's contents start at the
+		// current position in the tarball
+		w.current = &IndexEntry{
+			Path:   hdr.Name,
+			Size:   hdr.Size,
+			Offset: w.tarData.count,
+		}
+		w.currentHash = sha256.New()
+	}
+
+	return nil
+}
+
+func (w *gzipTarWriter) Write(data []byte) (int, error) {
+	n, err := w.tw.Write(data)
+	if w.current != nil {
+		w.currentHash.Write(data[:n])
+	}
+	return n, err
+}
+
+// RecordItemHash records hash as the item hash of the file at path, to be written
+// in the tarball's index.
+func (w *gzipTarWriter) RecordItemHash(path, hash string) {
+	w.itemHashes[path] = hash
+}
+
+// Close writes the tarball's index, flushes the tarball, and closes its gzip
+// stream. It doesn't close the underlying writer.
+func (w *gzipTarWriter) Close() error {
+	w.finishEntry()
+
+	if err := w.writeIndex(); err != nil {
+		w.gzippedData.Close()
+		return err
+	}
+
+	if err := w.tw.Close(); err != nil {
+		w.gzippedData.Close()
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(w.gzippedData.Close())
+}
+
+// finishEntry adds the file being written, if any, to the index.
+func (w *gzipTarWriter) finishEntry() {
+	if w.current == nil {
+		return
+	}
+
+	w.current.SHA256 = hex.EncodeToString(w.currentHash.Sum(nil))
+	w.index.Items = append(w.index.Items, *w.current)
+	w.current, w.currentHash = nil, nil
+}
+
+func (w *gzipTarWriter) writeIndex() error {
+	for i := range w.index.Items {
+		w.index.Items[i].ItemHash = w.itemHashes[w.index.Items[i].Path]
+	}
+
+	indexBytes, err := json.Marshal(w.index)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	hdr := &tar.Header{
+		Name:     api.ArchiveIndexFile,
+		Size:     int64(len(indexBytes)),
+		Typeflag: tar.TypeReg,
+		Mode:     0755,
+		ModTime:  time.Now(),
+	}
+
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := w.tw.Write(indexBytes); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.count += int64(n)
+	return n, err
+}
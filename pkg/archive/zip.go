@@ -0,0 +1,129 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// zipWriter implements Writer for zip files.
+type zipWriter struct {
+	zw      *zip.Writer
+	current io.Writer
+}
+
+// NewZipWriter returns a Writer that writes a zip file to w. Files are compressed
+// with deflate.
+func NewZipWriter(w io.Writer) Writer {
+	return &zipWriter{zw: zip.NewWriter(w)}
+}
+
+func (w *zipWriter) WriteHeader(hdr *tar.Header) error {
+	zipHeader := &zip.FileHeader{
+		Name:     hdr.Name,
+		Method:   zip.Deflate,
+		Modified: hdr.ModTime,
+	}
+	zipHeader.SetMode(hdr.FileInfo().Mode())
+
+	if hdr.Typeflag == tar.TypeDir {
+		zipHeader.Name = strings.TrimSuffix(hdr.Name, "/") + "/"
+		zipHeader.Method = zip.Store
+	}
+
+	current, err := w.zw.CreateHeader(zipHeader)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	w.current = current
+
+	return nil
+}
+
+func (w *zipWriter) Write(data []byte) (int, error) {
+	if w.current == nil {
+		return 0, errors.New("Write called before WriteHeader")
+	}
+	return w.current.Write(data)
+}
+
+// Close writes the zip file's central directory. It doesn't close the underlying
+// writer.
+func (w *zipWriter) Close() error {
+	return errors.WithStack(w.zw.Close())
+}
+
+// zipReader implements Reader for zip files.
+type zipReader struct {
+	files   []*zip.File
+	next    int
+	current io.ReadCloser
+}
+
+func newZipReader(r io.ReaderAt, size int64) (*zipReader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating zip reader")
+	}
+
+	return &zipReader{files: zr.File}, nil
+}
+
+func (r *zipReader) Next() (*tar.Header, error) {
+	if r.current != nil {
+		r.current.Close()
+		r.current = nil
+	}
+
+	if r.next >= len(r.files) {
+		return nil, io.EOF
+	}
+	file := r.files[r.next]
+	r.next++
+
+	hdr := &tar.Header{
+		Name:     file.Name,
+		Size:     int64(file.UncompressedSize64),
+		Mode:     int64(file.Mode().Perm()),
+		ModTime:  file.Modified,
+		Typeflag: tar.TypeReg,
+	}
+	if file.FileInfo().IsDir() {
+		hdr.Typeflag = tar.TypeDir
+		return hdr, nil
+	}
+
+	current, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening %s", file.Name)
+	}
+	r.current = current
+
+	return hdr, nil
+}
+
+func (r *zipReader) Read(data []byte) (int, error) {
+	if r.current == nil {
+		return 0, io.EOF
+	}
+	return r.current.Read(data)
+}
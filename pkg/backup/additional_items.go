@@ -0,0 +1,65 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// parseAdditionalItem parses an additional item of a backup, as
+// resource[.group]/namespace/name.
+func parseAdditionalItem(item string) (ResourceIdentifier, error) {
+	parts := strings.Split(item, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return ResourceIdentifier{}, errors.Errorf("invalid additional item %q, must be resource[.group]/namespace/name", item)
+	}
+
+	return ResourceIdentifier{
+		GroupResource: schema.ParseGroupResource(parts[0]),
+		Namespace:     parts[1],
+		Name:          parts[2],
+	}, nil
+}
+
+// ValidateAdditionalItem returns an error if item isn't a valid additional item
+// of a backup.
+func ValidateAdditionalItem(item string) error {
+	_, err := parseAdditionalItem(item)
+	return err
+}
+
+// additionalItemNames returns the names of the backup's additional items of
+// groupResource in namespace.
+func additionalItemNames(backup *api.Backup, groupResource schema.GroupResource, namespace string) []string {
+	var names []string
+	for _, item := range backup.Spec.AdditionalItems {
+		id, err := parseAdditionalItem(item)
+		if err != nil {
+			// the backup's additional items have been validated
+			continue
+		}
+		if id.GroupResource == groupResource && id.Namespace == namespace {
+			names = append(names, id.Name)
+		}
+	}
+	return names
+}
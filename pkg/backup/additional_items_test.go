@@ -0,0 +1,62 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+func TestValidateAdditionalItem(t *testing.T) {
+	tests := []struct {
+		item        string
+		expectedErr bool
+	}{
+		{item: "persistentvolumeclaims/ns-1/pvc-1"},
+		{item: "deployments.apps/ns-1/deploy-1"},
+		{item: "persistentvolumeclaims/pvc-1", expectedErr: true},
+		{item: "persistentvolumeclaims//pvc-1", expectedErr: true},
+		{item: "persistentvolumeclaims/ns-1/pvc-1/extra", expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.item, func(t *testing.T) {
+			assert.Equal(t, test.expectedErr, ValidateAdditionalItem(test.item) != nil)
+		})
+	}
+}
+
+func TestAdditionalItemNames(t *testing.T) {
+	backup := &api.Backup{
+		Spec: api.BackupSpec{
+			AdditionalItems: []string{
+				"persistentvolumeclaims/ns-1/pvc-1",
+				"persistentvolumeclaims/ns-2/pvc-2",
+				"persistentvolumeclaims/ns-1/pvc-3",
+				"deployments.apps/ns-1/deploy-1",
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"pvc-1", "pvc-3"}, additionalItemNames(backup, schema.GroupResource{Resource: "persistentvolumeclaims"}, "ns-1"))
+	assert.Equal(t, []string{"deploy-1"}, additionalItemNames(backup, schema.GroupResource{Group: "apps", Resource: "deployments"}, "ns-1"))
+	assert.Empty(t, additionalItemNames(backup, schema.GroupResource{Resource: "persistentvolumeclaims"}, "ns-3"))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// inFlightArkPhases are the phases, by resource, of Ark's operation resources
+// that haven't finished yet. Backing these up would capture them in a state
+// that's only meaningful to the server that's processing them, so restoring
+// them could make another server start, or never finish, the same operation.
+var inFlightArkPhases = map[string]sets.String{
+	"backups":              sets.NewString("", string(arkv1api.BackupPhaseNew), string(arkv1api.BackupPhaseQueued), string(arkv1api.BackupPhaseInProgress), string(arkv1api.BackupPhaseDeleting)),
+	"backupestimates":      sets.NewString("", string(arkv1api.BackupEstimatePhaseNew), string(arkv1api.BackupEstimatePhaseInProgress)),
+	"datadownloads":        sets.NewString("", string(arkv1api.DataDownloadPhaseNew), string(arkv1api.DataDownloadPhaseInProgress)),
+	"deletebackuprequests": sets.NewString("", string(arkv1api.DeleteBackupRequestPhaseNew), string(arkv1api.DeleteBackupRequestPhaseInProgress)),
+	"downloadrequests":     sets.NewString("", string(arkv1api.DownloadRequestPhaseNew)),
+	"podvolumebackups":     sets.NewString("", string(arkv1api.PodVolumeBackupPhaseNew), string(arkv1api.PodVolumeBackupPhaseInProgress)),
+	"podvolumerestores":    sets.NewString("", string(arkv1api.PodVolumeRestorePhaseNew), string(arkv1api.PodVolumeRestorePhaseInProgress)),
+	"restores":             sets.NewString("", string(arkv1api.RestorePhaseNew), string(arkv1api.RestorePhaseInProgress)),
+	"undorestorerequests":  sets.NewString("", string(arkv1api.UndoRestoreRequestPhaseNew), string(arkv1api.UndoRestoreRequestPhaseInProgress)),
+	"volumesnapshots":      sets.NewString("", string(arkv1api.VolumeSnapshotPhaseNew)),
+}
+
+// isInFlightArkResource returns whether obj, of groupResource, is one of Ark's
+// operation resources that hasn't finished yet, such as a backup that's in
+// progress, which shouldn't be backed up.
+func isInFlightArkResource(groupResource schema.GroupResource, obj runtime.Unstructured) bool {
+	if groupResource.Group != arkv1api.GroupName {
+		return false
+	}
+
+	phases, ok := inFlightArkPhases[groupResource.Resource]
+	if !ok {
+		return false
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.UnstructuredContent(), "status", "phase")
+	return phases.Has(phase)
+}
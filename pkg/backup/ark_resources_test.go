@@ -0,0 +1,74 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestIsInFlightArkResource(t *testing.T) {
+	tests := []struct {
+		name          string
+		groupResource schema.GroupResource
+		obj           string
+		expected      bool
+	}{
+		{
+			name:          "backup in progress is in flight",
+			groupResource: schema.GroupResource{Group: "ark.heptio.com", Resource: "backups"},
+			obj:           `{"apiVersion":"ark.heptio.com/v1","kind":"Backup","metadata":{"name":"backup-1"},"status":{"phase":"InProgress"}}`,
+			expected:      true,
+		},
+		{
+			name:          "restore without a phase is in flight",
+			groupResource: schema.GroupResource{Group: "ark.heptio.com", Resource: "restores"},
+			obj:           `{"apiVersion":"ark.heptio.com/v1","kind":"Restore","metadata":{"name":"restore-1"}}`,
+			expected:      true,
+		},
+		{
+			name:          "completed backup isn't in flight",
+			groupResource: schema.GroupResource{Group: "ark.heptio.com", Resource: "backups"},
+			obj:           `{"apiVersion":"ark.heptio.com/v1","kind":"Backup","metadata":{"name":"backup-1"},"status":{"phase":"Completed"}}`,
+		},
+		{
+			name:          "processed download request isn't in flight",
+			groupResource: schema.GroupResource{Group: "ark.heptio.com", Resource: "downloadrequests"},
+			obj:           `{"apiVersion":"ark.heptio.com/v1","kind":"DownloadRequest","metadata":{"name":"download-1"},"status":{"phase":"Processed"}}`,
+		},
+		{
+			name:          "new schedule isn't an operation",
+			groupResource: schema.GroupResource{Group: "ark.heptio.com", Resource: "schedules"},
+			obj:           `{"apiVersion":"ark.heptio.com/v1","kind":"Schedule","metadata":{"name":"schedule-1"},"status":{"phase":"New"}}`,
+		},
+		{
+			name:          "resource of another group named backups isn't Ark's",
+			groupResource: schema.GroupResource{Group: "example.com", Resource: "backups"},
+			obj:           `{"apiVersion":"example.com/v1","kind":"Backup","metadata":{"name":"backup-1"},"status":{"phase":"InProgress"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isInFlightArkResource(test.groupResource, arktest.UnstructuredOrDie(test.obj)))
+		})
+	}
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package backup
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -30,11 +28,15 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kuberrs "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
 	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/cloudprovider"
 	"github.com/heptio/ark/pkg/discovery"
+	"github.com/heptio/ark/pkg/httphook"
+	"github.com/heptio/ark/pkg/itemhash"
 	"github.com/heptio/ark/pkg/podexec"
 	"github.com/heptio/ark/pkg/restic"
 	"github.com/heptio/ark/pkg/util/collections"
@@ -45,7 +47,24 @@ import (
 type Backupper interface {
 	// Backup takes a backup using the specification in the api.Backup and writes backup and log data
 	// to the given writers.
-	Backup(logger logrus.FieldLogger, backup *Request, backupFile io.Writer, actions []ItemAction, blockStoreGetter BlockStoreGetter) error
+	// Backup stops backing up items once ctx is done.
+	Backup(ctx context.Context, logger logrus.FieldLogger, backup *Request, backupFile io.Writer, actions []ItemAction, blockStoreGetter BlockStoreGetter) error
+
+	// AddObserver registers an Observer to be notified of the progress of
+	// backups that start after it's added.
+	AddObserver(observer Observer)
+
+	// SetItemHasher replaces the Hasher used to compute the content hashes of
+	// items that are recorded in backups' archive indexes.
+	SetItemHasher(hasher itemhash.Hasher)
+
+	// Estimate collects the items that the backup would include and returns their
+	// number and size, and the volumes whose data would be backed up. Item actions
+	// are run, but hooks aren't, no archive is written, and no volume snapshots or
+	// restic backups are taken. Items that can't be collected are counted in the
+	// estimate's errors; the returned error is only for failures that keep any items
+	// from being collected. Estimate stops collecting items once ctx is done.
+	Estimate(ctx context.Context, logger logrus.FieldLogger, backup *Request, actions []ItemAction, blockStoreGetter BlockStoreGetter) (*Estimate, error)
 }
 
 // kubernetesBackupper implements Backupper.
@@ -55,7 +74,16 @@ type kubernetesBackupper struct {
 	podCommandExecutor     podexec.PodCommandExecutor
 	groupBackupperFactory  groupBackupperFactory
 	resticBackupperFactory restic.BackupperFactory
-	resticTimeout          time.Duration
+	resticTimeout          func() time.Duration
+	clientConfig           *rest.Config
+	pageSize               int
+	resourceTimeouts       map[string]time.Duration
+	newArchiveWriter       ArchiveWriterFactory
+	itemHasher             itemhash.Hasher
+	observers              observerRegistry
+
+	newDynamicFactory               func(config *rest.Config) (client.DynamicFactory, error)
+	newServiceAccountDynamicFactory func(config *rest.Config, namespace, name string) (client.DynamicFactory, error)
 }
 
 type itemKey struct {
@@ -90,9 +118,13 @@ func cohabitatingResources() map[string]*cohabitatingResource {
 func NewKubernetesBackupper(
 	discoveryHelper discovery.Helper,
 	dynamicFactory client.DynamicFactory,
+	clientConfig *rest.Config,
 	podCommandExecutor podexec.PodCommandExecutor,
 	resticBackupperFactory restic.BackupperFactory,
-	resticTimeout time.Duration,
+	resticTimeout func() time.Duration,
+	pageSize int,
+	resourceTimeouts map[string]time.Duration,
+	newArchiveWriter ArchiveWriterFactory,
 ) (Backupper, error) {
 	return &kubernetesBackupper{
 		discoveryHelper:        discoveryHelper,
@@ -101,6 +133,14 @@ func NewKubernetesBackupper(
 		groupBackupperFactory:  &defaultGroupBackupperFactory{},
 		resticBackupperFactory: resticBackupperFactory,
 		resticTimeout:          resticTimeout,
+		clientConfig:           clientConfig,
+		pageSize:               pageSize,
+		resourceTimeouts:       resourceTimeouts,
+		newArchiveWriter:       newArchiveWriter,
+		itemHasher:             itemhash.NewDefaultHasher(),
+
+		newDynamicFactory:               client.NewDynamicFactoryForConfig,
+		newServiceAccountDynamicFactory: client.NewServiceAccountDynamicFactory,
 	}, nil
 }
 
@@ -186,6 +226,17 @@ func getResourceHook(hookSpec api.BackupResourceHookSpec, discoveryHelper discov
 		preHooks = hookSpec.Hooks
 	}
 
+	for _, hooks := range [][]api.BackupResourceHook{preHooks, hookSpec.PostHooks} {
+		for _, hook := range hooks {
+			if hook.HTTP == nil {
+				continue
+			}
+			if err := httphook.Validate(hook.HTTP); err != nil {
+				return resourceHook{}, errors.Wrapf(err, "invalid hook %s", hookSpec.Name)
+			}
+		}
+	}
+
 	h := resourceHook{
 		name:       hookSpec.Name,
 		namespaces: collections.NewIncludesExcludes().Includes(hookSpec.IncludedNamespaces...).Excludes(hookSpec.ExcludedNamespaces...),
@@ -210,37 +261,49 @@ type BlockStoreGetter interface {
 }
 
 // Backup backs up the items specified in the Backup, placing them in a gzip-compressed tar file
-// written to backupFile. The finalized api.Backup is written to metadata.
-func (kb *kubernetesBackupper) Backup(logger logrus.FieldLogger, backupRequest *Request, backupFile io.Writer, actions []ItemAction, blockStoreGetter BlockStoreGetter) error {
-	gzippedData := gzip.NewWriter(backupFile)
-	defer gzippedData.Close()
+// written to backupFile. The finalized api.Backup is written to metadata. If ctx is done before
+// the backup completes, the remaining items are skipped and ctx's error is returned.
+func (kb *kubernetesBackupper) AddObserver(observer Observer) {
+	kb.observers.add(observer)
+}
 
-	tw := tar.NewWriter(gzippedData)
-	defer tw.Close()
+func (kb *kubernetesBackupper) SetItemHasher(hasher itemhash.Hasher) {
+	kb.itemHasher = hasher
+}
 
-	log := logger.WithField("backup", kubeutil.NamespaceAndName(backupRequest))
-	log.Info("Starting backup")
+func (kb *kubernetesBackupper) Backup(ctx context.Context, logger logrus.FieldLogger, backupRequest *Request, backupFile io.Writer, actions []ItemAction, blockStoreGetter BlockStoreGetter) error {
+	backupRequest.observers = kb.observers.list()
+	backupRequest.observers.OnPhaseChange(backupRequest.Backup, api.BackupPhaseInProgress)
 
-	backupRequest.NamespaceIncludesExcludes = getNamespaceIncludesExcludes(backupRequest.Backup)
-	log.Infof("Including namespaces: %s", backupRequest.NamespaceIncludesExcludes.IncludesString())
-	log.Infof("Excluding namespaces: %s", backupRequest.NamespaceIncludesExcludes.ExcludesString())
+	err := kb.backup(ctx, logger, backupRequest, backupFile, actions, blockStoreGetter)
 
-	backupRequest.ResourceIncludesExcludes = getResourceIncludesExcludes(kb.discoveryHelper, backupRequest.Spec.IncludedResources, backupRequest.Spec.ExcludedResources)
-	log.Infof("Including resources: %s", backupRequest.ResourceIncludesExcludes.IncludesString())
-	log.Infof("Excluding resources: %s", backupRequest.ResourceIncludesExcludes.ExcludesString())
+	if err == nil {
+		backupRequest.observers.OnPhaseChange(backupRequest.Backup, api.BackupPhaseCompleted)
+	} else {
+		backupRequest.observers.OnPhaseChange(backupRequest.Backup, api.BackupPhaseFailed)
+	}
 
-	var err error
-	backupRequest.ResourceHooks, err = getResourceHooks(backupRequest.Spec.Hooks.Resources, kb.discoveryHelper)
+	return err
+}
+
+func (kb *kubernetesBackupper) backup(ctx context.Context, logger logrus.FieldLogger, backupRequest *Request, backupFile io.Writer, actions []ItemAction, blockStoreGetter BlockStoreGetter) error {
+	archiveWriter, err := kb.newArchiveWriter(backupRequest.Spec.ArchiveFormat, backupFile)
 	if err != nil {
 		return err
 	}
+	tw := &itemWriter{Writer: archiveWriter}
+	defer tw.Close()
 
-	backupRequest.ResolvedActions, err = resolveActions(actions, kb.discoveryHelper)
+	log := logger.WithField("backup", kubeutil.NamespaceAndName(backupRequest))
+	log.Info("Starting backup")
+
+	dynamicFactory, err := kb.prepareRequest(log, backupRequest, actions)
 	if err != nil {
 		return err
 	}
+	backupRequest.ItemHasher = kb.itemHasher
 
-	podVolumeTimeout := kb.resticTimeout
+	podVolumeTimeout := kb.resticTimeout()
 	if val := backupRequest.Annotations[api.PodVolumeOperationTimeoutAnnotation]; val != "" {
 		parsed, err := time.ParseDuration(val)
 		if err != nil {
@@ -250,21 +313,101 @@ func (kb *kubernetesBackupper) Backup(logger logrus.FieldLogger, backupRequest *
 		}
 	}
 
-	ctx, cancelFunc := context.WithTimeout(context.Background(), podVolumeTimeout)
+	// the timeout applies to each pod volume backup individually, so the
+	// backupper's context only needs to be cancelled once the backup is done.
+	podVolumeCtx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
 
 	var resticBackupper restic.Backupper
 	if kb.resticBackupperFactory != nil {
-		resticBackupper, err = kb.resticBackupperFactory.NewBackupper(ctx, backupRequest.Backup)
+		resticBackupper, err = kb.resticBackupperFactory.NewBackupper(podVolumeCtx, backupRequest.Backup, podVolumeTimeout)
 		if err != nil {
 			return errors.WithStack(err)
 		}
 	}
 
+	errs := kb.backupItems(ctx, log, backupRequest, dynamicFactory, tw, resticBackupper, blockStoreGetter)
+
+	err = kuberrs.Flatten(kuberrs.NewAggregate(errs))
+	if err == nil {
+		log.Infof("Backup completed successfully")
+	} else {
+		log.Infof("Backup completed with errors: %v", err)
+	}
+
+	return err
+}
+
+// prepareRequest resolves the backup's namespace and resource includes and excludes, hooks,
+// and actions into backupRequest, and returns the dynamic factory to read items with.
+func (kb *kubernetesBackupper) prepareRequest(log logrus.FieldLogger, backupRequest *Request, actions []ItemAction) (client.DynamicFactory, error) {
+	var err error
+
+	backupRequest.NamespaceIncludesExcludes = getNamespaceIncludesExcludes(backupRequest.Backup)
+	if !includeTillerNamespace(backupRequest.Backup, backupRequest.NamespaceIncludesExcludes) {
+		log.Warnf("Not backing up Helm release records because Tiller namespace %s is excluded", tillerNamespace(backupRequest.Backup))
+	}
+	log.Infof("Including namespaces: %s", backupRequest.NamespaceIncludesExcludes.IncludesString())
+	log.Infof("Excluding namespaces: %s", backupRequest.NamespaceIncludesExcludes.ExcludesString())
+
+	backupRequest.ResourceIncludesExcludes = getResourceIncludesExcludes(kb.discoveryHelper, backupRequest.Spec.IncludedResources, backupRequest.Spec.ExcludedResources)
+	log.Infof("Including resources: %s", backupRequest.ResourceIncludesExcludes.IncludesString())
+	log.Infof("Excluding resources: %s", backupRequest.ResourceIncludesExcludes.ExcludesString())
+
+	backupRequest.ResourceHooks, err = getResourceHooks(backupRequest.Spec.Hooks.Resources, kb.discoveryHelper)
+	if err != nil {
+		return nil, err
+	}
+
+	backupRequest.ResolvedActions, err = resolveActions(actions, kb.discoveryHelper)
+	if err != nil {
+		return nil, err
+	}
+
+	backupRequest.PageSize = kb.pageSize
+	if backupRequest.Spec.PageSize > 0 {
+		backupRequest.PageSize = backupRequest.Spec.PageSize
+	}
+
+	backupRequest.ResourceTimeouts = kb.resourceTimeouts
+
+	clientConfig, lowered := client.LowerRateLimits(kb.clientConfig, backupRequest.Spec.ClientQPS, backupRequest.Spec.ClientBurst)
+
+	dynamicFactory := kb.dynamicFactory
+	switch name := backupRequest.Spec.ServiceAccountName; {
+	case name != "":
+		namespace := serviceAccountNamespace(backupRequest.Backup)
+		log.Infof("Reading objects as service account %s/%s", namespace, name)
+
+		if dynamicFactory, err = kb.newServiceAccountDynamicFactory(clientConfig, namespace, name); err != nil {
+			return nil, err
+		}
+	case lowered:
+		log.Infof("Reading objects with client QPS %v and burst %d", clientConfig.QPS, clientConfig.Burst)
+
+		if dynamicFactory, err = kb.newDynamicFactory(clientConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return dynamicFactory, nil
+}
+
+// backupItems backs up the items of every group to tw, and returns the errors backing
+// them up.
+func (kb *kubernetesBackupper) backupItems(
+	ctx context.Context,
+	log logrus.FieldLogger,
+	backupRequest *Request,
+	dynamicFactory client.DynamicFactory,
+	tw tarWriter,
+	resticBackupper restic.Backupper,
+	blockStoreGetter BlockStoreGetter,
+) []error {
 	gb := kb.groupBackupperFactory.newGroupBackupper(
 		log,
 		backupRequest,
-		kb.dynamicFactory,
+		dynamicFactory,
 		kb.discoveryHelper,
 		make(map[itemKey]struct{}),
 		cohabitatingResources(),
@@ -277,23 +420,35 @@ func (kb *kubernetesBackupper) Backup(logger logrus.FieldLogger, backupRequest *
 
 	var errs []error
 	for _, group := range kb.discoveryHelper.Resources() {
-		if err := gb.backupGroup(group); err != nil {
+		if ctx.Err() != nil {
+			errs = append(errs, errors.Wrap(ctx.Err(), "backup stopped before all groups were backed up"))
+			break
+		}
+
+		if err := gb.backupGroup(ctx, group); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	err = kuberrs.Flatten(kuberrs.NewAggregate(errs))
-	if err == nil {
-		log.Infof("Backup completed successfully")
-	} else {
-		log.Infof("Backup completed with errors: %v", err)
-	}
+	return errs
+}
 
-	return err
+// serviceAccountNamespace returns the namespace of the service account to impersonate
+// for the backup. Self-service backups use service accounts from the namespace they
+// were created in.
+func serviceAccountNamespace(backup *api.Backup) string {
+	if ns := backup.Labels[api.SelfServiceNamespaceLabel]; ns != "" {
+		return ns
+	}
+	return backup.Namespace
 }
 
 type tarWriter interface {
-	io.Closer
-	Write([]byte) (int, error)
-	WriteHeader(*tar.Header) error
+	archive.Writer
+	// WriteRaw writes size bytes read from r as the file for the item identified by
+	// groupResource, namespace, and name.
+	WriteRaw(groupResource, namespace, name string, r io.Reader, size int64) error
+	// RecordItemHash records hash as the content hash of the item identified by
+	// groupResource, namespace, and name, if the archive supports it.
+	RecordItemHash(groupResource, namespace, name, hash string)
 }
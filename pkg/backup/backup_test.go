@@ -18,6 +18,7 @@ package backup
 
 import (
 	"bytes"
+	"context"
 	"reflect"
 	"sort"
 	"testing"
@@ -34,8 +35,10 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 
 	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
 	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/discovery"
 	"github.com/heptio/ark/pkg/podexec"
@@ -519,9 +522,12 @@ func TestBackup(t *testing.T) {
 				dynamicFactory:        dynamicFactory,
 				podCommandExecutor:    podCommandExecutor,
 				groupBackupperFactory: groupBackupperFactory,
+				resticTimeout:         func() time.Duration { return 0 },
+				clientConfig:          &rest.Config{},
+				newArchiveWriter:      archive.NewWriter,
 			}
 
-			err := kb.Backup(logging.DefaultLogger(logrus.DebugLevel), req, new(bytes.Buffer), nil, nil)
+			err := kb.Backup(context.Background(), logging.DefaultLogger(logrus.DebugLevel), req, new(bytes.Buffer), nil, nil)
 
 			assert.Equal(t, test.expectedNamespaces, req.NamespaceIncludesExcludes)
 			assert.Equal(t, test.expectedResources, req.ResourceIncludesExcludes)
@@ -537,11 +543,56 @@ func TestBackup(t *testing.T) {
 	}
 }
 
+func TestBackupStopsWhenContextIsDone(t *testing.T) {
+	discoveryHelper := &arktest.FakeDiscoveryHelper{
+		ResourceList: []*metav1.APIResourceList{v1Group},
+	}
+
+	groupBackupperFactory := &mockGroupBackupperFactory{}
+	defer groupBackupperFactory.AssertExpectations(t)
+
+	// no calls to backupGroup are expected
+	groupBackupper := &mockGroupBackupper{}
+	defer groupBackupper.AssertExpectations(t)
+
+	groupBackupperFactory.On("newGroupBackupper",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(groupBackupper)
+
+	kb := &kubernetesBackupper{
+		discoveryHelper:       discoveryHelper,
+		groupBackupperFactory: groupBackupperFactory,
+		resticTimeout:         func() time.Duration { return time.Minute },
+		clientConfig:          &rest.Config{},
+		newArchiveWriter:      archive.NewWriter,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := kb.Backup(ctx, arktest.NewLogger(), &Request{Backup: new(v1.Backup)}, new(bytes.Buffer), nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+}
+
 func TestBackupUsesNewCohabitatingResourcesForEachBackup(t *testing.T) {
 	groupBackupperFactory := &mockGroupBackupperFactory{}
 	kb := &kubernetesBackupper{
 		discoveryHelper:       new(arktest.FakeDiscoveryHelper),
 		groupBackupperFactory: groupBackupperFactory,
+		resticTimeout:         func() time.Duration { return 0 },
+		clientConfig:          &rest.Config{},
+		newArchiveWriter:      archive.NewWriter,
 	}
 
 	defer groupBackupperFactory.AssertExpectations(t)
@@ -563,7 +614,7 @@ func TestBackupUsesNewCohabitatingResourcesForEachBackup(t *testing.T) {
 		mock.Anything,
 	).Return(&mockGroupBackupper{})
 
-	assert.NoError(t, kb.Backup(arktest.NewLogger(), &Request{Backup: &v1.Backup{}}, &bytes.Buffer{}, nil, nil))
+	assert.NoError(t, kb.Backup(context.Background(), arktest.NewLogger(), &Request{Backup: &v1.Backup{}}, &bytes.Buffer{}, nil, nil))
 
 	// mutate the cohabitatingResources map that was used in the first backup to simulate
 	// the first backup process having done so.
@@ -590,13 +641,109 @@ func TestBackupUsesNewCohabitatingResourcesForEachBackup(t *testing.T) {
 		mock.Anything,
 	).Return(&mockGroupBackupper{})
 
-	assert.NoError(t, kb.Backup(arktest.NewLogger(), &Request{Backup: new(v1.Backup)}, new(bytes.Buffer), nil, nil))
+	assert.NoError(t, kb.Backup(context.Background(), arktest.NewLogger(), &Request{Backup: new(v1.Backup)}, new(bytes.Buffer), nil, nil))
 	assert.NotEqual(t, firstCohabitatingResources, secondCohabitatingResources)
 	for _, resource := range secondCohabitatingResources {
 		assert.False(t, resource.seen)
 	}
 }
 
+func TestBackupUsesServiceAccountDynamicFactory(t *testing.T) {
+	var (
+		groupBackupperFactory = &mockGroupBackupperFactory{}
+		dynamicFactory        = &arktest.FakeDynamicFactory{}
+		saDynamicFactory      = &arktest.FakeDynamicFactory{}
+		saNamespace, saName   string
+	)
+
+	kb := &kubernetesBackupper{
+		discoveryHelper:       new(arktest.FakeDiscoveryHelper),
+		dynamicFactory:        dynamicFactory,
+		groupBackupperFactory: groupBackupperFactory,
+		resticTimeout:         func() time.Duration { return 0 },
+		clientConfig:          &rest.Config{},
+		newArchiveWriter:      archive.NewWriter,
+		newServiceAccountDynamicFactory: func(_ *rest.Config, namespace, name string) (client.DynamicFactory, error) {
+			saNamespace, saName = namespace, name
+			return saDynamicFactory, nil
+		},
+	}
+
+	defer groupBackupperFactory.AssertExpectations(t)
+
+	groupBackupperFactory.On("newGroupBackupper",
+		mock.Anything,
+		mock.Anything,
+		mock.MatchedBy(func(f client.DynamicFactory) bool { return f == saDynamicFactory }),
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(&mockGroupBackupper{})
+
+	backup := arktest.NewTestBackup().WithName("backup-1").WithLabel(v1.SelfServiceNamespaceLabel, "team-a").Backup
+	backup.Spec.ServiceAccountName = "backup-reader"
+
+	assert.NoError(t, kb.Backup(context.Background(), arktest.NewLogger(), &Request{Backup: backup}, new(bytes.Buffer), nil, nil))
+	assert.Equal(t, "team-a", saNamespace)
+	assert.Equal(t, "backup-reader", saName)
+}
+
+func TestBackupUsesLowerClientLimits(t *testing.T) {
+	var (
+		groupBackupperFactory = &mockGroupBackupperFactory{}
+		dynamicFactory        = &arktest.FakeDynamicFactory{}
+		limitedDynamicFactory = &arktest.FakeDynamicFactory{}
+		limitedConfig         *rest.Config
+		backupRequestPageSize int
+	)
+
+	kb := &kubernetesBackupper{
+		discoveryHelper:       new(arktest.FakeDiscoveryHelper),
+		dynamicFactory:        dynamicFactory,
+		groupBackupperFactory: groupBackupperFactory,
+		resticTimeout:         func() time.Duration { return 0 },
+		clientConfig:          &rest.Config{QPS: 20, Burst: 40},
+		pageSize:              500,
+		newArchiveWriter:      archive.NewWriter,
+		newDynamicFactory: func(config *rest.Config) (client.DynamicFactory, error) {
+			limitedConfig = config
+			return limitedDynamicFactory, nil
+		},
+	}
+
+	defer groupBackupperFactory.AssertExpectations(t)
+
+	groupBackupperFactory.On("newGroupBackupper",
+		mock.Anything,
+		mock.MatchedBy(func(req *Request) bool { backupRequestPageSize = req.PageSize; return true }),
+		mock.MatchedBy(func(f client.DynamicFactory) bool { return f == limitedDynamicFactory }),
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(&mockGroupBackupper{})
+
+	backup := arktest.NewTestBackup().WithName("backup-1").Backup
+	backup.Spec.ClientQPS = 5
+	backup.Spec.PageSize = 100
+
+	assert.NoError(t, kb.Backup(context.Background(), arktest.NewLogger(), &Request{Backup: backup}, new(bytes.Buffer), nil, nil))
+	require.NotNil(t, limitedConfig)
+	assert.Equal(t, float32(5), limitedConfig.QPS)
+	assert.Equal(t, 40, limitedConfig.Burst)
+	assert.Equal(t, float32(20), kb.clientConfig.QPS)
+	assert.Equal(t, 100, backupRequestPageSize)
+}
+
 type mockGroupBackupperFactory struct {
 	mock.Mock
 }
@@ -634,7 +781,7 @@ type mockGroupBackupper struct {
 	mock.Mock
 }
 
-func (gb *mockGroupBackupper) backupGroup(group *metav1.APIResourceList) error {
+func (gb *mockGroupBackupper) backupGroup(ctx context.Context, group *metav1.APIResourceList) error {
 	args := gb.Called(group)
 	return args.Error(0)
 }
@@ -778,3 +925,20 @@ func TestGetResourceHook(t *testing.T) {
 		})
 	}
 }
+
+func TestGetResourceHookInvalidHTTPHook(t *testing.T) {
+	hookSpec := v1.BackupResourceHookSpec{
+		Name: "spec1",
+		PostHooks: []v1.BackupResourceHook{
+			{
+				HTTP: &v1.HTTPHook{
+					URL: "example.com/hook",
+				},
+			},
+		},
+	}
+
+	_, err := getResourceHook(hookSpec, arktest.NewFakeDiscoveryHelper(false, nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid hook spec1")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+)
+
+// deploymentConfigAction implements ItemAction.
+type deploymentConfigAction struct {
+	log logrus.FieldLogger
+}
+
+// NewDeploymentConfigAction creates a new ItemAction for OpenShift deployment configs.
+func NewDeploymentConfigAction(logger logrus.FieldLogger) ItemAction {
+	return &deploymentConfigAction{log: logger}
+}
+
+// AppliesTo returns a ResourceSelector that applies only to deployment configs.
+func (a *deploymentConfigAction) AppliesTo() (ResourceSelector, error) {
+	return ResourceSelector{
+		IncludedResources: []string{"deploymentconfigs"},
+	}, nil
+}
+
+// Execute returns a ResourceIdentifier list containing references to the image streams
+// that the deployment config's image change triggers deploy images from. This ensures
+// that when a deployment config is backed up, the image streams it needs to be deployed
+// are backed up too.
+func (a *deploymentConfigAction) Execute(item runtime.Unstructured, backup *v1.Backup) (runtime.Unstructured, []ResourceIdentifier, error) {
+	deploymentConfig := &unstructured.Unstructured{Object: item.UnstructuredContent()}
+
+	triggers, _, err := unstructured.NestedSlice(deploymentConfig.Object, "spec", "triggers")
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error getting spec.triggers")
+	}
+
+	var additionalItems []ResourceIdentifier
+	added := make(map[ResourceIdentifier]bool)
+
+	for _, trigger := range triggers {
+		t, ok := trigger.(map[string]interface{})
+		if !ok || t["type"] != "ImageChange" {
+			continue
+		}
+
+		from, _, _ := unstructured.NestedMap(t, "imageChangeParams", "from")
+		if from["kind"] != "ImageStreamTag" {
+			continue
+		}
+
+		// image stream tags are named <image stream>:<tag>
+		tagName, _ := from["name"].(string)
+		imageStream := strings.SplitN(tagName, ":", 2)[0]
+		if imageStream == "" {
+			continue
+		}
+
+		namespace, _ := from["namespace"].(string)
+		if namespace == "" {
+			namespace = deploymentConfig.GetNamespace()
+		}
+
+		id := ResourceIdentifier{
+			GroupResource: kuberesource.ImageStreams,
+			Namespace:     namespace,
+			Name:          imageStream,
+		}
+		if added[id] {
+			continue
+		}
+		added[id] = true
+
+		a.log.Infof("Adding image stream %s/%s to additionalItems", namespace, imageStream)
+		additionalItems = append(additionalItems, id)
+	}
+
+	return item, additionalItems, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestDeploymentConfigActionExecute(t *testing.T) {
+	imageChangeTrigger := func(namespace, name string) map[string]interface{} {
+		from := map[string]interface{}{"kind": "ImageStreamTag", "name": name}
+		if namespace != "" {
+			from["namespace"] = namespace
+		}
+		return map[string]interface{}{
+			"type":              "ImageChange",
+			"imageChangeParams": map[string]interface{}{"automatic": true, "from": from},
+		}
+	}
+
+	deploymentConfig := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": "ns-1", "name": "dc-1"},
+		"spec": map[string]interface{}{
+			"triggers": []interface{}{
+				map[string]interface{}{"type": "ConfigChange"},
+				imageChangeTrigger("", "app:latest"),
+				imageChangeTrigger("", "app:v1"),
+				imageChangeTrigger("shared", "sidecar:stable"),
+			},
+		},
+	}}
+
+	action := NewDeploymentConfigAction(arktest.NewLogger())
+
+	res, additional, err := action.Execute(deploymentConfig, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, deploymentConfig, res)
+	expected := []ResourceIdentifier{
+		{GroupResource: kuberesource.ImageStreams, Namespace: "ns-1", Name: "app"},
+		{GroupResource: kuberesource.ImageStreams, Namespace: "shared", Name: "sidecar"},
+	}
+	assert.Equal(t, expected, additional)
+}
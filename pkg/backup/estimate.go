@@ -0,0 +1,122 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	corev1api "k8s.io/api/core/v1"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	kubeutil "github.com/heptio/ark/pkg/util/kube"
+)
+
+// Estimate is the number and size of the items and volumes a backup would include.
+type Estimate struct {
+	// Resources lists the items of each resource, sorted by resource.
+	Resources   []api.ResourceEstimate
+	TotalItems  int
+	TotalSize   int64
+	Volumes     int
+	VolumesSize int64
+	Errors      int
+}
+
+func (kb *kubernetesBackupper) Estimate(ctx context.Context, logger logrus.FieldLogger, backupRequest *Request, actions []ItemAction, blockStoreGetter BlockStoreGetter) (*Estimate, error) {
+	log := logger.WithField("backup", kubeutil.NamespaceAndName(backupRequest))
+	log.Info("Starting backup estimate")
+
+	backupRequest.DryRun = true
+
+	dynamicFactory, err := kb.prepareRequest(log, backupRequest, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	ew := &estimateWriter{resources: make(map[string]*api.ResourceEstimate)}
+	errs := kb.backupItems(ctx, log, backupRequest, dynamicFactory, ew, nil, blockStoreGetter)
+	for _, err := range errs {
+		log.WithError(err).Info("Error collecting items")
+	}
+
+	estimate := ew.estimate()
+	estimate.Errors = len(errs)
+	for _, pv := range backupRequest.EstimatedVolumes {
+		estimate.Volumes++
+		if capacity, ok := pv.Spec.Capacity[corev1api.ResourceStorage]; ok {
+			estimate.VolumesSize += capacity.Value()
+		}
+	}
+
+	log.Infof("Backup estimate completed: %d items, %d volumes", estimate.TotalItems, estimate.Volumes)
+
+	return estimate, nil
+}
+
+// estimateWriter implements tarWriter by counting the items written to it and their
+// sizes, without writing them anywhere.
+type estimateWriter struct {
+	resources map[string]*api.ResourceEstimate
+}
+
+func (w *estimateWriter) WriteRaw(groupResource, namespace, name string, r io.Reader, size int64) error {
+	resource, ok := w.resources[groupResource]
+	if !ok {
+		resource = &api.ResourceEstimate{Resource: groupResource}
+		w.resources[groupResource] = resource
+	}
+
+	resource.Items++
+	resource.Size += size
+
+	return nil
+}
+
+func (w *estimateWriter) RecordItemHash(groupResource, namespace, name, hash string) {}
+
+func (w *estimateWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *estimateWriter) WriteHeader(*tar.Header) error {
+	return nil
+}
+
+func (w *estimateWriter) Close() error {
+	return nil
+}
+
+// estimate returns the items written to w.
+func (w *estimateWriter) estimate() *Estimate {
+	estimate := new(Estimate)
+
+	for _, resource := range w.resources {
+		estimate.Resources = append(estimate.Resources, *resource)
+		estimate.TotalItems += resource.Items
+		estimate.TotalSize += resource.Size
+	}
+
+	sort.Slice(estimate.Resources, func(i, j int) bool {
+		return estimate.Resources[i].Resource < estimate.Resources[j].Resource
+	})
+
+	return estimate
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+func TestEstimateWriter(t *testing.T) {
+	w := &estimateWriter{resources: make(map[string]*api.ResourceEstimate)}
+
+	require.NoError(t, w.WriteRaw("pods", "ns-1", "pod-1", nil, 100))
+	require.NoError(t, w.WriteRaw("deployments.apps", "ns-1", "deploy-1", nil, 50))
+	require.NoError(t, w.WriteRaw("pods", "ns-1", "pod-2", nil, 200))
+
+	expected := &Estimate{
+		Resources: []api.ResourceEstimate{
+			{Resource: "deployments.apps", Items: 1, Size: 50},
+			{Resource: "pods", Items: 2, Size: 300},
+		},
+		TotalItems: 3,
+		TotalSize:  350,
+	}
+
+	assert.Equal(t, expected, w.estimate())
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/util/boolptr"
+)
+
+// FilterProfile is a named, curated set of resource filters that can be
+// applied to a backup instead of listing them individually.
+type FilterProfile struct {
+	// Description is a short summary of what the profile is for.
+	Description string
+
+	// IncludedResources are the resources to include when the backup
+	// doesn't specify any.
+	IncludedResources []string
+
+	// ExcludedResources are added to the backup's excluded resources,
+	// unless the backup explicitly includes them.
+	ExcludedResources []string
+
+	// IncludeClusterResources is used when the backup doesn't specify
+	// whether to include cluster-scoped resources.
+	IncludeClusterResources *bool
+}
+
+// transientResources are resources that are owned and continuously
+// rewritten by the cluster itself, so they're never useful to back up.
+var transientResources = []string{
+	"events",
+	"events.events.k8s.io",
+	"leases.coordination.k8s.io",
+	"nodes",
+	"nodes.metrics.k8s.io",
+	"pods.metrics.k8s.io",
+}
+
+var filterProfiles = map[string]FilterProfile{
+	"app-backup": {
+		Description:       "Namespaced application resources and their persistent volumes",
+		ExcludedResources: append([]string{"endpoints"}, transientResources...),
+	},
+	"cluster-migration": {
+		Description: "Everything needed to recreate workloads in a different cluster",
+		ExcludedResources: append([]string{
+			"apiservices.apiregistration.k8s.io",
+			"certificatesigningrequests.certificates.k8s.io",
+			"endpoints",
+		}, transientResources...),
+		IncludeClusterResources: boolptr.True(),
+	},
+	"full-dr": {
+		Description:             "All resources, for disaster recovery of the same cluster",
+		ExcludedResources:       transientResources,
+		IncludeClusterResources: boolptr.True(),
+	},
+}
+
+// FilterProfileNames returns the names of the built-in filter profiles, sorted.
+func FilterProfileNames() []string {
+	var names []string
+	for name := range filterProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// GetFilterProfile returns the built-in filter profile with the given name.
+func GetFilterProfile(name string) (FilterProfile, bool) {
+	profile, ok := filterProfiles[name]
+	return profile, ok
+}
+
+// ApplyFilterProfile expands the backup's filter profile, if it has one, into
+// its included and excluded resources. Filters specified directly on the backup
+// take precedence over the profile's.
+func ApplyFilterProfile(backup *api.Backup) error {
+	if backup.Spec.FilterProfile == "" {
+		return nil
+	}
+
+	profile, ok := GetFilterProfile(backup.Spec.FilterProfile)
+	if !ok {
+		return errors.Errorf("filter profile %s not found, valid profiles are %v", backup.Spec.FilterProfile, FilterProfileNames())
+	}
+
+	if len(backup.Spec.IncludedResources) == 0 {
+		backup.Spec.IncludedResources = append(backup.Spec.IncludedResources, profile.IncludedResources...)
+	}
+
+	included := sets.NewString(backup.Spec.IncludedResources...)
+	excluded := sets.NewString(backup.Spec.ExcludedResources...)
+	for _, resource := range profile.ExcludedResources {
+		if included.Has(resource) || excluded.Has(resource) {
+			continue
+		}
+		backup.Spec.ExcludedResources = append(backup.Spec.ExcludedResources, resource)
+		excluded.Insert(resource)
+	}
+
+	if backup.Spec.IncludeClusterResources == nil && profile.IncludeClusterResources != nil {
+		val := *profile.IncludeClusterResources
+		backup.Spec.IncludeClusterResources = &val
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/util/boolptr"
+)
+
+func TestApplyFilterProfile(t *testing.T) {
+	tests := []struct {
+		name                            string
+		spec                            v1.BackupSpec
+		expectedIncludedResources       []string
+		expectedExcludedResources       []string
+		expectedIncludeClusterResources *bool
+		expectedErr                     bool
+	}{
+		{
+			name: "no filter profile leaves the backup unchanged",
+			spec: v1.BackupSpec{
+				ExcludedResources: []string{"secrets"},
+			},
+			expectedExcludedResources: []string{"secrets"},
+		},
+		{
+			name: "non-existent filter profile returns an error",
+			spec: v1.BackupSpec{
+				FilterProfile: "nonexistent",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "filter profile's excluded resources are added to the backup's",
+			spec: v1.BackupSpec{
+				FilterProfile:     "full-dr",
+				ExcludedResources: []string{"secrets", "events"},
+			},
+			expectedExcludedResources: []string{
+				"secrets",
+				"events",
+				"events.events.k8s.io",
+				"leases.coordination.k8s.io",
+				"nodes",
+				"nodes.metrics.k8s.io",
+				"pods.metrics.k8s.io",
+			},
+			expectedIncludeClusterResources: boolptr.True(),
+		},
+		{
+			name: "explicitly included resources are not excluded by the filter profile",
+			spec: v1.BackupSpec{
+				FilterProfile:           "full-dr",
+				IncludedResources:       []string{"nodes", "pods"},
+				IncludeClusterResources: boolptr.False(),
+			},
+			expectedIncludedResources: []string{"nodes", "pods"},
+			expectedExcludedResources: []string{
+				"events",
+				"events.events.k8s.io",
+				"leases.coordination.k8s.io",
+				"nodes.metrics.k8s.io",
+				"pods.metrics.k8s.io",
+			},
+			expectedIncludeClusterResources: boolptr.False(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backup := &v1.Backup{Spec: test.spec}
+
+			err := ApplyFilterProfile(backup)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedIncludedResources, backup.Spec.IncludedResources)
+			assert.Equal(t, test.expectedExcludedResources, backup.Spec.ExcludedResources)
+			assert.Equal(t, test.expectedIncludeClusterResources, backup.Spec.IncludeClusterResources)
+		})
+	}
+}
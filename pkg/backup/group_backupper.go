@@ -17,6 +17,7 @@ limitations under the License.
 package backup
 
 import (
+	"context"
 	"sort"
 	"strings"
 
@@ -81,7 +82,7 @@ func (f *defaultGroupBackupperFactory) newGroupBackupper(
 }
 
 type groupBackupper interface {
-	backupGroup(group *metav1.APIResourceList) error
+	backupGroup(ctx context.Context, group *metav1.APIResourceList) error
 }
 
 type defaultGroupBackupper struct {
@@ -100,7 +101,7 @@ type defaultGroupBackupper struct {
 }
 
 // backupGroup backs up a single API group.
-func (gb *defaultGroupBackupper) backupGroup(group *metav1.APIResourceList) error {
+func (gb *defaultGroupBackupper) backupGroup(ctx context.Context, group *metav1.APIResourceList) error {
 	var (
 		errs []error
 		log  = gb.log.WithField("group", group.GroupVersion)
@@ -133,7 +134,11 @@ func (gb *defaultGroupBackupper) backupGroup(group *metav1.APIResourceList) erro
 	}
 
 	for _, resource := range group.APIResources {
-		if err := rb.backupResource(group, resource); err != nil {
+		if ctx.Err() != nil {
+			return errors.WithStack(ctx.Err())
+		}
+
+		if err := rb.backupResource(ctx, group, resource); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package backup
 
 import (
+	"context"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -76,12 +77,52 @@ func TestBackupGroupBacksUpCorrectResourcesInCorrectOrder(t *testing.T) {
 	resourceBackupper.On("backupResource", group, metav1.APIResource{Name: "persistentvolumeclaims"}).Return(nil).Run(runFunc)
 	resourceBackupper.On("backupResource", group, metav1.APIResource{Name: "persistentvolumes"}).Return(nil).Run(runFunc)
 
-	require.NoError(t, gb.backupGroup(group))
+	require.NoError(t, gb.backupGroup(context.Background(), group))
 
 	// make sure PVs were last
 	assert.Equal(t, []string{"pods", "persistentvolumeclaims", "persistentvolumes"}, actualOrder)
 }
 
+func TestBackupGroupStopsWhenContextIsDone(t *testing.T) {
+	resourceBackupperFactory := new(mockResourceBackupperFactory)
+	resourceBackupper := new(mockResourceBackupper)
+
+	defer resourceBackupperFactory.AssertExpectations(t)
+	// no calls to backupResource are expected
+	defer resourceBackupper.AssertExpectations(t)
+
+	resourceBackupperFactory.On("newResourceBackupper",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(resourceBackupper)
+
+	gb := &defaultGroupBackupper{
+		log:                      arktest.NewLogger(),
+		resourceBackupperFactory: resourceBackupperFactory,
+	}
+
+	group := &metav1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "pods"},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.EqualError(t, gb.backupGroup(ctx, group), context.Canceled.Error())
+}
+
 type mockResourceBackupperFactory struct {
 	mock.Mock
 }
@@ -119,7 +160,7 @@ type mockResourceBackupper struct {
 	mock.Mock
 }
 
-func (rb *mockResourceBackupper) backupResource(group *metav1.APIResourceList, resource metav1.APIResource) error {
+func (rb *mockResourceBackupper) backupResource(ctx context.Context, group *metav1.APIResourceList, resource metav1.APIResource) error {
 	args := rb.Called(group, resource)
 	return args.Error(0)
 }
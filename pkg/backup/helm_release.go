@@ -0,0 +1,151 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+// defaultTillerNamespace is the namespace Tiller stores release records in if the
+// backup doesn't specify one.
+const defaultTillerNamespace = "kube-system"
+
+// helmReleaseLabels are the labels that charts conventionally set to the name of the
+// release an item belongs to.
+var helmReleaseLabels = []string{"app.kubernetes.io/instance", "release"}
+
+// tillerNamespace returns the namespace Tiller stores the backup's release records in.
+func tillerNamespace(backup *api.Backup) string {
+	if backup.Spec.TillerNamespace != "" {
+		return backup.Spec.TillerNamespace
+	}
+	return defaultTillerNamespace
+}
+
+// includeTillerNamespace adds the Tiller namespace to namespaces if the backup is of
+// Helm releases, so that the releases' records are backed up. It returns false if the
+// namespace is explicitly excluded.
+func includeTillerNamespace(backup *api.Backup, namespaces *collections.IncludesExcludes) bool {
+	if len(backup.Spec.HelmReleases) == 0 {
+		return true
+	}
+
+	namespace := tillerNamespace(backup)
+	for _, excluded := range namespaces.GetExcludes() {
+		if excluded == namespace {
+			return false
+		}
+	}
+
+	if !namespaces.ShouldInclude(namespace) {
+		namespaces.Includes(namespace)
+	}
+	return true
+}
+
+// itemSelectors returns the label selectors to list the backup's items with. An item
+// is backed up if it matches any of them. If the backup is of Helm releases, there's
+// one selector for each release label and release, each also requiring the backup's
+// label selector; otherwise there's just the backup's label selector.
+func itemSelectors(backup *api.Backup) ([]labels.Selector, error) {
+	selector := labels.Everything()
+	if backup.Spec.LabelSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(backup.Spec.LabelSelector); err != nil {
+			return nil, errors.Wrap(err, "invalid label selector")
+		}
+	}
+
+	if len(backup.Spec.HelmReleases) == 0 {
+		return []labels.Selector{selector}, nil
+	}
+
+	var selectors []labels.Selector
+	for _, release := range backup.Spec.HelmReleases {
+		for _, label := range helmReleaseLabels {
+			req, err := labels.NewRequirement(label, selection.Equals, []string{release})
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid Helm release name %q", release)
+			}
+			selectors = append(selectors, selector.Add(*req))
+		}
+	}
+
+	return selectors, nil
+}
+
+// releaseRecordSelectors returns the label selectors to list the records of the
+// backup's Helm releases with, if the backup is of Helm releases and items of
+// groupResource in namespace can be release records.
+func releaseRecordSelectors(backup *api.Backup, groupResource schema.GroupResource, namespace string) []labels.Selector {
+	if len(backup.Spec.HelmReleases) == 0 || namespace != tillerNamespace(backup) {
+		return nil
+	}
+	if groupResource != kuberesource.ConfigMaps && groupResource != kuberesource.Secrets {
+		return nil
+	}
+
+	var selectors []labels.Selector
+	for _, release := range backup.Spec.HelmReleases {
+		selectors = append(selectors, labels.SelectorFromSet(labels.Set{"OWNER": "TILLER", "NAME": release}))
+	}
+	return selectors
+}
+
+// recordHelmRelease updates the backup's status with the revision and status of
+// the release record, if it's newer than the revision already recorded.
+func recordHelmRelease(backup *api.Backup, record metav1.Object) {
+	recordLabels := record.GetLabels()
+
+	revision, err := strconv.Atoi(recordLabels["VERSION"])
+	if err != nil {
+		return
+	}
+
+	info := api.HelmReleaseInfo{
+		Name:            recordLabels["NAME"],
+		TillerNamespace: record.GetNamespace(),
+		Revision:        revision,
+		Status:          recordLabels["STATUS"],
+	}
+
+	for i, existing := range backup.Status.HelmReleases {
+		if existing.Name != info.Name {
+			continue
+		}
+		if info.Revision > existing.Revision {
+			backup.Status.HelmReleases[i] = info
+		}
+		return
+	}
+
+	backup.Status.HelmReleases = append(backup.Status.HelmReleases, info)
+	sort.Slice(backup.Status.HelmReleases, func(i, j int) bool {
+		return backup.Status.HelmReleases[i].Name < backup.Status.HelmReleases[j].Name
+	})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	"github.com/heptio/ark/pkg/util/collections"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestItemSelectors(t *testing.T) {
+	tests := []struct {
+		name          string
+		backup        *api.Backup
+		expectedMatch []labels.Set
+		expectedSkip  []labels.Set
+	}{
+		{
+			name:          "no label selector or releases matches everything",
+			backup:        arktest.NewTestBackup().Backup,
+			expectedMatch: []labels.Set{{}, {"release": "release-1"}},
+		},
+		{
+			name:          "label selector only",
+			backup:        arktest.NewTestBackup().WithLabelSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"a": "b"}}).Backup,
+			expectedMatch: []labels.Set{{"a": "b"}},
+			expectedSkip:  []labels.Set{{}, {"a": "c"}},
+		},
+		{
+			name:   "releases match either release label",
+			backup: arktest.NewTestBackup().WithHelmReleases("release-1", "release-2").Backup,
+			expectedMatch: []labels.Set{
+				{"app.kubernetes.io/instance": "release-1"},
+				{"release": "release-2"},
+			},
+			expectedSkip: []labels.Set{{}, {"release": "release-3"}, {"app": "release-1"}},
+		},
+		{
+			name:          "releases also require the label selector",
+			backup:        arktest.NewTestBackup().WithHelmReleases("release-1").WithLabelSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"a": "b"}}).Backup,
+			expectedMatch: []labels.Set{{"release": "release-1", "a": "b"}},
+			expectedSkip:  []labels.Set{{"release": "release-1"}, {"a": "b"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selectors, err := itemSelectors(test.backup)
+			require.NoError(t, err)
+
+			for _, set := range test.expectedMatch {
+				assert.True(t, matchesAny(selectors, set), "expected %v to match", set)
+			}
+			for _, set := range test.expectedSkip {
+				assert.False(t, matchesAny(selectors, set), "expected %v not to match", set)
+			}
+		})
+	}
+}
+
+func TestReleaseRecordSelectors(t *testing.T) {
+	backup := arktest.NewTestBackup().WithHelmReleases("release-1").Backup
+
+	selectors := releaseRecordSelectors(backup, kuberesource.ConfigMaps, "kube-system")
+	require.Len(t, selectors, 1)
+	assert.True(t, selectors[0].Matches(labels.Set{"OWNER": "TILLER", "NAME": "release-1", "VERSION": "3"}))
+	assert.False(t, selectors[0].Matches(labels.Set{"OWNER": "TILLER", "NAME": "release-2"}))
+
+	assert.Len(t, releaseRecordSelectors(backup, kuberesource.Secrets, "kube-system"), 1)
+	assert.Empty(t, releaseRecordSelectors(backup, kuberesource.Pods, "kube-system"))
+	assert.Empty(t, releaseRecordSelectors(backup, kuberesource.ConfigMaps, "ns-1"))
+	assert.Empty(t, releaseRecordSelectors(arktest.NewTestBackup().Backup, kuberesource.ConfigMaps, "kube-system"))
+
+	backup.Spec.TillerNamespace = "tiller"
+	assert.Len(t, releaseRecordSelectors(backup, kuberesource.ConfigMaps, "tiller"), 1)
+}
+
+func TestIncludeTillerNamespace(t *testing.T) {
+	backup := arktest.NewTestBackup().WithHelmReleases("release-1").Backup
+
+	namespaces := collections.NewIncludesExcludes().Includes("ns-1")
+	assert.True(t, includeTillerNamespace(backup, namespaces))
+	assert.Equal(t, []string{"kube-system", "ns-1"}, namespaces.GetIncludes())
+
+	namespaces = collections.NewIncludesExcludes().Includes("*")
+	assert.True(t, includeTillerNamespace(backup, namespaces))
+	assert.Equal(t, []string{"*"}, namespaces.GetIncludes())
+
+	namespaces = collections.NewIncludesExcludes().Includes("*").Excludes("kube-system")
+	assert.False(t, includeTillerNamespace(backup, namespaces))
+}
+
+func TestRecordHelmRelease(t *testing.T) {
+	backup := arktest.NewTestBackup().Backup
+
+	record := func(name, version, status string) metav1.Object {
+		return &metav1.ObjectMeta{
+			Namespace: "kube-system",
+			Name:      name + ".v" + version,
+			Labels:    map[string]string{"OWNER": "TILLER", "NAME": name, "VERSION": version, "STATUS": status},
+		}
+	}
+
+	recordHelmRelease(backup, record("release-2", "1", "DEPLOYED"))
+	recordHelmRelease(backup, record("release-1", "1", "SUPERSEDED"))
+	recordHelmRelease(backup, record("release-1", "3", "DEPLOYED"))
+	recordHelmRelease(backup, record("release-1", "2", "SUPERSEDED"))
+	recordHelmRelease(backup, record("release-3", "not-a-number", "DEPLOYED"))
+
+	expected := []api.HelmReleaseInfo{
+		{Name: "release-1", TillerNamespace: "kube-system", Revision: 3, Status: "DEPLOYED"},
+		{Name: "release-2", TillerNamespace: "kube-system", Revision: 1, Status: "DEPLOYED"},
+	}
+	assert.Equal(t, expected, backup.Status.HelmReleases)
+}
@@ -17,14 +17,14 @@ limitations under the License.
 package backup
 
 import (
-	"archive/tar"
+	"bytes"
 	"encoding/json"
-	"path/filepath"
-	"time"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -36,6 +36,7 @@ import (
 	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/cloudprovider"
 	"github.com/heptio/ark/pkg/discovery"
+	"github.com/heptio/ark/pkg/httphook"
 	"github.com/heptio/ark/pkg/kuberesource"
 	"github.com/heptio/ark/pkg/podexec"
 	"github.com/heptio/ark/pkg/restic"
@@ -81,6 +82,8 @@ func (f *defaultItemBackupperFactory) newItemBackupper(
 
 		itemHookHandler: &defaultItemHookHandler{
 			podCommandExecutor: podCommandExecutor,
+			httpHookCaller:     httphook.NewCaller(),
+			backupName:         backupRequest.Name,
 		},
 	}
 
@@ -107,6 +110,9 @@ type defaultItemBackupper struct {
 	itemHookHandler             itemHookHandler
 	additionalItemBackupper     ItemBackupper
 	snapshotLocationBlockStores map[string]cloudprovider.BlockStore
+
+	// itemBuffer is reused to encode each item before it's written to tarWriter
+	itemBuffer bytes.Buffer
 }
 
 // backupItem backs up an individual item to tarWriter. The item may be excluded based on the
@@ -148,6 +154,11 @@ func (ib *defaultItemBackupper) backupItem(logger logrus.FieldLogger, obj runtim
 		log.Info("Skipping item because it's being deleted.")
 		return nil
 	}
+
+	if isInFlightArkResource(groupResource, obj) {
+		log.Info("Skipping item because it's an Ark operation that hasn't finished.")
+		return nil
+	}
 	key := itemKey{
 		resource:  groupResource.String(),
 		namespace: namespace,
@@ -162,9 +173,11 @@ func (ib *defaultItemBackupper) backupItem(logger logrus.FieldLogger, obj runtim
 
 	log.Info("Backing up resource")
 
-	log.Debug("Executing pre hooks")
-	if err := ib.itemHookHandler.handleHooks(log, groupResource, obj, ib.backupRequest.ResourceHooks, hookPhasePre); err != nil {
-		return err
+	if !ib.backupRequest.DryRun {
+		log.Debug("Executing pre hooks")
+		if err := ib.itemHookHandler.handleHooks(log, groupResource, obj, ib.backupRequest.ResourceHooks, hookPhasePre); err != nil {
+			return err
+		}
 	}
 
 	var (
@@ -196,9 +209,11 @@ func (ib *defaultItemBackupper) backupItem(logger logrus.FieldLogger, obj runtim
 		backupErrs = append(backupErrs, err)
 
 		// if there was an error running actions, execute post hooks and return
-		log.Debug("Executing post hooks")
-		if err := ib.itemHookHandler.handleHooks(log, groupResource, obj, ib.backupRequest.ResourceHooks, hookPhasePost); err != nil {
-			backupErrs = append(backupErrs, err)
+		if !ib.backupRequest.DryRun {
+			log.Debug("Executing post hooks")
+			if err := ib.itemHookHandler.handleHooks(log, groupResource, obj, ib.backupRequest.ResourceHooks, hookPhasePost); err != nil {
+				backupErrs = append(backupErrs, err)
+			}
 		}
 
 		return kubeerrs.NewAggregate(backupErrs)
@@ -214,7 +229,7 @@ func (ib *defaultItemBackupper) backupItem(logger logrus.FieldLogger, obj runtim
 		}
 	}
 
-	if groupResource == kuberesource.Pods && pod != nil {
+	if groupResource == kuberesource.Pods && pod != nil && !ib.backupRequest.DryRun {
 		// this function will return partial results, so process volumeSnapshots
 		// even if there are errors.
 		volumeSnapshots, errs := ib.backupPodVolumes(log, pod, resticVolumesToBackup)
@@ -223,45 +238,123 @@ func (ib *defaultItemBackupper) backupItem(logger logrus.FieldLogger, obj runtim
 		for volume, snapshot := range volumeSnapshots {
 			restic.SetPodSnapshotAnnotation(metadata, volume, snapshot)
 		}
+		ib.recordPodVolumeSnapshots(pod, volumeSnapshots)
 
 		backupErrs = append(backupErrs, errs...)
 	}
 
-	log.Debug("Executing post hooks")
-	if err := ib.itemHookHandler.handleHooks(log, groupResource, obj, ib.backupRequest.ResourceHooks, hookPhasePost); err != nil {
-		backupErrs = append(backupErrs, err)
+	if !ib.backupRequest.DryRun {
+		log.Debug("Executing post hooks")
+		if err := ib.itemHookHandler.handleHooks(log, groupResource, obj, ib.backupRequest.ResourceHooks, hookPhasePost); err != nil {
+			backupErrs = append(backupErrs, err)
+		}
 	}
 
 	if len(backupErrs) != 0 {
 		return kubeerrs.NewAggregate(backupErrs)
 	}
 
-	var filePath string
-	if namespace != "" {
-		filePath = filepath.Join(api.ResourcesDir, groupResource.String(), api.NamespaceScopedDir, namespace, name+".json")
-	} else {
-		filePath = filepath.Join(api.ResourcesDir, groupResource.String(), api.ClusterScopedDir, name+".json")
+	if err := ib.writeItem(obj, groupResource, namespace, name); err != nil {
+		return err
 	}
 
-	itemBytes, err := json.Marshal(obj.UnstructuredContent())
-	if err != nil {
+	if ib.backupRequest.Spec.FollowReferences {
+		return ib.backupReferencedItems(log, obj, groupResource)
+	}
+
+	return nil
+}
+
+// writeItem encodes obj and writes it to tarWriter.
+func (ib *defaultItemBackupper) writeItem(obj runtime.Unstructured, groupResource schema.GroupResource, namespace, name string) error {
+	ib.itemBuffer.Reset()
+	if err := json.NewEncoder(&ib.itemBuffer).Encode(obj.UnstructuredContent()); err != nil {
 		return errors.WithStack(err)
 	}
+	// the encoder terminates the item with a newline, which isn't part of the item's JSON
+	ib.itemBuffer.Truncate(ib.itemBuffer.Len() - 1)
 
-	hdr := &tar.Header{
-		Name:     filePath,
-		Size:     int64(len(itemBytes)),
-		Typeflag: tar.TypeReg,
-		Mode:     0755,
-		ModTime:  time.Now(),
+	if err := ib.tarWriter.WriteRaw(groupResource.String(), namespace, name, &ib.itemBuffer, int64(ib.itemBuffer.Len())); err != nil {
+		return err
 	}
 
-	if err := ib.tarWriter.WriteHeader(hdr); err != nil {
-		return errors.WithStack(err)
+	if hasher := ib.backupRequest.ItemHasher; hasher != nil {
+		hash, err := hasher.Hash(groupResource.String(), obj.UnstructuredContent())
+		if err != nil {
+			return errors.Wrapf(err, "error hashing item %s", itemFilePath(groupResource.String(), namespace, name))
+		}
+		ib.tarWriter.RecordItemHash(groupResource.String(), namespace, name, hash)
 	}
 
-	if _, err := ib.tarWriter.Write(itemBytes); err != nil {
-		return errors.WithStack(err)
+	ib.backupRequest.observers.OnItemBackedUp(ib.backupRequest.Backup, groupResource, namespace, name)
+	return nil
+}
+
+// backupReferencedItems backs up the namespace-scoped items that obj directly references
+// and that are outside the backup's included namespaces, recording them as extra items
+// in the backup's status. Referenced items are backed up as-is: hooks and custom actions
+// aren't run for them, and their own references aren't followed. Items are read with the
+// backup's dynamic factory, so a backup that impersonates a service account only follows
+// references to items the service account is allowed to get.
+func (ib *defaultItemBackupper) backupReferencedItems(log logrus.FieldLogger, obj runtime.Unstructured, groupResource schema.GroupResource) error {
+	refs, err := referencedItems(obj, groupResource)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if ref.Namespace == "" || ib.backupRequest.NamespaceIncludesExcludes.ShouldInclude(ref.Namespace) {
+			continue
+		}
+
+		gvr, resource, err := ib.discoveryHelper.ResourceFor(ref.GroupResource.WithVersion(""))
+		if err != nil {
+			return err
+		}
+
+		refResource := gvr.GroupResource()
+		refLog := log.WithField("reference", ref.Namespace+"/"+ref.Name).WithField("referenceResource", refResource.String())
+
+		if !ib.backupRequest.ResourceIncludesExcludes.ShouldInclude(refResource.String()) {
+			refLog.Info("Not backing up referenced item because resource is excluded")
+			continue
+		}
+
+		key := itemKey{
+			resource:  refResource.String(),
+			namespace: ref.Namespace,
+			name:      ref.Name,
+		}
+		if _, exists := ib.backedUpItems[key]; exists {
+			continue
+		}
+
+		client, err := ib.dynamicFactory.ClientForGroupVersionResource(gvr.GroupVersion(), resource, ref.Namespace)
+		if err != nil {
+			return err
+		}
+
+		item, err := client.Get(ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			refLog.Warn("Not backing up referenced item because it doesn't exist")
+			continue
+		}
+		if apierrors.IsForbidden(err) && ib.backupRequest.Spec.ServiceAccountName != "" {
+			refLog.Infof("Not backing up referenced item because service account %s is not allowed to get it", ib.backupRequest.Spec.ServiceAccountName)
+			continue
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ib.backedUpItems[key] = struct{}{}
+
+		refLog.Info("Backing up referenced item")
+		if err := ib.writeItem(item, refResource, ref.Namespace, ref.Name); err != nil {
+			return err
+		}
+
+		ib.backupRequest.Status.ExtraItems = append(ib.backupRequest.Status.ExtraItems, strings.Join([]string{key.resource, key.namespace, key.name}, "/"))
 	}
 
 	return nil
@@ -282,6 +375,29 @@ func (ib *defaultItemBackupper) backupPodVolumes(log logrus.FieldLogger, pod *co
 	return ib.resticBackupper.BackupPodVolumes(ib.backupRequest.Backup, pod, log)
 }
 
+// recordPodVolumeSnapshots adds the restic snapshots of a pod's volumes, as a map of
+// volume name -> snapshot ID, to the backup request, in volume order.
+func (ib *defaultItemBackupper) recordPodVolumeSnapshots(pod *corev1api.Pod, volumeSnapshots map[string]string) {
+	for _, podVolume := range pod.Spec.Volumes {
+		snapshotID, ok := volumeSnapshots[podVolume.Name]
+		if !ok {
+			continue
+		}
+
+		snapshot := &volume.PodVolumeSnapshot{
+			PodNamespace: pod.Namespace,
+			PodName:      pod.Name,
+			Volume:       podVolume.Name,
+			SnapshotID:   snapshotID,
+		}
+		if podVolume.PersistentVolumeClaim != nil {
+			snapshot.PersistentVolumeClaim = podVolume.PersistentVolumeClaim.ClaimName
+		}
+
+		ib.backupRequest.PodVolumeSnapshots = append(ib.backupRequest.PodVolumeSnapshots, snapshot)
+	}
+}
+
 func (ib *defaultItemBackupper) executeActions(
 	log logrus.FieldLogger,
 	obj runtime.Unstructured,
@@ -377,11 +493,6 @@ const zoneLabel = "failure-domain.beta.kubernetes.io/zone"
 func (ib *defaultItemBackupper) takePVSnapshot(obj runtime.Unstructured, log logrus.FieldLogger) error {
 	log.Info("Executing takePVSnapshot")
 
-	if ib.backupRequest.Spec.SnapshotVolumes != nil && !*ib.backupRequest.Spec.SnapshotVolumes {
-		log.Info("Backup has volume snapshots disabled; skipping volume snapshot action.")
-		return nil
-	}
-
 	pv := new(corev1api.PersistentVolume)
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), pv); err != nil {
 		return errors.WithStack(err)
@@ -392,10 +503,18 @@ func (ib *defaultItemBackupper) takePVSnapshot(obj runtime.Unstructured, log log
 	if pv.Spec.ClaimRef != nil {
 		if ib.resticSnapshotTracker.Has(pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name) {
 			log.Info("Skipping Persistent Volume snapshot because volume has already been backed up.")
+			if ib.backupRequest.DryRun {
+				ib.backupRequest.EstimatedVolumes = append(ib.backupRequest.EstimatedVolumes, pv)
+			}
 			return nil
 		}
 	}
 
+	if ib.backupRequest.Spec.SnapshotVolumes != nil && !*ib.backupRequest.Spec.SnapshotVolumes {
+		log.Info("Backup has volume snapshots disabled; skipping volume snapshot action.")
+		return nil
+	}
+
 	metadata, err := meta.Accessor(obj)
 	if err != nil {
 		return errors.WithStack(err)
@@ -443,6 +562,12 @@ func (ib *defaultItemBackupper) takePVSnapshot(obj runtime.Unstructured, log log
 		return nil
 	}
 
+	if ib.backupRequest.DryRun {
+		log.Info("Not snapshotting PersistentVolume because backup is a dry run")
+		ib.backupRequest.EstimatedVolumes = append(ib.backupRequest.EstimatedVolumes, pv)
+		return nil
+	}
+
 	log = log.WithField("volumeID", volumeID)
 
 	tags := map[string]string{
@@ -459,6 +584,9 @@ func (ib *defaultItemBackupper) takePVSnapshot(obj runtime.Unstructured, log log
 
 	log.Info("Snapshotting PersistentVolume")
 	snapshot := volumeSnapshot(ib.backupRequest.Backup, metadata.GetName(), volumeID, volumeType, pvFailureDomainZone, location, iops)
+	if capacity, ok := pv.Spec.Capacity[corev1api.ResourceStorage]; ok {
+		snapshot.Spec.VolumeSize = capacity.String()
+	}
 
 	var errs []error
 	snapshotID, err := blockStore.CreateSnapshot(snapshot.Spec.ProviderVolumeID, snapshot.Spec.VolumeAZ, tags)
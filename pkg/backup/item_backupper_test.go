@@ -20,6 +20,7 @@ import (
 	"archive/tar"
 	"encoding/json"
 	"fmt"
+	"io"
 	"reflect"
 	"testing"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -41,9 +43,12 @@ import (
 	"github.com/heptio/ark/pkg/apis/ark/v1"
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
 	"github.com/heptio/ark/pkg/cloudprovider"
+	"github.com/heptio/ark/pkg/itemhash"
+	"github.com/heptio/ark/pkg/kuberesource"
 	resticmocks "github.com/heptio/ark/pkg/restic/mocks"
 	"github.com/heptio/ark/pkg/util/collections"
 	arktest "github.com/heptio/ark/pkg/util/test"
+	"github.com/heptio/ark/pkg/volume"
 )
 
 func TestBackupItemSkips(t *testing.T) {
@@ -597,8 +602,10 @@ func TestItemActionModificationsToItemPersist(t *testing.T) {
 			},
 		}
 		req = &Request{
+			Backup:                    &v1.Backup{},
 			NamespaceIncludesExcludes: collections.NewIncludesExcludes(),
 			ResourceIncludesExcludes:  collections.NewIncludesExcludes(),
+			ItemHasher:                itemhash.NewDefaultHasher(),
 			ResolvedActions: []resolvedAction{
 				{
 					ItemAction:                &addAnnotationAction{},
@@ -636,6 +643,113 @@ func TestItemActionModificationsToItemPersist(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.EqualValues(t, expected.Object, actual)
+
+	// the item's hash is computed from the modified item
+	expectedHash, err := req.ItemHasher.Hash("resource.group", expected.Object)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"resources/resource.group/namespaces/myns/bar.json": expectedHash}, w.itemHashes)
+}
+
+func TestBackupItemFollowReferences(t *testing.T) {
+	var (
+		w   = &fakeTarWriter{}
+		obj = arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1","annotations":{"backup.ark.heptio.com/references":"secrets/ns-2/secret-1,secrets/ns-1/secret-2,secrets/ns-2/missing,widgets.example.com/ns-3/widget-1"}}}`)
+		req = &Request{
+			Backup:                    &v1.Backup{Spec: v1.BackupSpec{FollowReferences: true}},
+			NamespaceIncludesExcludes: collections.NewIncludesExcludes().Includes("ns-1"),
+			ResourceIncludesExcludes:  collections.NewIncludesExcludes().Includes("configmaps", "secrets"),
+		}
+		dynamicFactory = &arktest.FakeDynamicFactory{}
+		secretsClient  = &arktest.FakeDynamicClient{}
+		secret         = arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"Secret","metadata":{"namespace":"ns-2","name":"secret-1"}}`)
+	)
+	defer dynamicFactory.AssertExpectations(t)
+	defer secretsClient.AssertExpectations(t)
+
+	dynamicFactory.On("ClientForGroupVersionResource", schema.GroupVersion{}, metav1.APIResource{Name: "secrets"}, "ns-2").Return(secretsClient, nil)
+	secretsClient.On("Get", "secret-1", metav1.GetOptions{}).Return(secret, nil)
+	secretsClient.On("Get", "missing", metav1.GetOptions{}).Return(&unstructured.Unstructured{}, apierrors.NewNotFound(kuberesource.Secrets, "missing"))
+
+	b := (&defaultItemBackupperFactory{}).newItemBackupper(
+		req,
+		make(map[itemKey]struct{}),
+		nil,
+		w,
+		dynamicFactory,
+		arktest.NewFakeDiscoveryHelper(true, nil),
+		nil,
+		newPVCSnapshotTracker(),
+		nil,
+	).(*defaultItemBackupper)
+
+	require.NoError(t, b.backupItem(arktest.NewLogger(), obj, kuberesource.ConfigMaps))
+
+	// the config map and the secret outside the included namespaces are backed up, the
+	// secret in an included namespace is left to be backed up normally, and the missing
+	// secret and the excluded widget are skipped.
+	require.Len(t, w.data, 2)
+	actual, err := arktest.GetAsMap(string(w.data[1]))
+	require.NoError(t, err)
+	assert.EqualValues(t, secret.Object, actual)
+
+	assert.Equal(t, []string{"secrets/ns-2/secret-1"}, req.Status.ExtraItems)
+	assert.Contains(t, b.backedUpItems, itemKey{resource: "secrets", namespace: "ns-2", name: "secret-1"})
+
+	// references aren't followed when the backup doesn't ask for it
+	w = &fakeTarWriter{}
+	req.Spec.FollowReferences = false
+	req.Status.ExtraItems = nil
+	b.tarWriter = w
+	b.backedUpItems = make(map[itemKey]struct{})
+
+	require.NoError(t, b.backupItem(arktest.NewLogger(), obj, kuberesource.ConfigMaps))
+	assert.Len(t, w.data, 1)
+	assert.Empty(t, req.Status.ExtraItems)
+}
+
+func TestBackupItemFollowReferencesAsServiceAccount(t *testing.T) {
+	var (
+		w   = &fakeTarWriter{}
+		obj = arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1","annotations":{"backup.ark.heptio.com/references":"secrets/ns-2/secret-1"}}}`)
+		req = &Request{
+			Backup:                    &v1.Backup{Spec: v1.BackupSpec{FollowReferences: true, ServiceAccountName: "backup-sa"}},
+			NamespaceIncludesExcludes: collections.NewIncludesExcludes().Includes("ns-1"),
+			ResourceIncludesExcludes:  collections.NewIncludesExcludes(),
+		}
+		dynamicFactory = &arktest.FakeDynamicFactory{}
+		secretsClient  = &arktest.FakeDynamicClient{}
+	)
+	defer dynamicFactory.AssertExpectations(t)
+	defer secretsClient.AssertExpectations(t)
+
+	dynamicFactory.On("ClientForGroupVersionResource", schema.GroupVersion{}, metav1.APIResource{Name: "secrets"}, "ns-2").Return(secretsClient, nil)
+	secretsClient.On("Get", "secret-1", metav1.GetOptions{}).Return(&unstructured.Unstructured{}, apierrors.NewForbidden(kuberesource.Secrets, "secret-1", errors.New("forbidden")))
+
+	b := (&defaultItemBackupperFactory{}).newItemBackupper(
+		req,
+		make(map[itemKey]struct{}),
+		nil,
+		w,
+		dynamicFactory,
+		arktest.NewFakeDiscoveryHelper(true, nil),
+		nil,
+		newPVCSnapshotTracker(),
+		nil,
+	).(*defaultItemBackupper)
+
+	// the service account isn't allowed to get the referenced secret, so only the
+	// config map is backed up
+	require.NoError(t, b.backupItem(arktest.NewLogger(), obj, kuberesource.ConfigMaps))
+	assert.Len(t, w.data, 1)
+	assert.Empty(t, req.Status.ExtraItems)
+
+	// without a service account, a forbidden reference is an error
+	w = &fakeTarWriter{}
+	req.Spec.ServiceAccountName = ""
+	b.tarWriter = w
+	b.backedUpItems = make(map[itemKey]struct{})
+
+	assert.Error(t, b.backupItem(arktest.NewLogger(), obj, kuberesource.ConfigMaps))
 }
 
 func TestResticAnnotationsPersist(t *testing.T) {
@@ -653,6 +767,7 @@ func TestResticAnnotationsPersist(t *testing.T) {
 			},
 		}
 		req = &Request{
+			Backup:                    &v1.Backup{},
 			NamespaceIncludesExcludes: collections.NewIncludesExcludes(),
 			ResourceIncludesExcludes:  collections.NewIncludesExcludes(),
 			ResolvedActions: []resolvedAction{
@@ -706,6 +821,44 @@ func TestResticAnnotationsPersist(t *testing.T) {
 	assert.EqualValues(t, expected.Object, actual)
 }
 
+func TestRecordPodVolumeSnapshots(t *testing.T) {
+	pod := &corev1api.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "pod-1",
+		},
+		Spec: corev1api.PodSpec{
+			Volumes: []corev1api.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1api.VolumeSource{
+						PersistentVolumeClaim: &corev1api.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-1"},
+					},
+				},
+				{
+					Name: "not-backed-up",
+				},
+				{
+					Name: "scratch",
+					VolumeSource: corev1api.VolumeSource{
+						EmptyDir: &corev1api.EmptyDirVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+
+	ib := &defaultItemBackupper{backupRequest: &Request{}}
+
+	ib.recordPodVolumeSnapshots(pod, map[string]string{"scratch": "snap-2", "data": "snap-1"})
+
+	expected := []*volume.PodVolumeSnapshot{
+		{PodNamespace: "ns-1", PodName: "pod-1", Volume: "data", PersistentVolumeClaim: "pvc-1", SnapshotID: "snap-1"},
+		{PodNamespace: "ns-1", PodName: "pod-1", Volume: "scratch", SnapshotID: "snap-2"},
+	}
+	assert.Equal(t, expected, ib.backupRequest.PodVolumeSnapshots)
+}
+
 func TestTakePVSnapshot(t *testing.T) {
 	iops := int64(1000)
 
@@ -717,6 +870,7 @@ func TestTakePVSnapshot(t *testing.T) {
 		expectError            bool
 		expectedVolumeID       string
 		expectedSnapshotsTaken int
+		expectedVolumeSize     string
 		volumeInfo             map[string]v1.VolumeBackupInfo
 	}{
 		{
@@ -754,6 +908,19 @@ func TestTakePVSnapshot(t *testing.T) {
 				"vol-abc123": {Type: "io1", Iops: &iops, SnapshotID: "snap-1", AvailabilityZone: "us-east-1c"},
 			},
 		},
+		{
+			name:                   "with capacity",
+			snapshotEnabled:        true,
+			pv:                     `{"apiVersion": "v1", "kind": "PersistentVolume", "metadata": {"name": "mypv", "labels": {"failure-domain.beta.kubernetes.io/zone": "us-east-1c"}}, "spec": {"capacity": {"storage": "10Gi"}, "awsElasticBlockStore": {"volumeID": "aws://us-east-1c/vol-abc123"}}}`,
+			expectError:            false,
+			expectedSnapshotsTaken: 1,
+			expectedVolumeID:       "vol-abc123",
+			expectedVolumeSize:     "10Gi",
+			ttl:                    5 * time.Minute,
+			volumeInfo: map[string]v1.VolumeBackupInfo{
+				"vol-abc123": {Type: "gp", SnapshotID: "snap-1", AvailabilityZone: "us-east-1c"},
+			},
+		},
 		{
 			name:             "create snapshot error",
 			snapshotEnabled:  true,
@@ -835,6 +1002,7 @@ func TestTakePVSnapshot(t *testing.T) {
 				assert.Equal(t, test.volumeInfo[test.expectedVolumeID].Type, snapshot.Spec.VolumeType)
 				assert.Equal(t, test.volumeInfo[test.expectedVolumeID].Iops, snapshot.Spec.VolumeIOPS)
 				assert.Equal(t, test.volumeInfo[test.expectedVolumeID].AvailabilityZone, snapshot.Spec.VolumeAZ)
+				assert.Equal(t, test.expectedVolumeSize, snapshot.Spec.VolumeSize)
 			}
 		})
 	}
@@ -846,13 +1014,18 @@ type fakeTarWriter struct {
 	data             [][]byte
 	writeHeaderError error
 	writeError       error
+	itemHashes       map[string]string
 }
 
 func (w *fakeTarWriter) Close() error { return nil }
 
 func (w *fakeTarWriter) Write(data []byte) (int, error) {
-	w.data = append(w.data, data)
-	return 0, w.writeError
+	// the data's buffer may be reused once Write returns, so keep a copy
+	w.data = append(w.data, append([]byte(nil), data...))
+	if w.writeError != nil {
+		return 0, w.writeError
+	}
+	return len(data), nil
 }
 
 func (w *fakeTarWriter) WriteHeader(header *tar.Header) error {
@@ -860,6 +1033,17 @@ func (w *fakeTarWriter) WriteHeader(header *tar.Header) error {
 	return w.writeHeaderError
 }
 
+func (w *fakeTarWriter) WriteRaw(groupResource, namespace, name string, r io.Reader, size int64) error {
+	return writeRaw(w, groupResource, namespace, name, r, size)
+}
+
+func (w *fakeTarWriter) RecordItemHash(groupResource, namespace, name, hash string) {
+	if w.itemHashes == nil {
+		w.itemHashes = make(map[string]string)
+	}
+	w.itemHashes[itemFilePath(groupResource, namespace, name)] = hash
+}
+
 type mockItemBackupper struct {
 	mock.Mock
 }
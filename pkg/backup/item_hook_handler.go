@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/httphook"
 	"github.com/heptio/ark/pkg/kuberesource"
 	"github.com/heptio/ark/pkg/podexec"
 	"github.com/heptio/ark/pkg/util/collections"
@@ -45,9 +46,10 @@ const (
 // itemHookHandler invokes hooks for an item.
 type itemHookHandler interface {
 	// handleHooks invokes hooks for an item. If the item is a pod and the appropriate annotations exist
-	// to specify a hook, that is executed. Otherwise, this looks at the backup context's Backup to
-	// determine if there are any hooks relevant to the item, taking into account the hook spec's
-	// namespaces, resources, and label selector.
+	// to specify an exec hook, that is executed instead of any exec hooks from the backup spec. This also
+	// looks at the backup context's Backup to determine if there are any hooks relevant to the item, taking
+	// into account the hook spec's namespaces, resources, and label selector. Exec hooks only run for pods,
+	// while HTTP hooks run for items of any resource.
 	handleHooks(
 		log logrus.FieldLogger,
 		groupResource schema.GroupResource,
@@ -60,6 +62,8 @@ type itemHookHandler interface {
 // defaultItemHookHandler is the default itemHookHandler.
 type defaultItemHookHandler struct {
 	podCommandExecutor podexec.PodCommandExecutor
+	httpHookCaller     httphook.Caller
+	backupName         string
 }
 
 func (h *defaultItemHookHandler) handleHooks(
@@ -69,8 +73,12 @@ func (h *defaultItemHookHandler) handleHooks(
 	resourceHooks []resourceHook,
 	phase hookPhase,
 ) error {
-	// We only support hooks on pods right now
-	if groupResource != kuberesource.Pods {
+	isPod := groupResource == kuberesource.Pods
+
+	// Only pods can have hooks specified via annotations, and HTTP hooks are the only
+	// kind that applies to other resources, so there's nothing to do for them without
+	// any resource hooks.
+	if !isPod && len(resourceHooks) == 0 {
 		return nil
 	}
 
@@ -82,11 +90,15 @@ func (h *defaultItemHookHandler) handleHooks(
 	namespace := metadata.GetNamespace()
 	name := metadata.GetName()
 
-	// If the pod has the hook specified via annotations, that takes priority.
-	hookFromAnnotations := getPodExecHookFromAnnotations(metadata.GetAnnotations(), phase)
-	if phase == hookPhasePre && hookFromAnnotations == nil {
-		// See if the pod has the legacy hook annotation keys (i.e. without a phase specified)
-		hookFromAnnotations = getPodExecHookFromAnnotations(metadata.GetAnnotations(), "")
+	// If the pod has an exec hook specified via annotations, that takes priority over
+	// exec hooks in the backup spec.
+	var hookFromAnnotations *api.ExecHook
+	if isPod {
+		hookFromAnnotations = getPodExecHookFromAnnotations(metadata.GetAnnotations(), phase)
+		if phase == hookPhasePre && hookFromAnnotations == nil {
+			// See if the pod has the legacy hook annotation keys (i.e. without a phase specified)
+			hookFromAnnotations = getPodExecHookFromAnnotations(metadata.GetAnnotations(), "")
+		}
 	}
 	if hookFromAnnotations != nil {
 		hookLog := log.WithFields(
@@ -102,12 +114,10 @@ func (h *defaultItemHookHandler) handleHooks(
 				return err
 			}
 		}
-
-		return nil
 	}
 
 	labels := labels.Set(metadata.GetLabels())
-	// Otherwise, check for hooks defined in the backup spec.
+	// Check for hooks defined in the backup spec.
 	for _, resourceHook := range resourceHooks {
 		if !resourceHook.applicableTo(groupResource, namespace, labels) {
 			continue
@@ -120,21 +130,36 @@ func (h *defaultItemHookHandler) handleHooks(
 			hooks = resourceHook.post
 		}
 		for _, hook := range hooks {
-			if groupResource == kuberesource.Pods {
-				if hook.Exec != nil {
-					hookLog := log.WithFields(
-						logrus.Fields{
-							"hookSource": "backupSpec",
-							"hookType":   "exec",
-							"hookPhase":  phase,
-						},
-					)
-					err := h.podCommandExecutor.ExecutePodCommand(hookLog, obj.UnstructuredContent(), namespace, name, resourceHook.name, hook.Exec)
-					if err != nil {
-						hookLog.WithError(err).Error("Error executing hook")
-						if hook.Exec.OnError == api.HookErrorModeFail {
-							return err
-						}
+			if isPod && hook.Exec != nil && hookFromAnnotations == nil {
+				hookLog := log.WithFields(
+					logrus.Fields{
+						"hookSource": "backupSpec",
+						"hookType":   "exec",
+						"hookPhase":  phase,
+					},
+				)
+				err := h.podCommandExecutor.ExecutePodCommand(hookLog, obj.UnstructuredContent(), namespace, name, resourceHook.name, hook.Exec)
+				if err != nil {
+					hookLog.WithError(err).Error("Error executing hook")
+					if hook.Exec.OnError == api.HookErrorModeFail {
+						return err
+					}
+				}
+			}
+
+			if hook.HTTP != nil {
+				hookLog := log.WithFields(
+					logrus.Fields{
+						"hookSource": "backupSpec",
+						"hookType":   "http",
+						"hookPhase":  phase,
+					},
+				)
+				if err := h.callHTTPHook(hookLog, groupResource, obj, resourceHook.name, hook.HTTP, phase); err != nil {
+					hookLog.WithError(err).Error("Error calling hook")
+					// HTTP hooks default to failing, like exec hooks
+					if hook.HTTP.OnError != api.HookErrorModeContinue {
+						return err
 					}
 				}
 			}
@@ -144,6 +169,30 @@ func (h *defaultItemHookHandler) handleHooks(
 	return nil
 }
 
+func (h *defaultItemHookHandler) callHTTPHook(
+	log logrus.FieldLogger,
+	groupResource schema.GroupResource,
+	obj runtime.Unstructured,
+	hookName string,
+	hook *api.HTTPHook,
+	phase hookPhase,
+) error {
+	item, err := httphook.NewItem(groupResource, obj)
+	if err != nil {
+		return err
+	}
+
+	payload := &httphook.Payload{
+		Operation: "backup",
+		Phase:     string(phase),
+		Backup:    h.backupName,
+		Hook:      hookName,
+		Item:      item,
+	}
+
+	return h.httpHookCaller.Call(log, hook, payload)
+}
+
 const (
 	podBackupHookContainerAnnotationKey = "hook.backup.ark.heptio.com/container"
 	podBackupHookCommandAnnotationKey   = "hook.backup.ark.heptio.com/command"
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/httphook"
 	"github.com/heptio/ark/pkg/util/collections"
 	arktest "github.com/heptio/ark/pkg/util/test"
 )
@@ -694,3 +695,164 @@ func TestResourceHookApplicableTo(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleHooksHTTP(t *testing.T) {
+	configMap := arktest.UnstructuredOrDie(`
+		{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"namespace": "ns",
+				"name": "name",
+				"labels": {
+					"app": "db"
+				}
+			}
+		}`)
+	podWithAnnotation := arktest.UnstructuredOrDie(`
+		{
+			"apiVersion": "v1",
+			"kind": "Pod",
+			"metadata": {
+				"namespace": "ns",
+				"name": "name",
+				"annotations": {
+					"hook.backup.ark.heptio.com/container": "c",
+					"hook.backup.ark.heptio.com/command": "/bin/ls"
+				}
+			}
+		}`)
+
+	continueHook := &v1.HTTPHook{URL: "http://example.com/continue", OnError: v1.HookErrorModeContinue}
+	failHook := &v1.HTTPHook{URL: "http://example.com/fail", OnError: v1.HookErrorModeFail}
+
+	tests := []struct {
+		name              string
+		groupResource     string
+		item              runtime.Unstructured
+		hooks             []resourceHook
+		hookErrors        map[string]error
+		expectedCalls     []*v1.HTTPHook
+		expectedExecHooks int
+		expectedError     string
+	}{
+		{
+			name:          "non-pod resource runs HTTP hooks",
+			groupResource: "configmaps",
+			item:          configMap,
+			hooks: []resourceHook{
+				{
+					name: "hook1",
+					pre: []v1.BackupResourceHook{
+						{HTTP: continueHook},
+						{Exec: &v1.ExecHook{Container: "c", Command: []string{"ls"}}},
+					},
+				},
+			},
+			expectedCalls: []*v1.HTTPHook{continueHook},
+		},
+		{
+			name:          "HTTP hooks not applicable",
+			groupResource: "configmaps",
+			item:          configMap,
+			hooks: []resourceHook{
+				{
+					name:          "hook1",
+					labelSelector: parseLabelSelectorOrDie("app=web"),
+					pre:           []v1.BackupResourceHook{{HTTP: continueHook}},
+				},
+			},
+		},
+		{
+			name:          "error from continue hook is ignored",
+			groupResource: "configmaps",
+			item:          configMap,
+			hooks: []resourceHook{
+				{
+					name: "hook1",
+					pre: []v1.BackupResourceHook{
+						{HTTP: continueHook},
+						{HTTP: failHook},
+					},
+				},
+			},
+			hookErrors:    map[string]error{continueHook.URL: errors.New("continue error")},
+			expectedCalls: []*v1.HTTPHook{continueHook, failHook},
+		},
+		{
+			name:          "error from fail hook is returned",
+			groupResource: "configmaps",
+			item:          configMap,
+			hooks: []resourceHook{
+				{
+					name: "hook1",
+					pre: []v1.BackupResourceHook{
+						{HTTP: failHook},
+						{HTTP: continueHook},
+					},
+				},
+			},
+			hookErrors:    map[string]error{failHook.URL: errors.New("fail error")},
+			expectedCalls: []*v1.HTTPHook{failHook},
+			expectedError: "fail error",
+		},
+		{
+			name:          "pod with annotation runs HTTP hooks but not spec exec hooks",
+			groupResource: "pods",
+			item:          podWithAnnotation,
+			hooks: []resourceHook{
+				{
+					name: "hook1",
+					pre: []v1.BackupResourceHook{
+						{Exec: &v1.ExecHook{Container: "c", Command: []string{"ls"}}},
+						{HTTP: continueHook},
+					},
+				},
+			},
+			expectedCalls:     []*v1.HTTPHook{continueHook},
+			expectedExecHooks: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			podCommandExecutor := &arktest.MockPodCommandExecutor{}
+			defer podCommandExecutor.AssertExpectations(t)
+			httpHookCaller := &arktest.MockHTTPHookCaller{}
+			defer httpHookCaller.AssertExpectations(t)
+
+			h := &defaultItemHookHandler{
+				podCommandExecutor: podCommandExecutor,
+				httpHookCaller:     httpHookCaller,
+				backupName:         "backup-1",
+			}
+
+			if test.expectedExecHooks > 0 {
+				podCommandExecutor.On("ExecutePodCommand", mock.Anything, test.item.UnstructuredContent(), "ns", "name", "<from-annotation>", mock.Anything).Return(nil).Times(test.expectedExecHooks)
+			}
+			for _, hook := range test.expectedCalls {
+				httpHookCaller.On("Call", mock.Anything, hook, mock.Anything).Return(test.hookErrors[hook.URL]).Once()
+			}
+
+			groupResource := schema.ParseGroupResource(test.groupResource)
+			err := h.handleHooks(arktest.NewLogger(), groupResource, test.item, test.hooks, hookPhasePre)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, call := range httpHookCaller.Calls {
+				payload := call.Arguments.Get(2).(*httphook.Payload)
+				assert.Equal(t, "backup", payload.Operation)
+				assert.Equal(t, "pre", payload.Phase)
+				assert.Equal(t, "backup-1", payload.Backup)
+				assert.Equal(t, "hook1", payload.Hook)
+				assert.Equal(t, test.item.GetObjectKind().GroupVersionKind().Kind, payload.Item.Kind)
+				assert.Equal(t, "ns", payload.Item.Namespace)
+				assert.Equal(t, "name", payload.Item.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// Observer is notified of the progress of the backups run by a Backupper.
+// Its methods are called synchronously while the backup runs, so they should
+// return quickly, and they may be called concurrently for different backups.
+type Observer interface {
+	// OnPhaseChange is called with api.BackupPhaseInProgress when a backup
+	// starts, and with api.BackupPhaseCompleted or api.BackupPhaseFailed when
+	// it finishes.
+	OnPhaseChange(backup *api.Backup, phase api.BackupPhase)
+
+	// OnItemBackedUp is called after an item is written to a backup.
+	OnItemBackedUp(backup *api.Backup, groupResource schema.GroupResource, namespace, name string)
+}
+
+// observers notifies each of a list of Observers in turn.
+type observers []Observer
+
+func (o observers) OnPhaseChange(backup *api.Backup, phase api.BackupPhase) {
+	for _, observer := range o {
+		observer.OnPhaseChange(backup, phase)
+	}
+}
+
+func (o observers) OnItemBackedUp(backup *api.Backup, groupResource schema.GroupResource, namespace, name string) {
+	for _, observer := range o {
+		observer.OnItemBackedUp(backup, groupResource, namespace, name)
+	}
+}
+
+// observerRegistry is the list of Observers registered on a Backupper.
+type observerRegistry struct {
+	lock      sync.Mutex
+	observers observers
+}
+
+func (r *observerRegistry) add(observer Observer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.observers = append(r.observers, observer)
+}
+
+// list returns the Observers registered so far. Observers registered while
+// a backup runs aren't notified of its progress.
+func (r *observerRegistry) list() observers {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append(observers(nil), r.observers...)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+// recordingObserver records the notifications it receives.
+type recordingObserver struct {
+	phases []v1.BackupPhase
+	items  []string
+}
+
+func (o *recordingObserver) OnPhaseChange(backup *v1.Backup, phase v1.BackupPhase) {
+	o.phases = append(o.phases, phase)
+}
+
+func (o *recordingObserver) OnItemBackedUp(backup *v1.Backup, groupResource schema.GroupResource, namespace, name string) {
+	o.items = append(o.items, groupResource.String()+"/"+namespace+"/"+name)
+}
+
+func TestBackupNotifiesObserversOfPhaseChanges(t *testing.T) {
+	tests := []struct {
+		name           string
+		groupErr       error
+		expectedPhases []v1.BackupPhase
+	}{
+		{
+			name:           "successful backup",
+			expectedPhases: []v1.BackupPhase{v1.BackupPhaseInProgress, v1.BackupPhaseCompleted},
+		},
+		{
+			name:           "failed backup",
+			groupErr:       assert.AnError,
+			expectedPhases: []v1.BackupPhase{v1.BackupPhaseInProgress, v1.BackupPhaseFailed},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			groupBackupperFactory := &mockGroupBackupperFactory{}
+			groupBackupper := &mockGroupBackupper{}
+			groupBackupperFactory.On("newGroupBackupper",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+			).Return(groupBackupper)
+			groupBackupper.On("backupGroup", v1Group).Return(test.groupErr)
+
+			kb := &kubernetesBackupper{
+				discoveryHelper:       &arktest.FakeDiscoveryHelper{ResourceList: []*metav1.APIResourceList{v1Group}},
+				groupBackupperFactory: groupBackupperFactory,
+				resticTimeout:         func() time.Duration { return time.Minute },
+				clientConfig:          &rest.Config{},
+				newArchiveWriter:      archive.NewWriter,
+			}
+
+			observer := &recordingObserver{}
+			kb.AddObserver(observer)
+
+			err := kb.Backup(context.Background(), arktest.NewLogger(), &Request{Backup: new(v1.Backup)}, new(bytes.Buffer), nil, nil)
+			assert.Equal(t, test.groupErr != nil, err != nil)
+			assert.Equal(t, test.expectedPhases, observer.phases)
+		})
+	}
+}
+
+func TestWriteItemNotifiesObservers(t *testing.T) {
+	var (
+		observer = &recordingObserver{}
+		obj      = arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1"}}`)
+		ib       = &defaultItemBackupper{
+			backupRequest: &Request{Backup: new(v1.Backup), observers: observers{observer}},
+			tarWriter:     &fakeTarWriter{},
+		}
+	)
+
+	require.NoError(t, ib.writeItem(obj, kuberesource.ConfigMaps, "ns-1", "cm-1"))
+	assert.Equal(t, []string{"configmaps/ns-1/cm-1"}, observer.items)
+
+	// items that can't be written aren't reported
+	ib.tarWriter = &fakeTarWriter{writeError: assert.AnError}
+	require.Error(t, ib.writeItem(obj, kuberesource.ConfigMaps, "ns-1", "cm-2"))
+	assert.Equal(t, []string{"configmaps/ns-1/cm-1"}, observer.items)
+}
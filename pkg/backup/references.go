@@ -0,0 +1,88 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/heptio/ark/pkg/kuberesource"
+)
+
+// ReferencesAnnotation is the annotation key whose value is a comma-separated
+// list of items, as "resource[.group]/namespace/name", that an item references
+// and that should be backed up with it when a backup follows references.
+const ReferencesAnnotation = "backup.ark.heptio.com/references"
+
+// referencedItems returns the namespace-scoped items that obj directly references,
+// either through the references annotation or through well-known fields such as
+// the service account subjects of role bindings.
+func referencedItems(obj runtime.Unstructured, groupResource schema.GroupResource) ([]ResourceIdentifier, error) {
+	metadata, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var refs []ResourceIdentifier
+
+	if value := metadata.GetAnnotations()[ReferencesAnnotation]; value != "" {
+		for _, ref := range strings.Split(value, ",") {
+			parts := strings.Split(strings.TrimSpace(ref), "/")
+			if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+				return nil, errors.Errorf("invalid reference %q in annotation %s, must be resource[.group]/namespace/name", ref, ReferencesAnnotation)
+			}
+
+			refs = append(refs, ResourceIdentifier{
+				GroupResource: schema.ParseGroupResource(parts[0]),
+				Namespace:     parts[1],
+				Name:          parts[2],
+			})
+		}
+	}
+
+	if groupResource == kuberesource.RoleBindings || groupResource == kuberesource.ClusterRoleBindings {
+		// subjects are the same in all versions of the RBAC API
+		binding := new(rbacv1.RoleBinding)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), binding); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for _, subject := range binding.Subjects {
+			if subject.Kind != rbacv1.ServiceAccountKind {
+				continue
+			}
+
+			namespace := subject.Namespace
+			if namespace == "" {
+				namespace = binding.Namespace
+			}
+
+			refs = append(refs, ResourceIdentifier{
+				GroupResource: kuberesource.ServiceAccounts,
+				Namespace:     namespace,
+				Name:          subject.Name,
+			})
+		}
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestReferencedItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		obj           string
+		groupResource schema.GroupResource
+		expected      []ResourceIdentifier
+		expectedErr   bool
+	}{
+		{
+			name:          "item without references returns none",
+			obj:           `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1"}}`,
+			groupResource: kuberesource.ConfigMaps,
+		},
+		{
+			name:          "references in annotation are returned",
+			obj:           `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1","annotations":{"backup.ark.heptio.com/references":"secrets/ns-2/secret-1, widgets.example.com/ns-3/widget-1"}}}`,
+			groupResource: kuberesource.ConfigMaps,
+			expected: []ResourceIdentifier{
+				{GroupResource: kuberesource.Secrets, Namespace: "ns-2", Name: "secret-1"},
+				{GroupResource: schema.GroupResource{Group: "example.com", Resource: "widgets"}, Namespace: "ns-3", Name: "widget-1"},
+			},
+		},
+		{
+			name:          "invalid reference in annotation returns an error",
+			obj:           `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1","annotations":{"backup.ark.heptio.com/references":"secrets/secret-1"}}}`,
+			groupResource: kuberesource.ConfigMaps,
+			expectedErr:   true,
+		},
+		{
+			name:          "role binding service account subjects are returned",
+			obj:           `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"RoleBinding","metadata":{"namespace":"ns-1","name":"rb-1"},"roleRef":{"apiGroup":"rbac.authorization.k8s.io","kind":"Role","name":"role-1"},"subjects":[{"kind":"ServiceAccount","namespace":"ns-2","name":"sa-1"},{"kind":"ServiceAccount","name":"sa-2"},{"kind":"User","name":"user-1"}]}`,
+			groupResource: kuberesource.RoleBindings,
+			expected: []ResourceIdentifier{
+				{GroupResource: kuberesource.ServiceAccounts, Namespace: "ns-2", Name: "sa-1"},
+				{GroupResource: kuberesource.ServiceAccounts, Namespace: "ns-1", Name: "sa-2"},
+			},
+		},
+		{
+			name:          "cluster role binding service account subjects are returned",
+			obj:           `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRoleBinding","metadata":{"name":"crb-1"},"roleRef":{"apiGroup":"rbac.authorization.k8s.io","kind":"ClusterRole","name":"role-1"},"subjects":[{"kind":"ServiceAccount","namespace":"ns-2","name":"sa-1"}]}`,
+			groupResource: kuberesource.ClusterRoleBindings,
+			expected: []ResourceIdentifier{
+				{GroupResource: kuberesource.ServiceAccounts, Namespace: "ns-2", Name: "sa-1"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refs, err := referencedItems(arktest.UnstructuredOrDie(test.obj), test.groupResource)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, refs)
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package backup
 
 import (
+	"time"
+
+	corev1api "k8s.io/api/core/v1"
+
 	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/itemhash"
 	"github.com/heptio/ark/pkg/util/collections"
 	"github.com/heptio/ark/pkg/volume"
 )
@@ -17,6 +22,22 @@ type Request struct {
 	ResourceIncludesExcludes  *collections.IncludesExcludes
 	ResourceHooks             []resourceHook
 	ResolvedActions           []resolvedAction
+	PageSize                  int
+	ResourceTimeouts          map[string]time.Duration
+
+	// ItemHasher computes the content hashes of items that are recorded in the
+	// backup's archive index. If it's nil, hashes aren't recorded.
+	ItemHasher itemhash.Hasher
+
+	// observers are notified of the backup's progress.
+	observers observers
+
+	// DryRun collects the backup's items without running its hooks, taking
+	// volume snapshots, or backing up pod volumes with restic. The volumes
+	// whose data would be backed up are recorded in EstimatedVolumes instead.
+	DryRun           bool
+	EstimatedVolumes []*corev1api.PersistentVolume
 
-	VolumeSnapshots []*volume.Snapshot
+	VolumeSnapshots    []*volume.Snapshot
+	PodVolumeSnapshots []*volume.PodVolumeSnapshot
 }
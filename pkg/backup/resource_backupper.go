@@ -17,8 +17,11 @@ limitations under the License.
 package backup
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -32,6 +35,7 @@ import (
 	"github.com/heptio/ark/pkg/podexec"
 	"github.com/heptio/ark/pkg/restic"
 	"github.com/heptio/ark/pkg/util/collections"
+	arksync "github.com/heptio/ark/pkg/util/sync"
 )
 
 type resourceBackupperFactory interface {
@@ -83,7 +87,7 @@ func (f *defaultResourceBackupperFactory) newResourceBackupper(
 }
 
 type resourceBackupper interface {
-	backupResource(group *metav1.APIResourceList, resource metav1.APIResource) error
+	backupResource(ctx context.Context, group *metav1.APIResourceList, resource metav1.APIResource) error
 }
 
 type defaultResourceBackupper struct {
@@ -103,6 +107,7 @@ type defaultResourceBackupper struct {
 
 // backupResource backs up all the objects for a given group-version-resource.
 func (rb *defaultResourceBackupper) backupResource(
+	ctx context.Context,
 	group *metav1.APIResourceList,
 	resource metav1.APIResource,
 ) error {
@@ -160,6 +165,26 @@ func (rb *defaultResourceBackupper) backupResource(
 		cohabitator.seen = true
 	}
 
+	// stopErr returns the error to record when ctx is done, which is either because
+	// the backup was stopped or because the resource's timeout expired.
+	backupCtx := ctx
+	stopErr := func() error {
+		return errors.WithStack(ctx.Err())
+	}
+	if timeout, ok := rb.backupRequest.ResourceTimeouts[grString]; ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		stopErr = func() error {
+			if backupCtx.Err() != nil {
+				return errors.WithStack(backupCtx.Err())
+			}
+			log.Errorf("Timed out backing up resource after %s", timeout)
+			return errors.Errorf("timed out backing up resource %s after %s", grString, timeout)
+		}
+	}
+
 	itemBackupper := rb.itemBackupperFactory.newItemBackupper(
 		rb.backupRequest,
 		rb.backedUpItems,
@@ -172,6 +197,12 @@ func (rb *defaultResourceBackupper) backupResource(
 		rb.blockStoreGetter,
 	)
 
+	selectors, err := itemSelectors(rb.backupRequest.Backup)
+	if err != nil {
+		// This should never happen...
+		return err
+	}
+
 	namespacesToList := getNamespacesToList(rb.backupRequest.NamespaceIncludesExcludes)
 
 	// Check if we're backing up namespaces, and only certain ones
@@ -181,25 +212,24 @@ func (rb *defaultResourceBackupper) backupResource(
 			return err
 		}
 
-		var labelSelector labels.Selector
-		if rb.backupRequest.Spec.LabelSelector != nil {
-			labelSelector, err = metav1.LabelSelectorAsSelector(rb.backupRequest.Spec.LabelSelector)
-			if err != nil {
-				// This should never happen...
-				return errors.Wrap(err, "invalid label selector")
+		for _, ns := range namespacesToList {
+			if ctx.Err() != nil {
+				errs = append(errs, stopErr())
+				return kuberrs.NewAggregate(errs)
 			}
-		}
 
-		for _, ns := range namespacesToList {
 			log.WithField("namespace", ns).Info("Getting namespace")
 			unstructured, err := resourceClient.Get(ns, metav1.GetOptions{})
+			if apierrors.IsForbidden(err) && rb.backupRequest.Spec.ServiceAccountName != "" {
+				log.WithField("namespace", ns).Infof("Skipping namespace because service account %s is not allowed to get it", rb.backupRequest.Spec.ServiceAccountName)
+				continue
+			}
 			if err != nil {
 				errs = append(errs, errors.Wrap(err, "error getting namespace"))
 				continue
 			}
 
-			labels := labels.Set(unstructured.GetLabels())
-			if labelSelector != nil && !labelSelector.Matches(labels) {
+			if !matchesAny(selectors, labels.Set(unstructured.GetLabels())) {
 				log.WithField("name", unstructured.GetName()).Info("skipping item because it does not match the backup's label selector")
 				continue
 			}
@@ -223,25 +253,54 @@ func (rb *defaultResourceBackupper) backupResource(
 			return err
 		}
 
-		var labelSelector string
-		if selector := rb.backupRequest.Spec.LabelSelector; selector != nil {
-			labelSelector = metav1.FormatLabelSelector(selector)
-		}
+		// the records of the backup's Helm releases are listed separately because
+		// they don't have the release labels
+		recordSelectors := releaseRecordSelectors(rb.backupRequest.Backup, gr, namespace)
 
 		log.WithField("namespace", namespace).Info("Listing items")
-		unstructuredList, err := resourceClient.List(metav1.ListOptions{LabelSelector: labelSelector})
+		var items, records []runtime.Object
+		err = arksync.RunWithContext(ctx, func() error {
+			var err error
+			if items, err = rb.listItemsMatchingAny(resourceClient, selectors); err != nil {
+				return err
+			}
+			records, err = rb.listItemsMatchingAny(resourceClient, recordSelectors)
+			return err
+		})
+		if ctx.Err() != nil {
+			errs = append(errs, stopErr())
+			return kuberrs.NewAggregate(errs)
+		}
+		if apierrors.IsForbidden(errors.Cause(err)) && rb.backupRequest.Spec.ServiceAccountName != "" {
+			// the backup's service account isn't allowed to list this resource, so
+			// it's excluded from the backup
+			log.WithField("namespace", namespace).Infof("Skipping resource because service account %s is not allowed to list it", rb.backupRequest.Spec.ServiceAccountName)
+			continue
+		}
 		if err != nil {
-			return errors.WithStack(err)
+			return err
 		}
 
 		// do the backup
-		items, err := meta.ExtractList(unstructuredList)
+
+		// additional items are backed up whether or not they match the selectors
+		additionalItems, err := rb.getAdditionalItems(log.WithField("namespace", namespace), resourceClient, gr, namespace, items)
 		if err != nil {
-			return errors.WithStack(err)
+			errs = append(errs, err)
 		}
+		items = append(items, additionalItems...)
 
 		log.WithField("namespace", namespace).Infof("Retrieved %d items", len(items))
-		for _, item := range items {
+		if len(records) > 0 {
+			log.WithField("namespace", namespace).Infof("Retrieved %d Helm release records", len(records))
+		}
+
+		for i, item := range append(items, records...) {
+			if ctx.Err() != nil {
+				errs = append(errs, stopErr())
+				return kuberrs.NewAggregate(errs)
+			}
+
 			unstructured, ok := item.(runtime.Unstructured)
 			if !ok {
 				errs = append(errs, errors.Errorf("unexpected type %T", item))
@@ -261,6 +320,11 @@ func (rb *defaultResourceBackupper) backupResource(
 
 			if err := itemBackupper.backupItem(log, unstructured, gr); err != nil {
 				errs = append(errs, err)
+				continue
+			}
+
+			if i >= len(items) {
+				recordHelmRelease(rb.backupRequest.Backup, metadata)
 			}
 		}
 	}
@@ -268,6 +332,115 @@ func (rb *defaultResourceBackupper) backupResource(
 	return kuberrs.NewAggregate(errs)
 }
 
+// getAdditionalItems gets the backup's additional items of groupResource in namespace
+// that aren't in listed.
+func (rb *defaultResourceBackupper) getAdditionalItems(log logrus.FieldLogger, resourceClient client.Dynamic, groupResource schema.GroupResource, namespace string, listed []runtime.Object) ([]runtime.Object, error) {
+	names := additionalItemNames(rb.backupRequest.Backup, groupResource, namespace)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	listedNames := make(map[string]bool)
+	for _, item := range listed {
+		if metadata, err := meta.Accessor(item); err == nil {
+			listedNames[metadata.GetName()] = true
+		}
+	}
+
+	var items []runtime.Object
+	for _, name := range names {
+		if listedNames[name] {
+			continue
+		}
+
+		item, err := resourceClient.Get(name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			log.WithField("name", name).Warn("Not backing up additional item because it doesn't exist")
+			continue
+		}
+		if err != nil {
+			return items, errors.Wrapf(err, "error getting additional item %s", name)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// matchesAny returns true if any of selectors matches set.
+func matchesAny(selectors []labels.Selector, set labels.Set) bool {
+	for _, selector := range selectors {
+		if selector.Matches(set) {
+			return true
+		}
+	}
+	return false
+}
+
+// listItemsMatchingAny lists the items matching any of selectors using resourceClient.
+// Items that match more than one selector are only returned once.
+func (rb *defaultResourceBackupper) listItemsMatchingAny(resourceClient client.Dynamic, selectors []labels.Selector) ([]runtime.Object, error) {
+	if len(selectors) == 1 {
+		return rb.listItems(resourceClient, selectors[0].String())
+	}
+
+	var items []runtime.Object
+	seen := make(map[string]bool)
+	for _, selector := range selectors {
+		list, err := rb.listItems(resourceClient, selector.String())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list {
+			metadata, err := meta.Accessor(item)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			if key := metadata.GetNamespace() + "/" + metadata.GetName(); !seen[key] {
+				seen[key] = true
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// listItems lists the items matching labelSelector using resourceClient. If the backup
+// request has a page size, the items are listed in chunks of that size rather than all
+// in a single request.
+func (rb *defaultResourceBackupper) listItems(resourceClient client.Dynamic, labelSelector string) ([]runtime.Object, error) {
+	options := metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         int64(rb.backupRequest.PageSize),
+	}
+
+	var items []runtime.Object
+	for {
+		unstructuredList, err := resourceClient.List(options)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		page, err := meta.ExtractList(unstructuredList)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		items = append(items, page...)
+
+		listMeta, err := meta.ListAccessor(unstructuredList)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if listMeta.GetContinue() == "" {
+			return items, nil
+		}
+		options.Continue = listMeta.GetContinue()
+	}
+}
+
 // getNamespacesToList examines ie and resolves the includes and excludes to a full list of
 // namespaces to list. If ie is nil or it includes *, the result is just "" (list across all
 // namespaces). Otherwise, the result is a list of every included namespace minus all excluded ones.
@@ -17,13 +17,16 @@ limitations under the License.
 package backup
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/heptio/ark/pkg/apis/ark/v1"
@@ -325,7 +328,7 @@ func TestBackupResource(t *testing.T) {
 				}
 			}
 
-			err := rb.backupResource(test.apiGroup, test.apiResource)
+			err := rb.backupResource(context.Background(), test.apiGroup, test.apiResource)
 			require.NoError(t, err)
 		})
 	}
@@ -460,11 +463,11 @@ func TestBackupResourceCohabitation(t *testing.T) {
 			client.On("List", metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(req.Backup.Spec.LabelSelector)}).Return(&unstructured.UnstructuredList{}, nil)
 
 			// STEP 2: do the backup
-			err := rb.backupResource(test.apiGroup1, test.apiResource)
+			err := rb.backupResource(context.Background(), test.apiGroup1, test.apiResource)
 			require.NoError(t, err)
 
 			// STEP 3: try to back up the cohabitating resource
-			err = rb.backupResource(test.apiGroup2, test.apiResource)
+			err = rb.backupResource(context.Background(), test.apiGroup2, test.apiResource)
 			require.NoError(t, err)
 		})
 	}
@@ -544,13 +547,67 @@ func TestBackupResourceOnlyIncludesSpecifiedNamespaces(t *testing.T) {
 	itemHookHandler.On("handleHooks", mock.Anything, schema.GroupResource{Group: "", Resource: "namespaces"}, ns1, req.ResourceHooks, hookPhasePre).Return(nil)
 	itemHookHandler.On("handleHooks", mock.Anything, schema.GroupResource{Group: "", Resource: "namespaces"}, ns1, req.ResourceHooks, hookPhasePost).Return(nil)
 
-	err := rb.backupResource(v1Group, namespacesResource)
+	err := rb.backupResource(context.Background(), v1Group, namespacesResource)
 	require.NoError(t, err)
 
 	require.Len(t, tarWriter.headers, 1)
 	assert.Equal(t, "resources/namespaces/cluster/ns-1.json", tarWriter.headers[0].Name)
 }
 
+func TestBackupResourceTimeout(t *testing.T) {
+	req := &Request{
+		Backup:                    &v1.Backup{},
+		NamespaceIncludesExcludes: collections.NewIncludesExcludes().Includes("*"),
+		ResourceIncludesExcludes:  collections.NewIncludesExcludes().Includes("*"),
+		ResourceTimeouts:          map[string]time.Duration{"pods": 10 * time.Millisecond},
+	}
+
+	dynamicFactory := &arktest.FakeDynamicFactory{}
+	defer dynamicFactory.AssertExpectations(t)
+
+	rb := (&defaultResourceBackupperFactory{}).newResourceBackupper(
+		arktest.NewLogger(),
+		req,
+		dynamicFactory,
+		arktest.NewFakeDiscoveryHelper(true, nil),
+		map[itemKey]struct{}{},
+		map[string]*cohabitatingResource{},
+		nil,
+		&fakeTarWriter{},
+		nil, // restic backupper
+		newPVCSnapshotTracker(),
+		nil,
+	).(*defaultResourceBackupper)
+
+	itemBackupperFactory := &mockItemBackupperFactory{}
+	defer itemBackupperFactory.AssertExpectations(t)
+	rb.itemBackupperFactory = itemBackupperFactory
+
+	itemBackupperFactory.On("newItemBackupper",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(&mockItemBackupper{})
+
+	// the list call hangs until the test is done
+	unblock := make(chan time.Time)
+	defer close(unblock)
+
+	client := &arktest.FakeDynamicClient{}
+	dynamicFactory.On("ClientForGroupVersionResource", schema.GroupVersion{Version: "v1"}, podsResource, "").Return(client, nil)
+	client.On("List", metav1.ListOptions{}).WaitUntil(unblock).Return(&unstructured.UnstructuredList{}, nil)
+
+	err := rb.backupResource(context.Background(), v1Group, podsResource)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out backing up resource pods after 10ms")
+}
+
 func TestBackupResourceListAllNamespacesExcludesCorrectly(t *testing.T) {
 	req := &Request{
 		Backup: &v1.Backup{
@@ -631,8 +688,36 @@ func TestBackupResourceListAllNamespacesExcludesCorrectly(t *testing.T) {
 
 	itemBackupper.On("backupItem", mock.AnythingOfType("*logrus.Entry"), ns2, kuberesource.Namespaces).Return(nil)
 
-	err := rb.backupResource(v1Group, namespacesResource)
+	err := rb.backupResource(context.Background(), v1Group, namespacesResource)
+	require.NoError(t, err)
+}
+
+func TestListItemsUsesPageSize(t *testing.T) {
+	rb := &defaultResourceBackupper{
+		backupRequest: &Request{PageSize: 2},
+	}
+
+	client := &arktest.FakeDynamicClient{}
+	defer client.AssertExpectations(t)
+
+	pod1 := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"Pod","metadata":{"namespace":"ns","name":"pod-1"}}`)
+	pod2 := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"Pod","metadata":{"namespace":"ns","name":"pod-2"}}`)
+	pod3 := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"Pod","metadata":{"namespace":"ns","name":"pod-3"}}`)
+
+	firstPage := &unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{*pod1, *pod2},
+	}
+	firstPage.SetContinue("page-2")
+	secondPage := &unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{*pod3},
+	}
+
+	client.On("List", metav1.ListOptions{LabelSelector: "a=b", Limit: 2}).Return(firstPage, nil)
+	client.On("List", metav1.ListOptions{LabelSelector: "a=b", Limit: 2, Continue: "page-2"}).Return(secondPage, nil)
+
+	items, err := rb.listItems(client, "a=b")
 	require.NoError(t, err)
+	assert.Equal(t, []runtime.Object{pod1, pod2, pod3}, items)
 }
 
 type mockItemBackupperFactory struct {
@@ -0,0 +1,86 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"archive/tar"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
+)
+
+// ArchiveWriterFactory returns the archive.Writer that a backup's items are written
+// to, given the backup's archive format and the writer for the backup file.
+// archive.NewWriter is the default; other factories can wrap the writers it returns,
+// for example to encrypt or split the archive.
+type ArchiveWriterFactory func(format api.ArchiveFormat, w io.Writer) (archive.Writer, error)
+
+// itemWriter implements tarWriter by writing items as files in an archive.Writer.
+type itemWriter struct {
+	archive.Writer
+}
+
+// WriteRaw writes size bytes read from r to the archive as the file for the item
+// identified by groupResource, namespace, and name. The bytes are streamed into
+// the archive rather than buffered.
+func (w *itemWriter) WriteRaw(groupResource, namespace, name string, r io.Reader, size int64) error {
+	return writeRaw(w, groupResource, namespace, name, r, size)
+}
+
+// RecordItemHash records hash as the item hash of the item's file if the archive
+// has an index that can store it.
+func (w *itemWriter) RecordItemHash(groupResource, namespace, name, hash string) {
+	if recorder, ok := w.Writer.(archive.ItemHashRecorder); ok {
+		recorder.RecordItemHash(itemFilePath(groupResource, namespace, name), hash)
+	}
+}
+
+// writeRaw writes the header for an item's file to tw, followed by size bytes
+// read from r.
+func writeRaw(tw archive.Writer, groupResource, namespace, name string, r io.Reader, size int64) error {
+	hdr := &tar.Header{
+		Name:     itemFilePath(groupResource, namespace, name),
+		Size:     size,
+		Typeflag: tar.TypeReg,
+		Mode:     0755,
+		ModTime:  time.Now(),
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := io.CopyN(tw, r, size); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// itemFilePath returns the path within a backup archive of the file for the item
+// identified by groupResource, namespace, and name.
+func itemFilePath(groupResource, namespace, name string) string {
+	if namespace != "" {
+		return filepath.Join(api.ResourcesDir, groupResource, api.NamespaceScopedDir, namespace, name+".json")
+	}
+	return filepath.Join(api.ResourcesDir, groupResource, api.ClusterScopedDir, name+".json")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
+)
+
+func TestItemWriterWriteRaw(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        v1.ArchiveFormat
+		expectedFiles []string
+	}{
+		{
+			name:   "tarballs start with a version file and end with an index",
+			format: v1.ArchiveFormatTarGzip,
+			expectedFiles: []string{
+				"metadata/version",
+				"resources/pods/namespaces/ns-1/pod-1.json",
+				"resources/persistentvolumes/cluster/pv-1.json",
+				"index.json",
+			},
+		},
+		{
+			name:   "zip files start with a version file and have no index",
+			format: v1.ArchiveFormatZip,
+			expectedFiles: []string{
+				"metadata/version",
+				"resources/pods/namespaces/ns-1/pod-1.json",
+				"resources/persistentvolumes/cluster/pv-1.json",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			archiveWriter, err := archive.NewWriter(test.format, buf)
+			require.NoError(t, err)
+			w := &itemWriter{Writer: archiveWriter}
+
+			require.NoError(t, w.WriteRaw("pods", "ns-1", "pod-1", strings.NewReader(`{"kind":"Pod"}`), 14))
+			require.NoError(t, w.WriteRaw("persistentvolumes", "", "pv-1", strings.NewReader(`{"kind":"PersistentVolume"}`), 27))
+			require.NoError(t, w.Close())
+
+			r, err := archive.NewReader(buf)
+			require.NoError(t, err)
+
+			var files []string
+			for {
+				hdr, err := r.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				assert.Equal(t, byte(tar.TypeReg), hdr.Typeflag)
+
+				data, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, hdr.Size, int64(len(data)))
+
+				files = append(files, hdr.Name)
+			}
+
+			assert.Equal(t, test.expectedFiles, files)
+		})
+	}
+}
+
+func TestItemWriterWriteRawShortReader(t *testing.T) {
+	w := &itemWriter{Writer: archive.NewGzipTarWriter(new(bytes.Buffer))}
+
+	err := w.WriteRaw("pods", "ns-1", "pod-1", strings.NewReader(`{}`), 10)
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildinfo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semanticVersion is the major, minor and patch numbers of an Ark version.
+type semanticVersion struct {
+	major, minor, patch int
+}
+
+// parseVersion parses a version like v0.10.1 or v1.0.0-beta.1, ignoring any
+// pre-release or build metadata.
+func parseVersion(version string) (semanticVersion, error) {
+	trimmed := strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) != 3 {
+		return semanticVersion{}, fmt.Errorf("%q is not a semantic version", version)
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semanticVersion{}, fmt.Errorf("%q is not a semantic version", version)
+		}
+		numbers[i] = n
+	}
+
+	return semanticVersion{major: numbers[0], minor: numbers[1], patch: numbers[2]}, nil
+}
+
+// compatible returns whether v and other have the same major version and,
+// before 1.0, when minor versions may break compatibility, the same minor
+// version.
+func (v semanticVersion) compatible(other semanticVersion) bool {
+	if v.major != other.major {
+		return false
+	}
+	return v.major > 0 || v.minor == other.minor
+}
+
+// newerThan returns whether v is a later version than other.
+func (v semanticVersion) newerThan(other semanticVersion) bool {
+	if v.major != other.major {
+		return v.major > other.major
+	}
+	if v.minor != other.minor {
+		return v.minor > other.minor
+	}
+	return v.patch > other.patch
+}
+
+// Compatible returns whether Ark versions a and b can be used together:
+// their major versions are the same and, before 1.0, their minor versions
+// too. It returns an error if either isn't a semantic version, such as the
+// version of a development build.
+func Compatible(a, b string) (bool, error) {
+	va, err := parseVersion(a)
+	if err != nil {
+		return false, err
+	}
+	vb, err := parseVersion(b)
+	if err != nil {
+		return false, err
+	}
+
+	return va.compatible(vb), nil
+}
+
+// NewerAndIncompatible returns whether Ark version a is both later than and
+// incompatible with version b, for example when a backup written by version a
+// is restored by version b. It returns false if either isn't a semantic
+// version, since nothing is known about their compatibility.
+func NewerAndIncompatible(a, b string) bool {
+	va, err := parseVersion(a)
+	if err != nil {
+		return false
+	}
+	vb, err := parseVersion(b)
+	if err != nil {
+		return false
+	}
+
+	return va.newerThan(vb) && !va.compatible(vb)
+}
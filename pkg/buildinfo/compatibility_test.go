@@ -0,0 +1,124 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name:     "same version is compatible",
+			a:        "v0.10.1",
+			b:        "v0.10.1",
+			expected: true,
+		},
+		{
+			name:     "different patch version before 1.0 is compatible",
+			a:        "v0.10.0",
+			b:        "v0.10.2-beta.1",
+			expected: true,
+		},
+		{
+			name: "different minor version before 1.0 is incompatible",
+			a:    "v0.9.11",
+			b:    "v0.10.0",
+		},
+		{
+			name:     "different minor version after 1.0 is compatible",
+			a:        "v1.1.0",
+			b:        "v1.3.0+build.5",
+			expected: true,
+		},
+		{
+			name: "different major version is incompatible",
+			a:    "v1.0.0",
+			b:    "v2.0.0",
+		},
+		{
+			name:        "development build returns an error",
+			a:           "v0.10.0",
+			b:           "main",
+			expectedErr: true,
+		},
+		{
+			name:        "empty version returns an error",
+			a:           "",
+			b:           "v0.10.0",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compatible, err := Compatible(test.a, test.b)
+
+			assert.Equal(t, test.expectedErr, err != nil)
+			assert.Equal(t, test.expected, compatible)
+		})
+	}
+}
+
+func TestNewerAndIncompatible(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "newer minor version before 1.0",
+			a:        "v0.11.0",
+			b:        "v0.10.1",
+			expected: true,
+		},
+		{
+			name: "older minor version before 1.0",
+			a:    "v0.9.0",
+			b:    "v0.10.1",
+		},
+		{
+			name: "newer patch version",
+			a:    "v0.10.2",
+			b:    "v0.10.1",
+		},
+		{
+			name:     "newer major version",
+			a:        "v2.0.0",
+			b:        "v1.4.0",
+			expected: true,
+		},
+		{
+			name: "development build",
+			a:    "v0.11.0",
+			b:    "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewerAndIncompatible(test.a, test.b))
+		})
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package client
 
 import (
+	"fmt"
+
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 )
 
 // DynamicFactory contains methods for retrieving dynamic clients for GroupVersionResources and
@@ -44,6 +48,34 @@ func NewDynamicFactory(dynamicClient dynamic.Interface) DynamicFactory {
 	return &dynamicFactory{dynamicClient: dynamicClient}
 }
 
+// NewServiceAccountDynamicFactory returns a new dynamic factory whose clients impersonate
+// the given service account, so that they're limited to its permissions.
+func NewServiceAccountDynamicFactory(config *rest.Config, namespace, name string) (DynamicFactory, error) {
+	return NewDynamicFactoryForConfig(ServiceAccountConfig(config, namespace, name))
+}
+
+// ServiceAccountConfig returns a copy of config that impersonates the given service
+// account.
+func ServiceAccountConfig(config *rest.Config, namespace, name string) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+	}
+
+	return config
+}
+
+// NewDynamicFactoryForConfig returns a new dynamic factory whose clients are created
+// from the given config.
+func NewDynamicFactoryForConfig(config *rest.Config) (DynamicFactory, error) {
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return NewDynamicFactory(dynamicClient), nil
+}
+
 func (f *dynamicFactory) ClientForGroupVersionResource(gv schema.GroupVersion, resource metav1.APIResource, namespace string) (Dynamic, error) {
 	return &dynamicResourceClient{
 		resourceClient: f.dynamicClient.Resource(gv.WithResource(resource.Name)).Namespace(namespace),
@@ -81,6 +113,12 @@ type Patcher interface {
 	Patch(name string, data []byte) (*unstructured.Unstructured, error)
 }
 
+// Deleter deletes an object.
+type Deleter interface {
+	// Delete deletes the named object.
+	Delete(name string, opts *metav1.DeleteOptions) error
+}
+
 // Dynamic contains client methods that Ark needs for backing up and restoring resources.
 type Dynamic interface {
 	Creator
@@ -88,6 +126,7 @@ type Dynamic interface {
 	Watcher
 	Getter
 	Patcher
+	Deleter
 }
 
 // dynamicResourceClient implements Dynamic.
@@ -116,3 +155,7 @@ func (d *dynamicResourceClient) Get(name string, opts metav1.GetOptions) (*unstr
 func (d *dynamicResourceClient) Patch(name string, data []byte) (*unstructured.Unstructured, error) {
 	return d.resourceClient.Patch(name, types.MergePatchType, data)
 }
+
+func (d *dynamicResourceClient) Delete(name string, opts *metav1.DeleteOptions) error {
+	return d.resourceClient.Delete(name, opts)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"k8s.io/client-go/rest"
+)
+
+// LowerRateLimits returns config with its QPS and burst lowered to qps and burst, where
+// they're lower than config's. Zero values of qps and burst are ignored. config is only
+// copied, rather than returned as is, if a limit was lowered, in which case the returned
+// bool is true.
+func LowerRateLimits(config *rest.Config, qps, burst int) (*rest.Config, bool) {
+	newQPS, newBurst := config.QPS, config.Burst
+	if newQPS == 0 {
+		newQPS = rest.DefaultQPS
+	}
+	if newBurst == 0 {
+		newBurst = rest.DefaultBurst
+	}
+
+	var lowered bool
+	if qps > 0 && float32(qps) < newQPS {
+		newQPS = float32(qps)
+		lowered = true
+	}
+	if burst > 0 && burst < newBurst {
+		newBurst = burst
+		lowered = true
+	}
+
+	if !lowered {
+		return config, false
+	}
+
+	config = rest.CopyConfig(config)
+	config.QPS = newQPS
+	config.Burst = newBurst
+
+	return config, true
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+func TestLowerRateLimits(t *testing.T) {
+	tests := []struct {
+		name            string
+		configQPS       float32
+		configBurst     int
+		qps             int
+		burst           int
+		expectedQPS     float32
+		expectedBurst   int
+		expectedLowered bool
+	}{
+		{
+			name:        "no limits uses the config as is",
+			configQPS:   50,
+			configBurst: 100,
+		},
+		{
+			name:            "lower limits are used",
+			configQPS:       50,
+			configBurst:     100,
+			qps:             10,
+			burst:           20,
+			expectedQPS:     10,
+			expectedBurst:   20,
+			expectedLowered: true,
+		},
+		{
+			name:        "higher limits are ignored",
+			configQPS:   50,
+			configBurst: 100,
+			qps:         500,
+			burst:       1000,
+		},
+		{
+			name:            "only the lower limit is used",
+			configQPS:       50,
+			configBurst:     100,
+			qps:             10,
+			burst:           1000,
+			expectedQPS:     10,
+			expectedBurst:   100,
+			expectedLowered: true,
+		},
+		{
+			name:            "limits are compared to the client defaults if the config's aren't set",
+			qps:             2,
+			burst:           20,
+			expectedQPS:     2,
+			expectedBurst:   10,
+			expectedLowered: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original := &rest.Config{Host: "server", QPS: test.configQPS, Burst: test.configBurst}
+
+			config, lowered := LowerRateLimits(original, test.qps, test.burst)
+			assert.Equal(t, test.expectedLowered, lowered)
+			if !lowered {
+				assert.True(t, config == original, "expected the original config to be returned")
+				return
+			}
+
+			assert.Equal(t, test.expectedQPS, config.QPS)
+			assert.Equal(t, test.expectedBurst, config.Burst)
+			assert.Equal(t, "server", config.Host)
+
+			// the original config must not be modified
+			assert.Equal(t, test.configQPS, original.QPS)
+			assert.Equal(t, test.configBurst, original.Burst)
+		})
+	}
+}
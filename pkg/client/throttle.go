@@ -0,0 +1,177 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// AdaptiveThrottle delays requests once the API server starts rejecting them because
+// of throttling (HTTP 429), doubling the delay each time a request is rejected and
+// halving it each time one succeeds. It's safe for concurrent use.
+type AdaptiveThrottle struct {
+	lock     sync.Mutex
+	delay    time.Duration
+	minDelay time.Duration
+	maxDelay time.Duration
+	sleep    func(time.Duration)
+}
+
+// NewAdaptiveThrottle returns an AdaptiveThrottle whose delay, when there is one, is
+// between minDelay and maxDelay.
+func NewAdaptiveThrottle(minDelay, maxDelay time.Duration) *AdaptiveThrottle {
+	return &AdaptiveThrottle{
+		minDelay: minDelay,
+		maxDelay: maxDelay,
+		sleep:    time.Sleep,
+	}
+}
+
+// Wait blocks for the throttle's current delay.
+func (t *AdaptiveThrottle) Wait() {
+	t.lock.Lock()
+	delay := t.delay
+	t.lock.Unlock()
+
+	if delay > 0 {
+		t.sleep(delay)
+	}
+}
+
+// Observe adjusts the throttle's delay based on the result of a request.
+func (t *AdaptiveThrottle) Observe(err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		t.delay *= 2
+		if t.delay < t.minDelay {
+			t.delay = t.minDelay
+		}
+
+		// respect the server's Retry-After, if it's longer
+		if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+			if suggested := time.Duration(seconds) * time.Second; suggested > t.delay {
+				t.delay = suggested
+			}
+		}
+
+		if t.delay > t.maxDelay {
+			t.delay = t.maxDelay
+		}
+	case err == nil:
+		t.delay /= 2
+		if t.delay < t.minDelay {
+			t.delay = 0
+		}
+	}
+}
+
+// Delay returns the throttle's current delay.
+func (t *AdaptiveThrottle) Delay() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.delay
+}
+
+// throttledDynamicFactory implements DynamicFactory by wrapping another DynamicFactory's
+// clients so that they share an AdaptiveThrottle.
+type throttledDynamicFactory struct {
+	factory  DynamicFactory
+	throttle *AdaptiveThrottle
+}
+
+// NewThrottledDynamicFactory returns a DynamicFactory whose clients are factory's clients,
+// with every request delayed by throttle and reported to it.
+func NewThrottledDynamicFactory(factory DynamicFactory, throttle *AdaptiveThrottle) DynamicFactory {
+	return &throttledDynamicFactory{
+		factory:  factory,
+		throttle: throttle,
+	}
+}
+
+func (f *throttledDynamicFactory) ClientForGroupVersionResource(gv schema.GroupVersion, resource metav1.APIResource, namespace string) (Dynamic, error) {
+	client, err := f.factory.ClientForGroupVersionResource(gv, resource, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &throttledDynamicClient{
+		client:   client,
+		throttle: f.throttle,
+	}, nil
+}
+
+// throttledDynamicClient implements Dynamic.
+type throttledDynamicClient struct {
+	client   Dynamic
+	throttle *AdaptiveThrottle
+}
+
+var _ Dynamic = &throttledDynamicClient{}
+
+func (d *throttledDynamicClient) Create(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	d.throttle.Wait()
+	res, err := d.client.Create(obj)
+	d.throttle.Observe(err)
+	return res, err
+}
+
+func (d *throttledDynamicClient) List(options metav1.ListOptions) (runtime.Object, error) {
+	d.throttle.Wait()
+	res, err := d.client.List(options)
+	d.throttle.Observe(err)
+	return res, err
+}
+
+func (d *throttledDynamicClient) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	d.throttle.Wait()
+	res, err := d.client.Watch(options)
+	d.throttle.Observe(err)
+	return res, err
+}
+
+func (d *throttledDynamicClient) Get(name string, opts metav1.GetOptions) (*unstructured.Unstructured, error) {
+	d.throttle.Wait()
+	res, err := d.client.Get(name, opts)
+	d.throttle.Observe(err)
+	return res, err
+}
+
+func (d *throttledDynamicClient) Patch(name string, data []byte) (*unstructured.Unstructured, error) {
+	d.throttle.Wait()
+	res, err := d.client.Patch(name, data)
+	d.throttle.Observe(err)
+	return res, err
+}
+
+func (d *throttledDynamicClient) Delete(name string, opts *metav1.DeleteOptions) error {
+	d.throttle.Wait()
+	err := d.client.Delete(name, opts)
+	d.throttle.Observe(err)
+	return err
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestAdaptiveThrottle(t *testing.T) {
+	var (
+		throttled = apierrors.NewTooManyRequests("throttled", 0)
+		retryIn5s = apierrors.NewTooManyRequests("throttled", 5)
+		other     = errors.New("other")
+	)
+
+	tests := []struct {
+		name          string
+		results       []error
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "no delay before any throttling",
+			results:       []error{nil, nil},
+			expectedDelay: 0,
+		},
+		{
+			name:          "first throttled request sets the minimum delay",
+			results:       []error{throttled},
+			expectedDelay: 100 * time.Millisecond,
+		},
+		{
+			name:          "each throttled request doubles the delay",
+			results:       []error{throttled, throttled, throttled},
+			expectedDelay: 400 * time.Millisecond,
+		},
+		{
+			name:          "delay is capped at the maximum",
+			results:       []error{throttled, throttled, throttled, throttled, throttled, throttled, throttled},
+			expectedDelay: time.Second,
+		},
+		{
+			name:          "server's suggested delay is used if it's longer, up to the maximum",
+			results:       []error{retryIn5s},
+			expectedDelay: time.Second,
+		},
+		{
+			name:          "each successful request halves the delay",
+			results:       []error{throttled, throttled, throttled, nil},
+			expectedDelay: 200 * time.Millisecond,
+		},
+		{
+			name:          "delay is removed once it falls below the minimum",
+			results:       []error{throttled, nil},
+			expectedDelay: 0,
+		},
+		{
+			name:          "other errors don't change the delay",
+			results:       []error{throttled, other},
+			expectedDelay: 100 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			throttle := NewAdaptiveThrottle(100*time.Millisecond, time.Second)
+
+			for _, res := range test.results {
+				throttle.Observe(res)
+			}
+
+			assert.Equal(t, test.expectedDelay, throttle.Delay())
+
+			var slept time.Duration
+			throttle.sleep = func(d time.Duration) { slept = d }
+			throttle.Wait()
+			assert.Equal(t, test.expectedDelay, slept)
+		})
+	}
+}
@@ -252,6 +252,73 @@ func (b *blockStore) DeleteSnapshot(snapshotID string) error {
 	return nil
 }
 
+func (b *blockStore) ListSnapshots(tags map[string]string) ([]cloudprovider.SnapshotInfo, error) {
+	req := &ec2.DescribeSnapshotsInput{
+		OwnerIds: []*string{aws.String("self")},
+	}
+
+	for k, v := range tags {
+		req.Filters = append(req.Filters, &ec2.Filter{
+			Name:   aws.String("tag:" + k),
+			Values: []*string{aws.String(v)},
+		})
+	}
+
+	var snapshots []cloudprovider.SnapshotInfo
+	err := b.ec2.DescribeSnapshotsPages(req, func(res *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+		for _, snapshot := range res.Snapshots {
+			snapshots = append(snapshots, snapshotInfo(snapshot))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return snapshots, nil
+}
+
+func (b *blockStore) GetSnapshotInfo(snapshotID string) (*cloudprovider.SnapshotInfo, error) {
+	req := &ec2.DescribeSnapshotsInput{
+		SnapshotIds: []*string{&snapshotID},
+	}
+
+	res, err := b.ec2.DescribeSnapshots(req)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidSnapshot.NotFound" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(res.Snapshots) == 0 {
+		return nil, nil
+	}
+
+	info := snapshotInfo(res.Snapshots[0])
+	return &info, nil
+}
+
+func snapshotInfo(snapshot *ec2.Snapshot) cloudprovider.SnapshotInfo {
+	info := cloudprovider.SnapshotInfo{
+		ID:       aws.StringValue(snapshot.SnapshotId),
+		VolumeID: aws.StringValue(snapshot.VolumeId),
+		SizeGB:   aws.Int64Value(snapshot.VolumeSize),
+		Status:   aws.StringValue(snapshot.State),
+		Tags:     make(map[string]string, len(snapshot.Tags)),
+	}
+
+	if snapshot.StartTime != nil {
+		info.CreationTimestamp = *snapshot.StartTime
+	}
+
+	for _, tag := range snapshot.Tags {
+		info.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return info
+}
+
 var ebsVolumeIDRegex = regexp.MustCompile("vol-.*")
 
 func (b *blockStore) GetVolumeID(pv runtime.Unstructured) (string, error) {
@@ -18,6 +18,7 @@ package aws
 
 import (
 	"io"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -28,6 +29,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
 	"github.com/heptio/ark/pkg/cloudprovider"
 )
 
@@ -36,6 +39,9 @@ const (
 	kmsKeyIDKey         = "kmsKeyId"
 	s3ForcePathStyleKey = "s3ForcePathStyle"
 	bucketKey           = "bucket"
+
+	storageClassOption = "storageClass"
+	taggingOption      = "tagging"
 )
 
 type objectStore struct {
@@ -117,12 +123,30 @@ func (o *objectStore) Init(config map[string]string) error {
 }
 
 func (o *objectStore) PutObject(bucket, key string, body io.Reader) error {
+	return o.PutObjectWithOptions(bucket, key, body, nil)
+}
+
+// PutObjectWithOptions creates a new object like PutObject. The supported options
+// are "storageClass", the object's S3 storage class, and "tagging", the object's
+// tags encoded as URL query parameters (e.g. "tier=infrequent&app=ark").
+func (o *objectStore) PutObjectWithOptions(bucket, key string, body io.Reader, options map[string]string) error {
 	req := &s3manager.UploadInput{
 		Bucket: &bucket,
 		Key:    &key,
 		Body:   body,
 	}
 
+	for option, value := range options {
+		switch option {
+		case storageClassOption:
+			req.StorageClass = aws.String(value)
+		case taggingOption:
+			req.Tagging = aws.String(value)
+		default:
+			return errors.Errorf("unsupported object option %q", option)
+		}
+	}
+
 	// if kmsKeyID is not empty, enable "aws:kms" encryption
 	if o.kmsKeyID != "" {
 		req.ServerSideEncryption = aws.String("aws:kms")
@@ -201,6 +225,69 @@ func (o *objectStore) DeleteObject(bucket, key string) error {
 	return errors.Wrapf(err, "error deleting object %s", key)
 }
 
+// CopyObject copies an object using S3's server-side copy, so the object's data
+// is not transferred through Ark.
+func (o *objectStore) CopyObject(sourceBucket, sourceKey, destinationBucket, destinationKey string) error {
+	req := &s3.CopyObjectInput{
+		Bucket:     &destinationBucket,
+		Key:        &destinationKey,
+		CopySource: aws.String(url.PathEscape(sourceBucket + "/" + sourceKey)),
+	}
+
+	// if kmsKeyID is not empty, enable "aws:kms" encryption
+	if o.kmsKeyID != "" {
+		req.ServerSideEncryption = aws.String("aws:kms")
+		req.SSEKMSKeyId = &o.kmsKeyID
+	}
+
+	_, err := o.s3.CopyObject(req)
+
+	return errors.Wrapf(err, "error copying object %s to %s", sourceKey, destinationKey)
+}
+
+// DeleteObjectsWithPrefix deletes each page of listed keys (up to 1000) with a
+// single DeleteObjects request.
+func (o *objectStore) DeleteObjectsWithPrefix(bucket, prefix string) error {
+	req := &s3.ListObjectsV2Input{
+		Bucket: &bucket,
+		Prefix: &prefix,
+	}
+
+	var errs []error
+	err := o.s3.ListObjectsV2Pages(req, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		if len(page.Contents) == 0 {
+			return !lastPage
+		}
+
+		deleteReq := &s3.DeleteObjectsInput{
+			Bucket: &bucket,
+			Delete: &s3.Delete{
+				Quiet: aws.Bool(true),
+			},
+		}
+		for _, obj := range page.Contents {
+			deleteReq.Delete.Objects = append(deleteReq.Delete.Objects, &s3.ObjectIdentifier{Key: obj.Key})
+		}
+
+		res, err := o.s3.DeleteObjects(deleteReq)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error deleting objects with prefix %s", prefix))
+			return !lastPage
+		}
+
+		for _, deleteErr := range res.Errors {
+			errs = append(errs, errors.Errorf("error deleting object %s: %s", aws.StringValue(deleteErr.Key), aws.StringValue(deleteErr.Message)))
+		}
+
+		return !lastPage
+	})
+	if err != nil {
+		errs = append(errs, errors.WithStack(err))
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
 func (o *objectStore) CreateSignedURL(bucket, key string, ttl time.Duration) (string, error) {
 	req, _ := o.s3.GetObjectRequest(&s3.GetObjectInput{
 		Bucket: aws.String(bucket),
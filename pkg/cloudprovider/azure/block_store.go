@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"regexp"
 	"strings"
 	"time"
@@ -271,6 +272,108 @@ func (b *blockStore) DeleteSnapshot(snapshotID string) error {
 	return nil
 }
 
+func (b *blockStore) ListSnapshots(tags map[string]string) ([]cloudprovider.SnapshotInfo, error) {
+	// Azure does not allow slashes in tag keys, so match on the same
+	// keys that were applied by getSnapshotTags
+	wantTags := make(map[string]string, len(tags))
+	for k, v := range tags {
+		wantTags[strings.Replace(k, "/", "-", -1)] = v
+	}
+
+	res, err := b.snaps.ListByResourceGroup(b.snapsResourceGroup)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var snapshots []cloudprovider.SnapshotInfo
+	for {
+		if res.Value != nil {
+			for _, snapshot := range *res.Value {
+				info := b.snapshotInfo(snapshot)
+				if hasTags(info.Tags, wantTags) {
+					snapshots = append(snapshots, info)
+				}
+			}
+		}
+
+		if res.NextLink == nil || *res.NextLink == "" {
+			break
+		}
+
+		if res, err = b.snaps.ListByResourceGroupNextResults(res); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return snapshots, nil
+}
+
+func (b *blockStore) GetSnapshotInfo(snapshotID string) (*cloudprovider.SnapshotInfo, error) {
+	snapshotIdentifier, err := b.parseSnapshotName(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := b.snaps.Get(snapshotIdentifier.resourceGroup, snapshotIdentifier.name)
+	if azureErr, ok := err.(autorest.DetailedError); ok && azureErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	info := b.snapshotInfo(res)
+	return &info, nil
+}
+
+func (b *blockStore) snapshotInfo(snapshot disk.Snapshot) cloudprovider.SnapshotInfo {
+	info := cloudprovider.SnapshotInfo{
+		Tags: make(map[string]string),
+	}
+
+	if snapshot.ID != nil {
+		info.ID = *snapshot.ID
+	} else if snapshot.Name != nil {
+		info.ID = getComputeResourceName(b.subscription, b.snapsResourceGroup, snapshotsResource, *snapshot.Name)
+	}
+
+	if snapshot.Tags != nil {
+		for k, v := range *snapshot.Tags {
+			if v != nil {
+				info.Tags[k] = *v
+			}
+		}
+	}
+
+	if props := snapshot.Properties; props != nil {
+		if props.CreationData != nil && props.CreationData.SourceResourceID != nil {
+			info.VolumeID = path.Base(*props.CreationData.SourceResourceID)
+		}
+		if props.DiskSizeGB != nil {
+			info.SizeGB = int64(*props.DiskSizeGB)
+		}
+		if props.TimeCreated != nil {
+			info.CreationTimestamp = props.TimeCreated.Time
+		}
+		if props.ProvisioningState != nil {
+			info.Status = *props.ProvisioningState
+		}
+	}
+
+	return info
+}
+
+// hasTags returns true if all of the key-value pairs in want are present
+// in tags.
+func hasTags(tags, want map[string]string) bool {
+	for k, v := range want {
+		if val, ok := tags[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
 func getComputeResourceName(subscription, resourceGroup, resource, name string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/%s/%s", subscription, resourceGroup, resource, name)
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/heptio/ark/pkg/cloudprovider"
 )
@@ -206,6 +207,68 @@ func (o *objectStore) DeleteObject(bucket string, key string) error {
 	return errors.WithStack(blob.Delete(nil))
 }
 
+// CopyObject copies a blob using Azure's server-side copy, so the blob's data
+// is not transferred through Ark. The source and destination containers must
+// be in the configured storage account.
+func (o *objectStore) CopyObject(sourceBucket, sourceKey, destinationBucket, destinationKey string) error {
+	sourceContainer, err := getContainerReference(o.blobClient, sourceBucket)
+	if err != nil {
+		return err
+	}
+
+	sourceBlob, err := getBlobReference(sourceContainer, sourceKey)
+	if err != nil {
+		return err
+	}
+
+	destinationContainer, err := getContainerReference(o.blobClient, destinationBucket)
+	if err != nil {
+		return err
+	}
+
+	destinationBlob, err := getBlobReference(destinationContainer, destinationKey)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrapf(destinationBlob.Copy(sourceBlob.GetURL(), nil), "error copying object %s to %s", sourceKey, destinationKey)
+}
+
+// DeleteObjectsWithPrefix deletes each blob under the prefix, one page of
+// listed blobs at a time, since this version of the storage API does not
+// support batched deletes.
+func (o *objectStore) DeleteObjectsWithPrefix(bucket, prefix string) error {
+	container, err := getContainerReference(o.blobClient, bucket)
+	if err != nil {
+		return err
+	}
+
+	params := storage.ListBlobsParameters{
+		Prefix: prefix,
+	}
+
+	var errs []error
+	for {
+		res, err := container.ListBlobs(params)
+		if err != nil {
+			return kerrors.NewAggregate(append(errs, errors.WithStack(err)))
+		}
+
+		for _, blob := range res.Blobs {
+			if err := o.DeleteObject(bucket, blob.Name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if res.NextMarker == "" {
+			break
+		}
+		params.Marker = res.NextMarker
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
 func (o *objectStore) CreateSignedURL(bucket, key string, ttl time.Duration) (string, error) {
 	container, err := getContainerReference(o.blobClient, bucket)
 	if err != nil {
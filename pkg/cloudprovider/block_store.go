@@ -17,9 +17,32 @@ limitations under the License.
 package cloudprovider
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// SnapshotInfo describes a volume snapshot stored in a block store.
+type SnapshotInfo struct {
+	// ID is the cloud provider specific identifier for the snapshot.
+	ID string
+
+	// VolumeID is the identifier of the volume the snapshot was taken from.
+	VolumeID string
+
+	// SizeGB is the size of the source volume, in GB.
+	SizeGB int64
+
+	// CreationTimestamp is when the snapshot was started.
+	CreationTimestamp time.Time
+
+	// Status is the cloud provider specific state of the snapshot.
+	Status string
+
+	// Tags is the set of tags applied to the snapshot.
+	Tags map[string]string
+}
+
 // BlockStore exposes basic block-storage operations required
 // by Ark.
 type BlockStore interface {
@@ -49,4 +72,12 @@ type BlockStore interface {
 
 	// DeleteSnapshot deletes the specified volume snapshot.
 	DeleteSnapshot(snapshotID string) error
+
+	// ListSnapshots returns the snapshots in the block store that have all of the
+	// provided tags. If tags is empty, all snapshots are returned.
+	ListSnapshots(tags map[string]string) ([]SnapshotInfo, error)
+
+	// GetSnapshotInfo returns information about the specified volume snapshot. It
+	// returns nil (and no error) if the snapshot does not exist.
+	GetSnapshotInfo(snapshotID string) (*SnapshotInfo, error)
 }
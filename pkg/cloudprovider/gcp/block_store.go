@@ -17,10 +17,13 @@ limitations under the License.
 package gcp
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/satori/uuid"
@@ -205,6 +208,81 @@ func (b *blockStore) DeleteSnapshot(snapshotID string) error {
 	return nil
 }
 
+func (b *blockStore) ListSnapshots(tags map[string]string) ([]cloudprovider.SnapshotInfo, error) {
+	var snapshots []cloudprovider.SnapshotInfo
+
+	// snapshot tags are stored as a JSON doc in the description field, which can't
+	// be filtered on server-side, so list all snapshots and filter them here.
+	err := b.gce.Snapshots.List(b.project).Pages(context.Background(), func(res *compute.SnapshotList) error {
+		for _, snapshot := range res.Items {
+			info := b.snapshotInfo(snapshot)
+			if hasTags(info.Tags, tags) {
+				snapshots = append(snapshots, info)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return snapshots, nil
+}
+
+func (b *blockStore) GetSnapshotInfo(snapshotID string) (*cloudprovider.SnapshotInfo, error) {
+	snapshot, err := b.gce.Snapshots.Get(b.project, snapshotID).Do()
+	if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	info := b.snapshotInfo(snapshot)
+	return &info, nil
+}
+
+func (b *blockStore) snapshotInfo(snapshot *compute.Snapshot) cloudprovider.SnapshotInfo {
+	info := cloudprovider.SnapshotInfo{
+		ID:     snapshot.Name,
+		SizeGB: snapshot.DiskSizeGb,
+		Status: snapshot.Status,
+	}
+
+	// SourceDisk is the disk's full URL; the volume ID is its last segment
+	if snapshot.SourceDisk != "" {
+		info.VolumeID = path.Base(snapshot.SourceDisk)
+	}
+
+	if snapshot.CreationTimestamp != "" {
+		creationTimestamp, err := time.Parse(time.RFC3339, snapshot.CreationTimestamp)
+		if err != nil {
+			b.log.WithError(err).WithField("snapshot", snapshot.Name).Warn("Unable to parse snapshot's creation timestamp")
+		} else {
+			info.CreationTimestamp = creationTimestamp
+		}
+	}
+
+	if snapshot.Description != "" {
+		if err := json.Unmarshal([]byte(snapshot.Description), &info.Tags); err != nil {
+			b.log.WithError(err).WithField("snapshot", snapshot.Name).Debug("Unable to decode snapshot's description as JSON tags")
+		}
+	}
+
+	return info
+}
+
+// hasTags returns true if all of the key-value pairs in want are present
+// in tags.
+func hasTags(tags, want map[string]string) bool {
+	for k, v := range want {
+		if val, ok := tags[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
 func (b *blockStore) GetVolumeID(pv runtime.Unstructured) (string, error) {
 	if !collections.Exists(pv.UnstructuredContent(), "spec.gcePersistentDisk") {
 		return "", nil
@@ -19,11 +19,14 @@ package gcp
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/heptio/ark/pkg/cloudprovider"
 	"github.com/heptio/ark/pkg/util/collections"
 	arktest "github.com/heptio/ark/pkg/util/test"
 )
@@ -152,3 +155,70 @@ func TestGetSnapshotTags(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot *compute.Snapshot
+		expected cloudprovider.SnapshotInfo
+	}{
+		{
+			name: "all fields populated",
+			snapshot: &compute.Snapshot{
+				Name:              "snap-1",
+				SourceDisk:        "https://www.googleapis.com/compute/v1/projects/p/zones/z/disks/disk-1",
+				DiskSizeGb:        10,
+				CreationTimestamp: "2018-05-01T10:00:00Z",
+				Status:            "READY",
+				Description:       `{"ark.heptio.com/backup":"backup-1"}`,
+			},
+			expected: cloudprovider.SnapshotInfo{
+				ID:                "snap-1",
+				VolumeID:          "disk-1",
+				SizeGB:            10,
+				CreationTimestamp: time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC),
+				Status:            "READY",
+				Tags:              map[string]string{"ark.heptio.com/backup": "backup-1"},
+			},
+		},
+		{
+			name: "non-JSON description and invalid timestamp are ignored",
+			snapshot: &compute.Snapshot{
+				Name:              "snap-2",
+				CreationTimestamp: "not-a-timestamp",
+				Description:       "some description",
+			},
+			expected: cloudprovider.SnapshotInfo{
+				ID: "snap-2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &blockStore{log: arktest.NewLogger()}
+
+			actual := b.snapshotInfo(test.snapshot)
+			assert.Equal(t, test.expected.ID, actual.ID)
+			assert.Equal(t, test.expected.VolumeID, actual.VolumeID)
+			assert.Equal(t, test.expected.SizeGB, actual.SizeGB)
+			assert.Equal(t, test.expected.Status, actual.Status)
+			assert.True(t, test.expected.CreationTimestamp.Equal(actual.CreationTimestamp))
+			assert.Equal(t, len(test.expected.Tags), len(actual.Tags))
+			for k, v := range test.expected.Tags {
+				assert.Equal(t, v, actual.Tags[k])
+			}
+		})
+	}
+}
+
+func TestHasTags(t *testing.T) {
+	tags := map[string]string{"a": "1", "b": "2"}
+
+	assert.True(t, hasTags(tags, nil))
+	assert.True(t, hasTags(tags, map[string]string{"a": "1"}))
+	assert.True(t, hasTags(tags, map[string]string{"a": "1", "b": "2"}))
+	assert.False(t, hasTags(tags, map[string]string{"a": "2"}))
+	assert.False(t, hasTags(tags, map[string]string{"c": "3"}))
+	assert.False(t, hasTags(nil, map[string]string{"a": "1"}))
+}
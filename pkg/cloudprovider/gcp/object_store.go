@@ -29,6 +29,7 @@ import (
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/heptio/ark/pkg/cloudprovider"
 )
@@ -174,6 +175,43 @@ func (o *objectStore) DeleteObject(bucket, key string) error {
 	return errors.Wrapf(o.client.Bucket(bucket).Object(key).Delete(context.Background()), "error deleting object %s", key)
 }
 
+// CopyObject copies an object using GCS's server-side rewrite, so the object's
+// data is not transferred through Ark.
+func (o *objectStore) CopyObject(sourceBucket, sourceKey, destinationBucket, destinationKey string) error {
+	src := o.client.Bucket(sourceBucket).Object(sourceKey)
+	dst := o.client.Bucket(destinationBucket).Object(destinationKey)
+
+	_, err := dst.CopierFrom(src).Run(context.Background())
+
+	return errors.Wrapf(err, "error copying object %s to %s", sourceKey, destinationKey)
+}
+
+// DeleteObjectsWithPrefix deletes each object under the prefix as it's listed,
+// since GCS does not have a multi-object delete API.
+func (o *objectStore) DeleteObjectsWithPrefix(bucket, prefix string) error {
+	q := &storage.Query{
+		Prefix: prefix,
+	}
+
+	var errs []error
+
+	iter := o.client.Bucket(bucket).Objects(context.Background(), q)
+
+	for {
+		obj, err := iter.Next()
+		if err == iterator.Done {
+			return kerrors.NewAggregate(errs)
+		}
+		if err != nil {
+			return kerrors.NewAggregate(append(errs, errors.WithStack(err)))
+		}
+
+		if err := o.DeleteObject(bucket, obj.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+}
+
 func (o *objectStore) CreateSignedURL(bucket, key string, ttl time.Duration) (string, error) {
 	return storage.SignedURL(bucket, key, &storage.SignedURLOptions{
 		GoogleAccessID: o.googleAccessID,
@@ -141,6 +141,42 @@ func (o *InMemoryObjectStore) DeleteObject(bucket, key string) error {
 	return nil
 }
 
+func (o *InMemoryObjectStore) CopyObject(sourceBucket, sourceKey, destinationBucket, destinationKey string) error {
+	sourceData, ok := o.Data[sourceBucket]
+	if !ok {
+		return errors.New("bucket not found")
+	}
+
+	obj, ok := sourceData[sourceKey]
+	if !ok {
+		return errors.New("key not found")
+	}
+
+	destinationData, ok := o.Data[destinationBucket]
+	if !ok {
+		return errors.New("bucket not found")
+	}
+
+	destinationData[destinationKey] = append([]byte(nil), obj...)
+
+	return nil
+}
+
+func (o *InMemoryObjectStore) DeleteObjectsWithPrefix(bucket, prefix string) error {
+	bucketData, ok := o.Data[bucket]
+	if !ok {
+		return errors.New("bucket not found")
+	}
+
+	for key := range bucketData {
+		if strings.HasPrefix(key, prefix) {
+			delete(bucketData, key)
+		}
+	}
+
+	return nil
+}
+
 func (o *InMemoryObjectStore) CreateSignedURL(bucket, key string, ttl time.Duration) (string, error) {
 	bucketData, ok := o.Data[bucket]
 	if !ok {
@@ -17,6 +17,7 @@ limitations under the License.
 // Code generated by mockery v1.0.0. DO NOT EDIT.
 package mocks
 
+import cloudprovider "github.com/heptio/ark/pkg/cloudprovider"
 import mock "github.com/stretchr/testify/mock"
 import runtime "k8s.io/apimachinery/pkg/runtime"
 
@@ -81,6 +82,29 @@ func (_m *BlockStore) DeleteSnapshot(snapshotID string) error {
 	return r0
 }
 
+// GetSnapshotInfo provides a mock function with given fields: snapshotID
+func (_m *BlockStore) GetSnapshotInfo(snapshotID string) (*cloudprovider.SnapshotInfo, error) {
+	ret := _m.Called(snapshotID)
+
+	var r0 *cloudprovider.SnapshotInfo
+	if rf, ok := ret.Get(0).(func(string) *cloudprovider.SnapshotInfo); ok {
+		r0 = rf(snapshotID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cloudprovider.SnapshotInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(snapshotID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVolumeID provides a mock function with given fields: pv
 func (_m *BlockStore) GetVolumeID(pv runtime.Unstructured) (string, error) {
 	ret := _m.Called(pv)
@@ -146,6 +170,29 @@ func (_m *BlockStore) Init(config map[string]string) error {
 	return r0
 }
 
+// ListSnapshots provides a mock function with given fields: tags
+func (_m *BlockStore) ListSnapshots(tags map[string]string) ([]cloudprovider.SnapshotInfo, error) {
+	ret := _m.Called(tags)
+
+	var r0 []cloudprovider.SnapshotInfo
+	if rf, ok := ret.Get(0).(func(map[string]string) []cloudprovider.SnapshotInfo); ok {
+		r0 = rf(tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cloudprovider.SnapshotInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
+		r1 = rf(tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetVolumeID provides a mock function with given fields: pv, volumeID
 func (_m *BlockStore) SetVolumeID(pv runtime.Unstructured, volumeID string) (runtime.Unstructured, error) {
 	ret := _m.Called(pv, volumeID)
@@ -26,6 +26,20 @@ type ObjectStore struct {
 	mock.Mock
 }
 
+// CopyObject provides a mock function with given fields: sourceBucket, sourceKey, destinationBucket, destinationKey
+func (_m *ObjectStore) CopyObject(sourceBucket string, sourceKey string, destinationBucket string, destinationKey string) error {
+	ret := _m.Called(sourceBucket, sourceKey, destinationBucket, destinationKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
+		r0 = rf(sourceBucket, sourceKey, destinationBucket, destinationKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateSignedURL provides a mock function with given fields: bucket, key, ttl
 func (_m *ObjectStore) CreateSignedURL(bucket string, key string, ttl time.Duration) (string, error) {
 	ret := _m.Called(bucket, key, ttl)
@@ -61,6 +75,20 @@ func (_m *ObjectStore) DeleteObject(bucket string, key string) error {
 	return r0
 }
 
+// DeleteObjectsWithPrefix provides a mock function with given fields: bucket, prefix
+func (_m *ObjectStore) DeleteObjectsWithPrefix(bucket string, prefix string) error {
+	ret := _m.Called(bucket, prefix)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(bucket, prefix)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetObject provides a mock function with given fields: bucket, key
 func (_m *ObjectStore) GetObject(bucket string, key string) (io.ReadCloser, error) {
 	ret := _m.Called(bucket, key)
@@ -157,3 +185,17 @@ func (_m *ObjectStore) PutObject(bucket string, key string, body io.Reader) erro
 
 	return r0
 }
+
+// PutObjectWithOptions provides a mock function with given fields: bucket, key, body, options
+func (_m *ObjectStore) PutObjectWithOptions(bucket string, key string, body io.Reader, options map[string]string) error {
+	ret := _m.Called(bucket, key, body, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, io.Reader, map[string]string) error); ok {
+		r0 = rf(bucket, key, body, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -59,4 +59,24 @@ type ObjectStore interface {
 
 	// CreateSignedURL creates a pre-signed URL for the given bucket and key that expires after ttl.
 	CreateSignedURL(bucket, key string, ttl time.Duration) (string, error)
+
+	// CopyObject copies the object with the given source key in the source bucket
+	// to the destination key in the destination bucket, without downloading it.
+	// The buckets may be the same.
+	CopyObject(sourceBucket, sourceKey, destinationBucket, destinationKey string) error
+
+	// DeleteObjectsWithPrefix removes all objects in the given bucket whose keys
+	// start with the specified prefix, using batched deletes where the provider
+	// supports them. It attempts to delete every object and returns an aggregate
+	// of any errors.
+	DeleteObjectsWithPrefix(bucket, prefix string) error
+}
+
+// ObjectOptionsPutter is implemented by object stores that can set provider-specific
+// options, such as a storage class, on the objects they create.
+type ObjectOptionsPutter interface {
+	// PutObjectWithOptions creates a new object like PutObject, applying the
+	// provided options to it. It returns an error if any of the options aren't
+	// supported.
+	PutObjectWithOptions(bucket, key string, body io.Reader, options map[string]string) error
 }
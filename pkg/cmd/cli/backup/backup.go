@@ -31,11 +31,15 @@ func NewCommand(f client.Factory) *cobra.Command {
 
 	c.AddCommand(
 		NewCreateCommand(f, "create"),
+		NewEstimateCommand(f),
 		NewGetCommand(f, "get"),
 		NewLogsCommand(f),
 		NewDescribeCommand(f, "describe"),
 		NewDownloadCommand(f),
 		NewDeleteCommand(f, "delete"),
+		NewHoldCommand(f),
+		NewReleaseCommand(f),
+		NewMigrateFormatCommand(),
 	)
 
 	return c
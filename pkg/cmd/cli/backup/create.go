@@ -18,14 +18,18 @@ package backup
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
+	pkgbackup "github.com/heptio/ark/pkg/backup"
 	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/cmd"
 	"github.com/heptio/ark/pkg/cmd/util/flag"
@@ -70,6 +74,16 @@ type CreateOptions struct {
 	Wait                    bool
 	StorageLocation         string
 	SnapshotLocations       []string
+	FilterProfile           string
+	ClientQPS               int
+	ClientBurst             int
+	PageSize                int
+	ArchiveFormat           string
+	Hold                    bool
+	FollowReferences        bool
+	ResticHostPathVolumes   bool
+	HelmReleases            []string
+	TillerNamespace         string
 
 	client arkclient.Interface
 }
@@ -94,6 +108,16 @@ func (o *CreateOptions) BindFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&o.StorageLocation, "storage-location", "", "location in which to store the backup")
 	flags.StringSliceVar(&o.SnapshotLocations, "volume-snapshot-locations", o.SnapshotLocations, "list of locations (at most one per provider) where volume snapshots should be stored")
 	flags.VarP(&o.Selector, "selector", "l", "only back up resources matching this label selector")
+	flags.StringVar(&o.FilterProfile, "filter-profile", "", fmt.Sprintf("named set of resource filters to apply to the backup; valid values are %s", strings.Join(pkgbackup.FilterProfileNames(), ", ")))
+	flags.IntVar(&o.ClientQPS, "client-qps", 0, "maximum number of requests per second to the Kubernetes API server while backing up objects; can only lower the server's limit")
+	flags.IntVar(&o.ClientBurst, "client-burst", 0, "maximum burst of requests to the Kubernetes API server while backing up objects; can only lower the server's limit")
+	flags.IntVar(&o.PageSize, "page-size", 0, "maximum number of objects to request from the Kubernetes API server per list call; if zero, the server's setting is used")
+	flags.StringVar(&o.ArchiveFormat, "archive-format", "", fmt.Sprintf("format of the archive the backup's items are written to; valid values are %s and %s (default %s)", api.ArchiveFormatTarGzip, api.ArchiveFormatZip, api.ArchiveFormatTarGzip))
+	flags.BoolVar(&o.Hold, "hold", o.Hold, "keep the backup from being deleted, including when it expires, until it's released with 'ark backup release'")
+	flags.BoolVar(&o.FollowReferences, "follow-references", o.FollowReferences, "also back up items outside the included namespaces that are directly referenced by backed-up items")
+	flags.BoolVar(&o.ResticHostPathVolumes, "restic-host-path-volumes", o.ResticHostPathVolumes, "back up hostPath volumes annotated for restic backup from the node's root filesystem; requires the restic daemonset to mount it")
+	flags.StringSliceVar(&o.HelmReleases, "helm-releases", o.HelmReleases, "only back up items belonging to these Helm releases, along with the releases' records")
+	flags.StringVar(&o.TillerNamespace, "tiller-namespace", "", "namespace that Tiller stores Helm release records in (default kube-system)")
 	f := flags.VarPF(&o.SnapshotVolumes, "snapshot-volumes", "", "take snapshots of PersistentVolumes as part of the backup")
 	// this allows the user to just specify "--snapshot-volumes" as shorthand for "--snapshot-volumes=true"
 	// like a normal bool flag
@@ -126,6 +150,18 @@ func (o *CreateOptions) Validate(c *cobra.Command, args []string, f client.Facto
 		}
 	}
 
+	if o.ArchiveFormat != "" {
+		if err := archive.ValidateFormat(api.ArchiveFormat(o.ArchiveFormat)); err != nil {
+			return err
+		}
+	}
+
+	if o.FilterProfile != "" {
+		if _, ok := pkgbackup.GetFilterProfile(o.FilterProfile); !ok {
+			return errors.Errorf("invalid filter profile %s, valid profiles are %s", o.FilterProfile, strings.Join(pkgbackup.FilterProfileNames(), ", "))
+		}
+	}
+
 	return nil
 }
 
@@ -146,18 +182,7 @@ func (o *CreateOptions) Run(c *cobra.Command, f client.Factory) error {
 			Name:      o.Name,
 			Labels:    o.Labels.Data(),
 		},
-		Spec: api.BackupSpec{
-			IncludedNamespaces: o.IncludeNamespaces,
-			ExcludedNamespaces: o.ExcludeNamespaces,
-			IncludedResources:  o.IncludeResources,
-			ExcludedResources:  o.ExcludeResources,
-			LabelSelector:      o.Selector.LabelSelector,
-			SnapshotVolumes:    o.SnapshotVolumes.Value,
-			TTL:                metav1.Duration{Duration: o.TTL},
-			IncludeClusterResources: o.IncludeClusterResources.Value,
-			StorageLocation:         o.StorageLocation,
-			VolumeSnapshotLocations: o.SnapshotLocations,
-		},
+		Spec: o.backupSpec(),
 	}
 
 	if printed, err := output.PrintWithFormat(c, backup); printed || err != nil {
@@ -225,7 +250,7 @@ func (o *CreateOptions) Run(c *cobra.Command, f client.Factory) error {
 					return nil
 				}
 
-				if backup.Status.Phase != api.BackupPhaseNew && backup.Status.Phase != api.BackupPhaseInProgress {
+				if backup.Status.Phase != api.BackupPhaseNew && backup.Status.Phase != api.BackupPhaseQueued && backup.Status.Phase != api.BackupPhaseInProgress {
 					fmt.Printf("\nBackup completed with status: %s. You may check for more information using the commands `ark backup describe %s` and `ark backup logs %s`.\n", backup.Status.Phase, backup.Name, backup.Name)
 					return nil
 				}
@@ -239,3 +264,29 @@ func (o *CreateOptions) Run(c *cobra.Command, f client.Factory) error {
 
 	return nil
 }
+
+// backupSpec returns the BackupSpec described by o's flags.
+func (o *CreateOptions) backupSpec() api.BackupSpec {
+	return api.BackupSpec{
+		IncludedNamespaces:      o.IncludeNamespaces,
+		ExcludedNamespaces:      o.ExcludeNamespaces,
+		IncludedResources:       o.IncludeResources,
+		ExcludedResources:       o.ExcludeResources,
+		LabelSelector:           o.Selector.LabelSelector,
+		SnapshotVolumes:         o.SnapshotVolumes.Value,
+		TTL:                     metav1.Duration{Duration: o.TTL},
+		IncludeClusterResources: o.IncludeClusterResources.Value,
+		StorageLocation:         o.StorageLocation,
+		VolumeSnapshotLocations: o.SnapshotLocations,
+		FilterProfile:           o.FilterProfile,
+		ClientQPS:               o.ClientQPS,
+		ClientBurst:             o.ClientBurst,
+		PageSize:                o.PageSize,
+		ArchiveFormat:           api.ArchiveFormat(o.ArchiveFormat),
+		Hold:                    o.Hold,
+		FollowReferences:        o.FollowReferences,
+		ResticHostPathVolumes:   o.ResticHostPathVolumes,
+		HelmReleases:            o.HelmReleases,
+		TillerNamespace:         o.TillerNamespace,
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -50,8 +52,27 @@ func NewDownloadCommand(f client.Factory) *cobra.Command {
 	return c
 }
 
+// downloadKind is a file of a backup's that can be downloaded.
+type downloadKind struct {
+	targetKind v1.DownloadTargetKind
+	// defaultSuffix is appended to the backup's name to get the default
+	// name of the downloaded file.
+	defaultSuffix string
+}
+
+// downloadKinds are the values of the --kind flag.
+var downloadKinds = map[string]downloadKind{
+	"contents":           {targetKind: v1.DownloadTargetKindBackupContents, defaultSuffix: "-data.tar.gz"},
+	"index":              {targetKind: v1.DownloadTargetKindBackupIndex, defaultSuffix: "-index.json"},
+	"statusdetails":      {targetKind: v1.DownloadTargetKindBackupStatusDetails, defaultSuffix: "-status.json"},
+	"log":                {targetKind: v1.DownloadTargetKindBackupLog, defaultSuffix: "-logs.txt"},
+	"volumesnapshots":    {targetKind: v1.DownloadTargetKindBackupVolumeSnapshots, defaultSuffix: "-volumesnapshots.json"},
+	"podvolumesnapshots": {targetKind: v1.DownloadTargetKindBackupPodVolumeSnapshots, defaultSuffix: "-podvolumesnapshots.json"},
+}
+
 type DownloadOptions struct {
 	Name         string
+	Kind         string
 	Output       string
 	Force        bool
 	Timeout      time.Duration
@@ -60,20 +81,34 @@ type DownloadOptions struct {
 
 func NewDownloadOptions() *DownloadOptions {
 	return &DownloadOptions{
+		Kind:    "contents",
 		Timeout: time.Minute,
 	}
 }
 
 func (o *DownloadOptions) BindFlags(flags *pflag.FlagSet) {
-	flags.StringVarP(&o.Output, "output", "o", o.Output, "path to output file. Defaults to <NAME>-data.tar.gz in the current directory")
+	flags.StringVar(&o.Kind, "kind", o.Kind, fmt.Sprintf("the file of the backup to download. Valid values are %s. Files other than the contents are decompressed", strings.Join(downloadKindNames(), ", ")))
+	flags.StringVarP(&o.Output, "output", "o", o.Output, "path to output file. Defaults to <NAME>-data.tar.gz in the current directory, or a name based on --kind for other files")
 	flags.BoolVar(&o.Force, "force", o.Force, "forces the download and will overwrite file if it exists already")
 	flags.DurationVar(&o.Timeout, "timeout", o.Timeout, "maximum time to wait to process download request")
 }
 
 func (o *DownloadOptions) Validate(c *cobra.Command, args []string) error {
+	if _, ok := downloadKinds[o.Kind]; !ok {
+		return errors.Errorf("invalid kind %q, valid values are %s", o.Kind, strings.Join(downloadKindNames(), ", "))
+	}
 	return nil
 }
 
+func downloadKindNames() []string {
+	names := make([]string, 0, len(downloadKinds))
+	for name := range downloadKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (o *DownloadOptions) Complete(args []string) error {
 	o.Name = args[0]
 
@@ -87,7 +122,7 @@ func (o *DownloadOptions) Complete(args []string) error {
 		if err != nil {
 			return errors.Wrapf(err, "error getting current directory")
 		}
-		o.Output = filepath.Join(path, fmt.Sprintf("%s-data.tar.gz", o.Name))
+		o.Output = filepath.Join(path, o.Name+downloadKinds[o.Kind].defaultSuffix)
 	}
 
 	return nil
@@ -103,7 +138,7 @@ func (o *DownloadOptions) Run(c *cobra.Command, f client.Factory) error {
 	}
 	defer backupDest.Close()
 
-	err = downloadrequest.Stream(arkClient.ArkV1(), f.Namespace(), o.Name, v1.DownloadTargetKindBackupContents, backupDest, o.Timeout)
+	err = downloadrequest.Stream(arkClient.ArkV1(), f.Namespace(), o.Name, downloadKinds[o.Kind].targetKind, backupDest, o.Timeout)
 	if err != nil {
 		os.Remove(o.Output)
 		cmd.CheckError(err)
@@ -0,0 +1,153 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/client"
+	"github.com/heptio/ark/pkg/cmd"
+	"github.com/heptio/ark/pkg/cmd/util/output"
+)
+
+func NewEstimateCommand(f client.Factory) *cobra.Command {
+	o := NewEstimateOptions()
+
+	c := &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimate the number of items and size of a backup without running it",
+		Long: `Estimate the number of items and size of a backup without running it.
+
+The Ark server collects the items that a backup with the given flags would include, running
+backup item actions but not hooks, and reports their number and uncompressed size by resource,
+along with the number and capacity of the persistent volumes whose data would be backed up.
+No volume snapshots or restic backups are taken, and nothing is written to object storage.`,
+		Args: cobra.NoArgs,
+		Run: func(c *cobra.Command, args []string) {
+			cmd.CheckError(o.Complete(args, f))
+			cmd.CheckError(o.Validate(c, args, f))
+			cmd.CheckError(o.Run(c, f))
+		},
+	}
+
+	o.BindFlags(c.Flags())
+	output.BindFlags(c.Flags())
+	output.ClearOutputFlagDefault(c)
+
+	return c
+}
+
+type EstimateOptions struct {
+	*CreateOptions
+
+	Timeout time.Duration
+}
+
+func NewEstimateOptions() *EstimateOptions {
+	return &EstimateOptions{
+		CreateOptions: NewCreateOptions(),
+		Timeout:       5 * time.Minute,
+	}
+}
+
+func (o *EstimateOptions) BindFlags(flags *pflag.FlagSet) {
+	o.CreateOptions.BindFlags(flags)
+	flags.DurationVar(&o.Timeout, "timeout", o.Timeout, "how long to wait for the estimate")
+}
+
+func (o *EstimateOptions) Complete(args []string, f client.Factory) error {
+	// the estimate is named after the time it's requested, as download
+	// requests are
+	return o.CreateOptions.Complete([]string{fmt.Sprintf("estimate-%s", time.Now().Format("20060102150405"))}, f)
+}
+
+func (o *EstimateOptions) Run(c *cobra.Command, f client.Factory) error {
+	estimate := &api.BackupEstimate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.Namespace(),
+			Name:      o.Name,
+		},
+		Spec: api.BackupEstimateSpec{
+			Backup: o.backupSpec(),
+		},
+	}
+
+	estimate, err := o.client.ArkV1().BackupEstimates(estimate.Namespace).Create(estimate)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer o.client.ArkV1().BackupEstimates(estimate.Namespace).Delete(estimate.Name, nil)
+
+	watcher, err := o.client.ArkV1().BackupEstimates(estimate.Namespace).Watch(metav1.ListOptions{ResourceVersion: estimate.ResourceVersion})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer watcher.Stop()
+
+	expired := time.NewTimer(o.Timeout)
+	defer expired.Stop()
+
+Loop:
+	for {
+		select {
+		case <-expired.C:
+			return errors.New("timed out waiting for backup estimate")
+		case e, ok := <-watcher.ResultChan():
+			if !ok {
+				return errors.New("unable to watch backup estimates")
+			}
+
+			updated, ok := e.Object.(*api.BackupEstimate)
+			if !ok {
+				return errors.Errorf("unexpected type %T", e.Object)
+			}
+			if updated.Name != estimate.Name {
+				continue
+			}
+
+			switch e.Type {
+			case watch.Deleted:
+				return errors.New("backup estimate was unexpectedly deleted")
+			case watch.Modified:
+				if updated.Status.Phase == api.BackupEstimatePhaseCompleted || updated.Status.Phase == api.BackupEstimatePhaseFailed {
+					estimate = updated
+					break Loop
+				}
+			}
+		}
+	}
+
+	if output.GetOutputFlagValue(c) == "json" || output.GetOutputFlagValue(c) == "yaml" {
+		_, err := output.PrintWithFormat(c, estimate)
+		return err
+	}
+
+	fmt.Print(output.DescribeBackupEstimate(estimate))
+
+	if estimate.Status.Phase == api.BackupEstimatePhaseFailed {
+		return errors.New("backup estimate failed")
+	}
+	return nil
+}
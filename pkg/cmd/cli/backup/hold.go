@@ -0,0 +1,92 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	kubeerrs "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/heptio/ark/pkg/client"
+	"github.com/heptio/ark/pkg/cmd"
+)
+
+// NewHoldCommand creates a new command that puts backups on hold.
+func NewHoldCommand(f client.Factory) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "hold NAMES",
+		Short: "Keep backups from being deleted",
+		Long:  "Keep backups from being deleted, including when they expire, until they're released with 'ark backup release'",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			cmd.CheckError(setHold(f, args, true))
+		},
+	}
+
+	return c
+}
+
+// NewReleaseCommand creates a new command that releases backups from hold.
+func NewReleaseCommand(f client.Factory) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "release NAMES",
+		Short: "Release backups from hold",
+		Long:  "Release backups from hold, so that they can be deleted and are garbage collected when they expire",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			cmd.CheckError(setHold(f, args, false))
+		},
+	}
+
+	return c
+}
+
+func setHold(f client.Factory, names []string, hold bool) error {
+	arkClient, err := f.Client()
+	if err != nil {
+		return err
+	}
+
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"hold": hold,
+		},
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var errs []error
+	for _, name := range names {
+		if _, err := arkClient.ArkV1().Backups(f.Namespace()).Patch(name, types.MergePatchType, patchBytes); err != nil {
+			errs = append(errs, errors.WithStack(err))
+			continue
+		}
+
+		if hold {
+			fmt.Printf("Backup %q is on hold.\n", name)
+		} else {
+			fmt.Printf("Backup %q has been released from hold.\n", name)
+		}
+	}
+
+	return kubeerrs.NewAggregate(errs)
+}
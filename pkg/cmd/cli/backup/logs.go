@@ -17,19 +17,29 @@ limitations under the License.
 package backup
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/heptio/ark/pkg/apis/ark/v1"
 	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/cmd"
 	"github.com/heptio/ark/pkg/cmd/util/downloadrequest"
+	arkclientv1 "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
 )
 
+// followPollInterval is how often the log of an in-progress backup is downloaded
+// when following it.
+const followPollInterval = 5 * time.Second
+
 func NewLogsCommand(f client.Factory) *cobra.Command {
 	timeout := time.Minute
+	follow := false
 
 	c := &cobra.Command{
 		Use:   "logs BACKUP",
@@ -39,12 +49,52 @@ func NewLogsCommand(f client.Factory) *cobra.Command {
 			arkClient, err := f.Client()
 			cmd.CheckError(err)
 
-			err = downloadrequest.Stream(arkClient.ArkV1(), f.Namespace(), args[0], v1.DownloadTargetKindBackupLog, os.Stdout, timeout)
+			if follow {
+				err = followLogs(arkClient.ArkV1(), f.Namespace(), args[0], os.Stdout, timeout)
+			} else {
+				err = downloadrequest.Stream(arkClient.ArkV1(), f.Namespace(), args[0], v1.DownloadTargetKindBackupLog, os.Stdout, timeout)
+			}
 			cmd.CheckError(err)
 		},
 	}
 
 	c.Flags().DurationVar(&timeout, "timeout", timeout, "how long to wait to receive logs")
+	c.Flags().BoolVarP(&follow, "follow", "f", follow, "if the backup is in progress, keep printing its log as it's uploaded until the backup finishes")
 
 	return c
 }
+
+// followLogs prints a backup's log, downloading it periodically while the backup
+// is in progress and printing what's been added to it, until the backup finishes.
+// The Ark server uploads the log of an in-progress backup periodically, so the
+// log is printed in chunks.
+func followLogs(client arkclientv1.ArkV1Interface, namespace, name string, w io.Writer, timeout time.Duration) error {
+	var printed int
+
+	for {
+		backup, err := client.Backups(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		inProgress := backup.Status.Phase == "" || backup.Status.Phase == v1.BackupPhaseNew || backup.Status.Phase == v1.BackupPhaseQueued || backup.Status.Phase == v1.BackupPhaseInProgress
+
+		buf := new(bytes.Buffer)
+		if err := downloadrequest.Stream(client, namespace, name, v1.DownloadTargetKindBackupLog, buf, timeout); err != nil {
+			// the log of an in-progress backup may not have been uploaded yet
+			if !inProgress {
+				return err
+			}
+		} else if buf.Len() > printed {
+			if _, err := w.Write(buf.Bytes()[printed:]); err != nil {
+				return errors.WithStack(err)
+			}
+			printed = buf.Len()
+		}
+
+		if !inProgress {
+			return nil
+		}
+
+		time.Sleep(followPollInterval)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/heptio/ark/pkg/archive"
+	"github.com/heptio/ark/pkg/cmd"
+)
+
+func NewMigrateFormatCommand() *cobra.Command {
+	o := new(MigrateFormatOptions)
+
+	c := &cobra.Command{
+		Use:   "migrate-format FILE",
+		Short: "Rewrite a downloaded backup archive in the current format version",
+		Long: fmt.Sprintf(`Rewrite a backup archive, such as one downloaded with "ark backup download", in the current
+format version (%d), so that it can still be restored once Ark can no longer read its
+original format version. The archive is written to the file given by --output, in the
+same archive format (tar.gz or zip) as the original.
+
+To replace the original, copy the migrated archive over the backup's <NAME>.tar.gz file in
+object storage, and set status.tarballSizeBytes and status.tarballSHA256 in the backup's
+ark-backup.json file to the size and checksum that are printed.`, archive.FormatVersion),
+		Args: cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			cmd.CheckError(o.Validate(args))
+			cmd.CheckError(o.Run(args))
+		},
+	}
+
+	o.BindFlags(c.Flags())
+
+	return c
+}
+
+// MigrateFormatOptions are the options for the migrate-format command.
+type MigrateFormatOptions struct {
+	Output string
+	Force  bool
+}
+
+func (o *MigrateFormatOptions) BindFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Output, "output", "o", o.Output, "path to write the migrated archive to")
+	flags.BoolVar(&o.Force, "force", o.Force, "overwrite the output file if it exists already")
+}
+
+func (o *MigrateFormatOptions) Validate(args []string) error {
+	if o.Output == "" {
+		return errors.New("--output is required")
+	}
+	if o.Output == args[0] {
+		return errors.New("--output must be a different file than the original archive")
+	}
+	return nil
+}
+
+func (o *MigrateFormatOptions) Run(args []string) error {
+	in, err := os.Open(args[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer in.Close()
+
+	flags := os.O_RDWR | os.O_CREATE | os.O_EXCL
+	if o.Force {
+		flags = os.O_RDWR | os.O_CREATE | os.O_TRUNC
+	}
+
+	out, err := os.OpenFile(o.Output, flags, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var (
+		hash    = sha256.New()
+		counter = &countingWriter{}
+	)
+
+	version, err := archive.Migrate(in, io.MultiWriter(out, hash, counter))
+	if closeErr := out.Close(); err == nil {
+		err = errors.WithStack(closeErr)
+	}
+	if err != nil {
+		os.Remove(o.Output)
+		return err
+	}
+
+	fmt.Printf("Backup archive migrated from format version %d to %d: %s\n", version, archive.FormatVersion, o.Output)
+	fmt.Printf("Size: %d bytes\n", counter.count)
+	fmt.Printf("SHA-256: %s\n", hex.EncodeToString(hash.Sum(nil)))
+	return nil
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	count int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.count += int64(len(p))
+	return len(p), nil
+}
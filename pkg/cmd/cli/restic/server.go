@@ -18,11 +18,13 @@ package restic
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,12 +40,18 @@ import (
 	"github.com/heptio/ark/pkg/controller"
 	clientset "github.com/heptio/ark/pkg/generated/clientset/versioned"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
-	"github.com/heptio/ark/pkg/restic"
+	"github.com/heptio/ark/pkg/metrics"
 	"github.com/heptio/ark/pkg/util/logging"
 )
 
+// the port where prometheus metrics are exposed
+const defaultMetricsAddress = ":8085"
+
 func NewServerCommand(f client.Factory) *cobra.Command {
-	logLevelFlag := logging.LogLevelFlag(logrus.InfoLevel)
+	var (
+		logLevelFlag   = logging.LogLevelFlag(logrus.InfoLevel)
+		metricsAddress = defaultMetricsAddress
+	)
 
 	command := &cobra.Command{
 		Use:   "server",
@@ -56,7 +64,7 @@ func NewServerCommand(f client.Factory) *cobra.Command {
 			logger := logging.DefaultLogger(logLevel)
 			logger.Infof("Starting Ark restic server %s", buildinfo.FormattedGitSHA())
 
-			s, err := newResticServer(logger, fmt.Sprintf("%s-%s", c.Parent().Name(), c.Name()))
+			s, err := newResticServer(logger, fmt.Sprintf("%s-%s", c.Parent().Name(), c.Name()), metricsAddress)
 			cmd.CheckError(err)
 
 			s.run()
@@ -64,6 +72,7 @@ func NewServerCommand(f client.Factory) *cobra.Command {
 	}
 
 	command.Flags().Var(logLevelFlag, "log-level", fmt.Sprintf("the level at which to log. Valid values are %s.", strings.Join(logLevelFlag.AllowedValues(), ", ")))
+	command.Flags().StringVar(&metricsAddress, "metrics-address", metricsAddress, "the address to expose prometheus metrics")
 
 	return command
 }
@@ -78,9 +87,11 @@ type resticServer struct {
 	logger              logrus.FieldLogger
 	ctx                 context.Context
 	cancelFunc          context.CancelFunc
+	metricsAddress      string
+	metrics             *metrics.ServerMetrics
 }
 
-func newResticServer(logger logrus.FieldLogger, baseName string) (*resticServer, error) {
+func newResticServer(logger logrus.FieldLogger, baseName, metricsAddress string) (*resticServer, error) {
 	clientConfig, err := client.Config("", "", baseName)
 	if err != nil {
 		return nil, err
@@ -108,20 +119,14 @@ func newResticServer(logger logrus.FieldLogger, baseName string) (*resticServer,
 		},
 	)
 
-	// use a stand-alone secrets informer so we can filter to only the restic credentials
-	// secret(s) within the heptio-ark namespace
-	//
-	// note: using an informer to access the single secret for all ark-managed
-	// restic repositories is overkill for now, but will be useful when we move
-	// to fully-encrypted backups and have unique keys per repository.
-	secretInformer := corev1informers.NewFilteredSecretInformer(
+	// use a stand-alone secrets informer so we can filter to the secrets within the
+	// heptio-ark namespace, since backup storage locations can keep the password of their
+	// restic repositories in any secret there
+	secretInformer := corev1informers.NewSecretInformer(
 		kubeClient,
 		os.Getenv("HEPTIO_ARK_NAMESPACE"),
 		0,
 		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
-		func(opts *metav1.ListOptions) {
-			opts.FieldSelector = fmt.Sprintf("metadata.name=%s", restic.CredentialsSecretName)
-		},
 	)
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
@@ -136,12 +141,24 @@ func newResticServer(logger logrus.FieldLogger, baseName string) (*resticServer,
 		logger:              logger,
 		ctx:                 ctx,
 		cancelFunc:          cancelFunc,
+		metricsAddress:      metricsAddress,
+		metrics:             metrics.NewResticServerMetrics(),
 	}, nil
 }
 
 func (s *resticServer) run() {
 	signals.CancelOnShutdown(s.cancelFunc, s.logger)
 
+	go func() {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		s.logger.Infof("Starting metric server at address [%s]", s.metricsAddress)
+		if err := http.ListenAndServe(s.metricsAddress, metricsMux); err != nil {
+			s.logger.Fatalf("Failed to start metric server at [%s]: %v", s.metricsAddress, err)
+		}
+	}()
+	s.metrics.RegisterAllMetrics()
+
 	s.logger.Info("Starting controllers")
 
 	var wg sync.WaitGroup
@@ -155,6 +172,7 @@ func (s *resticServer) run() {
 		s.kubeInformerFactory.Core().V1().PersistentVolumeClaims(),
 		s.arkInformerFactory.Ark().V1().BackupStorageLocations(),
 		os.Getenv("NODE_NAME"),
+		s.metrics,
 	)
 	wg.Add(1)
 	go func() {
@@ -171,6 +189,7 @@ func (s *resticServer) run() {
 		s.kubeInformerFactory.Core().V1().PersistentVolumeClaims(),
 		s.arkInformerFactory.Ark().V1().BackupStorageLocations(),
 		os.Getenv("NODE_NAME"),
+		s.metrics,
 	)
 	wg.Add(1)
 	go func() {
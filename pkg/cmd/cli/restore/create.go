@@ -18,6 +18,7 @@ package restore
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -39,7 +40,7 @@ func NewCreateCommand(f client.Factory, use string) *cobra.Command {
 	o := NewCreateOptions()
 
 	c := &cobra.Command{
-		Use:   use + " [RESTORE_NAME] [--from-backup BACKUP_NAME | --from-schedule SCHEDULE_NAME]",
+		Use:   use + " [RESTORE_NAME] [--from-backup BACKUP_NAME | --from-schedule SCHEDULE_NAME [--at TIME] | --from-application APPLICATION_NAME [--at TIME]]",
 		Short: "Create a restore",
 		Example: `  # create a restore named "restore-1" from backup "backup-1"
   ark restore create restore-1 --from-backup backup-1
@@ -49,6 +50,13 @@ func NewCreateCommand(f client.Factory, use string) *cobra.Command {
  
   # create a restore from the latest successful backup triggered by schedule "schedule-1"
   ark restore create --from-schedule schedule-1
+
+  # create a restore from the latest successful backup triggered by schedule "schedule-1" that
+  # started at or before 2018-09-01T02:00:00Z
+  ark restore create --from-schedule schedule-1 --at 2018-09-01T02:00:00Z
+
+  # create a restore from the latest successful backup of protected application "app-1"
+  ark restore create --from-application app-1
   `,
 		Args: cobra.MaximumNArgs(1),
 		Run: func(c *cobra.Command, args []string) {
@@ -66,21 +74,52 @@ func NewCreateCommand(f client.Factory, use string) *cobra.Command {
 }
 
 type CreateOptions struct {
-	BackupName              string
-	ScheduleName            string
-	RestoreName             string
-	RestoreVolumes          flag.OptionalBool
-	Labels                  flag.Map
-	IncludeNamespaces       flag.StringArray
-	ExcludeNamespaces       flag.StringArray
-	IncludeResources        flag.StringArray
-	ExcludeResources        flag.StringArray
-	NamespaceMappings       flag.Map
-	Selector                flag.LabelSelector
-	IncludeClusterResources flag.OptionalBool
-	Wait                    bool
+	BackupName                string
+	ScheduleName              string
+	ApplicationName           string
+	At                        string
+	RestoreName               string
+	RestoreVolumes            flag.OptionalBool
+	VolumeIDMappings          flag.Map
+	VolumeType                string
+	VolumeIOPS                int64
+	Labels                    flag.Map
+	IncludeNamespaces         flag.StringArray
+	ExcludeNamespaces         flag.StringArray
+	IncludeResources          flag.StringArray
+	ExcludeResources          flag.StringArray
+	NamespaceMappings         flag.Map
+	NamespaceOrder            flag.StringArray
+	IterationMode             string
+	HostnameSuffixMappings    flag.Map
+	StripAnnotations          flag.StringArray
+	TranslateSecurityPolicies string
+	SecurityPolicyMappings    flag.Map
+	RestoredLabels            flag.Map
+	RestoredAnnotations       flag.Map
+	OmitLegacyRestoreLabel    bool
+	Selector                  flag.LabelSelector
+	IncludeClusterResources   flag.OptionalBool
+	RestorePriorityName       string
+	ConflictPolicy            string
+	RecreateResources         flag.StringArray
+	RecreateTimeout           time.Duration
+	ConfirmSourceCluster      bool
+	CreateNamespaces          string
+	MergeNamespaceMetadata    bool
+	ClientQPS                 int
+	ClientBurst               int
+	VerifyPodVolumes          bool
+	Strict                    bool
+	IncludeArkResources       bool
+	WaitForNamespaceLock      bool
+	WaitForReady              bool
+	WaitForReadyTimeout       time.Duration
+	ResourceTimeout           time.Duration
+	Wait                      bool
 
 	client arkclient.Interface
+	asOf   *metav1.Time
 }
 
 func NewCreateOptions() *CreateOptions {
@@ -88,6 +127,11 @@ func NewCreateOptions() *CreateOptions {
 		Labels:                  flag.NewMap(),
 		IncludeNamespaces:       flag.NewStringArray("*"),
 		NamespaceMappings:       flag.NewMap().WithEntryDelimiter(",").WithKeyValueDelimiter(":"),
+		HostnameSuffixMappings:  flag.NewMap().WithEntryDelimiter(",").WithKeyValueDelimiter(":"),
+		VolumeIDMappings:        flag.NewMap().WithEntryDelimiter(",").WithKeyValueDelimiter(":"),
+		SecurityPolicyMappings:  flag.NewMap().WithEntryDelimiter(",").WithKeyValueDelimiter(":"),
+		RestoredLabels:          flag.NewMap(),
+		RestoredAnnotations:     flag.NewMap(),
 		RestoreVolumes:          flag.NewOptionalBool(nil),
 		IncludeClusterResources: flag.NewOptionalBool(nil),
 	}
@@ -96,10 +140,18 @@ func NewCreateOptions() *CreateOptions {
 func (o *CreateOptions) BindFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&o.BackupName, "from-backup", "", "backup to restore from")
 	flags.StringVar(&o.ScheduleName, "from-schedule", "", "schedule to restore from")
+	flags.StringVar(&o.ApplicationName, "from-application", "", "protected application to restore from")
+	flags.StringVar(&o.At, "at", "", "restore from the schedule's or application's most recent completed backup that started at or before this time, in RFC3339 format (e.g. 2018-09-01T02:00:00Z); requires --from-schedule or --from-application")
 	flags.Var(&o.IncludeNamespaces, "include-namespaces", "namespaces to include in the restore (use '*' for all namespaces)")
 	flags.Var(&o.ExcludeNamespaces, "exclude-namespaces", "namespaces to exclude from the restore")
-	flags.Var(&o.NamespaceMappings, "namespace-mappings", "namespace mappings from name in the backup to desired restored name in the form src1:dst1,src2:dst2,...")
+	flags.Var(&o.NamespaceMappings, "namespace-mappings", "namespace mappings from name in the backup to desired restored name in the form src1:dst1,src2:dst2,... Restored names may use the template variables {{ .Restore.Name }}, {{ .Backup.Name }} and {{ .Timestamp }}")
+	flags.Var(&o.NamespaceOrder, "namespace-order", "namespaces in the backup to restore first, one at a time in the given order, before the other namespaces")
+	flags.StringVar(&o.IterationMode, "iteration-mode", "", fmt.Sprintf("whether to restore objects resource by resource across all namespaces, or namespace by namespace; valid values are %s (default) and %s", api.RestoreIterationModeByResource, api.RestoreIterationModeByNamespace))
+	flags.Var(&o.HostnameSuffixMappings, "hostname-suffix-mappings", "hostname suffixes of restored ingresses and routes to replace, and their replacements, in the form src1:dst1,src2:dst2,...")
 	flags.Var(&o.Labels, "labels", "labels to apply to the restore")
+	flags.Var(&o.RestoredLabels, "restored-labels", "labels to add to every restored object, in addition to the ones identifying the restore and backup; values may use the same template variables as --namespace-mappings")
+	flags.Var(&o.RestoredAnnotations, "restored-annotations", "annotations to add to every restored object; values may use the same template variables as --namespace-mappings")
+	flags.BoolVar(&o.OmitLegacyRestoreLabel, "omit-legacy-restore-label", o.OmitLegacyRestoreLabel, "don't label restored objects with the deprecated ark-restore label")
 	flags.Var(&o.IncludeResources, "include-resources", "resources to include in the restore, formatted as resource.group, such as storageclasses.storage.k8s.io (use '*' for all resources)")
 	flags.Var(&o.ExcludeResources, "exclude-resources", "resources to exclude from the restore, formatted as resource.group, such as storageclasses.storage.k8s.io")
 	flags.VarP(&o.Selector, "selector", "l", "only restore resources matching this label selector")
@@ -108,9 +160,34 @@ func (o *CreateOptions) BindFlags(flags *pflag.FlagSet) {
 	// like a normal bool flag
 	f.NoOptDefVal = "true"
 
+	flags.StringVar(&o.VolumeType, "volume-type", "", "cloud provider volume type, such as gp2, to create volumes restored from snapshots with instead of the backed-up volumes' types")
+	flags.Int64Var(&o.VolumeIOPS, "volume-iops", 0, "provisioned IOPS to create volumes restored from snapshots with instead of the backed-up volumes' IOPS")
+	flags.Var(&o.VolumeIDMappings, "volume-id-mappings", "persistent volumes in the backup to restore with existing volumes in the cloud provider instead of from their snapshots, in the form pv1:volumeID1,pv2:volumeID2,...")
+
 	f = flags.VarPF(&o.IncludeClusterResources, "include-cluster-resources", "", "include cluster-scoped resources in the restore")
 	f.NoOptDefVal = "true"
 
+	f = flags.VarPF(&o.StripAnnotations, "strip-annotations", "", "annotations to remove from restored objects, as keys or key prefixes ending in '*'; 'default' (the value if the flag is given without one) stands for known cloud load balancer and external-dns annotations")
+	f.NoOptDefVal = "default"
+
+	flags.StringVar(&o.TranslateSecurityPolicies, "translate-security-policies", "", fmt.Sprintf("restore the backup's pod security policies as OpenShift security context constraints (%s), or its security context constraints as pod security policies (%s)", api.RestoreSecurityPolicyTranslationPSPToSCC, api.RestoreSecurityPolicyTranslationSCCToPSP))
+	flags.Var(&o.SecurityPolicyMappings, "security-policy-mappings", "names of translated security policies in the backup to the names to restore them as, in the form src1:dst1,src2:dst2,...")
+	flags.StringVar(&o.RestorePriorityName, "restore-priority", "", "restore priority that defines the order in which resources are restored")
+	flags.StringVar(&o.ConflictPolicy, "conflict-policy", "", fmt.Sprintf("what to do with objects that already exist in the cluster; valid values are %s (default), %s and %s", api.RestoreConflictPolicySkip, api.RestoreConflictPolicyThreeWayMerge, api.RestoreConflictPolicyRecreate))
+	flags.Var(&o.RecreateResources, "recreate-resources", "resources whose existing objects the Recreate conflict policy may delete and recreate from the backup, formatted as resource.group, such as deployments.apps")
+	flags.DurationVar(&o.RecreateTimeout, "recreate-timeout", o.RecreateTimeout, "how long to wait for an existing object to be deleted before recreating it (default 1m)")
+	flags.BoolVar(&o.ConfirmSourceCluster, "confirm-source-cluster", o.ConfirmSourceCluster, "confirm that a restore whose conflict policy changes existing objects should restore a backup into the cluster it was taken in, where the backed-up application may still be running")
+	flags.StringVar(&o.CreateNamespaces, "create-namespaces", "", fmt.Sprintf("which namespaces that don't exist the restore may create; valid values are %s (default), %s and %s (only namespaces that are the target of a namespace mapping)", api.RestoreNamespaceCreationPolicyAlways, api.RestoreNamespaceCreationPolicyNever, api.RestoreNamespaceCreationPolicyIfMappedOnly))
+	flags.BoolVar(&o.MergeNamespaceMetadata, "merge-namespace-metadata", o.MergeNamespaceMetadata, "add the labels and annotations of backed-up namespaces to the namespaces they're restored into if those already exist")
+	flags.IntVar(&o.ClientQPS, "client-qps", 0, "maximum number of requests per second to the Kubernetes API server while restoring objects; can only lower the server's limit")
+	flags.IntVar(&o.ClientBurst, "client-burst", 0, "maximum burst of requests to the Kubernetes API server while restoring objects; can only lower the server's limit")
+	flags.BoolVar(&o.VerifyPodVolumes, "verify-pod-volumes", o.VerifyPodVolumes, "check the files restored into pod volumes by restic against their snapshots, failing the pod volume restore if any are missing or have a different size")
+	flags.BoolVar(&o.Strict, "strict", o.Strict, "fail the restore before restoring anything if a preflight check finds a problem, such as items that would exceed a namespace's resource quota, instead of reporting a warning")
+	flags.BoolVar(&o.IncludeArkResources, "include-ark-resources", o.IncludeArkResources, "allow the restore to restore objects into the Ark server's namespace, overwriting its live configuration")
+	flags.BoolVar(&o.WaitForNamespaceLock, "wait-for-namespace-lock", o.WaitForNamespaceLock, "queue the restore until other in-progress restores into the same namespaces have finished, instead of failing validation")
+	flags.BoolVar(&o.WaitForReady, "wait-for-ready", o.WaitForReady, "wait for the restored items of each resource with a readiness check, such as deployments, jobs and custom resource definitions, to become ready before restoring the next resource")
+	flags.DurationVar(&o.WaitForReadyTimeout, "wait-for-ready-timeout", o.WaitForReadyTimeout, "how long to wait for the restored items of each resource to become ready, if --wait-for-ready is set (default 5m)")
+	flags.DurationVar(&o.ResourceTimeout, "resource-timeout", o.ResourceTimeout, "how long restoring the items of each resource, across all namespaces, can take before the rest of them are skipped with an error; applies to the resources that don't have a timeout in the server's --resource-timeouts (default no timeout)")
 	flags.BoolVarP(&o.Wait, "wait", "w", o.Wait, "wait for the operation to complete")
 }
 
@@ -122,6 +199,9 @@ func (o *CreateOptions) Complete(args []string, f client.Factory) error {
 		if o.ScheduleName != "" {
 			sourceName = o.ScheduleName
 		}
+		if o.ApplicationName != "" {
+			sourceName = o.ApplicationName
+		}
 
 		o.RestoreName = fmt.Sprintf("%s-%s", sourceName, time.Now().Format("20060102150405"))
 	}
@@ -136,12 +216,26 @@ func (o *CreateOptions) Complete(args []string, f client.Factory) error {
 }
 
 func (o *CreateOptions) Validate(c *cobra.Command, args []string, f client.Factory) error {
-	if o.BackupName != "" && o.ScheduleName != "" {
-		return errors.New("either a backup or schedule must be specified, but not both")
+	sources := 0
+	for _, name := range []string{o.BackupName, o.ScheduleName, o.ApplicationName} {
+		if name != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errors.New("exactly one of a backup, schedule or application must be specified")
 	}
 
-	if o.BackupName == "" && o.ScheduleName == "" {
-		return errors.New("either a backup or schedule must be specified, but not both")
+	if o.At != "" {
+		if o.ScheduleName == "" && o.ApplicationName == "" {
+			return errors.New("--at can only be used with --from-schedule or --from-application")
+		}
+
+		at, err := time.Parse(time.RFC3339, o.At)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing --at value %q", o.At)
+		}
+		o.asOf = &metav1.Time{Time: at}
 	}
 
 	if err := output.ValidateFlags(c); err != nil {
@@ -162,6 +256,10 @@ func (o *CreateOptions) Validate(c *cobra.Command, args []string, f client.Facto
 		if _, err := o.client.ArkV1().Schedules(f.Namespace()).Get(o.ScheduleName, metav1.GetOptions{}); err != nil {
 			return err
 		}
+	case o.ApplicationName != "":
+		if _, err := o.client.ArkV1().ProtectedApplications(f.Namespace()).Get(o.ApplicationName, metav1.GetOptions{}); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -180,19 +278,64 @@ func (o *CreateOptions) Run(c *cobra.Command, f client.Factory) error {
 			Labels:    o.Labels.Data(),
 		},
 		Spec: api.RestoreSpec{
-			BackupName:              o.BackupName,
-			ScheduleName:            o.ScheduleName,
-			IncludedNamespaces:      o.IncludeNamespaces,
-			ExcludedNamespaces:      o.ExcludeNamespaces,
-			IncludedResources:       o.IncludeResources,
-			ExcludedResources:       o.ExcludeResources,
-			NamespaceMapping:        o.NamespaceMappings.Data(),
-			LabelSelector:           o.Selector.LabelSelector,
-			RestorePVs:              o.RestoreVolumes.Value,
-			IncludeClusterResources: o.IncludeClusterResources.Value,
+			BackupName:                o.BackupName,
+			ScheduleName:              o.ScheduleName,
+			ApplicationName:           o.ApplicationName,
+			AsOf:                      o.asOf,
+			IncludedNamespaces:        o.IncludeNamespaces,
+			ExcludedNamespaces:        o.ExcludeNamespaces,
+			IncludedResources:         o.IncludeResources,
+			ExcludedResources:         o.ExcludeResources,
+			NamespaceMapping:          o.NamespaceMappings.Data(),
+			NamespaceOrder:            o.NamespaceOrder,
+			IterationMode:             api.RestoreIterationMode(o.IterationMode),
+			LabelSelector:             o.Selector.LabelSelector,
+			RestorePVs:                o.RestoreVolumes.Value,
+			VolumeIDMapping:           o.VolumeIDMappings.Data(),
+			IncludeClusterResources:   o.IncludeClusterResources.Value,
+			RestorePriorityName:       o.RestorePriorityName,
+			ConflictPolicy:            api.RestoreConflictPolicy(o.ConflictPolicy),
+			CreateNamespaces:          api.RestoreNamespaceCreationPolicy(o.CreateNamespaces),
+			MergeNamespaceMetadata:    o.MergeNamespaceMetadata,
+			ClientQPS:                 o.ClientQPS,
+			ClientBurst:               o.ClientBurst,
+			VerifyPodVolumes:          o.VerifyPodVolumes,
+			Strict:                    o.Strict,
+			IncludeArkResources:       o.IncludeArkResources,
+			WaitForNamespaceLock:      o.WaitForNamespaceLock,
+			WaitForReady:              o.WaitForReady,
+			HostnameRewrites:          hostnameSuffixRewrites(o.HostnameSuffixMappings.Data()),
+			StripAnnotations:          o.StripAnnotations,
+			SecurityPolicyTranslation: api.RestoreSecurityPolicyTranslation(o.TranslateSecurityPolicies),
+			SecurityPolicyMapping:     o.SecurityPolicyMappings.Data(),
+			AdditionalLabels:          o.RestoredLabels.Data(),
+			AdditionalAnnotations:     o.RestoredAnnotations.Data(),
+			OmitLegacyRestoreLabel:    o.OmitLegacyRestoreLabel,
+			RecreateResources:         o.RecreateResources,
+			ConfirmSourceCluster:      o.ConfirmSourceCluster,
 		},
 	}
 
+	if o.VolumeType != "" || o.VolumeIOPS != 0 {
+		override := api.RestoreVolumeOverride{VolumeType: o.VolumeType}
+		if o.VolumeIOPS != 0 {
+			override.IOPS = &o.VolumeIOPS
+		}
+		restore.Spec.VolumeOverrides = []api.RestoreVolumeOverride{override}
+	}
+
+	if o.RecreateTimeout != 0 {
+		restore.Spec.RecreateTimeout = &metav1.Duration{Duration: o.RecreateTimeout}
+	}
+
+	if o.WaitForReadyTimeout != 0 {
+		restore.Spec.WaitForReadyTimeout = &metav1.Duration{Duration: o.WaitForReadyTimeout}
+	}
+
+	if o.ResourceTimeout != 0 {
+		restore.Spec.ResourceTimeout = &metav1.Duration{Duration: o.ResourceTimeout}
+	}
+
 	if printed, err := output.PrintWithFormat(c, restore); printed || err != nil {
 		return err
 	}
@@ -272,3 +415,22 @@ func (o *CreateOptions) Run(c *cobra.Command, f client.Factory) error {
 
 	return nil
 }
+
+// hostnameSuffixRewrites returns a hostname rewrite for each suffix mapping,
+// longest suffix first so that the most specific suffix that matches a
+// hostname is the one that's replaced.
+func hostnameSuffixRewrites(mappings map[string]string) []api.HostnameRewrite {
+	var rewrites []api.HostnameRewrite
+	for suffix, replacement := range mappings {
+		rewrites = append(rewrites, api.HostnameRewrite{Suffix: suffix, Replacement: replacement})
+	}
+
+	sort.Slice(rewrites, func(i, j int) bool {
+		if len(rewrites[i].Suffix) != len(rewrites[j].Suffix) {
+			return len(rewrites[i].Suffix) > len(rewrites[j].Suffix)
+		}
+		return rewrites[i].Suffix < rewrites[j].Suffix
+	})
+
+	return rewrites
+}
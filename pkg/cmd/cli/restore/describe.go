@@ -64,7 +64,15 @@ func NewDescribeCommand(f client.Factory, use string) *cobra.Command {
 					fmt.Fprintf(os.Stderr, "error getting PodVolumeRestores for restore %s: %v\n", restore.Name, err)
 				}
 
-				s := output.DescribeRestore(&restore, podvolumeRestoreList.Items, details, arkClient)
+				var undoRequests []api.UndoRestoreRequest
+				undoOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", api.RestoreNameLabel, restore.Name)}
+				if undoRequestList, err := arkClient.ArkV1().UndoRestoreRequests(f.Namespace()).List(undoOpts); err != nil {
+					fmt.Fprintf(os.Stderr, "error getting UndoRestoreRequests for restore %s: %v\n", restore.Name, err)
+				} else {
+					undoRequests = undoRequestList.Items
+				}
+
+				s := output.DescribeRestore(&restore, podvolumeRestoreList.Items, undoRequests, details, arkClient)
 				if first {
 					first = false
 					fmt.Print(s)
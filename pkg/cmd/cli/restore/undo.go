@@ -0,0 +1,114 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/client"
+	"github.com/heptio/ark/pkg/cmd"
+	"github.com/heptio/ark/pkg/cmd/cli"
+)
+
+// NewUndoCommand creates a new command that undoes a restore.
+func NewUndoCommand(f client.Factory) *cobra.Command {
+	o := &UndoOptions{}
+
+	c := &cobra.Command{
+		Use:   "undo NAME",
+		Short: "Delete the objects a restore created",
+		Long: `Delete exactly the objects a restore created, leaving objects that already existed alone.
+
+Objects that have been deleted, or deleted and recreated, since the restore are skipped.`,
+		Example: `	# delete the objects created by the restore named "restore-1"
+	ark restore undo restore-1
+
+	# also delete the namespaces the restore created, along with everything in them
+	ark restore undo restore-1 --delete-namespaces`,
+		Args: cobra.ExactArgs(1),
+		Run: func(c *cobra.Command, args []string) {
+			cmd.CheckError(o.Complete(args))
+			cmd.CheckError(o.Run(f))
+		},
+	}
+
+	o.BindFlags(c.Flags())
+
+	return c
+}
+
+// UndoOptions contains parameters for undoing a restore.
+type UndoOptions struct {
+	Name             string
+	DeleteNamespaces bool
+	Confirm          bool
+}
+
+// BindFlags binds the options to the flag set.
+func (o *UndoOptions) BindFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.DeleteNamespaces, "delete-namespaces", o.DeleteNamespaces, "also delete the namespaces the restore created, including any objects created in them since the restore")
+	flags.BoolVar(&o.Confirm, "confirm", o.Confirm, "confirm undoing the restore without prompting")
+}
+
+// Complete fills in the restore's name.
+func (o *UndoOptions) Complete(args []string) error {
+	o.Name = args[0]
+	return nil
+}
+
+// Run submits an UndoRestoreRequest for the restore.
+func (o *UndoOptions) Run(f client.Factory) error {
+	arkClient, err := f.Client()
+	if err != nil {
+		return err
+	}
+
+	if _, err := arkClient.ArkV1().Restores(f.Namespace()).Get(o.Name, metav1.GetOptions{}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !o.Confirm && !cli.GetConfirmation() {
+		return nil
+	}
+
+	req := &arkv1api.UndoRestoreRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: o.Name + "-",
+			Labels: map[string]string{
+				arkv1api.RestoreNameLabel: o.Name,
+			},
+		},
+		Spec: arkv1api.UndoRestoreRequestSpec{
+			RestoreName:      o.Name,
+			DeleteNamespaces: o.DeleteNamespaces,
+		},
+	}
+
+	req, err = arkClient.ArkV1().UndoRestoreRequests(f.Namespace()).Create(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	fmt.Printf("Request %q to undo restore %q submitted successfully.\nRun `ark restore describe %s` for more details.\n", req.Name, o.Name, o.Name)
+	return nil
+}
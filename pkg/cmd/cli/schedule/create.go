@@ -117,6 +117,16 @@ func (o *CreateOptions) Run(c *cobra.Command, f client.Factory) error {
 				TTL:                     metav1.Duration{Duration: o.BackupOptions.TTL},
 				StorageLocation:         o.BackupOptions.StorageLocation,
 				VolumeSnapshotLocations: o.BackupOptions.SnapshotLocations,
+				FilterProfile:           o.BackupOptions.FilterProfile,
+				ClientQPS:               o.BackupOptions.ClientQPS,
+				ClientBurst:             o.BackupOptions.ClientBurst,
+				PageSize:                o.BackupOptions.PageSize,
+				ArchiveFormat:           api.ArchiveFormat(o.BackupOptions.ArchiveFormat),
+				Hold:                    o.BackupOptions.Hold,
+				FollowReferences:        o.BackupOptions.FollowReferences,
+				ResticHostPathVolumes:   o.BackupOptions.ResticHostPathVolumes,
+				HelmReleases:            o.BackupOptions.HelmReleases,
+				TillerNamespace:         o.BackupOptions.TillerNamespace,
 			},
 			Schedule: o.Schedule,
 		},
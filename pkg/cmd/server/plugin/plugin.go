@@ -47,12 +47,16 @@ func NewCommand(f client.Factory) *cobra.Command {
 				RegisterBlockStore("aws", newAwsBlockStore).
 				RegisterBlockStore("azure", newAzureBlockStore).
 				RegisterBlockStore("gcp", newGcpBlockStore).
+				RegisterBackupItemAction("deploymentconfig", newDeploymentConfigBackupItemAction).
 				RegisterBackupItemAction("pv", newPVBackupItemAction).
 				RegisterBackupItemAction("pod", newPodBackupItemAction).
 				RegisterBackupItemAction("serviceaccount", newServiceAccountBackupItemAction(f)).
+				RegisterRestoreItemAction("hostname", newHostnameRewriteRestoreItemAction).
+				RegisterRestoreItemAction("imagestream", newImageStreamRestoreItemAction).
 				RegisterRestoreItemAction("job", newJobRestoreItemAction).
 				RegisterRestoreItemAction("pod", newPodRestoreItemAction).
 				RegisterRestoreItemAction("restic", newResticRestoreItemAction).
+				RegisterRestoreItemAction("route", newRouteRestoreItemAction).
 				RegisterRestoreItemAction("service", newServiceRestoreItemAction).
 				Serve()
 		},
@@ -85,6 +89,10 @@ func newGcpBlockStore(logger logrus.FieldLogger) (interface{}, error) {
 	return gcp.NewBlockStore(logger), nil
 }
 
+func newDeploymentConfigBackupItemAction(logger logrus.FieldLogger) (interface{}, error) {
+	return backup.NewDeploymentConfigAction(logger), nil
+}
+
 func newPVBackupItemAction(logger logrus.FieldLogger) (interface{}, error) {
 	return backup.NewBackupPVAction(logger), nil
 }
@@ -118,6 +126,14 @@ func newServiceAccountBackupItemAction(f client.Factory) arkplugin.HandlerInitia
 	}
 }
 
+func newHostnameRewriteRestoreItemAction(logger logrus.FieldLogger) (interface{}, error) {
+	return restore.NewHostnameRewriteAction(logger), nil
+}
+
+func newImageStreamRestoreItemAction(logger logrus.FieldLogger) (interface{}, error) {
+	return restore.NewImageStreamAction(logger), nil
+}
+
 func newJobRestoreItemAction(logger logrus.FieldLogger) (interface{}, error) {
 	return restore.NewJobAction(logger), nil
 }
@@ -130,6 +146,10 @@ func newResticRestoreItemAction(logger logrus.FieldLogger) (interface{}, error)
 	return restore.NewResticRestoreAction(logger), nil
 }
 
+func newRouteRestoreItemAction(logger logrus.FieldLogger) (interface{}, error) {
+	return restore.NewRouteAction(logger), nil
+}
+
 func newServiceRestoreItemAction(logger logrus.FieldLogger) (interface{}, error) {
 	return restore.NewServiceAction(logger), nil
 }
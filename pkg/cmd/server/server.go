@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -33,25 +35,31 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	corev1api "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	kubeerrs "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	appsv1informers "k8s.io/client-go/informers/apps/v1"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
 	"github.com/heptio/ark/pkg/backup"
 	"github.com/heptio/ark/pkg/buildinfo"
 	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/cmd"
+	"github.com/heptio/ark/pkg/cmd/util/downloadrequest"
 	"github.com/heptio/ark/pkg/cmd/util/flag"
 	"github.com/heptio/ark/pkg/cmd/util/signals"
 	"github.com/heptio/ark/pkg/controller"
@@ -64,6 +72,7 @@ import (
 	"github.com/heptio/ark/pkg/podexec"
 	"github.com/heptio/ark/pkg/restic"
 	"github.com/heptio/ark/pkg/restore"
+	"github.com/heptio/ark/pkg/statusapi"
 	"github.com/heptio/ark/pkg/util/kube"
 	"github.com/heptio/ark/pkg/util/logging"
 	"github.com/heptio/ark/pkg/util/stringslice"
@@ -75,28 +84,56 @@ const (
 
 	defaultBackupSyncPeriod          = time.Minute
 	defaultPodVolumeOperationTimeout = 60 * time.Minute
+	defaultInProgressTimeout         = 4 * time.Hour
+	defaultRestoreMaxExtractedSize   = 10 << 30
+	defaultBackupLogUploadInterval   = 15 * time.Second
+	defaultBackupExpiryWarningPeriod = 72 * time.Hour
 )
 
 type serverConfig struct {
 	pluginDir, metricsAddress, defaultBackupLocation string
+	serverConfigMapName                              string
 	backupSyncPeriod, podVolumeOperationTimeout      time.Duration
+	inProgressTimeout                                time.Duration
 	restoreResourcePriorities                        []string
 	defaultVolumeSnapshotLocations                   map[string]string
-	restoreOnly                                      bool
+	restoreOnly, enableSelfService                   bool
+	restoreClientQPS, backupClientQPS                float32
+	restoreClientBurst, backupClientBurst            int
+	backupPageSize                                   int
+	restoreMaxExtractedSize                          int64
+	scratchDir                                       string
+	restoreCacheSize                                 int64
+	backupLogUploadInterval                          time.Duration
+	backupExpiryWarningPeriod                        time.Duration
+	protectLatestScheduledBackups                    bool
+	resourceTimeouts                                 map[string]time.Duration
+	restoreItemTimeout                               time.Duration
+	restoreFailureThreshold                          int
+	restoreCreateWorkers                             int
+	blockStoreTimeout                                time.Duration
+	statusAPIAddress, statusAPITokenFile             string
+	statusAPITLSCertFile, statusAPITLSKeyFile        string
 }
 
 func NewCommand() *cobra.Command {
 	var (
 		volumeSnapshotLocations = flag.NewMap().WithKeyValueDelimiter(":")
+		resourceTimeouts        = flag.NewMap()
 		logLevelFlag            = logging.LogLevelFlag(logrus.InfoLevel)
 		config                  = serverConfig{
 			pluginDir:                      "/plugins",
 			metricsAddress:                 defaultMetricsAddress,
 			defaultBackupLocation:          "default",
+			serverConfigMapName:            "ark-server-config",
 			defaultVolumeSnapshotLocations: make(map[string]string),
 			backupSyncPeriod:               defaultBackupSyncPeriod,
 			podVolumeOperationTimeout:      defaultPodVolumeOperationTimeout,
+			inProgressTimeout:              defaultInProgressTimeout,
 			restoreResourcePriorities:      defaultRestorePriorities,
+			restoreMaxExtractedSize:        defaultRestoreMaxExtractedSize,
+			backupLogUploadInterval:        defaultBackupLogUploadInterval,
+			backupExpiryWarningPeriod:      defaultBackupExpiryWarningPeriod,
 		}
 	)
 
@@ -136,6 +173,10 @@ func NewCommand() *cobra.Command {
 				config.defaultVolumeSnapshotLocations = volumeSnapshotLocations.Data()
 			}
 
+			timeouts, err := parseResourceTimeouts(resourceTimeouts.Data())
+			cmd.CheckError(err)
+			config.resourceTimeouts = timeouts
+
 			s, err := newServer(namespace, fmt.Sprintf("%s-%s", c.Parent().Name(), c.Name()), config, logger)
 			cmd.CheckError(err)
 
@@ -147,15 +188,100 @@ func NewCommand() *cobra.Command {
 	command.Flags().StringVar(&config.pluginDir, "plugin-dir", config.pluginDir, "directory containing Ark plugins")
 	command.Flags().StringVar(&config.metricsAddress, "metrics-address", config.metricsAddress, "the address to expose prometheus metrics")
 	command.Flags().DurationVar(&config.backupSyncPeriod, "backup-sync-period", config.backupSyncPeriod, "how often to ensure all Ark backups in object storage exist as Backup API objects in the cluster")
-	command.Flags().DurationVar(&config.podVolumeOperationTimeout, "restic-timeout", config.podVolumeOperationTimeout, "how long backups/restores of pod volumes should be allowed to run before timing out")
+	command.Flags().DurationVar(&config.podVolumeOperationTimeout, "restic-timeout", config.podVolumeOperationTimeout, "how long the backup/restore of each pod volume should be allowed to run before timing out")
+	command.Flags().DurationVar(&config.inProgressTimeout, "in-progress-timeout", config.inProgressTimeout, "how long a backup or restore can remain in progress without being processed by this server before it's marked as failed")
 	command.Flags().BoolVar(&config.restoreOnly, "restore-only", config.restoreOnly, "run in a mode where only restores are allowed; backups, schedules, and garbage-collection are all disabled")
+	command.Flags().BoolVar(&config.enableSelfService, "enable-self-service", config.enableSelfService, "allow backups and restores of a namespace to be created by users with access to only that namespace")
+	command.Flags().Float32Var(&config.restoreClientQPS, "restore-client-qps", config.restoreClientQPS, "maximum number of requests per second to the Kubernetes API server while restoring objects; if zero, the server's own client limit is used")
+	command.Flags().IntVar(&config.restoreClientBurst, "restore-client-burst", config.restoreClientBurst, "maximum burst of requests to the Kubernetes API server while restoring objects; if zero, the server's own client limit is used")
+	command.Flags().Float32Var(&config.backupClientQPS, "backup-client-qps", config.backupClientQPS, "maximum number of requests per second to the Kubernetes API server while backing up objects; if zero, the server's own client limit is used")
+	command.Flags().IntVar(&config.backupClientBurst, "backup-client-burst", config.backupClientBurst, "maximum burst of requests to the Kubernetes API server while backing up objects; if zero, the server's own client limit is used")
+	command.Flags().DurationVar(&config.backupLogUploadInterval, "backup-log-upload-interval", config.backupLogUploadInterval, "how often to upload the log of an in-progress backup, so that it can be followed with 'ark backup logs --follow'; if zero, a backup's log is only uploaded once it finishes")
+	command.Flags().IntVar(&config.backupPageSize, "backup-page-size", config.backupPageSize, "maximum number of objects to request from the Kubernetes API server per list call while backing up; if zero, each resource is listed in a single call")
+	command.Flags().Int64Var(&config.restoreMaxExtractedSize, "restore-max-extracted-size", config.restoreMaxExtractedSize, "maximum total size in bytes of the files extracted from a backup archive while restoring it; if zero, there is no limit")
+	command.Flags().StringVar(&config.scratchDir, "scratch-dir", config.scratchDir, "directory where backups and restores write their temporary files, such as backup archives; if empty, the system's default temp directory is used")
+	command.Flags().Int64Var(&config.restoreCacheSize, "restore-cache-size", config.restoreCacheSize, "maximum total size in bytes of the backup tarballs cached in the scratch directory for restores, so that restores from the same backup don't download it again; if zero, tarballs aren't cached")
+	command.Flags().DurationVar(&config.backupExpiryWarningPeriod, "backup-expiry-warning-period", config.backupExpiryWarningPeriod, "how long before a backup expires to record a warning event for it; if zero, no warnings are recorded")
+	command.Flags().BoolVar(&config.protectLatestScheduledBackups, "protect-latest-scheduled-backups", config.protectLatestScheduledBackups, "annotate the latest completed backup of each schedule to never expire when it's about to expire, so that a schedule that stops creating backups keeps its last one")
 	command.Flags().StringSliceVar(&config.restoreResourcePriorities, "restore-resource-priorities", config.restoreResourcePriorities, "desired order of resource restores; any resource not in the list will be restored alphabetically after the prioritized resources")
+	command.Flags().StringVar(&config.serverConfigMapName, "server-config-map", config.serverConfigMapName, "name of a ConfigMap in the server's namespace whose settings override the restore resource priorities, restic timeout, and backup sync period flags while the server is running")
 	command.Flags().StringVar(&config.defaultBackupLocation, "default-backup-storage-location", config.defaultBackupLocation, "name of the default backup storage location")
+	command.Flags().DurationVar(&config.restoreItemTimeout, "restore-item-timeout", config.restoreItemTimeout, "how long creating each item can take while restoring before it's recorded as an error; if zero, there is no timeout")
+	command.Flags().DurationVar(&config.blockStoreTimeout, "volume-snapshot-timeout", config.blockStoreTimeout, "how long a volume snapshotter plugin can take to create a snapshot while backing up or to create a volume from a snapshot while restoring before the volume is recorded as an error; if zero, there is no timeout")
+	command.Flags().IntVar(&config.restoreFailureThreshold, "restore-failure-threshold", config.restoreFailureThreshold, "number of consecutive items of a resource in a namespace that can fail to be created while restoring before the rest of them are skipped with a single error; if zero, no items are skipped")
+	command.Flags().IntVar(&config.restoreCreateWorkers, "restore-create-workers", config.restoreCreateWorkers, "number of items of each resource that are created at once while restoring, through a shared client, to speed up restoring resources with many small items; if one or less, items are created one at a time")
+	command.Flags().Var(&resourceTimeouts, "resource-timeouts", "how long backing up or restoring each resource can take before the resource is recorded as an error and the backup or restore continues with other resources, as resource.group=duration pairs (e.g. widgets.example.com=2m,pods=10m); resources that aren't listed have no timeout")
+	command.Flags().StringVar(&config.statusAPIAddress, "status-api-address", config.statusAPIAddress, "the address to serve a read-only JSON API of backups, restores, schedules and storage locations on; if empty, the API isn't served")
+	command.Flags().StringVar(&config.statusAPITokenFile, "status-api-token-file", config.statusAPITokenFile, "file containing the bearer token that requests to the status API must have; required if --status-api-address is set")
+	command.Flags().StringVar(&config.statusAPITLSCertFile, "status-api-tls-cert-file", config.statusAPITLSCertFile, "file containing the TLS certificate to serve the status API with; required, along with --status-api-tls-key-file, unless --status-api-address is a loopback address")
+	command.Flags().StringVar(&config.statusAPITLSKeyFile, "status-api-tls-key-file", config.statusAPITLSKeyFile, "file containing the private key of the status API's TLS certificate")
 	command.Flags().Var(&volumeSnapshotLocations, "default-volume-snapshot-locations", "list of unique volume providers and default volume snapshot location (provider1:location-01,provider2:location-02,...)")
 
 	return command
 }
 
+// readStatusAPIToken returns the bearer token in the status API's token file.
+func readStatusAPIToken(file string) (string, error) {
+	if file == "" {
+		return "", errors.New("--status-api-token-file must be set to serve the status API")
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrap(err, "error reading status API token file")
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.Errorf("status API token file %s is empty", file)
+	}
+
+	return token, nil
+}
+
+// validateStatusAPITLS returns an error if the status API would be served without TLS
+// on an address that isn't a loopback address, since its requests carry the bearer
+// token and its responses include the configuration of storage locations.
+func validateStatusAPITLS(address, certFile, keyFile string) error {
+	if (certFile == "") != (keyFile == "") {
+		return errors.New("--status-api-tls-cert-file and --status-api-tls-key-file must be set together")
+	}
+	if certFile != "" {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrapf(err, "invalid status API address %s", address)
+	}
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return nil
+	}
+
+	return errors.Errorf("--status-api-tls-cert-file and --status-api-tls-key-file must be set to serve the status API on non-loopback address %s", address)
+}
+
+// parseResourceTimeouts parses the resource timeouts flag's resource.group=duration pairs into
+// a map of group-resource names to timeouts.
+func parseResourceTimeouts(data map[string]string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(data))
+
+	for resource, value := range data {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid timeout for resource %s", resource)
+		}
+		if timeout <= 0 {
+			return nil, errors.Errorf("invalid timeout %s for resource %s, must be positive", value, resource)
+		}
+
+		groupResource := schema.ParseGroupResource(resource)
+		timeouts[groupResource.String()] = timeout
+	}
+
+	return timeouts, nil
+}
+
 func getServerNamespace(namespaceFlag *pflag.Flag) string {
 	if namespaceFlag.Changed {
 		return namespaceFlag.Value.String()
@@ -189,6 +315,7 @@ type server struct {
 	resticManager         restic.RepositoryManager
 	metrics               *metrics.ServerMetrics
 	config                serverConfig
+	clusterUID            string
 }
 
 func newServer(namespace, baseName string, config serverConfig, logger *logrus.Logger) (*server, error) {
@@ -211,7 +338,7 @@ func newServer(namespace, baseName string, config serverConfig, logger *logrus.L
 	if err := pluginRegistry.DiscoverPlugins(); err != nil {
 		return nil, err
 	}
-	pluginManager := plugin.NewManager(logger, logger.Level, pluginRegistry)
+	pluginManager := plugin.NewManager(logger, logger.Level, pluginRegistry, 0, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -256,6 +383,12 @@ func (s *server) run() error {
 		return err
 	}
 
+	if err := s.recordVersion(); err != nil {
+		return err
+	}
+
+	s.initClusterUID()
+
 	if err := s.initDiscoveryHelper(); err != nil {
 		return err
 	}
@@ -297,6 +430,50 @@ func (s *server) namespaceExists(namespace string) error {
 	return nil
 }
 
+// initClusterUID gets the UID that identifies the cluster, which backups record so
+// that restores into the same cluster can be detected. If it can't be gotten, the
+// server still runs, without recording or checking it.
+func (s *server) initClusterUID() {
+	clusterUID, err := kube.ClusterUID(s.kubeClient.CoreV1().Namespaces())
+	if err != nil {
+		s.logger.WithError(err).Warn("Unable to get the cluster's UID; backups won't record it, and restores into the cluster a backup was taken in won't need to be confirmed")
+		return
+	}
+
+	s.clusterUID = clusterUID
+}
+
+// recordVersion creates or updates the config map that records the server's
+// version, so that clients can check that they're compatible with it.
+func (s *server) recordVersion() error {
+	data := map[string]string{
+		api.ServerVersionKey:   buildinfo.Version,
+		api.ServerGitCommitKey: buildinfo.FormattedGitSHA(),
+	}
+
+	configMaps := s.kubeClient.CoreV1().ConfigMaps(s.namespace)
+
+	configMap, err := configMaps.Get(api.ServerVersionConfigMap, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1api.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace,
+				Name:      api.ServerVersionConfigMap,
+			},
+			Data: data,
+		}
+		_, err = configMaps.Create(configMap)
+		return errors.Wrap(err, "error creating server version config map")
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting server version config map")
+	}
+
+	configMap.Data = data
+	_, err = configMaps.Update(configMap)
+	return errors.Wrap(err, "error updating server version config map")
+}
+
 // initDiscoveryHelper instantiates the server's discovery helper and spawns a
 // goroutine to call Refresh() every 5 minutes.
 func (s *server) initDiscoveryHelper() error {
@@ -372,6 +549,11 @@ func (s *server) validateBackupStorageLocations() error {
 
 	var invalid []string
 	for _, location := range locations.Items {
+		if err := restic.ValidateRepoPrefix(&location); err != nil {
+			invalid = append(invalid, errors.Wrapf(err, "restic repository config for location %q is invalid", location.Name).Error())
+			continue
+		}
+
 		backupStore, err := persistence.NewObjectBackupStore(&location, s.pluginManager, s.logger)
 		if err != nil {
 			invalid = append(invalid, errors.Wrapf(err, "error getting backup store for location %q", location.Name).Error())
@@ -432,20 +614,20 @@ func (s *server) initRestic() error {
 		return err
 	}
 
-	// use a stand-alone secrets informer so we can filter to only the restic credentials
-	// secret(s) within the heptio-ark namespace
-	//
-	// note: using an informer to access the single secret for all ark-managed
-	// restic repositories is overkill for now, but will be useful when we move
-	// to fully-encrypted backups and have unique keys per repository.
-	secretsInformer := corev1informers.NewFilteredSecretInformer(
+	// make sure repositories from older versions can be looked up by
+	// workload namespace and backup storage location
+	if err := restic.MigrateRepositories(s.arkClient.ArkV1(), s.namespace, s.config.defaultBackupLocation, s.logger); err != nil {
+		return err
+	}
+
+	// use a stand-alone secrets informer so we can filter to the secrets within the
+	// heptio-ark namespace, since backup storage locations can keep the password of their
+	// restic repositories in any secret there
+	secretsInformer := corev1informers.NewSecretInformer(
 		s.kubeClient,
 		s.namespace,
 		0,
 		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
-		func(opts *metav1.ListOptions) {
-			opts.FieldSelector = fmt.Sprintf("metadata.name=%s", restic.CredentialsSecretName)
-		},
 	)
 	go secretsInformer.Run(s.ctx.Done())
 
@@ -453,6 +635,7 @@ func (s *server) initRestic() error {
 		s.ctx,
 		s.namespace,
 		s.arkClient,
+		s.kubeClient.CoreV1(),
 		secretsInformer,
 		s.sharedInformerFactory.Ark().V1().ResticRepositories(),
 		s.arkClient.ArkV1(),
@@ -483,19 +666,56 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 	}()
 	s.metrics = metrics.NewServerMetrics()
 	s.metrics.RegisterAllMetrics()
+	// controllers' work queues are created with the provider that's set when
+	// they're created, so this must be set before any controllers are created
+	workqueue.SetProvider(s.metrics.QueueMetricsProvider())
 
 	newPluginManager := func(logger logrus.FieldLogger) plugin.Manager {
-		return plugin.NewManager(logger, s.logLevel, s.pluginRegistry)
+		return plugin.NewManager(logger, s.logLevel, s.pluginRegistry, s.config.blockStoreTimeout, s.metrics)
 	}
 
+	// the flags provide the defaults for settings that can be changed while the
+	// server is running via the server ConfigMap
+	serverConfig := controller.NewServerConfig(controller.ServerSettings{
+		RestoreResourcePriorities: s.config.restoreResourcePriorities,
+		ResticTimeout:             s.config.podVolumeOperationTimeout,
+		BackupSyncPeriod:          s.config.backupSyncPeriod,
+	})
+
+	configMapInformer := corev1informers.NewFilteredConfigMapInformer(
+		s.kubeClient,
+		s.namespace,
+		0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fmt.Sprintf("metadata.name=%s", s.config.serverConfigMapName)
+		},
+	)
+	go configMapInformer.Run(ctx.Done())
+
+	serverConfigController := controller.NewServerConfigController(
+		s.namespace,
+		s.config.serverConfigMapName,
+		configMapInformer,
+		serverConfig,
+		s.logger,
+	)
+	wg.Add(1)
+	go func() {
+		serverConfigController.Run(ctx, 1)
+		wg.Done()
+	}()
+
 	backupSyncController := controller.NewBackupSyncController(
+		s.arkClient.ArkV1(),
 		s.arkClient.ArkV1(),
 		s.arkClient.ArkV1(),
 		s.sharedInformerFactory.Ark().V1().Backups(),
 		s.sharedInformerFactory.Ark().V1().BackupStorageLocations(),
-		s.config.backupSyncPeriod,
+		serverConfig.BackupSyncPeriod,
 		s.namespace,
 		s.config.defaultBackupLocation,
+		serverConfig.MaintenanceMode,
 		newPluginManager,
 		s.logger,
 	)
@@ -505,23 +725,39 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 		wg.Done()
 	}()
 
+	backupTracker := controller.NewBackupTracker()
+	restoreTracker := controller.NewRestoreTracker()
+
 	if s.config.restoreOnly {
 		s.logger.Info("Restore only mode - not starting the backup, schedule, delete-backup, or GC controllers")
 	} else {
-		backupTracker := controller.NewBackupTracker()
+		backupClientConfig := rest.CopyConfig(s.kubeClientConfig)
+		if s.config.backupClientQPS > 0 {
+			backupClientConfig.QPS = s.config.backupClientQPS
+		}
+		if s.config.backupClientBurst > 0 {
+			backupClientConfig.Burst = s.config.backupClientBurst
+		}
+		backupDynamicFactory, err := client.NewDynamicFactoryForConfig(backupClientConfig)
+		cmd.CheckError(err)
 
 		backupper, err := backup.NewKubernetesBackupper(
 			s.discoveryHelper,
-			client.NewDynamicFactory(s.dynamicClient),
+			backupDynamicFactory,
+			backupClientConfig,
 			podexec.NewPodCommandExecutor(s.kubeClientConfig, s.kubeClient.CoreV1().RESTClient()),
 			s.resticManager,
-			s.config.podVolumeOperationTimeout,
+			serverConfig.ResticTimeout,
+			s.config.backupPageSize,
+			s.config.resourceTimeouts,
+			archive.NewWriter,
 		)
 		cmd.CheckError(err)
 
 		backupController := controller.NewBackupController(
 			s.sharedInformerFactory.Ark().V1().Backups(),
 			s.arkClient.ArkV1(),
+			s.arkClient.ArkV1(),
 			backupper,
 			s.logger,
 			s.logLevel,
@@ -529,8 +765,11 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 			backupTracker,
 			s.sharedInformerFactory.Ark().V1().BackupStorageLocations(),
 			s.config.defaultBackupLocation,
+			s.clusterUID,
 			s.sharedInformerFactory.Ark().V1().VolumeSnapshotLocations(),
 			defaultVolumeSnapshotLocations,
+			s.config.scratchDir,
+			s.config.backupLogUploadInterval,
 			s.metrics,
 		)
 		wg.Add(1)
@@ -539,13 +778,30 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 			wg.Done()
 		}()
 
+		backupEstimateController := controller.NewBackupEstimateController(
+			s.arkClient.ArkV1(),
+			s.sharedInformerFactory.Ark().V1().BackupEstimates(),
+			backupper,
+			s.sharedInformerFactory.Ark().V1().VolumeSnapshotLocations(),
+			defaultVolumeSnapshotLocations,
+			newPluginManager,
+			s.logger,
+		)
+		wg.Add(1)
+		go func() {
+			backupEstimateController.Run(ctx, 1)
+			wg.Done()
+		}()
+
 		scheduleController := controller.NewScheduleController(
 			s.namespace,
 			s.arkClient.ArkV1(),
 			s.arkClient.ArkV1(),
 			s.sharedInformerFactory.Ark().V1().Schedules(),
+			s.sharedInformerFactory.Ark().V1().Backups(),
 			s.logger,
 			s.metrics,
+			serverConfig.MaintenanceMode,
 		)
 		wg.Add(1)
 		go func() {
@@ -553,11 +809,59 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 			wg.Done()
 		}()
 
+		// namespaces annotated for backups can be in any namespace, so use a
+		// stand-alone informer that watches all of them
+		namespaceInformer := corev1informers.NewNamespaceInformer(s.kubeClient, 0, cache.Indexers{})
+		go namespaceInformer.Run(ctx.Done())
+
+		namespaceScheduleController := controller.NewNamespaceScheduleController(
+			s.namespace,
+			namespaceInformer,
+			s.sharedInformerFactory.Ark().V1().Schedules(),
+			s.arkClient.ArkV1(),
+			s.logger,
+		)
+		wg.Add(1)
+		go func() {
+			namespaceScheduleController.Run(ctx, 1)
+			wg.Done()
+		}()
+
+		protectedApplicationController := controller.NewProtectedApplicationController(
+			s.sharedInformerFactory.Ark().V1().ProtectedApplications(),
+			s.arkClient.ArkV1(),
+			s.sharedInformerFactory.Ark().V1().Schedules(),
+			s.arkClient.ArkV1(),
+			s.logger,
+		)
+		wg.Add(1)
+		go func() {
+			protectedApplicationController.Run(ctx, 1)
+			wg.Done()
+		}()
+
+		backupPolicyController := controller.NewBackupPolicyController(
+			s.sharedInformerFactory.Ark().V1().BackupPolicies(),
+			s.arkClient.ArkV1(),
+			namespaceInformer,
+			s.sharedInformerFactory.Ark().V1().Schedules(),
+			s.arkClient.ArkV1(),
+			s.kubeClient.CoreV1(),
+			s.logger,
+			s.metrics,
+		)
+		wg.Add(1)
+		go func() {
+			backupPolicyController.Run(ctx, 1)
+			wg.Done()
+		}()
+
 		gcController := controller.NewGCController(
 			s.logger,
 			s.sharedInformerFactory.Ark().V1().Backups(),
 			s.sharedInformerFactory.Ark().V1().DeleteBackupRequests(),
 			s.arkClient.ArkV1(),
+			serverConfig.MaintenanceMode,
 		)
 		wg.Add(1)
 		go func() {
@@ -565,6 +869,23 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 			wg.Done()
 		}()
 
+		if s.config.backupExpiryWarningPeriod > 0 {
+			backupExpiryController := controller.NewBackupExpiryController(
+				s.logger,
+				s.sharedInformerFactory.Ark().V1().Backups(),
+				s.arkClient.ArkV1(),
+				s.kubeClient.CoreV1(),
+				s.config.backupExpiryWarningPeriod,
+				s.config.protectLatestScheduledBackups,
+				s.metrics,
+			)
+			wg.Add(1)
+			go func() {
+				backupExpiryController.Run(ctx, 1)
+				wg.Done()
+			}()
+		}
+
 		backupDeletionController := controller.NewBackupDeletionController(
 			s.logger,
 			s.sharedInformerFactory.Ark().V1().DeleteBackupRequests(),
@@ -572,6 +893,7 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 			s.arkClient.ArkV1(), // backupClient
 			s.sharedInformerFactory.Ark().V1().Restores(),
 			s.arkClient.ArkV1(), // restoreClient
+			s.arkClient.ArkV1(), // volumeSnapshotClient
 			backupTracker,
 			s.resticManager,
 			s.sharedInformerFactory.Ark().V1().PodVolumeBackups(),
@@ -587,16 +909,37 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 
 	}
 
+	restoreClientConfig := rest.CopyConfig(s.kubeClientConfig)
+	if s.config.restoreClientQPS > 0 {
+		restoreClientConfig.QPS = s.config.restoreClientQPS
+	}
+	if s.config.restoreClientBurst > 0 {
+		restoreClientConfig.Burst = s.config.restoreClientBurst
+	}
+	restoreDynamicFactory, err := client.NewDynamicFactoryForConfig(restoreClientConfig)
+	cmd.CheckError(err)
+
 	restorer, err := restore.NewKubernetesRestorer(
 		s.discoveryHelper,
-		client.NewDynamicFactory(s.dynamicClient),
-		s.config.restoreResourcePriorities,
+		restoreDynamicFactory,
+		restoreClientConfig,
+		serverConfig.RestoreResourcePriorities,
+		s.sharedInformerFactory.Ark().V1().RestorePriorities().Lister(),
 		s.kubeClient.CoreV1().Namespaces(),
+		s.kubeClient.CoreV1(),
 		s.resticManager,
-		s.config.podVolumeOperationTimeout,
+		serverConfig.ResticTimeout,
+		s.config.restoreMaxExtractedSize,
+		s.config.scratchDir,
+		s.config.resourceTimeouts,
+		s.config.restoreItemTimeout,
+		s.config.restoreFailureThreshold,
+		s.config.restoreCreateWorkers,
+		serverConfig.ReadinessChecks,
 		s.logger,
 	)
 	cmd.CheckError(err)
+	restorer.AddObserver(restore.NewDeploymentConfigRolloutObserver(s.dynamicClient, s.logger))
 
 	restoreController := controller.NewRestoreController(
 		s.namespace,
@@ -607,10 +950,17 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 		s.sharedInformerFactory.Ark().V1().Backups(),
 		s.sharedInformerFactory.Ark().V1().BackupStorageLocations(),
 		s.sharedInformerFactory.Ark().V1().VolumeSnapshotLocations(),
+		s.sharedInformerFactory.Ark().V1().VolumeSnapshots(),
+		s.sharedInformerFactory.Ark().V1().RestorePriorities(),
 		s.logger,
 		s.logLevel,
 		newPluginManager,
+		restoreTracker,
+		s.kubeClient.CoreV1(),
 		s.config.defaultBackupLocation,
+		s.clusterUID,
+		s.config.scratchDir,
+		s.config.restoreCacheSize,
 		s.metrics,
 	)
 
@@ -620,6 +970,92 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 		wg.Done()
 	}()
 
+	restoreVerificationController := controller.NewRestoreVerificationController(
+		s.sharedInformerFactory.Ark().V1().RestoreVerifications(),
+		s.arkClient.ArkV1(),
+		s.sharedInformerFactory.Ark().V1().Restores(),
+		s.arkClient.ArkV1(),
+		s.kubeClient.BatchV1(),
+		s.kubeClient.CoreV1(),
+		s.logger,
+	)
+	wg.Add(1)
+	go func() {
+		restoreVerificationController.Run(ctx, 1)
+		wg.Done()
+	}()
+
+	restoreUndoController := controller.NewRestoreUndoController(
+		s.logger,
+		s.sharedInformerFactory.Ark().V1().UndoRestoreRequests(),
+		s.arkClient.ArkV1(),
+		s.sharedInformerFactory.Ark().V1().Restores(),
+		s.sharedInformerFactory.Ark().V1().Backups(),
+		s.sharedInformerFactory.Ark().V1().BackupStorageLocations(),
+		restoreDynamicFactory,
+		s.kubeClient.CoreV1().Namespaces(),
+		newPluginManager,
+	)
+	wg.Add(1)
+	go func() {
+		restoreUndoController.Run(ctx, 1)
+		wg.Done()
+	}()
+
+	staleOperationController := controller.NewStaleOperationController(
+		s.namespace,
+		s.sharedInformerFactory.Ark().V1().Backups(),
+		s.arkClient.ArkV1(),
+		backupTracker,
+		s.sharedInformerFactory.Ark().V1().Restores(),
+		s.arkClient.ArkV1(),
+		restoreTracker,
+		s.config.inProgressTimeout,
+		s.logger,
+	)
+	wg.Add(1)
+	go func() {
+		staleOperationController.Run(ctx, 1)
+		wg.Done()
+	}()
+
+	// self-service backups and restores are created in users' own namespaces,
+	// so they need informers that watch all namespaces.
+	clusterInformerFactory := informers.NewSharedInformerFactory(s.arkClient, 0)
+
+	if s.config.enableSelfService {
+		if !s.config.restoreOnly {
+			selfServiceBackupController := controller.NewSelfServiceBackupController(
+				s.namespace,
+				clusterInformerFactory.Ark().V1().Backups(),
+				s.sharedInformerFactory.Ark().V1().Backups(),
+				s.arkClient.ArkV1(),
+				s.sharedInformerFactory.Ark().V1().BackupStorageLocations(),
+				s.config.defaultBackupLocation,
+				s.logger,
+			)
+			wg.Add(1)
+			go func() {
+				selfServiceBackupController.Run(ctx, 1)
+				wg.Done()
+			}()
+		}
+
+		selfServiceRestoreController := controller.NewSelfServiceRestoreController(
+			s.namespace,
+			clusterInformerFactory.Ark().V1().Restores(),
+			s.sharedInformerFactory.Ark().V1().Restores(),
+			s.arkClient.ArkV1(),
+			s.sharedInformerFactory.Ark().V1().Backups(),
+			s.logger,
+		)
+		wg.Add(1)
+		go func() {
+			selfServiceRestoreController.Run(ctx, 1)
+			wg.Done()
+		}()
+	}
+
 	downloadRequestController := controller.NewDownloadRequestController(
 		s.arkClient.ArkV1(),
 		s.sharedInformerFactory.Ark().V1().DownloadRequests(),
@@ -635,6 +1071,18 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 		wg.Done()
 	}()
 
+	snapshotLocationController := controller.NewVolumeSnapshotLocationController(
+		s.sharedInformerFactory.Ark().V1().VolumeSnapshotLocations(),
+		s.arkClient.ArkV1(),
+		newPluginManager,
+		s.logger,
+	)
+	wg.Add(1)
+	go func() {
+		snapshotLocationController.Run(ctx, 1)
+		wg.Done()
+	}()
+
 	resticRepoController := controller.NewResticRepositoryController(
 		s.logger,
 		s.sharedInformerFactory.Ark().V1().ResticRepositories(),
@@ -650,8 +1098,99 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 		wg.Done()
 	}()
 
+	// use a stand-alone daemonset informer so we only watch the restic daemonset
+	resticDaemonSetInformer := appsv1informers.NewFilteredDaemonSetInformer(
+		s.kubeClient,
+		s.namespace,
+		0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fmt.Sprintf("metadata.name=%s", restic.DaemonSet)
+		},
+	)
+	go resticDaemonSetInformer.Run(ctx.Done())
+
+	resticDaemonSetConfigController := controller.NewResticDaemonSetConfigController(
+		s.namespace,
+		s.sharedInformerFactory.Ark().V1().ResticDaemonSetConfigs(),
+		resticDaemonSetInformer,
+		s.kubeClient.AppsV1(),
+		s.logger,
+	)
+	wg.Add(1)
+	go func() {
+		resticDaemonSetConfigController.Run(ctx, 1)
+		wg.Done()
+	}()
+
+	dataDownloadController := controller.NewDataDownloadController(
+		s.logger,
+		s.sharedInformerFactory.Ark().V1().DataDownloads(),
+		s.arkClient.ArkV1(),
+		s.sharedInformerFactory.Ark().V1().PodVolumeBackups(),
+		s.sharedInformerFactory.Ark().V1().PodVolumeRestores(),
+		s.arkClient.ArkV1(),
+		s.kubeClient.CoreV1(),
+		s.kubeClient.CoreV1(),
+	)
+	wg.Add(1)
+	go func() {
+		dataDownloadController.Run(ctx, 1)
+		wg.Done()
+	}()
+
+	// errors serving the status API stop the server
+	statusAPIErrs := make(chan error, 1)
+
+	if s.config.statusAPIAddress != "" {
+		token, err := readStatusAPIToken(s.config.statusAPITokenFile)
+		if err != nil {
+			return err
+		}
+
+		if err := validateStatusAPITLS(s.config.statusAPIAddress, s.config.statusAPITLSCertFile, s.config.statusAPITLSKeyFile); err != nil {
+			return err
+		}
+
+		statusAPIHandler := statusapi.NewHandler(
+			s.namespace,
+			token,
+			s.sharedInformerFactory.Ark().V1().Backups().Lister(),
+			s.sharedInformerFactory.Ark().V1().Restores().Lister(),
+			s.sharedInformerFactory.Ark().V1().Schedules().Lister(),
+			s.sharedInformerFactory.Ark().V1().BackupStorageLocations().Lister(),
+			s.sharedInformerFactory.Ark().V1().VolumeSnapshotLocations().Lister(),
+			func(name string, kind api.DownloadTargetKind, w io.Writer) error {
+				return downloadrequest.Stream(s.arkClient.ArkV1(), s.namespace, name, kind, w, time.Minute)
+			},
+			s.logger,
+		)
+
+		statusAPIServer := &http.Server{Addr: s.config.statusAPIAddress, Handler: statusAPIHandler}
+
+		go func() {
+			s.logger.Infof("Starting status API server at address [%s]", s.config.statusAPIAddress)
+
+			var err error
+			if s.config.statusAPITLSCertFile != "" {
+				err = statusAPIServer.ListenAndServeTLS(s.config.statusAPITLSCertFile, s.config.statusAPITLSKeyFile)
+			} else {
+				err = statusAPIServer.ListenAndServe()
+			}
+			if err != http.ErrServerClosed {
+				statusAPIErrs <- errors.Wrapf(err, "error serving status API at %s", s.config.statusAPIAddress)
+			}
+		}()
+
+		go func() {
+			<-ctx.Done()
+			statusAPIServer.Close()
+		}()
+	}
+
 	// SHARED INFORMERS HAVE TO BE STARTED AFTER ALL CONTROLLERS
 	go s.sharedInformerFactory.Start(ctx.Done())
+	go clusterInformerFactory.Start(ctx.Done())
 
 	// TODO(1.0): remove
 	cache.WaitForCacheSync(ctx.Done(), s.sharedInformerFactory.Ark().V1().Backups().Informer().HasSynced)
@@ -659,12 +1198,18 @@ func (s *server) runControllers(defaultVolumeSnapshotLocations map[string]string
 
 	s.logger.Info("Server started successfully")
 
-	<-ctx.Done()
+	var serveErr error
+	select {
+	case <-ctx.Done():
+	case serveErr = <-statusAPIErrs:
+		s.logger.WithError(serveErr).Error("Status API server failed, shutting down")
+		s.cancelFunc()
+	}
 
 	s.logger.Info("Waiting for all controllers to shut down gracefully")
 	wg.Wait()
 
-	return nil
+	return serveErr
 }
 
 // TODO(1.0): remove
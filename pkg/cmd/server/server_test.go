@@ -17,9 +17,14 @@ limitations under the License.
 package server
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/heptio/ark/pkg/apis/ark/v1"
@@ -69,3 +74,128 @@ func TestArkResourcesExist(t *testing.T) {
 	arkAPIResourceList.APIResources = arkAPIResourceList.APIResources[:3]
 	assert.Error(t, server.arkResourcesExist())
 }
+
+func TestParseResourceTimeouts(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        map[string]string
+		expected    map[string]time.Duration
+		expectedErr bool
+	}{
+		{
+			name:     "no timeouts",
+			expected: map[string]time.Duration{},
+		},
+		{
+			name: "valid timeouts are parsed",
+			data: map[string]string{"pods": "10m", "widgets.example.com": "30s"},
+			expected: map[string]time.Duration{
+				"pods":                10 * time.Minute,
+				"widgets.example.com": 30 * time.Second,
+			},
+		},
+		{
+			name:        "invalid duration returns an error",
+			data:        map[string]string{"pods": "forever"},
+			expectedErr: true,
+		},
+		{
+			name:        "non-positive duration returns an error",
+			data:        map[string]string{"pods": "0s"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timeouts, err := parseResourceTimeouts(test.data)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, timeouts)
+		})
+	}
+}
+
+func TestReadStatusAPIToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("secret\n"), 0600))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, ioutil.WriteFile(emptyFile, []byte(" \n"), 0600))
+
+	token, err := readStatusAPIToken(tokenFile)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", token)
+
+	_, err = readStatusAPIToken("")
+	assert.Error(t, err)
+
+	_, err = readStatusAPIToken(emptyFile)
+	assert.Error(t, err)
+
+	_, err = readStatusAPIToken(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestValidateStatusAPITLS(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     string
+		certFile    string
+		keyFile     string
+		expectedErr bool
+	}{
+		{
+			name:    "loopback IP address without TLS",
+			address: "127.0.0.1:8090",
+		},
+		{
+			name:    "localhost without TLS",
+			address: "localhost:8090",
+		},
+		{
+			name:        "all interfaces without TLS",
+			address:     ":8090",
+			expectedErr: true,
+		},
+		{
+			name:        "non-loopback address without TLS",
+			address:     "10.0.0.1:8090",
+			expectedErr: true,
+		},
+		{
+			name:     "non-loopback address with TLS",
+			address:  ":8090",
+			certFile: "tls.crt",
+			keyFile:  "tls.key",
+		},
+		{
+			name:        "certificate without a key",
+			address:     "127.0.0.1:8090",
+			certFile:    "tls.crt",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid address",
+			address:     "8090",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateStatusAPITLS(test.address, test.certFile, test.keyFile)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ func DescribeBackup(
 	deleteRequests []arkv1api.DeleteBackupRequest,
 	podVolumeBackups []arkv1api.PodVolumeBackup,
 	details bool,
+	volumeDetails bool,
 	arkClient clientset.Interface,
 ) string {
 	return Describe(func(d *Describer) {
@@ -48,6 +49,12 @@ func DescribeBackup(
 			phase = arkv1api.BackupPhaseNew
 		}
 		d.Printf("Phase:\t%s\n", phase)
+		if phase == arkv1api.BackupPhaseQueued && backup.Status.QueuePosition > 0 {
+			d.Printf("Queue position:\t%d\n", backup.Status.QueuePosition)
+		}
+		if backup.Status.FailureReason != "" {
+			d.Printf("Failure reason:\t%s\n", backup.Status.FailureReason)
+		}
 
 		d.Println()
 		DescribeBackupSpec(d, backup.Spec)
@@ -64,6 +71,11 @@ func DescribeBackup(
 			d.Println()
 			DescribePodVolumeBackups(d, podVolumeBackups, details)
 		}
+
+		if volumeDetails {
+			d.Println()
+			DescribeBackupVolumeDetails(d, backup, arkClient)
+		}
 	})
 }
 
@@ -101,6 +113,12 @@ func DescribeBackupSpec(d *Describer, spec arkv1api.BackupSpec) {
 	d.Printf("\tExcluded:\t%s\n", s)
 
 	d.Printf("\tCluster-scoped:\t%s\n", BoolPointerString(spec.IncludeClusterResources, "excluded", "included", "auto"))
+	if spec.FilterProfile != "" {
+		d.Printf("\tFilter profile:\t%s\n", spec.FilterProfile)
+	}
+	if spec.FollowReferences {
+		d.Printf("\tFollow references:\ttrue\n")
+	}
 
 	d.Println()
 	s = "<none>"
@@ -109,14 +127,33 @@ func DescribeBackupSpec(d *Describer, spec arkv1api.BackupSpec) {
 	}
 	d.Printf("Label selector:\t%s\n", s)
 
+	if len(spec.HelmReleases) > 0 {
+		d.Println()
+		d.Printf("Helm releases:\t%s\n", strings.Join(spec.HelmReleases, ", "))
+		if spec.TillerNamespace != "" {
+			d.Printf("Tiller namespace:\t%s\n", spec.TillerNamespace)
+		}
+	}
+
 	d.Println()
 	d.Printf("Storage Location:\t%s\n", spec.StorageLocation)
 
+	if spec.ServiceAccountName != "" {
+		d.Println()
+		d.Printf("Service Account:\t%s\n", spec.ServiceAccountName)
+	}
+
 	d.Println()
 	d.Printf("Snapshot PVs:\t%s\n", BoolPointerString(spec.SnapshotVolumes, "false", "true", "auto"))
+	if spec.ResticHostPathVolumes {
+		d.Printf("Restic hostPath volumes:\ttrue\n")
+	}
 
 	d.Println()
 	d.Printf("TTL:\t%s\n", spec.TTL.Duration)
+	if spec.Hold {
+		d.Printf("Hold:\ttrue\n")
+	}
 
 	d.Println()
 	if len(spec.Hooks.Resources) == 0 {
@@ -183,6 +220,12 @@ func DescribeBackupStatus(d *Describer, backup *arkv1api.Backup, details bool, a
 	status := backup.Status
 
 	d.Printf("Backup Format Version:\t%d\n", status.Version)
+	if status.ServerVersion != "" {
+		d.Printf("Ark Server Version:\t%s\n", status.ServerVersion)
+	}
+	if status.ClusterUID != "" {
+		d.Printf("Cluster UID:\t%s\n", status.ClusterUID)
+	}
 
 	d.Println()
 	// "<n/a>" output should only be applicable for backups that failed validation
@@ -210,6 +253,27 @@ func DescribeBackupStatus(d *Describer, backup *arkv1api.Backup, details bool, a
 		}
 	}
 
+	if len(status.ExtraItems) > 0 {
+		d.Println()
+		d.Printf("Extra items (referenced from included namespaces):\n")
+		for _, item := range status.ExtraItems {
+			d.Printf("\t%s\n", item)
+		}
+	}
+
+	if len(status.HelmReleases) > 0 {
+		d.Println()
+		d.Printf("Helm releases:\n")
+		for _, release := range status.HelmReleases {
+			d.Printf("\t%s:\trevision %d (%s) from %s\n", release.Name, release.Revision, release.Status, release.TillerNamespace)
+		}
+	}
+
+	if status.TruncatedStatus {
+		d.Println()
+		d.Printf("Some lists above were truncated. Run `ark backup download %s --kind statusdetails` for the complete lists.\n", backup.Name)
+	}
+
 	d.Println()
 	if len(status.VolumeBackups) > 0 {
 		// pre-v0.10 backup
@@ -261,6 +325,70 @@ func printSnapshot(d *Describer, pvName, snapshotID, volumeType, volumeAZ string
 	d.Printf("\t\tIOPS:\t%s\n", iopsString)
 }
 
+// DescribeBackupVolumeDetails describes each volume snapshotted by a backup, using
+// the volume snapshot and pod volume snapshot files in the backup's storage location.
+func DescribeBackupVolumeDetails(d *Describer, backup *arkv1api.Backup, arkClient clientset.Interface) {
+	d.Printf("Volume Snapshots:")
+	var snapshots []*volume.Snapshot
+	if err := downloadAndDecode(arkClient, backup, arkv1api.DownloadTargetKindBackupVolumeSnapshots, &snapshots); err != nil {
+		d.Printf("\t<error getting volume snapshot info: %v>\n", err)
+	} else if len(snapshots) == 0 {
+		d.Printf("\t<none>\n")
+	} else {
+		d.Println()
+		for _, snap := range snapshots {
+			d.Printf("\t%s:\n", snap.Spec.PersistentVolumeName)
+			d.Printf("\t\tSnapshot ID:\t%s\n", snap.Status.ProviderSnapshotID)
+			d.Printf("\t\tSize:\t%s\n", stringOrNA(snap.Spec.VolumeSize))
+			d.Printf("\t\tType:\t%s\n", snap.Spec.VolumeType)
+			d.Printf("\t\tAvailability Zone:\t%s\n", snap.Spec.VolumeAZ)
+			iopsString := "<N/A>"
+			if snap.Spec.VolumeIOPS != nil {
+				iopsString = fmt.Sprintf("%d", *snap.Spec.VolumeIOPS)
+			}
+			d.Printf("\t\tIOPS:\t%s\n", iopsString)
+			d.Printf("\t\tPhase:\t%s\n", snap.Status.Phase)
+		}
+	}
+
+	d.Println()
+	d.Printf("Restic Snapshots:")
+	var podSnapshots []*volume.PodVolumeSnapshot
+	if err := downloadAndDecode(arkClient, backup, arkv1api.DownloadTargetKindBackupPodVolumeSnapshots, &podSnapshots); err != nil {
+		d.Printf("\t<error getting pod volume snapshot info: %v>\n", err)
+		return
+	}
+	if len(podSnapshots) == 0 {
+		d.Printf("\t<none>\n")
+		return
+	}
+
+	d.Println()
+	for _, snap := range podSnapshots {
+		d.Printf("\t%s/%s: %s\n", snap.PodNamespace, snap.PodName, snap.Volume)
+		d.Printf("\t\tPersistent Volume Claim:\t%s\n", stringOrNA(snap.PersistentVolumeClaim))
+		d.Printf("\t\tSnapshot ID:\t%s\n", snap.SnapshotID)
+	}
+}
+
+// downloadAndDecode downloads the given target of a backup and decodes its
+// JSON contents into into.
+func downloadAndDecode(arkClient clientset.Interface, backup *arkv1api.Backup, kind arkv1api.DownloadTargetKind, into interface{}) error {
+	buf := new(bytes.Buffer)
+	if err := downloadrequest.Stream(arkClient.ArkV1(), backup.Namespace, backup.Name, kind, buf, downloadRequestTimeout); err != nil {
+		return err
+	}
+
+	return json.NewDecoder(buf).Decode(into)
+}
+
+func stringOrNA(s string) string {
+	if s == "" {
+		return "<N/A>"
+	}
+	return s
+}
+
 // DescribeDeleteBackupRequests describes delete backup requests in human-readable format.
 func DescribeDeleteBackupRequests(d *Describer, requests []arkv1api.DeleteBackupRequest) {
 	d.Printf("Deletion Attempts")
@@ -312,6 +440,7 @@ func DescribePodVolumeBackups(d *Describer, backups []arkv1api.PodVolumeBackup,
 	for _, phase := range []string{
 		string(arkv1api.PodVolumeBackupPhaseCompleted),
 		string(arkv1api.PodVolumeBackupPhaseFailed),
+		"Timed Out",
 		"In Progress",
 		string(arkv1api.PodVolumeBackupPhaseNew),
 	} {
@@ -348,6 +477,7 @@ func groupByPhase(backups []arkv1api.PodVolumeBackup) map[string][]arkv1api.PodV
 	phaseToGroup := map[arkv1api.PodVolumeBackupPhase]string{
 		arkv1api.PodVolumeBackupPhaseCompleted:  string(arkv1api.PodVolumeBackupPhaseCompleted),
 		arkv1api.PodVolumeBackupPhaseFailed:     string(arkv1api.PodVolumeBackupPhaseFailed),
+		arkv1api.PodVolumeBackupPhaseTimedOut:   "Timed Out",
 		arkv1api.PodVolumeBackupPhaseInProgress: "In Progress",
 		arkv1api.PodVolumeBackupPhaseNew:        string(arkv1api.PodVolumeBackupPhaseNew),
 		"": string(arkv1api.PodVolumeBackupPhaseNew),
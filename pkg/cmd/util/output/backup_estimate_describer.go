@@ -0,0 +1,67 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// DescribeBackupEstimate describes the items and volumes a completed
+// BackupEstimate found, by resource.
+func DescribeBackupEstimate(estimate *v1.BackupEstimate) string {
+	return Describe(func(d *Describer) {
+		d.Printf("Phase:\t%s\n", estimate.Status.Phase)
+		if estimate.Status.FailureReason != "" {
+			d.Printf("Failure reason:\t%s\n", estimate.Status.FailureReason)
+			return
+		}
+
+		d.Println()
+		d.Printf("RESOURCE\tITEMS\tSIZE\n")
+		for _, resource := range estimate.Status.Resources {
+			d.Printf("%s\t%d\t%s\n", resource.Resource, resource.Items, byteSize(resource.Size))
+		}
+
+		d.Println()
+		d.Printf("Total items:\t%d\n", estimate.Status.TotalItems)
+		d.Printf("Total size:\t%s (uncompressed)\n", byteSize(estimate.Status.TotalSize))
+		d.Printf("Volumes:\t%d\n", estimate.Status.Volumes)
+		d.Printf("Volumes capacity:\t%s\n", byteSize(estimate.Status.VolumesSize))
+		if estimate.Status.Errors > 0 {
+			d.Printf("Errors:\t%d items couldn't be collected and aren't included\n", estimate.Status.Errors)
+		}
+	})
+}
+
+// byteSize formats n bytes using the largest binary unit that keeps
+// the value at least 1, e.g. "1.5 MiB".
+func byteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -91,6 +91,9 @@ func printBackup(backup *arkv1api.Backup, w io.Writer, options printers.PrintOpt
 	if backup.DeletionTimestamp != nil && !backup.DeletionTimestamp.Time.IsZero() {
 		status = "Deleting"
 	}
+	if status == arkv1api.BackupPhaseQueued && backup.Status.QueuePosition > 0 {
+		status = arkv1api.BackupPhase(fmt.Sprintf("%s (%d)", status, backup.Status.QueuePosition))
+	}
 
 	location := backup.Spec.StorageLocation
 
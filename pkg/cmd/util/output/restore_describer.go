@@ -19,6 +19,7 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -29,12 +30,18 @@ import (
 	clientset "github.com/heptio/ark/pkg/generated/clientset/versioned"
 )
 
-func DescribeRestore(restore *v1.Restore, podVolumeRestores []v1.PodVolumeRestore, details bool, arkClient clientset.Interface) string {
+func DescribeRestore(restore *v1.Restore, podVolumeRestores []v1.PodVolumeRestore, undoRequests []v1.UndoRestoreRequest, details bool, arkClient clientset.Interface) string {
 	return Describe(func(d *Describer) {
 		d.DescribeMetadata(restore.ObjectMeta)
 
 		d.Println()
 		d.Printf("Backup:\t%s\n", restore.Spec.BackupName)
+		if restore.Spec.ApplicationName != "" {
+			d.Printf("Application:\t%s\n", restore.Spec.ApplicationName)
+		}
+		if restore.Spec.AsOf != nil {
+			d.Printf("As Of:\t%s\n", restore.Spec.AsOf.Time)
+		}
 
 		d.Println()
 		d.Printf("Namespaces:\n")
@@ -72,6 +79,54 @@ func DescribeRestore(restore *v1.Restore, podVolumeRestores []v1.PodVolumeRestor
 		d.Println()
 		d.DescribeMap("Namespace mappings", restore.Spec.NamespaceMapping)
 
+		if len(restore.Spec.NamespaceOrder) > 0 {
+			d.Println()
+			d.Printf("Namespace order:\t%s\n", strings.Join(restore.Spec.NamespaceOrder, ", "))
+		}
+
+		if restore.Spec.IterationMode != "" {
+			d.Println()
+			d.Printf("Iteration mode:\t%s\n", restore.Spec.IterationMode)
+		}
+
+		if restore.Spec.SecurityPolicyTranslation != "" {
+			d.Println()
+			d.Printf("Security policy translation:\t%s\n", restore.Spec.SecurityPolicyTranslation)
+			d.DescribeMap("Security policy mappings", restore.Spec.SecurityPolicyMapping)
+		}
+
+		if len(restore.Spec.AdditionalLabels) > 0 {
+			d.Println()
+			d.DescribeMap("Restored object labels", restore.Spec.AdditionalLabels)
+		}
+
+		if len(restore.Spec.AdditionalAnnotations) > 0 {
+			d.Println()
+			d.DescribeMap("Restored object annotations", restore.Spec.AdditionalAnnotations)
+		}
+
+		if restore.Spec.OmitLegacyRestoreLabel {
+			d.Println()
+			d.Printf("Legacy restore label:\tomitted\n")
+		}
+
+		if len(restore.Spec.StripAnnotations) > 0 {
+			d.Println()
+			d.Printf("Strip annotations:\t%s\n", strings.Join(restore.Spec.StripAnnotations, ", "))
+		}
+
+		if len(restore.Spec.HostnameRewrites) > 0 {
+			d.Println()
+			d.Printf("Hostname rewrites:\n")
+			for _, rewrite := range restore.Spec.HostnameRewrites {
+				if rewrite.Regex != "" {
+					d.Printf("\tregex %s:\t%s\n", rewrite.Regex, rewrite.Replacement)
+				} else {
+					d.Printf("\tsuffix %s:\t%s\n", rewrite.Suffix, rewrite.Replacement)
+				}
+			}
+		}
+
 		d.Println()
 		s = "<none>"
 		if restore.Spec.LabelSelector != nil {
@@ -82,8 +137,100 @@ func DescribeRestore(restore *v1.Restore, podVolumeRestores []v1.PodVolumeRestor
 		d.Println()
 		d.Printf("Restore PVs:\t%s\n", BoolPointerString(restore.Spec.RestorePVs, "false", "true", "auto"))
 
+		if len(restore.Spec.VolumeIDMapping) > 0 {
+			d.Println()
+			d.DescribeMap("Volume ID mappings", restore.Spec.VolumeIDMapping)
+		}
+
+		if len(restore.Spec.VolumeOverrides) > 0 {
+			d.Println()
+			d.Printf("Volume overrides:\n")
+			for _, override := range restore.Spec.VolumeOverrides {
+				storageClass := override.StorageClassName
+				if storageClass == "" {
+					storageClass = "<all>"
+				}
+				volumeType, iops := override.VolumeType, "<unchanged>"
+				if volumeType == "" {
+					volumeType = "<unchanged>"
+				} else {
+					iops = "<none>"
+				}
+				if override.IOPS != nil {
+					iops = fmt.Sprintf("%d", *override.IOPS)
+				}
+				d.Printf("\t%s:\ttype=%s, iops=%s\n", storageClass, volumeType, iops)
+			}
+		}
+
+		if restore.Spec.ServiceAccountName != "" {
+			d.Println()
+			d.Printf("Service Account:\t%s\n", restore.Spec.ServiceAccountName)
+		}
+
+		if restore.Spec.ConflictPolicy != "" {
+			d.Println()
+			d.Printf("Conflict Policy:\t%s\n", restore.Spec.ConflictPolicy)
+		}
+
+		if len(restore.Spec.RecreateResources) > 0 {
+			d.Printf("Recreate Resources:\t%s\n", strings.Join(restore.Spec.RecreateResources, ", "))
+			recreateTimeout := "1m0s"
+			if restore.Spec.RecreateTimeout != nil {
+				recreateTimeout = restore.Spec.RecreateTimeout.Duration.String()
+			}
+			d.Printf("Recreate Timeout:\t%s\n", recreateTimeout)
+		}
+
+		if restore.Spec.ConfirmSourceCluster {
+			d.Printf("Confirm Source Cluster:\ttrue\n")
+		}
+
+		if restore.Spec.CreateNamespaces != "" {
+			d.Println()
+			d.Printf("Create Namespaces:\t%s\n", restore.Spec.CreateNamespaces)
+		}
+
+		if restore.Spec.MergeNamespaceMetadata {
+			d.Println()
+			d.Printf("Merge Namespace Metadata:\ttrue\n")
+		}
+
+		if restore.Spec.VerifyPodVolumes {
+			d.Println()
+			d.Printf("Verify Pod Volumes:\ttrue\n")
+		}
+
+		if restore.Spec.WaitForReady {
+			waitForReadyTimeout := "5m0s"
+			if restore.Spec.WaitForReadyTimeout != nil {
+				waitForReadyTimeout = restore.Spec.WaitForReadyTimeout.Duration.String()
+			}
+			d.Println()
+			d.Printf("Wait For Ready:\ttrue (timeout %s)\n", waitForReadyTimeout)
+		}
+
+		if restore.Spec.ResourceTimeout != nil {
+			d.Println()
+			d.Printf("Resource Timeout:\t%s\n", restore.Spec.ResourceTimeout.Duration)
+		}
+
+		if restore.Spec.RestorePriorityName != "" {
+			d.Println()
+			d.Printf("Restore Priority:\t%s\n", restore.Spec.RestorePriorityName)
+		}
+
 		d.Println()
 		d.Printf("Phase:\t%s\n", restore.Status.Phase)
+		if !restore.Status.StartTimestamp.IsZero() {
+			d.Printf("Started:\t%s\n", restore.Status.StartTimestamp.Time)
+		}
+		if restore.Status.FailureReason != "" {
+			d.Printf("Failure reason:\t%s\n", restore.Status.FailureReason)
+		}
+		if progress := restore.Status.Progress; progress != nil && restore.Status.Phase == v1.RestorePhaseInProgress {
+			describeRestoreProgress(d, progress)
+		}
 
 		d.Println()
 		d.Printf("Validation errors:")
@@ -96,7 +243,17 @@ func DescribeRestore(restore *v1.Restore, podVolumeRestores []v1.PodVolumeRestor
 		}
 
 		d.Println()
-		describeRestoreResults(d, restore, arkClient)
+		describeRestoreResults(d, restore, details, arkClient)
+
+		if len(restore.Status.ResourceTimings) > 0 {
+			d.Println()
+			describeResourceTimings(d, restore.Status.ResourceTimings, details)
+		}
+
+		if len(undoRequests) > 0 {
+			d.Println()
+			describeUndoRestoreRequests(d, undoRequests)
+		}
 
 		if len(podVolumeRestores) > 0 {
 			d.Println()
@@ -105,7 +262,60 @@ func DescribeRestore(restore *v1.Restore, podVolumeRestores []v1.PodVolumeRestor
 	})
 }
 
-func describeRestoreResults(d *Describer, restore *v1.Restore, arkClient clientset.Interface) {
+// describeRestoreProgress describes the item an in-progress restore is working on.
+func describeRestoreProgress(d *Describer, progress *v1.RestoreProgress) {
+	name := progress.Name
+	if progress.Namespace != "" {
+		name = progress.Namespace + "/" + name
+	}
+	d.Printf("Current item:\t%s %s (since %s)\n", progress.Resource, name, progress.UpdateTimestamp.Time)
+	d.Printf("Items restored:\t%d\n", progress.ItemsRestored)
+}
+
+// describeResourceTimings describes how long a restore spent on each resource, or
+// unless details is true, only on the slowest few.
+func describeResourceTimings(d *Describer, timings []v1.RestoreResourceTiming, details bool) {
+	if details || len(timings) <= maxDescribedResourceTimings {
+		d.Printf("Resource timings:\n")
+	} else {
+		d.Printf("Resource timings (specify --details for all %d resources):\n", len(timings))
+		timings = timings[:maxDescribedResourceTimings]
+	}
+
+	for _, timing := range timings {
+		d.Printf("\t%s:\t%s\n", timing.Resource, timing.Duration.Duration)
+	}
+}
+
+// describeUndoRestoreRequests describes undo restore requests in human-readable format.
+func describeUndoRestoreRequests(d *Describer, requests []v1.UndoRestoreRequest) {
+	d.Println("Undo Attempts:")
+
+	for i, req := range requests {
+		if i > 0 {
+			d.Println()
+		}
+
+		d.Printf("\t%s: %s\n", req.CreationTimestamp.String(), req.Status.Phase)
+		if req.Status.Phase != v1.UndoRestoreRequestPhaseProcessed {
+			continue
+		}
+
+		d.Printf("\tObjects deleted:\t%d\n", req.Status.DeletedItems)
+		d.Printf("\tObjects skipped:\t%d\n", req.Status.SkippedItems)
+		if req.Spec.DeleteNamespaces {
+			d.Printf("\tNamespaces deleted:\t%d\n", req.Status.DeletedNamespaces)
+		}
+		if len(req.Status.Errors) > 0 {
+			d.Printf("\tErrors:\n")
+			for _, err := range req.Status.Errors {
+				d.Printf("\t\t%s\n", err)
+			}
+		}
+	}
+}
+
+func describeRestoreResults(d *Describer, restore *v1.Restore, details bool, arkClient clientset.Interface) {
 	if restore.Status.Warnings == 0 && restore.Status.Errors == 0 {
 		d.Printf("Warnings:\t<none>\nErrors:\t<none>\n")
 		return
@@ -124,21 +334,161 @@ func describeRestoreResults(d *Describer, restore *v1.Restore, arkClient clients
 		return
 	}
 
-	describeRestoreResult(d, "Warnings", resultMap["warnings"])
+	describeRestoreResultCounts(d, resultMap["warnings"], resultMap["errors"])
 	d.Println()
-	describeRestoreResult(d, "Errors", resultMap["errors"])
+	describeRestoreResult(d, "Warnings", resultMap["warnings"], details)
+	d.Println()
+	describeRestoreResult(d, "Errors", resultMap["errors"], details)
+
+	if conflicts := resultMap["warnings"].Conflicts; len(conflicts) > 0 {
+		d.Println()
+		describeRestoreConflicts(d, conflicts, details)
+	}
+}
+
+// describeRestoreConflicts describes objects that weren't restored because they
+// already exist in the cluster, in human-readable format.
+func describeRestoreConflicts(d *Describer, conflicts []v1.RestoreConflict, details bool) {
+	if !details {
+		d.Printf("Conflicts (specify --details for more information):\t%d\n", len(conflicts))
+		return
+	}
+
+	d.Printf("Conflicts:\n")
+	for _, conflict := range conflicts {
+		name := conflict.Name
+		if conflict.Namespace != "" {
+			name = conflict.Namespace + "/" + name
+		}
+		d.Printf("\t%s %s:\n", conflict.Resource, name)
+
+		for _, field := range conflict.Fields {
+			d.Printf("\t\t%s:\tbackup=%s\tcluster=%s\n", field.Path, valueOrNone(field.Backup), valueOrNone(field.Cluster))
+		}
+	}
 }
 
-func describeRestoreResult(d *Describer, name string, result v1.RestoreResult) {
-	d.Printf("%s:\n", name)
-	d.DescribeSlice(1, "Ark", result.Ark)
-	d.DescribeSlice(1, "Cluster", result.Cluster)
+// firstResultMessages returns messages, or unless details is true, only the first
+// few of them followed by a count of the rest.
+func firstResultMessages(messages []string, details bool) []string {
+	if details || len(messages) <= maxDescribedResultMessages {
+		return messages
+	}
+
+	first := make([]string, maxDescribedResultMessages, maxDescribedResultMessages+1)
+	copy(first, messages)
+	return append(first, fmt.Sprintf("... and %d more", len(messages)-maxDescribedResultMessages))
+}
+
+func valueOrNone(val string) string {
+	if val == "" {
+		return "<none>"
+	}
+	return val
+}
+
+// maxDescribedResultMessages is the number of a restore's warnings or errors
+// that are described for Ark, the cluster, and each namespace without --details.
+const maxDescribedResultMessages = 3
+
+// maxDescribedResourceTimings is the number of a restore's slowest resources
+// whose timings are described without --details.
+const maxDescribedResourceTimings = 5
+
+const (
+	arkResultLocation     = "<ark>"
+	clusterResultLocation = "<cluster>"
+)
+
+// restoreResultCounts returns the number of messages in result for Ark, for
+// the cluster, and for each namespace, including messages that were only
+// counted in summaries.
+func restoreResultCounts(result v1.RestoreResult) map[string]int {
+	counts := make(map[string]int)
+	if len(result.Ark) > 0 {
+		counts[arkResultLocation] = len(result.Ark)
+	}
+	if len(result.Cluster) > 0 {
+		counts[clusterResultLocation] = len(result.Cluster)
+	}
+	for ns, messages := range result.Namespaces {
+		counts[ns] += len(messages)
+	}
+	for _, summary := range result.Summaries {
+		location := summary.Namespace
+		if location == "" {
+			location = clusterResultLocation
+		}
+		counts[location] += summary.Count
+	}
+	return counts
+}
+
+// describeRestoreResultCounts describes the number of warnings and errors for
+// Ark, the cluster, and each namespace as a table.
+func describeRestoreResultCounts(d *Describer, warnings, errors v1.RestoreResult) {
+	warningCounts, errorCounts := restoreResultCounts(warnings), restoreResultCounts(errors)
+
+	var namespaces []string
+	for location := range warningCounts {
+		namespaces = append(namespaces, location)
+	}
+	for location := range errorCounts {
+		if _, ok := warningCounts[location]; !ok {
+			namespaces = append(namespaces, location)
+		}
+	}
+	sort.Strings(namespaces)
+
+	// Ark and the cluster are listed before the namespaces
+	var locations []string
+	for _, location := range []string{arkResultLocation, clusterResultLocation} {
+		if warningCounts[location] > 0 || errorCounts[location] > 0 {
+			locations = append(locations, location)
+		}
+	}
+	for _, ns := range namespaces {
+		if ns != arkResultLocation && ns != clusterResultLocation {
+			locations = append(locations, ns)
+		}
+	}
+
+	d.Printf("Results by namespace:\n")
+	d.Printf("\tNAMESPACE\tWARNINGS\tERRORS\n")
+	for _, location := range locations {
+		d.Printf("\t%s\t%d\t%d\n", location, warningCounts[location], errorCounts[location])
+	}
+}
+
+func describeRestoreResult(d *Describer, name string, result v1.RestoreResult, details bool) {
+	if details {
+		d.Printf("%s:\n", name)
+	} else {
+		d.Printf("%s (specify --details for all messages):\n", name)
+	}
+	d.DescribeSlice(1, "Ark", firstResultMessages(result.Ark, details))
+	d.DescribeSlice(1, "Cluster", firstResultMessages(result.Cluster, details))
 	if len(result.Namespaces) == 0 {
 		d.Printf("\tNamespaces: <none>\n")
 	} else {
 		d.Printf("\tNamespaces:\n")
-		for ns, warnings := range result.Namespaces {
-			d.DescribeSlice(2, ns, warnings)
+		namespaces := make([]string, 0, len(result.Namespaces))
+		for ns := range result.Namespaces {
+			namespaces = append(namespaces, ns)
+		}
+		sort.Strings(namespaces)
+		for _, ns := range namespaces {
+			d.DescribeSlice(2, ns, firstResultMessages(result.Namespaces[ns], details))
+		}
+	}
+	if len(result.Summaries) > 0 {
+		d.Printf("\tRepeated (omitted from above):\n")
+		for _, summary := range result.Summaries {
+			location := "cluster"
+			if summary.Namespace != "" {
+				location = "namespace " + summary.Namespace
+			}
+			d.Printf("\t\t%d more %s in %s: %s (e.g. %s)\n", summary.Count, summary.Resource, location, summary.Message, strings.Join(summary.SampleNames, ", "))
 		}
 	}
 }
@@ -158,6 +508,7 @@ func describePodVolumeRestores(d *Describer, restores []v1.PodVolumeRestore, det
 	for _, phase := range []string{
 		string(v1.PodVolumeRestorePhaseCompleted),
 		string(v1.PodVolumeRestorePhaseFailed),
+		"Timed Out",
 		"In Progress",
 		string(v1.PodVolumeRestorePhaseNew),
 	} {
@@ -194,6 +545,7 @@ func groupRestoresByPhase(restores []v1.PodVolumeRestore) map[string][]v1.PodVol
 	phaseToGroup := map[v1.PodVolumeRestorePhase]string{
 		v1.PodVolumeRestorePhaseCompleted:  string(v1.PodVolumeRestorePhaseCompleted),
 		v1.PodVolumeRestorePhaseFailed:     string(v1.PodVolumeRestorePhaseFailed),
+		v1.PodVolumeRestorePhaseTimedOut:   "Timed Out",
 		v1.PodVolumeRestorePhaseInProgress: "In Progress",
 		v1.PodVolumeRestorePhaseNew:        string(v1.PodVolumeRestorePhaseNew),
 		"": string(v1.PodVolumeRestorePhaseNew),
@@ -0,0 +1,91 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+func TestDescribeRestoreResultCounts(t *testing.T) {
+	warnings := v1.RestoreResult{
+		Cluster:    []string{"w1"},
+		Namespaces: map[string][]string{"ns-2": {"w2", "w3"}},
+	}
+	errors := v1.RestoreResult{
+		Ark:        []string{"e1"},
+		Namespaces: map[string][]string{"ns-1": {"e2"}},
+		Summaries: []v1.RestoreResultSummary{
+			{Resource: "configmaps", Namespace: "ns-1", Count: 10},
+			{Resource: "clusterroles", Count: 2},
+		},
+	}
+
+	expected := `Results by namespace:
+  NAMESPACE  WARNINGS  ERRORS
+  <ark>      0         1
+  <cluster>  1         2
+  ns-1       0         11
+  ns-2       2         0
+`
+
+	assert.Equal(t, expected, Describe(func(d *Describer) {
+		describeRestoreResultCounts(d, warnings, errors)
+	}))
+}
+
+func TestFirstResultMessages(t *testing.T) {
+	messages := []string{"a", "b", "c", "d", "e"}
+
+	assert.Equal(t, []string{"a", "b", "c", "... and 2 more"}, firstResultMessages(messages, false))
+	assert.Equal(t, messages, firstResultMessages(messages, true))
+	assert.Equal(t, []string{"a", "b"}, firstResultMessages(messages[:2], false))
+}
+
+func TestDescribeResourceTimings(t *testing.T) {
+	var timings []v1.RestoreResourceTiming
+	for i, resource := range []string{"persistentvolumes", "pods", "deployments.apps", "configmaps", "secrets", "services"} {
+		timings = append(timings, v1.RestoreResourceTiming{
+			Resource: resource,
+			Duration: metav1.Duration{Duration: time.Duration(6-i) * time.Second},
+		})
+	}
+
+	expected := `Resource timings (specify --details for all 6 resources):
+  persistentvolumes:  6s
+  pods:               5s
+  deployments.apps:   4s
+  configmaps:         3s
+  secrets:            2s
+`
+	assert.Equal(t, expected, Describe(func(d *Describer) {
+		describeResourceTimings(d, timings, false)
+	}))
+
+	expected = `Resource timings:
+  persistentvolumes:  6s
+  pods:               5s
+`
+	assert.Equal(t, expected, Describe(func(d *Describer) {
+		describeResourceTimings(d, timings[:2], false)
+	}))
+}
@@ -65,4 +65,17 @@ func DescribeScheduleStatus(d *Describer, status v1.ScheduleStatus) {
 		lastBackup = fmt.Sprintf("%v", status.LastBackup.Time)
 	}
 	d.Printf("Last Backup:\t%s\n", lastBackup)
+
+	if status.LastBackupName != "" {
+		d.Printf("Last Backup Name:\t%s\n", status.LastBackupName)
+		d.Printf("Last Backup Sequence:\t%d\n", status.LastBackupSequence)
+		if status.LastBackupPhase != "" {
+			d.Printf("Last Backup Phase:\t%s\n", status.LastBackupPhase)
+		}
+		d.Printf("Consecutive Failures:\t%d\n", status.ConsecutiveFailures)
+	}
+
+	if !status.NextRunTime.IsZero() {
+		d.Printf("Next Run:\t%s\n", status.NextRunTime.Time)
+	}
 }
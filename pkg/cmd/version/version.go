@@ -18,22 +18,123 @@ package version
 
 import (
 	"fmt"
+	"io"
+	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
 	"github.com/heptio/ark/pkg/buildinfo"
+	"github.com/heptio/ark/pkg/client"
+	"github.com/heptio/ark/pkg/cmd"
 )
 
-func NewCommand() *cobra.Command {
+func NewCommand(f client.Factory) *cobra.Command {
+	o := new(VersionOptions)
+
 	c := &cobra.Command{
 		Use:   "version",
 		Short: "Print the ark version and associated image",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("Version: %s\n", buildinfo.Version)
-			fmt.Printf("Git commit: %s\n", buildinfo.GitSHA)
-			fmt.Printf("Git tree state: %s\n", buildinfo.GitTreeState)
+		Long: `Print the versions of the ark client and the Ark server, and check that they're compatible.
+
+Versions are compatible if their major versions are the same and, before 1.0, their minor
+versions too. If they aren't, a warning is printed, or with --strict, the command fails.`,
+		Run: func(c *cobra.Command, args []string) {
+			cmd.CheckError(o.Complete(f))
+			cmd.CheckError(o.Run(os.Stdout))
 		},
 	}
 
+	o.BindFlags(c.Flags())
+
 	return c
 }
+
+// VersionOptions are the options for the version command.
+type VersionOptions struct {
+	ClientOnly bool
+	Strict     bool
+
+	namespace  string
+	kubeClient kubernetes.Interface
+}
+
+func (o *VersionOptions) BindFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.ClientOnly, "client-only", o.ClientOnly, "only print the client's version, without contacting the Ark server")
+	flags.BoolVar(&o.Strict, "strict", o.Strict, "fail if the server's version is incompatible with the client's, or their compatibility can't be determined")
+}
+
+func (o *VersionOptions) Complete(f client.Factory) error {
+	if o.ClientOnly {
+		return nil
+	}
+
+	kubeClient, err := f.KubeClient()
+	if err != nil {
+		return err
+	}
+
+	o.namespace = f.Namespace()
+	o.kubeClient = kubeClient
+	return nil
+}
+
+func (o *VersionOptions) Run(w io.Writer) error {
+	fmt.Fprintln(w, "Client:")
+	fmt.Fprintf(w, "\tVersion: %s\n", buildinfo.Version)
+	fmt.Fprintf(w, "\tGit commit: %s\n", buildinfo.GitSHA)
+	fmt.Fprintf(w, "\tGit tree state: %s\n", buildinfo.GitTreeState)
+
+	if o.ClientOnly {
+		return nil
+	}
+
+	fmt.Fprintln(w, "Server:")
+
+	configMap, err := o.kubeClient.CoreV1().ConfigMaps(o.namespace).Get(api.ServerVersionConfigMap, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting server version")
+	}
+
+	var serverVersion string
+	if err == nil {
+		serverVersion = configMap.Data[api.ServerVersionKey]
+		fmt.Fprintf(w, "\tVersion: %s\n", serverVersion)
+		fmt.Fprintf(w, "\tGit commit: %s\n", configMap.Data[api.ServerGitCommitKey])
+	} else {
+		fmt.Fprintln(w, "\tVersion: <unknown>")
+	}
+
+	if problem := compatibilityProblem(buildinfo.Version, serverVersion, err == nil); problem != "" {
+		if o.Strict {
+			return errors.New(problem)
+		}
+		fmt.Fprintf(w, "WARNING: %s\n", problem)
+	}
+
+	return nil
+}
+
+// compatibilityProblem returns a description of why the client and server
+// versions aren't known to be compatible, or "" if they are. serverFound is
+// whether the server has recorded its version.
+func compatibilityProblem(clientVersion, serverVersion string, serverFound bool) string {
+	if !serverFound {
+		return "the server's version is unknown, either because it hasn't started or because it's too old to record its version"
+	}
+
+	compatible, err := buildinfo.Compatible(clientVersion, serverVersion)
+	if err != nil {
+		return fmt.Sprintf("unable to check the compatibility of client version %q and server version %q: %v", clientVersion, serverVersion, err)
+	}
+	if !compatible {
+		return fmt.Sprintf("client version %s is incompatible with server version %s; use a client with the same version as the server", clientVersion, serverVersion)
+	}
+
+	return ""
+}
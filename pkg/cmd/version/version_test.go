@@ -0,0 +1,66 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatibilityProblem(t *testing.T) {
+	tests := []struct {
+		name            string
+		clientVersion   string
+		serverVersion   string
+		serverFound     bool
+		expectedProblem bool
+	}{
+		{
+			name:          "same minor version is compatible",
+			clientVersion: "v0.10.0",
+			serverVersion: "v0.10.1",
+			serverFound:   true,
+		},
+		{
+			name:            "different minor version before 1.0 is incompatible",
+			clientVersion:   "v0.9.0",
+			serverVersion:   "v0.10.1",
+			serverFound:     true,
+			expectedProblem: true,
+		},
+		{
+			name:            "development build can't be checked",
+			clientVersion:   "v0.10.0",
+			serverVersion:   "",
+			serverFound:     true,
+			expectedProblem: true,
+		},
+		{
+			name:            "unknown server version can't be checked",
+			clientVersion:   "v0.10.0",
+			expectedProblem: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problem := compatibilityProblem(test.clientVersion, test.serverVersion, test.serverFound)
+			assert.Equal(t, test.expectedProblem, problem != "")
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/persistence"
+)
+
+// backupCache is an on-disk cache of the backup tarballs downloaded for restores,
+// so that restores from the same backup don't each download its tarball. Tarballs
+// are cached by backup name and checksum, and are verified against the checksum
+// when they're downloaded and each time they're used. Once the cached tarballs'
+// total size exceeds the cache's maximum size, the least recently used ones are
+// removed.
+type backupCache struct {
+	dir     string
+	maxSize int64
+	clock   clock.Clock
+
+	lock        sync.Mutex
+	initialized bool
+	entries     map[string]*backupCacheEntry
+	size        int64
+}
+
+type backupCacheEntry struct {
+	path     string
+	size     int64
+	checksum string
+	lastUsed time.Time
+}
+
+// backupCacheDir returns the directory that backup tarballs are cached in, within
+// scratchDir or the default temp directory if scratchDir is empty.
+func backupCacheDir(scratchDir string) string {
+	if scratchDir == "" {
+		scratchDir = os.TempDir()
+	}
+	return filepath.Join(scratchDir, "ark-backup-cache")
+}
+
+// newBackupCache returns a backupCache that stores tarballs in dir, up to maxSize
+// bytes. If maxSize is zero, tarballs aren't cached.
+func newBackupCache(dir string, maxSize int64) *backupCache {
+	return &backupCache{
+		dir:     dir,
+		maxSize: maxSize,
+		clock:   &clock.RealClock{},
+		entries: make(map[string]*backupCacheEntry),
+	}
+}
+
+// open returns a reader for a backup's tarball, downloading it from backupStore if
+// it isn't cached. Tarballs that can't be cached, because the backup doesn't record
+// their checksum or they're larger than the cache, are downloaded to temp files in
+// tempDir that are removed when the reader is closed.
+func (c *backupCache) open(backup *api.Backup, backupStore persistence.BackupStore, tempDir string, log logrus.FieldLogger) (io.ReadCloser, error) {
+	checksum := backup.Status.TarballSHA256
+	if c.maxSize <= 0 || checksum == "" || backup.Status.TarballSizeBytes > c.maxSize {
+		file, err := downloadToTempFile(backup.Name, backupStore, tempDir, log)
+		if err != nil {
+			return nil, err
+		}
+		return &tempBackupFile{File: file, log: log}, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+
+	log = log.WithField("backup", backup.Name)
+	key := fmt.Sprintf("%s-%s", backup.Name, checksum)
+
+	if entry, ok := c.entries[key]; ok {
+		err := verifyChecksum(entry.path, checksum)
+		if err == nil {
+			log.Debug("Using cached backup tarball")
+			entry.lastUsed = c.clock.Now()
+			return openCachedFile(entry.path)
+		}
+
+		log.WithError(err).Warn("Cached backup tarball is invalid, downloading it again")
+		c.remove(key)
+	}
+
+	entry, err := c.download(backup.Name, key, checksum, backupStore)
+	if err != nil {
+		return nil, err
+	}
+
+	c.entries[key] = entry
+	c.size += entry.size
+	c.evict(key, log)
+
+	return openCachedFile(entry.path)
+}
+
+func openCachedFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening cached backup tarball")
+	}
+	return file, nil
+}
+
+// init removes any tarballs left in the cache's directory by a previous server,
+// since the cache's entries are only kept in memory, and creates the directory.
+func (c *backupCache) init() error {
+	if c.initialized {
+		return nil
+	}
+
+	if err := os.RemoveAll(c.dir); err != nil {
+		return errors.Wrap(err, "error clearing backup cache directory")
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return errors.Wrap(err, "error creating backup cache directory")
+	}
+
+	c.initialized = true
+	return nil
+}
+
+// download downloads a backup's tarball into the cache's directory, verifying it
+// against checksum.
+func (c *backupCache) download(backupName, key, checksum string, backupStore persistence.BackupStore) (*backupCacheEntry, error) {
+	readCloser, err := backupStore.GetBackupContents(backupName)
+	if err != nil {
+		return nil, err
+	}
+	defer readCloser.Close()
+
+	path := filepath.Join(c.dir, key)
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating backup cache file")
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), readCloser)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, errors.Wrap(err, "error copying backup to cache file")
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		os.Remove(path)
+		return nil, errors.Errorf("backup %s's tarball has checksum %s, expected %s", backupName, actual, checksum)
+	}
+
+	return &backupCacheEntry{
+		path:     path,
+		size:     size,
+		checksum: checksum,
+		lastUsed: c.clock.Now(),
+	}, nil
+}
+
+// evict removes the least recently used tarballs, other than the one for keep,
+// until the cache's total size is no more than its maximum size.
+func (c *backupCache) evict(keep string, log logrus.FieldLogger) {
+	for c.size > c.maxSize {
+		var oldest string
+		for key, entry := range c.entries {
+			if key == keep {
+				continue
+			}
+			if oldest == "" || entry.lastUsed.Before(c.entries[oldest].lastUsed) {
+				oldest = key
+			}
+		}
+
+		if oldest == "" {
+			return
+		}
+
+		log.WithField("entry", oldest).Debug("Evicting backup tarball from cache")
+		c.remove(oldest)
+	}
+}
+
+// remove deletes a tarball from the cache. Readers that have the tarball open
+// can continue to read it.
+func (c *backupCache) remove(key string) {
+	entry := c.entries[key]
+
+	os.Remove(entry.path)
+	c.size -= entry.size
+	delete(c.entries, key)
+}
+
+// verifyChecksum returns an error if the hex-encoded SHA-256 checksum of the file at
+// path isn't checksum.
+func verifyChecksum(path, checksum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		return errors.Errorf("file has checksum %s, expected %s", actual, checksum)
+	}
+
+	return nil
+}
+
+// tempBackupFile is a backup tarball downloaded to a temp file that's removed when
+// it's closed.
+type tempBackupFile struct {
+	*os.File
+	log logrus.FieldLogger
+}
+
+func (f *tempBackupFile) Close() error {
+	closeAndRemoveFile(f.File, f.log)
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	persistencemocks "github.com/heptio/ark/pkg/persistence/mocks"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func newCachedBackup(name, contents string) *v1.Backup {
+	sum := sha256.Sum256([]byte(contents))
+
+	backup := arktest.NewTestBackup().WithName(name).Backup
+	backup.Status.TarballSizeBytes = int64(len(contents))
+	backup.Status.TarballSHA256 = hex.EncodeToString(sum[:])
+	return backup
+}
+
+func readBackupFromCache(t *testing.T, cache *backupCache, backup *v1.Backup, backupStore *persistencemocks.BackupStore, tempDir string) string {
+	rc, err := cache.open(backup, backupStore, tempDir, arktest.NewLogger())
+	require.NoError(t, err)
+	defer rc.Close()
+
+	data, err := ioutil.ReadAll(rc)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestBackupCacheOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		cache       = newBackupCache(filepath.Join(dir, "cache"), 10)
+		fakeClock   = clock.NewFakeClock(time.Now())
+		backupStore = new(persistencemocks.BackupStore)
+		backup1     = newCachedBackup("backup-1", "aaaa")
+		backup2     = newCachedBackup("backup-2", "bbbb")
+		backup3     = newCachedBackup("backup-3", "cccc")
+	)
+	cache.clock = fakeClock
+	defer backupStore.AssertExpectations(t)
+
+	// the first restore from a backup downloads it, and later ones use the cache
+	backupStore.On("GetBackupContents", "backup-1").Return(ioutil.NopCloser(strings.NewReader("aaaa")), nil).Once()
+	assert.Equal(t, "aaaa", readBackupFromCache(t, cache, backup1, backupStore, dir))
+	assert.Equal(t, "aaaa", readBackupFromCache(t, cache, backup1, backupStore, dir))
+
+	fakeClock.Step(time.Minute)
+	backupStore.On("GetBackupContents", "backup-2").Return(ioutil.NopCloser(strings.NewReader("bbbb")), nil).Once()
+	assert.Equal(t, "bbbb", readBackupFromCache(t, cache, backup2, backupStore, dir))
+	assert.Len(t, cache.entries, 2)
+
+	// using backup-1 makes backup-2 the least recently used, so it's evicted
+	// once backup-3 is cached
+	fakeClock.Step(time.Minute)
+	assert.Equal(t, "aaaa", readBackupFromCache(t, cache, backup1, backupStore, dir))
+
+	fakeClock.Step(time.Minute)
+	backupStore.On("GetBackupContents", "backup-3").Return(ioutil.NopCloser(strings.NewReader("cccc")), nil).Once()
+	assert.Equal(t, "cccc", readBackupFromCache(t, cache, backup3, backupStore, dir))
+
+	assert.Len(t, cache.entries, 2)
+	assert.Equal(t, int64(8), cache.size)
+	files, err := ioutil.ReadDir(cache.dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	backupStore.On("GetBackupContents", "backup-2").Return(ioutil.NopCloser(strings.NewReader("bbbb")), nil).Once()
+	assert.Equal(t, "bbbb", readBackupFromCache(t, cache, backup2, backupStore, dir))
+}
+
+func TestBackupCacheVerifiesChecksums(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		cache       = newBackupCache(filepath.Join(dir, "cache"), 10)
+		backupStore = new(persistencemocks.BackupStore)
+		backup      = newCachedBackup("backup-1", "aaaa")
+	)
+	defer backupStore.AssertExpectations(t)
+
+	// a download that doesn't match the backup's checksum is an error
+	backupStore.On("GetBackupContents", "backup-1").Return(ioutil.NopCloser(strings.NewReader("abcd")), nil).Once()
+	_, err = cache.open(backup, backupStore, dir, arktest.NewLogger())
+	assert.EqualError(t, err, "backup backup-1's tarball has checksum "+newCachedBackup("", "abcd").Status.TarballSHA256+", expected "+backup.Status.TarballSHA256)
+	assert.Empty(t, cache.entries)
+
+	// a cached tarball that's been modified is downloaded again
+	backupStore.On("GetBackupContents", "backup-1").Return(ioutil.NopCloser(strings.NewReader("aaaa")), nil).Once()
+	assert.Equal(t, "aaaa", readBackupFromCache(t, cache, backup, backupStore, dir))
+
+	for _, entry := range cache.entries {
+		require.NoError(t, ioutil.WriteFile(entry.path, []byte("abcd"), 0644))
+	}
+
+	backupStore.On("GetBackupContents", "backup-1").Return(ioutil.NopCloser(strings.NewReader("aaaa")), nil).Once()
+	assert.Equal(t, "aaaa", readBackupFromCache(t, cache, backup, backupStore, dir))
+}
+
+func TestBackupCacheSkipsUncacheableBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		maxSize int64
+		backup  *v1.Backup
+	}{
+		{
+			name:    "cache is disabled",
+			maxSize: 0,
+			backup:  newCachedBackup("backup-1", "aaaa"),
+		},
+		{
+			name:    "backup has no checksum",
+			maxSize: 10,
+			backup:  arktest.NewTestBackup().WithName("backup-1").Backup,
+		},
+		{
+			name:    "backup is larger than the cache",
+			maxSize: 3,
+			backup:  newCachedBackup("backup-1", "aaaa"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				cache       = newBackupCache(filepath.Join(dir, "cache"), test.maxSize)
+				backupStore = new(persistencemocks.BackupStore)
+				tempDir     = filepath.Join(dir, "temp")
+			)
+			defer backupStore.AssertExpectations(t)
+			require.NoError(t, os.MkdirAll(tempDir, 0755))
+
+			// each restore downloads the backup
+			for i := 0; i < 2; i++ {
+				backupStore.On("GetBackupContents", "backup-1").Return(ioutil.NopCloser(strings.NewReader("aaaa")), nil).Once()
+				assert.Equal(t, "aaaa", readBackupFromCache(t, cache, test.backup, backupStore, tempDir))
+			}
+
+			assert.Empty(t, cache.entries)
+
+			// the temp files are removed once they're closed
+			files, err := ioutil.ReadDir(tempDir)
+			require.NoError(t, err)
+			assert.Empty(t, files)
+		})
+	}
+}
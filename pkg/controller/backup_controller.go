@@ -19,25 +19,34 @@ package controller
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/clock"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
 	pkgbackup "github.com/heptio/ark/pkg/backup"
+	"github.com/heptio/ark/pkg/buildinfo"
 	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
 	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
@@ -46,12 +55,15 @@ import (
 	"github.com/heptio/ark/pkg/plugin"
 	"github.com/heptio/ark/pkg/util/collections"
 	"github.com/heptio/ark/pkg/util/encode"
+	"github.com/heptio/ark/pkg/util/filesystem"
 	kubeutil "github.com/heptio/ark/pkg/util/kube"
 	"github.com/heptio/ark/pkg/util/logging"
 	"github.com/heptio/ark/pkg/volume"
 )
 
-const backupVersion = 1
+// backupQueueReportPeriod is how often the queue positions of the backups
+// waiting to run are updated.
+const backupQueueReportPeriod = 10 * time.Second
 
 type backupController struct {
 	*genericController
@@ -59,21 +71,28 @@ type backupController struct {
 	backupper                pkgbackup.Backupper
 	lister                   listers.BackupLister
 	client                   arkv1client.BackupsGetter
+	volumeSnapshotClient     arkv1client.VolumeSnapshotsGetter
 	clock                    clock.Clock
 	backupLogLevel           logrus.Level
 	newPluginManager         func(logrus.FieldLogger) plugin.Manager
 	backupTracker            BackupTracker
+	queuedBackups            *backupQueue
 	backupLocationLister     listers.BackupStorageLocationLister
 	defaultBackupLocation    string
+	clusterUID               string
 	snapshotLocationLister   listers.VolumeSnapshotLocationLister
 	defaultSnapshotLocations map[string]string
 	metrics                  *metrics.ServerMetrics
+	scratchDir               string
+	logUploadInterval        time.Duration
 	newBackupStore           func(*api.BackupStorageLocation, persistence.ObjectStoreGetter, logrus.FieldLogger) (persistence.BackupStore, error)
+	freeSpace                func(path string) (int64, error)
 }
 
 func NewBackupController(
 	backupInformer informers.BackupInformer,
 	client arkv1client.BackupsGetter,
+	volumeSnapshotClient arkv1client.VolumeSnapshotsGetter,
 	backupper pkgbackup.Backupper,
 	logger logrus.FieldLogger,
 	backupLogLevel logrus.Level,
@@ -81,8 +100,11 @@ func NewBackupController(
 	backupTracker BackupTracker,
 	backupLocationInformer informers.BackupStorageLocationInformer,
 	defaultBackupLocation string,
+	clusterUID string,
 	volumeSnapshotLocationInformer informers.VolumeSnapshotLocationInformer,
 	defaultSnapshotLocations map[string]string,
+	scratchDir string,
+	logUploadInterval time.Duration,
 	metrics *metrics.ServerMetrics,
 ) Interface {
 	c := &backupController{
@@ -90,20 +112,28 @@ func NewBackupController(
 		backupper:                backupper,
 		lister:                   backupInformer.Lister(),
 		client:                   client,
+		volumeSnapshotClient:     volumeSnapshotClient,
 		clock:                    &clock.RealClock{},
 		backupLogLevel:           backupLogLevel,
 		newPluginManager:         newPluginManager,
 		backupTracker:            backupTracker,
+		queuedBackups:            newBackupQueue(),
 		backupLocationLister:     backupLocationInformer.Lister(),
 		defaultBackupLocation:    defaultBackupLocation,
+		clusterUID:               clusterUID,
 		snapshotLocationLister:   volumeSnapshotLocationInformer.Lister(),
 		defaultSnapshotLocations: defaultSnapshotLocations,
 		metrics:                  metrics,
+		scratchDir:               scratchDir,
+		logUploadInterval:        logUploadInterval,
 
 		newBackupStore: persistence.NewObjectBackupStore,
+		freeSpace:      filesystem.FreeSpace,
 	}
 
 	c.syncHandler = c.processBackup
+	c.resyncFunc = c.reportQueuePositions
+	c.resyncPeriod = backupQueueReportPeriod
 	c.cacheSyncWaiters = append(c.cacheSyncWaiters,
 		backupInformer.Informer().HasSynced,
 		backupLocationInformer.Informer().HasSynced,
@@ -116,8 +146,15 @@ func NewBackupController(
 				backup := obj.(*api.Backup)
 
 				switch backup.Status.Phase {
-				case "", api.BackupPhaseNew:
+				case "", api.BackupPhaseNew, api.BackupPhaseQueued:
 					// only process new backups
+				case api.BackupPhaseCompleted:
+					// existing backups are added when the server starts, so the time of each
+					// schedule's last successful backup is known before it runs another one
+					if !backup.Status.CompletionTimestamp.IsZero() {
+						c.metrics.SetBackupLastSuccessfulTimestamp(backup.Labels[api.ScheduleNameLabel], backup.Status.CompletionTimestamp.Time)
+					}
+					return
 				default:
 					c.logger.WithFields(logrus.Fields{
 						"backup": kubeutil.NamespaceAndName(backup),
@@ -131,6 +168,7 @@ func NewBackupController(
 					c.logger.WithError(err).WithField("backup", backup).Error("Error creating queue key, item not added to queue")
 					return
 				}
+				c.queuedBackups.add(key)
 				c.queue.Add(key)
 			},
 		},
@@ -139,6 +177,46 @@ func NewBackupController(
 	return c
 }
 
+// reportQueuePositions sets the phase of each backup waiting to run to Queued,
+// and records its position in the queue in its status.
+func (c *backupController) reportQueuePositions() {
+	c.queuedBackups.report(func(key string, position int) bool {
+		log := c.logger.WithField("key", key)
+
+		ns, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			log.WithError(err).Error("Error splitting queue key")
+			return false
+		}
+
+		original, err := c.lister.Backups(ns).Get(name)
+		if err != nil {
+			log.WithError(err).Debug("Error getting backup")
+			return false
+		}
+
+		switch original.Status.Phase {
+		case "", api.BackupPhaseNew, api.BackupPhaseQueued:
+		default:
+			return false
+		}
+
+		if original.Status.Phase == api.BackupPhaseQueued && original.Status.QueuePosition == position {
+			return true
+		}
+
+		updated := original.DeepCopy()
+		updated.Status.Phase = api.BackupPhaseQueued
+		updated.Status.QueuePosition = position
+
+		if _, err := patchBackup(original, updated, c.client); err != nil {
+			log.WithError(err).Error("Error updating backup's queue position")
+			return false
+		}
+		return true
+	})
+}
+
 func (c *backupController) processBackup(key string) error {
 	log := c.logger.WithField("key", key)
 
@@ -148,6 +226,8 @@ func (c *backupController) processBackup(key string) error {
 		return errors.Wrap(err, "error splitting queue key")
 	}
 
+	reportedPosition := c.queuedBackups.remove(key)
+
 	log.Debug("Getting backup")
 	original, err := c.lister.Backups(ns).Get(name)
 	if err != nil {
@@ -163,17 +243,31 @@ func (c *backupController) processBackup(key string) error {
 	// InProgress, we still need this check so we can return nil to indicate we've finished processing
 	// this key (even though it was a no-op).
 	switch original.Status.Phase {
-	case "", api.BackupPhaseNew:
+	case "", api.BackupPhaseNew, api.BackupPhaseQueued:
 		// only process new backups
 	default:
 		return nil
 	}
 
+	// the lister may not have seen the backup's queue position yet, so make
+	// sure it's cleared when the backup's status is updated
+	if reportedPosition > 0 && original.Status.QueuePosition == 0 {
+		original = original.DeepCopy()
+		original.Status.QueuePosition = reportedPosition
+	}
+
 	log.Debug("Preparing backup request")
 	request := c.prepareBackupRequest(original)
+	request.Status.QueuePosition = 0
 
 	if len(request.Status.ValidationErrors) > 0 {
 		request.Status.Phase = api.BackupPhaseFailedValidation
+		// The backup isn't uploaded, so there's nowhere to put the complete list. It's logged instead.
+		var truncated bool
+		allErrors := request.Status.ValidationErrors
+		if request.Status.ValidationErrors, truncated = truncateStatusList(allErrors); truncated {
+			log.WithField("validationErrors", allErrors).Info("Backup failed validation with more errors than are kept in its status")
+		}
 	} else {
 		request.Status.Phase = api.BackupPhaseInProgress
 	}
@@ -196,15 +290,17 @@ func (c *backupController) processBackup(key string) error {
 
 	log.Debug("Running backup")
 	// execution & upload of backup
-	backupScheduleName := request.GetLabels()["ark-schedule"]
+	backupScheduleName := request.GetLabels()[api.ScheduleNameLabel]
 	c.metrics.RegisterBackupAttempt(backupScheduleName)
 
 	if err := c.runBackup(request); err != nil {
 		log.WithError(err).Error("backup failed")
 		request.Status.Phase = api.BackupPhaseFailed
+		request.Status.FailureReason = err.Error()
 		c.metrics.RegisterBackupFailed(backupScheduleName)
 	} else {
 		c.metrics.RegisterBackupSuccess(backupScheduleName)
+		c.metrics.SetBackupLastSuccessfulTimestamp(backupScheduleName, request.Status.CompletionTimestamp.Time)
 	}
 
 	log.Debug("Updating backup's final status")
@@ -245,7 +341,15 @@ func (c *backupController) prepareBackupRequest(backup *api.Backup) *pkgbackup.R
 	}
 
 	// set backup version
-	request.Status.Version = backupVersion
+	request.Status.Version = archive.FormatVersion
+
+	// record the server's version, so that servers that restore the backup
+	// can tell whether they're compatible with it
+	request.Status.ServerVersion = buildinfo.Version
+
+	// record the cluster's UID, so that restores can tell whether they're
+	// restoring the backup into the cluster it was taken in
+	request.Status.ClusterUID = c.clusterUID
 
 	// calculate expiration
 	if request.Spec.TTL.Duration > 0 {
@@ -263,6 +367,11 @@ func (c *backupController) prepareBackupRequest(backup *api.Backup) *pkgbackup.R
 	}
 	request.Labels[api.StorageLocationLabel] = request.Spec.StorageLocation
 
+	// expand the filter profile, if any, into included/excluded resources
+	if err := pkgbackup.ApplyFilterProfile(request.Backup); err != nil {
+		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid filter profile: %v", err))
+	}
+
 	// validate the included/excluded resources and namespaces
 	for _, err := range collections.ValidateIncludesExcludes(request.Spec.IncludedResources, request.Spec.ExcludedResources) {
 		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid included/excluded resource lists: %v", err))
@@ -272,11 +381,47 @@ func (c *backupController) prepareBackupRequest(backup *api.Backup) *pkgbackup.R
 		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid included/excluded namespace lists: %v", err))
 	}
 
+	// default the archive format, so that the format is recorded with the backup
+	if request.Spec.ArchiveFormat == "" {
+		request.Spec.ArchiveFormat = api.ArchiveFormatTarGzip
+	}
+	if err := archive.ValidateFormat(request.Spec.ArchiveFormat); err != nil {
+		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid archive format: %v", err))
+	}
+
+	if request.Spec.ClientQPS < 0 {
+		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid client QPS %d, must not be negative", request.Spec.ClientQPS))
+	}
+	if request.Spec.ClientBurst < 0 {
+		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid client burst %d, must not be negative", request.Spec.ClientBurst))
+	}
+	if request.Spec.PageSize < 0 {
+		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid page size %d, must not be negative", request.Spec.PageSize))
+	}
+
+	for _, item := range request.Spec.AdditionalItems {
+		if err := pkgbackup.ValidateAdditionalItem(item); err != nil {
+			request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid additional item: %v", err))
+		}
+	}
+
+	// Helm release names are matched against label values
+	for _, release := range request.Spec.HelmReleases {
+		for _, msg := range validation.IsValidLabelValue(release) {
+			request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Invalid Helm release name %q: %s", release, msg))
+		}
+	}
+
 	// validate the storage location, and store the BackupStorageLocation API obj on the request
 	if storageLocation, err := c.backupLocationLister.BackupStorageLocations(request.Namespace).Get(request.Spec.StorageLocation); err != nil {
 		request.Status.ValidationErrors = append(request.Status.ValidationErrors, fmt.Sprintf("Error getting backup storage location: %v", err))
 	} else {
 		request.StorageLocation = storageLocation
+
+		// validate that the backup won't exceed its tenant's storage quota, if it has one
+		if err := c.validateTenantQuota(request.Backup, storageLocation); err != "" {
+			request.Status.ValidationErrors = append(request.Status.ValidationErrors, err)
+		}
 	}
 
 	// validate and get the backup's VolumeSnapshotLocations, and store the
@@ -284,8 +429,18 @@ func (c *backupController) prepareBackupRequest(backup *api.Backup) *pkgbackup.R
 	if locs, errs := c.validateAndGetSnapshotLocations(request.Backup); len(errs) > 0 {
 		request.Status.ValidationErrors = append(request.Status.ValidationErrors, errs...)
 	} else {
+		// order the locations by provider, since each persistent volume is snapshotted
+		// using the first location whose block store recognizes it, and the backup's
+		// volumes may come from more than one provider.
+		providers := make([]string, 0, len(locs))
+		for provider := range locs {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+
 		request.Spec.VolumeSnapshotLocations = nil
-		for _, loc := range locs {
+		for _, provider := range providers {
+			loc := locs[provider]
 			request.Spec.VolumeSnapshotLocations = append(request.Spec.VolumeSnapshotLocations, loc.Name)
 			request.SnapshotLocations = append(request.SnapshotLocations, loc)
 		}
@@ -294,6 +449,86 @@ func (c *backupController) prepareBackupRequest(backup *api.Backup) *pkgbackup.R
 	return request
 }
 
+// validateTenantQuota returns a validation error if the backup belongs to a tenant
+// with a storage quota in the location, and the backup would likely exceed it. Since
+// the size of the backup isn't known ahead of time, the size of the tenant's most
+// recent backup in the location is used as an estimate.
+func (c *backupController) validateTenantQuota(backup *api.Backup, location *api.BackupStorageLocation) string {
+	tenant := persistence.BackupTenant(backup)
+
+	storage := persistence.TenantStorageFor(location, tenant)
+	if storage == nil || storage.Quota == nil {
+		return ""
+	}
+
+	used, latest, err := tenantUsage(c.lister, backup.Namespace, location.Name, tenant)
+	if err != nil {
+		return fmt.Sprintf("Error getting storage usage for tenant %s: %v", tenant, err)
+	}
+
+	if used+latest > storage.Quota.Value() {
+		return fmt.Sprintf("Backup would exceed the storage quota of %s for tenant %s in backup storage location %s (%d bytes used)", storage.Quota.String(), tenant, location.Name, used)
+	}
+
+	return ""
+}
+
+// tenantUsage returns the total size of the tarballs of all of a tenant's backups in
+// the given backup storage location, along with the size of its most recent one.
+func tenantUsage(lister listers.BackupLister, namespace, location, tenant string) (used, latest int64, err error) {
+	selector := labels.SelectorFromSet(map[string]string{
+		api.StorageLocationLabel:      location,
+		api.SelfServiceNamespaceLabel: tenant,
+	})
+
+	backups, err := lister.Backups(namespace).List(selector)
+	if err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+
+	var latestStart time.Time
+	for _, backup := range backups {
+		used += backup.Status.TarballSizeBytes
+
+		if backup.Status.TarballSizeBytes > 0 && backup.Status.StartTimestamp.After(latestStart) {
+			latestStart = backup.Status.StartTimestamp.Time
+			latest = backup.Status.TarballSizeBytes
+		}
+	}
+
+	return used, latest, nil
+}
+
+// expectedBackupSize estimates the size of a backup's tarball as the size of the
+// most recent backup in the same storage location, from the same schedule if the
+// backup was created by one. It returns zero if there's no such backup.
+func expectedBackupSize(lister listers.BackupLister, backup *api.Backup) (int64, error) {
+	set := map[string]string{
+		api.StorageLocationLabel: backup.Labels[api.StorageLocationLabel],
+	}
+	if schedule := backup.Labels[api.ScheduleNameLabel]; schedule != "" {
+		set[api.ScheduleNameLabel] = schedule
+	}
+
+	backups, err := lister.Backups(backup.Namespace).List(labels.SelectorFromSet(set))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	var (
+		size        int64
+		latestStart time.Time
+	)
+	for _, b := range backups {
+		if b.Status.TarballSizeBytes > 0 && b.Status.StartTimestamp.After(latestStart) {
+			latestStart = b.Status.StartTimestamp.Time
+			size = b.Status.TarballSizeBytes
+		}
+	}
+
+	return size, nil
+}
+
 // validateAndGetSnapshotLocations gets a collection of VolumeSnapshotLocation objects that
 // this backup will use (returned as a map of provider name -> VSL), and ensures:
 // - each location name in .spec.volumeSnapshotLocations exists as a location
@@ -302,12 +537,20 @@ func (c *backupController) prepareBackupRequest(backup *api.Backup) *pkgbackup.R
 //   is not explicitly specified for the provider (if there's only one location for the provider,
 //   it will automatically be used)
 func (c *backupController) validateAndGetSnapshotLocations(backup *api.Backup) (map[string]*api.VolumeSnapshotLocation, []string) {
+	return validateAndGetSnapshotLocations(c.snapshotLocationLister, c.defaultSnapshotLocations, backup)
+}
+
+func validateAndGetSnapshotLocations(
+	snapshotLocationLister listers.VolumeSnapshotLocationLister,
+	defaultSnapshotLocations map[string]string,
+	backup *api.Backup,
+) (map[string]*api.VolumeSnapshotLocation, []string) {
 	errors := []string{}
 	providerLocations := make(map[string]*api.VolumeSnapshotLocation)
 
 	for _, locationName := range backup.Spec.VolumeSnapshotLocations {
 		// validate each locationName exists as a VolumeSnapshotLocation
-		location, err := c.snapshotLocationLister.VolumeSnapshotLocations(backup.Namespace).Get(locationName)
+		location, err := snapshotLocationLister.VolumeSnapshotLocations(backup.Namespace).Get(locationName)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("error getting volume snapshot location named %s: %v", locationName, err))
 			continue
@@ -330,7 +573,7 @@ func (c *backupController) validateAndGetSnapshotLocations(backup *api.Backup) (
 		return nil, errors
 	}
 
-	allLocations, err := c.snapshotLocationLister.VolumeSnapshotLocations(backup.Namespace).List(labels.Everything())
+	allLocations, err := snapshotLocationLister.VolumeSnapshotLocations(backup.Namespace).List(labels.Everything())
 	if err != nil {
 		errors = append(errors, fmt.Sprintf("error listing volume snapshot locations: %v", err))
 		return nil, errors
@@ -354,12 +597,12 @@ func (c *backupController) validateAndGetSnapshotLocations(backup *api.Backup) (
 		if len(locations) > 1 {
 			// more than one possible location for the provider: check
 			// the defaults
-			defaultLocation := c.defaultSnapshotLocations[provider]
+			defaultLocation := defaultSnapshotLocations[provider]
 			if defaultLocation == "" {
 				errors = append(errors, fmt.Sprintf("provider %s has more than one possible volume snapshot location, and none were specified explicitly or as a default", provider))
 				continue
 			}
-			location, err := c.snapshotLocationLister.VolumeSnapshotLocations(backup.Namespace).Get(defaultLocation)
+			location, err := snapshotLocationLister.VolumeSnapshotLocations(backup.Namespace).Get(defaultLocation)
 			if err != nil {
 				errors = append(errors, fmt.Sprintf("error getting volume snapshot location named %s: %v", defaultLocation, err))
 				continue
@@ -373,6 +616,21 @@ func (c *backupController) validateAndGetSnapshotLocations(backup *api.Backup) (
 		providerLocations[provider] = locations[0]
 	}
 
+	// reject locations that the volume snapshot location controller
+	// has found to be unusable, rather than failing mid-backup
+	providers := make([]string, 0, len(providerLocations))
+	for provider := range providerLocations {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	for _, provider := range providers {
+		location := providerLocations[provider]
+		if location.Status.Phase == api.VolumeSnapshotLocationPhaseUnavailable {
+			errors = append(errors, fmt.Sprintf("volume snapshot location %s is unavailable: %s", location.Name, location.Status.Message))
+		}
+	}
+
 	if len(errors) > 0 {
 		return nil, errors
 	}
@@ -385,7 +643,15 @@ func (c *backupController) runBackup(backup *pkgbackup.Request) error {
 	log.Info("Starting backup")
 	backup.Status.StartTimestamp.Time = c.clock.Now()
 
-	logFile, err := ioutil.TempFile("", "")
+	expectedSize, err := expectedBackupSize(c.lister, backup.Backup)
+	if err != nil {
+		return err
+	}
+	if err := checkScratchSpace(c.freeSpace, c.scratchDir, expectedSize); err != nil {
+		return err
+	}
+
+	logFile, err := ioutil.TempFile(c.scratchDir, "")
 	if err != nil {
 		return errors.Wrap(err, "error creating temp file for backup log")
 	}
@@ -399,11 +665,24 @@ func (c *backupController) runBackup(backup *pkgbackup.Request) error {
 	// backup log failed for whatever reason.
 	logger := logging.DefaultLogger(c.backupLogLevel)
 	logger.Out = io.MultiWriter(os.Stdout, gzippedLogFile)
+
+	// If the log is uploaded while the backup is in progress, also log to an
+	// uncompressed file that can be read and uploaded at any point.
+	var progress *progressLog
+	if c.logUploadInterval > 0 {
+		if progress, err = newProgressLog(c.scratchDir); err != nil {
+			return err
+		}
+		defer closeAndRemoveFile(progress.file, c.logger)
+
+		logger.Out = io.MultiWriter(os.Stdout, gzippedLogFile, progress)
+	}
+
 	log = logger.WithField("backup", kubeutil.NamespaceAndName(backup))
 
 	log.Info("Starting backup")
 
-	backupFile, err := ioutil.TempFile("", "")
+	backupFile, err := ioutil.TempFile(c.scratchDir, "")
 	if err != nil {
 		return errors.Wrap(err, "error creating temp file for backup")
 	}
@@ -417,21 +696,49 @@ func (c *backupController) runBackup(backup *pkgbackup.Request) error {
 		return err
 	}
 
-	backupStore, err := c.newBackupStore(backup.StorageLocation, pluginManager, log)
+	location, err := persistence.TenantLocation(backup.StorageLocation, persistence.BackupTenant(backup.Backup))
+	if err != nil {
+		return err
+	}
+
+	backupStore, err := c.newBackupStore(location, pluginManager, log)
 	if err != nil {
 		return err
 	}
 
 	var errs []error
 
-	// Do the actual backup
-	if err := c.backupper.Backup(log, backup, backupFile, actions, pluginManager); err != nil {
+	// Upload the log periodically while the backup is in progress. The uploads
+	// stop before the backup is persisted, which uploads the complete log.
+	stopLogUploads := func() {}
+	if progress != nil {
+		stop, done := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(done)
+			wait.Until(func() {
+				if err := progress.upload(backup.Name, backupStore); err != nil {
+					c.logger.WithError(err).WithField("backup", kubeutil.NamespaceAndName(backup)).Warn("Error uploading log of in-progress backup")
+				}
+			}, c.logUploadInterval, stop)
+		}()
+
+		stopLogUploads = func() {
+			close(stop)
+			<-done
+		}
+	}
+
+	// Do the actual backup, computing the tarball's checksum as it's written
+	tarballHash := sha256.New()
+	if err := c.backupper.Backup(context.Background(), log, backup, io.MultiWriter(backupFile, tarballHash), actions, pluginManager); err != nil {
 		errs = append(errs, err)
 		backup.Status.Phase = api.BackupPhaseFailed
 	} else {
 		backup.Status.Phase = api.BackupPhaseCompleted
 	}
 
+	stopLogUploads()
+
 	if err := gzippedLogFile.Close(); err != nil {
 		c.logger.WithError(err).Error("error closing gzippedLogFile")
 	}
@@ -447,7 +754,39 @@ func (c *backupController) runBackup(backup *pkgbackup.Request) error {
 		}
 	}
 
-	errs = append(errs, persistBackup(backup, backupFile, logFile, backupStore, c.logger)...)
+	if backupFileStat, err := backupFile.Stat(); err != nil {
+		errs = append(errs, errors.Wrap(err, "error getting backup file info"))
+	} else {
+		backup.Status.TarballSizeBytes = backupFileStat.Size()
+	}
+	backup.Status.TarballSHA256 = hex.EncodeToString(tarballHash.Sum(nil))
+
+	statusDetails, err := truncateBackupStatus(backup.Backup)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	persistErrs := persistBackup(backup, backupFile, logFile, backupStore, c.logger)
+	if len(persistErrs) == 0 {
+		// The volume snapshot records are only an in-cluster copy of the volumesnapshots
+		// file in object storage, so creating them is best-effort; if it fails, we log the
+		// error but it doesn't impact the backup's status.
+		for _, err := range createVolumeSnapshotRecords(c.volumeSnapshotClient, backup.Namespace, backup.VolumeSnapshots) {
+			log.WithError(err).Error("Error creating volume snapshot record")
+		}
+
+		// The index is also in the tarball, so uploading it separately is best-effort too.
+		if err := putBackupIndex(backup, backupFile, backupStore); err != nil {
+			log.WithError(err).Error("Error uploading backup index")
+		}
+
+		if statusDetails != nil {
+			if err := backupStore.PutBackupStatusDetails(backup.Name, statusDetails); err != nil {
+				persistErrs = append(persistErrs, err)
+			}
+		}
+	}
+	errs = append(errs, persistErrs...)
 	errs = append(errs, recordBackupMetrics(backup.Backup, backupFile, c.metrics))
 
 	log.Info("Backup completed")
@@ -456,7 +795,7 @@ func (c *backupController) runBackup(backup *pkgbackup.Request) error {
 }
 
 func recordBackupMetrics(backup *api.Backup, backupFile *os.File, serverMetrics *metrics.ServerMetrics) error {
-	backupScheduleName := backup.GetLabels()["ark-schedule"]
+	backupScheduleName := backup.GetLabels()[api.ScheduleNameLabel]
 
 	var backupSizeBytes int64
 	var err error
@@ -493,20 +832,76 @@ func persistBackup(backup *pkgbackup.Request, backupContents, backupLog *os.File
 		errs = append(errs, errors.Wrap(err, "error closing gzip writer"))
 	}
 
+	podVolumeSnapshots := new(bytes.Buffer)
+	pgzw := gzip.NewWriter(podVolumeSnapshots)
+	defer pgzw.Close()
+
+	if err := json.NewEncoder(pgzw).Encode(backup.PodVolumeSnapshots); err != nil {
+		errs = append(errs, errors.Wrap(err, "error encoding list of pod volume snapshots"))
+	}
+	if err := pgzw.Close(); err != nil {
+		errs = append(errs, errors.Wrap(err, "error closing gzip writer"))
+	}
+
 	if len(errs) > 0 {
 		// Don't upload the JSON files or backup tarball if encoding to json fails.
 		backupJSON = nil
 		backupContents = nil
 		volumeSnapshots = nil
+		podVolumeSnapshots = nil
 	}
 
-	if err := backupStore.PutBackup(backup.Name, backupJSON, backupContents, backupLog, volumeSnapshots); err != nil {
+	if err := backupStore.PutBackup(backup.Name, backupJSON, backupContents, backupLog, volumeSnapshots, podVolumeSnapshots); err != nil {
 		errs = append(errs, err)
 	}
 
 	return errs
 }
 
+// putBackupIndex uploads the index of a completed tar.gz backup, read from its
+// tarball, as a separate gzip-compressed file. Zip archives don't have an index.
+func putBackupIndex(backup *pkgbackup.Request, backupContents *os.File, backupStore persistence.BackupStore) error {
+	if backup.Status.Phase != api.BackupPhaseCompleted || backup.Spec.ArchiveFormat != api.ArchiveFormatTarGzip {
+		return nil
+	}
+
+	if _, err := backupContents.Seek(0, io.SeekStart); err != nil {
+		return errors.WithStack(err)
+	}
+
+	index, err := archive.ReadIndex(backupContents)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	if err := json.NewEncoder(gzw).Encode(index); err != nil {
+		return errors.Wrap(err, "error encoding backup index")
+	}
+	if err := gzw.Close(); err != nil {
+		return errors.Wrap(err, "error closing gzip writer")
+	}
+
+	return backupStore.PutBackupIndex(backup.Name, buf)
+}
+
+// createVolumeSnapshotRecords creates a VolumeSnapshot record, in the given namespace,
+// for each of a backup's volume snapshots. Records that already exist are left as-is.
+func createVolumeSnapshotRecords(client arkv1client.VolumeSnapshotsGetter, namespace string, snapshots []*volume.Snapshot) []error {
+	var errs []error
+
+	for _, snapshot := range snapshots {
+		record := volume.NewVolumeSnapshot(namespace, snapshot)
+
+		if _, err := client.VolumeSnapshots(namespace).Create(record); err != nil && !apierrors.IsAlreadyExists(err) {
+			errs = append(errs, errors.Wrapf(err, "error creating volume snapshot record %s", kubeutil.NamespaceAndName(record)))
+		}
+	}
+
+	return errs
+}
+
 func closeAndRemoveFile(file *os.File, log logrus.FieldLogger) {
 	if err := file.Close(); err != nil {
 		log.WithError(err).WithField("file", file.Name()).Error("error closing file")
@@ -17,9 +17,14 @@ limitations under the License.
 package controller
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"sort"
 	"strings"
 	"testing"
@@ -30,13 +35,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
 	pkgbackup "github.com/heptio/ark/pkg/backup"
 	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/itemhash"
 	"github.com/heptio/ark/pkg/metrics"
 	"github.com/heptio/ark/pkg/persistence"
 	persistencemocks "github.com/heptio/ark/pkg/persistence/mocks"
@@ -50,11 +58,27 @@ type fakeBackupper struct {
 	mock.Mock
 }
 
-func (b *fakeBackupper) Backup(logger logrus.FieldLogger, backup *pkgbackup.Request, backupFile io.Writer, actions []pkgbackup.ItemAction, blockStoreGetter pkgbackup.BlockStoreGetter) error {
+func (b *fakeBackupper) Backup(ctx context.Context, logger logrus.FieldLogger, backup *pkgbackup.Request, backupFile io.Writer, actions []pkgbackup.ItemAction, blockStoreGetter pkgbackup.BlockStoreGetter) error {
 	args := b.Called(logger, backup, backupFile, actions, blockStoreGetter)
 	return args.Error(0)
 }
 
+func (b *fakeBackupper) AddObserver(observer pkgbackup.Observer) {
+	b.Called(observer)
+}
+
+func (b *fakeBackupper) SetItemHasher(hasher itemhash.Hasher) {
+	b.Called(hasher)
+}
+
+func (b *fakeBackupper) Estimate(ctx context.Context, logger logrus.FieldLogger, backup *pkgbackup.Request, actions []pkgbackup.ItemAction, blockStoreGetter pkgbackup.BlockStoreGetter) (*pkgbackup.Estimate, error) {
+	args := b.Called(logger, backup, actions, blockStoreGetter)
+	if estimate := args.Get(0); estimate != nil {
+		return estimate.(*pkgbackup.Estimate), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func TestProcessBackupNonProcessedItems(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -104,6 +128,7 @@ func TestProcessBackupNonProcessedItems(t *testing.T) {
 			c := &backupController{
 				genericController: newGenericController("backup-test", logger),
 				lister:            sharedInformers.Ark().V1().Backups().Lister(),
+				queuedBackups:     newBackupQueue(),
 			}
 
 			if test.backup != nil {
@@ -147,6 +172,34 @@ func TestProcessBackupValidationFailures(t *testing.T) {
 			backupLocation: defaultBackupLocation,
 			expectedErrs:   []string{"Invalid included/excluded namespace lists: excludes list cannot contain an item in the includes list: foo"},
 		},
+		{
+			name:           "non-existent filter profile fails validation",
+			backup:         arktest.NewTestBackup().WithName("backup-1").WithFilterProfile("nonexistent").Backup,
+			backupLocation: defaultBackupLocation,
+			expectedErrs:   []string{"Invalid filter profile: filter profile nonexistent not found, valid profiles are [app-backup cluster-migration full-dr]"},
+		},
+		{
+			name:           "unknown archive format fails validation",
+			backup:         arktest.NewTestBackup().WithName("backup-1").WithArchiveFormat("rar").Backup,
+			backupLocation: defaultBackupLocation,
+			expectedErrs:   []string{`Invalid archive format: invalid archive format "rar", valid formats are tar.gz and zip`},
+		},
+		{
+			name:           "negative client limits and page size fail validation",
+			backup:         arktest.NewTestBackup().WithName("backup-1").WithClientLimits(-1, -2, -3).Backup,
+			backupLocation: defaultBackupLocation,
+			expectedErrs: []string{
+				"Invalid client QPS -1, must not be negative",
+				"Invalid client burst -2, must not be negative",
+				"Invalid page size -3, must not be negative",
+			},
+		},
+		{
+			name:           "Helm release name that isn't a valid label value fails validation",
+			backup:         arktest.NewTestBackup().WithName("backup-1").WithHelmReleases("release-1", strings.Repeat("a", 64)).Backup,
+			backupLocation: defaultBackupLocation,
+			expectedErrs:   []string{fmt.Sprintf("Invalid Helm release name %q: must be no more than 63 characters", strings.Repeat("a", 64))},
+		},
 		{
 			name:         "non-existent backup location fails validation",
 			backup:       arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("nonexistent").Backup,
@@ -169,6 +222,7 @@ func TestProcessBackupValidationFailures(t *testing.T) {
 				backupLocationLister:   sharedInformers.Ark().V1().BackupStorageLocations().Lister(),
 				snapshotLocationLister: sharedInformers.Ark().V1().VolumeSnapshotLocations().Lister(),
 				defaultBackupLocation:  defaultBackupLocation.Name,
+				queuedBackups:          newBackupQueue(),
 			}
 
 			require.NotNil(t, test.backup)
@@ -197,6 +251,128 @@ func TestProcessBackupValidationFailures(t *testing.T) {
 	}
 }
 
+func TestReportQueuePositions(t *testing.T) {
+	var (
+		newBackup        = arktest.NewTestBackup().WithName("backup-1").WithPhase(v1.BackupPhaseNew).Backup
+		movedBackup      = arktest.NewTestBackup().WithName("backup-2").WithPhase(v1.BackupPhaseQueued).Backup
+		unchangedBackup  = arktest.NewTestBackup().WithName("backup-3").WithPhase(v1.BackupPhaseQueued).Backup
+		inProgressBackup = arktest.NewTestBackup().WithName("backup-4").WithPhase(v1.BackupPhaseInProgress).Backup
+		backups          = []*v1.Backup{newBackup, movedBackup, unchangedBackup, inProgressBackup}
+	)
+	movedBackup.Status.QueuePosition = 3
+	unchangedBackup.Status.QueuePosition = 3
+
+	var (
+		clientset       = fake.NewSimpleClientset(newBackup, movedBackup, unchangedBackup, inProgressBackup)
+		sharedInformers = informers.NewSharedInformerFactory(clientset, 0)
+		logger          = logging.DefaultLogger(logrus.DebugLevel)
+	)
+
+	c := &backupController{
+		genericController: newGenericController("backup-test", logger),
+		client:            clientset.ArkV1(),
+		lister:            sharedInformers.Ark().V1().Backups().Lister(),
+		queuedBackups:     newBackupQueue(),
+	}
+
+	for _, backup := range backups {
+		require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(backup))
+		c.queuedBackups.add(fmt.Sprintf("%s/%s", backup.Namespace, backup.Name))
+	}
+	// backups are only queued once
+	c.queuedBackups.add(fmt.Sprintf("%s/%s", newBackup.Namespace, newBackup.Name))
+
+	clientset.ClearActions()
+	c.reportQueuePositions()
+
+	// the unchanged backup's status is already up to date
+	assert.Len(t, clientset.Actions(), 2)
+
+	for _, expected := range []struct {
+		backup   *v1.Backup
+		phase    v1.BackupPhase
+		position int
+	}{
+		{backup: newBackup, phase: v1.BackupPhaseQueued, position: 1},
+		{backup: movedBackup, phase: v1.BackupPhaseQueued, position: 2},
+		{backup: unchangedBackup, phase: v1.BackupPhaseQueued, position: 3},
+		{backup: inProgressBackup, phase: v1.BackupPhaseInProgress, position: 0},
+	} {
+		res, err := clientset.ArkV1().Backups(expected.backup.Namespace).Get(expected.backup.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, expected.phase, res.Status.Phase, expected.backup.Name)
+		assert.Equal(t, expected.position, res.Status.QueuePosition, expected.backup.Name)
+	}
+
+	assert.Equal(t, 2, c.queuedBackups.remove(fmt.Sprintf("%s/%s", movedBackup.Namespace, movedBackup.Name)))
+	assert.Equal(t, 0, c.queuedBackups.remove(fmt.Sprintf("%s/%s", inProgressBackup.Namespace, inProgressBackup.Name)))
+	assert.Equal(t, []string{"heptio-ark/backup-1", "heptio-ark/backup-3"}, c.queuedBackups.keys)
+}
+
+func TestProcessBackupClearsQueuePosition(t *testing.T) {
+	var (
+		// the lister hasn't seen the backup's queue position yet
+		backup          = arktest.NewTestBackup().WithName("backup-1").WithPhase(v1.BackupPhaseNew).WithStorageLocation("nonexistent").Backup
+		queued          = backup.DeepCopy()
+		clientset       = fake.NewSimpleClientset(queued)
+		sharedInformers = informers.NewSharedInformerFactory(clientset, 0)
+		logger          = logging.DefaultLogger(logrus.DebugLevel)
+	)
+	queued.Status.Phase = v1.BackupPhaseQueued
+	queued.Status.QueuePosition = 1
+
+	c := &backupController{
+		genericController:      newGenericController("backup-test", logger),
+		client:                 clientset.ArkV1(),
+		lister:                 sharedInformers.Ark().V1().Backups().Lister(),
+		backupLocationLister:   sharedInformers.Ark().V1().BackupStorageLocations().Lister(),
+		snapshotLocationLister: sharedInformers.Ark().V1().VolumeSnapshotLocations().Lister(),
+		queuedBackups:          newBackupQueue(),
+	}
+
+	require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(backup))
+	c.queuedBackups.add("heptio-ark/backup-1")
+	c.queuedBackups.report(func(string, int) bool { return true })
+
+	require.NoError(t, c.processBackup("heptio-ark/backup-1"))
+
+	res, err := clientset.ArkV1().Backups(backup.Namespace).Get(backup.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, v1.BackupPhaseFailedValidation, res.Status.Phase)
+	assert.Equal(t, 0, res.Status.QueuePosition)
+	assert.Empty(t, c.queuedBackups.keys)
+}
+
+func TestProcessBackupWithoutQueue(t *testing.T) {
+	var (
+		backup          = arktest.NewTestBackup().WithName("backup-1").WithPhase(v1.BackupPhaseNew).WithStorageLocation("nonexistent").Backup
+		clientset       = fake.NewSimpleClientset(backup)
+		sharedInformers = informers.NewSharedInformerFactory(clientset, 0)
+		logger          = logging.DefaultLogger(logrus.DebugLevel)
+	)
+
+	// controllers that aren't built by NewBackupController have no queue
+	c := &backupController{
+		genericController:      newGenericController("backup-test", logger),
+		client:                 clientset.ArkV1(),
+		lister:                 sharedInformers.Ark().V1().Backups().Lister(),
+		backupLocationLister:   sharedInformers.Ark().V1().BackupStorageLocations().Lister(),
+		snapshotLocationLister: sharedInformers.Ark().V1().VolumeSnapshotLocations().Lister(),
+	}
+
+	require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(backup))
+	require.NoError(t, c.processBackup("heptio-ark/backup-1"))
+
+	res, err := clientset.ArkV1().Backups(backup.Namespace).Get(backup.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, v1.BackupPhaseFailedValidation, res.Status.Phase)
+}
+
+// emptyTarballSHA256 is the checksum of the empty tarball written by fakeBackupper.
+const emptyTarballSHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
 func TestProcessBackupCompletions(t *testing.T) {
 	defaultBackupLocation := arktest.NewTestBackupStorageLocation().WithName("loc-1").BackupStorageLocation
 
@@ -224,12 +400,14 @@ func TestProcessBackupCompletions(t *testing.T) {
 				},
 				Spec: v1.BackupSpec{
 					StorageLocation: defaultBackupLocation.Name,
+					ArchiveFormat:   v1.ArchiveFormatTarGzip,
 				},
 				Status: v1.BackupStatus{
 					Phase:               v1.BackupPhaseCompleted,
-					Version:             1,
+					Version:             archive.FormatVersion,
 					StartTimestamp:      metav1.NewTime(now),
 					CompletionTimestamp: metav1.NewTime(now),
+					TarballSHA256:       emptyTarballSHA256,
 				},
 			},
 		},
@@ -247,12 +425,14 @@ func TestProcessBackupCompletions(t *testing.T) {
 				},
 				Spec: v1.BackupSpec{
 					StorageLocation: "alt-loc",
+					ArchiveFormat:   v1.ArchiveFormatTarGzip,
 				},
 				Status: v1.BackupStatus{
 					Phase:               v1.BackupPhaseCompleted,
-					Version:             1,
+					Version:             archive.FormatVersion,
 					StartTimestamp:      metav1.NewTime(now),
 					CompletionTimestamp: metav1.NewTime(now),
+					TarballSHA256:       emptyTarballSHA256,
 				},
 			},
 		},
@@ -271,13 +451,15 @@ func TestProcessBackupCompletions(t *testing.T) {
 				Spec: v1.BackupSpec{
 					TTL:             metav1.Duration{Duration: 10 * time.Minute},
 					StorageLocation: defaultBackupLocation.Name,
+					ArchiveFormat:   v1.ArchiveFormatTarGzip,
 				},
 				Status: v1.BackupStatus{
 					Phase:               v1.BackupPhaseCompleted,
-					Version:             1,
+					Version:             archive.FormatVersion,
 					Expiration:          metav1.NewTime(now.Add(10 * time.Minute)),
 					StartTimestamp:      metav1.NewTime(now),
 					CompletionTimestamp: metav1.NewTime(now),
+					TarballSHA256:       emptyTarballSHA256,
 				},
 			},
 		},
@@ -321,7 +503,7 @@ func TestProcessBackupCompletions(t *testing.T) {
 			completionTimestampIsPresent := func(buf *bytes.Buffer) bool {
 				return strings.Contains(buf.String(), `"completionTimestamp": "2006-01-02T22:04:05Z"`)
 			}
-			backupStore.On("PutBackup", test.backup.Name, mock.MatchedBy(completionTimestampIsPresent), mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			backupStore.On("PutBackup", test.backup.Name, mock.MatchedBy(completionTimestampIsPresent), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 			// add the test's backup to the informer/lister store
 			require.NotNil(t, test.backup)
@@ -439,6 +621,33 @@ func TestValidateAndGetSnapshotLocations(t *testing.T) {
 			expectedVolumeSnapshotLocationNames: []string{"aws-us-west-1", "some-name"},
 			expectedSuccess:                     true,
 		},
+		{
+			name:   "explicitly-named location is unavailable: error",
+			backup: arktest.NewTestBackup().WithName("backup1").WithVolumeSnapshotLocations("aws-us-west-1"),
+			locations: []*arktest.TestVolumeSnapshotLocation{
+				arktest.NewTestVolumeSnapshotLocation().WithProvider("aws").WithName("aws-us-west-1").WithPhase(v1.VolumeSnapshotLocationPhaseUnavailable, "bad credentials"),
+			},
+			expectedErrors:  "volume snapshot location aws-us-west-1 is unavailable: bad credentials",
+			expectedSuccess: false,
+		},
+		{
+			name:   "only location for the provider is unavailable: error",
+			backup: arktest.NewTestBackup().WithName("backup1").WithPhase(v1.BackupPhaseNew),
+			locations: []*arktest.TestVolumeSnapshotLocation{
+				arktest.NewTestVolumeSnapshotLocation().WithProvider("aws").WithName("aws-us-east-1").WithPhase(v1.VolumeSnapshotLocationPhaseUnavailable, "bad region"),
+			},
+			expectedErrors:  "volume snapshot location aws-us-east-1 is unavailable: bad region",
+			expectedSuccess: false,
+		},
+		{
+			name:   "available location: use it",
+			backup: arktest.NewTestBackup().WithName("backup1").WithVolumeSnapshotLocations("aws-us-west-1"),
+			locations: []*arktest.TestVolumeSnapshotLocation{
+				arktest.NewTestVolumeSnapshotLocation().WithProvider("aws").WithName("aws-us-west-1").WithPhase(v1.VolumeSnapshotLocationPhaseAvailable, ""),
+			},
+			expectedVolumeSnapshotLocationNames: []string{"aws-us-west-1"},
+			expectedSuccess:                     true,
+		},
 	}
 
 	for _, test := range tests {
@@ -483,3 +692,255 @@ func TestValidateAndGetSnapshotLocations(t *testing.T) {
 		})
 	}
 }
+
+func TestPrepareBackupRequestOrdersSnapshotLocationsByProvider(t *testing.T) {
+	var (
+		client          = fake.NewSimpleClientset()
+		sharedInformers = informers.NewSharedInformerFactory(client, 0)
+	)
+
+	c := &backupController{
+		lister:                 sharedInformers.Ark().V1().Backups().Lister(),
+		backupLocationLister:   sharedInformers.Ark().V1().BackupStorageLocations().Lister(),
+		snapshotLocationLister: sharedInformers.Ark().V1().VolumeSnapshotLocations().Lister(),
+		clock:                  clock.NewFakeClock(time.Now()),
+	}
+
+	require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(
+		arktest.NewTestBackupStorageLocation().WithName("loc-1").BackupStorageLocation,
+	))
+	for _, location := range []*arktest.TestVolumeSnapshotLocation{
+		arktest.NewTestVolumeSnapshotLocation().WithName("px-1").WithProvider("portworx"),
+		arktest.NewTestVolumeSnapshotLocation().WithName("ebs-1").WithProvider("aws"),
+		arktest.NewTestVolumeSnapshotLocation().WithName("pd-1").WithProvider("gcp"),
+	} {
+		require.NoError(t, sharedInformers.Ark().V1().VolumeSnapshotLocations().Informer().GetStore().Add(location.VolumeSnapshotLocation))
+	}
+
+	request := c.prepareBackupRequest(arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("loc-1").Backup)
+
+	require.Empty(t, request.Status.ValidationErrors)
+	assert.Equal(t, []string{"ebs-1", "pd-1", "px-1"}, request.Spec.VolumeSnapshotLocations)
+	require.Len(t, request.SnapshotLocations, 3)
+	for i, location := range request.SnapshotLocations {
+		assert.Equal(t, request.Spec.VolumeSnapshotLocations[i], location.Name)
+	}
+}
+
+func TestValidateTenantQuota(t *testing.T) {
+	now := time.Now()
+
+	tenantBackup := func(name string, size int64, start time.Time) *v1.Backup {
+		backup := arktest.NewTestBackup().WithName(name).
+			WithLabel(v1.StorageLocationLabel, "loc-1").
+			WithLabel(v1.SelfServiceNamespaceLabel, "team-a").
+			WithStartTimestamp(start).
+			Backup
+		backup.Status.TarballSizeBytes = size
+		return backup
+	}
+
+	quota := resource.MustParse("1Ki")
+
+	tests := []struct {
+		name          string
+		backup        *v1.Backup
+		tenants       []v1.TenantStorage
+		existing      []*v1.Backup
+		expectedError bool
+	}{
+		{
+			name:     "backup without a tenant is not limited",
+			backup:   arktest.NewTestBackup().WithName("backup-1").Backup,
+			tenants:  []v1.TenantStorage{{Namespace: "team-a", Quota: &quota}},
+			existing: []*v1.Backup{tenantBackup("old", 2048, now)},
+		},
+		{
+			name:     "tenant without a quota is not limited",
+			backup:   tenantBackup("backup-1", 0, time.Time{}),
+			tenants:  []v1.TenantStorage{{Namespace: "team-a"}},
+			existing: []*v1.Backup{tenantBackup("old", 2048, now)},
+		},
+		{
+			name:    "tenant within its quota is allowed",
+			backup:  tenantBackup("backup-1", 0, time.Time{}),
+			tenants: []v1.TenantStorage{{Namespace: "team-a", Quota: &quota}},
+			existing: []*v1.Backup{
+				tenantBackup("old-1", 256, now.Add(-time.Hour)),
+				tenantBackup("old-2", 256, now),
+			},
+		},
+		{
+			name:    "tenant whose next backup would exceed its quota is rejected",
+			backup:  tenantBackup("backup-1", 0, time.Time{}),
+			tenants: []v1.TenantStorage{{Namespace: "team-a", Quota: &quota}},
+			existing: []*v1.Backup{
+				tenantBackup("old-1", 256, now.Add(-time.Hour)),
+				tenantBackup("old-2", 512, now),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+			)
+
+			c := &backupController{
+				lister: sharedInformers.Ark().V1().Backups().Lister(),
+			}
+
+			for _, backup := range test.existing {
+				require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(backup))
+			}
+
+			location := arktest.NewTestBackupStorageLocation().WithName("loc-1").BackupStorageLocation
+			location.Spec.Tenants = test.tenants
+
+			err := c.validateTenantQuota(test.backup, location)
+			if test.expectedError {
+				assert.NotEmpty(t, err)
+			} else {
+				assert.Empty(t, err)
+			}
+		})
+	}
+}
+
+func TestExpectedBackupSize(t *testing.T) {
+	now := time.Now()
+
+	newBackup := func(name, location, schedule string, size int64, start time.Time) *v1.Backup {
+		backup := arktest.NewTestBackup().WithName(name).
+			WithLabel(v1.StorageLocationLabel, location).
+			WithStartTimestamp(start).
+			Backup
+		if schedule != "" {
+			backup.Labels["ark-schedule"] = schedule
+		}
+		backup.Status.TarballSizeBytes = size
+		return backup
+	}
+
+	tests := []struct {
+		name         string
+		backup       *v1.Backup
+		existing     []*v1.Backup
+		expectedSize int64
+	}{
+		{
+			name:   "no previous backups",
+			backup: newBackup("backup-1", "loc-1", "", 0, time.Time{}),
+		},
+		{
+			name:   "most recent backup in the location is used",
+			backup: newBackup("backup-1", "loc-1", "", 0, time.Time{}),
+			existing: []*v1.Backup{
+				newBackup("old-1", "loc-1", "", 100, now.Add(-time.Hour)),
+				newBackup("old-2", "loc-1", "", 200, now),
+				newBackup("old-3", "loc-2", "", 300, now.Add(time.Hour)),
+			},
+			expectedSize: 200,
+		},
+		{
+			name:   "most recent backup from the same schedule is used",
+			backup: newBackup("backup-1", "loc-1", "daily", 0, time.Time{}),
+			existing: []*v1.Backup{
+				newBackup("old-1", "loc-1", "daily", 100, now.Add(-time.Hour)),
+				newBackup("old-2", "loc-1", "", 200, now),
+			},
+			expectedSize: 100,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+			)
+
+			for _, backup := range test.existing {
+				require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(backup))
+			}
+
+			size, err := expectedBackupSize(sharedInformers.Ark().V1().Backups().Lister(), test.backup)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSize, size)
+		})
+	}
+}
+
+func TestPutBackupIndex(t *testing.T) {
+	backupFile, err := ioutil.TempFile("", "")
+	require.NoError(t, err)
+	defer closeAndRemoveFile(backupFile, arktest.NewLogger())
+
+	tw := archive.NewGzipTarWriter(backupFile)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "resources/pods/namespaces/ns-1/pod-1.json", Size: 4, Typeflag: tar.TypeReg, Mode: 0755}))
+	_, err = tw.Write([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	tests := []struct {
+		name          string
+		phase         v1.BackupPhase
+		format        v1.ArchiveFormat
+		expectedPaths []string
+	}{
+		{
+			name:          "completed tar.gz backup's index is uploaded",
+			phase:         v1.BackupPhaseCompleted,
+			format:        v1.ArchiveFormatTarGzip,
+			expectedPaths: []string{"resources/pods/namespaces/ns-1/pod-1.json"},
+		},
+		{
+			name:   "failed backup's index isn't uploaded",
+			phase:  v1.BackupPhaseFailed,
+			format: v1.ArchiveFormatTarGzip,
+		},
+		{
+			name:   "zip backup has no index",
+			phase:  v1.BackupPhaseCompleted,
+			format: v1.ArchiveFormatZip,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backup := &pkgbackup.Request{
+				Backup: arktest.NewTestBackup().WithName("backup-1").WithPhase(test.phase).WithArchiveFormat(test.format).Backup,
+			}
+
+			var uploaded *archive.Index
+			backupStore := new(persistencemocks.BackupStore)
+			defer backupStore.AssertExpectations(t)
+
+			if test.expectedPaths != nil {
+				backupStore.On("PutBackupIndex", "backup-1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					gzr, err := gzip.NewReader(args.Get(1).(io.Reader))
+					require.NoError(t, err)
+
+					uploaded = new(archive.Index)
+					require.NoError(t, json.NewDecoder(gzr).Decode(uploaded))
+				})
+			}
+
+			require.NoError(t, putBackupIndex(backup, backupFile, backupStore))
+
+			if test.expectedPaths == nil {
+				return
+			}
+
+			require.NotNil(t, uploaded)
+			var paths []string
+			for _, item := range uploaded.Items {
+				paths = append(paths, item.Path)
+			}
+			assert.Equal(t, test.expectedPaths, paths)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -54,6 +55,7 @@ type backupDeletionController struct {
 	backupClient              arkv1client.BackupsGetter
 	restoreLister             listers.RestoreLister
 	restoreClient             arkv1client.RestoresGetter
+	volumeSnapshotClient      arkv1client.VolumeSnapshotsGetter
 	backupTracker             BackupTracker
 	resticMgr                 restic.RepositoryManager
 	podvolumeBackupLister     listers.PodVolumeBackupLister
@@ -73,6 +75,7 @@ func NewBackupDeletionController(
 	backupClient arkv1client.BackupsGetter,
 	restoreInformer informers.RestoreInformer,
 	restoreClient arkv1client.RestoresGetter,
+	volumeSnapshotClient arkv1client.VolumeSnapshotsGetter,
 	backupTracker BackupTracker,
 	resticMgr restic.RepositoryManager,
 	podvolumeBackupInformer informers.PodVolumeBackupInformer,
@@ -87,6 +90,7 @@ func NewBackupDeletionController(
 		backupClient:              backupClient,
 		restoreLister:             restoreInformer.Lister(),
 		restoreClient:             restoreClient,
+		volumeSnapshotClient:      volumeSnapshotClient,
 		backupTracker:             backupTracker,
 		resticMgr:                 resticMgr,
 		podvolumeBackupLister:     podvolumeBackupInformer.Lister(),
@@ -215,6 +219,27 @@ func (c *backupDeletionController) processRequest(req *v1.DeleteBackupRequest) e
 		return errors.Wrap(err, "error getting Backup")
 	}
 
+	// Don't allow deleting a backup that's on hold
+	if backup.Spec.Hold {
+		_, err = c.patchDeleteBackupRequest(req, func(r *v1.DeleteBackupRequest) {
+			r.Status.Phase = v1.DeleteBackupRequestPhaseProcessed
+			r.Status.Errors = []string{"backup is on hold"}
+		})
+
+		return err
+	}
+
+	// Don't allow deleting a backup synced from another cluster, since its files
+	// and snapshots belong to that cluster
+	if cluster := persistence.BackupSourceCluster(backup); cluster != "" {
+		_, err = c.patchDeleteBackupRequest(req, func(r *v1.DeleteBackupRequest) {
+			r.Status.Phase = v1.DeleteBackupRequestPhaseProcessed
+			r.Status.Errors = []string{fmt.Sprintf("backup was synced from cluster %s and can only be deleted from it", cluster)}
+		})
+
+		return err
+	}
+
 	// Set backup-uid label if needed
 	if req.Labels[v1.BackupUIDLabel] == "" {
 		req, err = c.patchDeleteBackupRequest(req, func(r *v1.DeleteBackupRequest) {
@@ -244,7 +269,14 @@ func (c *backupDeletionController) processRequest(req *v1.DeleteBackupRequest) e
 		errs = append(errs, backupStoreErr.Error())
 	}
 
-	if backupStore != nil {
+	// the files of backups in deletion-protected locations, including their restic
+	// data and PV snapshots, are retained so that the backup can still be restored
+	retainFiles := c.hasDeletionProtection(backup, log)
+	if retainFiles {
+		log.Info("Backup storage location has deletion protection enabled, so the backup's files and PV snapshots won't be removed")
+	}
+
+	if backupStore != nil && !retainFiles {
 		log.Info("Removing PV snapshots")
 		if len(backup.Status.VolumeBackups) > 0 {
 			// pre-v0.10 backup
@@ -292,14 +324,16 @@ func (c *backupDeletionController) processRequest(req *v1.DeleteBackupRequest) e
 		}
 	}
 
-	log.Info("Removing restic snapshots")
-	if deleteErrs := c.deleteResticSnapshots(backup); len(deleteErrs) > 0 {
-		for _, err := range deleteErrs {
-			errs = append(errs, err.Error())
+	if !retainFiles {
+		log.Info("Removing restic snapshots")
+		if deleteErrs := c.deleteResticSnapshots(backup); len(deleteErrs) > 0 {
+			for _, err := range deleteErrs {
+				errs = append(errs, err.Error())
+			}
 		}
 	}
 
-	if backupStore != nil {
+	if backupStore != nil && !retainFiles {
 		log.Info("Removing backup from backup storage")
 		if err := backupStore.DeleteBackup(backup.Name); err != nil {
 			errs = append(errs, err.Error())
@@ -317,11 +351,13 @@ func (c *backupDeletionController) processRequest(req *v1.DeleteBackupRequest) e
 
 			restoreLog := log.WithField("restore", kube.NamespaceAndName(restore))
 
-			restoreLog.Info("Deleting restore log/results from backup storage")
-			if err := backupStore.DeleteRestore(restore.Name); err != nil {
-				errs = append(errs, err.Error())
-				// if we couldn't delete the restore files, don't delete the API object
-				continue
+			if !retainFiles {
+				restoreLog.Info("Deleting restore log/results from backup storage")
+				if err := backupStore.DeleteRestore(restore.Name); err != nil {
+					errs = append(errs, err.Error())
+					// if we couldn't delete the restore files, don't delete the API object
+					continue
+				}
 			}
 
 			restoreLog.Info("Deleting restore referencing backup")
@@ -331,6 +367,18 @@ func (c *backupDeletionController) processRequest(req *v1.DeleteBackupRequest) e
 		}
 	}
 
+	if len(errs) == 0 {
+		// The volume snapshot records are needed to restore the backup, so only delete them
+		// once its snapshots are gone.
+		log.Info("Removing volume snapshot records")
+		listOptions := metav1.ListOptions{
+			LabelSelector: labels.Set(map[string]string{v1.BackupNameLabel: backup.Name}).AsSelector().String(),
+		}
+		if err := c.volumeSnapshotClient.VolumeSnapshots(backup.Namespace).DeleteCollection(nil, listOptions); err != nil {
+			errs = append(errs, errors.Wrap(err, "error deleting volume snapshot records").Error())
+		}
+	}
+
 	if len(errs) == 0 {
 		// Only try to delete the backup object from kube if everything preceding went smoothly
 		err = c.backupClient.Backups(backup.Namespace).Delete(backup.Name, nil)
@@ -389,13 +437,32 @@ func (c *backupDeletionController) backupStoreForBackup(backup *v1.Backup, plugi
 		return nil, errors.WithStack(err)
 	}
 
-	backupStore, err := c.newBackupStore(backupLocation, pluginManager, log)
+	location, err := persistence.BackupLocation(backupLocation, backup)
+	if err != nil {
+		return nil, err
+	}
+
+	backupStore, err := c.newBackupStore(location, pluginManager, log)
 	if err != nil {
 		return nil, err
 	}
 
 	return backupStore, nil
 }
+
+// hasDeletionProtection returns true if the backup's storage location has deletion
+// protection enabled. If the location can't be looked up, the backup is treated as
+// protected so that nothing is removed from a location that may be protected.
+func (c *backupDeletionController) hasDeletionProtection(backup *v1.Backup, log logrus.FieldLogger) bool {
+	location, err := c.backupLocationLister.BackupStorageLocations(backup.Namespace).Get(backup.Spec.StorageLocation)
+	if err != nil {
+		log.WithError(errors.WithStack(err)).Warn("Unable to get backup storage location, treating it as deletion-protected")
+		return true
+	}
+
+	return location.Spec.DeletionProtection
+}
+
 func (c *backupDeletionController) deleteExistingDeletionRequests(req *v1.DeleteBackupRequest, log logrus.FieldLogger) []error {
 	log.Info("Removing existing deletion requests for backup")
 	selector := labels.SelectorFromSet(labels.Set(map[string]string{
@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,6 +56,7 @@ func TestBackupDeletionControllerProcessQueueItem(t *testing.T) {
 		client.ArkV1(), // backupClient
 		sharedInformers.Ark().V1().Restores(),
 		client.ArkV1(), // restoreClient
+		client.ArkV1(), // volumeSnapshotClient
 		NewBackupTracker(),
 		nil, // restic repository manager
 		sharedInformers.Ark().V1().PodVolumeBackups(),
@@ -141,6 +143,7 @@ func setupBackupDeletionControllerTest(objects ...runtime.Object) *backupDeletio
 			client.ArkV1(), // backupClient
 			sharedInformers.Ark().V1().Restores(),
 			client.ArkV1(), // restoreClient
+			client.ArkV1(), // volumeSnapshotClient
 			NewBackupTracker(),
 			nil, // restic repository manager
 			sharedInformers.Ark().V1().PodVolumeBackups(),
@@ -323,6 +326,74 @@ func TestBackupDeletionControllerProcessRequest(t *testing.T) {
 		assert.Equal(t, expectedActions, td.client.Actions())
 	})
 
+	t.Run("deleting a backup on hold isn't allowed", func(t *testing.T) {
+		backup := arktest.NewTestBackup().WithName("foo").WithHold(true).Backup
+		td := setupBackupDeletionControllerTest(backup)
+
+		td.client.PrependReactor("patch", "deletebackuprequests", func(action core.Action) (bool, runtime.Object, error) {
+			return true, td.req, nil
+		})
+
+		err := td.controller.processRequest(td.req)
+		require.NoError(t, err)
+
+		expectedActions := []core.Action{
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				td.req.Name,
+				[]byte(`{"status":{"phase":"InProgress"}}`),
+			),
+			core.NewGetAction(
+				v1.SchemeGroupVersion.WithResource("backups"),
+				td.req.Namespace,
+				td.req.Spec.BackupName,
+			),
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				td.req.Name,
+				[]byte(`{"status":{"errors":["backup is on hold"],"phase":"Processed"}}`),
+			),
+		}
+
+		assert.Equal(t, expectedActions, td.client.Actions())
+	})
+
+	t.Run("deleting a backup synced from another cluster isn't allowed", func(t *testing.T) {
+		backup := arktest.NewTestBackup().WithName("foo").WithLabel(v1.SourceClusterLabel, "cluster-2").Backup
+		td := setupBackupDeletionControllerTest(backup)
+
+		td.client.PrependReactor("patch", "deletebackuprequests", func(action core.Action) (bool, runtime.Object, error) {
+			return true, td.req, nil
+		})
+
+		err := td.controller.processRequest(td.req)
+		require.NoError(t, err)
+
+		expectedActions := []core.Action{
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				td.req.Name,
+				[]byte(`{"status":{"phase":"InProgress"}}`),
+			),
+			core.NewGetAction(
+				v1.SchemeGroupVersion.WithResource("backups"),
+				td.req.Namespace,
+				td.req.Spec.BackupName,
+			),
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				td.req.Name,
+				[]byte(`{"status":{"errors":["backup was synced from cluster cluster-2 and can only be deleted from it"],"phase":"Processed"}}`),
+			),
+		}
+
+		assert.Equal(t, expectedActions, td.client.Actions())
+	})
+
 	t.Run("pre-v0.10 backup with snapshots, no errors", func(t *testing.T) {
 		backup := arktest.NewTestBackup().WithName("foo").Backup
 		backup.UID = "uid"
@@ -432,6 +503,11 @@ func TestBackupDeletionControllerProcessRequest(t *testing.T) {
 				td.req.Namespace,
 				"restore-2",
 			),
+			core.NewDeleteCollectionAction(
+				v1.SchemeGroupVersion.WithResource("volumesnapshots"),
+				td.req.Namespace,
+				metav1.ListOptions{LabelSelector: v1.BackupNameLabel + "=" + td.req.Spec.BackupName},
+			),
 			core.NewDeleteAction(
 				v1.SchemeGroupVersion.WithResource("backups"),
 				td.req.Namespace,
@@ -572,6 +648,11 @@ func TestBackupDeletionControllerProcessRequest(t *testing.T) {
 				td.req.Namespace,
 				"restore-2",
 			),
+			core.NewDeleteCollectionAction(
+				v1.SchemeGroupVersion.WithResource("volumesnapshots"),
+				td.req.Namespace,
+				metav1.ListOptions{LabelSelector: v1.BackupNameLabel + "=" + td.req.Spec.BackupName},
+			),
 			core.NewDeleteAction(
 				v1.SchemeGroupVersion.WithResource("backups"),
 				td.req.Namespace,
@@ -595,6 +676,190 @@ func TestBackupDeletionControllerProcessRequest(t *testing.T) {
 		// Make sure snapshot was deleted
 		assert.Equal(t, 0, td.blockStore.SnapshotsTaken.Len())
 	})
+
+	t.Run("deletion-protected location, files and snapshots are retained", func(t *testing.T) {
+		backup := arktest.NewTestBackup().WithName("foo").Backup
+		backup.UID = "uid"
+		backup.Spec.StorageLocation = "primary"
+
+		restore1 := arktest.NewTestRestore("heptio-ark", "restore-1", v1.RestorePhaseCompleted).WithBackup("foo").Restore
+		restore2 := arktest.NewTestRestore("heptio-ark", "restore-2", v1.RestorePhaseCompleted).WithBackup("foo").Restore
+		restore3 := arktest.NewTestRestore("heptio-ark", "restore-3", v1.RestorePhaseCompleted).WithBackup("some-other-backup").Restore
+
+		td := setupBackupDeletionControllerTest(backup, restore1, restore2, restore3)
+
+		td.sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(restore1)
+		td.sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(restore2)
+		td.sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(restore3)
+
+		location := &v1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: backup.Namespace,
+				Name:      backup.Spec.StorageLocation,
+			},
+			Spec: v1.BackupStorageLocationSpec{
+				Provider:           "objStoreProvider",
+				DeletionProtection: true,
+				StorageType: v1.StorageType{
+					ObjectStorage: &v1.ObjectStorageLocation{
+						Bucket: "bucket",
+					},
+				},
+			},
+		}
+		require.NoError(t, td.sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(location))
+
+		snapshotLocation := &v1.VolumeSnapshotLocation{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: backup.Namespace,
+				Name:      "vsl-1",
+			},
+			Spec: v1.VolumeSnapshotLocationSpec{
+				Provider: "provider-1",
+			},
+		}
+		require.NoError(t, td.sharedInformers.Ark().V1().VolumeSnapshotLocations().Informer().GetStore().Add(snapshotLocation))
+
+		// Clear out req labels to make sure the controller adds them
+		td.req.Labels = make(map[string]string)
+
+		td.client.PrependReactor("get", "backups", func(action core.Action) (bool, runtime.Object, error) {
+			return true, backup, nil
+		})
+		td.blockStore.SnapshotsTaken.Insert("snap-1")
+
+		td.client.PrependReactor("patch", "deletebackuprequests", func(action core.Action) (bool, runtime.Object, error) {
+			return true, td.req, nil
+		})
+
+		td.client.PrependReactor("patch", "backups", func(action core.Action) (bool, runtime.Object, error) {
+			return true, backup, nil
+		})
+
+		snapshots := []*volume.Snapshot{
+			{
+				Spec: volume.SnapshotSpec{
+					Location: "vsl-1",
+				},
+				Status: volume.SnapshotStatus{
+					ProviderSnapshotID: "snap-1",
+				},
+			},
+		}
+
+		pluginManager := &pluginmocks.Manager{}
+		pluginManager.On("GetBlockStore", "provider-1").Return(td.blockStore, nil)
+		pluginManager.On("CleanupClients")
+		td.controller.newPluginManager = func(logrus.FieldLogger) plugin.Manager { return pluginManager }
+
+		td.backupStore.On("GetBackupVolumeSnapshots", td.req.Spec.BackupName).Return(snapshots, nil)
+
+		err := td.controller.processRequest(td.req)
+		require.NoError(t, err)
+
+		expectedActions := []core.Action{
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				td.req.Name,
+				[]byte(`{"metadata":{"labels":{"ark.heptio.com/backup-name":"foo"}},"status":{"phase":"InProgress"}}`),
+			),
+			core.NewGetAction(
+				v1.SchemeGroupVersion.WithResource("backups"),
+				td.req.Namespace,
+				td.req.Spec.BackupName,
+			),
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				td.req.Name,
+				[]byte(`{"metadata":{"labels":{"ark.heptio.com/backup-uid":"uid"}}}`),
+			),
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("backups"),
+				td.req.Namespace,
+				td.req.Spec.BackupName,
+				[]byte(`{"status":{"phase":"Deleting"}}`),
+			),
+			core.NewDeleteAction(
+				v1.SchemeGroupVersion.WithResource("restores"),
+				td.req.Namespace,
+				"restore-1",
+			),
+			core.NewDeleteAction(
+				v1.SchemeGroupVersion.WithResource("restores"),
+				td.req.Namespace,
+				"restore-2",
+			),
+			core.NewDeleteCollectionAction(
+				v1.SchemeGroupVersion.WithResource("volumesnapshots"),
+				td.req.Namespace,
+				metav1.ListOptions{LabelSelector: v1.BackupNameLabel + "=" + td.req.Spec.BackupName},
+			),
+			core.NewDeleteAction(
+				v1.SchemeGroupVersion.WithResource("backups"),
+				td.req.Namespace,
+				td.req.Spec.BackupName,
+			),
+			core.NewPatchAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				td.req.Name,
+				[]byte(`{"status":{"phase":"Processed"}}`),
+			),
+			core.NewDeleteCollectionAction(
+				v1.SchemeGroupVersion.WithResource("deletebackuprequests"),
+				td.req.Namespace,
+				pkgbackup.NewDeleteBackupRequestListOptions(td.req.Spec.BackupName, "uid"),
+			),
+		}
+
+		arktest.CompareActions(t, expectedActions, td.client.Actions())
+
+		td.backupStore.AssertNotCalled(t, "DeleteBackup", mock.Anything)
+		td.backupStore.AssertNotCalled(t, "DeleteRestore", mock.Anything)
+
+		td.backupStore.AssertNotCalled(t, "GetBackupVolumeSnapshots", mock.Anything)
+
+		// Make sure snapshot was retained
+		assert.Equal(t, 1, td.blockStore.SnapshotsTaken.Len())
+	})
+}
+
+func TestBackupDeletionControllerHasDeletionProtection(t *testing.T) {
+	tests := []struct {
+		name     string
+		location *v1.BackupStorageLocation
+		expected bool
+	}{
+		{
+			name:     "location without deletion protection",
+			location: arktest.NewTestBackupStorageLocation().WithName("primary").BackupStorageLocation,
+			expected: false,
+		},
+		{
+			name:     "location with deletion protection",
+			location: arktest.NewTestBackupStorageLocation().WithName("primary").WithDeletionProtection(true).BackupStorageLocation,
+			expected: true,
+		},
+		{
+			name:     "location that can't be found is treated as protected",
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backup := arktest.NewTestBackup().WithName("foo").WithStorageLocation("primary").Backup
+			td := setupBackupDeletionControllerTest(backup)
+
+			if test.location != nil {
+				require.NoError(t, td.sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(test.location))
+			}
+
+			assert.Equal(t, test.expected, td.controller.hasDeletionProtection(backup, arktest.NewLogger()))
+		})
+	}
 }
 
 func TestBackupDeletionControllerDeleteExpiredRequests(t *testing.T) {
@@ -717,6 +982,7 @@ func TestBackupDeletionControllerDeleteExpiredRequests(t *testing.T) {
 				client.ArkV1(), // backupClient
 				sharedInformers.Ark().V1().Restores(),
 				client.ArkV1(), // restoreClient
+				client.ArkV1(), // volumeSnapshotClient
 				NewBackupTracker(),
 				nil,
 				sharedInformers.Ark().V1().PodVolumeBackups(),
@@ -0,0 +1,297 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	pkgbackup "github.com/heptio/ark/pkg/backup"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/plugin"
+	"github.com/heptio/ark/pkg/util/collections"
+	"github.com/heptio/ark/pkg/util/kube"
+)
+
+// backupEstimateTTL is how long a computed BackupEstimate is kept before
+// it's deleted.
+const backupEstimateTTL = time.Hour
+
+type backupEstimateController struct {
+	*genericController
+
+	backupEstimateClient     arkv1client.BackupEstimatesGetter
+	backupEstimateLister     listers.BackupEstimateLister
+	backupper                pkgbackup.Backupper
+	snapshotLocationLister   listers.VolumeSnapshotLocationLister
+	defaultSnapshotLocations map[string]string
+	newPluginManager         func(logrus.FieldLogger) plugin.Manager
+	clock                    clock.Clock
+}
+
+// NewBackupEstimateController creates a new BackupEstimateController.
+func NewBackupEstimateController(
+	backupEstimateClient arkv1client.BackupEstimatesGetter,
+	backupEstimateInformer informers.BackupEstimateInformer,
+	backupper pkgbackup.Backupper,
+	volumeSnapshotLocationInformer informers.VolumeSnapshotLocationInformer,
+	defaultSnapshotLocations map[string]string,
+	newPluginManager func(logrus.FieldLogger) plugin.Manager,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &backupEstimateController{
+		genericController:        newGenericController("backupestimate", logger),
+		backupEstimateClient:     backupEstimateClient,
+		backupEstimateLister:     backupEstimateInformer.Lister(),
+		backupper:                backupper,
+		snapshotLocationLister:   volumeSnapshotLocationInformer.Lister(),
+		defaultSnapshotLocations: defaultSnapshotLocations,
+
+		// use variables to refer to these functions so they can be
+		// replaced with fakes for testing.
+		newPluginManager: newPluginManager,
+
+		clock: &clock.RealClock{},
+	}
+
+	c.syncHandler = c.processBackupEstimate
+	c.resyncFunc = c.resync
+	c.resyncPeriod = time.Minute
+	c.cacheSyncWaiters = append(
+		c.cacheSyncWaiters,
+		backupEstimateInformer.Informer().HasSynced,
+		volumeSnapshotLocationInformer.Informer().HasSynced,
+	)
+
+	backupEstimateInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				key, err := cache.MetaNamespaceKeyFunc(obj)
+				if err != nil {
+					estimate := obj.(*api.BackupEstimate)
+					c.logger.WithError(errors.WithStack(err)).
+						WithField("backupEstimate", estimate.Name).
+						Error("Error creating queue key, item not added to queue")
+					return
+				}
+				c.queue.Add(key)
+			},
+		},
+	)
+
+	return c
+}
+
+// processBackupEstimate is the default per-item sync handler. It computes a new
+// BackupEstimate or deletes the BackupEstimate if it has expired.
+func (c *backupEstimateController) processBackupEstimate(key string) error {
+	log := c.logger.WithField("key", key)
+
+	log.Debug("Running processBackupEstimate")
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.WithError(err).Error("error splitting queue key")
+		return nil
+	}
+
+	estimate, err := c.backupEstimateLister.BackupEstimates(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Unable to find BackupEstimate")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting BackupEstimate")
+	}
+
+	switch estimate.Status.Phase {
+	case "", api.BackupEstimatePhaseNew:
+		return c.computeEstimate(estimate, log)
+	case api.BackupEstimatePhaseCompleted, api.BackupEstimatePhaseFailed:
+		return c.deleteIfExpired(estimate)
+	}
+
+	return nil
+}
+
+// computeEstimate collects the items that estimate's backup would include, records
+// their number and size in its status, and persists the changes to storage.
+func (c *backupEstimateController) computeEstimate(estimate *api.BackupEstimate, log logrus.FieldLogger) error {
+	original := estimate
+	estimate = estimate.DeepCopy()
+
+	estimate.Status.Phase = api.BackupEstimatePhaseInProgress
+	updated, err := patchBackupEstimate(original, estimate, c.backupEstimateClient)
+	if err != nil {
+		return errors.Wrapf(err, "error updating BackupEstimate phase to %s", estimate.Status.Phase)
+	}
+	original = updated
+	estimate = updated.DeepCopy()
+
+	if err := c.runEstimate(estimate, log); err != nil {
+		log.WithError(err).Error("backup estimate failed")
+		estimate.Status.Phase = api.BackupEstimatePhaseFailed
+		estimate.Status.FailureReason = err.Error()
+	} else {
+		estimate.Status.Phase = api.BackupEstimatePhaseCompleted
+	}
+
+	estimate.Status.CompletionTimestamp = metav1.NewTime(c.clock.Now())
+	estimate.Status.Expiration = metav1.NewTime(c.clock.Now().Add(backupEstimateTTL))
+
+	_, err = patchBackupEstimate(original, estimate, c.backupEstimateClient)
+	return errors.Wrap(err, "error updating BackupEstimate's final status")
+}
+
+func (c *backupEstimateController) runEstimate(estimate *api.BackupEstimate, log logrus.FieldLogger) error {
+	request := &pkgbackup.Request{
+		Backup: &api.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: estimate.Namespace,
+				Name:      estimate.Name,
+			},
+			Spec: *estimate.Spec.Backup.DeepCopy(),
+		},
+	}
+
+	var validationErrors []string
+
+	if err := pkgbackup.ApplyFilterProfile(request.Backup); err != nil {
+		validationErrors = append(validationErrors, fmt.Sprintf("Invalid filter profile: %v", err))
+	}
+	for _, err := range collections.ValidateIncludesExcludes(request.Spec.IncludedResources, request.Spec.ExcludedResources) {
+		validationErrors = append(validationErrors, fmt.Sprintf("Invalid included/excluded resource lists: %v", err))
+	}
+	for _, err := range collections.ValidateIncludesExcludes(request.Spec.IncludedNamespaces, request.Spec.ExcludedNamespaces) {
+		validationErrors = append(validationErrors, fmt.Sprintf("Invalid included/excluded namespace lists: %v", err))
+	}
+
+	locs, errs := validateAndGetSnapshotLocations(c.snapshotLocationLister, c.defaultSnapshotLocations, request.Backup)
+	validationErrors = append(validationErrors, errs...)
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	// order the locations by provider, as the backup controller does
+	providers := make([]string, 0, len(locs))
+	for provider := range locs {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		request.SnapshotLocations = append(request.SnapshotLocations, locs[provider])
+	}
+
+	pluginManager := c.newPluginManager(log)
+	defer pluginManager.CleanupClients()
+
+	actions, err := pluginManager.GetBackupItemActions()
+	if err != nil {
+		return err
+	}
+
+	result, err := c.backupper.Estimate(context.Background(), log, request, actions, pluginManager)
+	if err != nil {
+		return err
+	}
+
+	estimate.Status.Resources = result.Resources
+	estimate.Status.TotalItems = result.TotalItems
+	estimate.Status.TotalSize = result.TotalSize
+	estimate.Status.Volumes = result.Volumes
+	estimate.Status.VolumesSize = result.VolumesSize
+	estimate.Status.Errors = result.Errors
+
+	return nil
+}
+
+// deleteIfExpired deletes estimate if it has expired.
+func (c *backupEstimateController) deleteIfExpired(estimate *api.BackupEstimate) error {
+	log := c.logger.WithField("key", kube.NamespaceAndName(estimate))
+	if estimate.Status.Expiration.Time.After(c.clock.Now()) {
+		log.Debug("BackupEstimate has not expired")
+		return nil
+	}
+
+	log.Debug("BackupEstimate has expired - deleting")
+	err := c.backupEstimateClient.BackupEstimates(estimate.Namespace).Delete(estimate.Name, nil)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// resync requeues all the BackupEstimates in the lister's cache, so that expired
+// estimates are deleted.
+func (c *backupEstimateController) resync() {
+	list, err := c.backupEstimateLister.List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(errors.WithStack(err)).Error("error listing backup estimates")
+		return
+	}
+
+	for _, estimate := range list {
+		key, err := cache.MetaNamespaceKeyFunc(estimate)
+		if err != nil {
+			c.logger.WithError(errors.WithStack(err)).WithField("backupEstimate", estimate.Name).Error("error generating key for backup estimate")
+			continue
+		}
+
+		c.queue.Add(key)
+	}
+}
+
+func patchBackupEstimate(original, updated *api.BackupEstimate, client arkv1client.BackupEstimatesGetter) (*api.BackupEstimate, error) {
+	origBytes, err := json.Marshal(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling original backup estimate")
+	}
+
+	updatedBytes, err := json.Marshal(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling updated backup estimate")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(origBytes, updatedBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating json merge patch for backup estimate")
+	}
+
+	res, err := client.BackupEstimates(original.Namespace).Patch(original.Name, types.MergePatchType, patchBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error patching backup estimate")
+	}
+
+	return res, nil
+}
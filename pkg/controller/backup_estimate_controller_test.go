@@ -0,0 +1,170 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	pkgbackup "github.com/heptio/ark/pkg/backup"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/plugin"
+	pluginmocks "github.com/heptio/ark/pkg/plugin/mocks"
+	kubeutil "github.com/heptio/ark/pkg/util/kube"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessBackupEstimate(t *testing.T) {
+	now, err := time.Parse(time.RFC1123, time.RFC1123)
+	require.NoError(t, err)
+
+	newEstimate := func(phase api.BackupEstimatePhase, expiration time.Time) *api.BackupEstimate {
+		return &api.BackupEstimate{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: api.DefaultNamespace,
+				Name:      "estimate-1",
+			},
+			Spec: api.BackupEstimateSpec{
+				Backup: api.BackupSpec{IncludedNamespaces: []string{"ns-1"}},
+			},
+			Status: api.BackupEstimateStatus{
+				Phase:      phase,
+				Expiration: metav1.NewTime(expiration),
+			},
+		}
+	}
+
+	invalid := newEstimate(api.BackupEstimatePhaseNew, time.Time{})
+	invalid.Spec.Backup.ExcludedNamespaces = []string{"*"}
+
+	tests := []struct {
+		name           string
+		estimate       *api.BackupEstimate
+		backupperErr   error
+		expectEstimate bool
+		expectedPhase  api.BackupEstimatePhase
+		expectDeleted  bool
+	}{
+		{
+			name:           "new estimate is computed",
+			estimate:       newEstimate(api.BackupEstimatePhaseNew, time.Time{}),
+			expectEstimate: true,
+			expectedPhase:  api.BackupEstimatePhaseCompleted,
+		},
+		{
+			name:           "estimate that can't be computed fails",
+			estimate:       newEstimate("", time.Time{}),
+			backupperErr:   errors.New("discovery failed"),
+			expectEstimate: true,
+			expectedPhase:  api.BackupEstimatePhaseFailed,
+		},
+		{
+			name:          "invalid estimate fails without collecting items",
+			estimate:      invalid,
+			expectedPhase: api.BackupEstimatePhaseFailed,
+		},
+		{
+			name:          "expired estimate is deleted",
+			estimate:      newEstimate(api.BackupEstimatePhaseCompleted, now.Add(-time.Minute)),
+			expectDeleted: true,
+		},
+		{
+			name:     "unexpired estimate is kept",
+			estimate: newEstimate(api.BackupEstimatePhaseCompleted, now.Add(time.Minute)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset(test.estimate)
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				backupper       = new(fakeBackupper)
+				pluginManager   = new(pluginmocks.Manager)
+			)
+
+			c := NewBackupEstimateController(
+				client.ArkV1(),
+				sharedInformers.Ark().V1().BackupEstimates(),
+				backupper,
+				sharedInformers.Ark().V1().VolumeSnapshotLocations(),
+				nil,
+				func(logrus.FieldLogger) plugin.Manager { return pluginManager },
+				arktest.NewLogger(),
+			).(*backupEstimateController)
+			c.clock = clock.NewFakeClock(now)
+
+			require.NoError(t, sharedInformers.Ark().V1().BackupEstimates().Informer().GetStore().Add(test.estimate))
+
+			pluginManager.On("CleanupClients").Return()
+			pluginManager.On("GetBackupItemActions").Return(nil, nil)
+
+			result := &pkgbackup.Estimate{
+				Resources:  []api.ResourceEstimate{{Resource: "pods", Items: 2, Size: 300}},
+				TotalItems: 2,
+				TotalSize:  300,
+				Volumes:    1,
+			}
+			if test.backupperErr != nil {
+				result = nil
+			}
+			backupper.On("Estimate", mock.Anything, mock.Anything, mock.Anything, pluginManager).Return(result, test.backupperErr)
+
+			require.NoError(t, c.processBackupEstimate(kubeutil.NamespaceAndName(test.estimate)))
+
+			if test.expectEstimate {
+				backupper.AssertCalled(t, "Estimate", mock.Anything, mock.Anything, mock.Anything, pluginManager)
+			} else {
+				backupper.AssertNotCalled(t, "Estimate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			res, err := client.ArkV1().BackupEstimates(api.DefaultNamespace).Get("estimate-1", metav1.GetOptions{})
+
+			if test.expectDeleted {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if test.expectedPhase == "" {
+				assert.Equal(t, test.estimate.Status, res.Status)
+				return
+			}
+
+			assert.Equal(t, string(test.expectedPhase), string(res.Status.Phase), res.Status.FailureReason)
+			assert.Equal(t, now.Add(backupEstimateTTL).Unix(), res.Status.Expiration.Unix())
+			if test.expectedPhase == api.BackupEstimatePhaseCompleted {
+				assert.Equal(t, result.Resources, res.Status.Resources)
+				assert.Equal(t, 2, res.Status.TotalItems)
+				assert.Equal(t, int64(300), res.Status.TotalSize)
+				assert.Equal(t, 1, res.Status.Volumes)
+			} else {
+				assert.NotEmpty(t, res.Status.FailureReason)
+			}
+		})
+	}
+}
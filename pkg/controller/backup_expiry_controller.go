@@ -0,0 +1,250 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/metrics"
+	"github.com/heptio/ark/pkg/util/kube"
+)
+
+const (
+	BackupExpirySyncPeriod = 60 * time.Minute
+
+	backupExpiringSoonReason = "BackupExpiringSoon"
+	backupNeverExpireReason  = "BackupNeverExpires"
+)
+
+// backupExpiryController records events for backups that will expire soon, and
+// optionally keeps the latest completed backup of each schedule from expiring.
+// A backup that was kept because it was the latest is allowed to expire again
+// once a newer backup of its schedule completes.
+type backupExpiryController struct {
+	*genericController
+
+	backupLister                  listers.BackupLister
+	backupClient                  arkv1client.BackupsGetter
+	createEvent                   func(*corev1api.Event) error
+	warningPeriod                 time.Duration
+	protectLatestScheduledBackups bool
+	metrics                       *metrics.ServerMetrics
+
+	clock clock.Clock
+}
+
+// NewBackupExpiryController constructs a new backupExpiryController.
+func NewBackupExpiryController(
+	logger logrus.FieldLogger,
+	backupInformer informers.BackupInformer,
+	backupClient arkv1client.BackupsGetter,
+	eventClient corev1client.EventsGetter,
+	warningPeriod time.Duration,
+	protectLatestScheduledBackups bool,
+	metrics *metrics.ServerMetrics,
+) Interface {
+	c := &backupExpiryController{
+		genericController:             newGenericController("backup-expiry", logger),
+		backupLister:                  backupInformer.Lister(),
+		backupClient:                  backupClient,
+		warningPeriod:                 warningPeriod,
+		protectLatestScheduledBackups: protectLatestScheduledBackups,
+		metrics:                       metrics,
+		clock:                         clock.RealClock{},
+	}
+
+	c.createEvent = func(event *corev1api.Event) error {
+		_, err := eventClient.Events(event.Namespace).Create(event)
+		return err
+	}
+
+	c.resyncFunc = c.run
+	c.resyncPeriod = BackupExpirySyncPeriod
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, backupInformer.Informer().HasSynced)
+
+	return c
+}
+
+func (c *backupExpiryController) run() {
+	c.logger.Debug("Checking for backups that will expire soon")
+
+	backups, err := c.backupLister.List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(errors.WithStack(err)).Error("Error listing backups")
+		return
+	}
+
+	// find the latest completed backup of each schedule
+	latest := make(map[string]*arkv1api.Backup)
+	for _, backup := range backups {
+		schedule := backup.Labels[arkv1api.ScheduleNameLabel]
+		if schedule == "" || backup.Status.Phase != arkv1api.BackupPhaseCompleted {
+			continue
+		}
+
+		if current, ok := latest[schedule]; !ok || current.Status.StartTimestamp.Before(&backup.Status.StartTimestamp) {
+			latest[schedule] = backup
+		}
+	}
+
+	// backups that were kept because they were the latest of their schedule expire
+	// again once they've been superseded, so that only one backup of each schedule
+	// is kept, or once the latest backups are no longer protected
+	for _, backup := range backups {
+		if backup.Annotations[arkv1api.LatestScheduledBackupAnnotation] != "true" {
+			continue
+		}
+		if c.protectLatestScheduledBackups && latest[backup.Labels[arkv1api.ScheduleNameLabel]] == backup {
+			continue
+		}
+
+		log := c.logger.WithField("backup", kube.NamespaceAndName(backup))
+		log.Info("Backup is no longer kept as the latest backup of its schedule, removing its never-expire annotation")
+		if err := c.patchNeverExpire(backup, nil); err != nil {
+			log.WithError(err).Error("Error removing backup's never-expire annotation")
+		}
+	}
+
+	now := c.clock.Now()
+	expiringSoon := make(map[string]int)
+
+	for _, backup := range backups {
+		schedule := backup.Labels[arkv1api.ScheduleNameLabel]
+
+		// report a count for every schedule, so that counts drop to zero
+		if _, ok := expiringSoon[schedule]; !ok {
+			expiringSoon[schedule] = 0
+		}
+
+		if !c.expiresSoon(backup, now) {
+			continue
+		}
+
+		log := c.logger.WithFields(logrus.Fields{
+			"backup":     kube.NamespaceAndName(backup),
+			"expiration": backup.Status.Expiration.Time,
+		})
+
+		if c.protectLatestScheduledBackups && latest[schedule] == backup {
+			log.Info("Latest backup of schedule will expire soon, annotating it to never expire")
+			if err := c.patchNeverExpire(backup, "true"); err != nil {
+				log.WithError(err).Error("Error annotating backup to never expire")
+			} else {
+				c.recordEvent(backup, corev1api.EventTypeNormal, backupNeverExpireReason,
+					fmt.Sprintf("Backup is the latest backup of schedule %s and would have expired at %s, so it has been annotated to never expire", schedule, backup.Status.Expiration.Time.UTC().Format(time.RFC3339)))
+				continue
+			}
+		}
+
+		log.Info("Backup will expire soon")
+		expiringSoon[schedule]++
+		c.recordEvent(backup, corev1api.EventTypeWarning, backupExpiringSoonReason,
+			fmt.Sprintf("Backup will expire at %s", backup.Status.Expiration.Time.UTC().Format(time.RFC3339)))
+	}
+
+	for schedule, count := range expiringSoon {
+		c.metrics.SetBackupsExpiringSoonGauge(schedule, count)
+	}
+}
+
+// expiresSoon returns true if backup will be garbage collected within the
+// controller's warning period.
+func (c *backupExpiryController) expiresSoon(backup *arkv1api.Backup, now time.Time) bool {
+	expiration := backup.Status.Expiration.Time
+
+	switch {
+	case expiration.IsZero(), backup.Status.Phase == arkv1api.BackupPhaseDeleting:
+		return false
+	case backup.Annotations[arkv1api.NeverExpireAnnotation] == "true", backup.Spec.Hold, backup.Labels[arkv1api.SourceClusterLabel] != "":
+		return false
+	default:
+		return expiration.After(now) && expiration.Before(now.Add(c.warningPeriod))
+	}
+}
+
+// patchNeverExpire sets the never-expire annotation of backup, which is the latest backup
+// of its schedule, and the annotation marking it as the latest, to value. A nil value
+// removes them.
+func (c *backupExpiryController) patchNeverExpire(backup *arkv1api.Backup, value interface{}) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				arkv1api.NeverExpireAnnotation:           value,
+				arkv1api.LatestScheduledBackupAnnotation: value,
+			},
+		},
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := c.backupClient.Backups(backup.Namespace).Patch(backup.Name, types.MergePatchType, patchBytes); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// recordEvent records an event for backup. Events are named after the backup and
+// their reason, so each is only recorded once.
+func (c *backupExpiryController) recordEvent(backup *arkv1api.Backup, eventType, reason, message string) {
+	now := metav1.NewTime(c.clock.Now())
+
+	event := &corev1api.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: backup.Namespace,
+			Name:      fmt.Sprintf("%s.%s", backup.Name, strings.ToLower(reason)),
+		},
+		InvolvedObject: corev1api.ObjectReference{
+			APIVersion: arkv1api.SchemeGroupVersion.String(),
+			Kind:       "Backup",
+			Namespace:  backup.Namespace,
+			Name:       backup.Name,
+			UID:        backup.UID,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1api.EventSource{Component: "ark"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	if err := c.createEvent(event); err != nil && !apierrors.IsAlreadyExists(err) {
+		c.logger.WithError(errors.WithStack(err)).WithField("backup", kube.NamespaceAndName(backup)).Error("Error recording event")
+	}
+}
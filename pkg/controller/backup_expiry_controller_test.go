@@ -0,0 +1,210 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/metrics"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestBackupExpiryControllerRun(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+
+	newScheduledBackup := func(name string, started, expiration time.Time) *api.Backup {
+		return arktest.NewTestBackup().WithName(name).
+			WithLabel(api.ScheduleNameLabel, "daily").
+			WithPhase(api.BackupPhaseCompleted).
+			WithStartTimestamp(started).
+			WithExpiration(expiration).
+			Backup
+	}
+
+	// keptBackup returns a scheduled backup that was annotated to never expire
+	// because it was the latest of its schedule
+	keptBackup := func(name string, started, expiration time.Time) *api.Backup {
+		backup := newScheduledBackup(name, started, expiration)
+		backup.Annotations = map[string]string{
+			api.NeverExpireAnnotation:           "true",
+			api.LatestScheduledBackupAnnotation: "true",
+		}
+		return backup
+	}
+
+	tests := []struct {
+		name                          string
+		backups                       []*api.Backup
+		protectLatestScheduledBackups bool
+		expectedEvents                map[string]string
+		expectedAnnotated             []string
+		expectedReleased              []string
+	}{
+		{
+			name: "backups that don't expire within the warning period don't get events",
+			backups: []*api.Backup{
+				arktest.NewTestBackup().WithName("backup-1").WithExpiration(fakeClock.Now().Add(96 * time.Hour)).Backup,
+				arktest.NewTestBackup().WithName("backup-2").WithExpiration(fakeClock.Now().Add(-time.Hour)).Backup,
+				arktest.NewTestBackup().WithName("backup-3").Backup,
+			},
+		},
+		{
+			name: "backups that expire within the warning period get warning events",
+			backups: []*api.Backup{
+				arktest.NewTestBackup().WithName("backup-1").WithExpiration(fakeClock.Now().Add(time.Hour)).Backup,
+				arktest.NewTestBackup().WithName("backup-2").WithExpiration(fakeClock.Now().Add(96 * time.Hour)).Backup,
+			},
+			expectedEvents: map[string]string{
+				"backup-1.backupexpiringsoon": backupExpiringSoonReason,
+			},
+		},
+		{
+			name: "backups annotated to never expire or on hold don't get events",
+			backups: []*api.Backup{
+				arktest.NewTestBackup().WithName("backup-1").WithExpiration(fakeClock.Now().Add(time.Hour)).WithAnnotation(api.NeverExpireAnnotation, "true").Backup,
+				arktest.NewTestBackup().WithName("backup-2").WithExpiration(fakeClock.Now().Add(time.Hour)).WithHold(true).Backup,
+			},
+		},
+		{
+			name: "latest scheduled backups aren't annotated unless enabled",
+			backups: []*api.Backup{
+				newScheduledBackup("backup-1", fakeClock.Now().Add(-48*time.Hour), fakeClock.Now().Add(time.Hour)),
+			},
+			expectedEvents: map[string]string{
+				"backup-1.backupexpiringsoon": backupExpiringSoonReason,
+			},
+		},
+		{
+			name: "latest completed backup of each schedule gets annotated to never expire when enabled",
+			backups: []*api.Backup{
+				newScheduledBackup("backup-1", fakeClock.Now().Add(-72*time.Hour), fakeClock.Now().Add(time.Hour)),
+				newScheduledBackup("backup-2", fakeClock.Now().Add(-48*time.Hour), fakeClock.Now().Add(2*time.Hour)),
+				arktest.NewTestBackup().WithName("backup-3").WithLabel(api.ScheduleNameLabel, "daily").WithPhase(api.BackupPhaseFailed).
+					WithStartTimestamp(fakeClock.Now().Add(-24 * time.Hour)).WithExpiration(fakeClock.Now().Add(3 * time.Hour)).Backup,
+			},
+			protectLatestScheduledBackups: true,
+			expectedEvents: map[string]string{
+				"backup-1.backupexpiringsoon": backupExpiringSoonReason,
+				"backup-2.backupneverexpires": backupNeverExpireReason,
+				"backup-3.backupexpiringsoon": backupExpiringSoonReason,
+			},
+			expectedAnnotated: []string{"backup-2"},
+		},
+		{
+			name: "kept backup is released once a newer backup of its schedule completes",
+			backups: []*api.Backup{
+				keptBackup("backup-1", fakeClock.Now().Add(-48*time.Hour), fakeClock.Now().Add(-time.Hour)),
+				keptBackup("backup-2", fakeClock.Now().Add(-24*time.Hour), fakeClock.Now().Add(time.Hour)),
+				newScheduledBackup("backup-3", fakeClock.Now().Add(-12*time.Hour), fakeClock.Now().Add(12*time.Hour)),
+				// backups annotated to never expire by users are left alone
+				arktest.NewTestBackup().WithName("backup-4").WithLabel(api.ScheduleNameLabel, "daily").WithPhase(api.BackupPhaseCompleted).
+					WithAnnotation(api.NeverExpireAnnotation, "true").WithExpiration(fakeClock.Now().Add(-time.Hour)).Backup,
+			},
+			protectLatestScheduledBackups: true,
+			expectedEvents: map[string]string{
+				"backup-3.backupneverexpires": backupNeverExpireReason,
+			},
+			expectedReleased:  []string{"backup-1", "backup-2"},
+			expectedAnnotated: []string{"backup-3"},
+		},
+		{
+			name: "latest kept backup stays kept",
+			backups: []*api.Backup{
+				keptBackup("backup-1", fakeClock.Now().Add(-48*time.Hour), fakeClock.Now().Add(-time.Hour)),
+			},
+			protectLatestScheduledBackups: true,
+		},
+		{
+			name: "kept backups are released when protection is disabled",
+			backups: []*api.Backup{
+				keptBackup("backup-1", fakeClock.Now().Add(-48*time.Hour), fakeClock.Now().Add(-time.Hour)),
+			},
+			expectedReleased: []string{"backup-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+			)
+
+			c := NewBackupExpiryController(
+				arktest.NewLogger(),
+				sharedInformers.Ark().V1().Backups(),
+				client.ArkV1(),
+				nil,
+				72*time.Hour,
+				test.protectLatestScheduledBackups,
+				metrics.NewServerMetrics(),
+			).(*backupExpiryController)
+			c.clock = fakeClock
+
+			events := make(map[string]string)
+			c.createEvent = func(event *corev1api.Event) error {
+				events[event.Name] = event.Reason
+				return nil
+			}
+
+			for _, backup := range test.backups {
+				require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(backup))
+			}
+
+			client.PrependReactor("patch", "backups", func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, nil
+			})
+
+			c.run()
+
+			if test.expectedEvents == nil {
+				test.expectedEvents = map[string]string{}
+			}
+			assert.Equal(t, test.expectedEvents, events)
+
+			var annotated, released []string
+			for _, action := range client.Actions() {
+				patch, ok := action.(core.PatchAction)
+				require.True(t, ok)
+
+				switch string(patch.GetPatch()) {
+				case `{"metadata":{"annotations":{"ark.heptio.com/latest-scheduled-backup":"true","ark.heptio.com/never-expire":"true"}}}`:
+					annotated = append(annotated, patch.GetName())
+				case `{"metadata":{"annotations":{"ark.heptio.com/latest-scheduled-backup":null,"ark.heptio.com/never-expire":null}}}`:
+					released = append(released, patch.GetName())
+				default:
+					t.Errorf("unexpected patch %s", patch.GetPatch())
+				}
+			}
+			sort.Strings(released)
+			assert.Equal(t, test.expectedAnnotated, annotated)
+			assert.Equal(t, test.expectedReleased, released)
+		})
+	}
+}
@@ -0,0 +1,455 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/metrics"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+// namespaceNotCoveredReason is the reason of the events recorded for a backup
+// policy when a namespace it selects stops being covered by a schedule.
+const namespaceNotCoveredReason = "NamespaceNotCovered"
+
+// backupPolicyController evaluates each BackupPolicy against the cluster's namespaces
+// and the Ark server's schedules. A namespace that a policy selects is covered if an
+// enabled schedule backs up all of it, and its backups are retained for at least the
+// policy's minimum TTL. Policies with a schedule template get a Schedule for each
+// namespace that no other schedule covers, labeled with api.BackupPolicyLabel.
+type backupPolicyController struct {
+	*genericController
+
+	policyLister    listers.BackupPolicyLister
+	policyClient    arkv1client.BackupPoliciesGetter
+	namespaceLister corev1listers.NamespaceLister
+	scheduleLister  listers.ScheduleLister
+	scheduleClient  arkv1client.SchedulesGetter
+	createEvent     func(*corev1api.Event) error
+	metrics         *metrics.ServerMetrics
+	clock           clock.Clock
+}
+
+// NewBackupPolicyController constructs a new backupPolicyController.
+// namespaceInformer must watch all namespaces.
+func NewBackupPolicyController(
+	policyInformer informers.BackupPolicyInformer,
+	policyClient arkv1client.BackupPoliciesGetter,
+	namespaceInformer cache.SharedIndexInformer,
+	scheduleInformer informers.ScheduleInformer,
+	scheduleClient arkv1client.SchedulesGetter,
+	eventClient corev1client.EventsGetter,
+	logger logrus.FieldLogger,
+	metrics *metrics.ServerMetrics,
+) Interface {
+	c := &backupPolicyController{
+		genericController: newGenericController("backup-policy", logger),
+		policyLister:      policyInformer.Lister(),
+		policyClient:      policyClient,
+		namespaceLister:   corev1listers.NewNamespaceLister(namespaceInformer.GetIndexer()),
+		scheduleLister:    scheduleInformer.Lister(),
+		scheduleClient:    scheduleClient,
+		metrics:           metrics,
+		clock:             clock.RealClock{},
+	}
+
+	c.createEvent = func(event *corev1api.Event) error {
+		_, err := eventClient.Events(event.Namespace).Create(event)
+		return err
+	}
+
+	c.syncHandler = c.processPolicy
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters,
+		policyInformer.Informer().HasSynced,
+		namespaceInformer.HasSynced,
+		scheduleInformer.Informer().HasSynced,
+	)
+
+	enqueuePolicy := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			c.logger.WithError(errors.WithStack(err)).Error("Error creating queue key, item not added to queue")
+			return
+		}
+		c.queue.Add(key)
+	}
+
+	policyInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueuePolicy,
+			UpdateFunc: func(_, obj interface{}) { enqueuePolicy(obj) },
+			DeleteFunc: enqueuePolicy,
+		},
+	)
+
+	// any namespace or schedule can change whether any policy is complied
+	// with, so all policies are evaluated again when one changes
+	enqueueAllPolicies := func(_ interface{}) {
+		policies, err := c.policyLister.List(labels.Everything())
+		if err != nil {
+			c.logger.WithError(errors.WithStack(err)).Error("Error listing backup policies")
+			return
+		}
+		for _, policy := range policies {
+			enqueuePolicy(policy)
+		}
+	}
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueueAllPolicies,
+		UpdateFunc: func(_, obj interface{}) { enqueueAllPolicies(obj) },
+		DeleteFunc: enqueueAllPolicies,
+	}
+	namespaceInformer.AddEventHandler(handler)
+	scheduleInformer.Informer().AddEventHandler(handler)
+
+	return c
+}
+
+func (c *backupPolicyController) processPolicy(key string) error {
+	log := c.logger.WithField("key", key)
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	policy, err := c.policyLister.BackupPolicies(ns).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting backup policy")
+	}
+
+	schedules, err := c.scheduleLister.Schedules(ns).List(labels.Everything())
+	if err != nil {
+		return errors.Wrap(err, "error listing schedules")
+	}
+
+	// the schedules that were created for the policy, keyed by name, and the
+	// schedules that weren't created for any policy
+	generated := make(map[string]*api.Schedule)
+	var others []*api.Schedule
+	for _, schedule := range schedules {
+		switch schedule.Labels[api.BackupPolicyLabel] {
+		case name:
+			generated[schedule.Name] = schedule
+		case "":
+			others = append(others, schedule)
+		}
+	}
+
+	if policy == nil {
+		for _, schedule := range generated {
+			log.WithField("schedule", schedule.Name).Info("Deleting schedule because backup policy was deleted")
+			if err := deleteSchedule(c.scheduleClient, schedule); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if validationErrors := validateBackupPolicy(policy, log); len(validationErrors) > 0 {
+		for _, schedule := range generated {
+			log.WithField("schedule", schedule.Name).Info("Deleting schedule because backup policy failed validation")
+			if err := deleteSchedule(c.scheduleClient, schedule); err != nil {
+				return err
+			}
+		}
+		return c.updateStatus(policy, api.BackupPolicyStatus{
+			Phase:            api.BackupPolicyPhaseFailedValidation,
+			ValidationErrors: validationErrors,
+		})
+	}
+
+	namespaces, err := c.selectedNamespaces(policy)
+	if err != nil {
+		return err
+	}
+
+	// namespaces that other schedules cover don't need a schedule of their own.
+	// Schedules created for other policies are ignored, so that policies that
+	// select the same namespaces don't delete each other's schedules.
+	desired := make(map[string]*api.Schedule)
+	if policy.Spec.ScheduleTemplate != nil {
+		for _, namespace := range namespaces {
+			if !namespaceCovered(namespace, others, policy.Spec.MinimumTTL.Duration) {
+				schedule := backupPolicySchedule(policy, namespace)
+				desired[schedule.Name] = schedule
+			}
+		}
+	}
+
+	for scheduleName, schedule := range generated {
+		if _, ok := desired[scheduleName]; ok {
+			continue
+		}
+		log.WithField("schedule", scheduleName).Info("Deleting schedule because its namespace no longer needs one")
+		if err := deleteSchedule(c.scheduleClient, schedule); err != nil {
+			return err
+		}
+		delete(generated, scheduleName)
+	}
+
+	for scheduleName, schedule := range desired {
+		if err := c.ensureSchedule(schedule, generated[scheduleName], schedules, log); err != nil {
+			return err
+		}
+	}
+
+	status := api.BackupPolicyStatus{
+		Phase: api.BackupPolicyPhaseCompliant,
+	}
+	for _, namespace := range namespaces {
+		// schedules that were just created or updated aren't enabled yet, so the
+		// namespaces they cover will be when the schedules' updates are seen
+		if namespaceCovered(namespace, schedules, policy.Spec.MinimumTTL.Duration) {
+			status.CoveredNamespaces++
+			continue
+		}
+		status.NonCompliantNamespaces = append(status.NonCompliantNamespaces, namespace)
+	}
+	if len(status.NonCompliantNamespaces) > 0 {
+		status.Phase = api.BackupPolicyPhaseNonCompliant
+	}
+
+	c.metrics.SetBackupPolicyNonCompliantNamespaces(policy.Name, len(status.NonCompliantNamespaces))
+
+	previous := sets.NewString(policy.Status.NonCompliantNamespaces...)
+	for _, namespace := range status.NonCompliantNamespaces {
+		if !previous.Has(namespace) {
+			log.WithField("namespace", namespace).Warn("Namespace isn't covered by a schedule")
+			c.recordNamespaceNotCovered(policy, namespace, log)
+		}
+	}
+
+	return c.updateStatus(policy, status)
+}
+
+// selectedNamespaces returns the sorted names of the existing namespaces that the
+// policy selects.
+func (c *backupPolicyController) selectedNamespaces(policy *api.BackupPolicy) ([]string, error) {
+	selector := labels.Everything()
+	if policy.Spec.NamespaceSelector != nil {
+		var err error
+		// the policy's namespace selector has been validated
+		if selector, err = metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	namespaces, err := c.namespaceLister.List(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing namespaces")
+	}
+
+	var names []string
+	for _, ns := range namespaces {
+		if ns.DeletionTimestamp != nil || ns.Status.Phase == corev1api.NamespaceTerminating {
+			continue
+		}
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// ensureSchedule makes the schedule that was created for a namespace of the policy,
+// existing, match desired, creating it if existing is nil. It isn't created if one of
+// schedules already has its name.
+func (c *backupPolicyController) ensureSchedule(desired, existing *api.Schedule, schedules []*api.Schedule, log logrus.FieldLogger) error {
+	log = log.WithField("schedule", desired.Name)
+
+	if existing == nil {
+		for _, schedule := range schedules {
+			if schedule.Name == desired.Name {
+				log.Warn("Not creating schedule for namespace because a schedule with the same name already exists")
+				return nil
+			}
+		}
+	}
+
+	_, err := syncSchedule(c.scheduleClient, existing, desired, log)
+	return err
+}
+
+// recordNamespaceNotCovered records a warning event for the policy about a namespace
+// that isn't covered by a schedule. An event is recorded each time the namespace stops
+// being covered, so events are given generated names.
+func (c *backupPolicyController) recordNamespaceNotCovered(policy *api.BackupPolicy, namespace string, log logrus.FieldLogger) {
+	now := metav1.NewTime(c.clock.Now())
+
+	event := &corev1api.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    policy.Namespace,
+			GenerateName: policy.Name + "-",
+		},
+		InvolvedObject: corev1api.ObjectReference{
+			APIVersion:      api.SchemeGroupVersion.String(),
+			Kind:            "BackupPolicy",
+			Namespace:       policy.Namespace,
+			Name:            policy.Name,
+			UID:             policy.UID,
+			ResourceVersion: policy.ResourceVersion,
+		},
+		Reason:         namespaceNotCoveredReason,
+		Message:        fmt.Sprintf("Namespace %s isn't covered by a schedule whose backups are retained for at least %s", namespace, policy.Spec.MinimumTTL.Duration),
+		Source:         corev1api.EventSource{Component: "ark"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Type:           corev1api.EventTypeWarning,
+	}
+
+	if err := c.createEvent(event); err != nil {
+		log.WithError(errors.WithStack(err)).Error("Error recording event")
+	}
+}
+
+func (c *backupPolicyController) updateStatus(policy *api.BackupPolicy, status api.BackupPolicyStatus) error {
+	if equality.Semantic.DeepEqual(policy.Status, status) {
+		return nil
+	}
+
+	updated := policy.DeepCopy()
+	updated.Status = status
+
+	_, err := patchBackupPolicy(policy, updated, c.policyClient)
+	return err
+}
+
+// validateBackupPolicy returns the errors in the policy's spec.
+func validateBackupPolicy(policy *api.BackupPolicy, log logrus.FieldLogger) []string {
+	var errs []string
+
+	if policy.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); err != nil {
+			errs = append(errs, fmt.Sprintf("Invalid namespace selector: %v", err))
+		}
+	}
+
+	if policy.Spec.MinimumTTL.Duration < 0 {
+		errs = append(errs, "Minimum TTL must not be negative")
+	}
+
+	if template := policy.Spec.ScheduleTemplate; template != nil {
+		_, cronErrs := parseCronSchedule(&api.Schedule{Spec: *template}, log)
+		errs = append(errs, cronErrs...)
+
+		if ttl := template.Template.TTL.Duration; ttl > 0 && ttl < policy.Spec.MinimumTTL.Duration {
+			errs = append(errs, fmt.Sprintf("Schedule template's TTL %s is shorter than the minimum TTL %s", ttl, policy.Spec.MinimumTTL.Duration))
+		}
+		if template.Template.LabelSelector != nil {
+			errs = append(errs, "Schedule template must not have a label selector, since its schedules must back up all of their namespace")
+		}
+	}
+
+	return errs
+}
+
+// namespaceCovered returns whether one of schedules is enabled, backs up all of
+// namespace, and creates backups that are retained for at least minimumTTL.
+func namespaceCovered(namespace string, schedules []*api.Schedule, minimumTTL time.Duration) bool {
+	for _, schedule := range schedules {
+		if schedule.Status.Phase != api.SchedulePhaseEnabled {
+			continue
+		}
+
+		template := schedule.Spec.Template
+
+		// a schedule that only backs up some of the namespace's objects doesn't cover it
+		if template.LabelSelector != nil && (len(template.LabelSelector.MatchLabels) > 0 || len(template.LabelSelector.MatchExpressions) > 0) {
+			continue
+		}
+
+		// backups without a TTL never expire
+		if ttl := template.TTL.Duration; ttl > 0 && ttl < minimumTTL {
+			continue
+		}
+
+		namespaces := collections.NewIncludesExcludes().Includes(template.IncludedNamespaces...).Excludes(template.ExcludedNamespaces...)
+		if namespaces.ShouldInclude(namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backupPolicySchedule returns the schedule that the policy creates for namespace.
+func backupPolicySchedule(policy *api.BackupPolicy, namespace string) *api.Schedule {
+	spec := policy.Spec.ScheduleTemplate.DeepCopy()
+	spec.Template.IncludedNamespaces = []string{namespace}
+	spec.Template.ExcludedNamespaces = nil
+
+	return &api.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: policy.Namespace,
+			Name:      fmt.Sprintf("%s-%s", policy.Name, namespace),
+			Labels: map[string]string{
+				api.BackupPolicyLabel: policy.Name,
+			},
+		},
+		Spec: *spec,
+	}
+}
+
+func patchBackupPolicy(original, updated *api.BackupPolicy, client arkv1client.BackupPoliciesGetter) (*api.BackupPolicy, error) {
+	origBytes, err := json.Marshal(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling original backup policy")
+	}
+
+	updatedBytes, err := json.Marshal(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling updated backup policy")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(origBytes, updatedBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating json merge patch for backup policy")
+	}
+
+	res, err := client.BackupPolicies(original.Namespace).Patch(original.Name, types.MergePatchType, patchBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error patching backup policy")
+	}
+
+	return res, nil
+}
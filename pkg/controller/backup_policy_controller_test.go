@@ -0,0 +1,248 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/metrics"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessBackupPolicy(t *testing.T) {
+	newNamespace := func(name string, tier string) *corev1api.Namespace {
+		return &corev1api.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tier": tier}},
+		}
+	}
+
+	newSchedule := func(name string, ttl time.Duration, phase api.SchedulePhase, namespaces ...string) *api.Schedule {
+		schedule := arktest.NewTestSchedule(api.DefaultNamespace, name).WithCronSchedule("0 2 * * *").WithPhase(phase).Schedule
+		schedule.Spec.Template.IncludedNamespaces = namespaces
+		schedule.Spec.Template.TTL = metav1.Duration{Duration: ttl}
+		return schedule
+	}
+
+	generatedSchedule := func(namespace string, phase api.SchedulePhase) *api.Schedule {
+		schedule := newSchedule("policy-"+namespace, 720*time.Hour, phase, namespace)
+		schedule.Labels = map[string]string{api.BackupPolicyLabel: "policy"}
+		return schedule
+	}
+
+	newPolicy := func(template bool, status api.BackupPolicyStatus) *api.BackupPolicy {
+		policy := &api.BackupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: "policy"},
+			Spec: api.BackupPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+				MinimumTTL:        metav1.Duration{Duration: 720 * time.Hour},
+			},
+			Status: status,
+		}
+		if template {
+			policy.Spec.ScheduleTemplate = &api.ScheduleSpec{
+				Schedule: "0 2 * * *",
+				Template: api.BackupSpec{TTL: metav1.Duration{Duration: 720 * time.Hour}},
+			}
+		}
+		return policy
+	}
+
+	namespaces := []*corev1api.Namespace{
+		newNamespace("ns-1", "prod"),
+		newNamespace("ns-2", "prod"),
+		newNamespace("ns-3", "dev"),
+	}
+
+	tests := []struct {
+		name            string
+		policy          *api.BackupPolicy
+		schedules       []*api.Schedule
+		expectedActions []string
+		expectedCreated []string
+		expectedStatus  *api.BackupPolicyStatus
+		expectedEvents  []string
+	}{
+		{
+			name:   "namespaces covered by schedules are compliant",
+			policy: newPolicy(false, api.BackupPolicyStatus{}),
+			schedules: []*api.Schedule{
+				newSchedule("all", 0, api.SchedulePhaseEnabled, "*"),
+			},
+			expectedActions: []string{"patch"},
+			expectedStatus: &api.BackupPolicyStatus{
+				Phase:             api.BackupPolicyPhaseCompliant,
+				CoveredNamespaces: 2,
+			},
+		},
+		{
+			name:   "namespaces without schedules, or with schedules that are disabled or whose TTL is too short, are non-compliant",
+			policy: newPolicy(false, api.BackupPolicyStatus{}),
+			schedules: []*api.Schedule{
+				newSchedule("short", 24*time.Hour, api.SchedulePhaseEnabled, "ns-1"),
+				newSchedule("invalid", 720*time.Hour, api.SchedulePhaseFailedValidation, "ns-2"),
+				newSchedule("dev", 720*time.Hour, api.SchedulePhaseEnabled, "ns-3"),
+			},
+			expectedActions: []string{"patch"},
+			expectedStatus: &api.BackupPolicyStatus{
+				Phase:                  api.BackupPolicyPhaseNonCompliant,
+				NonCompliantNamespaces: []string{"ns-1", "ns-2"},
+			},
+			expectedEvents: []string{"ns-1", "ns-2"},
+		},
+		{
+			name: "events are only recorded for namespaces that weren't already non-compliant",
+			policy: newPolicy(false, api.BackupPolicyStatus{
+				Phase:                  api.BackupPolicyPhaseNonCompliant,
+				NonCompliantNamespaces: []string{"ns-1"},
+			}),
+			expectedActions: []string{"patch"},
+			// the status patch only has the fields that changed
+			expectedStatus: &api.BackupPolicyStatus{
+				NonCompliantNamespaces: []string{"ns-1", "ns-2"},
+			},
+			expectedEvents: []string{"ns-2"},
+		},
+		{
+			name:            "schedule template creates schedules for uncovered namespaces",
+			policy:          newPolicy(true, api.BackupPolicyStatus{}),
+			schedules:       []*api.Schedule{newSchedule("ns-1", 720*time.Hour, api.SchedulePhaseEnabled, "ns-1")},
+			expectedActions: []string{"create", "patch"},
+			expectedCreated: []string{"policy-ns-2"},
+			expectedStatus: &api.BackupPolicyStatus{
+				Phase:                  api.BackupPolicyPhaseNonCompliant,
+				CoveredNamespaces:      1,
+				NonCompliantNamespaces: []string{"ns-2"},
+			},
+			expectedEvents: []string{"ns-2"},
+		},
+		{
+			name:   "schedules created for namespaces that are covered otherwise or no longer selected are deleted",
+			policy: newPolicy(true, api.BackupPolicyStatus{Phase: api.BackupPolicyPhaseCompliant, CoveredNamespaces: 2}),
+			schedules: []*api.Schedule{
+				newSchedule("ns-1", 720*time.Hour, api.SchedulePhaseEnabled, "ns-1"),
+				generatedSchedule("ns-1", api.SchedulePhaseEnabled),
+				generatedSchedule("ns-2", api.SchedulePhaseEnabled),
+				generatedSchedule("ns-3", api.SchedulePhaseEnabled),
+			},
+			expectedActions: []string{"delete", "delete"},
+		},
+		{
+			name: "invalid policy fails validation and deletes its schedules",
+			policy: func() *api.BackupPolicy {
+				policy := newPolicy(true, api.BackupPolicyStatus{})
+				policy.Spec.ScheduleTemplate.Template.TTL = metav1.Duration{Duration: time.Hour}
+				return policy
+			}(),
+			schedules:       []*api.Schedule{generatedSchedule("ns-1", api.SchedulePhaseEnabled)},
+			expectedActions: []string{"delete", "patch"},
+			expectedStatus: &api.BackupPolicyStatus{
+				Phase:            api.BackupPolicyPhaseFailedValidation,
+				ValidationErrors: []string{"Schedule template's TTL 1h0m0s is shorter than the minimum TTL 720h0m0s"},
+			},
+		},
+		{
+			name:            "deleted policy deletes its schedules",
+			schedules:       []*api.Schedule{generatedSchedule("ns-1", api.SchedulePhaseEnabled), newSchedule("other", 0, api.SchedulePhaseEnabled, "*")},
+			expectedActions: []string{"delete"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client            = fake.NewSimpleClientset()
+				sharedInformers   = informers.NewSharedInformerFactory(client, 0)
+				namespaceInformer = cache.NewSharedIndexInformer(nil, new(corev1api.Namespace), 0, cache.Indexers{})
+			)
+
+			var objs []runtime.Object
+			if test.policy != nil {
+				require.NoError(t, sharedInformers.Ark().V1().BackupPolicies().Informer().GetStore().Add(test.policy))
+				objs = append(objs, test.policy)
+			}
+			for _, schedule := range test.schedules {
+				require.NoError(t, sharedInformers.Ark().V1().Schedules().Informer().GetStore().Add(schedule))
+				objs = append(objs, schedule)
+			}
+			client = fake.NewSimpleClientset(objs...)
+
+			for _, ns := range namespaces {
+				require.NoError(t, namespaceInformer.GetStore().Add(ns))
+			}
+
+			c := NewBackupPolicyController(
+				sharedInformers.Ark().V1().BackupPolicies(),
+				client.ArkV1(),
+				namespaceInformer,
+				sharedInformers.Ark().V1().Schedules(),
+				client.ArkV1(),
+				nil,
+				arktest.NewLogger(),
+				metrics.NewServerMetrics(),
+			).(*backupPolicyController)
+
+			var events []*corev1api.Event
+			c.createEvent = func(event *corev1api.Event) error {
+				events = append(events, event)
+				return nil
+			}
+
+			require.NoError(t, c.processPolicy(api.DefaultNamespace+"/policy"))
+
+			var (
+				actions []string
+				created []string
+			)
+			for _, action := range client.Actions() {
+				actions = append(actions, action.GetVerb())
+				if create, ok := action.(core.CreateAction); ok {
+					schedule := create.GetObject().(*api.Schedule)
+					assert.Equal(t, "policy", schedule.Labels[api.BackupPolicyLabel])
+					assert.Equal(t, []string{schedule.Name[len("policy-"):]}, schedule.Spec.Template.IncludedNamespaces)
+					created = append(created, schedule.Name)
+				}
+				if patch, ok := action.(core.PatchAction); ok && test.expectedStatus != nil {
+					policy := new(api.BackupPolicy)
+					require.NoError(t, json.Unmarshal(patch.GetPatch(), policy))
+					assert.Equal(t, *test.expectedStatus, policy.Status)
+				}
+			}
+			assert.Equal(t, test.expectedActions, actions)
+			assert.Equal(t, test.expectedCreated, created)
+
+			require.Len(t, events, len(test.expectedEvents))
+			for i, event := range events {
+				assert.Equal(t, namespaceNotCoveredReason, event.Reason)
+				assert.Equal(t, "policy", event.InvolvedObject.Name)
+				assert.Contains(t, event.Message, "Namespace "+test.expectedEvents[i]+" ")
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/heptio/ark/pkg/persistence"
+)
+
+// progressLog is the log of an in-progress backup. It's written to an uncompressed
+// temp file, and uploaded to backup storage periodically so that the log can be
+// followed with `ark backup logs --follow` while the backup is running.
+type progressLog struct {
+	file *os.File
+
+	lock     sync.Mutex
+	size     int64
+	uploaded int64
+}
+
+// newProgressLog returns a progressLog whose temp file is created in dir.
+func newProgressLog(dir string) (*progressLog, error) {
+	file, err := ioutil.TempFile(dir, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating temp file for backup progress log")
+	}
+
+	return &progressLog{file: file}, nil
+}
+
+func (l *progressLog) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+	return n, err
+}
+
+// upload uploads the log written so far as the backup's log, if anything has been
+// written since the last upload. The upload replaces the previous one, since
+// object storage doesn't support appending to objects.
+func (l *progressLog) upload(backupName string, backupStore persistence.BackupStore) error {
+	l.lock.Lock()
+	size := l.size
+	l.lock.Unlock()
+
+	if size == l.uploaded {
+		return nil
+	}
+
+	// the file is only appended to, so the first size bytes can be read
+	// while the backup continues to log
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	if _, err := io.Copy(gzw, io.NewSectionReader(l.file, 0, size)); err != nil {
+		return errors.Wrap(err, "error reading backup progress log")
+	}
+	if err := gzw.Close(); err != nil {
+		return errors.Wrap(err, "error compressing backup progress log")
+	}
+
+	if err := backupStore.PutBackupLog(backupName, buf); err != nil {
+		return err
+	}
+
+	l.uploaded = size
+	return nil
+}
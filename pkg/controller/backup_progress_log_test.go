@@ -0,0 +1,61 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	persistencemocks "github.com/heptio/ark/pkg/persistence/mocks"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProgressLogUpload(t *testing.T) {
+	log, err := newProgressLog("")
+	require.NoError(t, err)
+	defer closeAndRemoveFile(log.file, arktest.NewLogger())
+
+	var uploads []string
+	backupStore := new(persistencemocks.BackupStore)
+	backupStore.On("PutBackupLog", "backup-1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		gzr, err := gzip.NewReader(args.Get(1).(io.Reader))
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(gzr)
+		require.NoError(t, err)
+		uploads = append(uploads, string(data))
+	})
+
+	_, err = log.Write([]byte("first\n"))
+	require.NoError(t, err)
+	require.NoError(t, log.upload("backup-1", backupStore))
+
+	// nothing is uploaded if nothing has been logged since the last upload
+	require.NoError(t, log.upload("backup-1", backupStore))
+
+	_, err = log.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, log.upload("backup-1", backupStore))
+
+	assert.Equal(t, []string{"first\n", "first\nsecond\n"}, uploads)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+)
+
+// backupQueue keeps track of the order of the backups waiting for the backup
+// controller, and of the queue positions reported in their statuses.
+type backupQueue struct {
+	lock     sync.Mutex
+	keys     []string
+	reported map[string]int
+}
+
+func newBackupQueue() *backupQueue {
+	return &backupQueue{
+		reported: make(map[string]int),
+	}
+}
+
+// add adds the backup with the given key to the end of the queue, if it's not
+// already queued.
+func (q *backupQueue) add(key string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, queued := range q.keys {
+		if queued == key {
+			return
+		}
+	}
+	q.keys = append(q.keys, key)
+}
+
+// remove removes the backup with the given key from the queue, and returns the
+// queue position last reported in its status, or 0 if none was reported or the
+// queue is nil.
+func (q *backupQueue) remove(key string) int {
+	if q == nil {
+		return 0
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for i, queued := range q.keys {
+		if queued == key {
+			q.keys = append(q.keys[:i], q.keys[i+1:]...)
+			break
+		}
+	}
+
+	reported := q.reported[key]
+	delete(q.reported, key)
+
+	return reported
+}
+
+// report calls reportFunc with the key and position, starting at 1, of each
+// backup in the queue, in order, and records the position as reported if
+// reportFunc returns true. The queue is locked while report runs, so that a
+// backup can't be removed from the queue, and start running, while its
+// position is being reported.
+func (q *backupQueue) report(reportFunc func(key string, position int) bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for i, key := range q.keys {
+		if reportFunc(key, i+1) {
+			q.reported[key] = i + 1
+		}
+	}
+}
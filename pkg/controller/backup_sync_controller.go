@@ -43,10 +43,12 @@ type backupSyncController struct {
 
 	backupClient                arkv1client.BackupsGetter
 	backupLocationClient        arkv1client.BackupStorageLocationsGetter
+	volumeSnapshotClient        arkv1client.VolumeSnapshotsGetter
 	backupLister                listers.BackupLister
 	backupStorageLocationLister listers.BackupStorageLocationLister
 	namespace                   string
 	defaultBackupLocation       string
+	maintenanceMode             func() bool
 	newPluginManager            func(logrus.FieldLogger) plugin.Manager
 	newBackupStore              func(*arkv1api.BackupStorageLocation, persistence.ObjectStoreGetter, logrus.FieldLogger) (persistence.BackupStore, error)
 }
@@ -54,25 +56,24 @@ type backupSyncController struct {
 func NewBackupSyncController(
 	backupClient arkv1client.BackupsGetter,
 	backupLocationClient arkv1client.BackupStorageLocationsGetter,
+	volumeSnapshotClient arkv1client.VolumeSnapshotsGetter,
 	backupInformer informers.BackupInformer,
 	backupStorageLocationInformer informers.BackupStorageLocationInformer,
-	syncPeriod time.Duration,
+	syncPeriod func() time.Duration,
 	namespace string,
 	defaultBackupLocation string,
+	maintenanceMode func() bool,
 	newPluginManager func(logrus.FieldLogger) plugin.Manager,
 	logger logrus.FieldLogger,
 ) Interface {
-	if syncPeriod < time.Minute {
-		logger.Infof("Provided backup sync period %v is too short. Setting to 1 minute", syncPeriod)
-		syncPeriod = time.Minute
-	}
-
 	c := &backupSyncController{
 		genericController:           newGenericController("backup-sync", logger),
 		backupClient:                backupClient,
 		backupLocationClient:        backupLocationClient,
+		volumeSnapshotClient:        volumeSnapshotClient,
 		namespace:                   namespace,
 		defaultBackupLocation:       defaultBackupLocation,
+		maintenanceMode:             maintenanceMode,
 		backupLister:                backupInformer.Lister(),
 		backupStorageLocationLister: backupStorageLocationInformer.Lister(),
 
@@ -83,7 +84,14 @@ func NewBackupSyncController(
 	}
 
 	c.resyncFunc = c.run
-	c.resyncPeriod = syncPeriod
+	c.resyncPeriodFunc = func() time.Duration {
+		period := syncPeriod()
+		if period < time.Minute {
+			c.logger.Debugf("Provided backup sync period %v is too short. Using 1 minute", period)
+			period = time.Minute
+		}
+		return period
+	}
 	c.cacheSyncWaiters = []cache.InformerSynced{
 		backupInformer.Informer().HasSynced,
 		backupStorageLocationInformer.Informer().HasSynced,
@@ -122,6 +130,11 @@ func shouldSync(location *arkv1api.BackupStorageLocation, now time.Time, backupS
 }
 
 func (c *backupSyncController) run() {
+	if c.maintenanceMode() {
+		c.logger.Info("Server is in maintenance mode, not syncing backup storage locations")
+		return
+	}
+
 	c.logger.Info("Checking for backup storage locations to sync into cluster")
 
 	locations, err := c.backupStorageLocationLister.BackupStorageLocations(c.namespace).List(labels.Everything())
@@ -157,6 +170,35 @@ func (c *backupSyncController) run() {
 		backupStoreBackups := sets.NewString(res...)
 		log.WithField("backupCount", len(backupStoreBackups)).Info("Got backups from backup store")
 
+		// backups belonging to tenants with their own storage in the location
+		// are kept in separate backup stores
+		tenantBackups, err := c.listTenantBackups(location, pluginManager, log)
+		if err != nil {
+			log.WithError(err).Error("Error listing tenant backups in backup store")
+			continue
+		}
+		for backupName := range tenantBackups {
+			backupStoreBackups.Insert(backupName)
+		}
+
+		// backups created by other clusters are stored under their own prefixes
+		sourceClusterBackups, err := c.listSourceClusterBackups(location, pluginManager, log)
+		if err != nil {
+			log.WithError(err).Error("Error listing source cluster backups in backup store")
+			continue
+		}
+		for backupName, clusterStore := range sourceClusterBackups {
+			if backupStoreBackups.Has(backupName) {
+				log.WithFields(logrus.Fields{
+					"backup":        backupName,
+					"sourceCluster": clusterStore.cluster,
+				}).Warn("Not syncing source cluster backup because the location already has a backup with the same name")
+				delete(sourceClusterBackups, backupName)
+				continue
+			}
+			backupStoreBackups.Insert(backupName)
+		}
+
 		for backupName := range backupStoreBackups {
 			log = log.WithField("backup", backupName)
 			log.Debug("Checking backup store backup to see if it needs to be synced into the cluster")
@@ -183,12 +225,28 @@ func (c *backupSyncController) run() {
 				log.WithError(errors.WithStack(err)).Error("Error getting backup from client, proceeding with sync into cluster")
 			}
 
-			backup, err = backupStore.GetBackupMetadata(backupName)
+			store := backupStore
+			if tenantStore, ok := tenantBackups[backupName]; ok {
+				store = tenantStore
+			}
+			clusterStore, fromSourceCluster := sourceClusterBackups[backupName]
+			if fromSourceCluster {
+				store = clusterStore
+			}
+
+			backup, err = store.GetBackupMetadata(backupName)
 			if err != nil {
 				log.WithError(errors.WithStack(err)).Error("Error getting backup metadata from backup store")
 				continue
 			}
 
+			// expired backups are deleted from the cluster but kept in deletion-protected
+			// locations, so don't sync them back in
+			if location.Spec.DeletionProtection && !backup.Status.Expiration.IsZero() && backup.Status.Expiration.Time.Before(time.Now()) {
+				log.Debug("Not syncing expired backup into cluster because its backup storage location has deletion protection enabled")
+				continue
+			}
+
 			// remove the pre-v0.8.0 gcFinalizer if it exists
 			// TODO(1.0): remove this
 			backup.Finalizers = stringslice.Except(backup.Finalizers, gcFinalizer)
@@ -203,6 +261,9 @@ func (c *backupSyncController) run() {
 				backup.Labels = make(map[string]string)
 			}
 			backup.Labels[arkv1api.StorageLocationLabel] = backup.Spec.StorageLocation
+			if fromSourceCluster {
+				backup.Labels[arkv1api.SourceClusterLabel] = clusterStore.cluster
+			}
 
 			_, err = c.backupClient.Backups(backup.Namespace).Create(backup)
 			switch {
@@ -215,6 +276,18 @@ func (c *backupSyncController) run() {
 			default:
 				log.Debug("Synced backup into cluster")
 			}
+
+			// sync the backup's volume snapshot records too, so they don't have to be read
+			// from the backup store when restoring the backup. Pre-v0.10 backups store their
+			// snapshots in .status.volumeBackups instead, and have no volumesnapshots file.
+			snapshots, err := store.GetBackupVolumeSnapshots(backupName)
+			if err != nil {
+				log.WithError(errors.WithStack(err)).Error("Error getting backup's volume snapshots from backup store")
+				continue
+			}
+			for _, err := range createVolumeSnapshotRecords(c.volumeSnapshotClient, c.namespace, snapshots) {
+				log.WithError(err).Error("Error syncing volume snapshot record into cluster")
+			}
 		}
 
 		c.deleteOrphanedBackups(location.Name, backupStoreBackups, log)
@@ -244,6 +317,66 @@ func (c *backupSyncController) run() {
 	}
 }
 
+// listTenantBackups returns the names of the backups in each of the tenant backup stores in the
+// location, mapped to the backup store that contains them.
+func (c *backupSyncController) listTenantBackups(location *arkv1api.BackupStorageLocation, pluginManager plugin.Manager, log logrus.FieldLogger) (map[string]persistence.BackupStore, error) {
+	res := make(map[string]persistence.BackupStore)
+
+	for _, tenant := range location.Spec.Tenants {
+		tenantLocation, err := persistence.TenantLocation(location, tenant.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		backupStore, err := c.newBackupStore(tenantLocation, pluginManager, log.WithField("tenant", tenant.Namespace))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting backup store for tenant %s", tenant.Namespace)
+		}
+
+		backups, err := backupStore.ListBackups()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing backups for tenant %s", tenant.Namespace)
+		}
+
+		for _, backup := range backups {
+			res[backup] = backupStore
+		}
+	}
+
+	return res, nil
+}
+
+// sourceClusterBackupStore is the backup store containing a source cluster's
+// backups.
+type sourceClusterBackupStore struct {
+	persistence.BackupStore
+	cluster string
+}
+
+// listSourceClusterBackups returns the names of the backups stored by each of the location's
+// source clusters, mapped to the backup store that contains them.
+func (c *backupSyncController) listSourceClusterBackups(location *arkv1api.BackupStorageLocation, pluginManager plugin.Manager, log logrus.FieldLogger) (map[string]sourceClusterBackupStore, error) {
+	res := make(map[string]sourceClusterBackupStore)
+
+	for _, sourceCluster := range location.Spec.SourceClusters {
+		backupStore, err := c.newBackupStore(persistence.SourceClusterLocation(location, sourceCluster.Name), pluginManager, log.WithField("sourceCluster", sourceCluster.Name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting backup store for source cluster %s", sourceCluster.Name)
+		}
+
+		backups, err := backupStore.ListBackups()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing backups for source cluster %s", sourceCluster.Name)
+		}
+
+		for _, backup := range backups {
+			res[backup] = sourceClusterBackupStore{BackupStore: backupStore, cluster: sourceCluster.Name}
+		}
+	}
+
+	return res, nil
+}
+
 func patchStorageLocation(backup *arkv1api.Backup, client arkv1client.BackupInterface, location string) error {
 	patch := map[string]interface{}{
 		"spec": map[string]interface{}{
@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/clock"
@@ -39,6 +40,7 @@ import (
 	pluginmocks "github.com/heptio/ark/pkg/plugin/mocks"
 	"github.com/heptio/ark/pkg/util/stringslice"
 	arktest "github.com/heptio/ark/pkg/util/test"
+	"github.com/heptio/ark/pkg/volume"
 )
 
 func defaultLocationsList(namespace string) []*arkv1api.BackupStorageLocation {
@@ -80,7 +82,9 @@ func TestBackupSyncControllerRun(t *testing.T) {
 		namespace       string
 		locations       []*arkv1api.BackupStorageLocation
 		cloudBackups    map[string][]*arkv1api.Backup
+		cloudSnapshots  map[string][]*volume.Snapshot
 		existingBackups []*arkv1api.Backup
+		unsyncedBackups sets.String
 	}{
 		{
 			name: "no cloud backups",
@@ -160,6 +164,29 @@ func TestBackupSyncControllerRun(t *testing.T) {
 				arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-1").WithLabel("i-exist", "true").Backup,
 			},
 		},
+		{
+			name:      "volume snapshot records get synced for new backups",
+			namespace: "ns-1",
+			locations: defaultLocationsList("ns-1"),
+			cloudBackups: map[string][]*arkv1api.Backup{
+				"bucket-1": {
+					arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-1").Backup,
+					arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-2").Backup,
+				},
+			},
+			cloudSnapshots: map[string][]*volume.Snapshot{
+				"backup-1": {
+					{
+						Spec:   volume.SnapshotSpec{BackupName: "backup-1", PersistentVolumeName: "pv-1", Location: "default"},
+						Status: volume.SnapshotStatus{ProviderSnapshotID: "snap-1", Phase: volume.SnapshotPhaseCompleted},
+					},
+					{
+						Spec:   volume.SnapshotSpec{BackupName: "backup-1", PersistentVolumeName: "pv-2", Location: "default"},
+						Status: volume.SnapshotStatus{ProviderSnapshotID: "snap-2", Phase: volume.SnapshotPhaseCompleted},
+					},
+				},
+			},
+		},
 		{
 			name:      "backup storage location names and labels get updated",
 			namespace: "ns-1",
@@ -174,6 +201,25 @@ func TestBackupSyncControllerRun(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "expired backups in deletion-protected locations don't get synced",
+			namespace: "ns-1",
+			locations: func() []*arkv1api.BackupStorageLocation {
+				locations := defaultLocationsList("ns-1")
+				locations[0].Spec.DeletionProtection = true
+				return locations
+			}(),
+			cloudBackups: map[string][]*arkv1api.Backup{
+				"bucket-1": {
+					arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-1").WithExpiration(time.Now().Add(-time.Hour)).Backup,
+					arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-2").WithExpiration(time.Now().Add(time.Hour)).Backup,
+				},
+				"bucket-2": {
+					arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-3").WithExpiration(time.Now().Add(-time.Hour)).Backup,
+				},
+			},
+			unsyncedBackups: sets.NewString("backup-1"),
+		},
 	}
 
 	for _, test := range tests {
@@ -186,13 +232,15 @@ func TestBackupSyncControllerRun(t *testing.T) {
 			)
 
 			c := NewBackupSyncController(
+				client.ArkV1(),
 				client.ArkV1(),
 				client.ArkV1(),
 				sharedInformers.Ark().V1().Backups(),
 				sharedInformers.Ark().V1().BackupStorageLocations(),
-				time.Duration(0),
+				func() time.Duration { return 0 },
 				test.namespace,
 				"",
+				func() bool { return false },
 				func(logrus.FieldLogger) plugin.Manager { return pluginManager },
 				arktest.NewLogger(),
 			).(*backupSyncController)
@@ -219,6 +267,7 @@ func TestBackupSyncControllerRun(t *testing.T) {
 				for _, b := range test.cloudBackups[location.Spec.ObjectStorage.Bucket] {
 					backupNames = append(backupNames, b.Name)
 					backupStore.On("GetBackupMetadata", b.Name).Return(b, nil)
+					backupStore.On("GetBackupVolumeSnapshots", b.Name).Return(test.cloudSnapshots[b.Name], nil)
 				}
 				backupStore.On("ListBackups").Return(backupNames, nil)
 			}
@@ -247,6 +296,10 @@ func TestBackupSyncControllerRun(t *testing.T) {
 
 				for _, cloudBackup := range backups {
 					obj, err := client.ArkV1().Backups(test.namespace).Get(cloudBackup.Name, metav1.GetOptions{})
+					if test.unsyncedBackups.Has(cloudBackup.Name) {
+						assert.True(t, apierrors.IsNotFound(err), "expected backup %s not to be synced", cloudBackup.Name)
+						continue
+					}
 					require.NoError(t, err)
 
 					// did this cloud backup already exist in the cluster?
@@ -274,6 +327,14 @@ func TestBackupSyncControllerRun(t *testing.T) {
 						// verify that the storage location field and label are set properly
 						assert.Equal(t, location.Name, obj.Spec.StorageLocation)
 						assert.Equal(t, location.Name, obj.Labels[arkv1api.StorageLocationLabel])
+
+						// verify that the backup's volume snapshot records are synced
+						for _, snapshot := range test.cloudSnapshots[cloudBackup.Name] {
+							expected := volume.NewVolumeSnapshot(test.namespace, snapshot)
+							record, err := client.ArkV1().VolumeSnapshots(test.namespace).Get(expected.Name, metav1.GetOptions{})
+							require.NoError(t, err)
+							assert.Equal(t, expected, record)
+						}
 					}
 				}
 			}
@@ -281,6 +342,103 @@ func TestBackupSyncControllerRun(t *testing.T) {
 	}
 }
 
+func TestBackupSyncControllerRunSourceClusters(t *testing.T) {
+	var (
+		client          = fake.NewSimpleClientset()
+		sharedInformers = informers.NewSharedInformerFactory(client, 0)
+		pluginManager   = &pluginmocks.Manager{}
+		localStore      = &persistencemocks.BackupStore{}
+		clusterStore    = &persistencemocks.BackupStore{}
+	)
+
+	c := NewBackupSyncController(
+		client.ArkV1(),
+		client.ArkV1(),
+		client.ArkV1(),
+		sharedInformers.Ark().V1().Backups(),
+		sharedInformers.Ark().V1().BackupStorageLocations(),
+		func() time.Duration { return 0 },
+		"ns-1",
+		"",
+		func() bool { return false },
+		func(logrus.FieldLogger) plugin.Manager { return pluginManager },
+		arktest.NewLogger(),
+	).(*backupSyncController)
+
+	c.newBackupStore = func(loc *arkv1api.BackupStorageLocation, _ persistence.ObjectStoreGetter, _ logrus.FieldLogger) (persistence.BackupStore, error) {
+		if loc.Spec.ObjectStorage.Prefix == "clusters/cluster-2" {
+			return clusterStore, nil
+		}
+		return localStore, nil
+	}
+
+	pluginManager.On("CleanupClients").Return(nil)
+
+	location := arktest.NewTestBackupStorageLocation().WithNamespace("ns-1").WithName("location-1").WithObjectStorage("bucket-1").BackupStorageLocation
+	location.Spec.SourceClusters = []arkv1api.SourceCluster{{Name: "cluster-2", Prefix: "clusters/cluster-2"}}
+	require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(location))
+
+	localBackup := arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-1").Backup
+	clusterBackup := arktest.NewTestBackup().WithNamespace("ns-1").WithName("backup-2").Backup
+
+	localStore.On("GetRevision").Return("foo", nil)
+	localStore.On("ListBackups").Return([]string{"backup-1"}, nil)
+	localStore.On("GetBackupMetadata", "backup-1").Return(localBackup, nil)
+	localStore.On("GetBackupVolumeSnapshots", "backup-1").Return(nil, nil)
+
+	// backup-1 is also in the source cluster's storage, but the location's own backup takes precedence
+	clusterStore.On("ListBackups").Return([]string{"backup-1", "backup-2"}, nil)
+	clusterStore.On("GetBackupMetadata", "backup-2").Return(clusterBackup, nil)
+	clusterStore.On("GetBackupVolumeSnapshots", "backup-2").Return(nil, nil)
+
+	c.run()
+
+	localStore.AssertExpectations(t)
+	clusterStore.AssertExpectations(t)
+
+	res, err := client.ArkV1().Backups("ns-1").Get("backup-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "location-1", res.Labels[arkv1api.StorageLocationLabel])
+	assert.NotContains(t, res.Labels, arkv1api.SourceClusterLabel)
+
+	res, err = client.ArkV1().Backups("ns-1").Get("backup-2", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "location-1", res.Spec.StorageLocation)
+	assert.Equal(t, "location-1", res.Labels[arkv1api.StorageLocationLabel])
+	assert.Equal(t, "cluster-2", res.Labels[arkv1api.SourceClusterLabel])
+}
+
+func TestBackupSyncControllerRunMaintenanceMode(t *testing.T) {
+	var (
+		client          = fake.NewSimpleClientset()
+		sharedInformers = informers.NewSharedInformerFactory(client, 0)
+	)
+
+	c := NewBackupSyncController(
+		client.ArkV1(),
+		client.ArkV1(),
+		client.ArkV1(),
+		sharedInformers.Ark().V1().Backups(),
+		sharedInformers.Ark().V1().BackupStorageLocations(),
+		func() time.Duration { return 0 },
+		"ns-1",
+		"",
+		func() bool { return true },
+		func(logrus.FieldLogger) plugin.Manager {
+			t.Fatal("backup storage locations shouldn't be synced in maintenance mode")
+			return nil
+		},
+		arktest.NewLogger(),
+	).(*backupSyncController)
+
+	location := arktest.NewTestBackupStorageLocation().WithNamespace("ns-1").WithName("location-1").WithObjectStorage("bucket-1").BackupStorageLocation
+	require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(location))
+
+	c.run()
+
+	assert.Len(t, client.Actions(), 0)
+}
+
 func TestDeleteOrphanedBackups(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -371,13 +529,15 @@ func TestDeleteOrphanedBackups(t *testing.T) {
 			)
 
 			c := NewBackupSyncController(
+				client.ArkV1(),
 				client.ArkV1(),
 				client.ArkV1(),
 				sharedInformers.Ark().V1().Backups(),
 				sharedInformers.Ark().V1().BackupStorageLocations(),
-				time.Duration(0),
+				func() time.Duration { return 0 },
 				test.namespace,
 				"",
+				func() bool { return false },
 				nil, // new plugin manager func
 				arktest.NewLogger(),
 			).(*backupSyncController)
@@ -0,0 +1,321 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/restic"
+	"github.com/heptio/ark/pkg/util/boolptr"
+)
+
+type dataDownloadController struct {
+	*genericController
+
+	dataDownloadClient     arkv1client.DataDownloadsGetter
+	dataDownloadLister     listers.DataDownloadLister
+	podVolumeBackupLister  listers.PodVolumeBackupLister
+	podVolumeRestoreClient arkv1client.PodVolumeRestoresGetter
+	podVolumeRestoreLister listers.PodVolumeRestoreLister
+	podClient              corev1client.PodsGetter
+	pvcClient              corev1client.PersistentVolumeClaimsGetter
+}
+
+// NewDataDownloadController creates a new data download controller.
+func NewDataDownloadController(
+	logger logrus.FieldLogger,
+	dataDownloadInformer informers.DataDownloadInformer,
+	dataDownloadClient arkv1client.DataDownloadsGetter,
+	podVolumeBackupInformer informers.PodVolumeBackupInformer,
+	podVolumeRestoreInformer informers.PodVolumeRestoreInformer,
+	podVolumeRestoreClient arkv1client.PodVolumeRestoresGetter,
+	podClient corev1client.PodsGetter,
+	pvcClient corev1client.PersistentVolumeClaimsGetter,
+) Interface {
+	c := &dataDownloadController{
+		genericController:      newGenericController("data-download", logger),
+		dataDownloadClient:     dataDownloadClient,
+		dataDownloadLister:     dataDownloadInformer.Lister(),
+		podVolumeBackupLister:  podVolumeBackupInformer.Lister(),
+		podVolumeRestoreClient: podVolumeRestoreClient,
+		podVolumeRestoreLister: podVolumeRestoreInformer.Lister(),
+		podClient:              podClient,
+		pvcClient:              pvcClient,
+	}
+
+	c.syncHandler = c.processDataDownload
+	c.cacheSyncWaiters = append(
+		c.cacheSyncWaiters,
+		dataDownloadInformer.Informer().HasSynced,
+		podVolumeBackupInformer.Informer().HasSynced,
+		podVolumeRestoreInformer.Informer().HasSynced,
+	)
+
+	dataDownloadInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueue,
+		},
+	)
+
+	podVolumeRestoreInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, obj interface{}) {
+				pvr := obj.(*arkv1api.PodVolumeRestore)
+
+				name := pvr.Labels[arkv1api.DataDownloadNameLabel]
+				if name == "" {
+					return
+				}
+
+				switch pvr.Status.Phase {
+				case arkv1api.PodVolumeRestorePhaseCompleted, arkv1api.PodVolumeRestorePhaseFailed, arkv1api.PodVolumeRestorePhaseTimedOut:
+					c.queue.Add(pvr.Namespace + "/" + name)
+				}
+			},
+		},
+	)
+
+	return c
+}
+
+func (c *dataDownloadController) processDataDownload(key string) error {
+	log := c.logger.WithField("key", key)
+
+	log.Debug("Running processDataDownload")
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.WithError(err).Error("error splitting queue key")
+		return nil
+	}
+
+	dataDownload, err := c.dataDownloadLister.DataDownloads(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Unable to find DataDownload")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting DataDownload")
+	}
+
+	// don't modify items in the cache
+	dataDownload = dataDownload.DeepCopy()
+
+	switch dataDownload.Status.Phase {
+	case "", arkv1api.DataDownloadPhaseNew:
+		return c.startDataDownload(dataDownload, log)
+	case arkv1api.DataDownloadPhaseInProgress:
+		return c.completeDataDownload(dataDownload, log)
+	}
+
+	return nil
+}
+
+// startDataDownload validates a new data download, and if it's valid, creates
+// a data mover pod that mounts the target persistent volume claim and a pod
+// volume restore to restore the pod volume backup's data into it.
+func (c *dataDownloadController) startDataDownload(dataDownload *arkv1api.DataDownload, log logrus.FieldLogger) error {
+	original := dataDownload.DeepCopy()
+
+	pvb, errs := c.validateDataDownload(dataDownload)
+	if len(errs) > 0 {
+		dataDownload.Status.Phase = arkv1api.DataDownloadPhaseFailedValidation
+		dataDownload.Status.ValidationErrors = errs
+		_, err := patchDataDownload(original, dataDownload, c.dataDownloadClient)
+		return err
+	}
+
+	log.Info("Starting data download")
+
+	dataMover, err := c.podClient.Pods(dataDownload.Spec.TargetNamespace).Create(restic.NewDataMoverPod(
+		dataDownload.Spec.TargetNamespace,
+		dataDownload.Spec.TargetPersistentVolumeClaim,
+		pvb.Spec.Volume,
+		dataDownload.UID,
+		map[string]string{
+			arkv1api.DataDownloadNameLabel: dataDownload.Name,
+		},
+	))
+	if err != nil {
+		return c.failDataDownload(original, dataDownload, errors.Wrap(err, "error creating data mover pod").Error())
+	}
+
+	pvr, err := c.podVolumeRestoreClient.PodVolumeRestores(dataDownload.Namespace).Create(newDataDownloadPodVolumeRestore(dataDownload, dataMover, pvb))
+	if err != nil {
+		c.deleteDataMoverPod(dataMover.Namespace, dataMover.Name, log)
+		return c.failDataDownload(original, dataDownload, errors.Wrap(err, "error creating pod volume restore").Error())
+	}
+
+	dataDownload.Status.Phase = arkv1api.DataDownloadPhaseInProgress
+	dataDownload.Status.DataMoverPod = dataMover.Name
+	dataDownload.Status.PodVolumeRestore = pvr.Name
+	_, err = patchDataDownload(original, dataDownload, c.dataDownloadClient)
+	return err
+}
+
+func (c *dataDownloadController) validateDataDownload(dataDownload *arkv1api.DataDownload) (*arkv1api.PodVolumeBackup, []string) {
+	var errs []string
+
+	if dataDownload.Spec.PodVolumeBackup == "" {
+		errs = append(errs, "PodVolumeBackup must be specified")
+	}
+	if dataDownload.Spec.TargetNamespace == "" {
+		errs = append(errs, "TargetNamespace must be specified")
+	}
+	if dataDownload.Spec.TargetPersistentVolumeClaim == "" {
+		errs = append(errs, "TargetPersistentVolumeClaim must be specified")
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	pvb, err := c.podVolumeBackupLister.PodVolumeBackups(dataDownload.Namespace).Get(dataDownload.Spec.PodVolumeBackup)
+	switch {
+	case err != nil:
+		errs = append(errs, fmt.Sprintf("Error getting pod volume backup %s: %v", dataDownload.Spec.PodVolumeBackup, err))
+	case pvb.Status.Phase != arkv1api.PodVolumeBackupPhaseCompleted:
+		errs = append(errs, fmt.Sprintf("Pod volume backup %s is not completed", pvb.Name))
+	}
+
+	if _, err := c.pvcClient.PersistentVolumeClaims(dataDownload.Spec.TargetNamespace).Get(dataDownload.Spec.TargetPersistentVolumeClaim, metav1.GetOptions{}); err != nil {
+		errs = append(errs, fmt.Sprintf("Error getting persistent volume claim %s/%s: %v", dataDownload.Spec.TargetNamespace, dataDownload.Spec.TargetPersistentVolumeClaim, err))
+	}
+
+	return pvb, errs
+}
+
+// completeDataDownload updates an in-progress data download's phase once its
+// pod volume restore is done, and deletes its data mover pod.
+func (c *dataDownloadController) completeDataDownload(dataDownload *arkv1api.DataDownload, log logrus.FieldLogger) error {
+	original := dataDownload.DeepCopy()
+
+	pvr, err := c.podVolumeRestoreLister.PodVolumeRestores(dataDownload.Namespace).Get(dataDownload.Status.PodVolumeRestore)
+	if apierrors.IsNotFound(err) {
+		c.deleteDataMoverPod(dataDownload.Spec.TargetNamespace, dataDownload.Status.DataMoverPod, log)
+		return c.failDataDownload(original, dataDownload, "pod volume restore not found")
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting pod volume restore")
+	}
+
+	switch pvr.Status.Phase {
+	case arkv1api.PodVolumeRestorePhaseCompleted:
+		dataDownload.Status.Phase = arkv1api.DataDownloadPhaseCompleted
+	case arkv1api.PodVolumeRestorePhaseFailed, arkv1api.PodVolumeRestorePhaseTimedOut:
+		dataDownload.Status.Phase = arkv1api.DataDownloadPhaseFailed
+		dataDownload.Status.Message = pvr.Status.Message
+	default:
+		return nil
+	}
+
+	c.deleteDataMoverPod(dataDownload.Spec.TargetNamespace, dataDownload.Status.DataMoverPod, log)
+
+	log.Infof("Data download %s", dataDownload.Status.Phase)
+
+	_, err = patchDataDownload(original, dataDownload, c.dataDownloadClient)
+	return err
+}
+
+func (c *dataDownloadController) deleteDataMoverPod(namespace, name string, log logrus.FieldLogger) {
+	if name == "" {
+		return
+	}
+
+	if err := c.podClient.Pods(namespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		log.WithError(errors.WithStack(err)).Errorf("Error deleting data mover pod %s/%s", namespace, name)
+	}
+}
+
+func (c *dataDownloadController) failDataDownload(original, dataDownload *arkv1api.DataDownload, msg string) error {
+	dataDownload.Status.Phase = arkv1api.DataDownloadPhaseFailed
+	dataDownload.Status.Message = msg
+
+	_, err := patchDataDownload(original, dataDownload, c.dataDownloadClient)
+	return err
+}
+
+func newDataDownloadPodVolumeRestore(dataDownload *arkv1api.DataDownload, dataMover *corev1api.Pod, pvb *arkv1api.PodVolumeBackup) *arkv1api.PodVolumeRestore {
+	return &arkv1api.PodVolumeRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    dataDownload.Namespace,
+			GenerateName: dataDownload.Name + "-",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: arkv1api.SchemeGroupVersion.String(),
+					Kind:       "DataDownload",
+					Name:       dataDownload.Name,
+					UID:        dataDownload.UID,
+					Controller: boolptr.True(),
+				},
+			},
+			Labels: map[string]string{
+				arkv1api.DataDownloadNameLabel: dataDownload.Name,
+				arkv1api.PodUIDLabel:           string(dataMover.UID),
+			},
+		},
+		Spec: arkv1api.PodVolumeRestoreSpec{
+			Pod: corev1api.ObjectReference{
+				Kind:      "Pod",
+				Namespace: dataMover.Namespace,
+				Name:      dataMover.Name,
+				UID:       dataMover.UID,
+			},
+			Volume:                pvb.Spec.Volume,
+			SnapshotID:            pvb.Status.SnapshotID,
+			BackupStorageLocation: pvb.Spec.BackupStorageLocation,
+			RepoIdentifier:        pvb.Spec.RepoIdentifier,
+		},
+	}
+}
+
+func patchDataDownload(original, updated *arkv1api.DataDownload, client arkv1client.DataDownloadsGetter) (*arkv1api.DataDownload, error) {
+	origBytes, err := json.Marshal(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling original data download")
+	}
+
+	updatedBytes, err := json.Marshal(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling updated data download")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(origBytes, updatedBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating json merge patch for data download")
+	}
+
+	res, err := client.DataDownloads(original.Namespace).Patch(original.Name, types.MergePatchType, patchBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error patching data download")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/restic"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessDataDownload(t *testing.T) {
+	completedPVB := newDataDownloadTestPVB(api.PodVolumeBackupPhaseCompleted)
+
+	tests := []struct {
+		name                     string
+		dataDownload             *api.DataDownload
+		pvb                      *api.PodVolumeBackup
+		pvr                      *api.PodVolumeRestore
+		expectedPhase            api.DataDownloadPhase
+		expectedValidationErrors int
+		expectedMessage          string
+		expectStart              bool
+		expectDelete             bool
+	}{
+		{
+			name:                     "data download without a spec fails validation",
+			dataDownload:             newDataDownload("", "", "", api.DataDownloadPhaseNew),
+			expectedPhase:            api.DataDownloadPhaseFailedValidation,
+			expectedValidationErrors: 3,
+		},
+		{
+			name:                     "data download of a missing pod volume backup into a missing claim fails validation",
+			dataDownload:             newDataDownload("pvb-1", "ns-1", "missing", api.DataDownloadPhaseNew),
+			expectedPhase:            api.DataDownloadPhaseFailedValidation,
+			expectedValidationErrors: 2,
+		},
+		{
+			name:                     "data download of an incomplete pod volume backup fails validation",
+			dataDownload:             newDataDownload("pvb-1", "ns-1", "pvc-1", api.DataDownloadPhaseNew),
+			pvb:                      newDataDownloadTestPVB(api.PodVolumeBackupPhaseInProgress),
+			expectedPhase:            api.DataDownloadPhaseFailedValidation,
+			expectedValidationErrors: 1,
+		},
+		{
+			name:          "valid data download creates a data mover pod and pod volume restore",
+			dataDownload:  newDataDownload("pvb-1", "ns-1", "pvc-1", api.DataDownloadPhaseNew),
+			pvb:           completedPVB,
+			expectedPhase: api.DataDownloadPhaseInProgress,
+			expectStart:   true,
+		},
+		{
+			name:         "in-progress data download whose pod volume restore isn't done is unchanged",
+			dataDownload: newDataDownload("pvb-1", "ns-1", "pvc-1", api.DataDownloadPhaseInProgress),
+			pvr:          newDataDownloadTestPVR(api.PodVolumeRestorePhaseInProgress, ""),
+		},
+		{
+			name:          "in-progress data download whose pod volume restore completed is completed",
+			dataDownload:  newDataDownload("pvb-1", "ns-1", "pvc-1", api.DataDownloadPhaseInProgress),
+			pvr:           newDataDownloadTestPVR(api.PodVolumeRestorePhaseCompleted, ""),
+			expectedPhase: api.DataDownloadPhaseCompleted,
+			expectDelete:  true,
+		},
+		{
+			name:            "in-progress data download whose pod volume restore failed is failed",
+			dataDownload:    newDataDownload("pvb-1", "ns-1", "pvc-1", api.DataDownloadPhaseInProgress),
+			pvr:             newDataDownloadTestPVR(api.PodVolumeRestorePhaseFailed, "restic error"),
+			expectedPhase:   api.DataDownloadPhaseFailed,
+			expectedMessage: "restic error",
+			expectDelete:    true,
+		},
+		{
+			name:            "in-progress data download whose pod volume restore is missing is failed",
+			dataDownload:    newDataDownload("pvb-1", "ns-1", "pvc-1", api.DataDownloadPhaseInProgress),
+			expectedPhase:   api.DataDownloadPhaseFailed,
+			expectedMessage: "pod volume restore not found",
+			expectDelete:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset(test.dataDownload)
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				podClient       = &fakeDataMoverPodClient{}
+				pvcClient       = &fakePVCClient{claims: map[string]bool{"ns-1/pvc-1": true}}
+			)
+
+			// the fake clientset doesn't generate names
+			client.PrependReactor("create", "podvolumerestores", func(action core.Action) (bool, runtime.Object, error) {
+				pvr := action.(core.CreateAction).GetObject().(*api.PodVolumeRestore)
+				pvr.Name = pvr.GenerateName + "abcde"
+				return true, pvr, nil
+			})
+
+			c := NewDataDownloadController(
+				arktest.NewLogger(),
+				sharedInformers.Ark().V1().DataDownloads(),
+				client.ArkV1(),
+				sharedInformers.Ark().V1().PodVolumeBackups(),
+				sharedInformers.Ark().V1().PodVolumeRestores(),
+				client.ArkV1(),
+				podClient,
+				pvcClient,
+			).(*dataDownloadController)
+
+			require.NoError(t, sharedInformers.Ark().V1().DataDownloads().Informer().GetStore().Add(test.dataDownload))
+			if test.pvb != nil {
+				require.NoError(t, sharedInformers.Ark().V1().PodVolumeBackups().Informer().GetStore().Add(test.pvb))
+			}
+			if test.pvr != nil {
+				require.NoError(t, sharedInformers.Ark().V1().PodVolumeRestores().Informer().GetStore().Add(test.pvr))
+			}
+
+			require.NoError(t, c.processDataDownload(test.dataDownload.Namespace+"/"+test.dataDownload.Name))
+
+			var (
+				creates []core.CreateAction
+				patches []core.PatchAction
+			)
+			for _, action := range client.Actions() {
+				switch a := action.(type) {
+				case core.CreateAction:
+					creates = append(creates, a)
+				case core.PatchAction:
+					patches = append(patches, a)
+				}
+			}
+
+			if test.expectStart {
+				require.Len(t, podClient.created, 1)
+				pod := podClient.created[0]
+				assert.Equal(t, "ns-1", pod.Namespace)
+				assert.Equal(t, "pvc-1", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+				assert.Equal(t, "data", pod.Spec.Volumes[0].Name)
+				assert.Equal(t, restic.InitContainer, pod.Spec.InitContainers[0].Name)
+				assert.Equal(t, []string{"download-uid"}, pod.Spec.InitContainers[0].Args)
+
+				require.Len(t, creates, 1)
+				pvr := creates[0].GetObject().(*api.PodVolumeRestore)
+				assert.Equal(t, api.DefaultNamespace, pvr.Namespace)
+				assert.Equal(t, "download-1", pvr.Labels[api.DataDownloadNameLabel])
+				assert.Equal(t, "pod-uid", pvr.Labels[api.PodUIDLabel])
+				assert.Equal(t, "download-uid", string(pvr.OwnerReferences[0].UID))
+				assert.Equal(t, api.PodVolumeRestoreSpec{
+					Pod: corev1api.ObjectReference{
+						Kind:      "Pod",
+						Namespace: "ns-1",
+						Name:      pod.Name,
+						UID:       "pod-uid",
+					},
+					Volume:                "data",
+					SnapshotID:            "snapshot-1",
+					BackupStorageLocation: "default",
+					RepoIdentifier:        "repo-1",
+				}, pvr.Spec)
+			} else {
+				assert.Empty(t, podClient.created)
+				assert.Empty(t, creates)
+			}
+
+			if test.expectDelete {
+				assert.Equal(t, []string{"ns-1/data-mover-1"}, podClient.deleted)
+			} else {
+				assert.Empty(t, podClient.deleted)
+			}
+
+			if test.expectedPhase == "" {
+				assert.Empty(t, patches)
+				return
+			}
+
+			require.Len(t, patches, 1)
+			status := decodeStatusPatch(t, patches[0].GetPatch())
+			assert.Equal(t, string(test.expectedPhase), status["phase"])
+			if test.expectedValidationErrors > 0 {
+				assert.Len(t, status["validationErrors"], test.expectedValidationErrors)
+			}
+			if test.expectedMessage != "" {
+				assert.Equal(t, test.expectedMessage, status["message"])
+			}
+			if test.expectStart {
+				assert.Equal(t, podClient.created[0].Name, status["dataMoverPod"])
+				assert.Equal(t, "download-1-abcde", status["podVolumeRestore"])
+			}
+		})
+	}
+}
+
+func newDataDownload(pvb, targetNamespace, targetClaim string, phase api.DataDownloadPhase) *api.DataDownload {
+	dataDownload := &api.DataDownload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: api.DefaultNamespace,
+			Name:      "download-1",
+			UID:       "download-uid",
+		},
+		Spec: api.DataDownloadSpec{
+			PodVolumeBackup:             pvb,
+			TargetNamespace:             targetNamespace,
+			TargetPersistentVolumeClaim: targetClaim,
+		},
+		Status: api.DataDownloadStatus{
+			Phase: phase,
+		},
+	}
+
+	if phase == api.DataDownloadPhaseInProgress {
+		dataDownload.Status.DataMoverPod = "data-mover-1"
+		dataDownload.Status.PodVolumeRestore = "download-1-abcde"
+	}
+
+	return dataDownload
+}
+
+func newDataDownloadTestPVB(phase api.PodVolumeBackupPhase) *api.PodVolumeBackup {
+	return &api.PodVolumeBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: api.DefaultNamespace,
+			Name:      "pvb-1",
+		},
+		Spec: api.PodVolumeBackupSpec{
+			Volume:                "data",
+			BackupStorageLocation: "default",
+			RepoIdentifier:        "repo-1",
+		},
+		Status: api.PodVolumeBackupStatus{
+			Phase:      phase,
+			SnapshotID: "snapshot-1",
+		},
+	}
+}
+
+func newDataDownloadTestPVR(phase api.PodVolumeRestorePhase, message string) *api.PodVolumeRestore {
+	return &api.PodVolumeRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: api.DefaultNamespace,
+			Name:      "download-1-abcde",
+		},
+		Status: api.PodVolumeRestoreStatus{
+			Phase:   phase,
+			Message: message,
+		},
+	}
+}
+
+// fakeDataMoverPodClient records the pods created and deleted through it.
+// Calling any other method panics.
+type fakeDataMoverPodClient struct {
+	corev1client.PodInterface
+
+	namespace string
+	created   []*corev1api.Pod
+	deleted   []string
+}
+
+func (c *fakeDataMoverPodClient) Pods(namespace string) corev1client.PodInterface {
+	c.namespace = namespace
+	return c
+}
+
+func (c *fakeDataMoverPodClient) Create(pod *corev1api.Pod) (*corev1api.Pod, error) {
+	pod = pod.DeepCopy()
+	pod.Name = pod.GenerateName + "abcde"
+	pod.UID = "pod-uid"
+	c.created = append(c.created, pod)
+	return pod, nil
+}
+
+func (c *fakeDataMoverPodClient) Delete(name string, options *metav1.DeleteOptions) error {
+	c.deleted = append(c.deleted, c.namespace+"/"+name)
+	return nil
+}
+
+// fakePVCClient returns the claims in its map, keyed by namespace/name, from
+// Get. Calling any other method panics.
+type fakePVCClient struct {
+	corev1client.PersistentVolumeClaimInterface
+
+	namespace string
+	claims    map[string]bool
+}
+
+func (c *fakePVCClient) PersistentVolumeClaims(namespace string) corev1client.PersistentVolumeClaimInterface {
+	c.namespace = namespace
+	return c
+}
+
+func (c *fakePVCClient) Get(name string, options metav1.GetOptions) (*corev1api.PersistentVolumeClaim, error) {
+	if !c.claims[c.namespace+"/"+name] {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "persistentvolumeclaims"}, name)
+	}
+
+	return &corev1api.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      name,
+		},
+	}, nil
+}
@@ -150,7 +150,7 @@ func (c *downloadRequestController) generatePreSignedURL(downloadRequest *v1.Dow
 	)
 
 	switch downloadRequest.Spec.Target.Kind {
-	case v1.DownloadTargetKindRestoreLog, v1.DownloadTargetKindRestoreResults:
+	case v1.DownloadTargetKindRestoreLog, v1.DownloadTargetKindRestoreResults, v1.DownloadTargetKindRestoreManifest:
 		restore, err := c.restoreLister.Restores(downloadRequest.Namespace).Get(downloadRequest.Spec.Target.Name)
 		if err != nil {
 			return errors.Wrap(err, "error getting Restore")
@@ -174,7 +174,12 @@ func (c *downloadRequestController) generatePreSignedURL(downloadRequest *v1.Dow
 	pluginManager := c.newPluginManager(log)
 	defer pluginManager.CleanupClients()
 
-	backupStore, err := c.newBackupStore(backupLocation, pluginManager, log)
+	location, err := persistence.BackupLocation(backupLocation, backup)
+	if err != nil {
+		return err
+	}
+
+	backupStore, err := c.newBackupStore(location, pluginManager, log)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -184,7 +189,7 @@ func (c *downloadRequestController) generatePreSignedURL(downloadRequest *v1.Dow
 	}
 
 	update.Status.Phase = v1.DownloadRequestPhaseProcessed
-	update.Status.Expiration = metav1.NewTime(c.clock.Now().Add(persistence.DownloadURLTTL))
+	update.Status.Expiration = metav1.NewTime(c.clock.Now().Add(persistence.SignedURLTTL(backupLocation)))
 
 	_, err = patchDownloadRequest(downloadRequest, update, c.downloadRequestClient)
 	return errors.WithStack(err)
@@ -44,6 +44,7 @@ type gcController struct {
 	backupLister              listers.BackupLister
 	deleteBackupRequestLister listers.DeleteBackupRequestLister
 	deleteBackupRequestClient arkv1client.DeleteBackupRequestsGetter
+	maintenanceMode           func() bool
 
 	clock clock.Clock
 }
@@ -54,6 +55,7 @@ func NewGCController(
 	backupInformer informers.BackupInformer,
 	deleteBackupRequestInformer informers.DeleteBackupRequestInformer,
 	deleteBackupRequestClient arkv1client.DeleteBackupRequestsGetter,
+	maintenanceMode func() bool,
 ) Interface {
 	c := &gcController{
 		genericController:         newGenericController("gc-controller", logger),
@@ -61,6 +63,7 @@ func NewGCController(
 		backupLister:              backupInformer.Lister(),
 		deleteBackupRequestLister: deleteBackupRequestInformer.Lister(),
 		deleteBackupRequestClient: deleteBackupRequestClient,
+		maintenanceMode:           maintenanceMode,
 	}
 
 	c.syncHandler = c.processQueueItem
@@ -132,6 +135,29 @@ func (c *gcController) processQueueItem(key string) error {
 
 	log.Info("Backup has expired")
 
+	// expired backups are enqueued again on the next resync
+	if c.maintenanceMode() {
+		log.Info("Server is in maintenance mode, not deleting expired backup")
+		return nil
+	}
+
+	if backup.Annotations[arkv1api.NeverExpireAnnotation] == "true" {
+		log.Info("Backup is annotated to never expire, skipping")
+		return nil
+	}
+
+	if backup.Spec.Hold {
+		log.Info("Backup is on hold, skipping")
+		return nil
+	}
+
+	// backups synced from other clusters are garbage collected by the cluster
+	// that created them
+	if cluster := backup.Labels[arkv1api.SourceClusterLabel]; cluster != "" {
+		log.WithField("sourceCluster", cluster).Info("Backup was synced from another cluster, skipping")
+		return nil
+	}
+
 	selector := labels.SelectorFromSet(labels.Set(map[string]string{
 		arkv1api.BackupNameLabel: backup.Name,
 		arkv1api.BackupUIDLabel:  string(backup.UID),
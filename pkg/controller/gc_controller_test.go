@@ -49,6 +49,7 @@ func TestGCControllerEnqueueAllBackups(t *testing.T) {
 			sharedInformers.Ark().V1().Backups(),
 			sharedInformers.Ark().V1().DeleteBackupRequests(),
 			client.ArkV1(),
+			func() bool { return false },
 		).(*gcController)
 	)
 
@@ -112,6 +113,7 @@ func TestGCControllerHasUpdateFunc(t *testing.T) {
 		sharedInformers.Ark().V1().Backups(),
 		sharedInformers.Ark().V1().DeleteBackupRequests(),
 		client.ArkV1(),
+		func() bool { return false },
 	).(*gcController)
 
 	keys := make(chan string)
@@ -154,6 +156,7 @@ func TestGCControllerProcessQueueItem(t *testing.T) {
 		name                           string
 		backup                         *api.Backup
 		deleteBackupRequests           []*api.DeleteBackupRequest
+		maintenanceMode                bool
 		expectDeletion                 bool
 		createDeleteBackupRequestError bool
 		expectError                    bool
@@ -175,6 +178,38 @@ func TestGCControllerProcessQueueItem(t *testing.T) {
 				Backup,
 			expectDeletion: true,
 		},
+		{
+			name: "expired backup is not deleted in maintenance mode",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithExpiration(fakeClock.Now().Add(-1 * time.Second)).
+				Backup,
+			maintenanceMode: true,
+			expectDeletion:  false,
+		},
+		{
+			name: "expired backup annotated to never expire is not deleted",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithExpiration(fakeClock.Now().Add(-1*time.Second)).
+				WithAnnotation(api.NeverExpireAnnotation, "true").
+				Backup,
+			expectDeletion: false,
+		},
+		{
+			name: "expired backup on hold is not deleted",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithExpiration(fakeClock.Now().Add(-1 * time.Second)).
+				WithHold(true).
+				Backup,
+			expectDeletion: false,
+		},
+		{
+			name: "expired backup synced from another cluster is not deleted",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithExpiration(fakeClock.Now().Add(-1*time.Second)).
+				WithLabel(api.SourceClusterLabel, "cluster-2").
+				Backup,
+			expectDeletion: false,
+		},
 		{
 			name: "expired backup with a pending deletion request is not deleted",
 			backup: arktest.NewTestBackup().WithName("backup-1").
@@ -242,6 +277,7 @@ func TestGCControllerProcessQueueItem(t *testing.T) {
 				sharedInformers.Ark().V1().Backups(),
 				sharedInformers.Ark().V1().DeleteBackupRequests(),
 				client.ArkV1(),
+				func() bool { return test.maintenanceMode },
 			).(*gcController)
 			controller.clock = fakeClock
 
@@ -36,6 +36,11 @@ type genericController struct {
 	resyncFunc       func()
 	resyncPeriod     time.Duration
 	cacheSyncWaiters []cache.InformerSynced
+
+	// resyncPeriodFunc, if set, is used instead of resyncPeriod to
+	// get the period to wait before each call to resyncFunc, so that
+	// it can change while the controller is running.
+	resyncPeriodFunc func() time.Duration
 }
 
 func newGenericController(name string, logger logrus.FieldLogger) *genericController {
@@ -93,14 +98,18 @@ func (c *genericController) Run(ctx context.Context, numWorkers int) error {
 	}
 
 	if c.resyncFunc != nil {
-		if c.resyncPeriod == 0 {
+		if c.resyncPeriod == 0 && c.resyncPeriodFunc == nil {
 			// Programmer error
 			panic("non-zero resyncPeriod is required")
 		}
 
 		wg.Add(1)
 		go func() {
-			wait.Until(c.resyncFunc, c.resyncPeriod, ctx.Done())
+			if c.resyncPeriodFunc != nil {
+				c.resyncUntil(ctx.Done())
+			} else {
+				wait.Until(c.resyncFunc, c.resyncPeriod, ctx.Done())
+			}
 			wg.Done()
 		}()
 	}
@@ -110,6 +119,26 @@ func (c *genericController) Run(ctx context.Context, numWorkers int) error {
 	return nil
 }
 
+// resyncUntil calls resyncFunc until stopCh is closed, waiting for the period
+// returned by resyncPeriodFunc after each call.
+func (c *genericController) resyncUntil(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		c.resyncFunc()
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(c.resyncPeriodFunc()):
+		}
+	}
+}
+
 func (c *genericController) runWorker() {
 	// continually take items off the queue (waits if it's
 	// empty) until we get a shutdown signal from the queue
@@ -0,0 +1,175 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+)
+
+// namespaceScheduleController keeps a Schedule in the Ark server's namespace for each
+// namespace annotated with api.BackupScheduleAnnotation. Each schedule is named after
+// its namespace and backs up only that namespace, on the annotation's cron schedule.
+// Schedules are updated when the annotation changes, and deleted when it's removed or
+// the namespace is deleted.
+type namespaceScheduleController struct {
+	*genericController
+
+	namespace       string
+	namespaceLister corev1listers.NamespaceLister
+	scheduleLister  listers.ScheduleLister
+	scheduleClient  arkv1client.SchedulesGetter
+}
+
+// NewNamespaceScheduleController constructs a new namespaceScheduleController.
+// namespaceInformer must watch all namespaces.
+func NewNamespaceScheduleController(
+	namespace string,
+	namespaceInformer cache.SharedIndexInformer,
+	scheduleInformer informers.ScheduleInformer,
+	scheduleClient arkv1client.SchedulesGetter,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &namespaceScheduleController{
+		genericController: newGenericController("namespace-schedule", logger),
+		namespace:         namespace,
+		namespaceLister:   corev1listers.NewNamespaceLister(namespaceInformer.GetIndexer()),
+		scheduleLister:    scheduleInformer.Lister(),
+		scheduleClient:    scheduleClient,
+	}
+
+	c.syncHandler = c.processNamespace
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, namespaceInformer.HasSynced, scheduleInformer.Informer().HasSynced)
+
+	enqueueNamespace := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		ns, ok := obj.(*corev1api.Namespace)
+		if !ok {
+			return
+		}
+		c.queue.Add(ns.Name)
+	}
+
+	namespaceInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueueNamespace,
+			UpdateFunc: func(_, obj interface{}) { enqueueNamespace(obj) },
+			DeleteFunc: enqueueNamespace,
+		},
+	)
+
+	// schedules that are changed or deleted by anything else are put back
+	// in line with their namespace's annotation
+	enqueueScheduleNamespace := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		schedule, ok := obj.(*api.Schedule)
+		if !ok {
+			return
+		}
+		if ns := schedule.Labels[api.ScheduleNamespaceLabel]; ns != "" {
+			c.queue.Add(ns)
+		}
+	}
+
+	scheduleInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, obj interface{}) { enqueueScheduleNamespace(obj) },
+			DeleteFunc: enqueueScheduleNamespace,
+		},
+	)
+
+	return c
+}
+
+func (c *namespaceScheduleController) processNamespace(key string) error {
+	log := c.logger.WithField("namespace", key)
+
+	ns, err := c.namespaceLister.Get(key)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting namespace")
+	}
+
+	schedule, err := c.scheduleLister.Schedules(c.namespace).Get(key)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting schedule")
+	}
+	if schedule != nil && schedule.Labels[api.ScheduleNamespaceLabel] != key {
+		if ns != nil && ns.Annotations[api.BackupScheduleAnnotation] != "" {
+			log.Warnf("Not managing schedule %s for namespace because a schedule with the same name already exists", key)
+		}
+		return nil
+	}
+
+	cronSchedule := namespaceBackupSchedule(ns)
+
+	if cronSchedule == "" {
+		if schedule == nil {
+			return nil
+		}
+
+		log.Info("Deleting schedule because namespace is no longer annotated for backups")
+		return deleteSchedule(c.scheduleClient, schedule)
+	}
+
+	desired := &api.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      key,
+			Labels: map[string]string{
+				api.ScheduleNamespaceLabel: key,
+			},
+		},
+		Spec: api.ScheduleSpec{
+			Schedule: cronSchedule,
+			Template: api.BackupSpec{
+				IncludedNamespaces: []string{key},
+			},
+		},
+	}
+
+	_, err = syncSchedule(c.scheduleClient, schedule, desired, log)
+	return err
+}
+
+// namespaceBackupSchedule returns the cron schedule that ns is annotated to be backed
+// up on, or "" if it shouldn't be backed up on a schedule. ns may be nil if the
+// namespace doesn't exist.
+func namespaceBackupSchedule(ns *corev1api.Namespace) string {
+	if ns == nil || ns.DeletionTimestamp != nil || ns.Status.Phase == corev1api.NamespaceTerminating {
+		return ""
+	}
+
+	return strings.TrimSpace(ns.Annotations[api.BackupScheduleAnnotation])
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessNamespace(t *testing.T) {
+	newNamespace := func(annotation string) *corev1api.Namespace {
+		ns := &corev1api.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant"},
+		}
+		if annotation != "" {
+			ns.Annotations = map[string]string{api.BackupScheduleAnnotation: annotation}
+		}
+		return ns
+	}
+
+	newSchedule := func(cronExpression string, phase api.SchedulePhase) *api.Schedule {
+		schedule := arktest.NewTestSchedule(api.DefaultNamespace, "tenant").WithCronSchedule(cronExpression).WithPhase(phase).Schedule
+		schedule.Labels = map[string]string{api.ScheduleNamespaceLabel: "tenant"}
+		schedule.Spec.Template.IncludedNamespaces = []string{"tenant"}
+		return schedule
+	}
+
+	terminating := newNamespace("0 2 * * *")
+	terminating.Status.Phase = corev1api.NamespaceTerminating
+
+	unmanaged := arktest.NewTestSchedule(api.DefaultNamespace, "tenant").WithCronSchedule("@every 1h").Schedule
+
+	tests := []struct {
+		name             string
+		namespace        *corev1api.Namespace
+		schedule         *api.Schedule
+		expectedActions  []string
+		expectedSchedule string
+	}{
+		{
+			name:             "annotated namespace without a schedule creates one",
+			namespace:        newNamespace(" 0 2 * * * "),
+			expectedActions:  []string{"create"},
+			expectedSchedule: "0 2 * * *",
+		},
+		{
+			name:      "schedule matching the annotation isn't changed",
+			namespace: newNamespace("0 2 * * *"),
+			schedule:  newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+		},
+		{
+			name:             "changed annotation updates the schedule",
+			namespace:        newNamespace("0 3 * * *"),
+			schedule:         newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions:  []string{"patch"},
+			expectedSchedule: "0 3 * * *",
+		},
+		{
+			name:             "changed annotation recreates a schedule that failed validation",
+			namespace:        newNamespace("0 3 * * *"),
+			schedule:         newSchedule("not a schedule", api.SchedulePhaseFailedValidation),
+			expectedActions:  []string{"delete", "create"},
+			expectedSchedule: "0 3 * * *",
+		},
+		{
+			name:            "removed annotation deletes the schedule",
+			namespace:       newNamespace(""),
+			schedule:        newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions: []string{"delete"},
+		},
+		{
+			name:            "deleted namespace deletes the schedule",
+			schedule:        newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions: []string{"delete"},
+		},
+		{
+			name:            "terminating namespace deletes the schedule",
+			namespace:       terminating,
+			schedule:        newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions: []string{"delete"},
+		},
+		{
+			name:      "schedule not created for the namespace isn't changed",
+			namespace: newNamespace("0 2 * * *"),
+			schedule:  unmanaged,
+		},
+		{
+			name:      "namespace without the annotation is ignored",
+			namespace: newNamespace(""),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client            = fake.NewSimpleClientset()
+				sharedInformers   = informers.NewSharedInformerFactory(client, 0)
+				namespaceInformer = cache.NewSharedIndexInformer(nil, new(corev1api.Namespace), 0, cache.Indexers{})
+			)
+
+			if test.schedule != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Schedules().Informer().GetStore().Add(test.schedule))
+				client = fake.NewSimpleClientset(test.schedule)
+			}
+			if test.namespace != nil {
+				require.NoError(t, namespaceInformer.GetStore().Add(test.namespace))
+			}
+
+			c := NewNamespaceScheduleController(
+				api.DefaultNamespace,
+				namespaceInformer,
+				sharedInformers.Ark().V1().Schedules(),
+				client.ArkV1(),
+				arktest.NewLogger(),
+			).(*namespaceScheduleController)
+
+			require.NoError(t, c.processNamespace("tenant"))
+
+			var actions []string
+			for _, action := range client.Actions() {
+				actions = append(actions, action.GetVerb())
+			}
+			assert.Equal(t, test.expectedActions, actions)
+
+			if test.expectedSchedule == "" {
+				return
+			}
+
+			switch action := client.Actions()[len(client.Actions())-1].(type) {
+			case core.CreateAction:
+				schedule := action.GetObject().(*api.Schedule)
+				assert.Equal(t, api.DefaultNamespace, schedule.Namespace)
+				assert.Equal(t, "tenant", schedule.Name)
+				assert.Equal(t, "tenant", schedule.Labels[api.ScheduleNamespaceLabel])
+				assert.Equal(t, test.expectedSchedule, schedule.Spec.Schedule)
+				assert.Equal(t, []string{"tenant"}, schedule.Spec.Template.IncludedNamespaces)
+			case core.PatchAction:
+				schedule := new(api.Schedule)
+				require.NoError(t, json.Unmarshal(action.GetPatch(), schedule))
+				assert.Equal(t, test.expectedSchedule, schedule.Spec.Schedule)
+			default:
+				t.Fatalf("unexpected action %v", action)
+			}
+		})
+	}
+}
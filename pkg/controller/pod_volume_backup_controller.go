@@ -17,11 +17,13 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/pkg/errors"
@@ -36,8 +38,8 @@ import (
 	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
 	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/metrics"
 	"github.com/heptio/ark/pkg/restic"
-	arkexec "github.com/heptio/ark/pkg/util/exec"
 	"github.com/heptio/ark/pkg/util/filesystem"
 	"github.com/heptio/ark/pkg/util/kube"
 )
@@ -55,6 +57,7 @@ type podVolumeBackupController struct {
 
 	processBackupFunc func(*arkv1api.PodVolumeBackup) error
 	fileSystem        filesystem.Interface
+	metrics           *metrics.ServerMetrics
 }
 
 // NewPodVolumeBackupController creates a new pod volume backup controller.
@@ -67,6 +70,7 @@ func NewPodVolumeBackupController(
 	pvcInformer corev1informers.PersistentVolumeClaimInformer,
 	backupLocationInformer informers.BackupStorageLocationInformer,
 	nodeName string,
+	metrics *metrics.ServerMetrics,
 ) Interface {
 	c := &podVolumeBackupController{
 		genericController:     newGenericController("pod-volume-backup", logger),
@@ -79,6 +83,7 @@ func NewPodVolumeBackupController(
 		nodeName:              nodeName,
 
 		fileSystem: filesystem.NewFileSystem(),
+		metrics:    metrics,
 	}
 
 	c.syncHandler = c.processQueueItem
@@ -173,7 +178,10 @@ func (c *podVolumeBackupController) processBackup(req *arkv1api.PodVolumeBackup)
 	var err error
 
 	// update status to InProgress
-	req, err = c.patchPodVolumeBackup(req, updatePhaseFunc(arkv1api.PodVolumeBackupPhaseInProgress))
+	req, err = c.patchPodVolumeBackup(req, func(r *arkv1api.PodVolumeBackup) {
+		r.Status.Phase = arkv1api.PodVolumeBackupPhaseInProgress
+		r.Status.Attempts = 1
+	})
 	if err != nil {
 		log.WithError(err).Error("Error setting phase to InProgress")
 		return errors.WithStack(err)
@@ -185,24 +193,32 @@ func (c *podVolumeBackupController) processBackup(req *arkv1api.PodVolumeBackup)
 		return c.fail(req, errors.Wrap(err, "error getting pod").Error(), log)
 	}
 
-	volumeDir, err := kube.GetVolumeDirectory(pod, req.Spec.Volume, c.pvcLister)
-	if err != nil {
-		log.WithError(err).Error("Error getting volume directory name")
-		return c.fail(req, errors.Wrap(err, "error getting volume directory name").Error(), log)
-	}
+	var path string
+	if req.Spec.HostPath != "" {
+		if path, err = c.hostRootPath(req.Spec.HostPath); err != nil {
+			log.WithError(err).Error("Error getting hostPath volume path")
+			return c.fail(req, errors.Wrap(err, "error getting hostPath volume path").Error(), log)
+		}
+		log.WithField("path", path).Warn("Backing up hostPath volume from the node's root filesystem")
+	} else {
+		volumeDir, err := kube.GetVolumeDirectory(pod, req.Spec.Volume, c.pvcLister)
+		if err != nil {
+			log.WithError(err).Error("Error getting volume directory name")
+			return c.fail(req, errors.Wrap(err, "error getting volume directory name").Error(), log)
+		}
 
-	pathGlob := fmt.Sprintf("/host_pods/%s/volumes/*/%s", string(req.Spec.Pod.UID), volumeDir)
-	log.WithField("pathGlob", pathGlob).Debug("Looking for path matching glob")
+		pathGlob := fmt.Sprintf("/host_pods/%s/volumes/*/%s", string(req.Spec.Pod.UID), volumeDir)
+		log.WithField("pathGlob", pathGlob).Debug("Looking for path matching glob")
 
-	path, err := singlePathMatch(pathGlob)
-	if err != nil {
-		log.WithError(err).Error("Error uniquely identifying volume path")
-		return c.fail(req, errors.Wrap(err, "error getting volume path on host").Error(), log)
+		if path, err = singlePathMatch(pathGlob); err != nil {
+			log.WithError(err).Error("Error uniquely identifying volume path")
+			return c.fail(req, errors.Wrap(err, "error getting volume path on host").Error(), log)
+		}
+		log.WithField("path", path).Debugf("Found path matching glob")
 	}
-	log.WithField("path", path).Debugf("Found path matching glob")
 
 	// temp creds
-	file, err := restic.TempCredentialsFile(c.secretLister, req.Namespace, req.Spec.Pod.Namespace, c.fileSystem)
+	file, err := restic.TempCredentialsFile(c.secretLister, c.backupLocationLister, req.Namespace, req.Spec.BackupStorageLocation, req.Spec.Pod.Namespace, c.fileSystem)
 	if err != nil {
 		log.WithError(err).Error("Error creating temp restic credentials file")
 		return c.fail(req, errors.Wrap(err, "error creating temp restic credentials file").Error(), log)
@@ -215,6 +231,8 @@ func (c *podVolumeBackupController) processBackup(req *arkv1api.PodVolumeBackup)
 		file,
 		path,
 		req.Spec.Tags,
+		req.Spec.IncludePaths,
+		req.Spec.ExcludePatterns,
 	)
 
 	// if this is azure, set resticCmd.Env appropriately
@@ -226,9 +244,28 @@ func (c *podVolumeBackupController) processBackup(req *arkv1api.PodVolumeBackup)
 		resticCmd.Env = env
 	}
 
+	ctx, cancelFunc := podVolumeOperationContext(req.Spec.Timeout.Duration)
+	defer cancelFunc()
+
+	onRetry := func(attempt int) {
+		log.Warnf("Retrying restic backup after a transient failure, attempt %d", attempt)
+		c.metrics.RegisterResticOperationRetry("backup")
+
+		if _, err := c.patchPodVolumeBackup(req, func(r *arkv1api.PodVolumeBackup) {
+			r.Status.Attempts = attempt
+		}); err != nil {
+			log.WithError(err).Error("Error updating attempts")
+		}
+	}
+
 	var stdout, stderr string
 
-	if stdout, stderr, err = arkexec.RunCommand(resticCmd.Cmd()); err != nil {
+	if stdout, stderr, _, err = restic.RunCommandWithRetries(ctx, resticCmd, onRetry); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			log.WithError(errors.WithStack(err)).Errorf("Timed out running command=%s after %s, stdout=%s, stderr=%s", resticCmd.String(), req.Spec.Timeout.Duration, stdout, stderr)
+			return c.failWithPhase(req, arkv1api.PodVolumeBackupPhaseTimedOut, fmt.Sprintf("restic backup did not complete within %s", req.Spec.Timeout.Duration), log)
+		}
+
 		log.WithError(errors.WithStack(err)).Errorf("Error running command=%s, stdout=%s, stderr=%s", resticCmd.String(), stdout, stderr)
 		return c.fail(req, fmt.Sprintf("error running restic backup, stderr=%s: %s", stderr, err.Error()), log)
 	}
@@ -256,6 +293,30 @@ func (c *podVolumeBackupController) processBackup(req *arkv1api.PodVolumeBackup)
 	return nil
 }
 
+// hostRoot is where the node's root filesystem is mounted in the restic
+// daemonset's pods, for backing up hostPath volumes.
+const hostRoot = "/host_root"
+
+// hostRootPath returns the path of a hostPath volume's directory under the
+// node's root filesystem mount, or an error if it's not mounted.
+func (c *podVolumeBackupController) hostRootPath(hostPath string) (string, error) {
+	if exists, err := c.fileSystem.DirExists(hostRoot); err != nil {
+		return "", errors.WithStack(err)
+	} else if !exists {
+		return "", errors.Errorf("the node's root filesystem isn't mounted at %s in the restic pod", hostRoot)
+	}
+
+	path := filepath.Join(hostRoot, filepath.Clean("/"+hostPath))
+
+	if exists, err := c.fileSystem.DirExists(path); err != nil {
+		return "", errors.WithStack(err)
+	} else if !exists {
+		return "", errors.Errorf("directory %s not found on node", hostPath)
+	}
+
+	return path, nil
+}
+
 func (c *podVolumeBackupController) patchPodVolumeBackup(req *arkv1api.PodVolumeBackup, mutate func(*arkv1api.PodVolumeBackup)) (*arkv1api.PodVolumeBackup, error) {
 	// Record original json
 	oldData, err := json.Marshal(req)
@@ -286,16 +347,29 @@ func (c *podVolumeBackupController) patchPodVolumeBackup(req *arkv1api.PodVolume
 }
 
 func (c *podVolumeBackupController) fail(req *arkv1api.PodVolumeBackup, msg string, log logrus.FieldLogger) error {
+	return c.failWithPhase(req, arkv1api.PodVolumeBackupPhaseFailed, msg, log)
+}
+
+func (c *podVolumeBackupController) failWithPhase(req *arkv1api.PodVolumeBackup, phase arkv1api.PodVolumeBackupPhase, msg string, log logrus.FieldLogger) error {
 	if _, err := c.patchPodVolumeBackup(req, func(r *arkv1api.PodVolumeBackup) {
-		r.Status.Phase = arkv1api.PodVolumeBackupPhaseFailed
+		r.Status.Phase = phase
 		r.Status.Message = msg
 	}); err != nil {
-		log.WithError(err).Error("Error setting phase to Failed")
+		log.WithError(err).Errorf("Error setting phase to %s", phase)
 		return err
 	}
 	return nil
 }
 
+// podVolumeOperationContext returns a context for running a restic backup or
+// restore that's done after timeout, or that's never done if timeout is zero.
+func podVolumeOperationContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
 func updatePhaseFunc(phase arkv1api.PodVolumeBackupPhase) func(r *arkv1api.PodVolumeBackup) {
 	return func(r *arkv1api.PodVolumeBackup) {
 		r.Status.Phase = phase
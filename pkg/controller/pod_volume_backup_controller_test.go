@@ -17,7 +17,9 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -169,3 +171,68 @@ func TestPVBHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestHostRootPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileSystem   *arktest.FakeFileSystem
+		hostPath     string
+		expectedPath string
+		expectedErr  bool
+	}{
+		{
+			name:        "host root not mounted is an error",
+			fileSystem:  arktest.NewFakeFileSystem(),
+			hostPath:    "/data",
+			expectedErr: true,
+		},
+		{
+			name:        "missing directory is an error",
+			fileSystem:  arktest.NewFakeFileSystem().WithDirectory("/host_root"),
+			hostPath:    "/data",
+			expectedErr: true,
+		},
+		{
+			name:         "existing directory is found under the host root",
+			fileSystem:   arktest.NewFakeFileSystem().WithDirectories("/host_root", "/host_root/data"),
+			hostPath:     "/data",
+			expectedPath: "/host_root/data",
+		},
+		{
+			name:         "relative path can't escape the host root",
+			fileSystem:   arktest.NewFakeFileSystem().WithDirectories("/host_root", "/host_root/data"),
+			hostPath:     "../../data",
+			expectedPath: "/host_root/data",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &podVolumeBackupController{
+				fileSystem: test.fileSystem,
+			}
+
+			path, err := c.hostRootPath(test.hostPath)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedPath, path)
+		})
+	}
+}
+
+func TestPodVolumeOperationContext(t *testing.T) {
+	ctx, cancelFunc := podVolumeOperationContext(0)
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline)
+	cancelFunc()
+	assert.Equal(t, context.Canceled, ctx.Err())
+
+	ctx, cancelFunc = podVolumeOperationContext(time.Millisecond)
+	defer cancelFunc()
+	<-ctx.Done()
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
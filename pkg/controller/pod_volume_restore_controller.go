@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -39,9 +40,9 @@ import (
 	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
 	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/metrics"
 	"github.com/heptio/ark/pkg/restic"
 	"github.com/heptio/ark/pkg/util/boolptr"
-	arkexec "github.com/heptio/ark/pkg/util/exec"
 	"github.com/heptio/ark/pkg/util/filesystem"
 	"github.com/heptio/ark/pkg/util/kube"
 )
@@ -59,6 +60,7 @@ type podVolumeRestoreController struct {
 
 	processRestoreFunc func(*arkv1api.PodVolumeRestore) error
 	fileSystem         filesystem.Interface
+	metrics            *metrics.ServerMetrics
 }
 
 // NewPodVolumeRestoreController creates a new pod volume restore controller.
@@ -71,6 +73,7 @@ func NewPodVolumeRestoreController(
 	pvcInformer corev1informers.PersistentVolumeClaimInformer,
 	backupLocationInformer informers.BackupStorageLocationInformer,
 	nodeName string,
+	metrics *metrics.ServerMetrics,
 ) Interface {
 	c := &podVolumeRestoreController{
 		genericController:      newGenericController("pod-volume-restore", logger),
@@ -83,6 +86,7 @@ func NewPodVolumeRestoreController(
 		nodeName:               nodeName,
 
 		fileSystem: filesystem.NewFileSystem(),
+		metrics:    metrics,
 	}
 
 	c.syncHandler = c.processQueueItem
@@ -258,7 +262,10 @@ func (c *podVolumeRestoreController) processRestore(req *arkv1api.PodVolumeResto
 	var err error
 
 	// update status to InProgress
-	req, err = c.patchPodVolumeRestore(req, updatePodVolumeRestorePhaseFunc(arkv1api.PodVolumeRestorePhaseInProgress))
+	req, err = c.patchPodVolumeRestore(req, func(r *arkv1api.PodVolumeRestore) {
+		r.Status.Phase = arkv1api.PodVolumeRestorePhaseInProgress
+		r.Status.Attempts = 1
+	})
 	if err != nil {
 		log.WithError(err).Error("Error setting phase to InProgress")
 		return errors.WithStack(err)
@@ -276,7 +283,7 @@ func (c *podVolumeRestoreController) processRestore(req *arkv1api.PodVolumeResto
 		return c.failRestore(req, errors.Wrap(err, "error getting volume directory name").Error(), log)
 	}
 
-	credsFile, err := restic.TempCredentialsFile(c.secretLister, req.Namespace, req.Spec.Pod.Namespace, c.fileSystem)
+	credsFile, err := restic.TempCredentialsFile(c.secretLister, c.backupLocationLister, req.Namespace, req.Spec.BackupStorageLocation, req.Spec.Pod.Namespace, c.fileSystem)
 	if err != nil {
 		log.WithError(err).Error("Error creating temp restic credentials file")
 		return c.failRestore(req, errors.Wrap(err, "error creating temp restic credentials file").Error(), log)
@@ -284,8 +291,16 @@ func (c *podVolumeRestoreController) processRestore(req *arkv1api.PodVolumeResto
 	// ignore error since there's nothing we can do and it's a temp file.
 	defer os.Remove(credsFile)
 
+	ctx, cancelFunc := podVolumeOperationContext(req.Spec.Timeout.Duration)
+	defer cancelFunc()
+
 	// execute the restore process
-	if err := c.restorePodVolume(req, credsFile, volumeDir, log); err != nil {
+	if err := c.restorePodVolume(ctx, req, credsFile, volumeDir, log); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			log.WithError(err).Errorf("Timed out restoring volume after %s", req.Spec.Timeout.Duration)
+			return c.failRestoreWithPhase(req, arkv1api.PodVolumeRestorePhaseTimedOut, fmt.Sprintf("restic restore did not complete within %s", req.Spec.Timeout.Duration), log)
+		}
+
 		log.WithError(err).Error("Error restoring volume")
 		return c.failRestore(req, errors.Wrap(err, "error restoring volume").Error(), log)
 	}
@@ -301,7 +316,7 @@ func (c *podVolumeRestoreController) processRestore(req *arkv1api.PodVolumeResto
 	return nil
 }
 
-func (c *podVolumeRestoreController) restorePodVolume(req *arkv1api.PodVolumeRestore, credsFile, volumeDir string, log logrus.FieldLogger) error {
+func (c *podVolumeRestoreController) restorePodVolume(ctx context.Context, req *arkv1api.PodVolumeRestore, credsFile, volumeDir string, log logrus.FieldLogger) error {
 	// Get the full path of the new volume's directory as mounted in the daemonset pod, which
 	// will look like: /host_pods/<new-pod-uid>/volumes/<volume-plugin-name>/<volume-dir>
 	volumePath, err := singlePathMatch(fmt.Sprintf("/host_pods/%s/volumes/*/%s", string(req.Spec.Pod.UID), volumeDir))
@@ -325,9 +340,20 @@ func (c *podVolumeRestoreController) restorePodVolume(req *arkv1api.PodVolumeRes
 		resticCmd.Env = env
 	}
 
+	onRetry := func(attempt int) {
+		log.Warnf("Retrying restic restore after a transient failure, attempt %d", attempt)
+		c.metrics.RegisterResticOperationRetry("restore")
+
+		if _, err := c.patchPodVolumeRestore(req, func(r *arkv1api.PodVolumeRestore) {
+			r.Status.Attempts = attempt
+		}); err != nil {
+			log.WithError(err).Error("Error updating attempts")
+		}
+	}
+
 	var stdout, stderr string
 
-	if stdout, stderr, err = arkexec.RunCommand(resticCmd.Cmd()); err != nil {
+	if stdout, stderr, _, err = restic.RunCommandWithRetries(ctx, resticCmd, onRetry); err != nil {
 		return errors.Wrapf(err, "error running restic restore, cmd=%s, stdout=%s, stderr=%s", resticCmd.String(), stdout, stderr)
 	}
 	log.Debugf("Ran command=%s, stdout=%s, stderr=%s", resticCmd.String(), stdout, stderr)
@@ -340,6 +366,12 @@ func (c *podVolumeRestoreController) restorePodVolume(req *arkv1api.PodVolumeRes
 		log.WithError(err).Warnf("error removing .ark directory from directory %s", volumePath)
 	}
 
+	if req.Spec.Verify {
+		if err := c.verifyPodVolume(req, credsFile, volumePath, resticCmd.Env, log); err != nil {
+			return err
+		}
+	}
+
 	var restoreUID types.UID
 	for _, owner := range req.OwnerReferences {
 		if boolptr.IsSetToTrue(owner.Controller) {
@@ -364,6 +396,36 @@ func (c *podVolumeRestoreController) restorePodVolume(req *arkv1api.PodVolumeRes
 	return nil
 }
 
+// verifyPodVolume compares the files restored into volumePath with the contents of
+// the restic snapshot and records the result in the PodVolumeRestore's status. An
+// error is returned if any of the snapshot's files are missing or have a different size.
+func (c *podVolumeRestoreController) verifyPodVolume(req *arkv1api.PodVolumeRestore, credsFile, volumePath string, env []string, log logrus.FieldLogger) error {
+	files, err := restic.ListSnapshotFiles(req.Spec.RepoIdentifier, credsFile, req.Spec.SnapshotID, env)
+	if err != nil {
+		return errors.Wrap(err, "error listing files in restic snapshot")
+	}
+
+	verification := restic.VerifyRestoredFiles(c.fileSystem, volumePath, files)
+
+	if _, err := c.patchPodVolumeRestore(req, func(r *arkv1api.PodVolumeRestore) {
+		r.Status.Verification = verification
+	}); err != nil {
+		return err
+	}
+
+	if verification.VerifiedFiles != verification.SnapshotFiles {
+		return errors.Errorf("%d of %d files in snapshot were not restored correctly: %s",
+			verification.SnapshotFiles-verification.VerifiedFiles,
+			verification.SnapshotFiles,
+			strings.Join(verification.Mismatches, "; "),
+		)
+	}
+
+	log.Infof("Verified %d restored files (%d bytes)", verification.VerifiedFiles, verification.VerifiedBytes)
+
+	return nil
+}
+
 func (c *podVolumeRestoreController) patchPodVolumeRestore(req *arkv1api.PodVolumeRestore, mutate func(*arkv1api.PodVolumeRestore)) (*arkv1api.PodVolumeRestore, error) {
 	// Record original json
 	oldData, err := json.Marshal(req)
@@ -394,11 +456,15 @@ func (c *podVolumeRestoreController) patchPodVolumeRestore(req *arkv1api.PodVolu
 }
 
 func (c *podVolumeRestoreController) failRestore(req *arkv1api.PodVolumeRestore, msg string, log logrus.FieldLogger) error {
+	return c.failRestoreWithPhase(req, arkv1api.PodVolumeRestorePhaseFailed, msg, log)
+}
+
+func (c *podVolumeRestoreController) failRestoreWithPhase(req *arkv1api.PodVolumeRestore, phase arkv1api.PodVolumeRestorePhase, msg string, log logrus.FieldLogger) error {
 	if _, err := c.patchPodVolumeRestore(req, func(pvr *arkv1api.PodVolumeRestore) {
-		pvr.Status.Phase = arkv1api.PodVolumeRestorePhaseFailed
+		pvr.Status.Phase = phase
 		pvr.Status.Message = msg
 	}); err != nil {
-		log.WithError(err).Error("Error setting phase to Failed")
+		log.WithError(err).Errorf("Error setting phase to %s", phase)
 		return err
 	}
 	return nil
@@ -0,0 +1,285 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+// protectedApplicationController keeps a Schedule for each ProtectedApplication that
+// backs up the application's namespaces, objects, and persistent volume claims. Each
+// schedule is named after its application and labeled with api.ProtectedApplicationLabel,
+// which the schedule's backups inherit, so that the application can be restored by name.
+// Schedules are updated when their application changes, and deleted when it's deleted.
+type protectedApplicationController struct {
+	*genericController
+
+	applicationLister listers.ProtectedApplicationLister
+	applicationClient arkv1client.ProtectedApplicationsGetter
+	scheduleLister    listers.ScheduleLister
+	scheduleClient    arkv1client.SchedulesGetter
+}
+
+// NewProtectedApplicationController constructs a new protectedApplicationController.
+func NewProtectedApplicationController(
+	applicationInformer informers.ProtectedApplicationInformer,
+	applicationClient arkv1client.ProtectedApplicationsGetter,
+	scheduleInformer informers.ScheduleInformer,
+	scheduleClient arkv1client.SchedulesGetter,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &protectedApplicationController{
+		genericController: newGenericController("protected-application", logger),
+		applicationLister: applicationInformer.Lister(),
+		applicationClient: applicationClient,
+		scheduleLister:    scheduleInformer.Lister(),
+		scheduleClient:    scheduleClient,
+	}
+
+	c.syncHandler = c.processApplication
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, applicationInformer.Informer().HasSynced, scheduleInformer.Informer().HasSynced)
+
+	enqueueApplication := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			c.logger.WithError(errors.WithStack(err)).Error("Error creating queue key, item not added to queue")
+			return
+		}
+		c.queue.Add(key)
+	}
+
+	applicationInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueueApplication,
+			UpdateFunc: func(_, obj interface{}) { enqueueApplication(obj) },
+			DeleteFunc: enqueueApplication,
+		},
+	)
+
+	// schedules that are changed or deleted by anything else are put back in
+	// line with their application, and their applications' statuses follow
+	// their last backups
+	enqueueScheduleApplication := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		schedule, ok := obj.(*api.Schedule)
+		if !ok {
+			return
+		}
+		if name := schedule.Labels[api.ProtectedApplicationLabel]; name != "" {
+			c.queue.Add(schedule.Namespace + "/" + name)
+		}
+	}
+
+	scheduleInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, obj interface{}) { enqueueScheduleApplication(obj) },
+			DeleteFunc: enqueueScheduleApplication,
+		},
+	)
+
+	return c
+}
+
+func (c *protectedApplicationController) processApplication(key string) error {
+	log := c.logger.WithField("key", key)
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	application, err := c.applicationLister.ProtectedApplications(ns).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting protected application")
+	}
+
+	schedule, err := c.scheduleLister.Schedules(ns).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting schedule")
+	}
+	if schedule != nil && schedule.Labels[api.ProtectedApplicationLabel] != name {
+		schedule = nil
+		if application != nil {
+			return c.updateStatus(application, nil, []string{fmt.Sprintf("A schedule named %s that wasn't created for the application already exists", name)})
+		}
+	}
+
+	if application == nil {
+		if schedule == nil {
+			return nil
+		}
+
+		log.Info("Deleting schedule because protected application was deleted")
+		return deleteSchedule(c.scheduleClient, schedule)
+	}
+
+	desired := applicationSchedule(application)
+
+	_, validationErrors := parseCronSchedule(desired, log)
+	validationErrors = append(validationErrors, validateApplication(application)...)
+	if len(validationErrors) > 0 {
+		if schedule != nil {
+			log.Info("Deleting schedule because protected application failed validation")
+			if err := deleteSchedule(c.scheduleClient, schedule); err != nil {
+				return err
+			}
+		}
+		return c.updateStatus(application, nil, validationErrors)
+	}
+
+	if schedule, err = syncSchedule(c.scheduleClient, schedule, desired, log); err != nil {
+		return err
+	}
+
+	return c.updateStatus(application, schedule, nil)
+}
+
+// updateStatus updates the application's status with its validation errors, if any, and
+// otherwise from its schedule.
+func (c *protectedApplicationController) updateStatus(application *api.ProtectedApplication, schedule *api.Schedule, validationErrors []string) error {
+	updated := application.DeepCopy()
+	updated.Status = api.ProtectedApplicationStatus{
+		Phase:            api.ProtectedApplicationPhaseEnabled,
+		ValidationErrors: validationErrors,
+	}
+	if len(validationErrors) > 0 {
+		updated.Status.Phase = api.ProtectedApplicationPhaseFailedValidation
+	}
+	if schedule != nil {
+		updated.Status.ScheduleName = schedule.Name
+		updated.Status.LastBackupName = schedule.Status.LastBackupName
+		updated.Status.LastBackupPhase = schedule.Status.LastBackupPhase
+	}
+
+	if equality.Semantic.DeepEqual(application.Status, updated.Status) {
+		return nil
+	}
+
+	_, err := patchProtectedApplication(application, updated, c.applicationClient)
+	return err
+}
+
+// validateApplication returns the errors in the application's spec that the schedule
+// controller doesn't validate.
+func validateApplication(application *api.ProtectedApplication) []string {
+	var errs []string
+
+	if len(application.Spec.IncludedNamespaces) == 0 {
+		errs = append(errs, "At least one namespace must be included in the application")
+	}
+	for _, err := range collections.ValidateIncludesExcludes(application.Spec.IncludedNamespaces, nil) {
+		errs = append(errs, fmt.Sprintf("Invalid included namespaces: %v", err))
+	}
+
+	for _, pvc := range application.Spec.PersistentVolumeClaims {
+		if parts := strings.Split(pvc, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			errs = append(errs, fmt.Sprintf("Invalid persistent volume claim %q, must be namespace/name", pvc))
+		}
+	}
+
+	return errs
+}
+
+// applicationSchedule returns the schedule that backs up the application.
+func applicationSchedule(application *api.ProtectedApplication) *api.Schedule {
+	spec := application.Spec
+
+	namespaces := sets.NewString(spec.IncludedNamespaces...)
+	var additionalItems []string
+	for _, pvc := range spec.PersistentVolumeClaims {
+		parts := strings.Split(pvc, "/")
+		if len(parts) != 2 {
+			// the application's persistent volume claims have been validated
+			continue
+		}
+		if !namespaces.Has("*") {
+			namespaces.Insert(parts[0])
+		}
+		additionalItems = append(additionalItems, kuberesource.PersistentVolumeClaims.Resource+"/"+pvc)
+	}
+	sort.Strings(additionalItems)
+
+	return &api.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: application.Namespace,
+			Name:      application.Name,
+			Labels: map[string]string{
+				api.ProtectedApplicationLabel: application.Name,
+			},
+		},
+		Spec: api.ScheduleSpec{
+			Schedule: strings.TrimSpace(spec.Schedule),
+			Template: api.BackupSpec{
+				IncludedNamespaces:      namespaces.List(),
+				LabelSelector:           spec.LabelSelector,
+				AdditionalItems:         additionalItems,
+				Hooks:                   spec.Hooks,
+				TTL:                     spec.TTL,
+				SnapshotVolumes:         spec.SnapshotVolumes,
+				StorageLocation:         spec.StorageLocation,
+				VolumeSnapshotLocations: spec.VolumeSnapshotLocations,
+			},
+		},
+	}
+}
+
+func patchProtectedApplication(original, updated *api.ProtectedApplication, client arkv1client.ProtectedApplicationsGetter) (*api.ProtectedApplication, error) {
+	origBytes, err := json.Marshal(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling original protected application")
+	}
+
+	updatedBytes, err := json.Marshal(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling updated protected application")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(origBytes, updatedBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating json merge patch for protected application")
+	}
+
+	res, err := client.ProtectedApplications(original.Namespace).Patch(original.Name, types.MergePatchType, patchBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error patching protected application")
+	}
+
+	return res, nil
+}
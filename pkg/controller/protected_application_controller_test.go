@@ -0,0 +1,197 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessApplication(t *testing.T) {
+	newApplication := func(cronExpression string, pvcs ...string) *api.ProtectedApplication {
+		return &api.ProtectedApplication{
+			ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: "app-1"},
+			Spec: api.ProtectedApplicationSpec{
+				IncludedNamespaces:     []string{"ns-1"},
+				LabelSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app-1"}},
+				PersistentVolumeClaims: pvcs,
+				Schedule:               cronExpression,
+			},
+		}
+	}
+
+	newSchedule := func(cronExpression string, phase api.SchedulePhase) *api.Schedule {
+		schedule := applicationSchedule(newApplication(cronExpression))
+		schedule.Status.Phase = phase
+		schedule.Status.LastBackupName = "app-1-20180901020000"
+		schedule.Status.LastBackupPhase = api.BackupPhaseCompleted
+		return schedule
+	}
+
+	unmanaged := arktest.NewTestSchedule(api.DefaultNamespace, "app-1").WithCronSchedule("@every 1h").Schedule
+
+	tests := []struct {
+		name                string
+		application         *api.ProtectedApplication
+		schedule            *api.Schedule
+		expectedActions     []string
+		expectedSchedule    string
+		expectedPhase       api.ProtectedApplicationPhase
+		expectedErrs        []string
+		expectedLastBackup  string
+		expectedNamespaces  []string
+		expectedAdditionals []string
+	}{
+		{
+			name:                "application without a schedule creates one",
+			application:         newApplication("0 2 * * *", "ns-2/data", "ns-1/cache"),
+			expectedActions:     []string{"create", "patch"},
+			expectedSchedule:    "0 2 * * *",
+			expectedPhase:       api.ProtectedApplicationPhaseEnabled,
+			expectedNamespaces:  []string{"ns-1", "ns-2"},
+			expectedAdditionals: []string{"persistentvolumeclaims/ns-1/cache", "persistentvolumeclaims/ns-2/data"},
+		},
+		{
+			name:               "schedule matching the application isn't changed, and its last backup is recorded",
+			application:        newApplication("0 2 * * *"),
+			schedule:           newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions:    []string{"patch"},
+			expectedPhase:      api.ProtectedApplicationPhaseEnabled,
+			expectedLastBackup: "app-1-20180901020000",
+		},
+		{
+			name:               "changed application updates the schedule",
+			application:        newApplication("0 3 * * *"),
+			schedule:           newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions:    []string{"patch", "patch"},
+			expectedSchedule:   "0 3 * * *",
+			expectedPhase:      api.ProtectedApplicationPhaseEnabled,
+			expectedLastBackup: "app-1-20180901020000",
+		},
+		{
+			name:             "changed application recreates a schedule that failed validation",
+			application:      newApplication("0 3 * * *"),
+			schedule:         newSchedule("not a schedule", api.SchedulePhaseFailedValidation),
+			expectedActions:  []string{"delete", "create", "patch"},
+			expectedSchedule: "0 3 * * *",
+			expectedPhase:    api.ProtectedApplicationPhaseEnabled,
+		},
+		{
+			name:            "invalid application deletes the schedule",
+			application:     newApplication("0 2 * * *", "data"),
+			schedule:        newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions: []string{"delete", "patch"},
+			expectedPhase:   api.ProtectedApplicationPhaseFailedValidation,
+			expectedErrs:    []string{`Invalid persistent volume claim "data", must be namespace/name`},
+		},
+		{
+			name:            "deleted application deletes the schedule",
+			schedule:        newSchedule("0 2 * * *", api.SchedulePhaseEnabled),
+			expectedActions: []string{"delete"},
+		},
+		{
+			name:            "schedule not created for the application fails validation",
+			application:     newApplication("0 2 * * *"),
+			schedule:        unmanaged,
+			expectedActions: []string{"patch"},
+			expectedPhase:   api.ProtectedApplicationPhaseFailedValidation,
+			expectedErrs:    []string{"A schedule named app-1 that wasn't created for the application already exists"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				objects         []runtime.Object
+			)
+
+			if test.application != nil {
+				require.NoError(t, sharedInformers.Ark().V1().ProtectedApplications().Informer().GetStore().Add(test.application))
+				objects = append(objects, test.application)
+			}
+			if test.schedule != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Schedules().Informer().GetStore().Add(test.schedule))
+				objects = append(objects, test.schedule)
+			}
+			client = fake.NewSimpleClientset(objects...)
+
+			c := NewProtectedApplicationController(
+				sharedInformers.Ark().V1().ProtectedApplications(),
+				client.ArkV1(),
+				sharedInformers.Ark().V1().Schedules(),
+				client.ArkV1(),
+				arktest.NewLogger(),
+			).(*protectedApplicationController)
+
+			require.NoError(t, c.processApplication(api.DefaultNamespace+"/app-1"))
+
+			var actions []string
+			for _, action := range client.Actions() {
+				actions = append(actions, action.GetVerb())
+			}
+			assert.Equal(t, test.expectedActions, actions)
+
+			if test.expectedSchedule != "" {
+				switch action := client.Actions()[len(client.Actions())-2].(type) {
+				case core.CreateAction:
+					schedule := action.GetObject().(*api.Schedule)
+					assert.Equal(t, api.DefaultNamespace, schedule.Namespace)
+					assert.Equal(t, "app-1", schedule.Name)
+					assert.Equal(t, "app-1", schedule.Labels[api.ProtectedApplicationLabel])
+					assert.Equal(t, test.expectedSchedule, schedule.Spec.Schedule)
+					if test.expectedNamespaces != nil {
+						assert.Equal(t, test.expectedNamespaces, schedule.Spec.Template.IncludedNamespaces)
+						assert.Equal(t, test.expectedAdditionals, schedule.Spec.Template.AdditionalItems)
+					}
+				case core.PatchAction:
+					schedule := new(api.Schedule)
+					require.NoError(t, json.Unmarshal(action.GetPatch(), schedule))
+					assert.Equal(t, test.expectedSchedule, schedule.Spec.Schedule)
+				default:
+					t.Fatalf("unexpected action %v", action)
+				}
+			}
+
+			if test.expectedPhase == "" {
+				return
+			}
+
+			patch, ok := client.Actions()[len(client.Actions())-1].(core.PatchAction)
+			require.True(t, ok)
+			assert.Equal(t, "protectedapplications", patch.GetResource().Resource)
+
+			application := new(api.ProtectedApplication)
+			require.NoError(t, json.Unmarshal(patch.GetPatch(), application))
+			assert.Equal(t, test.expectedPhase, application.Status.Phase)
+			assert.Equal(t, test.expectedErrs, application.Status.ValidationErrors)
+			assert.Equal(t, test.expectedLastBackup, application.Status.LastBackupName)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	appsv1api "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/restic"
+)
+
+type resticDaemonSetConfigController struct {
+	*genericController
+
+	namespace       string
+	configLister    listers.ResticDaemonSetConfigLister
+	daemonSetLister appsv1listers.DaemonSetLister
+	daemonSetClient appsv1client.DaemonSetsGetter
+}
+
+// NewResticDaemonSetConfigController returns a controller that keeps the restic
+// daemonset's node selector, tolerations and container resources in line with the
+// default ResticDaemonSetConfig. If there's no ResticDaemonSetConfig, the daemonset
+// is left alone.
+func NewResticDaemonSetConfigController(
+	namespace string,
+	configInformer informers.ResticDaemonSetConfigInformer,
+	daemonSetInformer cache.SharedIndexInformer,
+	daemonSetClient appsv1client.DaemonSetsGetter,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &resticDaemonSetConfigController{
+		genericController: newGenericController("restic-daemonset-config", logger),
+		namespace:         namespace,
+		configLister:      configInformer.Lister(),
+		daemonSetLister:   appsv1listers.NewDaemonSetLister(daemonSetInformer.GetIndexer()),
+		daemonSetClient:   daemonSetClient,
+	}
+
+	c.syncHandler = c.processDaemonSet
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, configInformer.Informer().HasSynced, daemonSetInformer.HasSynced)
+
+	// any change to either the config or the daemonset means the daemonset
+	// may need to be updated, so always enqueue the daemonset's key.
+	enqueueDaemonSet := func(_ interface{}) {
+		c.queue.Add(c.namespace + "/" + restic.DaemonSet)
+	}
+
+	configInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueueDaemonSet,
+			UpdateFunc: func(_, obj interface{}) { enqueueDaemonSet(obj) },
+		},
+	)
+
+	daemonSetInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueueDaemonSet,
+			UpdateFunc: func(_, obj interface{}) { enqueueDaemonSet(obj) },
+		},
+	)
+
+	return c
+}
+
+func (c *resticDaemonSetConfigController) processDaemonSet(key string) error {
+	log := c.logger.WithField("key", key)
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	config, err := c.configLister.ResticDaemonSetConfigs(ns).Get(v1.DefaultResticDaemonSetConfigName)
+	if apierrors.IsNotFound(err) {
+		log.Debug("No ResticDaemonSetConfig found, leaving restic daemonset unchanged")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting ResticDaemonSetConfig")
+	}
+
+	daemonSet, err := c.daemonSetLister.DaemonSets(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Restic daemonset not found")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting restic daemonset")
+	}
+
+	updated := daemonSet.DeepCopy()
+	applyResticDaemonSetConfig(updated, config)
+
+	if equality.Semantic.DeepEqual(daemonSet.Spec.Template.Spec, updated.Spec.Template.Spec) {
+		return nil
+	}
+
+	log.Info("Updating restic daemonset to match ResticDaemonSetConfig")
+
+	return c.patchDaemonSet(daemonSet, updated)
+}
+
+// applyResticDaemonSetConfig sets the daemonset's pod node selector, tolerations
+// and container resources to the ones in config.
+func applyResticDaemonSetConfig(daemonSet *appsv1api.DaemonSet, config *v1.ResticDaemonSetConfig) {
+	podSpec := &daemonSet.Spec.Template.Spec
+
+	podSpec.NodeSelector = config.Spec.NodeSelector
+	podSpec.Tolerations = config.Spec.Tolerations
+
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Resources = config.Spec.Resources
+	}
+}
+
+func (c *resticDaemonSetConfigController) patchDaemonSet(original, updated *appsv1api.DaemonSet) error {
+	origBytes, err := json.Marshal(original)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling original daemonset")
+	}
+
+	updatedBytes, err := json.Marshal(updated)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling updated daemonset")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(origBytes, updatedBytes)
+	if err != nil {
+		return errors.Wrap(err, "error creating json merge patch for daemonset")
+	}
+
+	if _, err := c.daemonSetClient.DaemonSets(original.Namespace).Patch(original.Name, types.MergePatchType, patchBytes); err != nil {
+		return errors.Wrap(err, "error patching daemonset")
+	}
+
+	return nil
+}
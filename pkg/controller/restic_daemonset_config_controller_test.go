@@ -0,0 +1,140 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1api "k8s.io/api/apps/v1"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func newResticDaemonSet(podSpec corev1api.PodSpec) *appsv1api.DaemonSet {
+	return &appsv1api.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "heptio-ark",
+			Name:      "restic",
+		},
+		Spec: appsv1api.DaemonSetSpec{
+			Template: corev1api.PodTemplateSpec{
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func TestApplyResticDaemonSetConfig(t *testing.T) {
+	daemonSet := newResticDaemonSet(corev1api.PodSpec{
+		NodeSelector: map[string]string{"old": "selector"},
+		Containers: []corev1api.Container{
+			{Name: "restic", Image: "gcr.io/heptio-images/ark:latest"},
+		},
+	})
+
+	config := &v1.ResticDaemonSetConfig{
+		Spec: v1.ResticDaemonSetConfigSpec{
+			NodeSelector: map[string]string{"foo": "bar"},
+			Tolerations: []corev1api.Toleration{
+				{Key: "dedicated", Operator: corev1api.TolerationOpEqual, Value: "storage", Effect: corev1api.TaintEffectNoSchedule},
+			},
+			Resources: corev1api.ResourceRequirements{
+				Limits: corev1api.ResourceList{
+					corev1api.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+	}
+
+	applyResticDaemonSetConfig(daemonSet, config)
+
+	podSpec := daemonSet.Spec.Template.Spec
+	assert.Equal(t, config.Spec.NodeSelector, podSpec.NodeSelector)
+	assert.Equal(t, config.Spec.Tolerations, podSpec.Tolerations)
+	require.Len(t, podSpec.Containers, 1)
+	assert.Equal(t, config.Spec.Resources, podSpec.Containers[0].Resources)
+	assert.Equal(t, "gcr.io/heptio-images/ark:latest", podSpec.Containers[0].Image)
+}
+
+func TestProcessResticDaemonSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *v1.ResticDaemonSetConfig
+		daemonSet *appsv1api.DaemonSet
+	}{
+		{
+			name:      "no config leaves the daemonset unchanged",
+			daemonSet: newResticDaemonSet(corev1api.PodSpec{NodeSelector: map[string]string{"foo": "bar"}}),
+		},
+		{
+			name: "no daemonset is not an error",
+			config: &v1.ResticDaemonSetConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "heptio-ark", Name: v1.DefaultResticDaemonSetConfigName},
+			},
+		},
+		{
+			name: "daemonset that matches the config isn't patched",
+			config: &v1.ResticDaemonSetConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "heptio-ark", Name: v1.DefaultResticDaemonSetConfigName},
+				Spec: v1.ResticDaemonSetConfigSpec{
+					NodeSelector: map[string]string{"foo": "bar"},
+				},
+			},
+			daemonSet: newResticDaemonSet(corev1api.PodSpec{
+				NodeSelector: map[string]string{"foo": "bar"},
+				Containers:   []corev1api.Container{{Name: "restic"}},
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client            = fake.NewSimpleClientset()
+				sharedInformers   = informers.NewSharedInformerFactory(client, 0)
+				daemonSetInformer = cache.NewSharedIndexInformer(nil, new(appsv1api.DaemonSet), 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			)
+
+			// a nil daemonset client makes the test fail if a patch is attempted
+			c := NewResticDaemonSetConfigController(
+				"heptio-ark",
+				sharedInformers.Ark().V1().ResticDaemonSetConfigs(),
+				daemonSetInformer,
+				nil,
+				arktest.NewLogger(),
+			).(*resticDaemonSetConfigController)
+
+			if test.config != nil {
+				require.NoError(t, sharedInformers.Ark().V1().ResticDaemonSetConfigs().Informer().GetStore().Add(test.config))
+			}
+			if test.daemonSet != nil {
+				require.NoError(t, daemonSetInformer.GetStore().Add(test.daemonSet))
+			}
+
+			assert.NoError(t, c.processDaemonSet("heptio-ark/restic"))
+		})
+	}
+}
@@ -18,35 +18,50 @@ package controller
 
 import (
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"sort"
+	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/buildinfo"
 	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
 	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
 	"github.com/heptio/ark/pkg/metrics"
 	"github.com/heptio/ark/pkg/persistence"
 	"github.com/heptio/ark/pkg/plugin"
-	"github.com/heptio/ark/pkg/restore"
+	pkgrestore "github.com/heptio/ark/pkg/restore"
 	"github.com/heptio/ark/pkg/util/collections"
+	"github.com/heptio/ark/pkg/util/filesystem"
 	kubeutil "github.com/heptio/ark/pkg/util/kube"
 	"github.com/heptio/ark/pkg/util/logging"
+	"github.com/heptio/ark/pkg/volume"
 )
 
+// restoreProgressInterval is how often the status of a restore that's in progress is
+// updated with the item it's working on.
+const restoreProgressInterval = 10 * time.Second
+
 // nonRestorableResources is a blacklist for the restoration process. Any resources
 // included here are explicitly excluded from the restoration process.
 var nonRestorableResources = []string{
@@ -61,6 +76,10 @@ var nonRestorableResources = []string{
 	// Restores are cluster-specific, and don't have value moving across clusters.
 	// https://github.com/heptio/ark/issues/622
 	"restores.ark.heptio.com",
+
+	// VolumeSnapshots are records of a backup's snapshots, which are synced into
+	// the cluster along with the backup.
+	"volumesnapshots.ark.heptio.com",
 }
 
 type restoreController struct {
@@ -69,17 +88,27 @@ type restoreController struct {
 	namespace              string
 	restoreClient          arkv1client.RestoresGetter
 	backupClient           arkv1client.BackupsGetter
-	restorer               restore.Restorer
+	restorer               pkgrestore.Restorer
 	backupLister           listers.BackupLister
 	restoreLister          listers.RestoreLister
 	backupLocationLister   listers.BackupStorageLocationLister
 	snapshotLocationLister listers.VolumeSnapshotLocationLister
+	volumeSnapshotLister   listers.VolumeSnapshotLister
+	restorePriorityLister  listers.RestorePriorityLister
 	restoreLogLevel        logrus.Level
+	restoreTracker         RestoreTracker
+	restoreLocker          *restoreLocker
 	defaultBackupLocation  string
+	clusterUID             string
 	metrics                *metrics.ServerMetrics
+	clock                  clock.Clock
+	scratchDir             string
+	backupCache            *backupCache
+	progressInterval       time.Duration
 
 	newPluginManager func(logger logrus.FieldLogger) plugin.Manager
 	newBackupStore   func(*api.BackupStorageLocation, persistence.ObjectStoreGetter, logrus.FieldLogger) (persistence.BackupStore, error)
+	freeSpace        func(path string) (int64, error)
 }
 
 type restoreResult struct {
@@ -91,14 +120,21 @@ func NewRestoreController(
 	restoreInformer informers.RestoreInformer,
 	restoreClient arkv1client.RestoresGetter,
 	backupClient arkv1client.BackupsGetter,
-	restorer restore.Restorer,
+	restorer pkgrestore.Restorer,
 	backupInformer informers.BackupInformer,
 	backupLocationInformer informers.BackupStorageLocationInformer,
 	snapshotLocationInformer informers.VolumeSnapshotLocationInformer,
+	volumeSnapshotInformer informers.VolumeSnapshotInformer,
+	restorePriorityInformer informers.RestorePriorityInformer,
 	logger logrus.FieldLogger,
 	restoreLogLevel logrus.Level,
 	newPluginManager func(logrus.FieldLogger) plugin.Manager,
+	restoreTracker RestoreTracker,
+	configMapClient corev1client.ConfigMapsGetter,
 	defaultBackupLocation string,
+	clusterUID string,
+	scratchDir string,
+	backupCacheSize int64,
 	metrics *metrics.ServerMetrics,
 ) Interface {
 	c := &restoreController{
@@ -111,14 +147,24 @@ func NewRestoreController(
 		restoreLister:          restoreInformer.Lister(),
 		backupLocationLister:   backupLocationInformer.Lister(),
 		snapshotLocationLister: snapshotLocationInformer.Lister(),
+		volumeSnapshotLister:   volumeSnapshotInformer.Lister(),
+		restorePriorityLister:  restorePriorityInformer.Lister(),
 		restoreLogLevel:        restoreLogLevel,
+		restoreTracker:         restoreTracker,
+		restoreLocker:          newRestoreLocker(namespace, configMapClient, restoreInformer.Lister()),
 		defaultBackupLocation:  defaultBackupLocation,
+		clusterUID:             clusterUID,
 		metrics:                metrics,
+		clock:                  &clock.RealClock{},
+		scratchDir:             scratchDir,
+		backupCache:            newBackupCache(backupCacheDir(scratchDir), backupCacheSize),
+		progressInterval:       restoreProgressInterval,
 
 		// use variables to refer to these functions so they can be
 		// replaced with fakes for testing.
 		newPluginManager: newPluginManager,
 		newBackupStore:   persistence.NewObjectBackupStore,
+		freeSpace:        filesystem.FreeSpace,
 	}
 
 	c.syncHandler = c.processRestore
@@ -127,6 +173,8 @@ func NewRestoreController(
 		restoreInformer.Informer().HasSynced,
 		backupLocationInformer.Informer().HasSynced,
 		snapshotLocationInformer.Informer().HasSynced,
+		volumeSnapshotInformer.Informer().HasSynced,
+		restorePriorityInformer.Informer().HasSynced,
 	)
 
 	restoreInformer.Informer().AddEventHandler(
@@ -175,13 +223,11 @@ func (c *restoreController) processRestore(key string) error {
 		return errors.Wrap(err, "error getting Restore")
 	}
 
-	// TODO I think this is now unnecessary. We only initially place
-	// item with Phase = ("" | New) into the queue. Items will only get
-	// re-queued if syncHandler returns an error, which will only
-	// happen if there's an error updating Phase from its initial
-	// state to something else. So any time it's re-queued it will
-	// still have its initial state, which we've already confirmed
-	// is ("" | New)
+	// Restores are only queued with Phase = ("" | New), but they can be
+	// processed again after their phase has changed: they're re-queued if
+	// syncHandler returns an error, and restores waiting for a namespace lock
+	// are re-queued with AddAfter, by which time another server may have
+	// processed them. So check the phase again.
 	switch restore.Status.Phase {
 	case "", api.RestorePhaseNew:
 		// only process new restores
@@ -205,15 +251,52 @@ func (c *restoreController) processRestore(key string) error {
 
 	// validate the restore and fetch the backup
 	info := c.validateAndComplete(restore, pluginManager)
+
+	// lock the namespaces the restore restores into, so that no other restore
+	// restores into them until this one is done
+	if len(restore.Status.ValidationErrors) == 0 {
+		conflict, err := c.restoreLocker.acquire(restore, restoreTargetNamespaces(restore, info.backup))
+		if err != nil {
+			return errors.Wrap(err, "error acquiring restore namespace locks")
+		}
+
+		switch {
+		case conflict == nil:
+			// the locks are renewed while the restore runs, so that they're
+			// released as stale if this server stops processing it
+			stopRenewing := c.restoreLocker.renewUntilStopped(restore, log)
+			defer func() {
+				stopRenewing()
+				if err := c.restoreLocker.release(restore); err != nil {
+					log.WithError(err).Error("Error releasing restore namespace locks")
+				}
+			}()
+		case restore.Spec.WaitForNamespaceLock:
+			log.Infof("%s, queueing restore", conflict)
+			c.queue.AddAfter(key, restoreLockRetryPeriod)
+			return nil
+		default:
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors,
+				fmt.Sprintf("%s, wait for it to finish or create the restore with --wait-for-namespace-lock", conflict))
+		}
+	}
 	backupScheduleName := restore.Spec.ScheduleName
 	// Register attempts after validation so we don't have to fetch the backup multiple times
 	c.metrics.RegisterRestoreAttempt(backupScheduleName)
 
 	if len(restore.Status.ValidationErrors) > 0 {
 		restore.Status.Phase = api.RestorePhaseFailedValidation
+		// A restore that fails validation has no logs or results uploaded, and its backup
+		// may not exist, so there's nowhere to put the complete list. It's logged instead.
+		var truncated bool
+		allErrors := restore.Status.ValidationErrors
+		if restore.Status.ValidationErrors, truncated = truncateStatusList(allErrors); truncated {
+			log.WithField("validationErrors", allErrors).Info("Restore failed validation with more errors than are kept in its status")
+		}
 		c.metrics.RegisterRestoreValidationFailed(backupScheduleName)
 	} else {
 		restore.Status.Phase = api.RestorePhaseInProgress
+		restore.Status.StartTimestamp.Time = c.clock.Now()
 	}
 
 	// patch to update status and persist to API
@@ -229,25 +312,43 @@ func (c *restoreController) processRestore(key string) error {
 		return nil
 	}
 
+	c.restoreTracker.Add(restore.Namespace, restore.Name)
+	defer c.restoreTracker.Delete(restore.Namespace, restore.Name)
+
 	log.Debug("Running restore")
 
+	progress := pkgrestore.NewProgress()
+	stopProgress := c.reportRestoreProgress(original, progress.Current, log)
+
 	// execution & upload of restore
 	restoreRes, restoreFailure := c.runRestore(
 		restore,
 		actions,
 		info,
 		pluginManager,
+		progress,
 	)
 
+	// the final status is patched from the last progress report, so that the
+	// progress is cleared once the restore finishes
+	original = stopProgress()
+	restore.Status.ResourceTimings = progress.ResourceTimings()
+
 	restore.Status.Warnings = len(restoreRes.warnings.Ark) + len(restoreRes.warnings.Cluster)
 	for _, w := range restoreRes.warnings.Namespaces {
 		restore.Status.Warnings += len(w)
 	}
+	for _, s := range restoreRes.warnings.Summaries {
+		restore.Status.Warnings += s.Count
+	}
 
 	restore.Status.Errors = len(restoreRes.errors.Ark) + len(restoreRes.errors.Cluster)
 	for _, e := range restoreRes.errors.Namespaces {
 		restore.Status.Errors += len(e)
 	}
+	for _, s := range restoreRes.errors.Summaries {
+		restore.Status.Errors += s.Count
+	}
 
 	if restoreFailure != nil {
 		log.Debug("restore failed")
@@ -274,6 +375,80 @@ type backupInfo struct {
 	backupStore persistence.BackupStore
 }
 
+// excludeArkNamespace adds the Ark server's namespace, arkNamespace, to the
+// namespaces excluded from restore if restore includes all namespaces, so
+// that the server's own live configuration, such as its backup storage
+// locations and credentials, isn't overwritten. It returns a validation error
+// if restore explicitly includes or maps a namespace into arkNamespace.
+func excludeArkNamespace(restore *api.Restore, arkNamespace string) []string {
+	var errs []string
+
+	for source, target := range restore.Spec.NamespaceMapping {
+		if target == arkNamespace {
+			errs = append(errs, fmt.Sprintf("Namespace %s is mapped to the Ark server's namespace %s, which requires --include-ark-resources", source, arkNamespace))
+		}
+	}
+	sort.Strings(errs)
+
+	// a namespace that's mapped elsewhere isn't restored into arkNamespace
+	if _, ok := restore.Spec.NamespaceMapping[arkNamespace]; ok {
+		return errs
+	}
+
+	included := sets.NewString(restore.Spec.IncludedNamespaces...)
+	switch {
+	case included.Has(arkNamespace):
+		errs = append(errs, fmt.Sprintf("Namespace %s is the Ark server's namespace, which requires --include-ark-resources", arkNamespace))
+	case included.Len() == 0 || included.Has("*"):
+		if !sets.NewString(restore.Spec.ExcludedNamespaces...).Has(arkNamespace) {
+			restore.Spec.ExcludedNamespaces = append(restore.Spec.ExcludedNamespaces, arkNamespace)
+		}
+	}
+
+	return errs
+}
+
+// validateHostnameRewrites returns a validation error for each invalid rewrite.
+func validateHostnameRewrites(rewrites []api.HostnameRewrite) []string {
+	var errs []string
+	for _, rewrite := range rewrites {
+		if err := pkgrestore.ValidateHostnameRewrite(rewrite); err != nil {
+			errs = append(errs, fmt.Sprintf("Invalid hostname rewrite: %v", err))
+		}
+	}
+	return errs
+}
+
+// validateAdditionalMetadata returns a validation error for each invalid additional
+// label or annotation. Values that are templates are validated once they're expanded.
+func validateAdditionalMetadata(restore *api.Restore) []string {
+	var errs []string
+
+	for _, key := range sortedKeys(restore.Spec.AdditionalLabels) {
+		switch key {
+		case api.BackupNameLabel, api.RestoreNameLabel, api.RestoreLabelKey:
+			errs = append(errs, fmt.Sprintf("Invalid additional label %s, it's set by Ark", key))
+			continue
+		}
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("Invalid additional label key %q: %s", key, strings.Join(msgs, "; ")))
+		}
+		if val := restore.Spec.AdditionalLabels[key]; !isTemplate(val) {
+			if msgs := validation.IsValidLabelValue(val); len(msgs) > 0 {
+				errs = append(errs, fmt.Sprintf("Invalid additional label %s value %q: %s", key, val, strings.Join(msgs, "; ")))
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(restore.Spec.AdditionalAnnotations) {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("Invalid additional annotation key %q: %s", key, strings.Join(msgs, "; ")))
+		}
+	}
+
+	return errs
+}
+
 func (c *restoreController) validateAndComplete(restore *api.Restore, pluginManager plugin.Manager) backupInfo {
 	// add non-restorable resources to restore's excluded resources
 	excludedResources := sets.NewString(restore.Spec.ExcludedResources...)
@@ -301,32 +476,146 @@ func (c *restoreController) validateAndComplete(restore *api.Restore, pluginMana
 		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid included/excluded namespace lists: %v", err))
 	}
 
-	// validate that exactly one of BackupName and ScheduleName have been specified
+	// validate the namespace order
+	orderedNamespaces := sets.NewString()
+	for _, ns := range restore.Spec.NamespaceOrder {
+		switch {
+		case ns == "" || ns == "*":
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid namespace order entry %q, must be a namespace name", ns))
+		case orderedNamespaces.Has(ns):
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid namespace order, namespace %s is listed more than once", ns))
+		}
+		orderedNamespaces.Insert(ns)
+	}
+
+	// validate the volume ID mapping
+	for _, pvName := range sets.StringKeySet(restore.Spec.VolumeIDMapping).List() {
+		if pvName == "" || restore.Spec.VolumeIDMapping[pvName] == "" {
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid volume ID mapping %q: %q, PV names and volume IDs must not be empty", pvName, restore.Spec.VolumeIDMapping[pvName]))
+		}
+	}
+
+	// validate the volume overrides
+	restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, validateVolumeOverrides(restore.Spec.VolumeOverrides)...)
+
+	// validate the hostname rewrites
+	restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, validateHostnameRewrites(restore.Spec.HostnameRewrites)...)
+
+	// validate the additional labels and annotations
+	restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, validateAdditionalMetadata(restore)...)
+
+	// validate the strip annotations
+	for _, entry := range restore.Spec.StripAnnotations {
+		if entry == "" || strings.Contains(strings.TrimSuffix(entry, "*"), "*") {
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid strip annotations entry %q, must be an annotation key, a key prefix ending in *, or default", entry))
+		}
+	}
+
+	// validate the security policy translation
+	switch restore.Spec.SecurityPolicyTranslation {
+	case "", api.RestoreSecurityPolicyTranslationPSPToSCC, api.RestoreSecurityPolicyTranslationSCCToPSP:
+		// valid translation
+	default:
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid security policy translation %s, must be %s or %s", restore.Spec.SecurityPolicyTranslation, api.RestoreSecurityPolicyTranslationPSPToSCC, api.RestoreSecurityPolicyTranslationSCCToPSP))
+	}
+	if len(restore.Spec.SecurityPolicyMapping) > 0 && restore.Spec.SecurityPolicyTranslation == "" {
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "Security policy mapping can only be used with a security policy translation")
+	}
+
+	// validate the iteration mode
+	switch restore.Spec.IterationMode {
+	case "", api.RestoreIterationModeByResource, api.RestoreIterationModeByNamespace:
+		// valid mode
+	default:
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid iteration mode %s, must be %s or %s", restore.Spec.IterationMode, api.RestoreIterationModeByResource, api.RestoreIterationModeByNamespace))
+	}
+
+	// validate the conflict policy
+	switch restore.Spec.ConflictPolicy {
+	case "", api.RestoreConflictPolicySkip, api.RestoreConflictPolicyThreeWayMerge, api.RestoreConflictPolicyRecreate:
+		// valid policy
+	default:
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid conflict policy %s, must be %s, %s or %s", restore.Spec.ConflictPolicy, api.RestoreConflictPolicySkip, api.RestoreConflictPolicyThreeWayMerge, api.RestoreConflictPolicyRecreate))
+	}
+	restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, validateRecreateResources(restore)...)
+
+	if restore.Spec.ResourceTimeout != nil && restore.Spec.ResourceTimeout.Duration <= 0 {
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid resource timeout %s, must be positive", restore.Spec.ResourceTimeout.Duration))
+	}
+
+	// validate the namespace creation policy
+	switch restore.Spec.CreateNamespaces {
+	case "", api.RestoreNamespaceCreationPolicyAlways, api.RestoreNamespaceCreationPolicyNever, api.RestoreNamespaceCreationPolicyIfMappedOnly:
+		// valid policy
+	default:
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid namespace creation policy %s, must be %s, %s or %s", restore.Spec.CreateNamespaces, api.RestoreNamespaceCreationPolicyAlways, api.RestoreNamespaceCreationPolicyNever, api.RestoreNamespaceCreationPolicyIfMappedOnly))
+	}
+
+	if restore.Spec.ClientQPS < 0 {
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid client QPS %d, must not be negative", restore.Spec.ClientQPS))
+	}
+	if restore.Spec.ClientBurst < 0 {
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Invalid client burst %d, must not be negative", restore.Spec.ClientBurst))
+	}
+
+	// validate that the restore priority exists, if one was specified
+	if name := restore.Spec.RestorePriorityName; name != "" {
+		if _, err := c.restorePriorityLister.RestorePriorities(restore.Namespace).Get(name); err != nil {
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Error getting restore priority %s: %v", name, err))
+		}
+	}
+
+	// validate that exactly one of BackupName, ScheduleName and ApplicationName have been specified
 	if !backupXorScheduleProvided(restore) {
-		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "Either a backup or schedule must be specified as a source for the restore, but not both")
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "Either a backup, schedule or application must be specified as a source for the restore, but not more than one")
+		return backupInfo{}
+	}
+
+	if restore.Spec.AsOf != nil && restore.Spec.ScheduleName == "" && restore.Spec.ApplicationName == "" {
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "A point in time can only be specified when restoring from a schedule or application")
 		return backupInfo{}
 	}
 
-	// if ScheduleName is specified, fill in BackupName with the most recent successful backup from
-	// the schedule
-	if restore.Spec.ScheduleName != "" {
-		selector := labels.SelectorFromSet(labels.Set(map[string]string{
-			"ark-schedule": restore.Spec.ScheduleName,
+	// if ScheduleName or ApplicationName is specified, fill in BackupName with the most recent
+	// successful backup from the schedule or of the application, optionally limited to backups
+	// started at or before AsOf
+	var source string
+	var selector labels.Selector
+	switch {
+	case restore.Spec.ScheduleName != "":
+		source = "schedule"
+		selector = labels.SelectorFromSet(labels.Set(map[string]string{
+			api.ScheduleNameLabel: restore.Spec.ScheduleName,
 		}))
+	case restore.Spec.ApplicationName != "":
+		source = "application"
+		selector = labels.SelectorFromSet(labels.Set(map[string]string{
+			api.ProtectedApplicationLabel: restore.Spec.ApplicationName,
+		}))
+	}
 
+	if selector != nil {
 		backups, err := c.backupLister.Backups(c.namespace).List(selector)
 		if err != nil {
-			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "Unable to list backups for schedule")
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "Unable to list backups for "+source)
 			return backupInfo{}
 		}
 		if len(backups) == 0 {
-			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "No backups found for schedule")
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "No backups found for "+source)
+			return backupInfo{}
+		}
+
+		if restore.Spec.AsOf != nil {
+			backups = backupsStartedAtOrBefore(backups, restore.Spec.AsOf.Time)
 		}
 
 		if backup := mostRecentCompletedBackup(backups); backup != nil {
 			restore.Spec.BackupName = backup.Name
+		} else if restore.Spec.AsOf != nil {
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("No completed backups found for %s at or before %s", source, restore.Spec.AsOf.UTC().Format(time.RFC3339)))
+			return backupInfo{}
 		} else {
-			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "No completed backups found for schedule")
+			restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, "No completed backups found for "+source)
 			return backupInfo{}
 		}
 	}
@@ -337,6 +626,25 @@ func (c *restoreController) validateAndComplete(restore *api.Restore, pluginMana
 		return backupInfo{}
 	}
 
+	// refuse backups written by a newer Ark server whose format this one may not understand
+	if version := info.backup.Status.ServerVersion; buildinfo.NewerAndIncompatible(version, buildinfo.Version) {
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, fmt.Sprintf("Backup was written by Ark %s, which is incompatible with this server's version %s; upgrade the server to restore it", version, buildinfo.Version))
+		return backupInfo{}
+	}
+
+	restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, validateSourceCluster(restore, info.backup, c.clusterUID)...)
+
+	// expand the templates in the namespace mapping and hostname rewrites now that
+	// the backup is known
+	restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, expandRestoreTemplates(restore, info.backup, c.clock.Now())...)
+
+	// keep the restore out of the Ark server's namespace unless it opts in. This is
+	// checked after the namespace mapping's templates are expanded, since they may
+	// produce the server's namespace.
+	if !restore.Spec.IncludeArkResources {
+		restore.Status.ValidationErrors = append(restore.Status.ValidationErrors, excludeArkNamespace(restore, c.namespace)...)
+	}
+
 	// Ensure that we have either .status.volumeBackups (for pre-v0.10 backups) OR a
 	// volumesnapshots.json.gz file in obj storage (for v0.10+ backups), but not both.
 	// If we have .status.volumeBackups, ensure that there's only one volume snapshot
@@ -359,24 +667,24 @@ func (c *restoreController) validateAndComplete(restore *api.Restore, pluginMana
 
 	// Fill in the ScheduleName so it's easier to consume for metrics.
 	if restore.Spec.ScheduleName == "" {
-		restore.Spec.ScheduleName = info.backup.GetLabels()["ark-schedule"]
+		restore.Spec.ScheduleName = info.backup.GetLabels()[api.ScheduleNameLabel]
 	}
 
 	return info
 }
 
-// backupXorScheduleProvided returns true if exactly one of BackupName and
-// ScheduleName are non-empty for the restore, or false otherwise.
+// backupXorScheduleProvided returns true if exactly one of BackupName,
+// ScheduleName and ApplicationName are non-empty for the restore, or false
+// otherwise.
 func backupXorScheduleProvided(restore *api.Restore) bool {
-	if restore.Spec.BackupName != "" && restore.Spec.ScheduleName != "" {
-		return false
-	}
-
-	if restore.Spec.BackupName == "" && restore.Spec.ScheduleName == "" {
-		return false
+	var sources int
+	for _, source := range []string{restore.Spec.BackupName, restore.Spec.ScheduleName, restore.Spec.ApplicationName} {
+		if source != "" {
+			sources++
+		}
 	}
 
-	return true
+	return sources == 1
 }
 
 // mostRecentCompletedBackup returns the most recent backup that's
@@ -396,6 +704,20 @@ func mostRecentCompletedBackup(backups []*api.Backup) *api.Backup {
 	return nil
 }
 
+// backupsStartedAtOrBefore returns the backups from a list of backups
+// that started at or before the given time.
+func backupsStartedAtOrBefore(backups []*api.Backup, t time.Time) []*api.Backup {
+	var res []*api.Backup
+	for _, backup := range backups {
+		if backup.Status.StartTimestamp.IsZero() || backup.Status.StartTimestamp.After(t) {
+			continue
+		}
+		res = append(res, backup)
+	}
+
+	return res
+}
+
 // fetchBackupInfo checks the backup lister for a backup that matches the given name. If it doesn't
 // find it, it tries to retrieve it from one of the backup storage locations.
 func (c *restoreController) fetchBackupInfo(backupName string, pluginManager plugin.Manager) (backupInfo, error) {
@@ -415,6 +737,10 @@ func (c *restoreController) fetchBackupInfo(backupName string, pluginManager plu
 		return backupInfo{}, errors.WithStack(err)
 	}
 
+	if location, err = persistence.BackupLocation(location, backup); err != nil {
+		return backupInfo{}, err
+	}
+
 	backupStore, err := c.newBackupStore(location, pluginManager, c.logger)
 	if err != nil {
 		return backupInfo{}, err
@@ -426,6 +752,41 @@ func (c *restoreController) fetchBackupInfo(backupName string, pluginManager plu
 	}, nil
 }
 
+// getVolumeSnapshots returns the volume snapshots taken by a backup, from the backup's
+// VolumeSnapshot records. The records are created best-effort and may not all be in
+// the informer cache yet, so they're only used if there's a record for each of the
+// backup's completed snapshots. Otherwise, e.g. because the backup was taken by an
+// older version of Ark, the volumesnapshots file in the backup store is used.
+func (c *restoreController) getVolumeSnapshots(info backupInfo) ([]*volume.Snapshot, error) {
+	selector := labels.Set(map[string]string{api.BackupNameLabel: info.backup.Name}).AsSelector()
+
+	records, err := c.volumeSnapshotLister.VolumeSnapshots(c.namespace).List(selector)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var completed int
+	for _, record := range records {
+		if record.Status.Phase == api.VolumeSnapshotPhaseCompleted {
+			completed++
+		}
+	}
+	if len(records) == 0 || completed != info.backup.Status.VolumeSnapshotsCompleted {
+		return info.backupStore.GetBackupVolumeSnapshots(info.backup.Name)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Spec.PersistentVolumeName < records[j].Spec.PersistentVolumeName
+	})
+
+	snapshots := make([]*volume.Snapshot, 0, len(records))
+	for _, record := range records {
+		snapshots = append(snapshots, volume.SnapshotFromVolumeSnapshot(record))
+	}
+
+	return snapshots, nil
+}
+
 // fetchFromBackupStorage checks each backup storage location, starting with the default,
 // looking for a backup that matches the given backup name.
 func (c *restoreController) fetchFromBackupStorage(backupName string, pluginManager plugin.Manager) (backupInfo, error) {
@@ -499,13 +860,14 @@ func (c *restoreController) backupInfoForLocation(location *api.BackupStorageLoc
 
 func (c *restoreController) runRestore(
 	restore *api.Restore,
-	actions []restore.ItemAction,
+	actions []pkgrestore.ItemAction,
 	info backupInfo,
 	pluginManager plugin.Manager,
+	progress *pkgrestore.Progress,
 ) (restoreResult, error) {
 	var restoreWarnings, restoreErrors api.RestoreResult
 	var restoreFailure error
-	logFile, err := ioutil.TempFile("", "")
+	logFile, err := ioutil.TempFile(c.scratchDir, "")
 	if err != nil {
 		c.logger.
 			WithFields(
@@ -535,16 +897,25 @@ func (c *restoreController) runRestore(
 			"backup":  restore.Spec.BackupName,
 		})
 
-	backupFile, err := downloadToTempFile(restore.Spec.BackupName, info.backupStore, c.logger)
+	// the backup's tarball is downloaded and then extracted, and its extracted
+	// contents are at least as large as the tarball
+	if err := checkScratchSpace(c.freeSpace, c.scratchDir, 2*info.backup.Status.TarballSizeBytes); err != nil {
+		log.WithError(err).Error("Error checking free space for backup")
+		restoreErrors.Ark = append(restoreErrors.Ark, err.Error())
+		restoreFailure = err
+		return restoreResult{warnings: restoreWarnings, errors: restoreErrors}, restoreFailure
+	}
+
+	backupFile, err := c.backupCache.open(info.backup, info.backupStore, c.scratchDir, c.logger)
 	if err != nil {
 		log.WithError(err).Error("Error downloading backup")
 		restoreErrors.Ark = append(restoreErrors.Ark, err.Error())
 		restoreFailure = err
 		return restoreResult{warnings: restoreWarnings, errors: restoreErrors}, restoreFailure
 	}
-	defer closeAndRemoveFile(backupFile, c.logger)
+	defer backupFile.Close()
 
-	resultsFile, err := ioutil.TempFile("", "")
+	resultsFile, err := ioutil.TempFile(c.scratchDir, "")
 	if err != nil {
 		log.WithError(errors.WithStack(err)).Error("Error creating results temp file")
 		restoreErrors.Ark = append(restoreErrors.Ark, err.Error())
@@ -553,7 +924,7 @@ func (c *restoreController) runRestore(
 	}
 	defer closeAndRemoveFile(resultsFile, c.logger)
 
-	volumeSnapshots, err := info.backupStore.GetBackupVolumeSnapshots(restore.Spec.BackupName)
+	volumeSnapshots, err := c.getVolumeSnapshots(info)
 	if err != nil {
 		log.WithError(errors.WithStack(err)).Error("Error fetching volume snapshots")
 		restoreErrors.Ark = append(restoreErrors.Ark, err.Error())
@@ -564,9 +935,17 @@ func (c *restoreController) runRestore(
 	// Any return statement above this line means a total restore failure
 	// Some failures after this line *may* be a total restore failure
 	log.Info("starting restore")
-	restoreWarnings, restoreErrors = c.restorer.Restore(log, restore, info.backup, volumeSnapshots, backupFile, actions, c.snapshotLocationLister, pluginManager)
+	manifest := pkgrestore.NewManifest()
+	restoreWarnings, restoreErrors = c.restorer.Restore(context.Background(), log, restore, info.backup, volumeSnapshots, backupFile, actions, c.snapshotLocationLister, pluginManager, manifest, progress)
 	log.Info("restore completed")
 
+	// The manifest of created objects is needed to undo the restore, so record a failure
+	// to upload it as an error.
+	if err := c.uploadRestoreManifest(restore, manifest, info.backupStore); err != nil {
+		log.WithError(err).Error("Error uploading restore manifest to backup storage")
+		restoreErrors.Ark = append(restoreErrors.Ark, fmt.Sprintf("error uploading restore manifest to backup storage: %v", err))
+	}
+
 	// Try to upload the log file. This is best-effort. If we fail, we'll add to the ark errors.
 	if err := gzippedLogFile.Close(); err != nil {
 		c.logger.WithError(err).Error("error closing gzippedLogFile")
@@ -605,9 +984,156 @@ func (c *restoreController) runRestore(
 	return restoreResult{warnings: restoreWarnings, errors: restoreErrors}, restoreFailure
 }
 
+// reportRestoreProgress patches the status of restore, which is in progress, with the item
+// returned by current every c.progressInterval, until the returned func is called. That func
+// returns the last version of the restore that was patched, or restore if none was, so that
+// the restore's final status can be patched from it.
+func (c *restoreController) reportRestoreProgress(restore *api.Restore, current func() *api.RestoreProgress, log logrus.FieldLogger) func() *api.Restore {
+	stop := make(chan struct{})
+	latest := make(chan *api.Restore)
+
+	go func() {
+		ticker := time.NewTicker(c.progressInterval)
+		defer ticker.Stop()
+
+		var reported *api.RestoreProgress
+		patched := restore
+		for {
+			select {
+			case <-stop:
+				latest <- patched
+				return
+			case <-ticker.C:
+			}
+
+			// the progress is only patched when the restore has moved on, so that
+			// UpdateTimestamp shows how long it's been on the current item
+			item := current()
+			if item == nil || (reported != nil && *item == *reported) {
+				continue
+			}
+
+			updated := patched.DeepCopy()
+			updated.Status.Progress = item.DeepCopy()
+			updated.Status.Progress.UpdateTimestamp.Time = c.clock.Now()
+
+			res, err := patchRestore(patched, updated, c.restoreClient)
+			if err != nil {
+				log.WithError(err).Warn("Error updating Restore progress")
+				continue
+			}
+			patched, reported = res, item
+		}
+	}()
+
+	return func() *api.Restore {
+		close(stop)
+		return <-latest
+	}
+}
+
+// validateRecreateResources returns the errors in the restore's list of resources
+// whose existing objects are recreated. Recreating an object deletes it, so only
+// resources that are listed one by one can be recreated, and never namespaces,
+// since deleting a namespace deletes everything in it.
+func validateRecreateResources(restore *api.Restore) []string {
+	var errs []string
+
+	if restore.Spec.ConflictPolicy != api.RestoreConflictPolicyRecreate {
+		if len(restore.Spec.RecreateResources) > 0 {
+			errs = append(errs, fmt.Sprintf("Recreate resources can only be set if the conflict policy is %s", api.RestoreConflictPolicyRecreate))
+		}
+		return errs
+	}
+
+	if len(restore.Spec.RecreateResources) == 0 {
+		errs = append(errs, fmt.Sprintf("Conflict policy %s requires at least one recreate resource", api.RestoreConflictPolicyRecreate))
+	}
+
+	for _, resource := range restore.Spec.RecreateResources {
+		switch schema.ParseGroupResource(resource) {
+		case schema.GroupResource{Resource: "*"}:
+			errs = append(errs, "Recreate resources must be listed individually, * isn't allowed")
+		case kuberesource.Namespaces, schema.GroupResource{Resource: "namespace"}, schema.GroupResource{Resource: "ns"}:
+			errs = append(errs, "Namespaces can't be recreated, since deleting a namespace deletes everything in it")
+		}
+	}
+
+	if restore.Spec.RecreateTimeout != nil && restore.Spec.RecreateTimeout.Duration <= 0 {
+		errs = append(errs, fmt.Sprintf("Invalid recreate timeout %s, must be positive", restore.Spec.RecreateTimeout.Duration))
+	}
+
+	return errs
+}
+
+// validateVolumeOverrides checks that each volume override changes something, and that
+// no two overrides select the same storage class.
+func validateVolumeOverrides(overrides []api.RestoreVolumeOverride) []string {
+	var errs []string
+
+	storageClasses := sets.NewString()
+	for _, override := range overrides {
+		selects := "storage class " + override.StorageClassName
+		if override.StorageClassName == "" {
+			selects = "all storage classes"
+		}
+
+		if override.VolumeType == "" && override.IOPS == nil {
+			errs = append(errs, fmt.Sprintf("Invalid volume override for %s, must specify a volume type or IOPS", selects))
+		}
+		if override.IOPS != nil && *override.IOPS <= 0 {
+			errs = append(errs, fmt.Sprintf("Invalid volume override for %s, IOPS must be positive", selects))
+		}
+		if storageClasses.Has(override.StorageClassName) {
+			errs = append(errs, fmt.Sprintf("Invalid volume overrides, %s is overridden more than once", selects))
+		}
+		storageClasses.Insert(override.StorageClassName)
+	}
+
+	return errs
+}
+
+// validateSourceCluster requires restores that change existing objects to confirm that
+// they're meant to restore a backup into the cluster it was taken in, since the backed-up
+// application may still be running there. Backups that don't record a cluster UID, and
+// servers that don't know theirs, can't be checked.
+func validateSourceCluster(restore *api.Restore, backup *api.Backup, clusterUID string) []string {
+	if clusterUID == "" || backup.Status.ClusterUID != clusterUID || restore.Spec.ConfirmSourceCluster {
+		return nil
+	}
+
+	switch restore.Spec.ConflictPolicy {
+	case api.RestoreConflictPolicyThreeWayMerge, api.RestoreConflictPolicyRecreate:
+		return []string{fmt.Sprintf("Backup %s was taken in this cluster, and conflict policy %s would change the existing objects of the application it backed up; set spec.confirmSourceCluster (ark restore create --confirm-source-cluster) to restore it anyway", backup.Name, restore.Spec.ConflictPolicy)}
+	}
+
+	return nil
+}
+
+// uploadRestoreManifest writes the manifest of the objects created by the restore to
+// a temp file and uploads it to the backup store.
+func (c *restoreController) uploadRestoreManifest(restore *api.Restore, manifest *pkgrestore.Manifest, backupStore persistence.BackupStore) error {
+	manifestFile, err := ioutil.TempFile(c.scratchDir, "")
+	if err != nil {
+		return errors.Wrap(err, "error creating manifest temp file")
+	}
+	defer closeAndRemoveFile(manifestFile, c.logger)
+
+	if err := manifest.Encode(manifestFile); err != nil {
+		return err
+	}
+
+	if _, err := manifestFile.Seek(0, 0); err != nil {
+		return errors.Wrap(err, "error resetting manifest file offset to 0")
+	}
+
+	return backupStore.PutRestoreManifest(restore.Name, manifestFile)
+}
+
 func downloadToTempFile(
 	backupName string,
 	backupStore persistence.BackupStore,
+	dir string,
 	logger logrus.FieldLogger,
 ) (*os.File, error) {
 	readCloser, err := backupStore.GetBackupContents(backupName)
@@ -616,7 +1142,7 @@ func downloadToTempFile(
 	}
 	defer readCloser.Close()
 
-	file, err := ioutil.TempFile("", backupName)
+	file, err := ioutil.TempFile(dir, backupName)
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating Backup temp file")
 	}
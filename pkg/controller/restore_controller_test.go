@@ -18,9 +18,11 @@ package controller
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,13 +33,16 @@ import (
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
 	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/buildinfo"
 	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
 	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/itemhash"
 	"github.com/heptio/ark/pkg/metrics"
 	"github.com/heptio/ark/pkg/persistence"
 	persistencemocks "github.com/heptio/ark/pkg/persistence/mocks"
@@ -106,10 +111,17 @@ func TestFetchBackupInfo(t *testing.T) {
 				sharedInformers.Ark().V1().Backups(),
 				sharedInformers.Ark().V1().BackupStorageLocations(),
 				sharedInformers.Ark().V1().VolumeSnapshotLocations(),
+				sharedInformers.Ark().V1().VolumeSnapshots(),
+				sharedInformers.Ark().V1().RestorePriorities(),
 				logger,
 				logrus.InfoLevel,
 				func(logrus.FieldLogger) plugin.Manager { return pluginManager },
+				NewRestoreTracker(),
+				newFakeConfigMapClient(),
 				"default",
+				"",
+				"",
+				0,
 				metrics.NewServerMetrics(),
 			).(*restoreController)
 
@@ -199,10 +211,17 @@ func TestProcessRestoreSkips(t *testing.T) {
 				sharedInformers.Ark().V1().Backups(),
 				sharedInformers.Ark().V1().BackupStorageLocations(),
 				sharedInformers.Ark().V1().VolumeSnapshotLocations(),
+				sharedInformers.Ark().V1().VolumeSnapshots(),
+				sharedInformers.Ark().V1().RestorePriorities(),
 				logger,
 				logrus.InfoLevel,
 				nil,
+				NewRestoreTracker(),
+				newFakeConfigMapClient(),
 				"default",
+				"",
+				"",
+				0,
 				metrics.NewServerMetrics(),
 			).(*restoreController)
 
@@ -218,16 +237,21 @@ func TestProcessRestoreSkips(t *testing.T) {
 }
 
 func TestProcessRestore(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2018-11-01T12:00:00Z")
+	require.NoError(t, err)
+
 	tests := []struct {
 		name                            string
 		restoreKey                      string
 		location                        *api.BackupStorageLocation
 		restore                         *api.Restore
 		backup                          *api.Backup
+		lockedBy                        *api.Restore
 		restorerError                   error
 		expectedErr                     bool
 		expectedPhase                   string
 		expectedValidationErrors        []string
+		expectedExcludedNamespaces      []string
 		expectedRestoreErrors           int
 		expectedRestorerCall            *api.Restore
 		backupStoreGetBackupMetadataErr error
@@ -247,25 +271,98 @@ func TestProcessRestore(t *testing.T) {
 		{
 			name:                     "restore with resource in both includedResources and excludedResources fails validation",
 			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
-			restore:                  NewRestore("foo", "bar", "backup-1", "*", "a-resource", api.RestorePhaseNew).WithExcludedResource("a-resource").Restore,
+			restore:                    NewRestore("foo", "bar", "backup-1", "*", "a-resource", api.RestorePhaseNew).WithExcludedResource("a-resource").Restore,
+			backup:                     arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:                false,
+			expectedPhase:              string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors:   []string{"Invalid included/excluded resource lists: excludes list cannot contain an item in the includes list: a-resource"},
+			expectedExcludedNamespaces: []string{api.DefaultNamespace},
+		},
+		{
+			name:                     "restore with invalid conflict policy fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithConflictPolicy("Overwrite").Restore,
+			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{"Invalid conflict policy Overwrite, must be Skip, ThreeWayMerge or Recreate"},
+		},
+		{
+			name:                     "restore with invalid namespace creation policy fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithCreateNamespaces("Sometimes").Restore,
 			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
 			expectedErr:              false,
 			expectedPhase:            string(api.RestorePhaseFailedValidation),
-			expectedValidationErrors: []string{"Invalid included/excluded resource lists: excludes list cannot contain an item in the includes list: a-resource"},
+			expectedValidationErrors: []string{"Invalid namespace creation policy Sometimes, must be Always, Never or IfMappedOnly"},
+		},
+		{
+			name:                     "restore with invalid strip annotations entry fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithStripAnnotations("default", "example.com/*-lb").Restore,
+			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{"Invalid strip annotations entry \"example.com/*-lb\", must be an annotation key, a key prefix ending in *, or default"},
+		},
+		{
+			name:                     "restore with invalid security policy translation fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithSecurityPolicyTranslation("PSPToRBAC").Restore,
+			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{"Invalid security policy translation PSPToRBAC, must be PSPToSCC or SCCToPSP"},
+		},
+		{
+			name:                     "restore with invalid iteration mode fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithIterationMode("ByName").Restore,
+			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{"Invalid iteration mode ByName, must be ByResource or ByNamespace"},
+		},
+		{
+			name:                     "restore with invalid namespace order fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithNamespaceOrder("infra", "*", "infra").Restore,
+			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{"Invalid namespace order entry \"*\", must be a namespace name", "Invalid namespace order, namespace infra is listed more than once"},
+		},
+		{
+			name:                     "restore with negative client limits fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithClientLimits(-1, -2).Restore,
+			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{"Invalid client QPS -1, must not be negative", "Invalid client burst -2, must not be negative"},
+		},
+		{
+			name:                     "restore with non-existent restore priority fails validation",
+			location:                 arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithRestorePriority("custom").Restore,
+			backup:                   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{`Error getting restore priority custom: restorepriority.ark.heptio.com "custom" not found`},
 		},
 		{
 			name:                     "new restore with empty backup and schedule names fails validation",
 			restore:                  NewRestore("foo", "bar", "", "ns-1", "", api.RestorePhaseNew).Restore,
 			expectedErr:              false,
 			expectedPhase:            string(api.RestorePhaseFailedValidation),
-			expectedValidationErrors: []string{"Either a backup or schedule must be specified as a source for the restore, but not both"},
+			expectedValidationErrors: []string{"Either a backup, schedule or application must be specified as a source for the restore, but not more than one"},
 		},
 		{
 			name:                     "new restore with backup and schedule names provided fails validation",
 			restore:                  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithSchedule("sched-1").Restore,
 			expectedErr:              false,
 			expectedPhase:            string(api.RestorePhaseFailedValidation),
-			expectedValidationErrors: []string{"Either a backup or schedule must be specified as a source for the restore, but not both"},
+			expectedValidationErrors: []string{"Either a backup, schedule or application must be specified as a source for the restore, but not more than one"},
 		},
 		{
 			name:     "valid restore with schedule name gets executed",
@@ -282,6 +379,28 @@ func TestProcessRestore(t *testing.T) {
 			expectedPhase:        string(api.RestorePhaseInProgress),
 			expectedRestorerCall: NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseInProgress).WithSchedule("sched-1").Restore,
 		},
+		{
+			name:                     "new restore with schedule and application names provided fails validation",
+			restore:                  NewRestore("foo", "bar", "", "ns-1", "", api.RestorePhaseNew).WithSchedule("sched-1").WithApplication("app-1").Restore,
+			expectedErr:              false,
+			expectedPhase:            string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{"Either a backup, schedule or application must be specified as a source for the restore, but not more than one"},
+		},
+		{
+			name:     "valid restore with application name gets executed",
+			location: arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:  NewRestore("foo", "bar", "", "ns-1", "", api.RestorePhaseNew).WithApplication("app-1").Restore,
+			backup: arktest.
+				NewTestBackup().
+				WithName("backup-1").
+				WithStorageLocation("default").
+				WithLabel(api.ProtectedApplicationLabel, "app-1").
+				WithPhase(api.BackupPhaseCompleted).
+				Backup,
+			expectedErr:          false,
+			expectedPhase:        string(api.RestorePhaseInProgress),
+			expectedRestorerCall: NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseInProgress).WithApplication("app-1").Restore,
+		},
 		{
 			name:                            "restore with non-existent backup name fails",
 			restore:                         NewRestore("foo", "bar", "backup-1", "ns-1", "*", api.RestorePhaseNew).Restore,
@@ -370,6 +489,24 @@ func TestProcessRestore(t *testing.T) {
 				"Invalid included/excluded resource lists: excludes list cannot contain an item in the includes list: restores.ark.heptio.com",
 			},
 		},
+		{
+			name:          "restore into a namespace locked by an in-progress restore fails validation",
+			location:      arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:       NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).Restore,
+			backup:        arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			lockedBy:      NewRestore("foo", "other", "backup-2", "ns-1", "", api.RestorePhaseInProgress).Restore,
+			expectedPhase: string(api.RestorePhaseFailedValidation),
+			expectedValidationErrors: []string{
+				"Namespace ns-1 is locked by in-progress restore foo/other, wait for it to finish or create the restore with --wait-for-namespace-lock",
+			},
+		},
+		{
+			name:     "restore into a namespace locked by an in-progress restore is queued if it waits for the lock",
+			location: arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
+			restore:  NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithWaitForNamespaceLock(true).Restore,
+			backup:   arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("default").Backup,
+			lockedBy: NewRestore("foo", "other", "backup-2", "*", "", api.RestorePhaseInProgress).Restore,
+		},
 		{
 			name:                            "backup download error results in failed restore",
 			location:                        arktest.NewTestBackupStorageLocation().WithName("default").WithProvider("myCloud").WithObjectStorage("bucket").BackupStorageLocation,
@@ -404,12 +541,26 @@ func TestProcessRestore(t *testing.T) {
 				sharedInformers.Ark().V1().Backups(),
 				sharedInformers.Ark().V1().BackupStorageLocations(),
 				sharedInformers.Ark().V1().VolumeSnapshotLocations(),
+				sharedInformers.Ark().V1().VolumeSnapshots(),
+				sharedInformers.Ark().V1().RestorePriorities(),
 				logger,
 				logrus.InfoLevel,
 				func(logrus.FieldLogger) plugin.Manager { return pluginManager },
+				NewRestoreTracker(),
+				newFakeConfigMapClient(),
 				"default",
+				"",
+				"",
+				0,
 				metrics.NewServerMetrics(),
 			).(*restoreController)
+			c.clock = clock.NewFakeClock(now)
+
+			if test.lockedBy != nil {
+				sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(test.lockedBy)
+				_, err := c.restoreLocker.acquire(test.lockedBy, test.lockedBy.Spec.IncludedNamespaces)
+				require.NoError(t, err)
+			}
 
 			c.newBackupStore = func(*api.BackupStorageLocation, persistence.ObjectStoreGetter, logrus.FieldLogger) (persistence.BackupStore, error) {
 				return backupStore, nil
@@ -479,6 +630,7 @@ func TestProcessRestore(t *testing.T) {
 				backupStore.On("PutRestoreLog", test.backup.Name, test.restore.Name, mock.Anything).Return(test.putRestoreLogErr)
 
 				backupStore.On("PutRestoreResults", test.backup.Name, test.restore.Name, mock.Anything).Return(nil)
+				backupStore.On("PutRestoreManifest", test.restore.Name, mock.Anything).Return(nil)
 
 				volumeSnapshots := []*volume.Snapshot{
 					{
@@ -529,13 +681,15 @@ func TestProcessRestore(t *testing.T) {
 
 			// structs and func for decoding patch content
 			type SpecPatch struct {
-				BackupName string `json:"backupName"`
+				BackupName         string   `json:"backupName"`
+				ExcludedNamespaces []string `json:"excludedNamespaces"`
 			}
 
 			type StatusPatch struct {
 				Phase            api.RestorePhase `json:"phase"`
 				ValidationErrors []string         `json:"validationErrors"`
 				Errors           int              `json:"errors"`
+				StartTimestamp   string           `json:"startTimestamp"`
 			}
 
 			type Patch struct {
@@ -560,11 +714,16 @@ func TestProcessRestore(t *testing.T) {
 				},
 			}
 
-			if test.restore.Spec.ScheduleName != "" && test.backup != nil {
+			if test.expectedPhase == string(api.RestorePhaseInProgress) {
+				expected.Status.StartTimestamp = now.Format(time.RFC3339)
+			}
+
+			if (test.restore.Spec.ScheduleName != "" || test.restore.Spec.ApplicationName != "") && test.backup != nil {
 				expected.Spec = SpecPatch{
 					BackupName: test.backup.Name,
 				}
 			}
+			expected.Spec.ExcludedNamespaces = test.expectedExcludedNamespaces
 
 			arktest.ValidatePatch(t, actions[0], expected, decode)
 
@@ -674,8 +833,26 @@ func TestValidateAndComplete(t *testing.T) {
 			restore:      arktest.NewDefaultTestRestore().WithBackup("backup-1").Restore,
 			expectedErrs: nil,
 		},
+		{
+			name:            "backup written by a compatible server does not error",
+			storageLocation: arktest.NewTestBackupStorageLocation().WithName("loc-1").BackupStorageLocation,
+			backup:          arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("loc-1").WithServerVersion("v0.10.2").Backup,
+			restore:         arktest.NewDefaultTestRestore().WithBackup("backup-1").Restore,
+			expectedErrs:    nil,
+		},
+		{
+			name:            "backup written by a newer incompatible server errors",
+			storageLocation: arktest.NewTestBackupStorageLocation().WithName("loc-1").BackupStorageLocation,
+			backup:          arktest.NewTestBackup().WithName("backup-1").WithStorageLocation("loc-1").WithServerVersion("v0.11.0").Backup,
+			restore:         arktest.NewDefaultTestRestore().WithBackup("backup-1").Restore,
+			expectedErrs:    []string{"Backup was written by Ark v0.11.0, which is incompatible with this server's version v0.10.1; upgrade the server to restore it"},
+		},
 	}
 
+	serverVersion := buildinfo.Version
+	buildinfo.Version = "v0.10.1"
+	defer func() { buildinfo.Version = serverVersion }()
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			var (
@@ -688,6 +865,7 @@ func TestValidateAndComplete(t *testing.T) {
 						logger: logger,
 					},
 					namespace:              api.DefaultNamespace,
+					clock:                  clock.NewFakeClock(time.Now()),
 					backupLister:           sharedInformers.Ark().V1().Backups().Lister(),
 					backupLocationLister:   sharedInformers.Ark().V1().BackupStorageLocations().Lister(),
 					snapshotLocationLister: sharedInformers.Ark().V1().VolumeSnapshotLocations().Lister(),
@@ -712,12 +890,125 @@ func TestValidateAndComplete(t *testing.T) {
 
 }
 
-func TestvalidateAndCompleteWhenScheduleNameSpecified(t *testing.T) {
+func TestGetVolumeSnapshots(t *testing.T) {
+	fileSnapshots := []*volume.Snapshot{
+		{
+			Spec:   volume.SnapshotSpec{BackupName: "backup-1", PersistentVolumeName: "pv-1"},
+			Status: volume.SnapshotStatus{ProviderSnapshotID: "file-snap-1"},
+		},
+	}
+
+	recordSnapshots := []*volume.Snapshot{
+		{
+			Spec:   volume.SnapshotSpec{BackupName: "backup-1", PersistentVolumeName: "pv-1", Location: "default"},
+			Status: volume.SnapshotStatus{ProviderSnapshotID: "snap-1", Phase: volume.SnapshotPhaseCompleted},
+		},
+		{
+			Spec:   volume.SnapshotSpec{BackupName: "backup-1", PersistentVolumeName: "pv-2", Location: "default"},
+			Status: volume.SnapshotStatus{ProviderSnapshotID: "snap-2", Phase: volume.SnapshotPhaseCompleted},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		snapshotsCompleted int
+		records            []*volume.Snapshot
+		expected           []*volume.Snapshot
+	}{
+		{
+			name:               "backup without volume snapshot records uses the backup store's volumesnapshots file",
+			snapshotsCompleted: 1,
+			expected:           fileSnapshots,
+		},
+		{
+			name:               "backup with volume snapshot records uses the records",
+			snapshotsCompleted: 2,
+			records:            recordSnapshots,
+			expected:           recordSnapshots,
+		},
+		{
+			name:               "backup missing a completed snapshot's record uses the backup store's volumesnapshots file",
+			snapshotsCompleted: 2,
+			records:            recordSnapshots[:1],
+			expected:           fileSnapshots,
+		},
+		{
+			name:               "records of failed snapshots aren't counted as completed",
+			snapshotsCompleted: 2,
+			records: append(recordSnapshots[:1:1], &volume.Snapshot{
+				Spec:   volume.SnapshotSpec{BackupName: "backup-1", PersistentVolumeName: "pv-3", Location: "default"},
+				Status: volume.SnapshotStatus{Phase: volume.SnapshotPhaseFailed},
+			}),
+			expected: fileSnapshots,
+		},
+		{
+			name: "records of other backups are ignored",
+			records: []*volume.Snapshot{
+				{
+					Spec:   volume.SnapshotSpec{BackupName: "backup-2", PersistentVolumeName: "pv-1"},
+					Status: volume.SnapshotStatus{ProviderSnapshotID: "snap-3"},
+				},
+			},
+			expected: fileSnapshots,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				backupStore     = &persistencemocks.BackupStore{}
+			)
+
+			c := NewRestoreController(
+				api.DefaultNamespace,
+				sharedInformers.Ark().V1().Restores(),
+				client.ArkV1(),
+				client.ArkV1(),
+				nil,
+				sharedInformers.Ark().V1().Backups(),
+				sharedInformers.Ark().V1().BackupStorageLocations(),
+				sharedInformers.Ark().V1().VolumeSnapshotLocations(),
+				sharedInformers.Ark().V1().VolumeSnapshots(),
+				sharedInformers.Ark().V1().RestorePriorities(),
+				arktest.NewLogger(),
+				logrus.DebugLevel,
+				nil,
+				NewRestoreTracker(),
+				newFakeConfigMapClient(),
+				"default",
+				"",
+				"",
+				0,
+				nil,
+			).(*restoreController)
+
+			for _, snapshot := range test.records {
+				require.NoError(t, sharedInformers.Ark().V1().VolumeSnapshots().Informer().GetStore().Add(volume.NewVolumeSnapshot(api.DefaultNamespace, snapshot)))
+			}
+			backupStore.On("GetBackupVolumeSnapshots", "backup-1").Return(fileSnapshots, nil)
+
+			info := backupInfo{
+				backup:      arktest.NewTestBackup().WithName("backup-1").Backup,
+				backupStore: backupStore,
+			}
+			info.backup.Status.VolumeSnapshotsCompleted = test.snapshotsCompleted
+
+			res, err := c.getVolumeSnapshots(info)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestValidateAndCompleteWhenScheduleNameSpecified(t *testing.T) {
 	var (
 		client          = fake.NewSimpleClientset()
 		sharedInformers = informers.NewSharedInformerFactory(client, 0)
 		logger          = arktest.NewLogger()
 		pluginManager   = &pluginmocks.Manager{}
+		backupStore     = &persistencemocks.BackupStore{}
 	)
 
 	c := NewRestoreController(
@@ -729,13 +1020,32 @@ func TestvalidateAndCompleteWhenScheduleNameSpecified(t *testing.T) {
 		sharedInformers.Ark().V1().Backups(),
 		sharedInformers.Ark().V1().BackupStorageLocations(),
 		sharedInformers.Ark().V1().VolumeSnapshotLocations(),
+		sharedInformers.Ark().V1().VolumeSnapshots(),
+		sharedInformers.Ark().V1().RestorePriorities(),
 		logger,
 		logrus.DebugLevel,
 		nil,
+		NewRestoreTracker(),
+		newFakeConfigMapClient(),
 		"default",
+		"",
+		"",
+		0,
 		nil,
 	).(*restoreController)
 
+	c.newBackupStore = func(*api.BackupStorageLocation, persistence.ObjectStoreGetter, logrus.FieldLogger) (persistence.BackupStore, error) {
+		return backupStore, nil
+	}
+
+	require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(arktest.
+		NewTestBackupStorageLocation().
+		WithName("default").
+		WithProvider("myCloud").
+		WithObjectStorage("bucket").
+		BackupStorageLocation,
+	))
+
 	restore := &api.Restore{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: api.DefaultNamespace,
@@ -755,8 +1065,8 @@ func TestvalidateAndCompleteWhenScheduleNameSpecified(t *testing.T) {
 		Backup,
 	))
 
-	errs := c.validateAndComplete(restore, pluginManager)
-	assert.Equal(t, []string{"No backups found for schedule"}, errs)
+	c.validateAndComplete(restore, pluginManager)
+	assert.Equal(t, []string{"No backups found for schedule"}, restore.Status.ValidationErrors)
 	assert.Empty(t, restore.Spec.BackupName)
 
 	// no completed backups created from the schedule: fail validation
@@ -768,8 +1078,9 @@ func TestvalidateAndCompleteWhenScheduleNameSpecified(t *testing.T) {
 		Backup,
 	))
 
-	errs = c.validateAndComplete(restore, pluginManager)
-	assert.Equal(t, []string{"No completed backups found for schedule"}, errs)
+	restore.Status.ValidationErrors = nil
+	c.validateAndComplete(restore, pluginManager)
+	assert.Equal(t, []string{"No completed backups found for schedule"}, restore.Status.ValidationErrors)
 	assert.Empty(t, restore.Spec.BackupName)
 
 	// multiple completed backups created from the schedule: use most recent
@@ -779,6 +1090,7 @@ func TestvalidateAndCompleteWhenScheduleNameSpecified(t *testing.T) {
 		NewTestBackup().
 		WithName("foo").
 		WithLabel("ark-schedule", "schedule-1").
+		WithStorageLocation("default").
 		WithPhase(api.BackupPhaseCompleted).
 		WithStartTimestamp(now).
 		Backup,
@@ -787,14 +1099,81 @@ func TestvalidateAndCompleteWhenScheduleNameSpecified(t *testing.T) {
 		NewTestBackup().
 		WithName("bar").
 		WithLabel("ark-schedule", "schedule-1").
+		WithStorageLocation("default").
 		WithPhase(api.BackupPhaseCompleted).
 		WithStartTimestamp(now.Add(time.Second)).
 		Backup,
 	))
 
-	errs = c.validateAndComplete(restore, pluginManager)
-	assert.Nil(t, errs)
+	restore.Status.ValidationErrors = nil
+	c.validateAndComplete(restore, pluginManager)
+	assert.Nil(t, restore.Status.ValidationErrors)
 	assert.Equal(t, "bar", restore.Spec.BackupName)
+
+	// point in time specified: use most recent completed backup started at or before it
+	restore.Spec.BackupName = ""
+	restore.Spec.AsOf = &metav1.Time{Time: now}
+	restore.Status.ValidationErrors = nil
+	c.validateAndComplete(restore, pluginManager)
+	assert.Nil(t, restore.Status.ValidationErrors)
+	assert.Equal(t, "foo", restore.Spec.BackupName)
+
+	// point in time before all backups from the schedule: fail validation
+	restore.Spec.BackupName = ""
+	restore.Spec.AsOf = &metav1.Time{Time: time.Date(2018, 9, 1, 2, 0, 0, 0, time.UTC)}
+	restore.Status.ValidationErrors = nil
+	c.validateAndComplete(restore, pluginManager)
+	assert.Equal(t, []string{"No completed backups found for schedule at or before 2018-09-01T02:00:00Z"}, restore.Status.ValidationErrors)
+	assert.Empty(t, restore.Spec.BackupName)
+
+	// point in time specified without a schedule: fail validation
+	restore.Spec.BackupName = "foo"
+	restore.Spec.ScheduleName = ""
+	restore.Status.ValidationErrors = nil
+	c.validateAndComplete(restore, pluginManager)
+	assert.Equal(t, []string{"A point in time can only be specified when restoring from a schedule or application"}, restore.Status.ValidationErrors)
+}
+
+func TestExcludeArkNamespace(t *testing.T) {
+	tests := []struct {
+		name             string
+		restore          *api.Restore
+		expectedExcludes []string
+		expectedErrs     []string
+	}{
+		{
+			name:             "Ark namespace is excluded from a restore of all namespaces",
+			restore:          NewRestore("foo", "bar", "backup-1", "*", "", api.RestorePhaseNew).WithExcludedNamespace("ns-1").Restore,
+			expectedExcludes: []string{"ns-1", api.DefaultNamespace},
+		},
+		{
+			name:    "restore of other namespaces is unchanged",
+			restore: NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).Restore,
+		},
+		{
+			name:    "Ark namespace mapped elsewhere isn't excluded",
+			restore: NewRestore("foo", "bar", "backup-1", "*", "", api.RestorePhaseNew).WithMappedNamespace(api.DefaultNamespace, "ark-copy").Restore,
+		},
+		{
+			name:         "explicitly included Ark namespace fails validation",
+			restore:      NewRestore("foo", "bar", "backup-1", api.DefaultNamespace, "", api.RestorePhaseNew).Restore,
+			expectedErrs: []string{"Namespace heptio-ark is the Ark server's namespace, which requires --include-ark-resources"},
+		},
+		{
+			name:         "namespace mapped into the Ark namespace fails validation",
+			restore:      NewRestore("foo", "bar", "backup-1", "ns-1", "", api.RestorePhaseNew).WithMappedNamespace("ns-1", api.DefaultNamespace).Restore,
+			expectedErrs: []string{"Namespace ns-1 is mapped to the Ark server's namespace heptio-ark, which requires --include-ark-resources"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := excludeArkNamespace(test.restore, api.DefaultNamespace)
+
+			assert.Equal(t, test.expectedErrs, errs)
+			assert.Equal(t, test.expectedExcludes, test.restore.Spec.ExcludedNamespaces)
+		})
+	}
 }
 
 func TestBackupXorScheduleProvided(t *testing.T) {
@@ -813,6 +1192,12 @@ func TestBackupXorScheduleProvided(t *testing.T) {
 	r.Spec.ScheduleName = "schedule-1"
 	assert.True(t, backupXorScheduleProvided(r))
 
+	r.Spec.ApplicationName = "app-1"
+	assert.False(t, backupXorScheduleProvided(r))
+
+	r.Spec.ScheduleName = ""
+	assert.True(t, backupXorScheduleProvided(r))
+
 }
 
 func TestMostRecentCompletedBackup(t *testing.T) {
@@ -905,12 +1290,61 @@ func NewRestore(ns, name, backup, includeNS, includeResource string, phase api.R
 	return restore
 }
 
+func TestReportRestoreProgress(t *testing.T) {
+	var (
+		now        = time.Now().Round(time.Second)
+		restore    = arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseInProgress).Restore
+		client     = fake.NewSimpleClientset(restore)
+		controller = &restoreController{
+			restoreClient:    client.ArkV1(),
+			clock:            clock.NewFakeClock(now),
+			progressInterval: time.Millisecond,
+		}
+		items = make(chan *api.RestoreProgress)
+		item  *api.RestoreProgress
+	)
+
+	// current is called once per interval, so each item sent is reported
+	// before the next one is received
+	current := func() *api.RestoreProgress {
+		select {
+		case item = <-items:
+		default:
+		}
+		return item.DeepCopy()
+	}
+
+	stop := controller.reportRestoreProgress(restore, current, arktest.NewLogger())
+
+	items <- &api.RestoreProgress{Resource: "configmaps", Namespace: "ns-1", Name: "cm-1", ItemsRestored: 1}
+	// an unchanged item isn't patched again
+	items <- &api.RestoreProgress{Resource: "configmaps", Namespace: "ns-1", Name: "cm-1", ItemsRestored: 1}
+	items <- &api.RestoreProgress{Resource: "pods", Namespace: "ns-1", Name: "pod-1", ItemsRestored: 2}
+
+	latest := stop()
+
+	var patches int
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" {
+			patches++
+		}
+	}
+	assert.Equal(t, 2, patches)
+
+	require.NotNil(t, latest.Status.Progress)
+	assert.Equal(t, "pods", latest.Status.Progress.Resource)
+	assert.Equal(t, "pod-1", latest.Status.Progress.Name)
+	assert.Equal(t, 2, latest.Status.Progress.ItemsRestored)
+	assert.True(t, now.Equal(latest.Status.Progress.UpdateTimestamp.Time))
+}
+
 type fakeRestorer struct {
 	mock.Mock
 	calledWithArg api.Restore
 }
 
 func (r *fakeRestorer) Restore(
+	ctx context.Context,
 	log logrus.FieldLogger,
 	restore *api.Restore,
 	backup *api.Backup,
@@ -919,6 +1353,8 @@ func (r *fakeRestorer) Restore(
 	actions []restore.ItemAction,
 	snapshotLocationLister listers.VolumeSnapshotLocationLister,
 	blockStoreGetter restore.BlockStoreGetter,
+	manifest *restore.Manifest,
+	progress *restore.Progress,
 ) (api.RestoreResult, api.RestoreResult) {
 	res := r.Called(log, restore, backup, backupReader, actions)
 
@@ -926,3 +1362,240 @@ func (r *fakeRestorer) Restore(
 
 	return res.Get(0).(api.RestoreResult), res.Get(1).(api.RestoreResult)
 }
+
+func (r *fakeRestorer) AddObserver(observer restore.Observer) {
+	r.Called(observer)
+}
+
+func (r *fakeRestorer) SetItemHasher(hasher itemhash.Hasher) {
+	r.Called(hasher)
+}
+
+func TestValidateAdditionalMetadata(t *testing.T) {
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		annotations  map[string]string
+		expectedErrs []string
+	}{
+		{
+			name:        "valid labels and annotations",
+			labels:      map[string]string{"example.com/restored": "true", "clone": "{{ .Restore.Name }}"},
+			annotations: map[string]string{"example.com/note": "restored for testing"},
+		},
+		{
+			name:   "labels set by Ark",
+			labels: map[string]string{api.RestoreNameLabel: "foo", api.RestoreLabelKey: "foo"},
+			expectedErrs: []string{
+				"Invalid additional label ark-restore, it's set by Ark",
+				"Invalid additional label ark.heptio.com/restore-name, it's set by Ark",
+			},
+		},
+		{
+			name:        "invalid keys and values",
+			labels:      map[string]string{"bad key": "ok", "note": "not a label value"},
+			annotations: map[string]string{"-bad": "ok"},
+			expectedErrs: []string{
+				"Invalid additional label key \"bad key\": ",
+				"Invalid additional label note value \"not a label value\": ",
+				"Invalid additional annotation key \"-bad\": ",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restore := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseNew).Restore
+			restore.Spec.AdditionalLabels = test.labels
+			restore.Spec.AdditionalAnnotations = test.annotations
+
+			errs := validateAdditionalMetadata(restore)
+
+			if assert.Len(t, errs, len(test.expectedErrs)) {
+				for i := range errs {
+					assert.True(t, strings.HasPrefix(errs[i], test.expectedErrs[i]), "error %q doesn't start with %q", errs[i], test.expectedErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRecreateResources(t *testing.T) {
+	tests := []struct {
+		name              string
+		conflictPolicy    api.RestoreConflictPolicy
+		recreateResources []string
+		recreateTimeout   *metav1.Duration
+		expectedErrs      []string
+	}{
+		{
+			name: "no conflict policy or recreate resources",
+		},
+		{
+			name:              "recreate resources with the Recreate policy",
+			conflictPolicy:    api.RestoreConflictPolicyRecreate,
+			recreateResources: []string{"deployments.apps", "configmaps"},
+			recreateTimeout:   &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		{
+			name:              "recreate resources without the Recreate policy",
+			conflictPolicy:    api.RestoreConflictPolicySkip,
+			recreateResources: []string{"configmaps"},
+			expectedErrs:      []string{"Recreate resources can only be set if the conflict policy is Recreate"},
+		},
+		{
+			name:           "Recreate policy without recreate resources",
+			conflictPolicy: api.RestoreConflictPolicyRecreate,
+			expectedErrs:   []string{"Conflict policy Recreate requires at least one recreate resource"},
+		},
+		{
+			name:              "wildcard and namespaces can't be recreated",
+			conflictPolicy:    api.RestoreConflictPolicyRecreate,
+			recreateResources: []string{"*", "ns"},
+			expectedErrs: []string{
+				"Recreate resources must be listed individually, * isn't allowed",
+				"Namespaces can't be recreated, since deleting a namespace deletes everything in it",
+			},
+		},
+		{
+			name:              "non-positive recreate timeout",
+			conflictPolicy:    api.RestoreConflictPolicyRecreate,
+			recreateResources: []string{"configmaps"},
+			recreateTimeout:   &metav1.Duration{},
+			expectedErrs:      []string{"Invalid recreate timeout 0s, must be positive"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restore := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseNew).Restore
+			restore.Spec.ConflictPolicy = test.conflictPolicy
+			restore.Spec.RecreateResources = test.recreateResources
+			restore.Spec.RecreateTimeout = test.recreateTimeout
+
+			assert.Equal(t, test.expectedErrs, validateRecreateResources(restore))
+		})
+	}
+}
+
+func TestValidateSourceCluster(t *testing.T) {
+	tests := []struct {
+		name                 string
+		backupClusterUID     string
+		serverClusterUID     string
+		conflictPolicy       api.RestoreConflictPolicy
+		confirmSourceCluster bool
+		expectErr            bool
+	}{
+		{
+			name:             "restore into another cluster",
+			backupClusterUID: "cluster-a",
+			serverClusterUID: "cluster-b",
+			conflictPolicy:   api.RestoreConflictPolicyThreeWayMerge,
+		},
+		{
+			name:             "restore into the source cluster that skips existing objects",
+			backupClusterUID: "cluster-a",
+			serverClusterUID: "cluster-a",
+		},
+		{
+			name:             "merging restore into the source cluster",
+			backupClusterUID: "cluster-a",
+			serverClusterUID: "cluster-a",
+			conflictPolicy:   api.RestoreConflictPolicyThreeWayMerge,
+			expectErr:        true,
+		},
+		{
+			name:             "recreating restore into the source cluster",
+			backupClusterUID: "cluster-a",
+			serverClusterUID: "cluster-a",
+			conflictPolicy:   api.RestoreConflictPolicyRecreate,
+			expectErr:        true,
+		},
+		{
+			name:                 "confirmed restore into the source cluster",
+			backupClusterUID:     "cluster-a",
+			serverClusterUID:     "cluster-a",
+			conflictPolicy:       api.RestoreConflictPolicyThreeWayMerge,
+			confirmSourceCluster: true,
+		},
+		{
+			name:           "backup without a cluster UID",
+			conflictPolicy: api.RestoreConflictPolicyThreeWayMerge,
+		},
+		{
+			name:             "server without a cluster UID",
+			backupClusterUID: "cluster-a",
+			conflictPolicy:   api.RestoreConflictPolicyThreeWayMerge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restore := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseNew).Restore
+			restore.Spec.ConflictPolicy = test.conflictPolicy
+			restore.Spec.ConfirmSourceCluster = test.confirmSourceCluster
+
+			backup := arktest.NewTestBackup().WithName("backup-1").Backup
+			backup.Status.ClusterUID = test.backupClusterUID
+
+			errs := validateSourceCluster(restore, backup, test.serverClusterUID)
+
+			if test.expectErr {
+				require.Len(t, errs, 1)
+				assert.Contains(t, errs[0], "Backup backup-1 was taken in this cluster")
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeOverrides(t *testing.T) {
+	iops := func(i int64) *int64 { return &i }
+
+	tests := []struct {
+		name         string
+		overrides    []api.RestoreVolumeOverride
+		expectedErrs []string
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name: "valid overrides",
+			overrides: []api.RestoreVolumeOverride{
+				{VolumeType: "standard"},
+				{StorageClassName: "fast", VolumeType: "io1", IOPS: iops(1000)},
+			},
+		},
+		{
+			name: "override that doesn't change anything",
+			overrides: []api.RestoreVolumeOverride{
+				{StorageClassName: "fast"},
+			},
+			expectedErrs: []string{"Invalid volume override for storage class fast, must specify a volume type or IOPS"},
+		},
+		{
+			name: "non-positive IOPS",
+			overrides: []api.RestoreVolumeOverride{
+				{IOPS: iops(0)},
+			},
+			expectedErrs: []string{"Invalid volume override for all storage classes, IOPS must be positive"},
+		},
+		{
+			name: "storage class overridden twice",
+			overrides: []api.RestoreVolumeOverride{
+				{StorageClassName: "fast", VolumeType: "gp2"},
+				{StorageClassName: "fast", VolumeType: "io1"},
+			},
+			expectedErrs: []string{"Invalid volume overrides, storage class fast is overridden more than once"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedErrs, validateVolumeOverrides(test.overrides))
+		})
+	}
+}
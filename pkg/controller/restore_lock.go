@@ -0,0 +1,340 @@
+/*
+Copyright 2017 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+)
+
+const (
+	// restoreLockConfigMapName is the name of the config map in the Ark
+	// namespace that records the namespaces that restores are restoring into.
+	// Its data has an entry for each restore holding a lock, keyed by the
+	// restore's namespace and name joined by a ".", whose value is a
+	// comma-separated list of the namespaces the restore holds locks on,
+	// followed by a ";" and the time the locks were last renewed.
+	restoreLockConfigMapName = "ark-restore-locks"
+
+	// allNamespacesLock is the namespace lock held by restores that may
+	// restore into any namespace. It conflicts with every other lock.
+	allNamespacesLock = "*"
+
+	// restoreLockRetryPeriod is how often a queued restore tries to acquire
+	// the locks on the namespaces it restores into.
+	restoreLockRetryPeriod = 30 * time.Second
+
+	// restoreLockRenewPeriod is how often a running restore renews its locks.
+	restoreLockRenewPeriod = time.Minute
+
+	// restoreLockLeaseDuration is how long a restore's locks are held after
+	// they were last renewed, so that the locks of a restore that's left in
+	// progress because the server processing it stopped are released.
+	restoreLockLeaseDuration = 5 * time.Minute
+)
+
+// restoreLockConflict describes a namespace lock that a restore couldn't
+// acquire because another restore holds it.
+type restoreLockConflict struct {
+	namespace string
+	holder    string
+}
+
+func (c *restoreLockConflict) String() string {
+	if c.namespace == allNamespacesLock {
+		return fmt.Sprintf("Restore into all namespaces is locked by in-progress restore %s", c.holder)
+	}
+	return fmt.Sprintf("Namespace %s is locked by in-progress restore %s", c.namespace, c.holder)
+}
+
+// restoreLocker acquires and releases locks on the namespaces that restores
+// restore into, so that two restores never restore into the same namespace
+// at the same time. The locks are kept in a single config map that's updated
+// with optimistic concurrency, so acquiring them is atomic even when several
+// Ark servers process restores.
+type restoreLocker struct {
+	namespace       string
+	configMapClient corev1client.ConfigMapsGetter
+	restoreLister   listers.RestoreLister
+	clock           clock.Clock
+}
+
+func newRestoreLocker(namespace string, configMapClient corev1client.ConfigMapsGetter, restoreLister listers.RestoreLister) *restoreLocker {
+	return &restoreLocker{
+		namespace:       namespace,
+		configMapClient: configMapClient,
+		restoreLister:   restoreLister,
+		clock:           clock.RealClock{},
+	}
+}
+
+// acquire locks namespaces for restore. If another restore that's still
+// new or in progress holds a lock on any of them, none are locked and the
+// conflicting lock is returned. Locks held by restores that no longer exist,
+// have finished, or haven't renewed their locks within the lease duration are
+// stale, and are released.
+func (l *restoreLocker) acquire(restore *api.Restore, namespaces []string) (*restoreLockConflict, error) {
+	var conflict *restoreLockConflict
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		conflict = nil
+
+		configMap, err := l.configMapClient.ConfigMaps(l.namespace).Get(restoreLockConfigMapName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			configMap = &corev1api.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: l.namespace,
+					Name:      restoreLockConfigMapName,
+				},
+				Data: map[string]string{
+					restoreLockKey(restore): l.restoreLockEntry(namespaces),
+				},
+			}
+
+			_, err = l.configMapClient.ConfigMaps(l.namespace).Create(configMap)
+			if apierrors.IsAlreadyExists(err) {
+				// another restore created the config map first, so get it again
+				return apierrors.NewConflict(corev1api.Resource("configmaps"), restoreLockConfigMapName, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		wanted := sets.NewString(namespaces...)
+		for key, value := range configMap.Data {
+			if key == restoreLockKey(restore) {
+				continue
+			}
+
+			holder := restoreLockHolder(key)
+			held, renewed := parseRestoreLockEntry(value)
+			if !l.isHeld(holder, renewed) {
+				delete(configMap.Data, key)
+				continue
+			}
+
+			if conflict = namespaceLockConflict(wanted, held, holder); conflict != nil {
+				return nil
+			}
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[restoreLockKey(restore)] = l.restoreLockEntry(namespaces)
+
+		_, err = l.configMapClient.ConfigMaps(l.namespace).Update(configMap)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error updating restore lock config map")
+	}
+
+	return conflict, nil
+}
+
+// release releases the namespace locks held by restore.
+func (l *restoreLocker) release(restore *api.Restore) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := l.configMapClient.ConfigMaps(l.namespace).Get(restoreLockConfigMapName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, ok := configMap.Data[restoreLockKey(restore)]; !ok {
+			return nil
+		}
+		delete(configMap.Data, restoreLockKey(restore))
+
+		_, err = l.configMapClient.ConfigMaps(l.namespace).Update(configMap)
+		return err
+	})
+
+	return errors.Wrap(err, "error updating restore lock config map")
+}
+
+// renew records that restore still holds its locks, so that they aren't
+// released as stale while it runs.
+func (l *restoreLocker) renew(restore *api.Restore) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := l.configMapClient.ConfigMaps(l.namespace).Get(restoreLockConfigMapName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		value, ok := configMap.Data[restoreLockKey(restore)]
+		if !ok {
+			return nil
+		}
+		namespaces, _ := parseRestoreLockEntry(value)
+		configMap.Data[restoreLockKey(restore)] = l.restoreLockEntry(namespaces)
+
+		_, err = l.configMapClient.ConfigMaps(l.namespace).Update(configMap)
+		return err
+	})
+
+	return errors.Wrap(err, "error updating restore lock config map")
+}
+
+// renewUntilStopped renews restore's locks every restoreLockRenewPeriod until
+// the returned function is called.
+func (l *restoreLocker) renewUntilStopped(restore *api.Restore, log logrus.FieldLogger) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := l.clock.NewTicker(restoreLockRenewPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C():
+			}
+
+			if err := l.renew(restore); err != nil {
+				log.WithError(err).Warn("Error renewing restore namespace locks")
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// isHeld returns whether the restore named by holder, in namespace/name form,
+// still holds its locks, i.e. whether it exists, is new or in progress, and
+// renewed its locks within the lease duration. Locks without a renewal time
+// were acquired by older versions of Ark, and don't expire.
+func (l *restoreLocker) isHeld(holder string, renewed time.Time) bool {
+	if !renewed.IsZero() && l.clock.Since(renewed) > restoreLockLeaseDuration {
+		return false
+	}
+
+	ns, name := holder, ""
+	if i := strings.Index(holder, "/"); i >= 0 {
+		ns, name = holder[:i], holder[i+1:]
+	}
+
+	restore, err := l.restoreLister.Restores(ns).Get(name)
+	if err != nil {
+		return false
+	}
+
+	switch restore.Status.Phase {
+	case "", api.RestorePhaseNew, api.RestorePhaseInProgress:
+		return true
+	default:
+		return false
+	}
+}
+
+// namespaceLockConflict returns the first of held, the namespace locks held
+// by holder, that conflicts with the wanted locks, or nil if none do.
+func namespaceLockConflict(wanted sets.String, held []string, holder string) *restoreLockConflict {
+	for _, ns := range held {
+		if ns == allNamespacesLock || wanted.Has(ns) || wanted.Has(allNamespacesLock) {
+			return &restoreLockConflict{namespace: ns, holder: holder}
+		}
+	}
+	return nil
+}
+
+// restoreLockEntry returns the value of the restore lock config map entry of a
+// restore holding locks on namespaces, renewed now.
+func (l *restoreLocker) restoreLockEntry(namespaces []string) string {
+	return strings.Join(namespaces, ",") + ";" + l.clock.Now().UTC().Format(time.RFC3339)
+}
+
+// parseRestoreLockEntry returns the namespaces that a restore lock config map
+// entry holds locks on, and when they were last renewed, which is zero if the
+// entry doesn't record it.
+func parseRestoreLockEntry(value string) ([]string, time.Time) {
+	var renewed time.Time
+	if i := strings.LastIndex(value, ";"); i >= 0 {
+		renewed, _ = time.Parse(time.RFC3339, value[i+1:])
+		value = value[:i]
+	}
+
+	return strings.Split(value, ","), renewed
+}
+
+// restoreLockKey returns the key of restore's entry in the restore lock config
+// map. Namespace names can't contain dots, so the key is unambiguous.
+func restoreLockKey(restore *api.Restore) string {
+	return restore.Namespace + "." + restore.Name
+}
+
+// restoreLockHolder returns the namespace/name of the restore whose entry in
+// the restore lock config map has key.
+func restoreLockHolder(key string) string {
+	return strings.Replace(key, ".", "/", 1)
+}
+
+// restoreTargetNamespaces returns the sorted names of the namespaces that
+// restore may restore objects from backup into, or just allNamespacesLock if
+// they can't be known before the backup's contents are read.
+func restoreTargetNamespaces(restore *api.Restore, backup *api.Backup) []string {
+	included := restore.Spec.IncludedNamespaces
+	if len(included) == 0 || sets.NewString(included...).Has("*") {
+		included = backup.Spec.IncludedNamespaces
+	}
+	if len(included) == 0 || sets.NewString(included...).Has("*") {
+		return []string{allNamespacesLock}
+	}
+
+	excluded := sets.NewString(restore.Spec.ExcludedNamespaces...)
+	targets := sets.NewString()
+	for _, ns := range included {
+		if excluded.Has(ns) {
+			continue
+		}
+		if mapped, ok := restore.Spec.NamespaceMapping[ns]; ok && mapped != "" {
+			ns = mapped
+		}
+		targets.Insert(ns)
+	}
+
+	return targets.List()
+}
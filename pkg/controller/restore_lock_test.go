@@ -0,0 +1,289 @@
+/*
+Copyright 2017 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestRestoreLockerAcquire(t *testing.T) {
+	now := time.Date(2018, 4, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		held             map[string]string
+		holders          []*api.Restore
+		namespaces       []string
+		expectedConflict *restoreLockConflict
+		expectedData     map[string]string
+	}{
+		{
+			name:         "locks are acquired when there's no config map",
+			namespaces:   []string{"ns-1", "ns-2"},
+			expectedData: map[string]string{"heptio-ark.restore-1": "ns-1,ns-2;2018-04-04T12:00:00Z"},
+		},
+		{
+			name:       "locks on other namespaces don't conflict",
+			held:       map[string]string{"heptio-ark.restore-2": "ns-2"},
+			holders:    []*api.Restore{NewRestore(api.DefaultNamespace, "restore-2", "backup-1", "ns-2", "", api.RestorePhaseInProgress).Restore},
+			namespaces: []string{"ns-1"},
+			expectedData: map[string]string{
+				"heptio-ark.restore-1": "ns-1;2018-04-04T12:00:00Z",
+				"heptio-ark.restore-2": "ns-2",
+			},
+		},
+		{
+			name:             "lock on the same namespace conflicts",
+			held:             map[string]string{"heptio-ark.restore-2": "ns-0,ns-1"},
+			holders:          []*api.Restore{NewRestore(api.DefaultNamespace, "restore-2", "backup-1", "ns-1", "", api.RestorePhaseInProgress).Restore},
+			namespaces:       []string{"ns-1"},
+			expectedConflict: &restoreLockConflict{namespace: "ns-1", holder: "heptio-ark/restore-2"},
+			expectedData:     map[string]string{"heptio-ark.restore-2": "ns-0,ns-1"},
+		},
+		{
+			name:             "lock on all namespaces conflicts with any namespace",
+			held:             map[string]string{"heptio-ark.restore-2": "*"},
+			holders:          []*api.Restore{NewRestore(api.DefaultNamespace, "restore-2", "backup-1", "*", "", api.RestorePhaseNew).Restore},
+			namespaces:       []string{"ns-1"},
+			expectedConflict: &restoreLockConflict{namespace: "*", holder: "heptio-ark/restore-2"},
+			expectedData:     map[string]string{"heptio-ark.restore-2": "*"},
+		},
+		{
+			name:             "any namespace lock conflicts with all namespaces",
+			held:             map[string]string{"heptio-ark.restore-2": "ns-3"},
+			holders:          []*api.Restore{NewRestore(api.DefaultNamespace, "restore-2", "backup-1", "ns-3", "", api.RestorePhaseInProgress).Restore},
+			namespaces:       []string{"*"},
+			expectedConflict: &restoreLockConflict{namespace: "ns-3", holder: "heptio-ark/restore-2"},
+			expectedData:     map[string]string{"heptio-ark.restore-2": "ns-3"},
+		},
+		{
+			name: "stale locks of finished and deleted restores are released",
+			held: map[string]string{
+				"heptio-ark.restore-2": "ns-1",
+				"heptio-ark.restore-3": "ns-1",
+			},
+			holders:      []*api.Restore{NewRestore(api.DefaultNamespace, "restore-2", "backup-1", "ns-1", "", api.RestorePhaseCompleted).Restore},
+			namespaces:   []string{"ns-1"},
+			expectedData: map[string]string{"heptio-ark.restore-1": "ns-1;2018-04-04T12:00:00Z"},
+		},
+		{
+			name:             "locks renewed within the lease duration conflict",
+			held:             map[string]string{"heptio-ark.restore-2": "ns-1;2018-04-04T11:56:00Z"},
+			holders:          []*api.Restore{NewRestore(api.DefaultNamespace, "restore-2", "backup-1", "ns-1", "", api.RestorePhaseInProgress).Restore},
+			namespaces:       []string{"ns-1"},
+			expectedConflict: &restoreLockConflict{namespace: "ns-1", holder: "heptio-ark/restore-2"},
+			expectedData:     map[string]string{"heptio-ark.restore-2": "ns-1;2018-04-04T11:56:00Z"},
+		},
+		{
+			name:         "locks of in-progress restores that weren't renewed within the lease duration are released",
+			held:         map[string]string{"heptio-ark.restore-2": "*;2018-04-04T11:54:00Z"},
+			holders:      []*api.Restore{NewRestore(api.DefaultNamespace, "restore-2", "backup-1", "*", "", api.RestorePhaseInProgress).Restore},
+			namespaces:   []string{"ns-1"},
+			expectedData: map[string]string{"heptio-ark.restore-1": "ns-1;2018-04-04T12:00:00Z"},
+		},
+		{
+			name:         "restore's own locks are replaced",
+			held:         map[string]string{"heptio-ark.restore-1": "ns-1;2018-04-04T11:00:00Z"},
+			namespaces:   []string{"ns-2"},
+			expectedData: map[string]string{"heptio-ark.restore-1": "ns-2;2018-04-04T12:00:00Z"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				configMapClient = newFakeConfigMapClient()
+				restore         = NewRestore(api.DefaultNamespace, "restore-1", "backup-1", "ns-1", "", api.RestorePhaseNew).Restore
+			)
+
+			if test.held != nil {
+				configMapClient.configMaps[restoreLockConfigMapName] = &corev1api.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: restoreLockConfigMapName},
+					Data:       test.held,
+				}
+			}
+			for _, holder := range test.holders {
+				require.NoError(t, sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(holder))
+			}
+
+			locker := newRestoreLocker(api.DefaultNamespace, configMapClient, sharedInformers.Ark().V1().Restores().Lister())
+			locker.clock = clock.NewFakeClock(now)
+
+			conflict, err := locker.acquire(restore, test.namespaces)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedConflict, conflict)
+			assert.Equal(t, test.expectedData, configMapClient.configMaps[restoreLockConfigMapName].Data)
+		})
+	}
+}
+
+func TestRestoreLockerRelease(t *testing.T) {
+	var (
+		client          = fake.NewSimpleClientset()
+		sharedInformers = informers.NewSharedInformerFactory(client, 0)
+		configMapClient = newFakeConfigMapClient()
+		locker          = newRestoreLocker(api.DefaultNamespace, configMapClient, sharedInformers.Ark().V1().Restores().Lister())
+		restore         = NewRestore(api.DefaultNamespace, "restore-1", "backup-1", "ns-1", "", api.RestorePhaseInProgress).Restore
+	)
+
+	// releasing when there's no config map is a no-op
+	require.NoError(t, locker.release(restore))
+
+	configMapClient.configMaps[restoreLockConfigMapName] = &corev1api.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: restoreLockConfigMapName},
+		Data: map[string]string{
+			"heptio-ark.restore-1": "ns-1",
+			"heptio-ark.restore-2": "ns-2",
+		},
+	}
+
+	require.NoError(t, locker.release(restore))
+	assert.Equal(t, map[string]string{"heptio-ark.restore-2": "ns-2"}, configMapClient.configMaps[restoreLockConfigMapName].Data)
+}
+
+func TestRestoreLockerRenew(t *testing.T) {
+	var (
+		client          = fake.NewSimpleClientset()
+		sharedInformers = informers.NewSharedInformerFactory(client, 0)
+		configMapClient = newFakeConfigMapClient()
+		locker          = newRestoreLocker(api.DefaultNamespace, configMapClient, sharedInformers.Ark().V1().Restores().Lister())
+		restore         = NewRestore(api.DefaultNamespace, "restore-1", "backup-1", "ns-1", "", api.RestorePhaseInProgress).Restore
+	)
+	locker.clock = clock.NewFakeClock(time.Date(2018, 4, 4, 12, 0, 0, 0, time.UTC))
+
+	// renewing when there's no config map is a no-op
+	require.NoError(t, locker.renew(restore))
+
+	configMapClient.configMaps[restoreLockConfigMapName] = &corev1api.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: restoreLockConfigMapName},
+		Data: map[string]string{
+			"heptio-ark.restore-1": "ns-1,ns-2;2018-04-04T11:59:00Z",
+			"heptio-ark.restore-2": "ns-3;2018-04-04T11:59:00Z",
+		},
+	}
+
+	// only the restore's own entry is renewed
+	require.NoError(t, locker.renew(restore))
+	assert.Equal(t, map[string]string{
+		"heptio-ark.restore-1": "ns-1,ns-2;2018-04-04T12:00:00Z",
+		"heptio-ark.restore-2": "ns-3;2018-04-04T11:59:00Z",
+	}, configMapClient.configMaps[restoreLockConfigMapName].Data)
+
+	// released locks aren't renewed
+	require.NoError(t, locker.release(restore))
+	require.NoError(t, locker.renew(restore))
+	assert.Equal(t, map[string]string{"heptio-ark.restore-2": "ns-3;2018-04-04T11:59:00Z"}, configMapClient.configMaps[restoreLockConfigMapName].Data)
+}
+
+func TestParseRestoreLockEntry(t *testing.T) {
+	namespaces, renewed := parseRestoreLockEntry("ns-1,ns-2;2018-04-04T12:00:00Z")
+	assert.Equal(t, []string{"ns-1", "ns-2"}, namespaces)
+	assert.Equal(t, time.Date(2018, 4, 4, 12, 0, 0, 0, time.UTC), renewed)
+
+	// entries written by older versions of Ark don't record when they were renewed
+	namespaces, renewed = parseRestoreLockEntry("*")
+	assert.Equal(t, []string{"*"}, namespaces)
+	assert.True(t, renewed.IsZero())
+}
+
+func TestRestoreTargetNamespaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		restore  *api.Restore
+		backup   *api.Backup
+		expected []string
+	}{
+		{
+			name:     "restore's included namespaces are mapped",
+			restore:  NewRestore("foo", "bar", "backup-1", "ns-2", "", api.RestorePhaseNew).WithIncludedNamespace("ns-1").WithMappedNamespace("ns-1", "ns-3").Restore,
+			backup:   arktest.NewTestBackup().WithName("backup-1").Backup,
+			expected: []string{"ns-2", "ns-3"},
+		},
+		{
+			name:     "backup's included namespaces are used when the restore includes all",
+			restore:  NewRestore("foo", "bar", "backup-1", "*", "", api.RestorePhaseNew).WithExcludedNamespace("ns-2").Restore,
+			backup:   arktest.NewTestBackup().WithName("backup-1").WithIncludedNamespaces("ns-1", "ns-2").Backup,
+			expected: []string{"ns-1"},
+		},
+		{
+			name:     "all namespaces are locked when neither the restore nor the backup list them",
+			restore:  NewRestore("foo", "bar", "backup-1", "*", "", api.RestorePhaseNew).Restore,
+			backup:   arktest.NewTestBackup().WithName("backup-1").Backup,
+			expected: []string{allNamespacesLock},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, restoreTargetNamespaces(test.restore, test.backup))
+		})
+	}
+}
+
+// fakeConfigMapClient gets, creates and updates config maps in its map, keyed
+// by name. Calling any other method panics.
+type fakeConfigMapClient struct {
+	corev1client.ConfigMapInterface
+
+	configMaps map[string]*corev1api.ConfigMap
+}
+
+func newFakeConfigMapClient() *fakeConfigMapClient {
+	return &fakeConfigMapClient{configMaps: make(map[string]*corev1api.ConfigMap)}
+}
+
+func (c *fakeConfigMapClient) ConfigMaps(namespace string) corev1client.ConfigMapInterface {
+	return c
+}
+
+func (c *fakeConfigMapClient) Get(name string, options metav1.GetOptions) (*corev1api.ConfigMap, error) {
+	configMap, ok := c.configMaps[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(corev1api.Resource("configmaps"), name)
+	}
+	return configMap.DeepCopy(), nil
+}
+
+func (c *fakeConfigMapClient) Create(configMap *corev1api.ConfigMap) (*corev1api.ConfigMap, error) {
+	if _, ok := c.configMaps[configMap.Name]; ok {
+		return nil, apierrors.NewAlreadyExists(corev1api.Resource("configmaps"), configMap.Name)
+	}
+	c.configMaps[configMap.Name] = configMap
+	return configMap, nil
+}
+
+func (c *fakeConfigMapClient) Update(configMap *corev1api.ConfigMap) (*corev1api.ConfigMap, error) {
+	c.configMaps[configMap.Name] = configMap
+	return configMap, nil
+}
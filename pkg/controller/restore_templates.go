@@ -0,0 +1,153 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// restoreTemplateTimestampFormat is the format of the Timestamp template variable,
+// which is the same one used in the names of scheduled backups and generated restores.
+const restoreTemplateTimestampFormat = "20060102150405"
+
+// restoreTemplateData is the data that templates in a restore's spec are executed with.
+type restoreTemplateData struct {
+	Restore   *api.Restore
+	Backup    *api.Backup
+	Timestamp string
+}
+
+// expandRestoreTemplates executes the templates in the values of restore's namespace
+// mapping, additional labels and additional annotations, and in the replacements of
+// its hostname rewrites, replacing them with the results so that the restore records
+// the names it actually used. It returns a validation error for each template that
+// can't be executed, and for each mapping or label whose template doesn't produce a
+// valid namespace name or label value.
+func expandRestoreTemplates(restore *api.Restore, backup *api.Backup, now time.Time) []string {
+	data := &restoreTemplateData{
+		Restore:   restore.DeepCopy(),
+		Backup:    backup,
+		Timestamp: now.UTC().Format(restoreTemplateTimestampFormat),
+	}
+
+	var errs []string
+
+	for _, source := range sortedKeys(restore.Spec.NamespaceMapping) {
+		target := restore.Spec.NamespaceMapping[source]
+		if !isTemplate(target) {
+			continue
+		}
+
+		expanded, err := executeRestoreTemplate(target, data)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Invalid namespace mapping template for %s: %v", source, err))
+			continue
+		}
+		if msgs := validation.IsDNS1123Label(expanded); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("Namespace mapping template for %s produced invalid namespace name %q: %s", source, expanded, strings.Join(msgs, "; ")))
+			continue
+		}
+		restore.Spec.NamespaceMapping[source] = expanded
+	}
+
+	for _, key := range sortedKeys(restore.Spec.AdditionalLabels) {
+		val := restore.Spec.AdditionalLabels[key]
+		if !isTemplate(val) {
+			continue
+		}
+
+		expanded, err := executeRestoreTemplate(val, data)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Invalid additional label template for %s: %v", key, err))
+			continue
+		}
+		if msgs := validation.IsValidLabelValue(expanded); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("Additional label template for %s produced invalid value %q: %s", key, expanded, strings.Join(msgs, "; ")))
+			continue
+		}
+		restore.Spec.AdditionalLabels[key] = expanded
+	}
+
+	for _, key := range sortedKeys(restore.Spec.AdditionalAnnotations) {
+		val := restore.Spec.AdditionalAnnotations[key]
+		if !isTemplate(val) {
+			continue
+		}
+
+		expanded, err := executeRestoreTemplate(val, data)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Invalid additional annotation template for %s: %v", key, err))
+			continue
+		}
+		restore.Spec.AdditionalAnnotations[key] = expanded
+	}
+
+	for i, rewrite := range restore.Spec.HostnameRewrites {
+		if !isTemplate(rewrite.Replacement) {
+			continue
+		}
+
+		expanded, err := executeRestoreTemplate(rewrite.Replacement, data)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Invalid hostname rewrite replacement template %q: %v", rewrite.Replacement, err))
+			continue
+		}
+		restore.Spec.HostnameRewrites[i].Replacement = expanded
+	}
+
+	return errs
+}
+
+// sortedKeys returns m's keys in order, so that validation errors are reported in
+// the same order every time.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isTemplate returns whether val contains a template action.
+func isTemplate(val string) bool {
+	return strings.Contains(val, "{{")
+}
+
+func executeRestoreTemplate(text string, data *restoreTemplateData) (string, error) {
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestExpandRestoreTemplates(t *testing.T) {
+	now := time.Date(2018, 10, 5, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                     string
+		namespaceMapping         map[string]string
+		hostnameRewrites         []api.HostnameRewrite
+		additionalLabels         map[string]string
+		additionalAnnotations    map[string]string
+		expectedNamespaceMapping map[string]string
+		expectedHostnameRewrites []api.HostnameRewrite
+		expectedLabels           map[string]string
+		expectedAnnotations      map[string]string
+		// expectedErrs are prefixes of the expected errors, which end in
+		// messages from the template and validation packages
+		expectedErrs []string
+	}{
+		{
+			name:                     "values without templates are unchanged",
+			namespaceMapping:         map[string]string{"ns-1": "ns-2"},
+			hostnameRewrites:         []api.HostnameRewrite{{Suffix: "example.com", Replacement: "staging.example.com"}},
+			expectedNamespaceMapping: map[string]string{"ns-1": "ns-2"},
+			expectedHostnameRewrites: []api.HostnameRewrite{{Suffix: "example.com", Replacement: "staging.example.com"}},
+		},
+		{
+			name: "namespace mapping templates are expanded",
+			namespaceMapping: map[string]string{
+				"ns-1": "ns-1-{{ .Restore.Name }}",
+				"ns-2": "{{ .Backup.Name }}-{{ .Timestamp }}",
+			},
+			expectedNamespaceMapping: map[string]string{
+				"ns-1": "ns-1-restore-1",
+				"ns-2": "backup-1-20181005143000",
+			},
+		},
+		{
+			name:                     "hostname rewrite templates are expanded",
+			hostnameRewrites:         []api.HostnameRewrite{{Regex: `^(\w+)\.example\.com$`, Replacement: "$1.{{ .Restore.Name }}.example.com"}},
+			expectedHostnameRewrites: []api.HostnameRewrite{{Regex: `^(\w+)\.example\.com$`, Replacement: "$1.restore-1.example.com"}},
+		},
+		{
+			name:                  "additional label and annotation templates are expanded",
+			additionalLabels:      map[string]string{"clone": "{{ .Restore.Name }}", "team": "qa"},
+			additionalAnnotations: map[string]string{"example.com/source": "{{ .Backup.Name }} at {{ .Timestamp }}"},
+			expectedLabels:        map[string]string{"clone": "restore-1", "team": "qa"},
+			expectedAnnotations:   map[string]string{"example.com/source": "backup-1 at 20181005143000"},
+		},
+		{
+			name:             "label templates must produce valid label values",
+			additionalLabels: map[string]string{"source": "{{ .Backup.Name }} at {{ .Timestamp }}"},
+			expectedLabels:   map[string]string{"source": "{{ .Backup.Name }} at {{ .Timestamp }}"},
+			expectedErrs: []string{
+				"Additional label template for source produced invalid value \"backup-1 at 20181005143000\": ",
+			},
+		},
+		{
+			name: "invalid templates are validation errors",
+			namespaceMapping: map[string]string{
+				"ns-1": "{{ .Restore.Name",
+				"ns-2": "{{ .Nope }}",
+			},
+			hostnameRewrites: []api.HostnameRewrite{{Suffix: "example.com", Replacement: "{{ .Backup.Nope }}"}},
+			expectedNamespaceMapping: map[string]string{
+				"ns-1": "{{ .Restore.Name",
+				"ns-2": "{{ .Nope }}",
+			},
+			expectedHostnameRewrites: []api.HostnameRewrite{{Suffix: "example.com", Replacement: "{{ .Backup.Nope }}"}},
+			expectedErrs: []string{
+				"Invalid namespace mapping template for ns-1: template:",
+				"Invalid namespace mapping template for ns-2: template:",
+				"Invalid hostname rewrite replacement template \"{{ .Backup.Nope }}\": template:",
+			},
+		},
+		{
+			name:                     "templates must produce valid namespace names",
+			namespaceMapping:         map[string]string{"ns-1": "{{ .Restore.Name }}_copy"},
+			expectedNamespaceMapping: map[string]string{"ns-1": "{{ .Restore.Name }}_copy"},
+			expectedErrs: []string{
+				"Namespace mapping template for ns-1 produced invalid namespace name \"restore-1_copy\": ",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restore := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseNew).WithBackup("backup-1").Restore
+			restore.Spec.NamespaceMapping = test.namespaceMapping
+			restore.Spec.HostnameRewrites = test.hostnameRewrites
+			restore.Spec.AdditionalLabels = test.additionalLabels
+			restore.Spec.AdditionalAnnotations = test.additionalAnnotations
+			backup := arktest.NewTestBackup().WithName("backup-1").Backup
+
+			errs := expandRestoreTemplates(restore, backup, now)
+
+			if assert.Len(t, errs, len(test.expectedErrs)) {
+				for i := range errs {
+					assert.True(t, strings.HasPrefix(errs[i], test.expectedErrs[i]), "error %q doesn't start with %q", errs[i], test.expectedErrs[i])
+				}
+			}
+			assert.Equal(t, test.expectedNamespaceMapping, restore.Spec.NamespaceMapping)
+			assert.Equal(t, test.expectedHostnameRewrites, restore.Spec.HostnameRewrites)
+			assert.Equal(t, test.expectedLabels, restore.Spec.AdditionalLabels)
+			assert.Equal(t, test.expectedAnnotations, restore.Spec.AdditionalAnnotations)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// RestoreTracker keeps track of in-progress restores.
+type RestoreTracker interface {
+	// Add informs the tracker that a restore is in progress.
+	Add(ns, name string)
+	// Delete informs the tracker that a restore is no longer in progress.
+	Delete(ns, name string)
+	// Contains returns true if the tracker is tracking the restore.
+	Contains(ns, name string) bool
+}
+
+type restoreTracker struct {
+	lock     sync.RWMutex
+	restores sets.String
+}
+
+// NewRestoreTracker returns a new RestoreTracker.
+func NewRestoreTracker() RestoreTracker {
+	return &restoreTracker{
+		restores: sets.NewString(),
+	}
+}
+
+func (rt *restoreTracker) Add(ns, name string) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	rt.restores.Insert(restoreTrackerKey(ns, name))
+}
+
+func (rt *restoreTracker) Delete(ns, name string) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	rt.restores.Delete(restoreTrackerKey(ns, name))
+}
+
+func (rt *restoreTracker) Contains(ns, name string) bool {
+	rt.lock.RLock()
+	defer rt.lock.RUnlock()
+
+	return rt.restores.Has(restoreTrackerKey(ns, name))
+}
+
+func restoreTrackerKey(ns, name string) string {
+	return fmt.Sprintf("%s/%s", ns, name)
+}
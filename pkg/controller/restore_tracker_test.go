@@ -0,0 +1,43 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreTracker(t *testing.T) {
+	rt := NewRestoreTracker()
+
+	assert.False(t, rt.Contains("ns", "name"))
+
+	rt.Add("ns", "name")
+	assert.True(t, rt.Contains("ns", "name"))
+
+	rt.Add("ns2", "name2")
+	assert.True(t, rt.Contains("ns", "name"))
+	assert.True(t, rt.Contains("ns2", "name2"))
+
+	rt.Delete("ns", "name")
+	assert.False(t, rt.Contains("ns", "name"))
+	assert.True(t, rt.Contains("ns2", "name2"))
+
+	rt.Delete("ns2", "name2")
+	assert.False(t, rt.Contains("ns2", "name2"))
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/client"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/persistence"
+	"github.com/heptio/ark/pkg/plugin"
+	pkgrestore "github.com/heptio/ark/pkg/restore"
+)
+
+type restoreUndoController struct {
+	*genericController
+
+	undoRestoreRequestClient arkv1client.UndoRestoreRequestsGetter
+	undoRestoreRequestLister listers.UndoRestoreRequestLister
+	restoreLister            listers.RestoreLister
+	backupLister             listers.BackupLister
+	backupLocationLister     listers.BackupStorageLocationLister
+	dynamicFactory           client.DynamicFactory
+	namespaceClient          corev1.NamespaceInterface
+	processRequestFunc       func(*v1.UndoRestoreRequest) error
+	clock                    clock.Clock
+	newPluginManager         func(logrus.FieldLogger) plugin.Manager
+	newBackupStore           func(*v1.BackupStorageLocation, persistence.ObjectStoreGetter, logrus.FieldLogger) (persistence.BackupStore, error)
+}
+
+// NewRestoreUndoController creates a new controller that processes UndoRestoreRequests
+// by deleting the objects, and optionally the namespaces, that restores created.
+func NewRestoreUndoController(
+	logger logrus.FieldLogger,
+	undoRestoreRequestInformer informers.UndoRestoreRequestInformer,
+	undoRestoreRequestClient arkv1client.UndoRestoreRequestsGetter,
+	restoreInformer informers.RestoreInformer,
+	backupInformer informers.BackupInformer,
+	backupLocationInformer informers.BackupStorageLocationInformer,
+	dynamicFactory client.DynamicFactory,
+	namespaceClient corev1.NamespaceInterface,
+	newPluginManager func(logrus.FieldLogger) plugin.Manager,
+) Interface {
+	c := &restoreUndoController{
+		genericController:        newGenericController("restore-undo", logger),
+		undoRestoreRequestClient: undoRestoreRequestClient,
+		undoRestoreRequestLister: undoRestoreRequestInformer.Lister(),
+		restoreLister:            restoreInformer.Lister(),
+		backupLister:             backupInformer.Lister(),
+		backupLocationLister:     backupLocationInformer.Lister(),
+		dynamicFactory:           dynamicFactory,
+		namespaceClient:          namespaceClient,
+
+		// use variables to refer to these functions so they can be
+		// replaced with fakes for testing.
+		newPluginManager: newPluginManager,
+		newBackupStore:   persistence.NewObjectBackupStore,
+
+		clock: &clock.RealClock{},
+	}
+
+	c.syncHandler = c.processQueueItem
+	c.cacheSyncWaiters = append(
+		c.cacheSyncWaiters,
+		undoRestoreRequestInformer.Informer().HasSynced,
+		restoreInformer.Informer().HasSynced,
+		backupInformer.Informer().HasSynced,
+		backupLocationInformer.Informer().HasSynced,
+	)
+	c.processRequestFunc = c.processRequest
+
+	undoRestoreRequestInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueue,
+		},
+	)
+
+	c.resyncPeriod = time.Hour
+	c.resyncFunc = c.deleteExpiredRequests
+
+	return c
+}
+
+func (c *restoreUndoController) processQueueItem(key string) error {
+	log := c.logger.WithField("key", key)
+	log.Debug("Running processItem")
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	req, err := c.undoRestoreRequestLister.UndoRestoreRequests(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Unable to find UndoRestoreRequest")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting UndoRestoreRequest")
+	}
+
+	switch req.Status.Phase {
+	case v1.UndoRestoreRequestPhaseProcessed:
+		// Don't do anything because it's already been processed
+	default:
+		// Don't mutate the shared cache
+		reqCopy := req.DeepCopy()
+		return c.processRequestFunc(reqCopy)
+	}
+
+	return nil
+}
+
+func (c *restoreUndoController) processRequest(req *v1.UndoRestoreRequest) error {
+	log := c.logger.WithFields(logrus.Fields{
+		"namespace": req.Namespace,
+		"name":      req.Name,
+		"restore":   req.Spec.RestoreName,
+	})
+
+	var err error
+
+	// Make sure we have the restore name
+	if req.Spec.RestoreName == "" {
+		_, err = c.patchUndoRestoreRequest(req, func(r *v1.UndoRestoreRequest) {
+			r.Status.Phase = v1.UndoRestoreRequestPhaseProcessed
+			r.Status.Errors = []string{"spec.restoreName is required"}
+		})
+		return err
+	}
+
+	restore, err := c.restoreLister.Restores(req.Namespace).Get(req.Spec.RestoreName)
+	if apierrors.IsNotFound(err) {
+		_, err = c.patchUndoRestoreRequest(req, func(r *v1.UndoRestoreRequest) {
+			r.Status.Phase = v1.UndoRestoreRequestPhaseProcessed
+			r.Status.Errors = []string{"restore not found"}
+		})
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting Restore")
+	}
+
+	// Only finished restores can be undone, since the manifest of the objects
+	// a restore created is uploaded once it finishes.
+	if restore.Status.Phase != v1.RestorePhaseCompleted && restore.Status.Phase != v1.RestorePhaseFailed {
+		_, err = c.patchUndoRestoreRequest(req, func(r *v1.UndoRestoreRequest) {
+			r.Status.Phase = v1.UndoRestoreRequestPhaseProcessed
+			r.Status.Errors = []string{fmt.Sprintf("restore has phase %s, but only completed or failed restores can be undone", restore.Status.Phase)}
+		})
+		return err
+	}
+
+	// Update status to InProgress and set restore-name label if needed
+	req, err = c.patchUndoRestoreRequest(req, func(r *v1.UndoRestoreRequest) {
+		r.Status.Phase = v1.UndoRestoreRequestPhaseInProgress
+
+		if r.Labels == nil {
+			r.Labels = make(map[string]string)
+		}
+		r.Labels[v1.RestoreNameLabel] = r.Spec.RestoreName
+	})
+	if err != nil {
+		return err
+	}
+
+	pluginManager := c.newPluginManager(log)
+	defer pluginManager.CleanupClients()
+
+	manifest, manifestErr := c.getManifest(restore, pluginManager, log)
+	if manifestErr != nil {
+		_, err = c.patchUndoRestoreRequest(req, func(r *v1.UndoRestoreRequest) {
+			r.Status.Phase = v1.UndoRestoreRequestPhaseProcessed
+			r.Status.Errors = []string{manifestErr.Error()}
+		})
+		return err
+	}
+
+	status := c.undo(manifest, req.Spec.DeleteNamespaces, log)
+
+	_, err = c.patchUndoRestoreRequest(req, func(r *v1.UndoRestoreRequest) {
+		r.Status = status
+		r.Status.Phase = v1.UndoRestoreRequestPhaseProcessed
+	})
+	return err
+}
+
+// getManifest downloads the manifest of the objects created by restore from the
+// storage location of the restore's backup.
+func (c *restoreUndoController) getManifest(restore *v1.Restore, pluginManager plugin.Manager, log logrus.FieldLogger) (*pkgrestore.Manifest, error) {
+	backup, err := c.backupLister.Backups(restore.Namespace).Get(restore.Spec.BackupName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting backup %s", restore.Spec.BackupName)
+	}
+
+	location, err := c.backupLocationLister.BackupStorageLocations(backup.Namespace).Get(backup.Spec.StorageLocation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting backup storage location %s", backup.Spec.StorageLocation)
+	}
+
+	if location, err = persistence.BackupLocation(location, backup); err != nil {
+		return nil, err
+	}
+
+	backupStore, err := c.newBackupStore(location, pluginManager, log)
+	if err != nil {
+		return nil, err
+	}
+
+	rdr, err := backupStore.GetRestoreManifest(restore.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting restore manifest")
+	}
+	if rdr == nil {
+		return nil, errors.New("restore has no manifest of the objects it created, so it can't be undone")
+	}
+	defer rdr.Close()
+
+	return pkgrestore.DecodeManifest(rdr)
+}
+
+// undo deletes the objects in manifest, in the reverse of the order they were created
+// in, and then the manifest's namespaces if deleteNamespaces is true. Each object is
+// only deleted if its UID still matches the manifest, so objects that were deleted and
+// recreated since the restore are left alone.
+func (c *restoreUndoController) undo(manifest *pkgrestore.Manifest, deleteNamespaces bool, log logrus.FieldLogger) v1.UndoRestoreRequestStatus {
+	var status v1.UndoRestoreRequestStatus
+
+	for i := len(manifest.Items) - 1; i >= 0; i-- {
+		item := manifest.Items[i]
+		itemLog := log.WithFields(logrus.Fields{
+			"resource":  item.Resource,
+			"namespace": item.Namespace,
+			"name":      item.Name,
+		})
+
+		gv, err := schema.ParseGroupVersion(item.APIVersion)
+		if err != nil {
+			status.Errors = append(status.Errors, errors.Wrapf(err, "error parsing apiVersion of %s %s", item.Resource, manifestEntryName(item)).Error())
+			continue
+		}
+		resource := metav1.APIResource{Name: schema.ParseGroupResource(item.Resource).Resource}
+
+		resourceClient, err := c.dynamicFactory.ClientForGroupVersionResource(gv, resource, item.Namespace)
+		if err != nil {
+			status.Errors = append(status.Errors, errors.Wrapf(err, "error getting client for %s", item.Resource).Error())
+			continue
+		}
+
+		err = resourceClient.Delete(item.Name, deleteOptionsForUID(item.UID))
+		switch {
+		case err == nil:
+			itemLog.Info("Deleted restored object")
+			status.DeletedItems++
+		case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+			// the object is gone, or its UID no longer matches because it was
+			// recreated since the restore
+			itemLog.Info("Restored object no longer exists, skipping")
+			status.SkippedItems++
+		default:
+			status.Errors = append(status.Errors, errors.Wrapf(err, "error deleting %s %s", item.Resource, manifestEntryName(item)).Error())
+		}
+	}
+
+	if !deleteNamespaces {
+		return status
+	}
+
+	for _, ns := range manifest.Namespaces {
+		err := c.namespaceClient.Delete(ns.Name, deleteOptionsForUID(ns.UID))
+		switch {
+		case err == nil:
+			log.WithField("namespace", ns.Name).Info("Deleted restored namespace")
+			status.DeletedNamespaces++
+		case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+			log.WithField("namespace", ns.Name).Info("Restored namespace no longer exists, skipping")
+		default:
+			status.Errors = append(status.Errors, errors.Wrapf(err, "error deleting namespace %s", ns.Name).Error())
+		}
+	}
+
+	return status
+}
+
+// deleteOptionsForUID returns options for deleting only the object with the given
+// UID, and deleting its dependents, such as a deployment's replica sets, in the
+// background.
+func deleteOptionsForUID(uid types.UID) *metav1.DeleteOptions {
+	propagation := metav1.DeletePropagationBackground
+
+	return &metav1.DeleteOptions{
+		Preconditions:     &metav1.Preconditions{UID: &uid},
+		PropagationPolicy: &propagation,
+	}
+}
+
+func manifestEntryName(item pkgrestore.ManifestEntry) string {
+	if item.Namespace == "" {
+		return item.Name
+	}
+	return item.Namespace + "/" + item.Name
+}
+
+const undoRestoreRequestMaxAge = 24 * time.Hour
+
+func (c *restoreUndoController) deleteExpiredRequests() {
+	c.logger.Info("Checking for expired UndoRestoreRequests")
+	defer c.logger.Info("Done checking for expired UndoRestoreRequests")
+
+	// Our shared informer factory filters on a single namespace, so asking for all is ok here.
+	requests, err := c.undoRestoreRequestLister.List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(err).Error("unable to check for expired UndoRestoreRequests")
+		return
+	}
+
+	now := c.clock.Now()
+
+	for _, req := range requests {
+		if req.Status.Phase != v1.UndoRestoreRequestPhaseProcessed {
+			continue
+		}
+
+		age := now.Sub(req.CreationTimestamp.Time)
+		if age >= undoRestoreRequestMaxAge {
+			reqLog := c.logger.WithFields(logrus.Fields{"namespace": req.Namespace, "name": req.Name})
+			reqLog.Info("Deleting expired UndoRestoreRequest")
+
+			err = c.undoRestoreRequestClient.UndoRestoreRequests(req.Namespace).Delete(req.Name, nil)
+			if err != nil {
+				reqLog.WithError(err).Error("Error deleting UndoRestoreRequest")
+			}
+		}
+	}
+}
+
+func (c *restoreUndoController) patchUndoRestoreRequest(req *v1.UndoRestoreRequest, mutate func(*v1.UndoRestoreRequest)) (*v1.UndoRestoreRequest, error) {
+	// Record original json
+	oldData, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling original UndoRestoreRequest")
+	}
+
+	// Mutate
+	mutate(req)
+
+	// Record new json
+	newData, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling updated UndoRestoreRequest")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(oldData, newData)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating json merge patch for UndoRestoreRequest")
+	}
+
+	req, err = c.undoRestoreRequestClient.UndoRestoreRequests(req.Namespace).Patch(req.Name, types.MergePatchType, patchBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error patching UndoRestoreRequest")
+	}
+
+	return req, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/persistence"
+	persistencemocks "github.com/heptio/ark/pkg/persistence/mocks"
+	"github.com/heptio/ark/pkg/plugin"
+	pluginmocks "github.com/heptio/ark/pkg/plugin/mocks"
+	pkgrestore "github.com/heptio/ark/pkg/restore"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+type fakeUndoNamespaceClient struct {
+	corev1.NamespaceInterface
+
+	deleted []string
+}
+
+func (c *fakeUndoNamespaceClient) Delete(name string, opts *metav1.DeleteOptions) error {
+	c.deleted = append(c.deleted, name)
+	return nil
+}
+
+func TestRestoreUndoControllerProcessRequest(t *testing.T) {
+	manifest := pkgrestore.NewManifest()
+	manifest.Namespaces = []pkgrestore.ManifestEntry{
+		{Resource: "namespaces", APIVersion: "v1", Name: "ns-1", UID: "ns-1-uid"},
+	}
+	manifest.Items = []pkgrestore.ManifestEntry{
+		{Resource: "configmaps", APIVersion: "v1", Namespace: "ns-1", Name: "cm-1", UID: "cm-1-uid"},
+		{Resource: "deployments.apps", APIVersion: "apps/v1", Namespace: "ns-1", Name: "deploy-1", UID: "deploy-1-uid"},
+	}
+	var manifestBuf bytes.Buffer
+	require.NoError(t, manifest.Encode(&manifestBuf))
+
+	tests := []struct {
+		name                 string
+		restore              *v1.Restore
+		manifest             []byte
+		deleteNamespaces     bool
+		expectedStatus       v1.UndoRestoreRequestStatus
+		expectedDeletedNames []string
+		expectedNamespaces   []string
+	}{
+		{
+			name: "restore not found",
+			expectedStatus: v1.UndoRestoreRequestStatus{
+				Phase:  v1.UndoRestoreRequestPhaseProcessed,
+				Errors: []string{"restore not found"},
+			},
+		},
+		{
+			name:    "in-progress restore can't be undone",
+			restore: arktest.NewTestRestore(v1.DefaultNamespace, "restore-1", v1.RestorePhaseInProgress).WithBackup("backup-1").Restore,
+			expectedStatus: v1.UndoRestoreRequestStatus{
+				Phase:  v1.UndoRestoreRequestPhaseProcessed,
+				Errors: []string{"restore has phase InProgress, but only completed or failed restores can be undone"},
+			},
+		},
+		{
+			name:    "restore without a manifest can't be undone",
+			restore: arktest.NewTestRestore(v1.DefaultNamespace, "restore-1", v1.RestorePhaseCompleted).WithBackup("backup-1").Restore,
+			expectedStatus: v1.UndoRestoreRequestStatus{
+				Phase:  v1.UndoRestoreRequestPhaseProcessed,
+				Errors: []string{"restore has no manifest of the objects it created, so it can't be undone"},
+			},
+		},
+		{
+			name:                 "created objects are deleted in reverse order, skipping missing ones",
+			restore:              arktest.NewTestRestore(v1.DefaultNamespace, "restore-1", v1.RestorePhaseCompleted).WithBackup("backup-1").Restore,
+			manifest:             manifestBuf.Bytes(),
+			expectedDeletedNames: []string{"deploy-1", "cm-1"},
+			expectedStatus: v1.UndoRestoreRequestStatus{
+				Phase:        v1.UndoRestoreRequestPhaseProcessed,
+				DeletedItems: 1,
+				SkippedItems: 1,
+			},
+		},
+		{
+			name:                 "created namespaces are deleted when requested",
+			restore:              arktest.NewTestRestore(v1.DefaultNamespace, "restore-1", v1.RestorePhaseFailed).WithBackup("backup-1").Restore,
+			manifest:             manifestBuf.Bytes(),
+			deleteNamespaces:     true,
+			expectedDeletedNames: []string{"deploy-1", "cm-1"},
+			expectedNamespaces:   []string{"ns-1"},
+			expectedStatus: v1.UndoRestoreRequestStatus{
+				Phase:             v1.UndoRestoreRequestPhaseProcessed,
+				DeletedItems:      1,
+				SkippedItems:      1,
+				DeletedNamespaces: 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				pluginManager   = &pluginmocks.Manager{}
+				backupStore     = &persistencemocks.BackupStore{}
+				dynamicFactory  = &arktest.FakeDynamicFactory{}
+				namespaceClient = &fakeUndoNamespaceClient{}
+			)
+
+			c := NewRestoreUndoController(
+				arktest.NewLogger(),
+				sharedInformers.Ark().V1().UndoRestoreRequests(),
+				client.ArkV1(),
+				sharedInformers.Ark().V1().Restores(),
+				sharedInformers.Ark().V1().Backups(),
+				sharedInformers.Ark().V1().BackupStorageLocations(),
+				dynamicFactory,
+				namespaceClient,
+				func(logrus.FieldLogger) plugin.Manager { return pluginManager },
+			).(*restoreUndoController)
+			c.newBackupStore = func(*v1.BackupStorageLocation, persistence.ObjectStoreGetter, logrus.FieldLogger) (persistence.BackupStore, error) {
+				return backupStore, nil
+			}
+			pluginManager.On("CleanupClients").Return(nil)
+
+			if test.restore != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(test.restore))
+			}
+			backup := arktest.NewTestBackup().WithNamespace(v1.DefaultNamespace).WithName("backup-1").WithStorageLocation("default").Backup
+			require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(backup))
+			location := arktest.NewTestBackupStorageLocation().WithNamespace(v1.DefaultNamespace).WithName("default").BackupStorageLocation
+			require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(location))
+
+			if test.manifest != nil {
+				backupStore.On("GetRestoreManifest", "restore-1").Return(ioutil.NopCloser(bytes.NewReader(test.manifest)), nil)
+			} else {
+				backupStore.On("GetRestoreManifest", "restore-1").Return(nil, nil)
+			}
+
+			var deletedNames []string
+			recordDelete := func(args mock.Arguments) { deletedNames = append(deletedNames, args.String(0)) }
+
+			configMapClient := &arktest.FakeDynamicClient{}
+			configMapClient.On("Delete", "cm-1", mock.Anything).Run(recordDelete).Return(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cm-1"))
+			dynamicFactory.On("ClientForGroupVersionResource", schema.GroupVersion{Version: "v1"}, metav1.APIResource{Name: "configmaps"}, "ns-1").Return(configMapClient, nil)
+
+			deploymentClient := &arktest.FakeDynamicClient{}
+			deploymentClient.On("Delete", "deploy-1", mock.Anything).Run(recordDelete).Return(nil)
+			dynamicFactory.On("ClientForGroupVersionResource", schema.GroupVersion{Group: "apps", Version: "v1"}, metav1.APIResource{Name: "deployments"}, "ns-1").Return(deploymentClient, nil)
+
+			req := &v1.UndoRestoreRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: v1.DefaultNamespace, Name: "restore-1-undo"},
+				Spec: v1.UndoRestoreRequestSpec{
+					RestoreName:      "restore-1",
+					DeleteNamespaces: test.deleteNamespaces,
+				},
+			}
+			_, err := client.ArkV1().UndoRestoreRequests(req.Namespace).Create(req)
+			require.NoError(t, err)
+
+			require.NoError(t, c.processRequest(req.DeepCopy()))
+
+			res, err := client.ArkV1().UndoRestoreRequests(req.Namespace).Get(req.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, res.Status)
+			assert.Equal(t, test.expectedDeletedNames, deletedNames)
+			assert.Equal(t, test.expectedNamespaces, namespaceClient.deleted)
+		})
+	}
+}
+
+func TestDeleteOptionsForUID(t *testing.T) {
+	opts := deleteOptionsForUID("uid-1")
+
+	require.NotNil(t, opts.Preconditions)
+	require.NotNil(t, opts.Preconditions.UID)
+	assert.Equal(t, "uid-1", string(*opts.Preconditions.UID))
+	require.NotNil(t, opts.PropagationPolicy)
+	assert.Equal(t, metav1.DeletePropagationBackground, *opts.PropagationPolicy)
+}
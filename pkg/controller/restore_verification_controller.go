@@ -0,0 +1,464 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+	batchv1api "k8s.io/api/batch/v1"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+)
+
+const (
+	restoreVerificationSyncPeriod     = time.Minute
+	defaultRestoreVerificationTimeout = time.Hour
+)
+
+// restoreVerificationController runs each RestoreVerification on its cron schedule. A
+// run restores the most recent completed backup of the verification's schedule into
+// sandbox namespaces, then creates the verification's jobs in them, and passes if the
+// restore completes without errors and all of the jobs succeed. Once a run passes or
+// fails, its sandbox namespaces are deleted and it's recorded as the last run.
+type restoreVerificationController struct {
+	*genericController
+
+	verificationLister listers.RestoreVerificationLister
+	verificationClient arkv1client.RestoreVerificationsGetter
+	restoreLister      listers.RestoreLister
+	restoreClient      arkv1client.RestoresGetter
+	jobClient          batchv1client.JobsGetter
+	namespaceClient    corev1client.NamespacesGetter
+	clock              clock.Clock
+}
+
+// NewRestoreVerificationController constructs a new restoreVerificationController.
+func NewRestoreVerificationController(
+	verificationInformer informers.RestoreVerificationInformer,
+	verificationClient arkv1client.RestoreVerificationsGetter,
+	restoreInformer informers.RestoreInformer,
+	restoreClient arkv1client.RestoresGetter,
+	jobClient batchv1client.JobsGetter,
+	namespaceClient corev1client.NamespacesGetter,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &restoreVerificationController{
+		genericController:  newGenericController("restore-verification", logger),
+		verificationLister: verificationInformer.Lister(),
+		verificationClient: verificationClient,
+		restoreLister:      restoreInformer.Lister(),
+		restoreClient:      restoreClient,
+		jobClient:          jobClient,
+		namespaceClient:    namespaceClient,
+		clock:              clock.RealClock{},
+	}
+
+	c.syncHandler = c.processVerification
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, verificationInformer.Informer().HasSynced, restoreInformer.Informer().HasSynced)
+	// runs are started when they're due, and their jobs are checked on, by
+	// periodically processing all verifications
+	c.resyncFunc = c.enqueueAllVerifications
+	c.resyncPeriod = restoreVerificationSyncPeriod
+
+	enqueueVerification := func(obj interface{}) {
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			c.logger.WithError(errors.WithStack(err)).Error("Error creating queue key, item not added to queue")
+			return
+		}
+		c.queue.Add(key)
+	}
+
+	verificationInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueueVerification,
+			UpdateFunc: func(_, obj interface{}) { enqueueVerification(obj) },
+		},
+	)
+
+	// runs move on as soon as their restores complete
+	restoreInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, obj interface{}) {
+				restore, ok := obj.(*api.Restore)
+				if !ok {
+					return
+				}
+				if name := restore.Labels[api.RestoreVerificationLabel]; name != "" {
+					c.queue.Add(restore.Namespace + "/" + name)
+				}
+			},
+		},
+	)
+
+	return c
+}
+
+func (c *restoreVerificationController) enqueueAllVerifications() {
+	verifications, err := c.verificationLister.List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(errors.WithStack(err)).Error("Error listing restore verifications")
+		return
+	}
+
+	for _, verification := range verifications {
+		key, err := cache.MetaNamespaceKeyFunc(verification)
+		if err != nil {
+			c.logger.WithError(errors.WithStack(err)).Error("Error creating queue key, item not added to queue")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+func (c *restoreVerificationController) processVerification(key string) error {
+	log := c.logger.WithField("key", key)
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	original, err := c.verificationLister.RestoreVerifications(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Restore verification not found")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting restore verification")
+	}
+
+	// don't modify items in the cache
+	verification := original.DeepCopy()
+	status := &verification.Status
+
+	cronSchedule, validationErrors := parseCronSchedule(&api.Schedule{ObjectMeta: verification.ObjectMeta, Spec: api.ScheduleSpec{Schedule: verification.Spec.Schedule}}, log)
+	validationErrors = append(validationErrors, validateRestoreVerification(verification)...)
+	if len(validationErrors) > 0 {
+		status.Phase = api.RestoreVerificationPhaseFailedValidation
+		status.ValidationErrors = validationErrors
+	} else {
+		status.Phase = api.RestoreVerificationPhaseEnabled
+		status.ValidationErrors = nil
+	}
+
+	switch {
+	case status.CurrentRun != nil && status.Phase == api.RestoreVerificationPhaseFailedValidation:
+		// the run's jobs may no longer match the verification, so it can't pass
+		err = c.finishRun(verification, api.RestoreVerificationRunPhaseFailed, "Restore verification failed validation", log)
+	case status.CurrentRun != nil:
+		err = c.progressRun(verification, log)
+	case status.Phase == api.RestoreVerificationPhaseEnabled:
+		err = c.startRunIfDue(verification, cronSchedule, log)
+	}
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(original.Status, verification.Status) {
+		return nil
+	}
+
+	_, err = patchRestoreVerification(original, verification, c.verificationClient)
+	return err
+}
+
+// startRunIfDue starts a run of the verification by creating its restore, if the
+// verification's cron schedule is due since its last run started.
+func (c *restoreVerificationController) startRunIfDue(verification *api.RestoreVerification, cronSchedule cron.Schedule, log logrus.FieldLogger) error {
+	now := c.clock.Now()
+
+	var lastRunStart time.Time
+	if verification.Status.LastRun != nil {
+		lastRunStart = verification.Status.LastRun.StartTimestamp.Time
+	}
+	if !now.After(cronSchedule.Next(lastRunStart)) {
+		return nil
+	}
+
+	timestamp := now.Format("20060102150405")
+	sandboxNamespaces := make(map[string]string)
+	for _, ns := range verification.Spec.IncludedNamespaces {
+		sandboxNamespaces[ns] = sandboxNamespace(ns, timestamp)
+	}
+
+	includeClusterResources := false
+	restore := &api.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: verification.Namespace,
+			Name:      fmt.Sprintf("%s-%s", verification.Name, timestamp),
+			Labels: map[string]string{
+				api.RestoreVerificationLabel: verification.Name,
+			},
+		},
+		Spec: api.RestoreSpec{
+			ScheduleName:       verification.Spec.ScheduleName,
+			IncludedNamespaces: verification.Spec.IncludedNamespaces,
+			NamespaceMapping:   sandboxNamespaces,
+			LabelSelector:      verification.Spec.LabelSelector,
+			RestorePVs:         verification.Spec.RestorePVs,
+			// cluster-scoped resources would be restored outside of the sandbox
+			IncludeClusterResources: &includeClusterResources,
+		},
+	}
+
+	log.WithField("restore", restore.Name).Info("Starting restore verification")
+	if _, err := c.restoreClient.Restores(restore.Namespace).Create(restore); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "error creating restore")
+	}
+
+	verification.Status.CurrentRun = &api.RestoreVerificationRun{
+		Phase:             api.RestoreVerificationRunPhaseRestoring,
+		RestoreName:       restore.Name,
+		SandboxNamespaces: sandboxNamespaces,
+		StartTimestamp:    metav1.NewTime(now),
+	}
+
+	return nil
+}
+
+// progressRun moves the verification's current run on once its restore or jobs have
+// completed, or fails it if it has timed out.
+func (c *restoreVerificationController) progressRun(verification *api.RestoreVerification, log logrus.FieldLogger) error {
+	run := verification.Status.CurrentRun
+	log = log.WithField("restore", run.RestoreName)
+
+	timeout := verification.Spec.Timeout.Duration
+	if timeout == 0 {
+		timeout = defaultRestoreVerificationTimeout
+	}
+	if c.clock.Now().After(run.StartTimestamp.Add(timeout)) {
+		return c.finishRun(verification, api.RestoreVerificationRunPhaseFailed, fmt.Sprintf("Restore verification didn't complete within %s", timeout), log)
+	}
+
+	switch run.Phase {
+	case api.RestoreVerificationRunPhaseRestoring:
+		restore, err := c.restoreLister.Restores(verification.Namespace).Get(run.RestoreName)
+		if apierrors.IsNotFound(err) {
+			// the restore may not have reached the cache yet, and the run
+			// fails if it never does
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "error getting restore")
+		}
+		run.BackupName = restore.Spec.BackupName
+
+		switch restore.Status.Phase {
+		case api.RestorePhaseCompleted:
+			if restore.Status.Errors > 0 {
+				return c.finishRun(verification, api.RestoreVerificationRunPhaseFailed, fmt.Sprintf("Restore %s completed with %d errors", restore.Name, restore.Status.Errors), log)
+			}
+		case api.RestorePhaseFailed, api.RestorePhaseFailedValidation:
+			return c.finishRun(verification, api.RestoreVerificationRunPhaseFailed, fmt.Sprintf("Restore %s finished with phase %s", restore.Name, restore.Status.Phase), log)
+		default:
+			return nil
+		}
+
+		for _, job := range verification.Spec.Jobs {
+			if err := c.createJob(verification, job, run.SandboxNamespaces[job.Namespace]); err != nil {
+				return err
+			}
+		}
+
+		log.Info("Restore completed, running verification jobs")
+		run.Phase = api.RestoreVerificationRunPhaseVerifying
+		fallthrough
+	case api.RestoreVerificationRunPhaseVerifying:
+		for _, job := range verification.Spec.Jobs {
+			succeeded, failed, err := c.jobFinished(run.SandboxNamespaces[job.Namespace], job.Name)
+			if err != nil {
+				return err
+			}
+			if failed {
+				return c.finishRun(verification, api.RestoreVerificationRunPhaseFailed, fmt.Sprintf("Verification job %s failed", job.Name), log)
+			}
+			if !succeeded {
+				return nil
+			}
+		}
+
+		return c.finishRun(verification, api.RestoreVerificationRunPhasePassed, "", log)
+	}
+
+	return nil
+}
+
+func (c *restoreVerificationController) createJob(verification *api.RestoreVerification, job api.RestoreVerificationJob, namespace string) error {
+	obj := &batchv1api.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      job.Name,
+			Labels: map[string]string{
+				api.RestoreVerificationLabel: verification.Name,
+			},
+		},
+		Spec: *job.Template.DeepCopy(),
+	}
+
+	if _, err := c.jobClient.Jobs(namespace).Create(obj); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "error creating verification job %s", job.Name)
+	}
+	return nil
+}
+
+// jobFinished returns whether the job in namespace has succeeded or failed. A job
+// that doesn't exist has failed.
+func (c *restoreVerificationController) jobFinished(namespace, name string) (succeeded, failed bool, err error) {
+	job, err := c.jobClient.Jobs(namespace).Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, true, nil
+	}
+	if err != nil {
+		return false, false, errors.Wrapf(err, "error getting verification job %s", name)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1api.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1api.JobComplete:
+			return true, false, nil
+		case batchv1api.JobFailed:
+			return false, true, nil
+		}
+	}
+
+	return false, false, nil
+}
+
+// finishRun deletes the sandbox namespaces of the verification's current run, and
+// records it as the last run with the given phase.
+func (c *restoreVerificationController) finishRun(verification *api.RestoreVerification, phase api.RestoreVerificationRunPhase, failureReason string, log logrus.FieldLogger) error {
+	run := verification.Status.CurrentRun
+
+	for _, ns := range run.SandboxNamespaces {
+		if err := c.namespaceClient.Namespaces().Delete(ns, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting sandbox namespace %s", ns)
+		}
+	}
+
+	now := metav1.NewTime(c.clock.Now())
+	run.Phase = phase
+	run.FailureReason = failureReason
+	run.CompletionTimestamp = now
+
+	verification.Status.CurrentRun = nil
+	verification.Status.LastRun = run
+	if phase == api.RestoreVerificationRunPhasePassed {
+		verification.Status.LastPassedTimestamp = now
+		log.Info("Restore verification passed")
+	} else {
+		log.WithField("reason", failureReason).Warn("Restore verification failed")
+	}
+
+	return nil
+}
+
+// validateRestoreVerification returns the errors in the verification's spec other
+// than in its cron schedule.
+func validateRestoreVerification(verification *api.RestoreVerification) []string {
+	var errs []string
+
+	if verification.Spec.ScheduleName == "" {
+		errs = append(errs, "A schedule to verify the backups of must be specified")
+	}
+
+	namespaces := sets.NewString(verification.Spec.IncludedNamespaces...)
+	if namespaces.Len() == 0 || namespaces.Has("*") {
+		errs = append(errs, "At least one namespace must be included, and namespaces can't be '*' since each is restored into its own sandbox namespace")
+	}
+
+	if verification.Spec.Timeout.Duration < 0 {
+		errs = append(errs, "Timeout must not be negative")
+	}
+
+	jobNames := sets.NewString()
+	for _, job := range verification.Spec.Jobs {
+		if job.Name == "" {
+			errs = append(errs, "Verification jobs must have a name")
+			continue
+		}
+		if jobNames.Has(job.Name) {
+			errs = append(errs, fmt.Sprintf("Verification job name %s isn't unique", job.Name))
+		}
+		jobNames.Insert(job.Name)
+
+		if !namespaces.Has(job.Namespace) {
+			errs = append(errs, fmt.Sprintf("Verification job %s's namespace %q isn't included in the restore", job.Name, job.Namespace))
+		}
+	}
+
+	return errs
+}
+
+// sandboxNamespace returns the name of the namespace that a verification run started
+// at timestamp restores namespace into.
+func sandboxNamespace(namespace, timestamp string) string {
+	suffix := "-verify-" + timestamp
+	if max := validation.DNS1123LabelMaxLength - len(suffix); len(namespace) > max {
+		namespace = strings.TrimRight(namespace[:max], "-")
+	}
+	return namespace + suffix
+}
+
+func patchRestoreVerification(original, updated *api.RestoreVerification, client arkv1client.RestoreVerificationsGetter) (*api.RestoreVerification, error) {
+	origBytes, err := json.Marshal(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling original restore verification")
+	}
+
+	updatedBytes, err := json.Marshal(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling updated restore verification")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(origBytes, updatedBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating json merge patch for restore verification")
+	}
+
+	res, err := client.RestoreVerifications(original.Namespace).Patch(original.Name, types.MergePatchType, patchBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error patching restore verification")
+	}
+
+	return res, nil
+}
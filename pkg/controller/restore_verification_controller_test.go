@@ -0,0 +1,387 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1api "k8s.io/api/batch/v1"
+	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessRestoreVerification(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2018-10-01T12:00:00Z")
+	require.NoError(t, err)
+
+	sandboxNamespaces := map[string]string{"ns-1": "ns-1-verify-20181001110000"}
+
+	newVerification := func(status api.RestoreVerificationStatus) *api.RestoreVerification {
+		return &api.RestoreVerification{
+			ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: "verify"},
+			Spec: api.RestoreVerificationSpec{
+				Schedule:           "0 * * * *",
+				ScheduleName:       "daily",
+				IncludedNamespaces: []string{"ns-1"},
+				Jobs: []api.RestoreVerificationJob{
+					{Name: "check", Namespace: "ns-1"},
+				},
+			},
+			Status: status,
+		}
+	}
+
+	newRun := func(phase api.RestoreVerificationRunPhase, start time.Time) *api.RestoreVerificationRun {
+		return &api.RestoreVerificationRun{
+			Phase:             phase,
+			RestoreName:       "verify-20181001110000",
+			SandboxNamespaces: sandboxNamespaces,
+			StartTimestamp:    metav1.NewTime(start),
+		}
+	}
+
+	newRestore := func(phase api.RestorePhase, errs int) *api.Restore {
+		restore := arktest.NewTestRestore(api.DefaultNamespace, "verify-20181001110000", phase).WithSchedule("daily").Restore
+		restore.Spec.BackupName = "daily-20181001020000"
+		restore.Status.Errors = errs
+		return restore
+	}
+
+	newJob := func(condition batchv1api.JobConditionType) *batchv1api.Job {
+		job := &batchv1api.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1-verify-20181001110000", Name: "check"}}
+		if condition != "" {
+			job.Status.Conditions = []batchv1api.JobCondition{{Type: condition, Status: corev1api.ConditionTrue}}
+		}
+		return job
+	}
+
+	tests := []struct {
+		name              string
+		verification      *api.RestoreVerification
+		restore           *api.Restore
+		job               *batchv1api.Job
+		expectedRestore   bool
+		expectedJob       bool
+		expectedDeletedNS []string
+		expectedStatus    api.RestoreVerificationStatus
+		expectedNoPatch   bool
+	}{
+		{
+			name:            "new verification starts a run by creating a restore",
+			verification:    newVerification(api.RestoreVerificationStatus{}),
+			expectedRestore: true,
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase: api.RestoreVerificationPhaseEnabled,
+				CurrentRun: &api.RestoreVerificationRun{
+					Phase:             api.RestoreVerificationRunPhaseRestoring,
+					RestoreName:       "verify-20181001120000",
+					SandboxNamespaces: map[string]string{"ns-1": "ns-1-verify-20181001120000"},
+					StartTimestamp:    metav1.NewTime(now),
+				},
+			},
+		},
+		{
+			name: "verification whose last run started less than a schedule period ago doesn't start a run",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:   api.RestoreVerificationPhaseEnabled,
+				LastRun: newRun(api.RestoreVerificationRunPhasePassed, now.Add(-10*time.Minute)),
+			}),
+			expectedNoPatch: true,
+		},
+		{
+			name: "run whose restore is in progress records the restored backup",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:      api.RestoreVerificationPhaseEnabled,
+				CurrentRun: newRun(api.RestoreVerificationRunPhaseRestoring, now.Add(-time.Hour+time.Minute)),
+			}),
+			restore: newRestore(api.RestorePhaseInProgress, 0),
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase: api.RestoreVerificationPhaseEnabled,
+				CurrentRun: func() *api.RestoreVerificationRun {
+					run := newRun(api.RestoreVerificationRunPhaseRestoring, now.Add(-time.Hour+time.Minute))
+					run.BackupName = "daily-20181001020000"
+					return run
+				}(),
+			},
+		},
+		{
+			name: "run whose restore completed creates the jobs",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:      api.RestoreVerificationPhaseEnabled,
+				CurrentRun: newRun(api.RestoreVerificationRunPhaseRestoring, now.Add(-time.Minute)),
+			}),
+			restore:     newRestore(api.RestorePhaseCompleted, 0),
+			expectedJob: true,
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase: api.RestoreVerificationPhaseEnabled,
+				CurrentRun: func() *api.RestoreVerificationRun {
+					run := newRun(api.RestoreVerificationRunPhaseVerifying, now.Add(-time.Minute))
+					run.BackupName = "daily-20181001020000"
+					return run
+				}(),
+			},
+		},
+		{
+			name: "run whose restore had errors fails and deletes the sandbox namespaces",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:      api.RestoreVerificationPhaseEnabled,
+				CurrentRun: newRun(api.RestoreVerificationRunPhaseRestoring, now.Add(-time.Minute)),
+			}),
+			restore:           newRestore(api.RestorePhaseCompleted, 2),
+			expectedDeletedNS: []string{"ns-1-verify-20181001110000"},
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase: api.RestoreVerificationPhaseEnabled,
+				LastRun: func() *api.RestoreVerificationRun {
+					run := newRun(api.RestoreVerificationRunPhaseFailed, now.Add(-time.Minute))
+					run.BackupName = "daily-20181001020000"
+					run.FailureReason = "Restore verify-20181001110000 completed with 2 errors"
+					run.CompletionTimestamp = metav1.NewTime(now)
+					return run
+				}(),
+			},
+		},
+		{
+			name: "run whose jobs succeeded passes and deletes the sandbox namespaces",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:      api.RestoreVerificationPhaseEnabled,
+				CurrentRun: newRun(api.RestoreVerificationRunPhaseVerifying, now.Add(-time.Minute)),
+			}),
+			job:               newJob(batchv1api.JobComplete),
+			expectedDeletedNS: []string{"ns-1-verify-20181001110000"},
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase: api.RestoreVerificationPhaseEnabled,
+				LastRun: func() *api.RestoreVerificationRun {
+					run := newRun(api.RestoreVerificationRunPhasePassed, now.Add(-time.Minute))
+					run.CompletionTimestamp = metav1.NewTime(now)
+					return run
+				}(),
+				LastPassedTimestamp: metav1.NewTime(now),
+			},
+		},
+		{
+			name: "run whose job failed fails",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:      api.RestoreVerificationPhaseEnabled,
+				CurrentRun: newRun(api.RestoreVerificationRunPhaseVerifying, now.Add(-time.Minute)),
+			}),
+			job:               newJob(batchv1api.JobFailed),
+			expectedDeletedNS: []string{"ns-1-verify-20181001110000"},
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase: api.RestoreVerificationPhaseEnabled,
+				LastRun: func() *api.RestoreVerificationRun {
+					run := newRun(api.RestoreVerificationRunPhaseFailed, now.Add(-time.Minute))
+					run.FailureReason = "Verification job check failed"
+					run.CompletionTimestamp = metav1.NewTime(now)
+					return run
+				}(),
+			},
+		},
+		{
+			name: "run whose job is still running isn't changed",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:      api.RestoreVerificationPhaseEnabled,
+				CurrentRun: newRun(api.RestoreVerificationRunPhaseVerifying, now.Add(-time.Minute)),
+			}),
+			job:             newJob(""),
+			expectedNoPatch: true,
+		},
+		{
+			name: "run that timed out fails",
+			verification: newVerification(api.RestoreVerificationStatus{
+				Phase:      api.RestoreVerificationPhaseEnabled,
+				CurrentRun: newRun(api.RestoreVerificationRunPhaseVerifying, now.Add(-2*time.Hour)),
+			}),
+			job:               newJob(""),
+			expectedDeletedNS: []string{"ns-1-verify-20181001110000"},
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase: api.RestoreVerificationPhaseEnabled,
+				LastRun: func() *api.RestoreVerificationRun {
+					run := newRun(api.RestoreVerificationRunPhaseFailed, now.Add(-2*time.Hour))
+					run.FailureReason = "Restore verification didn't complete within 1h0m0s"
+					run.CompletionTimestamp = metav1.NewTime(now)
+					return run
+				}(),
+			},
+		},
+		{
+			name: "invalid verification fails validation",
+			verification: func() *api.RestoreVerification {
+				verification := newVerification(api.RestoreVerificationStatus{})
+				verification.Spec.Jobs[0].Namespace = "ns-2"
+				return verification
+			}(),
+			expectedStatus: api.RestoreVerificationStatus{
+				Phase:            api.RestoreVerificationPhaseFailedValidation,
+				ValidationErrors: []string{`Verification job check's namespace "ns-2" isn't included in the restore`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset(test.verification)
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				jobClient       = &fakeJobClient{jobs: make(map[string]*batchv1api.Job)}
+				namespaceClient = new(fakeNamespaceClient)
+			)
+
+			require.NoError(t, sharedInformers.Ark().V1().RestoreVerifications().Informer().GetStore().Add(test.verification))
+			if test.restore != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(test.restore))
+			}
+			if test.job != nil {
+				jobClient.jobs[test.job.Namespace+"/"+test.job.Name] = test.job
+			}
+
+			c := NewRestoreVerificationController(
+				sharedInformers.Ark().V1().RestoreVerifications(),
+				client.ArkV1(),
+				sharedInformers.Ark().V1().Restores(),
+				client.ArkV1(),
+				jobClient,
+				namespaceClient,
+				arktest.NewLogger(),
+			).(*restoreVerificationController)
+			c.clock = clock.NewFakeClock(now)
+
+			require.NoError(t, c.processVerification(api.DefaultNamespace+"/verify"))
+
+			var (
+				createdRestore *api.Restore
+				patched        *api.RestoreVerification
+			)
+			for _, action := range client.Actions() {
+				switch action := action.(type) {
+				case core.CreateAction:
+					createdRestore = action.GetObject().(*api.Restore)
+				case core.PatchAction:
+					patched = new(api.RestoreVerification)
+					original, err := json.Marshal(test.verification)
+					require.NoError(t, err)
+					res, err := jsonpatch.MergePatch(original, action.GetPatch())
+					require.NoError(t, err)
+					require.NoError(t, json.Unmarshal(res, patched))
+				}
+			}
+
+			if test.expectedRestore {
+				require.NotNil(t, createdRestore)
+				assert.Equal(t, "verify", createdRestore.Labels[api.RestoreVerificationLabel])
+				assert.Equal(t, "daily", createdRestore.Spec.ScheduleName)
+				assert.Equal(t, map[string]string{"ns-1": "ns-1-verify-20181001120000"}, createdRestore.Spec.NamespaceMapping)
+				require.NotNil(t, createdRestore.Spec.IncludeClusterResources)
+				assert.False(t, *createdRestore.Spec.IncludeClusterResources)
+			} else {
+				assert.Nil(t, createdRestore)
+			}
+
+			if test.expectedJob {
+				require.Len(t, jobClient.created, 1)
+				assert.Equal(t, "ns-1-verify-20181001110000", jobClient.created[0].Namespace)
+				assert.Equal(t, "check", jobClient.created[0].Name)
+			} else {
+				assert.Empty(t, jobClient.created)
+			}
+
+			assert.Equal(t, test.expectedDeletedNS, namespaceClient.deleted)
+
+			if test.expectedNoPatch {
+				assert.Nil(t, patched)
+				return
+			}
+			require.NotNil(t, patched)
+
+			// timestamps are unmarshalled in the local time zone, so the expected
+			// status is compared after the same round trip as the patched one
+			expectedBytes, err := json.Marshal(test.expectedStatus)
+			require.NoError(t, err)
+			expected := api.RestoreVerificationStatus{}
+			require.NoError(t, json.Unmarshal(expectedBytes, &expected))
+			assert.Equal(t, expected, patched.Status)
+		})
+	}
+}
+
+func TestSandboxNamespace(t *testing.T) {
+	assert.Equal(t, "ns-1-verify-20181001120000", sandboxNamespace("ns-1", "20181001120000"))
+
+	long := "a-namespace-with-a-name-that-is-too-long-to-have-a-suffix-added"
+	assert.Equal(t, "a-namespace-with-a-name-that-is-too-long-verify-20181001120000", sandboxNamespace(long, "20181001120000"))
+}
+
+// fakeJobClient creates and gets jobs from its map, keyed by namespace/name.
+// Calling any other method panics.
+type fakeJobClient struct {
+	batchv1client.JobInterface
+
+	namespace string
+	jobs      map[string]*batchv1api.Job
+	created   []*batchv1api.Job
+}
+
+func (c *fakeJobClient) Jobs(namespace string) batchv1client.JobInterface {
+	c.namespace = namespace
+	return c
+}
+
+func (c *fakeJobClient) Create(job *batchv1api.Job) (*batchv1api.Job, error) {
+	c.created = append(c.created, job)
+	c.jobs[job.Namespace+"/"+job.Name] = job
+	return job, nil
+}
+
+func (c *fakeJobClient) Get(name string, options metav1.GetOptions) (*batchv1api.Job, error) {
+	job, ok := c.jobs[c.namespace+"/"+name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, name)
+	}
+	return job, nil
+}
+
+// fakeNamespaceClient records the namespaces deleted through it. Calling any
+// other method panics.
+type fakeNamespaceClient struct {
+	corev1client.NamespaceInterface
+
+	deleted []string
+}
+
+func (c *fakeNamespaceClient) Namespaces() corev1client.NamespaceInterface {
+	return c
+}
+
+func (c *fakeNamespaceClient) Delete(name string, options *metav1.DeleteOptions) error {
+	c.deleted = append(c.deleted, name)
+	return nil
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	pkgbackup "github.com/heptio/ark/pkg/backup"
 	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
 	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
@@ -51,8 +53,10 @@ type scheduleController struct {
 	schedulesClient arkv1client.SchedulesGetter
 	backupsClient   arkv1client.BackupsGetter
 	schedulesLister listers.ScheduleLister
+	backupLister    listers.BackupLister
 	clock           clock.Clock
 	metrics         *metrics.ServerMetrics
+	maintenanceMode func() bool
 }
 
 func NewScheduleController(
@@ -60,8 +64,10 @@ func NewScheduleController(
 	schedulesClient arkv1client.SchedulesGetter,
 	backupsClient arkv1client.BackupsGetter,
 	schedulesInformer informers.ScheduleInformer,
+	backupInformer informers.BackupInformer,
 	logger logrus.FieldLogger,
 	metrics *metrics.ServerMetrics,
+	maintenanceMode func() bool,
 ) *scheduleController {
 	c := &scheduleController{
 		genericController: newGenericController("schedule", logger),
@@ -69,12 +75,14 @@ func NewScheduleController(
 		schedulesClient:   schedulesClient,
 		backupsClient:     backupsClient,
 		schedulesLister:   schedulesInformer.Lister(),
+		backupLister:      backupInformer.Lister(),
 		clock:             clock.RealClock{},
 		metrics:           metrics,
+		maintenanceMode:   maintenanceMode,
 	}
 
 	c.syncHandler = c.processSchedule
-	c.cacheSyncWaiters = append(c.cacheSyncWaiters, schedulesInformer.Informer().HasSynced)
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, schedulesInformer.Informer().HasSynced, backupInformer.Informer().HasSynced)
 	c.resyncFunc = c.enqueueAllEnabledSchedules
 	c.resyncPeriod = scheduleSyncPeriod
 
@@ -170,6 +178,11 @@ func (c *scheduleController) processSchedule(key string) error {
 	currentPhase := schedule.Status.Phase
 
 	cronSchedule, errs := parseCronSchedule(schedule, c.logger)
+	if name := schedule.Spec.Template.FilterProfile; name != "" {
+		if _, ok := pkgbackup.GetFilterProfile(name); !ok {
+			errs = append(errs, fmt.Sprintf("invalid filter profile %s, valid profiles are %v", name, pkgbackup.FilterProfileNames()))
+		}
+	}
 	if len(errs) > 0 {
 		schedule.Status.Phase = api.SchedulePhaseFailedValidation
 		schedule.Status.ValidationErrors = errs
@@ -190,6 +203,17 @@ func (c *scheduleController) processSchedule(key string) error {
 		return nil
 	}
 
+	if schedule, err = c.updateLastBackupPhase(schedule); err != nil {
+		return err
+	}
+
+	// the schedule is checked again on the next resync, so a backup that
+	// comes due during maintenance is submitted once maintenance ends
+	if c.maintenanceMode() {
+		log.Debug("Server is in maintenance mode, not submitting scheduled backups")
+		return nil
+	}
+
 	// check for the schedule being due to run, and submit a Backup if so
 	if err := c.submitBackupIfDue(schedule, cronSchedule); err != nil {
 		return err
@@ -247,9 +271,26 @@ func (c *scheduleController) submitBackupIfDue(item *api.Schedule, cronSchedule
 
 	if !isDue {
 		log.WithField("nextRunTime", nextRunTime).Info("Schedule is not due, skipping")
+
+		if item.Status.NextRunTime.Time.Equal(nextRunTime) {
+			return nil
+		}
+
+		schedule := item.DeepCopy()
+		schedule.Status.NextRunTime = metav1.NewTime(nextRunTime)
+		if _, err := patchSchedule(item, schedule, c.schedulesClient); err != nil {
+			return errors.Wrapf(err, "error updating Schedule's NextRunTime to %v", schedule.Status.NextRunTime)
+		}
 		return nil
 	}
 
+	// If the run after the one that's due should also have started by now, the
+	// schedule's window was missed, e.g. because the server wasn't running.
+	if !item.Status.LastBackup.IsZero() && !cronSchedule.Next(nextRunTime).After(now) {
+		log.WithField("nextRunTime", nextRunTime).Warn("Schedule missed its window, submitting a single Backup")
+		c.metrics.RegisterScheduleMissedWindow(item.Name)
+	}
+
 	// Don't attempt to "catch up" if there are any missed or failed runs - simply
 	// trigger a Backup if it's time.
 	//
@@ -266,6 +307,10 @@ func (c *scheduleController) submitBackupIfDue(item *api.Schedule, cronSchedule
 	schedule := item.DeepCopy()
 
 	schedule.Status.LastBackup = metav1.NewTime(now)
+	schedule.Status.LastBackupName = backup.Name
+	schedule.Status.LastBackupSequence = item.Status.LastBackupSequence + 1
+	schedule.Status.LastBackupPhase = api.BackupPhaseNew
+	schedule.Status.NextRunTime = metav1.NewTime(cronSchedule.Next(now))
 
 	if _, err := patchSchedule(original, schedule, c.schedulesClient); err != nil {
 		return errors.Wrapf(err, "error updating Schedule's LastBackup time to %v", schedule.Status.LastBackup)
@@ -274,6 +319,45 @@ func (c *scheduleController) submitBackupIfDue(item *api.Schedule, cronSchedule
 	return nil
 }
 
+// updateLastBackupPhase records the phase of the schedule's last backup in its
+// status, counting consecutive failures as the backups finish.
+func (c *scheduleController) updateLastBackupPhase(schedule *api.Schedule) (*api.Schedule, error) {
+	if schedule.Status.LastBackupName == "" {
+		return schedule, nil
+	}
+
+	backup, err := c.backupLister.Backups(schedule.Namespace).Get(schedule.Status.LastBackupName)
+	if apierrors.IsNotFound(err) {
+		return schedule, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting schedule's last backup")
+	}
+
+	phase := backup.Status.Phase
+	if phase == "" {
+		phase = api.BackupPhaseNew
+	}
+	if phase == schedule.Status.LastBackupPhase {
+		return schedule, nil
+	}
+
+	updated := schedule.DeepCopy()
+	updated.Status.LastBackupPhase = phase
+	switch phase {
+	case api.BackupPhaseCompleted:
+		updated.Status.ConsecutiveFailures = 0
+	case api.BackupPhaseFailed, api.BackupPhaseFailedValidation:
+		updated.Status.ConsecutiveFailures++
+	}
+
+	res, err := patchSchedule(schedule, updated, c.schedulesClient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error updating Schedule's LastBackupPhase to %s", phase)
+	}
+	return res, nil
+}
+
 func getNextRunTime(schedule *api.Schedule, cronSchedule cron.Schedule, asOf time.Time) (bool, time.Time) {
 	// get the latest run time (if the schedule hasn't run yet, this will be the zero value which will trigger
 	// an immediate backup)
@@ -296,15 +380,35 @@ func getBackup(item *api.Schedule, timestamp time.Time) *api.Backup {
 	// add schedule labels and 'ark-schedule' label to the backup
 	addLabelsToBackup(item, backup)
 
+	// record where the backup falls in the schedule's sequence of backups
+	addChainAnnotationsToBackup(item, backup)
+
 	return backup
 }
 
+// addChainAnnotationsToBackup annotates a backup with the schedule run that
+// created it, so backups from the same schedule can be ordered without relying
+// on their names or timestamps.
+func addChainAnnotationsToBackup(item *api.Schedule, backup *api.Backup) {
+	annotations := map[string]string{
+		api.ScheduleSequenceAnnotation: strconv.FormatInt(item.Status.LastBackupSequence+1, 10),
+	}
+	if item.UID != "" {
+		annotations[api.ScheduleUIDAnnotation] = string(item.UID)
+	}
+	if item.Status.LastBackupName != "" {
+		annotations[api.PreviousBackupAnnotation] = item.Status.LastBackupName
+	}
+
+	backup.Annotations = annotations
+}
+
 func addLabelsToBackup(item *api.Schedule, backup *api.Backup) {
 	labels := item.Labels
 	if labels == nil {
 		labels = make(map[string]string)
 	}
-	labels["ark-schedule"] = item.Name
+	labels[api.ScheduleNameLabel] = item.Name
 
 	backup.Labels = labels
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/robfig/cron"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,11 +45,14 @@ func TestProcessSchedule(t *testing.T) {
 		scheduleKey              string
 		schedule                 *api.Schedule
 		fakeClockTime            string
+		maintenanceMode          bool
 		expectedErr              bool
 		expectedPhase            string
 		expectedValidationErrors []string
 		expectedBackupCreate     *api.Backup
 		expectedLastBackup       string
+		// expectedLastBackupSequence defaults to 1 when expectedLastBackup is set
+		expectedLastBackupSequence int64
 	}{
 		{
 			name:        "invalid key returns error",
@@ -92,7 +96,7 @@ func TestProcessSchedule(t *testing.T) {
 			fakeClockTime:        "2017-01-01 12:00:00",
 			expectedErr:          false,
 			expectedPhase:        string(api.SchedulePhaseEnabled),
-			expectedBackupCreate: arktest.NewTestBackup().WithNamespace("ns").WithName("name-20170101120000").WithLabel("ark-schedule", "name").Backup,
+			expectedBackupCreate: arktest.NewTestBackup().WithNamespace("ns").WithName("name-20170101120000").WithLabel("ark-schedule", "name").WithAnnotation(api.ScheduleSequenceAnnotation, "1").Backup,
 			expectedLastBackup:   "2017-01-01 12:00:00",
 		},
 		{
@@ -100,18 +104,36 @@ func TestProcessSchedule(t *testing.T) {
 			schedule:             arktest.NewTestSchedule("ns", "name").WithPhase(api.SchedulePhaseEnabled).WithCronSchedule("@every 5m").Schedule,
 			fakeClockTime:        "2017-01-01 12:00:00",
 			expectedErr:          false,
-			expectedBackupCreate: arktest.NewTestBackup().WithNamespace("ns").WithName("name-20170101120000").WithLabel("ark-schedule", "name").Backup,
+			expectedBackupCreate: arktest.NewTestBackup().WithNamespace("ns").WithName("name-20170101120000").WithLabel("ark-schedule", "name").WithAnnotation(api.ScheduleSequenceAnnotation, "1").Backup,
 			expectedLastBackup:   "2017-01-01 12:00:00",
 		},
+		{
+			name:            "schedule with phase Enabled doesn't trigger a backup in maintenance mode",
+			schedule:        arktest.NewTestSchedule("ns", "name").WithPhase(api.SchedulePhaseEnabled).WithCronSchedule("@every 5m").Schedule,
+			fakeClockTime:   "2017-01-01 12:00:00",
+			maintenanceMode: true,
+			expectedErr:     false,
+		},
 		{
 			name: "schedule that's already run gets LastBackup updated",
 			schedule: arktest.NewTestSchedule("ns", "name").WithPhase(api.SchedulePhaseEnabled).
 				WithCronSchedule("@every 5m").WithLastBackupTime("2000-01-01 00:00:00").Schedule,
 			fakeClockTime:        "2017-01-01 12:00:00",
 			expectedErr:          false,
-			expectedBackupCreate: arktest.NewTestBackup().WithNamespace("ns").WithName("name-20170101120000").WithLabel("ark-schedule", "name").Backup,
+			expectedBackupCreate: arktest.NewTestBackup().WithNamespace("ns").WithName("name-20170101120000").WithLabel("ark-schedule", "name").WithAnnotation(api.ScheduleSequenceAnnotation, "1").Backup,
 			expectedLastBackup:   "2017-01-01 12:00:00",
 		},
+		{
+			name: "schedule that's already run records the previous backup and next sequence number",
+			schedule: arktest.NewTestSchedule("ns", "name").WithPhase(api.SchedulePhaseEnabled).
+				WithCronSchedule("@every 5m").WithLastBackupTime("2000-01-01 00:00:00").WithLastBackup("name-20000101000000", 4).Schedule,
+			fakeClockTime: "2017-01-01 12:00:00",
+			expectedErr:   false,
+			expectedBackupCreate: arktest.NewTestBackup().WithNamespace("ns").WithName("name-20170101120000").WithLabel("ark-schedule", "name").
+				WithAnnotation(api.ScheduleSequenceAnnotation, "5").WithAnnotation(api.PreviousBackupAnnotation, "name-20000101000000").Backup,
+			expectedLastBackup:         "2017-01-01 12:00:00",
+			expectedLastBackupSequence: 5,
+		},
 	}
 
 	for _, test := range tests {
@@ -127,8 +149,10 @@ func TestProcessSchedule(t *testing.T) {
 				client.ArkV1(),
 				client.ArkV1(),
 				sharedInformers.Ark().V1().Schedules(),
+				sharedInformers.Ark().V1().Backups(),
 				logger,
 				metrics.NewServerMetrics(),
+				func() bool { return test.maintenanceMode },
 			)
 
 			var (
@@ -191,9 +215,13 @@ func TestProcessSchedule(t *testing.T) {
 			index := 0
 
 			type PatchStatus struct {
-				ValidationErrors []string          `json:"validationErrors"`
-				Phase            api.SchedulePhase `json:"phase"`
-				LastBackup       time.Time         `json:"lastBackup"`
+				ValidationErrors   []string          `json:"validationErrors"`
+				Phase              api.SchedulePhase `json:"phase"`
+				LastBackup         time.Time         `json:"lastBackup"`
+				LastBackupName     string            `json:"lastBackupName"`
+				LastBackupSequence int64             `json:"lastBackupSequence"`
+				LastBackupPhase    api.BackupPhase   `json:"lastBackupPhase"`
+				NextRunTime        time.Time         `json:"nextRunTime"`
 			}
 
 			type Patch struct {
@@ -238,9 +266,18 @@ func TestProcessSchedule(t *testing.T) {
 			if test.expectedLastBackup != "" {
 				require.True(t, len(actions) > index, "len(actions) is too small")
 
+				expectedSequence := test.expectedLastBackupSequence
+				if expectedSequence == 0 {
+					expectedSequence = 1
+				}
+
 				expected := Patch{
 					Status: PatchStatus{
-						LastBackup: parseTime(test.expectedLastBackup),
+						LastBackup:         parseTime(test.expectedLastBackup),
+						LastBackupName:     test.expectedBackupCreate.Name,
+						LastBackupSequence: expectedSequence,
+						LastBackupPhase:    api.BackupPhaseNew,
+						NextRunTime:        parseTime(test.expectedLastBackup).Add(5 * time.Minute),
 					},
 				}
 
@@ -250,6 +287,92 @@ func TestProcessSchedule(t *testing.T) {
 	}
 }
 
+func TestUpdateLastBackupPhase(t *testing.T) {
+	tests := []struct {
+		name                        string
+		lastBackupPhase             api.BackupPhase
+		consecutiveFailures         int
+		backup                      *api.Backup
+		expectedPatch               bool
+		expectedLastBackupPhase     api.BackupPhase
+		expectedConsecutiveFailures int
+	}{
+		{
+			name:                    "missing backup isn't recorded",
+			lastBackupPhase:         api.BackupPhaseNew,
+			expectedLastBackupPhase: api.BackupPhaseNew,
+		},
+		{
+			name:                    "unchanged phase isn't patched",
+			lastBackupPhase:         api.BackupPhaseInProgress,
+			backup:                  arktest.NewTestBackup().WithNamespace("ns").WithName("backup-1").WithPhase(api.BackupPhaseInProgress).Backup,
+			expectedLastBackupPhase: api.BackupPhaseInProgress,
+		},
+		{
+			name:                        "failed backup increments consecutive failures",
+			lastBackupPhase:             api.BackupPhaseInProgress,
+			consecutiveFailures:         2,
+			backup:                      arktest.NewTestBackup().WithNamespace("ns").WithName("backup-1").WithPhase(api.BackupPhaseFailed).Backup,
+			expectedPatch:               true,
+			expectedLastBackupPhase:     api.BackupPhaseFailed,
+			expectedConsecutiveFailures: 3,
+		},
+		{
+			name:                    "completed backup resets consecutive failures",
+			lastBackupPhase:         api.BackupPhaseInProgress,
+			consecutiveFailures:     2,
+			backup:                  arktest.NewTestBackup().WithNamespace("ns").WithName("backup-1").WithPhase(api.BackupPhaseCompleted).Backup,
+			expectedPatch:           true,
+			expectedLastBackupPhase: api.BackupPhaseCompleted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				schedule        = arktest.NewTestSchedule("ns", "name").WithLastBackup("backup-1", 1).Schedule
+			)
+			schedule.Status.LastBackupPhase = test.lastBackupPhase
+			schedule.Status.ConsecutiveFailures = test.consecutiveFailures
+
+			c := NewScheduleController(
+				"ns",
+				client.ArkV1(),
+				client.ArkV1(),
+				sharedInformers.Ark().V1().Schedules(),
+				sharedInformers.Ark().V1().Backups(),
+				arktest.NewLogger(),
+				metrics.NewServerMetrics(),
+				func() bool { return false },
+			)
+
+			if test.backup != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(test.backup))
+			}
+
+			client.PrependReactor("patch", "schedules", func(action core.Action) (bool, runtime.Object, error) {
+				original, err := json.Marshal(schedule)
+				require.NoError(t, err)
+				patched, err := jsonpatch.MergePatch(original, action.(core.PatchAction).GetPatch())
+				require.NoError(t, err)
+
+				res := new(api.Schedule)
+				require.NoError(t, json.Unmarshal(patched, res))
+				return true, res, nil
+			})
+
+			updated, err := c.updateLastBackupPhase(schedule)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedPatch, len(client.Actions()) > 0)
+			assert.Equal(t, test.expectedLastBackupPhase, updated.Status.LastBackupPhase)
+			assert.Equal(t, test.expectedConsecutiveFailures, updated.Status.ConsecutiveFailures)
+		})
+	}
+}
+
 func parseTime(timeString string) time.Time {
 	res, _ := time.Parse("2006-01-02 15:04:05", timeString)
 	return res
@@ -514,3 +637,51 @@ func TestGetBackup(t *testing.T) {
 		})
 	}
 }
+
+func TestAddChainAnnotationsToBackup(t *testing.T) {
+	tests := []struct {
+		name                string
+		schedule            *api.Schedule
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:     "first run of a schedule gets sequence number 1 and no previous backup",
+			schedule: arktest.NewTestSchedule("ns", "name").Schedule,
+			expectedAnnotations: map[string]string{
+				api.ScheduleSequenceAnnotation: "1",
+			},
+		},
+		{
+			name:     "later run of a schedule gets the next sequence number and the previous backup",
+			schedule: arktest.NewTestSchedule("ns", "name").WithLastBackup("name-20170101120000", 41).Schedule,
+			expectedAnnotations: map[string]string{
+				api.ScheduleSequenceAnnotation: "42",
+				api.PreviousBackupAnnotation:   "name-20170101120000",
+			},
+		},
+		{
+			name: "schedule uid is recorded when set",
+			schedule: &api.Schedule{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      "name",
+					UID:       "schedule-uid",
+				},
+			},
+			expectedAnnotations: map[string]string{
+				api.ScheduleSequenceAnnotation: "1",
+				api.ScheduleUIDAnnotation:      "schedule-uid",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backup := new(api.Backup)
+
+			addChainAnnotationsToBackup(test.schedule, backup)
+
+			assert.Equal(t, test.expectedAnnotations, backup.Annotations)
+		})
+	}
+}
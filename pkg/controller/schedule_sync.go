@@ -0,0 +1,73 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+)
+
+// syncSchedule makes the spec of existing match desired's, creating desired if existing
+// is nil, and returns the resulting schedule.
+func syncSchedule(client arkv1client.SchedulesGetter, existing, desired *api.Schedule, log logrus.FieldLogger) (*api.Schedule, error) {
+	log = log.WithField("schedule", desired.Name)
+
+	if existing == nil {
+		log.Info("Creating schedule")
+		res, err := client.Schedules(desired.Namespace).Create(desired)
+		return res, errors.Wrap(err, "error creating schedule")
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return existing, nil
+	}
+
+	// the schedule controller only validates schedules when they're created or
+	// while they're enabled, so a schedule that failed validation is recreated
+	// rather than updated. It has never created a backup, so nothing is lost.
+	if existing.Status.Phase == api.SchedulePhaseFailedValidation {
+		log.Info("Recreating schedule")
+		if err := deleteSchedule(client, existing); err != nil {
+			return nil, err
+		}
+		res, err := client.Schedules(desired.Namespace).Create(desired)
+		return res, errors.Wrap(err, "error creating schedule")
+	}
+
+	log.Info("Updating schedule")
+
+	updated := existing.DeepCopy()
+	updated.Spec = desired.Spec
+
+	res, err := patchSchedule(existing, updated, client)
+	return res, errors.Wrap(err, "error updating schedule")
+}
+
+// deleteSchedule deletes the schedule, ignoring it having already been deleted.
+func deleteSchedule(client arkv1client.SchedulesGetter, schedule *api.Schedule) error {
+	err := client.Schedules(schedule.Namespace).Delete(schedule.Name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error deleting schedule")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestSyncSchedule(t *testing.T) {
+	tests := []struct {
+		name            string
+		existing        *api.Schedule
+		expectedActions []string
+	}{
+		{
+			name:            "missing schedule is created",
+			expectedActions: []string{"create"},
+		},
+		{
+			name:     "matching schedule isn't changed",
+			existing: arktest.NewTestSchedule("ns", "name").WithCronSchedule("0 2 * * *").WithPhase(api.SchedulePhaseEnabled).Schedule,
+		},
+		{
+			name:            "changed schedule is patched",
+			existing:        arktest.NewTestSchedule("ns", "name").WithCronSchedule("0 3 * * *").WithPhase(api.SchedulePhaseEnabled).Schedule,
+			expectedActions: []string{"patch"},
+		},
+		{
+			name:            "changed schedule that failed validation is recreated",
+			existing:        arktest.NewTestSchedule("ns", "name").WithCronSchedule("not a schedule").WithPhase(api.SchedulePhaseFailedValidation).Schedule,
+			expectedActions: []string{"delete", "create"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			if test.existing != nil {
+				client = fake.NewSimpleClientset(test.existing)
+			}
+
+			desired := arktest.NewTestSchedule("ns", "name").WithCronSchedule("0 2 * * *").Schedule
+
+			res, err := syncSchedule(client.ArkV1(), test.existing, desired, arktest.NewLogger())
+			require.NoError(t, err)
+
+			var actions []string
+			for _, action := range client.Actions() {
+				actions = append(actions, action.GetVerb())
+			}
+			assert.Equal(t, test.expectedActions, actions)
+
+			assert.Equal(t, desired.Spec, res.Spec)
+
+			stored, err := client.ArkV1().Schedules("ns").Get("name", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, desired.Spec, stored.Spec)
+		})
+	}
+}
+
+func TestDeleteScheduleIgnoresNotFound(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	assert.NoError(t, deleteSchedule(client.ArkV1(), arktest.NewTestSchedule("ns", "name").Schedule))
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// checkScratchSpace returns an error if the file system containing dir, or the
+// default temp directory if dir is empty, has fewer than required bytes available.
+func checkScratchSpace(freeSpace func(path string) (int64, error), dir string, required int64) error {
+	if required <= 0 {
+		return nil
+	}
+
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	available, err := freeSpace(dir)
+	if err != nil {
+		return err
+	}
+
+	if available < required {
+		return errors.Errorf("not enough free space in scratch directory %s: %d bytes required, %d bytes available", dir, required, available)
+	}
+
+	return nil
+}
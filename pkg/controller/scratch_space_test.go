@@ -0,0 +1,90 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckScratchSpace(t *testing.T) {
+	tests := []struct {
+		name        string
+		dir         string
+		required    int64
+		available   int64
+		freeErr     error
+		expectedDir string
+		expectedErr string
+	}{
+		{
+			name:     "nothing required",
+			dir:      "/scratch",
+			required: 0,
+		},
+		{
+			name:        "enough space available",
+			dir:         "/scratch",
+			required:    100,
+			available:   100,
+			expectedDir: "/scratch",
+		},
+		{
+			name:        "not enough space available",
+			dir:         "/scratch",
+			required:    100,
+			available:   99,
+			expectedDir: "/scratch",
+			expectedErr: "not enough free space in scratch directory /scratch: 100 bytes required, 99 bytes available",
+		},
+		{
+			name:        "default temp dir is checked if dir is empty",
+			required:    100,
+			available:   100,
+			expectedDir: os.TempDir(),
+		},
+		{
+			name:        "error getting free space",
+			dir:         "/scratch",
+			required:    100,
+			freeErr:     errors.New("statfs failed"),
+			expectedDir: "/scratch",
+			expectedErr: "statfs failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var checkedDir string
+			freeSpace := func(path string) (int64, error) {
+				checkedDir = path
+				return test.available, test.freeErr
+			}
+
+			err := checkScratchSpace(freeSpace, test.dir, test.required)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedDir, checkedDir)
+		})
+	}
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/util/boolptr"
+	"github.com/heptio/ark/pkg/util/stringslice"
+)
+
+// selfServiceBackupController handles Backups created by namespace admins in their
+// own namespaces. Each one is validated to make sure it only backs up its own namespace
+// into a storage location designated for that namespace, and if valid, a corresponding
+// Backup is created in the Ark server's namespace. The status of the Ark backup is then
+// mirrored back onto the self-service backup.
+type selfServiceBackupController struct {
+	*genericController
+
+	namespace             string
+	selfServiceLister     listers.BackupLister
+	backupLister          listers.BackupLister
+	backupClient          arkv1client.BackupsGetter
+	backupLocationLister  listers.BackupStorageLocationLister
+	defaultBackupLocation string
+}
+
+// NewSelfServiceBackupController constructs a new selfServiceBackupController. selfServiceInformer
+// must watch all namespaces, while backupInformer and backupLocationInformer only need to
+// watch the Ark server's namespace.
+func NewSelfServiceBackupController(
+	namespace string,
+	selfServiceInformer informers.BackupInformer,
+	backupInformer informers.BackupInformer,
+	backupClient arkv1client.BackupsGetter,
+	backupLocationInformer informers.BackupStorageLocationInformer,
+	defaultBackupLocation string,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &selfServiceBackupController{
+		genericController:     newGenericController("self-service-backup", logger),
+		namespace:             namespace,
+		selfServiceLister:     selfServiceInformer.Lister(),
+		backupLister:          backupInformer.Lister(),
+		backupClient:          backupClient,
+		backupLocationLister:  backupLocationInformer.Lister(),
+		defaultBackupLocation: defaultBackupLocation,
+	}
+
+	c.syncHandler = c.processSelfServiceBackup
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters,
+		selfServiceInformer.Informer().HasSynced,
+		backupInformer.Informer().HasSynced,
+		backupLocationInformer.Informer().HasSynced,
+	)
+
+	selfServiceInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				backup := obj.(*api.Backup)
+
+				// backups in the server's namespace are handled by the backup controller
+				if backup.Namespace == c.namespace {
+					return
+				}
+
+				c.enqueue(backup)
+			},
+		},
+	)
+
+	enqueueSelfService := func(obj interface{}) {
+		backup := obj.(*api.Backup)
+
+		ns, name := backup.Labels[api.SelfServiceNamespaceLabel], backup.Labels[api.SelfServiceNameLabel]
+		if ns == "" || name == "" {
+			return
+		}
+
+		c.queue.Add(fmt.Sprintf("%s/%s", ns, name))
+	}
+
+	backupInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueueSelfService,
+			UpdateFunc: func(_, obj interface{}) { enqueueSelfService(obj) },
+		},
+	)
+
+	return c
+}
+
+func (c *selfServiceBackupController) processSelfServiceBackup(key string) error {
+	log := c.logger.WithField("key", key)
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	if ns == c.namespace {
+		return nil
+	}
+
+	selfService, err := c.selfServiceLister.Backups(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Unable to find self-service backup")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting self-service backup")
+	}
+
+	backupName := selfServiceObjectName(ns, name)
+
+	backup, err := c.backupLister.Backups(c.namespace).Get(backupName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting backup")
+	}
+
+	if backup != nil && isSelfServiceObjectFor(backup.ObjectMeta, ns, name) {
+		// mirror the status of the backup onto the self-service backup
+		if equality.Semantic.DeepEqual(selfService.Status, backup.Status) {
+			return nil
+		}
+
+		updated := selfService.DeepCopy()
+		updated.Status = backup.Status
+
+		if _, err := patchBackup(selfService, updated, c.backupClient); err != nil {
+			return errors.Wrap(err, "error updating self-service backup status")
+		}
+		return nil
+	}
+
+	switch selfService.Status.Phase {
+	case "", api.BackupPhaseNew:
+		// only process new self-service backups
+	default:
+		return nil
+	}
+
+	updated := selfService.DeepCopy()
+
+	var location *api.BackupStorageLocation
+	if updated.Spec.StorageLocation == "" {
+		updated.Spec.StorageLocation = c.defaultBackupLocation
+	}
+	if location, err = c.backupLocationLister.BackupStorageLocations(c.namespace).Get(updated.Spec.StorageLocation); err != nil {
+		updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, fmt.Sprintf("Error getting backup storage location: %v", err))
+	}
+
+	updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, validateSelfServiceBackup(updated, location)...)
+
+	spec := selfServiceBackupSpec(updated.Spec)
+	if !equality.Semantic.DeepEqual(spec, updated.Spec) {
+		updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, fmt.Sprintf("Self-service backups can only set the spec fields %s", strings.Join(selfServiceBackupFields, ", ")))
+	}
+
+	if backup != nil {
+		updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, fmt.Sprintf("A backup named %s already exists in namespace %s", backupName, c.namespace))
+	}
+
+	if len(updated.Status.ValidationErrors) > 0 {
+		log.WithField("errors", updated.Status.ValidationErrors).Info("Self-service backup failed validation")
+
+		updated.Status.Phase = api.BackupPhaseFailedValidation
+		if _, err := patchBackup(selfService, updated, c.backupClient); err != nil {
+			return errors.Wrapf(err, "error updating self-service backup status to %s", updated.Status.Phase)
+		}
+		return nil
+	}
+
+	backup = &api.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      backupName,
+			Labels:    selfServiceLabels(selfService.Labels, ns, name),
+		},
+		Spec: spec,
+	}
+
+	// self-service backups can only ever include their own namespace, and read
+	// it as a service account in their namespace
+	backup.Spec.IncludedNamespaces = []string{ns}
+	backup.Spec.ExcludedNamespaces = nil
+	backup.Spec.ServiceAccountName = selfServiceServiceAccountName(spec.ServiceAccountName)
+
+	log.WithField("backup", backupName).Info("Creating backup for self-service backup")
+	if _, err := c.backupClient.Backups(c.namespace).Create(backup); err != nil {
+		return errors.Wrap(err, "error creating backup")
+	}
+
+	return nil
+}
+
+// selfServiceBackupFields are the spec fields that self-service backups can set.
+var selfServiceBackupFields = []string{
+	"includedNamespaces",
+	"excludedNamespaces",
+	"includedResources",
+	"excludedResources",
+	"labelSelector",
+	"snapshotVolumes",
+	"ttl",
+	"includeClusterResources",
+	"storageLocation",
+	"volumeSnapshotLocations",
+	"serviceAccountName",
+	"filterProfile",
+	"archiveFormat",
+	"hold",
+}
+
+// selfServiceBackupSpec returns a copy of spec with only the fields in
+// selfServiceBackupFields, so that fields that are added to BackupSpec aren't
+// available to self-service backups until they're added here. FollowReferences is
+// also copied so that backups following references fail validation with a specific
+// error.
+func selfServiceBackupSpec(spec api.BackupSpec) api.BackupSpec {
+	return api.BackupSpec{
+		IncludedNamespaces:      spec.IncludedNamespaces,
+		ExcludedNamespaces:      spec.ExcludedNamespaces,
+		IncludedResources:       spec.IncludedResources,
+		ExcludedResources:       spec.ExcludedResources,
+		LabelSelector:           spec.LabelSelector,
+		SnapshotVolumes:         spec.SnapshotVolumes,
+		TTL:                     spec.TTL,
+		IncludeClusterResources: spec.IncludeClusterResources,
+		StorageLocation:         spec.StorageLocation,
+		VolumeSnapshotLocations: spec.VolumeSnapshotLocations,
+		ServiceAccountName:      spec.ServiceAccountName,
+		FilterProfile:           spec.FilterProfile,
+		ArchiveFormat:           spec.ArchiveFormat,
+		Hold:                    spec.Hold,
+		FollowReferences:        spec.FollowReferences,
+	}
+}
+
+// selfServiceServiceAccountName returns the name of the service account in a
+// self-service object's namespace that the object is processed as: the one it
+// specifies, or the namespace's default service account, so that self-service
+// objects never use the Ark server's own permissions.
+func selfServiceServiceAccountName(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// validateSelfServiceBackup returns a list of validation errors for a self-service
+// backup. location may be nil if the backup's storage location couldn't be found.
+func validateSelfServiceBackup(backup *api.Backup, location *api.BackupStorageLocation) []string {
+	var errs []string
+
+	for _, ns := range backup.Spec.IncludedNamespaces {
+		if ns != backup.Namespace {
+			errs = append(errs, fmt.Sprintf("Self-service backups can only include their own namespace (%s), not %s", backup.Namespace, ns))
+		}
+	}
+
+	if boolptr.IsSetToTrue(backup.Spec.IncludeClusterResources) {
+		errs = append(errs, "Self-service backups cannot include cluster-scoped resources")
+	}
+
+	if backup.Spec.FollowReferences {
+		errs = append(errs, "Self-service backups cannot follow references to items outside their namespace")
+	}
+
+	if location != nil && !isSelfServiceLocationFor(location, backup.Namespace) {
+		errs = append(errs, fmt.Sprintf("Backup storage location %s is not available for self-service backups from namespace %s", location.Name, backup.Namespace))
+	}
+
+	if name := selfServiceObjectName(backup.Namespace, backup.Name); len(name) > validation.DNS1035LabelMaxLength {
+		errs = append(errs, fmt.Sprintf("Self-service backup namespace and name must not be longer than %d characters combined", validation.DNS1035LabelMaxLength-1))
+	}
+
+	return errs
+}
+
+// isSelfServiceLocationFor returns true if the backup storage location is designated
+// for use by self-service backups from the given namespace.
+func isSelfServiceLocationFor(location *api.BackupStorageLocation, namespace string) bool {
+	val, ok := location.Annotations[api.SelfServiceNamespacesAnnotation]
+	if !ok {
+		return false
+	}
+
+	namespaces := strings.Split(val, ",")
+	for i := range namespaces {
+		namespaces[i] = strings.TrimSpace(namespaces[i])
+	}
+
+	return stringslice.Has(namespaces, "*") || stringslice.Has(namespaces, namespace)
+}
+
+// selfServiceObjectName returns the name of the object in the Ark server's namespace
+// that's created for the self-service object with the given namespace and name.
+// Namespace names can't contain dots, so objects from different namespaces can't
+// be given the same name.
+func selfServiceObjectName(namespace, name string) string {
+	return fmt.Sprintf("%s.%s", namespace, name)
+}
+
+// legacySelfServiceObjectName returns the name that objects were created with for
+// self-service objects before selfServiceObjectName separated the namespace and name
+// with a dot.
+func legacySelfServiceObjectName(namespace, name string) string {
+	return fmt.Sprintf("%s-%s", namespace, name)
+}
+
+// selfServiceLabels returns the labels of the object that's created for the self-service
+// object with the given namespace, name and labels. Labels that are used by Ark, such as
+// the schedule name label and ark.heptio.com labels, aren't copied, so that tenants
+// can't pass their objects off as another schedule's or location's.
+func selfServiceLabels(labels map[string]string, namespace, name string) map[string]string {
+	res := make(map[string]string)
+	for k, v := range labels {
+		if isArkLabel(k) {
+			continue
+		}
+		res[k] = v
+	}
+	res[api.SelfServiceNamespaceLabel] = namespace
+	res[api.SelfServiceNameLabel] = name
+
+	return res
+}
+
+// isArkLabel returns true if the label key is one that Ark uses: the schedule name
+// label, the legacy restore label, or a label prefixed with the Ark API group or one
+// of its subdomains.
+func isArkLabel(key string) bool {
+	if key == api.ScheduleNameLabel || key == api.RestoreLabelKey {
+		return true
+	}
+
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return false
+	}
+	prefix := key[:i]
+
+	return prefix == api.GroupName || strings.HasSuffix(prefix, "."+api.GroupName)
+}
+
+// isSelfServiceObjectFor returns true if the object metadata is labeled as having
+// been created for the self-service object with the given namespace and name.
+func isSelfServiceObjectFor(obj metav1.ObjectMeta, namespace, name string) bool {
+	return obj.Labels[api.SelfServiceNamespaceLabel] == namespace && obj.Labels[api.SelfServiceNameLabel] == name
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessSelfServiceBackup(t *testing.T) {
+	location := arktest.NewTestBackupStorageLocation().WithName("default").BackupStorageLocation
+	location.Annotations = map[string]string{api.SelfServiceNamespacesAnnotation: "other, tenant"}
+
+	tests := []struct {
+		name                  string
+		backup                *api.Backup
+		existing              *api.Backup
+		location              *api.BackupStorageLocation
+		expectCreate          bool
+		expectServiceAccount  string
+		expectLabels          map[string]string
+		expectValidationError bool
+		expectMirroredPhase   api.BackupPhase
+	}{
+		{
+			name:                 "valid backup creates a backup in the server namespace",
+			backup:               arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").WithIncludedNamespaces("tenant").Backup,
+			location:             location,
+			expectCreate:         true,
+			expectServiceAccount: "default",
+		},
+		{
+			name:                 "backup's service account is kept",
+			backup:               arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").WithServiceAccountName("backer-upper").Backup,
+			location:             location,
+			expectCreate:         true,
+			expectServiceAccount: "backer-upper",
+		},
+		{
+			name: "backup's labels are copied except for ones used by ark",
+			backup: arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").
+				WithLabel("app", "db").
+				WithLabel(api.ScheduleNameLabel, "other-schedule").
+				WithLabel(api.StorageLocationLabel, "other-location").
+				WithLabel("restic.ark.heptio.com/foo", "bar").
+				WithLabel(api.SelfServiceNamespaceLabel, "other").
+				Backup,
+			location:             location,
+			expectCreate:         true,
+			expectServiceAccount: "default",
+			expectLabels: map[string]string{
+				"app":                         "db",
+				api.SelfServiceNamespaceLabel: "tenant",
+				api.SelfServiceNameLabel:      "backup-1",
+			},
+		},
+		{
+			name:                  "backup of hostPath volumes fails validation",
+			backup:                arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").WithResticHostPathVolumes(true).Backup,
+			location:              location,
+			expectValidationError: true,
+		},
+		{
+			name:                  "backup including another namespace fails validation",
+			backup:                arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").WithIncludedNamespaces("tenant", "kube-system").Backup,
+			location:              location,
+			expectValidationError: true,
+		},
+		{
+			name:                  "backup following references fails validation",
+			backup:                arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").WithFollowReferences(true).Backup,
+			location:              location,
+			expectValidationError: true,
+		},
+		{
+			name:                  "backup to a location not designated for the namespace fails validation",
+			backup:                arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").Backup,
+			location:              arktest.NewTestBackupStorageLocation().WithName("default").BackupStorageLocation,
+			expectValidationError: true,
+		},
+		{
+			name:                  "backup to a missing location fails validation",
+			backup:                arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").Backup,
+			expectValidationError: true,
+		},
+		{
+			name:   "backup name collision fails validation",
+			backup: arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").Backup,
+			existing: arktest.NewTestBackup().WithName("tenant.backup-1").
+				WithPhase(api.BackupPhaseCompleted).
+				Backup,
+			location:              location,
+			expectValidationError: true,
+		},
+		{
+			name:   "status of existing backup is mirrored",
+			backup: arktest.NewTestBackup().WithNamespace("tenant").WithName("backup-1").Backup,
+			existing: arktest.NewTestBackup().WithName("tenant.backup-1").
+				WithLabel(api.SelfServiceNamespaceLabel, "tenant").
+				WithLabel(api.SelfServiceNameLabel, "backup-1").
+				WithPhase(api.BackupPhaseCompleted).
+				Backup,
+			location:            location,
+			expectMirroredPhase: api.BackupPhaseCompleted,
+		},
+		{
+			name:   "backups in the server namespace are ignored",
+			backup: arktest.NewTestBackup().WithName("backup-1").Backup,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset(test.backup)
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+			)
+
+			c := NewSelfServiceBackupController(
+				api.DefaultNamespace,
+				sharedInformers.Ark().V1().Backups(),
+				sharedInformers.Ark().V1().Backups(),
+				client.ArkV1(),
+				sharedInformers.Ark().V1().BackupStorageLocations(),
+				"default",
+				arktest.NewLogger(),
+			).(*selfServiceBackupController)
+
+			require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(test.backup))
+			if test.existing != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(test.existing))
+			}
+			if test.location != nil {
+				require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(test.location))
+			}
+
+			require.NoError(t, c.processSelfServiceBackup(test.backup.Namespace+"/"+test.backup.Name))
+
+			var (
+				creates []core.CreateAction
+				patches []core.PatchAction
+			)
+			for _, action := range client.Actions() {
+				switch a := action.(type) {
+				case core.CreateAction:
+					creates = append(creates, a)
+				case core.PatchAction:
+					patches = append(patches, a)
+				}
+			}
+
+			if test.expectCreate {
+				require.Len(t, creates, 1)
+				created := creates[0].GetObject().(*api.Backup)
+				assert.Equal(t, api.DefaultNamespace, created.Namespace)
+				assert.Equal(t, "tenant.backup-1", created.Name)
+				assert.Equal(t, []string{"tenant"}, created.Spec.IncludedNamespaces)
+				assert.Equal(t, test.expectServiceAccount, created.Spec.ServiceAccountName)
+				assert.True(t, isSelfServiceObjectFor(created.ObjectMeta, "tenant", "backup-1"))
+				if test.expectLabels != nil {
+					assert.Equal(t, test.expectLabels, created.Labels)
+				}
+			} else {
+				assert.Empty(t, creates)
+			}
+
+			switch {
+			case test.expectValidationError:
+				require.Len(t, patches, 1)
+				status := decodeStatusPatch(t, patches[0].GetPatch())
+				assert.Equal(t, string(api.BackupPhaseFailedValidation), status["phase"])
+				assert.NotEmpty(t, status["validationErrors"])
+			case test.expectMirroredPhase != "":
+				require.Len(t, patches, 1)
+				status := decodeStatusPatch(t, patches[0].GetPatch())
+				assert.Equal(t, string(test.expectMirroredPhase), status["phase"])
+			default:
+				assert.Empty(t, patches)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/util/boolptr"
+)
+
+// selfServiceRestoreController handles Restores created by namespace admins in their
+// own namespaces. Each one is validated to make sure it only restores one of the
+// namespace's own self-service backups back into the same namespace, and if valid,
+// a corresponding Restore is created in the Ark server's namespace. The status of the
+// Ark restore is then mirrored back onto the self-service restore.
+type selfServiceRestoreController struct {
+	*genericController
+
+	namespace         string
+	selfServiceLister listers.RestoreLister
+	restoreLister     listers.RestoreLister
+	restoreClient     arkv1client.RestoresGetter
+	backupLister      listers.BackupLister
+}
+
+// NewSelfServiceRestoreController constructs a new selfServiceRestoreController. selfServiceInformer
+// must watch all namespaces, while restoreInformer and backupInformer only need to watch the Ark
+// server's namespace.
+func NewSelfServiceRestoreController(
+	namespace string,
+	selfServiceInformer informers.RestoreInformer,
+	restoreInformer informers.RestoreInformer,
+	restoreClient arkv1client.RestoresGetter,
+	backupInformer informers.BackupInformer,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &selfServiceRestoreController{
+		genericController: newGenericController("self-service-restore", logger),
+		namespace:         namespace,
+		selfServiceLister: selfServiceInformer.Lister(),
+		restoreLister:     restoreInformer.Lister(),
+		restoreClient:     restoreClient,
+		backupLister:      backupInformer.Lister(),
+	}
+
+	c.syncHandler = c.processSelfServiceRestore
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters,
+		selfServiceInformer.Informer().HasSynced,
+		restoreInformer.Informer().HasSynced,
+		backupInformer.Informer().HasSynced,
+	)
+
+	selfServiceInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				restore := obj.(*api.Restore)
+
+				// restores in the server's namespace are handled by the restore controller
+				if restore.Namespace == c.namespace {
+					return
+				}
+
+				c.enqueue(restore)
+			},
+		},
+	)
+
+	enqueueSelfService := func(obj interface{}) {
+		restore := obj.(*api.Restore)
+
+		ns, name := restore.Labels[api.SelfServiceNamespaceLabel], restore.Labels[api.SelfServiceNameLabel]
+		if ns == "" || name == "" {
+			return
+		}
+
+		c.queue.Add(fmt.Sprintf("%s/%s", ns, name))
+	}
+
+	restoreInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueueSelfService,
+			UpdateFunc: func(_, obj interface{}) { enqueueSelfService(obj) },
+		},
+	)
+
+	return c
+}
+
+func (c *selfServiceRestoreController) processSelfServiceRestore(key string) error {
+	log := c.logger.WithField("key", key)
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	if ns == c.namespace {
+		return nil
+	}
+
+	selfService, err := c.selfServiceLister.Restores(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Unable to find self-service restore")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting self-service restore")
+	}
+
+	restoreName := selfServiceObjectName(ns, name)
+
+	restore, err := c.restoreLister.Restores(c.namespace).Get(restoreName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "error getting restore")
+	}
+
+	if restore != nil && isSelfServiceObjectFor(restore.ObjectMeta, ns, name) {
+		// mirror the status of the restore onto the self-service restore
+		if equality.Semantic.DeepEqual(selfService.Status, restore.Status) {
+			return nil
+		}
+
+		updated := selfService.DeepCopy()
+		updated.Status = restore.Status
+
+		if _, err := patchRestore(selfService, updated, c.restoreClient); err != nil {
+			return errors.Wrap(err, "error updating self-service restore status")
+		}
+		return nil
+	}
+
+	switch selfService.Status.Phase {
+	case "", api.RestorePhaseNew:
+		// only process new self-service restores
+	default:
+		return nil
+	}
+
+	updated := selfService.DeepCopy()
+	updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, validateSelfServiceRestore(updated)...)
+
+	spec := selfServiceRestoreSpec(updated.Spec)
+	if !equality.Semantic.DeepEqual(spec, updated.Spec) {
+		updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, fmt.Sprintf("Self-service restores can only set the spec fields %s", strings.Join(selfServiceRestoreFields, ", ")))
+	}
+
+	backupName := selfServiceObjectName(ns, updated.Spec.BackupName)
+	if updated.Spec.BackupName != "" {
+		backup, err := c.backupLister.Backups(c.namespace).Get(backupName)
+		if apierrors.IsNotFound(err) {
+			// the backup may have been created before self-service objects were named
+			// with a dot, which is checked by its labels below like any other backup
+			backupName = legacySelfServiceObjectName(ns, updated.Spec.BackupName)
+			backup, err = c.backupLister.Backups(c.namespace).Get(backupName)
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, fmt.Sprintf("Self-service backup %s not found", updated.Spec.BackupName))
+		case err != nil:
+			return errors.Wrap(err, "error getting backup")
+		case !isSelfServiceObjectFor(backup.ObjectMeta, ns, updated.Spec.BackupName):
+			updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, fmt.Sprintf("Self-service backup %s not found", updated.Spec.BackupName))
+		}
+	}
+
+	if restore != nil {
+		updated.Status.ValidationErrors = append(updated.Status.ValidationErrors, fmt.Sprintf("A restore named %s already exists in namespace %s", restoreName, c.namespace))
+	}
+
+	if len(updated.Status.ValidationErrors) > 0 {
+		log.WithField("errors", updated.Status.ValidationErrors).Info("Self-service restore failed validation")
+
+		updated.Status.Phase = api.RestorePhaseFailedValidation
+		if _, err := patchRestore(selfService, updated, c.restoreClient); err != nil {
+			return errors.Wrapf(err, "error updating self-service restore status to %s", updated.Status.Phase)
+		}
+		return nil
+	}
+
+	restore = &api.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      restoreName,
+			Labels:    selfServiceLabels(selfService.Labels, ns, name),
+		},
+		Spec: spec,
+	}
+
+	// self-service restores can only ever restore their own namespace, from
+	// their own backups, as a service account in their namespace
+	restore.Spec.BackupName = backupName
+	restore.Spec.IncludedNamespaces = []string{ns}
+	restore.Spec.ExcludedNamespaces = nil
+	restore.Spec.NamespaceMapping = nil
+	restore.Spec.ServiceAccountName = selfServiceServiceAccountName(spec.ServiceAccountName)
+
+	log.WithField("restore", restoreName).Info("Creating restore for self-service restore")
+	if _, err := c.restoreClient.Restores(c.namespace).Create(restore); err != nil {
+		return errors.Wrap(err, "error creating restore")
+	}
+
+	return nil
+}
+
+// selfServiceRestoreFields are the spec fields that self-service restores can set.
+var selfServiceRestoreFields = []string{
+	"backupName",
+	"includedNamespaces",
+	"excludedNamespaces",
+	"includedResources",
+	"excludedResources",
+	"namespaceMapping",
+	"labelSelector",
+	"restorePVs",
+	"includeClusterResources",
+	"serviceAccountName",
+	"strict",
+	"waitForReady",
+	"waitForReadyTimeout",
+	"resourceTimeout",
+	"stripAnnotations",
+	"additionalLabels",
+	"additionalAnnotations",
+	"omitLegacyRestoreLabel",
+}
+
+// selfServiceRestoreSpec returns a copy of spec with only the fields in
+// selfServiceRestoreFields, so that fields that are added to RestoreSpec aren't
+// available to self-service restores until they're added here. The schedule name
+// is also copied so that restores from schedules fail validation with a specific
+// error.
+func selfServiceRestoreSpec(spec api.RestoreSpec) api.RestoreSpec {
+	return api.RestoreSpec{
+		BackupName:              spec.BackupName,
+		ScheduleName:            spec.ScheduleName,
+		IncludedNamespaces:      spec.IncludedNamespaces,
+		ExcludedNamespaces:      spec.ExcludedNamespaces,
+		IncludedResources:       spec.IncludedResources,
+		ExcludedResources:       spec.ExcludedResources,
+		NamespaceMapping:        spec.NamespaceMapping,
+		LabelSelector:           spec.LabelSelector,
+		RestorePVs:              spec.RestorePVs,
+		IncludeClusterResources: spec.IncludeClusterResources,
+		ServiceAccountName:      spec.ServiceAccountName,
+		Strict:                  spec.Strict,
+		WaitForReady:            spec.WaitForReady,
+		WaitForReadyTimeout:     spec.WaitForReadyTimeout,
+		ResourceTimeout:         spec.ResourceTimeout,
+		StripAnnotations:        spec.StripAnnotations,
+		AdditionalLabels:        spec.AdditionalLabels,
+		AdditionalAnnotations:   spec.AdditionalAnnotations,
+		OmitLegacyRestoreLabel:  spec.OmitLegacyRestoreLabel,
+	}
+}
+
+// validateSelfServiceRestore returns a list of validation errors for a self-service
+// restore.
+func validateSelfServiceRestore(restore *api.Restore) []string {
+	var errs []string
+
+	if restore.Spec.BackupName == "" {
+		errs = append(errs, "Self-service restores must specify a backup")
+	}
+
+	if restore.Spec.ScheduleName != "" {
+		errs = append(errs, "Self-service restores cannot restore from a schedule")
+	}
+
+	for _, ns := range restore.Spec.IncludedNamespaces {
+		if ns != restore.Namespace {
+			errs = append(errs, fmt.Sprintf("Self-service restores can only include their own namespace (%s), not %s", restore.Namespace, ns))
+		}
+	}
+
+	for source, target := range restore.Spec.NamespaceMapping {
+		if source != restore.Namespace || target != restore.Namespace {
+			errs = append(errs, fmt.Sprintf("Self-service restores cannot map namespace %s to %s", source, target))
+		}
+	}
+
+	if boolptr.IsSetToTrue(restore.Spec.IncludeClusterResources) {
+		errs = append(errs, "Self-service restores cannot include cluster-scoped resources")
+	}
+
+	if name := selfServiceObjectName(restore.Namespace, restore.Name); len(name) > validation.DNS1035LabelMaxLength {
+		errs = append(errs, fmt.Sprintf("Self-service restore namespace and name must not be longer than %d characters combined", validation.DNS1035LabelMaxLength-1))
+	}
+
+	return errs
+}
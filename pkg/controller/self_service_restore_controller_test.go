@@ -0,0 +1,205 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessSelfServiceRestore(t *testing.T) {
+	selfServiceBackup := arktest.NewTestBackup().WithName("tenant.backup-1").
+		WithLabel(api.SelfServiceNamespaceLabel, "tenant").
+		WithLabel(api.SelfServiceNameLabel, "backup-1").
+		WithPhase(api.BackupPhaseCompleted).
+		Backup
+
+	tests := []struct {
+		name                  string
+		restore               *api.Restore
+		backup                *api.Backup
+		expectCreate          bool
+		expectServiceAccount  string
+		expectBackupName      string
+		expectLabels          map[string]string
+		expectValidationError bool
+	}{
+		{
+			name:                 "valid restore creates a restore in the server namespace",
+			restore:              NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).Restore,
+			backup:               selfServiceBackup,
+			expectCreate:         true,
+			expectServiceAccount: "default",
+		},
+		{
+			name:                 "restore's service account is kept",
+			restore:              NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).WithServiceAccountName("restorer").Restore,
+			backup:               selfServiceBackup,
+			expectCreate:         true,
+			expectServiceAccount: "restorer",
+		},
+		{
+			name: "restore's labels are copied except for ones used by ark",
+			restore: NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).
+				WithLabel("app", "db").
+				WithLabel(api.ScheduleNameLabel, "other-schedule").
+				WithLabel(api.RestoreLabelKey, "other-restore").
+				Restore,
+			backup:               selfServiceBackup,
+			expectCreate:         true,
+			expectServiceAccount: "default",
+			expectLabels: map[string]string{
+				"app":                         "db",
+				api.SelfServiceNamespaceLabel: "tenant",
+				api.SelfServiceNameLabel:      "restore-1",
+			},
+		},
+		{
+			name:    "restore of a backup with a legacy name restores it",
+			restore: NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).Restore,
+			backup: arktest.NewTestBackup().WithName("tenant-backup-1").
+				WithLabel(api.SelfServiceNamespaceLabel, "tenant").
+				WithLabel(api.SelfServiceNameLabel, "backup-1").
+				WithPhase(api.BackupPhaseCompleted).
+				Backup,
+			expectCreate:         true,
+			expectServiceAccount: "default",
+			expectBackupName:     "tenant-backup-1",
+		},
+		{
+			name:    "restore of another namespace's backup with a colliding legacy name fails validation",
+			restore: NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).Restore,
+			backup: arktest.NewTestBackup().WithName("tenant-backup-1").
+				WithLabel(api.SelfServiceNamespaceLabel, "tenant-backup").
+				WithLabel(api.SelfServiceNameLabel, "1").
+				WithPhase(api.BackupPhaseCompleted).
+				Backup,
+			expectValidationError: true,
+		},
+		{
+			name: "restore with hooks fails validation",
+			restore: NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).
+				WithHook(api.RestoreResourceHookSpec{Name: "hook-1"}).
+				Restore,
+			backup:                selfServiceBackup,
+			expectValidationError: true,
+		},
+		{
+			name:                  "restore with the recreate conflict policy fails validation",
+			restore:               NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).WithConflictPolicy(api.RestoreConflictPolicyRecreate).Restore,
+			backup:                selfServiceBackup,
+			expectValidationError: true,
+		},
+		{
+			name:                  "restore of a missing backup fails validation",
+			restore:               NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).Restore,
+			expectValidationError: true,
+		},
+		{
+			name:                  "restore of another namespace's backup fails validation",
+			restore:               NewRestore("other", "restore-1", "backup-1", "other", "", api.RestorePhaseNew).Restore,
+			backup:                arktest.NewTestBackup().WithName("other.backup-1").WithPhase(api.BackupPhaseCompleted).Backup,
+			expectValidationError: true,
+		},
+		{
+			name:                  "restore including another namespace fails validation",
+			restore:               NewRestore("tenant", "restore-1", "backup-1", "kube-system", "", api.RestorePhaseNew).Restore,
+			backup:                selfServiceBackup,
+			expectValidationError: true,
+		},
+		{
+			name:                  "restore with a namespace mapping fails validation",
+			restore:               NewRestore("tenant", "restore-1", "backup-1", "tenant", "", api.RestorePhaseNew).WithMappedNamespace("tenant", "other").Restore,
+			backup:                selfServiceBackup,
+			expectValidationError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset(test.restore)
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+			)
+
+			c := NewSelfServiceRestoreController(
+				api.DefaultNamespace,
+				sharedInformers.Ark().V1().Restores(),
+				sharedInformers.Ark().V1().Restores(),
+				client.ArkV1(),
+				sharedInformers.Ark().V1().Backups(),
+				arktest.NewLogger(),
+			).(*selfServiceRestoreController)
+
+			require.NoError(t, sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(test.restore))
+			if test.backup != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(test.backup))
+			}
+
+			require.NoError(t, c.processSelfServiceRestore(test.restore.Namespace+"/"+test.restore.Name))
+
+			var (
+				creates []core.CreateAction
+				patches []core.PatchAction
+			)
+			for _, action := range client.Actions() {
+				switch a := action.(type) {
+				case core.CreateAction:
+					creates = append(creates, a)
+				case core.PatchAction:
+					patches = append(patches, a)
+				}
+			}
+
+			if test.expectCreate {
+				require.Len(t, creates, 1)
+				created := creates[0].GetObject().(*api.Restore)
+				assert.Equal(t, api.DefaultNamespace, created.Namespace)
+				assert.Equal(t, "tenant.restore-1", created.Name)
+				expectBackupName := test.expectBackupName
+				if expectBackupName == "" {
+					expectBackupName = "tenant.backup-1"
+				}
+				assert.Equal(t, expectBackupName, created.Spec.BackupName)
+				assert.Equal(t, []string{"tenant"}, created.Spec.IncludedNamespaces)
+				assert.Nil(t, created.Spec.NamespaceMapping)
+				assert.Equal(t, test.expectServiceAccount, created.Spec.ServiceAccountName)
+				if test.expectLabels != nil {
+					assert.Equal(t, test.expectLabels, created.Labels)
+				}
+			} else {
+				assert.Empty(t, creates)
+			}
+
+			if test.expectValidationError {
+				require.Len(t, patches, 1)
+				status := decodeStatusPatch(t, patches[0].GetPatch())
+				assert.Equal(t, string(api.RestorePhaseFailedValidation), status["phase"])
+			} else {
+				assert.Empty(t, patches)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	pkgrestore "github.com/heptio/ark/pkg/restore"
+)
+
+const (
+	// RestoreResourcePrioritiesConfigKey is the server ConfigMap key for the
+	// comma-separated list of resources to restore first, in order.
+	RestoreResourcePrioritiesConfigKey = "restoreResourcePriorities"
+
+	// ResticTimeoutConfigKey is the server ConfigMap key for how long the
+	// backup or restore of each pod volume is allowed to run.
+	ResticTimeoutConfigKey = "resticTimeout"
+
+	// BackupSyncPeriodConfigKey is the server ConfigMap key for how often
+	// backups in object storage are synced into the cluster.
+	BackupSyncPeriodConfigKey = "backupSyncPeriod"
+
+	// MaintenanceModeConfigKey is the server ConfigMap key for whether the
+	// server is in maintenance mode, which pauses schedules, garbage
+	// collection, and backup sync.
+	MaintenanceModeConfigKey = "maintenanceMode"
+
+	// ReadinessChecksConfigKey is the server ConfigMap key for the YAML list
+	// of custom checks of whether restored items are ready.
+	ReadinessChecksConfigKey = "readinessChecks"
+)
+
+// ServerSettings are the Ark server settings that can be changed
+// while the server is running.
+type ServerSettings struct {
+	RestoreResourcePriorities []string
+	ResticTimeout             time.Duration
+	BackupSyncPeriod          time.Duration
+	MaintenanceMode           bool
+	ReadinessChecks           []pkgrestore.ReadinessCheck
+}
+
+// ServerConfig holds the server's current settings. It's safe for
+// concurrent use.
+type ServerConfig struct {
+	lock     sync.RWMutex
+	defaults ServerSettings
+	current  ServerSettings
+}
+
+// NewServerConfig returns a ServerConfig whose settings are initially
+// the provided defaults.
+func NewServerConfig(defaults ServerSettings) *ServerConfig {
+	return &ServerConfig{
+		defaults: defaults,
+		current:  defaults,
+	}
+}
+
+// RestoreResourcePriorities returns the current restore resource priorities.
+func (c *ServerConfig) RestoreResourcePriorities() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return append([]string(nil), c.current.RestoreResourcePriorities...)
+}
+
+// ResticTimeout returns the current restic timeout.
+func (c *ServerConfig) ResticTimeout() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.current.ResticTimeout
+}
+
+// BackupSyncPeriod returns the current backup sync period.
+func (c *ServerConfig) BackupSyncPeriod() time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.current.BackupSyncPeriod
+}
+
+// MaintenanceMode returns whether the server is in maintenance mode.
+func (c *ServerConfig) MaintenanceMode() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.current.MaintenanceMode
+}
+
+// ReadinessChecks returns the current custom readiness checks.
+func (c *ServerConfig) ReadinessChecks() []pkgrestore.ReadinessCheck {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return append([]pkgrestore.ReadinessCheck(nil), c.current.ReadinessChecks...)
+}
+
+// update replaces the current settings with the ones in data. Settings
+// that aren't in data revert to their defaults. If any setting in data is
+// invalid, an error is returned and the current settings are unchanged.
+func (c *ServerConfig) update(data map[string]string) error {
+	settings := c.defaults
+
+	if val, ok := data[RestoreResourcePrioritiesConfigKey]; ok {
+		settings.RestoreResourcePriorities = nil
+		for _, resource := range strings.Split(val, ",") {
+			if resource = strings.TrimSpace(resource); resource != "" {
+				settings.RestoreResourcePriorities = append(settings.RestoreResourcePriorities, resource)
+			}
+		}
+	}
+
+	if val, ok := data[ResticTimeoutConfigKey]; ok {
+		timeout, err := time.ParseDuration(val)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for %s", ResticTimeoutConfigKey)
+		}
+		if timeout <= 0 {
+			return errors.Errorf("invalid value for %s: must be positive", ResticTimeoutConfigKey)
+		}
+		settings.ResticTimeout = timeout
+	}
+
+	if val, ok := data[BackupSyncPeriodConfigKey]; ok {
+		period, err := time.ParseDuration(val)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for %s", BackupSyncPeriodConfigKey)
+		}
+		settings.BackupSyncPeriod = period
+	}
+
+	if val, ok := data[MaintenanceModeConfigKey]; ok {
+		maintenanceMode, err := strconv.ParseBool(val)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for %s", MaintenanceModeConfigKey)
+		}
+		settings.MaintenanceMode = maintenanceMode
+	}
+
+	if val, ok := data[ReadinessChecksConfigKey]; ok {
+		checks, err := pkgrestore.ParseReadinessChecks(val)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for %s", ReadinessChecksConfigKey)
+		}
+		settings.ReadinessChecks = checks
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.current = settings
+
+	return nil
+}
+
+type serverConfigController struct {
+	*genericController
+
+	namespace       string
+	name            string
+	configMapLister corev1listers.ConfigMapLister
+	config          *ServerConfig
+}
+
+// NewServerConfigController returns a controller that keeps config up to date
+// with the contents of the named ConfigMap. If the ConfigMap doesn't exist, the
+// config's defaults are used.
+func NewServerConfigController(
+	namespace string,
+	name string,
+	configMapInformer cache.SharedIndexInformer,
+	config *ServerConfig,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &serverConfigController{
+		genericController: newGenericController("server-config", logger),
+		namespace:         namespace,
+		name:              name,
+		configMapLister:   corev1listers.NewConfigMapLister(configMapInformer.GetIndexer()),
+		config:            config,
+	}
+
+	c.syncHandler = c.processConfigMap
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, configMapInformer.HasSynced)
+
+	configMapInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.enqueue,
+			UpdateFunc: c.enqueueSecond,
+			DeleteFunc: func(obj interface{}) {
+				// the ConfigMap is gone, so enqueue its key directly to
+				// revert to the defaults
+				c.queue.Add(c.namespace + "/" + c.name)
+			},
+		},
+	)
+
+	return c
+}
+
+func (c *serverConfigController) processConfigMap(key string) error {
+	log := c.logger.WithField("key", key)
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return errors.Wrap(err, "error splitting queue key")
+	}
+
+	if ns != c.namespace || name != c.name {
+		return nil
+	}
+
+	var data map[string]string
+
+	configMap, err := c.configMapLister.ConfigMaps(ns).Get(name)
+	switch {
+	case apierrors.IsNotFound(err):
+		log.Info("Server ConfigMap not found, using default settings")
+	case err != nil:
+		return errors.Wrap(err, "error getting server ConfigMap")
+	default:
+		data = configMap.Data
+	}
+
+	if err := c.config.update(data); err != nil {
+		// retrying won't help until the ConfigMap is changed, so don't return the error
+		log.WithError(err).Error("Invalid server ConfigMap, keeping current settings")
+		return nil
+	}
+
+	log.WithFields(logrus.Fields{
+		"restoreResourcePriorities": c.config.RestoreResourcePriorities(),
+		"resticTimeout":             c.config.ResticTimeout(),
+		"backupSyncPeriod":          c.config.BackupSyncPeriod(),
+		"maintenanceMode":           c.config.MaintenanceMode(),
+		"readinessChecks":           len(c.config.ReadinessChecks()),
+	}).Info("Updated server settings")
+
+	return nil
+}
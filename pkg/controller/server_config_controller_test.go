@@ -0,0 +1,144 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	pkgrestore "github.com/heptio/ark/pkg/restore"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessConfigMap(t *testing.T) {
+	defaults := ServerSettings{
+		RestoreResourcePriorities: []string{"namespaces", "persistentvolumes"},
+		ResticTimeout:             time.Hour,
+		BackupSyncPeriod:          time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]string
+		missing  bool
+		expected ServerSettings
+	}{
+		{
+			name:     "missing ConfigMap uses the defaults",
+			missing:  true,
+			expected: defaults,
+		},
+		{
+			name: "settings in the ConfigMap override the defaults",
+			data: map[string]string{
+				RestoreResourcePrioritiesConfigKey: "customresourcedefinitions, namespaces",
+				ResticTimeoutConfigKey:             "2h",
+				BackupSyncPeriodConfigKey:          "5m",
+				MaintenanceModeConfigKey:           "true",
+				ReadinessChecksConfigKey: `- resource: widgets.example.com
+  jsonPath: '{.status.conditions[?(@.type=="Ready")].status}'
+  value: "True"`,
+			},
+			expected: ServerSettings{
+				RestoreResourcePriorities: []string{"customresourcedefinitions", "namespaces"},
+				ResticTimeout:             2 * time.Hour,
+				BackupSyncPeriod:          5 * time.Minute,
+				MaintenanceMode:           true,
+				ReadinessChecks: []pkgrestore.ReadinessCheck{
+					{Resource: "widgets.example.com", JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`, Value: "True"},
+				},
+			},
+		},
+		{
+			name: "settings not in the ConfigMap use the defaults",
+			data: map[string]string{
+				ResticTimeoutConfigKey: "2h",
+			},
+			expected: ServerSettings{
+				RestoreResourcePriorities: defaults.RestoreResourcePriorities,
+				ResticTimeout:             2 * time.Hour,
+				BackupSyncPeriod:          defaults.BackupSyncPeriod,
+			},
+		},
+		{
+			name: "invalid settings leave the current settings unchanged",
+			data: map[string]string{
+				RestoreResourcePrioritiesConfigKey: "namespaces",
+				ResticTimeoutConfigKey:             "not-a-duration",
+			},
+			expected: defaults,
+		},
+		{
+			name: "invalid maintenance mode leaves the current settings unchanged",
+			data: map[string]string{
+				BackupSyncPeriodConfigKey: "5m",
+				MaintenanceModeConfigKey:  "sometimes",
+			},
+			expected: defaults,
+		},
+		{
+			name: "invalid readiness checks leave the current settings unchanged",
+			data: map[string]string{
+				BackupSyncPeriodConfigKey: "5m",
+				ReadinessChecksConfigKey:  "- resource: widgets.example.com\n  jsonPath: '{.status'",
+			},
+			expected: defaults,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				configMapInformer = cache.NewSharedIndexInformer(nil, new(corev1api.ConfigMap), 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+				config            = NewServerConfig(defaults)
+			)
+
+			c := NewServerConfigController(
+				"heptio-ark",
+				"ark-server-config",
+				configMapInformer,
+				config,
+				arktest.NewLogger(),
+			).(*serverConfigController)
+
+			if !test.missing {
+				configMap := &corev1api.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "heptio-ark",
+						Name:      "ark-server-config",
+					},
+					Data: test.data,
+				}
+				require.NoError(t, configMapInformer.GetStore().Add(configMap))
+			}
+
+			require.NoError(t, c.processConfigMap("heptio-ark/ark-server-config"))
+
+			assert.Equal(t, test.expected.RestoreResourcePriorities, config.RestoreResourcePriorities())
+			assert.Equal(t, test.expected.ResticTimeout, config.ResticTimeout())
+			assert.Equal(t, test.expected.BackupSyncPeriod, config.BackupSyncPeriod())
+			assert.Equal(t, test.expected.MaintenanceMode, config.MaintenanceMode())
+			assert.Equal(t, test.expected.ReadinessChecks, config.ReadinessChecks())
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	kubeutil "github.com/heptio/ark/pkg/util/kube"
+)
+
+const (
+	StaleOperationSyncPeriod = 5 * time.Minute
+)
+
+// staleOperationController finds backups and restores that have been InProgress for
+// longer than a configured duration without being tracked by this server (e.g. because
+// the server was restarted while processing them), and marks them as Failed.
+type staleOperationController struct {
+	*genericController
+
+	namespace      string
+	backupLister   listers.BackupLister
+	backupClient   arkv1client.BackupsGetter
+	backupTracker  BackupTracker
+	restoreLister  listers.RestoreLister
+	restoreClient  arkv1client.RestoresGetter
+	restoreTracker RestoreTracker
+	timeout        time.Duration
+
+	clock clock.Clock
+}
+
+// NewStaleOperationController constructs a new staleOperationController.
+func NewStaleOperationController(
+	namespace string,
+	backupInformer informers.BackupInformer,
+	backupClient arkv1client.BackupsGetter,
+	backupTracker BackupTracker,
+	restoreInformer informers.RestoreInformer,
+	restoreClient arkv1client.RestoresGetter,
+	restoreTracker RestoreTracker,
+	timeout time.Duration,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &staleOperationController{
+		genericController: newGenericController("stale-operation", logger),
+		namespace:         namespace,
+		backupLister:      backupInformer.Lister(),
+		backupClient:      backupClient,
+		backupTracker:     backupTracker,
+		restoreLister:     restoreInformer.Lister(),
+		restoreClient:     restoreClient,
+		restoreTracker:    restoreTracker,
+		timeout:           timeout,
+		clock:             clock.RealClock{},
+	}
+
+	c.resyncFunc = c.run
+	c.resyncPeriod = StaleOperationSyncPeriod
+	c.cacheSyncWaiters = []cache.InformerSynced{
+		backupInformer.Informer().HasSynced,
+		restoreInformer.Informer().HasSynced,
+	}
+
+	return c
+}
+
+func (c *staleOperationController) run() {
+	c.logger.Debug("Checking for stale in-progress backups and restores")
+
+	c.failStaleBackups()
+	c.failStaleRestores()
+}
+
+func (c *staleOperationController) failStaleBackups() {
+	backups, err := c.backupLister.Backups(c.namespace).List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(errors.WithStack(err)).Error("Error listing backups")
+		return
+	}
+
+	for _, backup := range backups {
+		if backup.Status.Phase != api.BackupPhaseInProgress {
+			continue
+		}
+
+		log := c.logger.WithField("backup", kubeutil.NamespaceAndName(backup))
+
+		if c.backupTracker.Contains(backup.Namespace, backup.Name) {
+			log.Debug("Backup is being actively processed, skipping")
+			continue
+		}
+
+		started := backup.Status.StartTimestamp.Time
+		if started.IsZero() {
+			started = backup.CreationTimestamp.Time
+		}
+		if !c.isStale(started) {
+			continue
+		}
+
+		log.Warnf("Backup has been in progress for longer than %s and is not being processed, marking it as failed", c.timeout)
+
+		updated := backup.DeepCopy()
+		updated.Status.Phase = api.BackupPhaseFailed
+		updated.Status.FailureReason = staleFailureReason(c.timeout)
+		updated.Status.CompletionTimestamp.Time = c.clock.Now()
+
+		if _, err := patchBackup(backup, updated, c.backupClient); err != nil {
+			log.WithError(err).Error("Error marking stale backup as failed")
+		}
+	}
+}
+
+func (c *staleOperationController) failStaleRestores() {
+	restores, err := c.restoreLister.Restores(c.namespace).List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(errors.WithStack(err)).Error("Error listing restores")
+		return
+	}
+
+	for _, restore := range restores {
+		if restore.Status.Phase != api.RestorePhaseInProgress {
+			continue
+		}
+
+		log := c.logger.WithField("restore", kubeutil.NamespaceAndName(restore))
+
+		if c.restoreTracker.Contains(restore.Namespace, restore.Name) {
+			log.Debug("Restore is being actively processed, skipping")
+			continue
+		}
+
+		// restores created by older versions of Ark won't have a start timestamp,
+		// so fall back to the creation timestamp.
+		started := restore.Status.StartTimestamp.Time
+		if started.IsZero() {
+			started = restore.CreationTimestamp.Time
+		}
+		if !c.isStale(started) {
+			continue
+		}
+
+		log.Warnf("Restore has been in progress for longer than %s and is not being processed, marking it as failed", c.timeout)
+
+		updated := restore.DeepCopy()
+		updated.Status.Phase = api.RestorePhaseFailed
+		updated.Status.FailureReason = staleFailureReason(c.timeout)
+
+		if _, err := patchRestore(restore, updated, c.restoreClient); err != nil {
+			log.WithError(err).Error("Error marking stale restore as failed")
+		}
+	}
+}
+
+func (c *staleOperationController) isStale(started time.Time) bool {
+	return !started.IsZero() && c.clock.Now().Sub(started) > c.timeout
+}
+
+func staleFailureReason(timeout time.Duration) string {
+	return fmt.Sprintf("timed out: in progress for longer than %s without being processed by an Ark server", timeout)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestStaleOperationControllerRun(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	timeout := time.Hour
+
+	tests := []struct {
+		name                string
+		backup              *api.Backup
+		restore             *api.Restore
+		trackBackup         bool
+		trackRestore        bool
+		expectBackupFailed  bool
+		expectRestoreFailed bool
+	}{
+		{
+			name: "completed backup is not modified",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithPhase(api.BackupPhaseCompleted).
+				WithStartTimestamp(fakeClock.Now().Add(-2 * timeout)).
+				Backup,
+		},
+		{
+			name: "in-progress backup within the timeout is not modified",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithPhase(api.BackupPhaseInProgress).
+				WithStartTimestamp(fakeClock.Now().Add(-1 * time.Minute)).
+				Backup,
+		},
+		{
+			name: "tracked in-progress backup past the timeout is not modified",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithPhase(api.BackupPhaseInProgress).
+				WithStartTimestamp(fakeClock.Now().Add(-2 * timeout)).
+				Backup,
+			trackBackup: true,
+		},
+		{
+			name: "untracked in-progress backup past the timeout is marked failed",
+			backup: arktest.NewTestBackup().WithName("backup-1").
+				WithPhase(api.BackupPhaseInProgress).
+				WithStartTimestamp(fakeClock.Now().Add(-2 * timeout)).
+				Backup,
+			expectBackupFailed: true,
+		},
+		{
+			name:    "in-progress restore within the timeout is not modified",
+			restore: withStartTimestamp(arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseInProgress).Restore, fakeClock.Now()),
+		},
+		{
+			name:         "tracked in-progress restore past the timeout is not modified",
+			restore:      withStartTimestamp(arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseInProgress).Restore, fakeClock.Now().Add(-2*timeout)),
+			trackRestore: true,
+		},
+		{
+			name:                "untracked in-progress restore past the timeout is marked failed",
+			restore:             withStartTimestamp(arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseInProgress).Restore, fakeClock.Now().Add(-2*timeout)),
+			expectRestoreFailed: true,
+		},
+		{
+			name: "untracked in-progress restore without a start timestamp uses its creation timestamp",
+			restore: func() *api.Restore {
+				r := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseInProgress).Restore
+				r.CreationTimestamp = metav1.NewTime(fakeClock.Now().Add(-2 * timeout))
+				return r
+			}(),
+			expectRestoreFailed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset()
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				backupTracker   = NewBackupTracker()
+				restoreTracker  = NewRestoreTracker()
+			)
+
+			c := NewStaleOperationController(
+				api.DefaultNamespace,
+				sharedInformers.Ark().V1().Backups(),
+				client.ArkV1(),
+				backupTracker,
+				sharedInformers.Ark().V1().Restores(),
+				client.ArkV1(),
+				restoreTracker,
+				timeout,
+				arktest.NewLogger(),
+			).(*staleOperationController)
+			c.clock = fakeClock
+
+			if test.backup != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Backups().Informer().GetStore().Add(test.backup))
+				if test.trackBackup {
+					backupTracker.Add(test.backup.Namespace, test.backup.Name)
+				}
+			}
+			if test.restore != nil {
+				require.NoError(t, sharedInformers.Ark().V1().Restores().Informer().GetStore().Add(test.restore))
+				if test.trackRestore {
+					restoreTracker.Add(test.restore.Namespace, test.restore.Name)
+				}
+			}
+
+			c.run()
+
+			var backupPatches, restorePatches []core.PatchAction
+			for _, action := range client.Actions() {
+				patch, ok := action.(core.PatchAction)
+				if !ok {
+					continue
+				}
+				switch patch.GetResource().Resource {
+				case "backups":
+					backupPatches = append(backupPatches, patch)
+				case "restores":
+					restorePatches = append(restorePatches, patch)
+				}
+			}
+
+			if test.expectBackupFailed {
+				require.Len(t, backupPatches, 1)
+				status := decodeStatusPatch(t, backupPatches[0].GetPatch())
+				assert.Equal(t, string(api.BackupPhaseFailed), status["phase"])
+				assert.Equal(t, staleFailureReason(timeout), status["failureReason"])
+			} else {
+				assert.Empty(t, backupPatches)
+			}
+
+			if test.expectRestoreFailed {
+				require.Len(t, restorePatches, 1)
+				status := decodeStatusPatch(t, restorePatches[0].GetPatch())
+				assert.Equal(t, string(api.RestorePhaseFailed), status["phase"])
+				assert.Equal(t, staleFailureReason(timeout), status["failureReason"])
+			} else {
+				assert.Empty(t, restorePatches)
+			}
+		})
+	}
+}
+
+func withStartTimestamp(restore *api.Restore, t time.Time) *api.Restore {
+	restore.Status.StartTimestamp = metav1.NewTime(t)
+	return restore
+}
+
+func decodeStatusPatch(t *testing.T, patch []byte) map[string]interface{} {
+	var res struct {
+		Status map[string]interface{} `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(patch, &res))
+	return res.Status
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// maxStatusListLength is the number of entries of a list in a backup's or
+// restore's status that are kept in the API object. Longer lists are
+// truncated so that the object stays well under etcd's size limit.
+const maxStatusListLength = 100
+
+// truncateStatusList returns the first maxStatusListLength entries of list,
+// followed by an entry saying how many were omitted, and whether it had to
+// be truncated.
+func truncateStatusList(list []string) ([]string, bool) {
+	if len(list) <= maxStatusListLength {
+		return list, false
+	}
+
+	truncated := make([]string, maxStatusListLength, maxStatusListLength+1)
+	copy(truncated, list)
+	truncated = append(truncated, fmt.Sprintf("... and %d more", len(list)-maxStatusListLength))
+
+	return truncated, true
+}
+
+// backupStatusDetails is the content of a backup's status details file: the
+// complete versions of the lists that were truncated in its status.
+type backupStatusDetails struct {
+	ValidationErrors []string `json:"validationErrors,omitempty"`
+	ExtraItems       []string `json:"extraItems,omitempty"`
+}
+
+// truncateBackupStatus truncates the lists in a backup's status that are too
+// long to be stored in the API object. If any were truncated, it sets the
+// status's TruncatedStatus and returns the gzip-compressed JSON of the
+// complete lists, to be uploaded as the backup's status details file;
+// otherwise, it returns nil.
+func truncateBackupStatus(backup *api.Backup) (*bytes.Buffer, error) {
+	details := backupStatusDetails{
+		ValidationErrors: backup.Status.ValidationErrors,
+		ExtraItems:       backup.Status.ExtraItems,
+	}
+
+	var validationErrorsTruncated, extraItemsTruncated bool
+	backup.Status.ValidationErrors, validationErrorsTruncated = truncateStatusList(backup.Status.ValidationErrors)
+	backup.Status.ExtraItems, extraItemsTruncated = truncateStatusList(backup.Status.ExtraItems)
+
+	if !validationErrorsTruncated && !extraItemsTruncated {
+		return nil, nil
+	}
+	backup.Status.TruncatedStatus = true
+
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	if err := json.NewEncoder(gzw).Encode(details); err != nil {
+		return nil, errors.Wrap(err, "error encoding backup status details")
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error closing gzip writer")
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+func stringList(prefix string, n int) []string {
+	var list []string
+	for i := 0; i < n; i++ {
+		list = append(list, fmt.Sprintf("%s-%d", prefix, i))
+	}
+	return list
+}
+
+func TestTruncateStatusList(t *testing.T) {
+	tests := []struct {
+		name              string
+		list              []string
+		expectedLength    int
+		expectedTruncated bool
+	}{
+		{
+			name: "nil list isn't truncated",
+		},
+		{
+			name:           "list at the limit isn't truncated",
+			list:           stringList("item", maxStatusListLength),
+			expectedLength: maxStatusListLength,
+		},
+		{
+			name:              "list over the limit is truncated",
+			list:              stringList("item", maxStatusListLength+5),
+			expectedLength:    maxStatusListLength + 1,
+			expectedTruncated: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, truncated := truncateStatusList(test.list)
+
+			assert.Equal(t, test.expectedTruncated, truncated)
+			assert.Len(t, list, test.expectedLength)
+			if test.expectedTruncated {
+				assert.Equal(t, test.list[:maxStatusListLength], list[:maxStatusListLength])
+				assert.Equal(t, "... and 5 more", list[maxStatusListLength])
+			}
+		})
+	}
+}
+
+func TestTruncateBackupStatus(t *testing.T) {
+	t.Run("short lists aren't truncated", func(t *testing.T) {
+		backup := &api.Backup{Status: api.BackupStatus{ExtraItems: stringList("item", 2)}}
+
+		details, err := truncateBackupStatus(backup)
+		require.NoError(t, err)
+
+		assert.Nil(t, details)
+		assert.False(t, backup.Status.TruncatedStatus)
+		assert.Equal(t, stringList("item", 2), backup.Status.ExtraItems)
+	})
+
+	t.Run("long lists are truncated and returned in full", func(t *testing.T) {
+		backup := &api.Backup{Status: api.BackupStatus{ExtraItems: stringList("item", maxStatusListLength*2)}}
+
+		details, err := truncateBackupStatus(backup)
+		require.NoError(t, err)
+		require.NotNil(t, details)
+
+		assert.True(t, backup.Status.TruncatedStatus)
+		assert.Len(t, backup.Status.ExtraItems, maxStatusListLength+1)
+
+		gzr, err := gzip.NewReader(details)
+		require.NoError(t, err)
+		var decoded backupStatusDetails
+		require.NoError(t, json.NewDecoder(gzr).Decode(&decoded))
+
+		assert.Equal(t, stringList("item", maxStatusListLength*2), decoded.ExtraItems)
+		assert.Empty(t, decoded.ValidationErrors)
+	})
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arkv1client "github.com/heptio/ark/pkg/generated/clientset/versioned/typed/ark/v1"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/plugin"
+)
+
+type volumeSnapshotLocationController struct {
+	*genericController
+
+	snapshotLocationClient arkv1client.VolumeSnapshotLocationsGetter
+	snapshotLocationLister listers.VolumeSnapshotLocationLister
+	newPluginManager       func(logrus.FieldLogger) plugin.Manager
+}
+
+// NewVolumeSnapshotLocationController creates a new controller that validates
+// each VolumeSnapshotLocation's provider and config when the location is created
+// or its spec changes, and records the result in the location's status.
+func NewVolumeSnapshotLocationController(
+	snapshotLocationInformer informers.VolumeSnapshotLocationInformer,
+	snapshotLocationClient arkv1client.VolumeSnapshotLocationsGetter,
+	newPluginManager func(logrus.FieldLogger) plugin.Manager,
+	logger logrus.FieldLogger,
+) Interface {
+	c := &volumeSnapshotLocationController{
+		genericController:      newGenericController("volumesnapshotlocation", logger),
+		snapshotLocationClient: snapshotLocationClient,
+		snapshotLocationLister: snapshotLocationInformer.Lister(),
+		newPluginManager:       newPluginManager,
+	}
+
+	c.syncHandler = c.processLocation
+	c.cacheSyncWaiters = append(c.cacheSyncWaiters, snapshotLocationInformer.Informer().HasSynced)
+
+	snapshotLocationInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueue,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldLocation := oldObj.(*api.VolumeSnapshotLocation)
+				newLocation := newObj.(*api.VolumeSnapshotLocation)
+
+				// status-only updates (including our own) don't need
+				// to be re-validated
+				if reflect.DeepEqual(oldLocation.Spec, newLocation.Spec) {
+					return
+				}
+
+				c.enqueue(newObj)
+			},
+		},
+	)
+
+	return c
+}
+
+// processLocation validates the VolumeSnapshotLocation identified by key by
+// initializing its provider's block store with its config, and sets its
+// status phase to Available or Unavailable accordingly.
+func (c *volumeSnapshotLocationController) processLocation(key string) error {
+	log := c.logger.WithField("key", key)
+
+	log.Debug("Running processLocation")
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.WithError(err).Error("error splitting queue key")
+		return nil
+	}
+
+	location, err := c.snapshotLocationLister.VolumeSnapshotLocations(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Debug("Unable to find VolumeSnapshotLocation")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "error getting VolumeSnapshotLocation")
+	}
+
+	update := location.DeepCopy()
+	if err := c.validateLocation(location, log); err != nil {
+		log.WithError(err).Warn("VolumeSnapshotLocation is unavailable")
+		update.Status.Phase = api.VolumeSnapshotLocationPhaseUnavailable
+		update.Status.Message = err.Error()
+	} else {
+		update.Status.Phase = api.VolumeSnapshotLocationPhaseAvailable
+		update.Status.Message = ""
+	}
+
+	if reflect.DeepEqual(location.Status, update.Status) {
+		return nil
+	}
+
+	_, err = patchVolumeSnapshotLocation(location, update, c.snapshotLocationClient)
+	return err
+}
+
+// validateLocation returns an error if location's provider has no block store
+// plugin or if the block store can't be initialized with location's config.
+func (c *volumeSnapshotLocationController) validateLocation(location *api.VolumeSnapshotLocation, log logrus.FieldLogger) error {
+	pluginManager := c.newPluginManager(log)
+	defer pluginManager.CleanupClients()
+
+	blockStore, err := pluginManager.GetBlockStore(location.Spec.Provider)
+	if err != nil {
+		return errors.Wrapf(err, "error getting block store for provider %s", location.Spec.Provider)
+	}
+
+	if err := blockStore.Init(location.Spec.Config); err != nil {
+		return errors.Wrapf(err, "error initializing block store for provider %s", location.Spec.Provider)
+	}
+
+	return nil
+}
+
+func patchVolumeSnapshotLocation(original, updated *api.VolumeSnapshotLocation, client arkv1client.VolumeSnapshotLocationsGetter) (*api.VolumeSnapshotLocation, error) {
+	origBytes, err := json.Marshal(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling original volume snapshot location")
+	}
+
+	updatedBytes, err := json.Marshal(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling updated volume snapshot location")
+	}
+
+	patchBytes, err := jsonpatch.CreateMergePatch(origBytes, updatedBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating json merge patch for volume snapshot location")
+	}
+
+	res, err := client.VolumeSnapshotLocations(original.Namespace).Patch(original.Name, types.MergePatchType, patchBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error patching volume snapshot location")
+	}
+
+	return res, nil
+}
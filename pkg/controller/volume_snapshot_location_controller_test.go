@@ -0,0 +1,128 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	cloudprovidermocks "github.com/heptio/ark/pkg/cloudprovider/mocks"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/plugin"
+	pluginmocks "github.com/heptio/ark/pkg/plugin/mocks"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestProcessVolumeSnapshotLocation(t *testing.T) {
+	tests := []struct {
+		name             string
+		location         *api.VolumeSnapshotLocation
+		getBlockStoreErr error
+		initErr          error
+		expectedPhase    api.VolumeSnapshotLocationPhase
+		expectedMessage  string
+	}{
+		{
+			name:          "valid new location is marked available",
+			location:      arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("aws").VolumeSnapshotLocation,
+			expectedPhase: api.VolumeSnapshotLocationPhaseAvailable,
+		},
+		{
+			name:             "location with no block store plugin is marked unavailable",
+			location:         arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("aws").VolumeSnapshotLocation,
+			getBlockStoreErr: errors.New("plugin not found"),
+			expectedPhase:    api.VolumeSnapshotLocationPhaseUnavailable,
+			expectedMessage:  "error getting block store for provider aws: plugin not found",
+		},
+		{
+			name:            "location whose block store fails to initialize is marked unavailable",
+			location:        arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("aws").VolumeSnapshotLocation,
+			initErr:         errors.New("missing region"),
+			expectedPhase:   api.VolumeSnapshotLocationPhaseUnavailable,
+			expectedMessage: "error initializing block store for provider aws: missing region",
+		},
+		{
+			name:          "previously unavailable location that now validates is marked available",
+			location:      arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("aws").WithPhase(api.VolumeSnapshotLocationPhaseUnavailable, "missing region").VolumeSnapshotLocation,
+			expectedPhase: api.VolumeSnapshotLocationPhaseAvailable,
+		},
+		{
+			name:     "already-available location that still validates is not patched",
+			location: arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("aws").WithPhase(api.VolumeSnapshotLocationPhaseAvailable, "").VolumeSnapshotLocation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				client          = fake.NewSimpleClientset(test.location)
+				sharedInformers = informers.NewSharedInformerFactory(client, 0)
+				pluginManager   = new(pluginmocks.Manager)
+				blockStore      = new(cloudprovidermocks.BlockStore)
+			)
+			defer pluginManager.AssertExpectations(t)
+
+			c := NewVolumeSnapshotLocationController(
+				sharedInformers.Ark().V1().VolumeSnapshotLocations(),
+				client.ArkV1(),
+				func(logrus.FieldLogger) plugin.Manager { return pluginManager },
+				arktest.NewLogger(),
+			).(*volumeSnapshotLocationController)
+
+			require.NoError(t, sharedInformers.Ark().V1().VolumeSnapshotLocations().Informer().GetStore().Add(test.location))
+
+			pluginManager.On("CleanupClients").Return()
+			if test.getBlockStoreErr != nil {
+				pluginManager.On("GetBlockStore", test.location.Spec.Provider).Return(nil, test.getBlockStoreErr)
+			} else {
+				pluginManager.On("GetBlockStore", test.location.Spec.Provider).Return(blockStore, nil)
+				blockStore.On("Init", test.location.Spec.Config).Return(test.initErr)
+			}
+
+			require.NoError(t, c.processLocation(test.location.Namespace+"/"+test.location.Name))
+
+			var patches []core.PatchAction
+			for _, action := range client.Actions() {
+				if patch, ok := action.(core.PatchAction); ok {
+					patches = append(patches, patch)
+				}
+			}
+
+			if test.expectedPhase == "" {
+				assert.Empty(t, patches)
+				return
+			}
+
+			require.Len(t, patches, 1)
+			status := decodeStatusPatch(t, patches[0].GetPatch())
+			assert.Equal(t, string(test.expectedPhase), status["phase"])
+			if test.expectedMessage != "" {
+				assert.Equal(t, test.expectedMessage, status["message"])
+			} else {
+				// a cleared message is sent as null
+				assert.Nil(t, status["message"])
+			}
+		})
+	}
+}
@@ -28,14 +28,23 @@ import (
 type ArkV1Interface interface {
 	RESTClient() rest.Interface
 	BackupsGetter
+	BackupEstimatesGetter
+	BackupPoliciesGetter
 	BackupStorageLocationsGetter
+	DataDownloadsGetter
 	DeleteBackupRequestsGetter
 	DownloadRequestsGetter
 	PodVolumeBackupsGetter
 	PodVolumeRestoresGetter
+	ProtectedApplicationsGetter
+	ResticDaemonSetConfigsGetter
 	ResticRepositoriesGetter
 	RestoresGetter
+	RestorePrioritiesGetter
+	RestoreVerificationsGetter
 	SchedulesGetter
+	UndoRestoreRequestsGetter
+	VolumeSnapshotsGetter
 	VolumeSnapshotLocationsGetter
 }
 
@@ -48,10 +57,22 @@ func (c *ArkV1Client) Backups(namespace string) BackupInterface {
 	return newBackups(c, namespace)
 }
 
+func (c *ArkV1Client) BackupEstimates(namespace string) BackupEstimateInterface {
+	return newBackupEstimates(c, namespace)
+}
+
+func (c *ArkV1Client) BackupPolicies(namespace string) BackupPolicyInterface {
+	return newBackupPolicies(c, namespace)
+}
+
 func (c *ArkV1Client) BackupStorageLocations(namespace string) BackupStorageLocationInterface {
 	return newBackupStorageLocations(c, namespace)
 }
 
+func (c *ArkV1Client) DataDownloads(namespace string) DataDownloadInterface {
+	return newDataDownloads(c, namespace)
+}
+
 func (c *ArkV1Client) DeleteBackupRequests(namespace string) DeleteBackupRequestInterface {
 	return newDeleteBackupRequests(c, namespace)
 }
@@ -68,6 +89,14 @@ func (c *ArkV1Client) PodVolumeRestores(namespace string) PodVolumeRestoreInterf
 	return newPodVolumeRestores(c, namespace)
 }
 
+func (c *ArkV1Client) ProtectedApplications(namespace string) ProtectedApplicationInterface {
+	return newProtectedApplications(c, namespace)
+}
+
+func (c *ArkV1Client) ResticDaemonSetConfigs(namespace string) ResticDaemonSetConfigInterface {
+	return newResticDaemonSetConfigs(c, namespace)
+}
+
 func (c *ArkV1Client) ResticRepositories(namespace string) ResticRepositoryInterface {
 	return newResticRepositories(c, namespace)
 }
@@ -76,10 +105,26 @@ func (c *ArkV1Client) Restores(namespace string) RestoreInterface {
 	return newRestores(c, namespace)
 }
 
+func (c *ArkV1Client) RestorePriorities(namespace string) RestorePriorityInterface {
+	return newRestorePriorities(c, namespace)
+}
+
+func (c *ArkV1Client) RestoreVerifications(namespace string) RestoreVerificationInterface {
+	return newRestoreVerifications(c, namespace)
+}
+
 func (c *ArkV1Client) Schedules(namespace string) ScheduleInterface {
 	return newSchedules(c, namespace)
 }
 
+func (c *ArkV1Client) UndoRestoreRequests(namespace string) UndoRestoreRequestInterface {
+	return newUndoRestoreRequests(c, namespace)
+}
+
+func (c *ArkV1Client) VolumeSnapshots(namespace string) VolumeSnapshotInterface {
+	return newVolumeSnapshots(c, namespace)
+}
+
 func (c *ArkV1Client) VolumeSnapshotLocations(namespace string) VolumeSnapshotLocationInterface {
 	return newVolumeSnapshotLocations(c, namespace)
 }
@@ -32,10 +32,22 @@ func (c *FakeArkV1) Backups(namespace string) v1.BackupInterface {
 	return &FakeBackups{c, namespace}
 }
 
+func (c *FakeArkV1) BackupEstimates(namespace string) v1.BackupEstimateInterface {
+	return &FakeBackupEstimates{c, namespace}
+}
+
+func (c *FakeArkV1) BackupPolicies(namespace string) v1.BackupPolicyInterface {
+	return &FakeBackupPolicies{c, namespace}
+}
+
 func (c *FakeArkV1) BackupStorageLocations(namespace string) v1.BackupStorageLocationInterface {
 	return &FakeBackupStorageLocations{c, namespace}
 }
 
+func (c *FakeArkV1) DataDownloads(namespace string) v1.DataDownloadInterface {
+	return &FakeDataDownloads{c, namespace}
+}
+
 func (c *FakeArkV1) DeleteBackupRequests(namespace string) v1.DeleteBackupRequestInterface {
 	return &FakeDeleteBackupRequests{c, namespace}
 }
@@ -52,6 +64,14 @@ func (c *FakeArkV1) PodVolumeRestores(namespace string) v1.PodVolumeRestoreInter
 	return &FakePodVolumeRestores{c, namespace}
 }
 
+func (c *FakeArkV1) ProtectedApplications(namespace string) v1.ProtectedApplicationInterface {
+	return &FakeProtectedApplications{c, namespace}
+}
+
+func (c *FakeArkV1) ResticDaemonSetConfigs(namespace string) v1.ResticDaemonSetConfigInterface {
+	return &FakeResticDaemonSetConfigs{c, namespace}
+}
+
 func (c *FakeArkV1) ResticRepositories(namespace string) v1.ResticRepositoryInterface {
 	return &FakeResticRepositories{c, namespace}
 }
@@ -60,10 +80,26 @@ func (c *FakeArkV1) Restores(namespace string) v1.RestoreInterface {
 	return &FakeRestores{c, namespace}
 }
 
+func (c *FakeArkV1) RestorePriorities(namespace string) v1.RestorePriorityInterface {
+	return &FakeRestorePriorities{c, namespace}
+}
+
+func (c *FakeArkV1) RestoreVerifications(namespace string) v1.RestoreVerificationInterface {
+	return &FakeRestoreVerifications{c, namespace}
+}
+
 func (c *FakeArkV1) Schedules(namespace string) v1.ScheduleInterface {
 	return &FakeSchedules{c, namespace}
 }
 
+func (c *FakeArkV1) UndoRestoreRequests(namespace string) v1.UndoRestoreRequestInterface {
+	return &FakeUndoRestoreRequests{c, namespace}
+}
+
+func (c *FakeArkV1) VolumeSnapshots(namespace string) v1.VolumeSnapshotInterface {
+	return &FakeVolumeSnapshots{c, namespace}
+}
+
 func (c *FakeArkV1) VolumeSnapshotLocations(namespace string) v1.VolumeSnapshotLocationInterface {
 	return &FakeVolumeSnapshotLocations{c, namespace}
 }
@@ -20,8 +20,14 @@ package v1
 
 type BackupExpansion interface{}
 
+type BackupEstimateExpansion interface{}
+
+type BackupPolicyExpansion interface{}
+
 type BackupStorageLocationExpansion interface{}
 
+type DataDownloadExpansion interface{}
+
 type DeleteBackupRequestExpansion interface{}
 
 type DownloadRequestExpansion interface{}
@@ -30,10 +36,22 @@ type PodVolumeBackupExpansion interface{}
 
 type PodVolumeRestoreExpansion interface{}
 
+type ProtectedApplicationExpansion interface{}
+
+type ResticDaemonSetConfigExpansion interface{}
+
 type ResticRepositoryExpansion interface{}
 
 type RestoreExpansion interface{}
 
+type RestorePriorityExpansion interface{}
+
+type RestoreVerificationExpansion interface{}
+
 type ScheduleExpansion interface{}
 
+type UndoRestoreRequestExpansion interface{}
+
+type VolumeSnapshotExpansion interface{}
+
 type VolumeSnapshotLocationExpansion interface{}
@@ -26,8 +26,14 @@ import (
 type Interface interface {
 	// Backups returns a BackupInformer.
 	Backups() BackupInformer
+	// BackupEstimates returns a BackupEstimateInformer.
+	BackupEstimates() BackupEstimateInformer
+	// BackupPolicies returns a BackupPolicyInformer.
+	BackupPolicies() BackupPolicyInformer
 	// BackupStorageLocations returns a BackupStorageLocationInformer.
 	BackupStorageLocations() BackupStorageLocationInformer
+	// DataDownloads returns a DataDownloadInformer.
+	DataDownloads() DataDownloadInformer
 	// DeleteBackupRequests returns a DeleteBackupRequestInformer.
 	DeleteBackupRequests() DeleteBackupRequestInformer
 	// DownloadRequests returns a DownloadRequestInformer.
@@ -36,12 +42,24 @@ type Interface interface {
 	PodVolumeBackups() PodVolumeBackupInformer
 	// PodVolumeRestores returns a PodVolumeRestoreInformer.
 	PodVolumeRestores() PodVolumeRestoreInformer
+	// ProtectedApplications returns a ProtectedApplicationInformer.
+	ProtectedApplications() ProtectedApplicationInformer
+	// ResticDaemonSetConfigs returns a ResticDaemonSetConfigInformer.
+	ResticDaemonSetConfigs() ResticDaemonSetConfigInformer
 	// ResticRepositories returns a ResticRepositoryInformer.
 	ResticRepositories() ResticRepositoryInformer
 	// Restores returns a RestoreInformer.
 	Restores() RestoreInformer
+	// RestorePriorities returns a RestorePriorityInformer.
+	RestorePriorities() RestorePriorityInformer
+	// RestoreVerifications returns a RestoreVerificationInformer.
+	RestoreVerifications() RestoreVerificationInformer
 	// Schedules returns a ScheduleInformer.
 	Schedules() ScheduleInformer
+	// UndoRestoreRequests returns a UndoRestoreRequestInformer.
+	UndoRestoreRequests() UndoRestoreRequestInformer
+	// VolumeSnapshots returns a VolumeSnapshotInformer.
+	VolumeSnapshots() VolumeSnapshotInformer
 	// VolumeSnapshotLocations returns a VolumeSnapshotLocationInformer.
 	VolumeSnapshotLocations() VolumeSnapshotLocationInformer
 }
@@ -62,11 +80,26 @@ func (v *version) Backups() BackupInformer {
 	return &backupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// BackupEstimates returns a BackupEstimateInformer.
+func (v *version) BackupEstimates() BackupEstimateInformer {
+	return &backupEstimateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// BackupPolicies returns a BackupPolicyInformer.
+func (v *version) BackupPolicies() BackupPolicyInformer {
+	return &backupPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // BackupStorageLocations returns a BackupStorageLocationInformer.
 func (v *version) BackupStorageLocations() BackupStorageLocationInformer {
 	return &backupStorageLocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DataDownloads returns a DataDownloadInformer.
+func (v *version) DataDownloads() DataDownloadInformer {
+	return &dataDownloadInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DeleteBackupRequests returns a DeleteBackupRequestInformer.
 func (v *version) DeleteBackupRequests() DeleteBackupRequestInformer {
 	return &deleteBackupRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -87,6 +120,16 @@ func (v *version) PodVolumeRestores() PodVolumeRestoreInformer {
 	return &podVolumeRestoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ProtectedApplications returns a ProtectedApplicationInformer.
+func (v *version) ProtectedApplications() ProtectedApplicationInformer {
+	return &protectedApplicationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ResticDaemonSetConfigs returns a ResticDaemonSetConfigInformer.
+func (v *version) ResticDaemonSetConfigs() ResticDaemonSetConfigInformer {
+	return &resticDaemonSetConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ResticRepositories returns a ResticRepositoryInformer.
 func (v *version) ResticRepositories() ResticRepositoryInformer {
 	return &resticRepositoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -97,11 +140,31 @@ func (v *version) Restores() RestoreInformer {
 	return &restoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// RestorePriorities returns a RestorePriorityInformer.
+func (v *version) RestorePriorities() RestorePriorityInformer {
+	return &restorePriorityInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RestoreVerifications returns a RestoreVerificationInformer.
+func (v *version) RestoreVerifications() RestoreVerificationInformer {
+	return &restoreVerificationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Schedules returns a ScheduleInformer.
 func (v *version) Schedules() ScheduleInformer {
 	return &scheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// UndoRestoreRequests returns a UndoRestoreRequestInformer.
+func (v *version) UndoRestoreRequests() UndoRestoreRequestInformer {
+	return &undoRestoreRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VolumeSnapshots returns a VolumeSnapshotInformer.
+func (v *version) VolumeSnapshots() VolumeSnapshotInformer {
+	return &volumeSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VolumeSnapshotLocations returns a VolumeSnapshotLocationInformer.
 func (v *version) VolumeSnapshotLocations() VolumeSnapshotLocationInformer {
 	return &volumeSnapshotLocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
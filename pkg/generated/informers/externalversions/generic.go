@@ -55,8 +55,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=ark.heptio.com, Version=v1
 	case v1.SchemeGroupVersion.WithResource("backups"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().Backups().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("backupestimates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().BackupEstimates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("backuppolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().BackupPolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("backupstoragelocations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().BackupStorageLocations().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("datadownloads"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().DataDownloads().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("deletebackuprequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().DeleteBackupRequests().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("downloadrequests"):
@@ -65,12 +71,24 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().PodVolumeBackups().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("podvolumerestores"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().PodVolumeRestores().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("protectedapplications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().ProtectedApplications().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("resticdaemonsetconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().ResticDaemonSetConfigs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("resticrepositories"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().ResticRepositories().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("restores"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().Restores().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("restorepriorities"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().RestorePriorities().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("restoreverifications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().RestoreVerifications().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("schedules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().Schedules().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("undorestorerequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().UndoRestoreRequests().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("volumesnapshots"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().VolumeSnapshots().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("volumesnapshotlocations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ark().V1().VolumeSnapshotLocations().Informer()}, nil
 
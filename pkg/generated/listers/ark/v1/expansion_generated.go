@@ -26,6 +26,22 @@ type BackupListerExpansion interface{}
 // BackupNamespaceLister.
 type BackupNamespaceListerExpansion interface{}
 
+// BackupEstimateListerExpansion allows custom methods to be added to
+// BackupEstimateLister.
+type BackupEstimateListerExpansion interface{}
+
+// BackupEstimateNamespaceListerExpansion allows custom methods to be added to
+// BackupEstimateNamespaceLister.
+type BackupEstimateNamespaceListerExpansion interface{}
+
+// BackupPolicyListerExpansion allows custom methods to be added to
+// BackupPolicyLister.
+type BackupPolicyListerExpansion interface{}
+
+// BackupPolicyNamespaceListerExpansion allows custom methods to be added to
+// BackupPolicyNamespaceLister.
+type BackupPolicyNamespaceListerExpansion interface{}
+
 // BackupStorageLocationListerExpansion allows custom methods to be added to
 // BackupStorageLocationLister.
 type BackupStorageLocationListerExpansion interface{}
@@ -34,6 +50,14 @@ type BackupStorageLocationListerExpansion interface{}
 // BackupStorageLocationNamespaceLister.
 type BackupStorageLocationNamespaceListerExpansion interface{}
 
+// DataDownloadListerExpansion allows custom methods to be added to
+// DataDownloadLister.
+type DataDownloadListerExpansion interface{}
+
+// DataDownloadNamespaceListerExpansion allows custom methods to be added to
+// DataDownloadNamespaceLister.
+type DataDownloadNamespaceListerExpansion interface{}
+
 // DeleteBackupRequestListerExpansion allows custom methods to be added to
 // DeleteBackupRequestLister.
 type DeleteBackupRequestListerExpansion interface{}
@@ -66,6 +90,22 @@ type PodVolumeRestoreListerExpansion interface{}
 // PodVolumeRestoreNamespaceLister.
 type PodVolumeRestoreNamespaceListerExpansion interface{}
 
+// ProtectedApplicationListerExpansion allows custom methods to be added to
+// ProtectedApplicationLister.
+type ProtectedApplicationListerExpansion interface{}
+
+// ProtectedApplicationNamespaceListerExpansion allows custom methods to be added to
+// ProtectedApplicationNamespaceLister.
+type ProtectedApplicationNamespaceListerExpansion interface{}
+
+// ResticDaemonSetConfigListerExpansion allows custom methods to be added to
+// ResticDaemonSetConfigLister.
+type ResticDaemonSetConfigListerExpansion interface{}
+
+// ResticDaemonSetConfigNamespaceListerExpansion allows custom methods to be added to
+// ResticDaemonSetConfigNamespaceLister.
+type ResticDaemonSetConfigNamespaceListerExpansion interface{}
+
 // ResticRepositoryListerExpansion allows custom methods to be added to
 // ResticRepositoryLister.
 type ResticRepositoryListerExpansion interface{}
@@ -82,6 +122,22 @@ type RestoreListerExpansion interface{}
 // RestoreNamespaceLister.
 type RestoreNamespaceListerExpansion interface{}
 
+// RestorePriorityListerExpansion allows custom methods to be added to
+// RestorePriorityLister.
+type RestorePriorityListerExpansion interface{}
+
+// RestorePriorityNamespaceListerExpansion allows custom methods to be added to
+// RestorePriorityNamespaceLister.
+type RestorePriorityNamespaceListerExpansion interface{}
+
+// RestoreVerificationListerExpansion allows custom methods to be added to
+// RestoreVerificationLister.
+type RestoreVerificationListerExpansion interface{}
+
+// RestoreVerificationNamespaceListerExpansion allows custom methods to be added to
+// RestoreVerificationNamespaceLister.
+type RestoreVerificationNamespaceListerExpansion interface{}
+
 // ScheduleListerExpansion allows custom methods to be added to
 // ScheduleLister.
 type ScheduleListerExpansion interface{}
@@ -90,6 +146,22 @@ type ScheduleListerExpansion interface{}
 // ScheduleNamespaceLister.
 type ScheduleNamespaceListerExpansion interface{}
 
+// UndoRestoreRequestListerExpansion allows custom methods to be added to
+// UndoRestoreRequestLister.
+type UndoRestoreRequestListerExpansion interface{}
+
+// UndoRestoreRequestNamespaceListerExpansion allows custom methods to be added to
+// UndoRestoreRequestNamespaceLister.
+type UndoRestoreRequestNamespaceListerExpansion interface{}
+
+// VolumeSnapshotListerExpansion allows custom methods to be added to
+// VolumeSnapshotLister.
+type VolumeSnapshotListerExpansion interface{}
+
+// VolumeSnapshotNamespaceListerExpansion allows custom methods to be added to
+// VolumeSnapshotNamespaceLister.
+type VolumeSnapshotNamespaceListerExpansion interface{}
+
 // VolumeSnapshotLocationListerExpansion allows custom methods to be added to
 // VolumeSnapshotLocationLister.
 type VolumeSnapshotLocationListerExpansion interface{}
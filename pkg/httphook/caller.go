@@ -0,0 +1,181 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httphook calls HTTP hooks, which notify external systems about the
+// items that Ark backs up and restores.
+package httphook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+
+	// maxErrorBodySize is the most of a failed response's body that's
+	// included in the error.
+	maxErrorBodySize = 1024
+)
+
+// Payload is the JSON body of the request sent by an HTTP hook.
+type Payload struct {
+	// Operation is "backup" or "restore".
+	Operation string `json:"operation"`
+	// Phase is "pre" or "post".
+	Phase string `json:"phase"`
+	// Backup is the name of the backup that's being created or restored.
+	Backup string `json:"backup"`
+	// Restore is the name of the restore, if the item is being restored.
+	Restore string `json:"restore,omitempty"`
+	// Hook is the name of the hook spec that the hook is part of.
+	Hook string `json:"hook"`
+	// Item describes the item that the hook is running for.
+	Item Item `json:"item"`
+}
+
+// Item describes the item that an HTTP hook is running for.
+type Item struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Resource   string            `json:"resource"`
+	Namespace  string            `json:"namespace,omitempty"`
+	Name       string            `json:"name"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// NewItem returns an Item describing obj, an item of groupResource.
+func NewItem(groupResource schema.GroupResource, obj runtime.Unstructured) (Item, error) {
+	metadata, err := meta.Accessor(obj)
+	if err != nil {
+		return Item{}, errors.Wrap(err, "unable to get a metadata accessor")
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+
+	return Item{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Resource:   groupResource.String(),
+		Namespace:  metadata.GetNamespace(),
+		Name:       metadata.GetName(),
+		Labels:     metadata.GetLabels(),
+	}, nil
+}
+
+// Caller is capable of calling HTTP hooks.
+type Caller interface {
+	// Call sends the hook's request, with payload as its body. If the request fails, takes
+	// longer than the hook's timeout, or gets a response whose status isn't 2xx, an error
+	// is returned.
+	Call(log logrus.FieldLogger, hook *api.HTTPHook, payload *Payload) error
+}
+
+type defaultCaller struct {
+	client *http.Client
+}
+
+// NewCaller creates a new Caller.
+func NewCaller() Caller {
+	return &defaultCaller{
+		client: &http.Client{},
+	}
+}
+
+func (c *defaultCaller) Call(log logrus.FieldLogger, hook *api.HTTPHook, payload *Payload) error {
+	if err := Validate(hook); err != nil {
+		return err
+	}
+
+	method := hook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "error encoding hook payload")
+	}
+
+	req, err := http.NewRequest(method, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error creating hook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, val := range hook.Headers {
+		req.Header.Set(key, val)
+	}
+
+	timeout := hook.Timeout.Duration
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	log.WithFields(logrus.Fields{
+		"hookName": payload.Hook,
+		"url":      hook.URL,
+		"method":   method,
+	}).Info("Calling HTTP hook")
+
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "error calling hook %s", payload.Hook)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		data, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+		if msg := strings.TrimSpace(string(data)); msg != "" {
+			return errors.Errorf("hook %s returned %s: %s", payload.Hook, res.Status, msg)
+		}
+		return errors.Errorf("hook %s returned %s", payload.Hook, res.Status)
+	}
+
+	// drain the body so the connection can be reused
+	io.Copy(ioutil.Discard, res.Body)
+
+	return nil
+}
+
+// Validate returns an error if hook's URL isn't an absolute http or https URL.
+func Validate(hook *api.HTTPHook) error {
+	u, err := url.Parse(hook.URL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid hook URL %q", hook.URL)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("invalid hook URL %q, must be an http or https URL", hook.URL)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httphook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+func TestCall(t *testing.T) {
+	payload := &Payload{
+		Operation: "backup",
+		Phase:     "pre",
+		Backup:    "backup-1",
+		Hook:      "hook-1",
+		Item: Item{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Resource:   "configmaps",
+			Namespace:  "ns",
+			Name:       "name",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		headers        map[string]string
+		status         int
+		body           string
+		delay          time.Duration
+		timeout        time.Duration
+		expectedMethod string
+		expectedError  string
+	}{
+		{
+			name:           "method defaults to POST",
+			status:         http.StatusOK,
+			expectedMethod: http.MethodPost,
+		},
+		{
+			name:           "method and headers from the hook are used",
+			method:         http.MethodPut,
+			headers:        map[string]string{"Authorization": "Bearer token"},
+			status:         http.StatusNoContent,
+			expectedMethod: http.MethodPut,
+		},
+		{
+			name:           "non-2xx status is an error that includes the body",
+			status:         http.StatusInternalServerError,
+			body:           "something broke\n",
+			expectedMethod: http.MethodPost,
+			expectedError:  "hook hook-1 returned 500 Internal Server Error: something broke",
+		},
+		{
+			name:           "non-2xx status without a body",
+			status:         http.StatusNotFound,
+			expectedMethod: http.MethodPost,
+			expectedError:  "hook hook-1 returned 404 Not Found",
+		},
+		{
+			name:           "hook times out",
+			status:         http.StatusOK,
+			delay:          time.Second,
+			timeout:        10 * time.Millisecond,
+			expectedMethod: http.MethodPost,
+			expectedError:  "error calling hook hook-1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedMethod, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				for key, val := range test.headers {
+					assert.Equal(t, val, r.Header.Get(key))
+				}
+
+				data, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				var received Payload
+				require.NoError(t, json.Unmarshal(data, &received))
+				assert.Equal(t, *payload, received)
+
+				if test.delay > 0 {
+					select {
+					case <-time.After(test.delay):
+					case <-r.Context().Done():
+					}
+				}
+
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			hook := &api.HTTPHook{
+				URL:     server.URL,
+				Method:  test.method,
+				Headers: test.headers,
+				Timeout: metav1.Duration{Duration: test.timeout},
+			}
+
+			logger := logrus.New()
+			logger.Out = ioutil.Discard
+
+			err := NewCaller().Call(logger, hook, payload)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		url         string
+		expectError bool
+	}{
+		{url: "http://example.com/hook"},
+		{url: "https://example.com:8443"},
+		{url: "", expectError: true},
+		{url: "example.com/hook", expectError: true},
+		{url: "ftp://example.com", expectError: true},
+		{url: "http://", expectError: true},
+		{url: "http://%zz", expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			err := Validate(&api.HTTPHook{URL: test.url})
+			assert.Equal(t, test.expectError, err != nil)
+		})
+	}
+}
+
+func TestNewItem(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"namespace": "ns",
+			"name":      "app",
+			"labels":    map[string]interface{}{"a": "b"},
+		},
+	}}
+
+	item, err := NewItem(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj)
+	require.NoError(t, err)
+	assert.Equal(t, Item{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Resource:   "deployments.apps",
+		Namespace:  "ns",
+		Name:       "app",
+		Labels:     map[string]string{"a": "b"},
+	}, item)
+}
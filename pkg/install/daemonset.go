@@ -53,6 +53,7 @@ func DaemonSet(namespace string, opts ...podTemplateOption) *appsv1.DaemonSet {
 					Labels: map[string]string{
 						"name": "restic",
 					},
+					Annotations: podAnnotations(),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: "ark",
@@ -71,6 +72,7 @@ func DaemonSet(namespace string, opts ...podTemplateOption) *appsv1.DaemonSet {
 							Name:            "restic",
 							Image:           c.image,
 							ImagePullPolicy: pullPolicy,
+							Ports:           containerPorts(),
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "host-pods",
@@ -124,6 +126,34 @@ func DaemonSet(namespace string, opts ...podTemplateOption) *appsv1.DaemonSet {
 		)
 	}
 
+	if c.withHostRootVolume {
+		daemonSet.Spec.Template.Spec.Volumes = append(
+			daemonSet.Spec.Template.Spec.Volumes,
+			corev1.Volume{
+				Name: "host-root",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: "/",
+					},
+				},
+			},
+		)
+		daemonSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(
+			daemonSet.Spec.Template.Spec.Containers[0].VolumeMounts,
+			corev1.VolumeMount{
+				Name:      "host-root",
+				MountPath: "/host_root",
+				ReadOnly:  true,
+			},
+		)
+	}
+
+	if c.sshKeySecret != "" {
+		volume, mount := sshKeyVolume(c.sshKeySecret)
+		daemonSet.Spec.Template.Spec.Volumes = append(daemonSet.Spec.Template.Spec.Volumes, volume)
+		daemonSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(daemonSet.Spec.Template.Spec.Containers[0].VolumeMounts, mount)
+	}
+
 	daemonSet.Spec.Template.Spec.Containers[0].Env = append(daemonSet.Spec.Template.Spec.Containers[0].Env, c.envVars...)
 
 	return daemonSet
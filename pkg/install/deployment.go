@@ -30,6 +30,8 @@ type podTemplateConfig struct {
 	image                    string
 	withoutCredentialsVolume bool
 	envVars                  []corev1.EnvVar
+	sshKeySecret             string
+	withHostRootVolume       bool
 }
 
 func WithImage(image string) podTemplateOption {
@@ -60,6 +62,43 @@ func WithEnvFromSecretKey(varName, secret, key string) podTemplateOption {
 	}
 }
 
+// WithSSHKeySecret mounts the given secret, which should contain an SSH
+// private key and known_hosts file, as the container's ~/.ssh directory so
+// restic can use SFTP repositories.
+func WithSSHKeySecret(secret string) podTemplateOption {
+	return func(c *podTemplateConfig) {
+		c.sshKeySecret = secret
+	}
+}
+
+// WithHostRootVolume mounts the node's root filesystem read-only into the
+// restic daemonset's pods so hostPath volumes can be backed up. It has no
+// effect on the Ark deployment.
+func WithHostRootVolume() podTemplateOption {
+	return func(c *podTemplateConfig) {
+		c.withHostRootVolume = true
+	}
+}
+
+// sshKeyVolume returns the volume and mount for the SSH key secret.
+func sshKeyVolume(secret string) (corev1.Volume, corev1.VolumeMount) {
+	mode := int32(0400)
+
+	return corev1.Volume{
+		Name: "ssh-key",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  secret,
+				DefaultMode: &mode,
+			},
+		},
+	}, corev1.VolumeMount{
+		Name:      "ssh-key",
+		MountPath: "/root/.ssh",
+		ReadOnly:  true,
+	}
+}
+
 func Deployment(namespace string, opts ...podTemplateOption) *appsv1beta1.Deployment {
 	c := &podTemplateConfig{
 		image: "gcr.io/heptio-images/ark:latest",
@@ -152,5 +191,13 @@ func Deployment(namespace string, opts ...podTemplateOption) *appsv1beta1.Deploy
 		)
 	}
 
+	if c.sshKeySecret != "" {
+		volume, mount := sshKeyVolume(c.sshKeySecret)
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, volume)
+		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, mount)
+	}
+
+	deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env, c.envVars...)
+
 	return deployment
 }
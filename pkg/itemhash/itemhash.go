@@ -0,0 +1,160 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package itemhash computes stable content hashes of Kubernetes items. Hashes
+// ignore metadata and fields that the API server or Ark change without the
+// item's content changing, so a backed-up item and the same item in a cluster
+// (or restored into one) hash the same.
+package itemhash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// hashPrefix identifies the algorithm used for hashes computed by this package.
+const hashPrefix = "sha256:"
+
+// Hasher computes content hashes of items.
+type Hasher interface {
+	// Hash returns the content hash of item, which belongs to groupResource (e.g.
+	// "deployments.apps"). item isn't modified.
+	Hash(groupResource string, item map[string]interface{}) (string, error)
+}
+
+// Normalizer removes fields that shouldn't affect an item's hash. It's called
+// with a copy of the item, which it may modify.
+type Normalizer interface {
+	Normalize(item map[string]interface{})
+}
+
+// NormalizerFunc is a function that implements Normalizer.
+type NormalizerFunc func(item map[string]interface{})
+
+// Normalize calls f(item).
+func (f NormalizerFunc) Normalize(item map[string]interface{}) {
+	f(item)
+}
+
+// DefaultHasher is a Hasher that normalizes every item's metadata and status,
+// then applies any normalizers registered for the item's group-resource, and
+// hashes the item's canonical JSON encoding.
+type DefaultHasher struct {
+	normalizers map[string][]Normalizer
+}
+
+// NewDefaultHasher returns a DefaultHasher with normalizers registered for the
+// built-in resources whose fields Ark's restore item actions reset.
+func NewDefaultHasher() *DefaultHasher {
+	h := &DefaultHasher{
+		normalizers: make(map[string][]Normalizer),
+	}
+
+	h.Register("services", NormalizerFunc(normalizeService))
+	h.Register("pods", NormalizerFunc(normalizePod))
+
+	return h
+}
+
+// Register adds a normalizer for items in groupResource. Normalizers run in the
+// order they're registered, after the default metadata and status normalization.
+func (h *DefaultHasher) Register(groupResource string, normalizer Normalizer) {
+	h.normalizers[groupResource] = append(h.normalizers[groupResource], normalizer)
+}
+
+// Hash returns the content hash of item.
+func (h *DefaultHasher) Hash(groupResource string, item map[string]interface{}) (string, error) {
+	normalized := runtime.DeepCopyJSON(item)
+
+	normalizeMetadataAndStatus(normalized)
+	for _, normalizer := range h.normalizers[groupResource] {
+		normalizer.Normalize(normalized)
+	}
+
+	// encoding/json sorts map keys, so the encoding is canonical
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", errors.Wrap(err, "error encoding item")
+	}
+
+	sum := sha256.Sum256(data)
+	return hashPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// normalizeMetadataAndStatus keeps only the metadata fields that restores keep,
+// minus the labels Ark adds to restored items, and removes the item's status.
+func normalizeMetadataAndStatus(item map[string]interface{}) {
+	delete(item, "status")
+
+	metadata, ok := item["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for k := range metadata {
+		switch k {
+		case "name", "namespace", "labels", "annotations":
+		default:
+			delete(metadata, k)
+		}
+	}
+
+	if labels, ok := metadata["labels"].(map[string]interface{}); ok {
+		delete(labels, api.BackupNameLabel)
+		delete(labels, api.RestoreNameLabel)
+		delete(labels, api.RestoreLabelKey)
+	}
+
+	// an empty map and a missing one are the same
+	for _, k := range []string{"labels", "annotations"} {
+		if m, ok := metadata[k].(map[string]interface{}); ok && len(m) == 0 {
+			delete(metadata, k)
+		}
+	}
+}
+
+// normalizeService removes the cluster IP and node ports that are allocated to a
+// service when it's created.
+func normalizeService(item map[string]interface{}) {
+	spec, ok := item["spec"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if clusterIP, _ := spec["clusterIP"].(string); clusterIP != "None" {
+		delete(spec, "clusterIP")
+	}
+
+	ports, _ := spec["ports"].([]interface{})
+	for _, port := range ports {
+		if p, ok := port.(map[string]interface{}); ok {
+			delete(p, "nodePort")
+		}
+	}
+}
+
+// normalizePod removes the node that a pod is scheduled to.
+func normalizePod(item map[string]interface{}) {
+	if spec, ok := item["spec"].(map[string]interface{}); ok {
+		delete(spec, "nodeName")
+	}
+}
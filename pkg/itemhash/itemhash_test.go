@@ -0,0 +1,138 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package itemhash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHasher(t *testing.T) {
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata": map[string]interface{}{
+				"name":      "svc-1",
+				"namespace": "ns-1",
+				"labels":    map[string]interface{}{"app": "foo"},
+			},
+			"spec": map[string]interface{}{
+				"clusterIP": "10.0.0.1",
+				"ports": []interface{}{
+					map[string]interface{}{"port": int64(80), "nodePort": int64(30000)},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		groupResource string
+		modify        func(item map[string]interface{})
+		expectedEqual bool
+	}{
+		{
+			name:          "volatile metadata and status are ignored",
+			groupResource: "services",
+			modify: func(item map[string]interface{}) {
+				metadata := item["metadata"].(map[string]interface{})
+				metadata["uid"] = "abc"
+				metadata["resourceVersion"] = "123"
+				metadata["creationTimestamp"] = "2018-01-01T00:00:00Z"
+				metadata["labels"].(map[string]interface{})["ark.heptio.com/restore-name"] = "restore-1"
+				metadata["labels"].(map[string]interface{})["ark-restore"] = "restore-1"
+				metadata["annotations"] = map[string]interface{}{}
+				item["status"] = map[string]interface{}{"loadBalancer": map[string]interface{}{}}
+			},
+			expectedEqual: true,
+		},
+		{
+			name:          "registered normalizers are applied",
+			groupResource: "services",
+			modify: func(item map[string]interface{}) {
+				spec := item["spec"].(map[string]interface{})
+				spec["clusterIP"] = "10.0.0.2"
+				spec["ports"].([]interface{})[0].(map[string]interface{})["nodePort"] = int64(30001)
+			},
+			expectedEqual: true,
+		},
+		{
+			name:          "normalizers for other resources aren't applied",
+			groupResource: "configmaps",
+			modify: func(item map[string]interface{}) {
+				item["spec"].(map[string]interface{})["clusterIP"] = "10.0.0.2"
+			},
+			expectedEqual: false,
+		},
+		{
+			name:          "labels are compared",
+			groupResource: "services",
+			modify: func(item map[string]interface{}) {
+				item["metadata"].(map[string]interface{})["labels"].(map[string]interface{})["app"] = "bar"
+			},
+			expectedEqual: false,
+		},
+		{
+			name:          "spec is compared",
+			groupResource: "services",
+			modify: func(item map[string]interface{}) {
+				item["spec"].(map[string]interface{})["type"] = "NodePort"
+			},
+			expectedEqual: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := NewDefaultHasher()
+
+			original := base()
+			expected, err := h.Hash(test.groupResource, original)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(expected, hashPrefix))
+
+			// hashing doesn't modify the item
+			assert.Equal(t, base(), original)
+
+			modified := base()
+			test.modify(modified)
+			actual, err := h.Hash(test.groupResource, modified)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedEqual, expected == actual)
+		})
+	}
+}
+
+func TestDefaultHasherRegister(t *testing.T) {
+	h := NewDefaultHasher()
+	h.Register("configmaps", NormalizerFunc(func(item map[string]interface{}) {
+		delete(item, "data")
+	}))
+
+	first, err := h.Hash("configmaps", map[string]interface{}{"data": map[string]interface{}{"a": "b"}})
+	require.NoError(t, err)
+
+	second, err := h.Hash("configmaps", map[string]interface{}{"data": map[string]interface{}{"a": "c"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
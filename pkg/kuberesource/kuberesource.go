@@ -21,12 +21,23 @@ import (
 )
 
 var (
-	ClusterRoleBindings    = schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"}
-	ClusterRoles           = schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"}
-	Jobs                   = schema.GroupResource{Group: "batch", Resource: "jobs"}
-	Namespaces             = schema.GroupResource{Group: "", Resource: "namespaces"}
-	PersistentVolumeClaims = schema.GroupResource{Group: "", Resource: "persistentvolumeclaims"}
-	PersistentVolumes      = schema.GroupResource{Group: "", Resource: "persistentvolumes"}
-	Pods                   = schema.GroupResource{Group: "", Resource: "pods"}
-	ServiceAccounts        = schema.GroupResource{Group: "", Resource: "serviceaccounts"}
+	ClusterRoleBindings       = schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"}
+	ClusterRoles              = schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"}
+	ConfigMaps                = schema.GroupResource{Group: "", Resource: "configmaps"}
+	CustomResourceDefinitions = schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}
+	DeploymentConfigs         = schema.GroupResource{Group: "apps.openshift.io", Resource: "deploymentconfigs"}
+	Deployments               = schema.GroupResource{Group: "apps", Resource: "deployments"}
+	ImageStreams              = schema.GroupResource{Group: "image.openshift.io", Resource: "imagestreams"}
+	Jobs                      = schema.GroupResource{Group: "batch", Resource: "jobs"}
+	Namespaces                = schema.GroupResource{Group: "", Resource: "namespaces"}
+	PersistentVolumeClaims    = schema.GroupResource{Group: "", Resource: "persistentvolumeclaims"}
+	PersistentVolumes         = schema.GroupResource{Group: "", Resource: "persistentvolumes"}
+	Pods                      = schema.GroupResource{Group: "", Resource: "pods"}
+	ReplicationControllers    = schema.GroupResource{Group: "", Resource: "replicationcontrollers"}
+	ResourceQuotas            = schema.GroupResource{Group: "", Resource: "resourcequotas"}
+	RoleBindings              = schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}
+	Routes                    = schema.GroupResource{Group: "route.openshift.io", Resource: "routes"}
+	Secrets                   = schema.GroupResource{Group: "", Resource: "secrets"}
+	ServiceAccounts           = schema.GroupResource{Group: "", Resource: "serviceaccounts"}
+	Services                  = schema.GroupResource{Group: "", Resource: "services"}
 )
@@ -17,6 +17,7 @@ limitations under the License.
 package metrics
 
 import (
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,23 +26,38 @@ import (
 // ServerMetrics contains Prometheus metrics for the Ark server.
 type ServerMetrics struct {
 	metrics map[string]prometheus.Collector
+
+	lock                  sync.Mutex
+	lastSuccessfulBackups map[string]time.Time
 }
 
 const (
 	metricNamespace             = "ark"
 	backupTarballSizeBytesGauge = "backup_tarball_size_bytes"
 	// TODO: Rename the Count variables to match their strings
-	backupAttemptCount           = "backup_attempt_total"
-	backupSuccessCount           = "backup_success_total"
-	backupFailureCount           = "backup_failure_total"
-	backupDurationSeconds        = "backup_duration_seconds"
-	restoreAttemptTotal          = "restore_attempt_total"
-	restoreValidationFailedTotal = "restore_validation_failed_total"
-	restoreSuccessTotal          = "restore_success_total"
-	restoreFailedTotal           = "restore_failed_total"
+	backupAttemptCount            = "backup_attempt_total"
+	backupSuccessCount            = "backup_success_total"
+	backupFailureCount            = "backup_failure_total"
+	backupDurationSeconds         = "backup_duration_seconds"
+	backupLastSuccessTimestamp    = "backup_last_successful_timestamp"
+	restoreAttemptTotal           = "restore_attempt_total"
+	restoreValidationFailedTotal  = "restore_validation_failed_total"
+	restoreSuccessTotal           = "restore_success_total"
+	restoreFailedTotal            = "restore_failed_total"
+	backupsExpiringSoonGauge      = "backups_expiring_soon"
+	scheduleMissedWindowsTotal    = "schedule_missed_windows_total"
+	resticOperationRetryTotal     = "restic_operation_retry_total"
+	nonCompliantNamespacesGauge   = "backup_policy_noncompliant_namespaces"
+	controllerQueueDepthGauge     = "controller_queue_depth"
+	volumeSnapshotDurationSeconds = "volume_snapshot_duration_seconds"
+	volumeSnapshotFailureTotal    = "volume_snapshot_failure_total"
 
 	scheduleLabel   = "schedule"
 	backupNameLabel = "backupName"
+	operationLabel  = "operation"
+	policyLabel     = "policy"
+	controllerLabel = "controller"
+	providerLabel   = "provider"
 
 	secondsInMinute = 60.0
 )
@@ -49,6 +65,7 @@ const (
 // NewServerMetrics returns new ServerMetrics
 func NewServerMetrics() *ServerMetrics {
 	return &ServerMetrics{
+		lastSuccessfulBackups: make(map[string]time.Time),
 		metrics: map[string]prometheus.Collector{
 			backupTarballSizeBytesGauge: prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
@@ -101,6 +118,14 @@ func NewServerMetrics() *ServerMetrics {
 				},
 				[]string{scheduleLabel},
 			),
+			backupLastSuccessTimestamp: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricNamespace,
+					Name:      backupLastSuccessTimestamp,
+					Help:      "Last time a backup completed successfully, as a Unix timestamp in seconds",
+				},
+				[]string{scheduleLabel},
+			),
 			restoreAttemptTotal: prometheus.NewCounterVec(
 				prometheus.CounterOpts{
 					Namespace: metricNamespace,
@@ -133,6 +158,80 @@ func NewServerMetrics() *ServerMetrics {
 				},
 				[]string{scheduleLabel},
 			),
+			backupsExpiringSoonGauge: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricNamespace,
+					Name:      backupsExpiringSoonGauge,
+					Help:      "Number of backups that will expire within the expiry warning period",
+				},
+				[]string{scheduleLabel},
+			),
+			scheduleMissedWindowsTotal: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricNamespace,
+					Name:      scheduleMissedWindowsTotal,
+					Help:      "Total number of times a schedule's backup wasn't run before its following run was due",
+				},
+				[]string{scheduleLabel},
+			),
+			nonCompliantNamespacesGauge: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricNamespace,
+					Name:      nonCompliantNamespacesGauge,
+					Help:      "Number of namespaces selected by a backup policy that aren't covered by a schedule",
+				},
+				[]string{policyLabel},
+			),
+			controllerQueueDepthGauge: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricNamespace,
+					Name:      controllerQueueDepthGauge,
+					Help:      "Number of items waiting in a controller's work queue",
+				},
+				[]string{controllerLabel},
+			),
+			volumeSnapshotDurationSeconds: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: metricNamespace,
+					Name:      volumeSnapshotDurationSeconds,
+					Help:      "Time taken by a volume snapshotter plugin to create a snapshot or a volume from a snapshot, in seconds",
+					Buckets: []float64{
+						toSeconds(1 * time.Second),
+						toSeconds(5 * time.Second),
+						toSeconds(10 * time.Second),
+						toSeconds(30 * time.Second),
+						toSeconds(1 * time.Minute),
+						toSeconds(5 * time.Minute),
+						toSeconds(10 * time.Minute),
+						toSeconds(30 * time.Minute),
+					},
+				},
+				[]string{providerLabel, operationLabel},
+			),
+			volumeSnapshotFailureTotal: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricNamespace,
+					Name:      volumeSnapshotFailureTotal,
+					Help:      "Total number of times a volume snapshotter plugin failed or timed out creating a snapshot or a volume from a snapshot",
+				},
+				[]string{providerLabel, operationLabel},
+			),
+		},
+	}
+}
+
+// NewResticServerMetrics returns new ServerMetrics for the restic server.
+func NewResticServerMetrics() *ServerMetrics {
+	return &ServerMetrics{
+		metrics: map[string]prometheus.Collector{
+			resticOperationRetryTotal: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricNamespace,
+					Name:      resticOperationRetryTotal,
+					Help:      "Total number of times a restic backup or restore of a pod volume was retried after a transient failure",
+				},
+				[]string{operationLabel},
+			),
 		},
 	}
 }
@@ -166,6 +265,9 @@ func (m *ServerMetrics) InitSchedule(scheduleName string) {
 	if c, ok := m.metrics[restoreValidationFailedTotal].(*prometheus.CounterVec); ok {
 		c.WithLabelValues(scheduleName).Set(0)
 	}
+	if c, ok := m.metrics[scheduleMissedWindowsTotal].(*prometheus.CounterVec); ok {
+		c.WithLabelValues(scheduleName).Set(0)
+	}
 }
 
 // SetBackupTarballSizeBytesGauge records the size, in bytes, of a backup tarball.
@@ -175,6 +277,29 @@ func (m *ServerMetrics) SetBackupTarballSizeBytesGauge(backupSchedule string, si
 	}
 }
 
+// SetBackupsExpiringSoonGauge records the number of backups that will expire soon.
+func (m *ServerMetrics) SetBackupsExpiringSoonGauge(backupSchedule string, count int) {
+	if g, ok := m.metrics[backupsExpiringSoonGauge].(*prometheus.GaugeVec); ok {
+		g.WithLabelValues(backupSchedule).Set(float64(count))
+	}
+}
+
+// SetBackupPolicyNonCompliantNamespaces records the number of namespaces selected
+// by a backup policy that aren't covered by a schedule.
+func (m *ServerMetrics) SetBackupPolicyNonCompliantNamespaces(policy string, count int) {
+	if g, ok := m.metrics[nonCompliantNamespacesGauge].(*prometheus.GaugeVec); ok {
+		g.WithLabelValues(policy).Set(float64(count))
+	}
+}
+
+// RegisterScheduleMissedWindow records a schedule whose backup wasn't run
+// before its following run was due.
+func (m *ServerMetrics) RegisterScheduleMissedWindow(scheduleName string) {
+	if c, ok := m.metrics[scheduleMissedWindowsTotal].(*prometheus.CounterVec); ok {
+		c.WithLabelValues(scheduleName).Inc()
+	}
+}
+
 // RegisterBackupAttempt records an backup attempt.
 func (m *ServerMetrics) RegisterBackupAttempt(backupSchedule string) {
 	if c, ok := m.metrics[backupAttemptCount].(*prometheus.CounterVec); ok {
@@ -189,6 +314,23 @@ func (m *ServerMetrics) RegisterBackupSuccess(backupSchedule string) {
 	}
 }
 
+// SetBackupLastSuccessfulTimestamp records the time a backup completed
+// successfully, unless a later backup of the same schedule has already been
+// recorded.
+func (m *ServerMetrics) SetBackupLastSuccessfulTimestamp(backupSchedule string, completed time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if last, ok := m.lastSuccessfulBackups[backupSchedule]; ok && !completed.After(last) {
+		return
+	}
+	m.lastSuccessfulBackups[backupSchedule] = completed
+
+	if g, ok := m.metrics[backupLastSuccessTimestamp].(*prometheus.GaugeVec); ok {
+		g.WithLabelValues(backupSchedule).Set(float64(completed.Unix()))
+	}
+}
+
 // RegisterBackupFailed records a failed backup.
 func (m *ServerMetrics) RegisterBackupFailed(backupSchedule string) {
 	if c, ok := m.metrics[backupFailureCount].(*prometheus.CounterVec); ok {
@@ -236,3 +378,27 @@ func (m *ServerMetrics) RegisterRestoreValidationFailed(backupSchedule string) {
 		c.WithLabelValues(backupSchedule).Inc()
 	}
 }
+
+// RegisterResticOperationRetry records a retry of a restic operation
+// ("backup" or "restore") after a transient failure.
+func (m *ServerMetrics) RegisterResticOperationRetry(operation string) {
+	if c, ok := m.metrics[resticOperationRetryTotal].(*prometheus.CounterVec); ok {
+		c.WithLabelValues(operation).Inc()
+	}
+}
+
+// RegisterVolumeSnapshotOperation records the number of seconds a block store
+// operation ("CreateSnapshot" or "CreateVolumeFromSnapshot") took for a provider.
+func (m *ServerMetrics) RegisterVolumeSnapshotOperation(provider, operation string, seconds float64) {
+	if h, ok := m.metrics[volumeSnapshotDurationSeconds].(*prometheus.HistogramVec); ok {
+		h.WithLabelValues(provider, operation).Observe(seconds)
+	}
+}
+
+// RegisterVolumeSnapshotOperationFailure records a block store operation that
+// failed or timed out for a provider.
+func (m *ServerMetrics) RegisterVolumeSnapshotOperationFailure(provider, operation string) {
+	if c, ok := m.metrics[volumeSnapshotFailureTotal].(*prometheus.CounterVec); ok {
+		c.WithLabelValues(provider, operation).Inc()
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// QueueMetricsProvider returns a workqueue.MetricsProvider that records the
+// depth of each controller's work queue, labeled with the queue's name. It
+// must be passed to workqueue.SetProvider before the controllers are created.
+func (m *ServerMetrics) QueueMetricsProvider() workqueue.MetricsProvider {
+	provider := queueMetricsProvider{}
+	if g, ok := m.metrics[controllerQueueDepthGauge].(*prometheus.GaugeVec); ok {
+		provider.depth = g
+	}
+	return provider
+}
+
+type queueMetricsProvider struct {
+	depth *prometheus.GaugeVec
+}
+
+func (p queueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	if p.depth == nil {
+		return noopMetric{}
+	}
+	return p.depth.WithLabelValues(name)
+}
+
+func (queueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return noopMetric{}
+}
+
+func (queueMetricsProvider) NewLatencyMetric(name string) workqueue.SummaryMetric {
+	return noopMetric{}
+}
+
+func (queueMetricsProvider) NewWorkDurationMetric(name string) workqueue.SummaryMetric {
+	return noopMetric{}
+}
+
+func (queueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return noopMetric{}
+}
+
+// noopMetric implements the workqueue metrics that aren't recorded.
+type noopMetric struct{}
+
+func (noopMetric) Inc()            {}
+func (noopMetric) Dec()            {}
+func (noopMetric) Observe(float64) {}
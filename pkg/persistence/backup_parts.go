@@ -0,0 +1,197 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistence
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/heptio/ark/pkg/cloudprovider"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// backupPartsManifest lists the parts that a backup tarball larger than its
+// location's maximum object size was split into, in order.
+type backupPartsManifest struct {
+	// Size is the size of the whole tarball in bytes.
+	Size int64 `json:"size"`
+
+	Parts []backupPart `json:"parts"`
+}
+
+// backupPart is one part of a split backup tarball.
+type backupPart struct {
+	// Name is the part's name within the backup's directory.
+	Name string `json:"name"`
+
+	// Size is the part's size in bytes.
+	Size int64 `json:"size"`
+}
+
+// putBackupContents uploads a backup tarball, splitting it into parts if it's larger
+// than the store's maximum object size, and returns the keys of the objects it
+// uploaded. Tarballs are only split if contents is seekable, so that their size is
+// known before they're uploaded.
+func (s *objectBackupStore) putBackupContents(name string, contents io.Reader) ([]string, error) {
+	size, ok := readerSize(contents)
+	if s.maxObjectSize <= 0 || !ok || size <= s.maxObjectSize {
+		key := s.layout.getBackupContentsKey(name)
+		if err := seekAndPutObject(s.objectStore, s.bucket, key, contents, s.objectOptions[arkv1api.DownloadTargetKindBackupContents]); err != nil {
+			return nil, err
+		}
+		return []string{key}, nil
+	}
+
+	if err := seekToBeginning(contents); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var (
+		manifest = backupPartsManifest{Size: size}
+		keys     []string
+	)
+	for part, remaining := 0, size; remaining > 0; part++ {
+		partSize := s.maxObjectSize
+		if remaining < partSize {
+			partSize = remaining
+		}
+
+		key := s.layout.getBackupContentsPartKey(name, part)
+		if err := putObject(s.objectStore, s.bucket, key, io.LimitReader(contents, partSize), s.objectOptions[arkv1api.DownloadTargetKindBackupContents]); err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+
+		manifest.Parts = append(manifest.Parts, backupPart{Name: path.Base(key), Size: partSize})
+		remaining -= partSize
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return keys, errors.WithStack(err)
+	}
+
+	key := s.layout.getBackupContentsManifestKey(name)
+	if err := s.objectStore.PutObject(s.bucket, key, bytes.NewReader(manifestBytes)); err != nil {
+		return keys, err
+	}
+
+	return append(keys, key), nil
+}
+
+// getBackupPartsManifest returns the manifest of the parts of a backup's tarball,
+// or nil if the tarball wasn't split.
+func (s *objectBackupStore) getBackupPartsManifest(name string) (*backupPartsManifest, error) {
+	key := s.layout.getBackupContentsManifestKey(name)
+
+	exists, err := keyExists(s.objectStore, s.bucket, s.layout.getBackupDir(name), key)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	res, err := s.objectStore.GetObject(s.bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	manifest := new(backupPartsManifest)
+	if err := json.NewDecoder(res).Decode(manifest); err != nil {
+		return nil, errors.Wrapf(err, "error decoding %s", key)
+	}
+
+	return manifest, nil
+}
+
+// readerSize returns the number of bytes in r, if it's seekable.
+func readerSize(r io.Reader) (int64, bool) {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return 0, false
+	}
+
+	size, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, false
+	}
+
+	return size, true
+}
+
+// partsReader reads the parts of a split backup tarball in order, as if they
+// were a single object. Each part is downloaded when the previous one has been
+// read.
+type partsReader struct {
+	objectStore cloudprovider.ObjectStore
+	bucket      string
+	dir         string
+	parts       []backupPart
+
+	current     io.ReadCloser
+	currentPart backupPart
+	currentRead int64
+}
+
+func (r *partsReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.parts) == 0 {
+				return 0, io.EOF
+			}
+
+			r.currentPart, r.parts = r.parts[0], r.parts[1:]
+			r.currentRead = 0
+
+			current, err := r.objectStore.GetObject(r.bucket, path.Join(r.dir, r.currentPart.Name))
+			if err != nil {
+				return 0, err
+			}
+			r.current = current
+		}
+
+		n, err := r.current.Read(p)
+		r.currentRead += int64(n)
+
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+
+			if r.currentRead != r.currentPart.Size {
+				return n, errors.Errorf("backup part %s is %d bytes, expected %d", r.currentPart.Name, r.currentRead, r.currentPart.Size)
+			}
+
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+
+		return n, err
+	}
+}
+
+func (r *partsReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.Close()
+}
@@ -130,6 +130,29 @@ func (_m *BackupStore) GetDownloadURL(target v1.DownloadTarget) (string, error)
 	return r0, r1
 }
 
+// GetRestoreManifest provides a mock function with given fields: restore
+func (_m *BackupStore) GetRestoreManifest(restore string) (io.ReadCloser, error) {
+	ret := _m.Called(restore)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(string) io.ReadCloser); ok {
+		r0 = rf(restore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(restore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsValid provides a mock function with given fields:
 func (_m *BackupStore) IsValid() error {
 	ret := _m.Called()
@@ -188,13 +211,55 @@ func (_m *BackupStore) ListBackups() ([]string, error) {
 	return r0, r1
 }
 
-// PutBackup provides a mock function with given fields: name, metadata, contents, log, volumeSnapshots
-func (_m *BackupStore) PutBackup(name string, metadata io.Reader, contents io.Reader, log io.Reader, volumeSnapshots io.Reader) error {
-	ret := _m.Called(name, metadata, contents, log, volumeSnapshots)
+// PutBackup provides a mock function with given fields: name, metadata, contents, log, volumeSnapshots, podVolumeSnapshots
+func (_m *BackupStore) PutBackup(name string, metadata io.Reader, contents io.Reader, log io.Reader, volumeSnapshots io.Reader, podVolumeSnapshots io.Reader) error {
+	ret := _m.Called(name, metadata, contents, log, volumeSnapshots, podVolumeSnapshots)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, io.Reader, io.Reader, io.Reader, io.Reader, io.Reader) error); ok {
+		r0 = rf(name, metadata, contents, log, volumeSnapshots, podVolumeSnapshots)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutBackupLog provides a mock function with given fields: name, log
+func (_m *BackupStore) PutBackupLog(name string, log io.Reader) error {
+	ret := _m.Called(name, log)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, io.Reader, io.Reader, io.Reader, io.Reader) error); ok {
-		r0 = rf(name, metadata, contents, log, volumeSnapshots)
+	if rf, ok := ret.Get(0).(func(string, io.Reader) error); ok {
+		r0 = rf(name, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutBackupIndex provides a mock function with given fields: name, index
+func (_m *BackupStore) PutBackupIndex(name string, index io.Reader) error {
+	ret := _m.Called(name, index)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, io.Reader) error); ok {
+		r0 = rf(name, index)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutBackupStatusDetails provides a mock function with given fields: name, details
+func (_m *BackupStore) PutBackupStatusDetails(name string, details io.Reader) error {
+	ret := _m.Called(name, details)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, io.Reader) error); ok {
+		r0 = rf(name, details)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -216,6 +281,20 @@ func (_m *BackupStore) PutRestoreLog(backup string, restore string, log io.Reade
 	return r0
 }
 
+// PutRestoreManifest provides a mock function with given fields: restore, manifest
+func (_m *BackupStore) PutRestoreManifest(restore string, manifest io.Reader) error {
+	ret := _m.Called(restore, manifest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, io.Reader) error); ok {
+		r0 = rf(restore, manifest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PutRestoreResults provides a mock function with given fields: backup, restore, results
 func (_m *BackupStore) PutRestoreResults(backup string, restore string, results io.Reader) error {
 	ret := _m.Called(backup, restore, results)
@@ -21,12 +21,15 @@ import (
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/satori/uuid"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
@@ -43,7 +46,10 @@ type BackupStore interface {
 
 	ListBackups() ([]string, error)
 
-	PutBackup(name string, metadata, contents, log, volumeSnapshots io.Reader) error
+	PutBackup(name string, metadata, contents, log, volumeSnapshots, podVolumeSnapshots io.Reader) error
+	PutBackupLog(name string, log io.Reader) error
+	PutBackupIndex(name string, index io.Reader) error
+	PutBackupStatusDetails(name string, details io.Reader) error
 	GetBackupMetadata(name string) (*arkv1api.Backup, error)
 	GetBackupVolumeSnapshots(name string) ([]*volume.Snapshot, error)
 	GetBackupContents(name string) (io.ReadCloser, error)
@@ -51,19 +57,36 @@ type BackupStore interface {
 
 	PutRestoreLog(backup, restore string, log io.Reader) error
 	PutRestoreResults(backup, restore string, results io.Reader) error
+	PutRestoreManifest(restore string, manifest io.Reader) error
+	GetRestoreManifest(restore string) (io.ReadCloser, error)
 	DeleteRestore(name string) error
 
 	GetDownloadURL(target arkv1api.DownloadTarget) (string, error)
 }
 
-// DownloadURLTTL is how long a download URL is valid for.
+// DownloadURLTTL is how long a download URL is valid for, unless the backup storage
+// location sets a different TTL.
 const DownloadURLTTL = 10 * time.Minute
 
+// SignedURLTTL returns how long the download URLs for files in a backup storage
+// location are valid for.
+func SignedURLTTL(location *arkv1api.BackupStorageLocation) time.Duration {
+	if location.Spec.SignedURLTTL == nil {
+		return DownloadURLTTL
+	}
+	return location.Spec.SignedURLTTL.Duration
+}
+
 type objectBackupStore struct {
-	objectStore cloudprovider.ObjectStore
-	bucket      string
-	layout      *ObjectStoreLayout
-	logger      logrus.FieldLogger
+	objectStore   cloudprovider.ObjectStore
+	bucket        string
+	layout        *ObjectStoreLayout
+	logger        logrus.FieldLogger
+	maxObjectSize int64
+
+	signedURLTTL    time.Duration
+	maxDownloadSize int64
+	objectOptions   map[arkv1api.DownloadTargetKind]map[string]string
 }
 
 // ObjectStoreGetter is a type that can get a cloudprovider.ObjectStore
@@ -81,6 +104,31 @@ func NewObjectBackupStore(location *arkv1api.BackupStorageLocation, objectStoreG
 		return nil, errors.New("object storage provider name must not be empty")
 	}
 
+	var maxObjectSize int64
+	if location.Spec.MaxObjectSize != nil {
+		if maxObjectSize = location.Spec.MaxObjectSize.Value(); maxObjectSize <= 0 {
+			return nil, errors.Errorf("max object size %s must be positive", location.Spec.MaxObjectSize.String())
+		}
+	}
+
+	signedURLTTL := SignedURLTTL(location)
+	if signedURLTTL <= 0 {
+		return nil, errors.Errorf("signed URL TTL %s must be positive", signedURLTTL)
+	}
+
+	var maxDownloadSize int64
+	if location.Spec.MaxDownloadSize != nil {
+		if maxDownloadSize = location.Spec.MaxDownloadSize.Value(); maxDownloadSize <= 0 {
+			return nil, errors.Errorf("max download size %s must be positive", location.Spec.MaxDownloadSize.String())
+		}
+	}
+
+	for kind := range location.Spec.ObjectOptions {
+		if !isUploadedKind(kind) {
+			return nil, errors.Errorf("object options can't be set for files of kind %q", kind)
+		}
+	}
+
 	objectStore, err := objectStoreGetter.GetObjectStore(location.Spec.Provider)
 	if err != nil {
 		return nil, err
@@ -106,10 +154,15 @@ func NewObjectBackupStore(location *arkv1api.BackupStorageLocation, objectStoreG
 	}))
 
 	return &objectBackupStore{
-		objectStore: objectStore,
-		bucket:      location.Spec.ObjectStorage.Bucket,
-		layout:      NewObjectStoreLayout(location.Spec.ObjectStorage.Prefix),
-		logger:      log,
+		objectStore:   objectStore,
+		bucket:        location.Spec.ObjectStorage.Bucket,
+		layout:        NewObjectStoreLayout(location.Spec.ObjectStorage.Prefix),
+		logger:        log,
+		maxObjectSize: maxObjectSize,
+
+		signedURLTTL:    signedURLTTL,
+		maxDownloadSize: maxDownloadSize,
+		objectOptions:   location.Spec.ObjectOptions,
 	}, nil
 }
 
@@ -162,8 +215,8 @@ func (s *objectBackupStore) ListBackups() ([]string, error) {
 	return output, nil
 }
 
-func (s *objectBackupStore) PutBackup(name string, metadata, contents, log, volumeSnapshots io.Reader) error {
-	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupLogKey(name), log); err != nil {
+func (s *objectBackupStore) PutBackup(name string, metadata, contents, log, volumeSnapshots, podVolumeSnapshots io.Reader) error {
+	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupLogKey(name), log, s.objectOptions[arkv1api.DownloadTargetKindBackupLog]); err != nil {
 		// Uploading the log file is best-effort; if it fails, we log the error but it doesn't impact the
 		// backup's status.
 		s.logger.WithError(err).WithField("backup", name).Error("Error uploading log file")
@@ -176,28 +229,43 @@ func (s *objectBackupStore) PutBackup(name string, metadata, contents, log, volu
 		return nil
 	}
 
-	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupMetadataKey(name), metadata); err != nil {
+	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupMetadataKey(name), metadata, nil); err != nil {
 		// failure to upload metadata file is a hard-stop
 		return err
 	}
 
-	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupContentsKey(name), contents); err != nil {
+	contentsKeys, err := s.putBackupContents(name, contents)
+	if err != nil {
+		errs := []error{err}
+		for _, key := range contentsKeys {
+			errs = append(errs, s.objectStore.DeleteObject(s.bucket, key))
+		}
+
 		deleteErr := s.objectStore.DeleteObject(s.bucket, s.layout.getBackupMetadataKey(name))
-		return kerrors.NewAggregate([]error{err, deleteErr})
+		errs = append(errs, deleteErr)
+
+		return kerrors.NewAggregate(errs)
 	}
 
-	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupVolumeSnapshotsKey(name), volumeSnapshots); err != nil {
+	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupVolumeSnapshotsKey(name), volumeSnapshots, s.objectOptions[arkv1api.DownloadTargetKindBackupVolumeSnapshots]); err != nil {
 		errs := []error{err}
 
-		deleteErr := s.objectStore.DeleteObject(s.bucket, s.layout.getBackupContentsKey(name))
-		errs = append(errs, deleteErr)
+		for _, key := range contentsKeys {
+			errs = append(errs, s.objectStore.DeleteObject(s.bucket, key))
+		}
 
-		deleteErr = s.objectStore.DeleteObject(s.bucket, s.layout.getBackupMetadataKey(name))
+		deleteErr := s.objectStore.DeleteObject(s.bucket, s.layout.getBackupMetadataKey(name))
 		errs = append(errs, deleteErr)
 
 		return kerrors.NewAggregate(errs)
 	}
 
+	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getBackupPodVolumeSnapshotsKey(name), podVolumeSnapshots, s.objectOptions[arkv1api.DownloadTargetKindBackupPodVolumeSnapshots]); err != nil {
+		// The pod volume snapshots file is only used to describe the backup, so uploading it is
+		// best-effort; if it fails, we log the error but it doesn't impact the backup's status.
+		s.logger.WithError(err).WithField("backup", name).Error("Error uploading pod volume snapshots file")
+	}
+
 	if err := s.putRevision(); err != nil {
 		s.logger.WithField("backup", name).WithError(err).Warn("Error updating backup store revision")
 	}
@@ -205,6 +273,24 @@ func (s *objectBackupStore) PutBackup(name string, metadata, contents, log, volu
 	return nil
 }
 
+// PutBackupLog uploads the log of a backup that's in progress, replacing any log
+// that was uploaded for it before. PutBackup uploads the backup's complete log.
+func (s *objectBackupStore) PutBackupLog(name string, log io.Reader) error {
+	return putObject(s.objectStore, s.bucket, s.layout.getBackupLogKey(name), log, s.objectOptions[arkv1api.DownloadTargetKindBackupLog])
+}
+
+// PutBackupIndex uploads the gzip-compressed index of a backup's tarball, so that
+// it can be downloaded without downloading the tarball.
+func (s *objectBackupStore) PutBackupIndex(name string, index io.Reader) error {
+	return putObject(s.objectStore, s.bucket, s.layout.getBackupIndexKey(name), index, s.objectOptions[arkv1api.DownloadTargetKindBackupIndex])
+}
+
+// PutBackupStatusDetails uploads the gzip-compressed, complete versions of the
+// lists that were truncated in a backup's status.
+func (s *objectBackupStore) PutBackupStatusDetails(name string, details io.Reader) error {
+	return putObject(s.objectStore, s.bucket, s.layout.getBackupStatusDetailsKey(name), details, s.objectOptions[arkv1api.DownloadTargetKindBackupStatusDetails])
+}
+
 func (s *objectBackupStore) GetBackupMetadata(name string) (*arkv1api.Backup, error) {
 	key := s.layout.getBackupMetadataKey(name)
 
@@ -284,35 +370,68 @@ func (s *objectBackupStore) GetBackupVolumeSnapshots(name string) ([]*volume.Sna
 	return volumeSnapshots, nil
 }
 
+// GetBackupContents returns a reader for the backup's tarball. If the tarball was
+// split into parts, the parts are read in order as if they were a single object.
 func (s *objectBackupStore) GetBackupContents(name string) (io.ReadCloser, error) {
-	return s.objectStore.GetObject(s.bucket, s.layout.getBackupContentsKey(name))
+	manifest, err := s.getBackupPartsManifest(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest == nil {
+		return s.objectStore.GetObject(s.bucket, s.layout.getBackupContentsKey(name))
+	}
+
+	return &partsReader{
+		objectStore: s.objectStore,
+		bucket:      s.bucket,
+		dir:         s.layout.getBackupDir(name),
+		parts:       manifest.Parts,
+	}, nil
 }
 
 func (s *objectBackupStore) DeleteBackup(name string) error {
-	objects, err := s.objectStore.ListObjects(s.bucket, s.layout.getBackupDir(name))
-	if err != nil {
-		return err
+	prefix := s.layout.getBackupDir(name)
+
+	err := s.deleteObjectsWithPrefix(prefix)
+
+	if err := s.putRevision(); err != nil {
+		s.logger.WithField("backup", name).WithError(err).Warn("Error updating backup store revision")
 	}
 
+	return errors.WithStack(err)
+}
+
+func (s *objectBackupStore) DeleteRestore(name string) error {
+	prefix := s.layout.getRestoreDir(name)
+
 	var errs []error
-	for _, key := range objects {
-		s.logger.WithFields(logrus.Fields{
-			"key": key,
-		}).Debug("Trying to delete object")
-		if err := s.objectStore.DeleteObject(s.bucket, key); err != nil {
-			errs = append(errs, err)
-		}
+	if err := s.deleteObjectsWithPrefix(prefix); err != nil {
+		errs = append(errs, err)
 	}
 
 	if err := s.putRevision(); err != nil {
-		s.logger.WithField("backup", name).WithError(err).Warn("Error updating backup store revision")
+		errs = append(errs, err)
 	}
 
 	return errors.WithStack(kerrors.NewAggregate(errs))
 }
 
-func (s *objectBackupStore) DeleteRestore(name string) error {
-	objects, err := s.objectStore.ListObjects(s.bucket, s.layout.getRestoreDir(name))
+// deleteObjectsWithPrefix deletes the objects whose keys start with prefix. Object store
+// plugins built before DeleteObjectsWithPrefix was added to the ObjectStore interface
+// don't implement it, so their objects are listed and deleted one at a time instead,
+// deleting as many as possible if some deletes fail.
+func (s *objectBackupStore) deleteObjectsWithPrefix(prefix string) error {
+	s.logger.WithField("prefix", prefix).Debug("Trying to delete objects")
+
+	err := s.objectStore.DeleteObjectsWithPrefix(s.bucket, prefix)
+	if st, ok := status.FromError(errors.Cause(err)); !ok || st.Code() != codes.Unimplemented {
+		return err
+	}
+
+	s.logger.WithField("prefix", prefix).Debug("Object store doesn't implement DeleteObjectsWithPrefix, deleting objects one at a time")
+
+	objects, err := s.objectStore.ListObjects(s.bucket, prefix)
 	if err != nil {
 		return err
 	}
@@ -327,36 +446,115 @@ func (s *objectBackupStore) DeleteRestore(name string) error {
 		}
 	}
 
-	if err = s.putRevision(); err != nil {
-		errs = append(errs, err)
-	}
-
-	return errors.WithStack(kerrors.NewAggregate(errs))
+	return kerrors.NewAggregate(errs)
 }
 
 func (s *objectBackupStore) PutRestoreLog(backup string, restore string, log io.Reader) error {
-	return s.objectStore.PutObject(s.bucket, s.layout.getRestoreLogKey(restore), log)
+	return putObject(s.objectStore, s.bucket, s.layout.getRestoreLogKey(restore), log, s.objectOptions[arkv1api.DownloadTargetKindRestoreLog])
 }
 
 func (s *objectBackupStore) PutRestoreResults(backup string, restore string, results io.Reader) error {
-	return s.objectStore.PutObject(s.bucket, s.layout.getRestoreResultsKey(restore), results)
+	return putObject(s.objectStore, s.bucket, s.layout.getRestoreResultsKey(restore), results, s.objectOptions[arkv1api.DownloadTargetKindRestoreResults])
+}
+
+func (s *objectBackupStore) PutRestoreManifest(restore string, manifest io.Reader) error {
+	return putObject(s.objectStore, s.bucket, s.layout.getRestoreManifestKey(restore), manifest, s.objectOptions[arkv1api.DownloadTargetKindRestoreManifest])
+}
+
+// GetRestoreManifest returns a reader for the restore's gzipped manifest of created
+// objects, or nil if the restore doesn't have one because it was run by an older
+// version of Ark.
+func (s *objectBackupStore) GetRestoreManifest(restore string) (io.ReadCloser, error) {
+	key := s.layout.getRestoreManifestKey(restore)
+
+	ok, err := keyExists(s.objectStore, s.bucket, s.layout.getRestoreDir(restore), key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return s.objectStore.GetObject(s.bucket, key)
 }
 
 func (s *objectBackupStore) GetDownloadURL(target arkv1api.DownloadTarget) (string, error) {
+	var key string
+
 	switch target.Kind {
 	case arkv1api.DownloadTargetKindBackupContents:
-		return s.objectStore.CreateSignedURL(s.bucket, s.layout.getBackupContentsKey(target.Name), DownloadURLTTL)
+		manifest, err := s.getBackupPartsManifest(target.Name)
+		if err != nil {
+			return "", err
+		}
+		if manifest != nil {
+			return "", errors.Errorf("backup %s is stored in %d parts, which can't be downloaded using a single URL", target.Name, len(manifest.Parts))
+		}
+
+		key = s.layout.getBackupContentsKey(target.Name)
 	case arkv1api.DownloadTargetKindBackupLog:
-		return s.objectStore.CreateSignedURL(s.bucket, s.layout.getBackupLogKey(target.Name), DownloadURLTTL)
+		key = s.layout.getBackupLogKey(target.Name)
 	case arkv1api.DownloadTargetKindBackupVolumeSnapshots:
-		return s.objectStore.CreateSignedURL(s.bucket, s.layout.getBackupVolumeSnapshotsKey(target.Name), DownloadURLTTL)
+		key = s.layout.getBackupVolumeSnapshotsKey(target.Name)
+	case arkv1api.DownloadTargetKindBackupPodVolumeSnapshots:
+		key = s.layout.getBackupPodVolumeSnapshotsKey(target.Name)
+	case arkv1api.DownloadTargetKindBackupIndex:
+		key = s.layout.getBackupIndexKey(target.Name)
+	case arkv1api.DownloadTargetKindBackupStatusDetails:
+		key = s.layout.getBackupStatusDetailsKey(target.Name)
 	case arkv1api.DownloadTargetKindRestoreLog:
-		return s.objectStore.CreateSignedURL(s.bucket, s.layout.getRestoreLogKey(target.Name), DownloadURLTTL)
+		key = s.layout.getRestoreLogKey(target.Name)
 	case arkv1api.DownloadTargetKindRestoreResults:
-		return s.objectStore.CreateSignedURL(s.bucket, s.layout.getRestoreResultsKey(target.Name), DownloadURLTTL)
+		key = s.layout.getRestoreResultsKey(target.Name)
+	case arkv1api.DownloadTargetKindRestoreManifest:
+		key = s.layout.getRestoreManifestKey(target.Name)
 	default:
 		return "", errors.Errorf("unsupported download target kind %q", target.Kind)
 	}
+
+	if err := s.checkDownloadSize(target, key); err != nil {
+		return "", err
+	}
+
+	return s.objectStore.CreateSignedURL(s.bucket, key, s.signedURLTTL)
+}
+
+// checkDownloadSize returns an error if the store has a maximum download size and
+// the file at key is larger than it. Object stores can't get the size of an object,
+// so the sizes of backup tarballs are read from their backups' metadata, and other
+// files, which are usually small, are read up to the maximum size.
+func (s *objectBackupStore) checkDownloadSize(target arkv1api.DownloadTarget, key string) error {
+	if s.maxDownloadSize <= 0 {
+		return nil
+	}
+
+	var size int64
+	if target.Kind == arkv1api.DownloadTargetKindBackupContents {
+		backup, err := s.GetBackupMetadata(target.Name)
+		if err != nil {
+			return err
+		}
+		size = backup.Status.TarballSizeBytes
+	}
+
+	// backups created by older versions of Ark don't record their tarballs' size
+	if size == 0 {
+		rc, err := s.objectStore.GetObject(s.bucket, key)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+
+		if size, err = io.Copy(ioutil.Discard, io.LimitReader(rc, s.maxDownloadSize+1)); err != nil {
+			return errors.Wrapf(err, "error reading %s", key)
+		}
+	}
+
+	if size > s.maxDownloadSize {
+		return errors.Errorf("%s is larger than the maximum download size of %d bytes", path.Base(key), s.maxDownloadSize)
+	}
+
+	return nil
 }
 
 func (s *objectBackupStore) GetRevision() (string, error) {
@@ -376,7 +574,7 @@ func (s *objectBackupStore) GetRevision() (string, error) {
 func (s *objectBackupStore) putRevision() error {
 	rdr := strings.NewReader(uuid.NewV4().String())
 
-	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getRevisionKey(), rdr); err != nil {
+	if err := seekAndPutObject(s.objectStore, s.bucket, s.layout.getRevisionKey(), rdr, nil); err != nil {
 		return errors.Wrap(err, "error updating revision file")
 	}
 
@@ -393,7 +591,7 @@ func seekToBeginning(r io.Reader) error {
 	return err
 }
 
-func seekAndPutObject(objectStore cloudprovider.ObjectStore, bucket, key string, file io.Reader) error {
+func seekAndPutObject(objectStore cloudprovider.ObjectStore, bucket, key string, file io.Reader, options map[string]string) error {
 	if file == nil {
 		return nil
 	}
@@ -402,5 +600,38 @@ func seekAndPutObject(objectStore cloudprovider.ObjectStore, bucket, key string,
 		return errors.WithStack(err)
 	}
 
-	return objectStore.PutObject(bucket, key, file)
+	return putObject(objectStore, bucket, key, file, options)
+}
+
+// putObject uploads body to key, setting options on the object if there are any.
+func putObject(objectStore cloudprovider.ObjectStore, bucket, key string, body io.Reader, options map[string]string) error {
+	if len(options) == 0 {
+		return objectStore.PutObject(bucket, key, body)
+	}
+
+	putter, ok := objectStore.(cloudprovider.ObjectOptionsPutter)
+	if !ok {
+		return errors.New("object store doesn't support object options")
+	}
+
+	return putter.PutObjectWithOptions(bucket, key, body, options)
+}
+
+// isUploadedKind returns true if files of the given kind are uploaded to
+// backup storage locations.
+func isUploadedKind(kind arkv1api.DownloadTargetKind) bool {
+	switch kind {
+	case arkv1api.DownloadTargetKindBackupContents,
+		arkv1api.DownloadTargetKindBackupLog,
+		arkv1api.DownloadTargetKindBackupVolumeSnapshots,
+		arkv1api.DownloadTargetKindBackupPodVolumeSnapshots,
+		arkv1api.DownloadTargetKindBackupIndex,
+		arkv1api.DownloadTargetKindBackupStatusDetails,
+		arkv1api.DownloadTargetKindRestoreLog,
+		arkv1api.DownloadTargetKindRestoreResults,
+		arkv1api.DownloadTargetKindRestoreManifest:
+		return true
+	default:
+		return false
+	}
 }
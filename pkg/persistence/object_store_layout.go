@@ -39,6 +39,7 @@ func NewObjectStoreLayout(prefix string) *ObjectStoreLayout {
 		"restores": path.Join(prefix, "restores") + "/",
 		"restic":   path.Join(prefix, "restic") + "/",
 		"metadata": path.Join(prefix, "metadata") + "/",
+		"tenants":  path.Join(prefix, "tenants") + "/",
 	}
 
 	return &ObjectStoreLayout{
@@ -54,6 +55,13 @@ func (l *ObjectStoreLayout) GetResticDir() string {
 	return l.subdirs["restic"]
 }
 
+// GetTenantDir returns the full prefix representing the directory
+// within an object storage bucket containing the backup store for
+// the tenant with the given prefix.
+func (l *ObjectStoreLayout) GetTenantDir(tenantPrefix string) string {
+	return path.Join(l.subdirs["tenants"], tenantPrefix) + "/"
+}
+
 func (l *ObjectStoreLayout) isValidSubdir(name string) bool {
 	_, ok := l.subdirs[name]
 	return ok
@@ -79,14 +87,34 @@ func (l *ObjectStoreLayout) getBackupContentsKey(backup string) string {
 	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s.tar.gz", backup))
 }
 
+func (l *ObjectStoreLayout) getBackupContentsPartKey(backup string, part int) string {
+	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s.tar.gz.%03d", backup, part))
+}
+
+func (l *ObjectStoreLayout) getBackupContentsManifestKey(backup string) string {
+	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s-parts.json", backup))
+}
+
 func (l *ObjectStoreLayout) getBackupLogKey(backup string) string {
 	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s-logs.gz", backup))
 }
 
+func (l *ObjectStoreLayout) getBackupIndexKey(backup string) string {
+	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s-index.json.gz", backup))
+}
+
+func (l *ObjectStoreLayout) getBackupStatusDetailsKey(backup string) string {
+	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s-status.json.gz", backup))
+}
+
 func (l *ObjectStoreLayout) getBackupVolumeSnapshotsKey(backup string) string {
 	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s-volumesnapshots.json.gz", backup))
 }
 
+func (l *ObjectStoreLayout) getBackupPodVolumeSnapshotsKey(backup string) string {
+	return path.Join(l.subdirs["backups"], backup, fmt.Sprintf("%s-podvolumesnapshots.json.gz", backup))
+}
+
 func (l *ObjectStoreLayout) getRestoreLogKey(restore string) string {
 	return path.Join(l.subdirs["restores"], restore, fmt.Sprintf("restore-%s-logs.gz", restore))
 }
@@ -94,3 +122,7 @@ func (l *ObjectStoreLayout) getRestoreLogKey(restore string) string {
 func (l *ObjectStoreLayout) getRestoreResultsKey(restore string) string {
 	return path.Join(l.subdirs["restores"], restore, fmt.Sprintf("restore-%s-results.gz", restore))
 }
+
+func (l *ObjectStoreLayout) getRestoreManifestKey(restore string) string {
+	return path.Join(l.subdirs["restores"], restore, fmt.Sprintf("restore-%s-manifest.json.gz", restore))
+}
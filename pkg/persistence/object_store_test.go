@@ -27,10 +27,13 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -55,10 +58,11 @@ func newObjectBackupStoreTestHarness(bucket, prefix string) *objectBackupStoreTe
 
 	return &objectBackupStoreTestHarness{
 		objectBackupStore: &objectBackupStore{
-			objectStore: objectStore,
-			bucket:      bucket,
-			layout:      NewObjectStoreLayout(prefix),
-			logger:      arktest.NewLogger(),
+			objectStore:  objectStore,
+			bucket:       bucket,
+			layout:       NewObjectStoreLayout(prefix),
+			logger:       arktest.NewLogger(),
+			signedURLTTL: DownloadURLTTL,
 		},
 		objectStore: objectStore,
 		bucket:      bucket,
@@ -214,37 +218,43 @@ func TestPutBackup(t *testing.T) {
 		contents     io.Reader
 		log          io.Reader
 		snapshots    io.Reader
+		podSnapshots io.Reader
+		maxSize      int64
 		expectedErr  string
 		expectedKeys []string
 	}{
 		{
-			name:        "normal case",
-			metadata:    newStringReadSeeker("metadata"),
-			contents:    newStringReadSeeker("contents"),
-			log:         newStringReadSeeker("log"),
-			snapshots:   newStringReadSeeker("snapshots"),
-			expectedErr: "",
+			name:         "normal case",
+			metadata:     newStringReadSeeker("metadata"),
+			contents:     newStringReadSeeker("contents"),
+			log:          newStringReadSeeker("log"),
+			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
+			expectedErr:  "",
 			expectedKeys: []string{
 				"backups/backup-1/ark-backup.json",
 				"backups/backup-1/backup-1.tar.gz",
 				"backups/backup-1/backup-1-logs.gz",
 				"backups/backup-1/backup-1-volumesnapshots.json.gz",
+				"backups/backup-1/backup-1-podvolumesnapshots.json.gz",
 				"metadata/revision",
 			},
 		},
 		{
-			name:        "normal case with backup store prefix",
-			prefix:      "prefix-1/",
-			metadata:    newStringReadSeeker("metadata"),
-			contents:    newStringReadSeeker("contents"),
-			log:         newStringReadSeeker("log"),
-			snapshots:   newStringReadSeeker("snapshots"),
-			expectedErr: "",
+			name:         "normal case with backup store prefix",
+			prefix:       "prefix-1/",
+			metadata:     newStringReadSeeker("metadata"),
+			contents:     newStringReadSeeker("contents"),
+			log:          newStringReadSeeker("log"),
+			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
+			expectedErr:  "",
 			expectedKeys: []string{
 				"prefix-1/backups/backup-1/ark-backup.json",
 				"prefix-1/backups/backup-1/backup-1.tar.gz",
 				"prefix-1/backups/backup-1/backup-1-logs.gz",
 				"prefix-1/backups/backup-1/backup-1-volumesnapshots.json.gz",
+				"prefix-1/backups/backup-1/backup-1-podvolumesnapshots.json.gz",
 				"prefix-1/metadata/revision",
 			},
 		},
@@ -254,6 +264,7 @@ func TestPutBackup(t *testing.T) {
 			contents:     newStringReadSeeker("contents"),
 			log:          newStringReadSeeker("log"),
 			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
 			expectedErr:  "error readers return errors",
 			expectedKeys: []string{"backups/backup-1/backup-1-logs.gz"},
 		},
@@ -263,20 +274,77 @@ func TestPutBackup(t *testing.T) {
 			contents:     new(errorReader),
 			log:          newStringReadSeeker("log"),
 			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
 			expectedErr:  "error readers return errors",
 			expectedKeys: []string{"backups/backup-1/backup-1-logs.gz"},
 		},
 		{
-			name:        "error on log upload is ok",
-			metadata:    newStringReadSeeker("foo"),
-			contents:    newStringReadSeeker("bar"),
-			log:         new(errorReader),
-			snapshots:   newStringReadSeeker("snapshots"),
-			expectedErr: "",
+			name:         "error on log upload is ok",
+			metadata:     newStringReadSeeker("foo"),
+			contents:     newStringReadSeeker("bar"),
+			log:          new(errorReader),
+			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
+			expectedErr:  "",
 			expectedKeys: []string{
 				"backups/backup-1/ark-backup.json",
 				"backups/backup-1/backup-1.tar.gz",
 				"backups/backup-1/backup-1-volumesnapshots.json.gz",
+				"backups/backup-1/backup-1-podvolumesnapshots.json.gz",
+				"metadata/revision",
+			},
+		},
+		{
+			name:         "error on pod volume snapshots upload is ok",
+			metadata:     newStringReadSeeker("foo"),
+			contents:     newStringReadSeeker("bar"),
+			log:          newStringReadSeeker("log"),
+			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: new(errorReader),
+			expectedErr:  "",
+			expectedKeys: []string{
+				"backups/backup-1/ark-backup.json",
+				"backups/backup-1/backup-1.tar.gz",
+				"backups/backup-1/backup-1-logs.gz",
+				"backups/backup-1/backup-1-volumesnapshots.json.gz",
+				"metadata/revision",
+			},
+		},
+		{
+			name:         "contents larger than max object size are split into parts",
+			metadata:     newStringReadSeeker("metadata"),
+			contents:     strings.NewReader("contents"),
+			log:          newStringReadSeeker("log"),
+			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
+			maxSize:      5,
+			expectedErr:  "",
+			expectedKeys: []string{
+				"backups/backup-1/ark-backup.json",
+				"backups/backup-1/backup-1.tar.gz.000",
+				"backups/backup-1/backup-1.tar.gz.001",
+				"backups/backup-1/backup-1-parts.json",
+				"backups/backup-1/backup-1-logs.gz",
+				"backups/backup-1/backup-1-volumesnapshots.json.gz",
+				"backups/backup-1/backup-1-podvolumesnapshots.json.gz",
+				"metadata/revision",
+			},
+		},
+		{
+			name:         "contents no larger than max object size aren't split",
+			metadata:     newStringReadSeeker("metadata"),
+			contents:     newStringReadSeeker("contents"),
+			log:          newStringReadSeeker("log"),
+			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
+			maxSize:      8,
+			expectedErr:  "",
+			expectedKeys: []string{
+				"backups/backup-1/ark-backup.json",
+				"backups/backup-1/backup-1.tar.gz",
+				"backups/backup-1/backup-1-logs.gz",
+				"backups/backup-1/backup-1-volumesnapshots.json.gz",
+				"backups/backup-1/backup-1-podvolumesnapshots.json.gz",
 				"metadata/revision",
 			},
 		},
@@ -286,6 +354,7 @@ func TestPutBackup(t *testing.T) {
 			contents:     newStringReadSeeker("contents"),
 			log:          newStringReadSeeker("log"),
 			snapshots:    newStringReadSeeker("snapshots"),
+			podSnapshots: newStringReadSeeker("pod-snapshots"),
 			expectedErr:  "",
 			expectedKeys: []string{"backups/backup-1/backup-1-logs.gz"},
 		},
@@ -294,8 +363,9 @@ func TestPutBackup(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			harness := newObjectBackupStoreTestHarness("foo", tc.prefix)
+			harness.maxObjectSize = tc.maxSize
 
-			err := harness.PutBackup("backup-1", tc.metadata, tc.contents, tc.log, tc.snapshots)
+			err := harness.PutBackup("backup-1", tc.metadata, tc.contents, tc.log, tc.snapshots, tc.podSnapshots)
 
 			arktest.AssertErrorMatches(t, tc.expectedErr, err)
 			assert.Len(t, harness.objectStore.Data[harness.bucket], len(tc.expectedKeys))
@@ -306,6 +376,76 @@ func TestPutBackup(t *testing.T) {
 	}
 }
 
+func TestPutBackupLog(t *testing.T) {
+	harness := newObjectBackupStoreTestHarness("test-bucket", "prefix-1/")
+
+	require.NoError(t, harness.PutBackupLog("backup-1", newStringReadSeeker("first")))
+	require.NoError(t, harness.PutBackupLog("backup-1", newStringReadSeeker("first second")))
+
+	assert.Len(t, harness.objectStore.Data[harness.bucket], 1)
+	assert.Equal(t, "first second", string(harness.objectStore.Data[harness.bucket]["prefix-1/backups/backup-1/backup-1-logs.gz"]))
+}
+
+func TestPutBackupIndex(t *testing.T) {
+	harness := newObjectBackupStoreTestHarness("test-bucket", "")
+
+	require.NoError(t, harness.PutBackupIndex("backup-1", newStringReadSeeker("index")))
+
+	assert.Equal(t, "index", string(harness.objectStore.Data[harness.bucket]["backups/backup-1/backup-1-index.json.gz"]))
+}
+
+func TestPutBackupStatusDetails(t *testing.T) {
+	harness := newObjectBackupStoreTestHarness("test-bucket", "")
+
+	require.NoError(t, harness.PutBackupStatusDetails("backup-1", newStringReadSeeker("details")))
+
+	assert.Equal(t, "details", string(harness.objectStore.Data[harness.bucket]["backups/backup-1/backup-1-status.json.gz"]))
+}
+
+// objectOptionsStore is an in-memory object store that records the options
+// objects are uploaded with.
+type objectOptionsStore struct {
+	*cloudprovider.InMemoryObjectStore
+	options map[string]map[string]string
+}
+
+func (o *objectOptionsStore) PutObjectWithOptions(bucket, key string, body io.Reader, options map[string]string) error {
+	o.options[key] = options
+	return o.PutObject(bucket, key, body)
+}
+
+func TestPutBackupObjectOptions(t *testing.T) {
+	harness := newObjectBackupStoreTestHarness("test-bucket", "")
+	objectStore := &objectOptionsStore{
+		InMemoryObjectStore: harness.objectStore,
+		options:             make(map[string]map[string]string),
+	}
+	harness.objectBackupStore.objectStore = objectStore
+	harness.objectOptions = map[api.DownloadTargetKind]map[string]string{
+		api.DownloadTargetKindBackupContents: {"storageClass": "STANDARD_IA"},
+		api.DownloadTargetKindBackupLog:      {"storageClass": "STANDARD"},
+	}
+
+	require.NoError(t, harness.PutBackup("backup-1", newStringReadSeeker("metadata"), newStringReadSeeker("contents"), newStringReadSeeker("log"), newStringReadSeeker("snapshots"), nil))
+
+	expected := map[string]map[string]string{
+		"backups/backup-1/backup-1.tar.gz":  {"storageClass": "STANDARD_IA"},
+		"backups/backup-1/backup-1-logs.gz": {"storageClass": "STANDARD"},
+	}
+	assert.Equal(t, expected, objectStore.options)
+	assert.Equal(t, "metadata", string(harness.objectStore.Data[harness.bucket]["backups/backup-1/ark-backup.json"]))
+	assert.Equal(t, "snapshots", string(harness.objectStore.Data[harness.bucket]["backups/backup-1/backup-1-volumesnapshots.json.gz"]))
+}
+
+func TestPutBackupObjectOptionsNotSupported(t *testing.T) {
+	harness := newObjectBackupStoreTestHarness("test-bucket", "")
+	harness.objectOptions = map[api.DownloadTargetKind]map[string]string{
+		api.DownloadTargetKindRestoreLog: {"storageClass": "STANDARD"},
+	}
+
+	assert.EqualError(t, harness.PutRestoreLog("backup-1", "restore-1", newStringReadSeeker("log")), "object store doesn't support object options")
+}
+
 func TestGetBackupVolumeSnapshots(t *testing.T) {
 	harness := newObjectBackupStoreTestHarness("test-bucket", "")
 
@@ -362,10 +502,39 @@ func TestGetBackupContents(t *testing.T) {
 	assert.Equal(t, "foo", string(data))
 }
 
+func TestGetBackupContentsParts(t *testing.T) {
+	harness := newObjectBackupStoreTestHarness("test-bucket", "")
+	harness.maxObjectSize = 3
+
+	require.NoError(t, harness.PutBackup("test-backup", newStringReadSeeker("metadata"), strings.NewReader("contents"), nil, nil, nil))
+	require.Contains(t, harness.objectStore.Data[harness.bucket], "backups/test-backup/test-backup.tar.gz.002")
+
+	rc, err := harness.GetBackupContents("test-backup")
+	require.NoError(t, err)
+	defer rc.Close()
+
+	data, err := ioutil.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "contents", string(data))
+
+	// a part that doesn't match the manifest is an error
+	require.NoError(t, harness.objectStore.PutObject(harness.bucket, "backups/test-backup/test-backup.tar.gz.001", newStringReadSeeker("t")))
+
+	rc, err = harness.GetBackupContents("test-backup")
+	require.NoError(t, err)
+	defer rc.Close()
+
+	_, err = ioutil.ReadAll(rc)
+	assert.EqualError(t, err, "backup part test-backup.tar.gz.001 is 1 bytes, expected 3")
+}
+
 func TestDeleteBackup(t *testing.T) {
+	unimplemented := status.Error(codes.Unimplemented, "unknown method DeleteObjectsWithPrefix")
+
 	tests := []struct {
 		name             string
 		prefix           string
+		deleteError      error
 		listObjectsError error
 		deleteErrors     []error
 		expectedErr      string
@@ -377,8 +546,24 @@ func TestDeleteBackup(t *testing.T) {
 			name:   "normal case with backup store prefix",
 			prefix: "ark-backups/",
 		},
+		{
+			name:        "delete error is returned",
+			deleteError: errors.New("a"),
+			expectedErr: "a",
+		},
+		{
+			name:        "object store without delete by prefix deletes each object",
+			deleteError: unimplemented,
+		},
+		{
+			name:             "object store without delete by prefix returns list error",
+			deleteError:      unimplemented,
+			listObjectsError: errors.New("list"),
+			expectedErr:      "list",
+		},
 		{
 			name:         "some delete errors, do as much as we can",
+			deleteError:  unimplemented,
 			deleteErrors: []error{errors.New("a"), nil, errors.New("c")},
 			expectedErr:  "[a, c]",
 		},
@@ -395,17 +580,25 @@ func TestDeleteBackup(t *testing.T) {
 			}
 			defer objectStore.AssertExpectations(t)
 
-			objects := []string{test.prefix + "backups/bak/ark-backup.json", test.prefix + "backups/bak/bak.tar.gz", test.prefix + "backups/bak/bak.log.gz"}
+			objectStore.On("DeleteObjectsWithPrefix", backupStore.bucket, test.prefix+"backups/bak/").Return(test.deleteError)
+			objectStore.On("PutObject", "test-bucket", path.Join(test.prefix, "metadata", "revision"), mock.Anything).Return(nil)
 
-			objectStore.On("ListObjects", backupStore.bucket, test.prefix+"backups/bak/").Return(objects, test.listObjectsError)
-			for i, obj := range objects {
-				var err error
-				if i < len(test.deleteErrors) {
-					err = test.deleteErrors[i]
-				}
+			if test.deleteError == unimplemented {
+				objects := []string{test.prefix + "backups/bak/ark-backup.json", test.prefix + "backups/bak/bak.tar.gz", test.prefix + "backups/bak/bak.log.gz"}
+
+				objectStore.On("ListObjects", backupStore.bucket, test.prefix+"backups/bak/").Return(objects, test.listObjectsError)
+				for i, obj := range objects {
+					if test.listObjectsError != nil {
+						break
+					}
+
+					var err error
+					if i < len(test.deleteErrors) {
+						err = test.deleteErrors[i]
+					}
 
-				objectStore.On("DeleteObject", backupStore.bucket, obj).Return(err)
-				objectStore.On("PutObject", "test-bucket", path.Join(test.prefix, "metadata", "revision"), mock.Anything).Return(nil)
+					objectStore.On("DeleteObject", backupStore.bucket, obj).Return(err)
+				}
 			}
 
 			err := backupStore.DeleteBackup("bak")
@@ -415,6 +608,66 @@ func TestDeleteBackup(t *testing.T) {
 	}
 }
 
+func TestDeleteRestore(t *testing.T) {
+	tests := []struct {
+		name             string
+		deleteError      error
+		putRevisionError error
+		deleteErrors     []error
+		expectedErr      string
+	}{
+		{
+			name: "normal case",
+		},
+		{
+			name:        "delete error is returned",
+			deleteError: errors.New("a"),
+			expectedErr: "a",
+		},
+		{
+			name:             "delete and revision errors are aggregated",
+			deleteError:      errors.New("a"),
+			putRevisionError: errors.New("b"),
+			expectedErr:      "[a, error updating revision file: b]",
+		},
+		{
+			name:         "object store without delete by prefix deletes each object",
+			deleteError:  status.Error(codes.Unimplemented, "unknown method DeleteObjectsWithPrefix"),
+			deleteErrors: []error{errors.New("a"), nil},
+			expectedErr:  "a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objectStore := new(cloudprovidermocks.ObjectStore)
+			backupStore := &objectBackupStore{
+				objectStore: objectStore,
+				bucket:      "test-bucket",
+				layout:      NewObjectStoreLayout(""),
+				logger:      arktest.NewLogger(),
+			}
+			defer objectStore.AssertExpectations(t)
+
+			objectStore.On("DeleteObjectsWithPrefix", backupStore.bucket, "restores/res/").Return(test.deleteError)
+			objectStore.On("PutObject", "test-bucket", path.Join("metadata", "revision"), mock.Anything).Return(test.putRevisionError)
+
+			if test.deleteErrors != nil {
+				objects := []string{"restores/res/restore-res-logs.gz", "restores/res/restore-res-results.gz"}
+
+				objectStore.On("ListObjects", backupStore.bucket, "restores/res/").Return(objects, nil)
+				for i, obj := range objects {
+					objectStore.On("DeleteObject", backupStore.bucket, obj).Return(test.deleteErrors[i])
+				}
+			}
+
+			err := backupStore.DeleteRestore("res")
+
+			arktest.AssertErrorMatches(t, test.expectedErr, err)
+		})
+	}
+}
+
 func TestGetDownloadURL(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -429,12 +682,30 @@ func TestGetDownloadURL(t *testing.T) {
 			targetName:  "my-backup",
 			expectedKey: "backups/my-backup/my-backup.tar.gz",
 		},
+		{
+			name:        "backup index",
+			targetKind:  api.DownloadTargetKindBackupIndex,
+			targetName:  "my-backup",
+			expectedKey: "backups/my-backup/my-backup-index.json.gz",
+		},
+		{
+			name:        "backup status details",
+			targetKind:  api.DownloadTargetKindBackupStatusDetails,
+			targetName:  "my-backup",
+			expectedKey: "backups/my-backup/my-backup-status.json.gz",
+		},
 		{
 			name:        "backup log",
 			targetKind:  api.DownloadTargetKindBackupLog,
 			targetName:  "my-backup",
 			expectedKey: "backups/my-backup/my-backup-logs.gz",
 		},
+		{
+			name:        "backup pod volume snapshots",
+			targetKind:  api.DownloadTargetKindBackupPodVolumeSnapshots,
+			targetName:  "my-backup",
+			expectedKey: "backups/my-backup/my-backup-podvolumesnapshots.json.gz",
+		},
 		{
 			name:        "scheduled backup contents",
 			targetKind:  api.DownloadTargetKindBackupContents,
@@ -472,6 +743,12 @@ func TestGetDownloadURL(t *testing.T) {
 			targetName:  "b-cool-20170913154901-20170913154902",
 			expectedKey: "restores/b-cool-20170913154901-20170913154902/restore-b-cool-20170913154901-20170913154902-results.gz",
 		},
+		{
+			name:        "restore manifest",
+			targetKind:  api.DownloadTargetKindRestoreManifest,
+			targetName:  "b-20170913154901",
+			expectedKey: "restores/b-20170913154901/restore-b-20170913154901-manifest.json.gz",
+		},
 	}
 
 	for _, test := range tests {
@@ -487,6 +764,121 @@ func TestGetDownloadURL(t *testing.T) {
 	}
 }
 
+func TestGetDownloadURLSplitBackup(t *testing.T) {
+	harness := newObjectBackupStoreTestHarness("test-bucket", "")
+	harness.maxObjectSize = 5
+
+	require.NoError(t, harness.PutBackup("my-backup", newStringReadSeeker("metadata"), strings.NewReader("contents"), nil, nil, nil))
+
+	_, err := harness.GetDownloadURL(api.DownloadTarget{Kind: api.DownloadTargetKindBackupContents, Name: "my-backup"})
+	assert.EqualError(t, err, "backup my-backup is stored in 2 parts, which can't be downloaded using a single URL")
+}
+
+func TestGetDownloadURLSignedURLTTL(t *testing.T) {
+	objectStore := new(cloudprovidermocks.ObjectStore)
+	backupStore := &objectBackupStore{
+		objectStore:  objectStore,
+		bucket:       "test-bucket",
+		layout:       NewObjectStoreLayout(""),
+		logger:       arktest.NewLogger(),
+		signedURLTTL: time.Minute,
+	}
+	defer objectStore.AssertExpectations(t)
+
+	objectStore.On("CreateSignedURL", "test-bucket", "backups/my-backup/my-backup-logs.gz", time.Minute).Return("a-url", nil)
+
+	url, err := backupStore.GetDownloadURL(api.DownloadTarget{Kind: api.DownloadTargetKindBackupLog, Name: "my-backup"})
+	require.NoError(t, err)
+	assert.Equal(t, "a-url", url)
+}
+
+func TestGetDownloadURLMaxDownloadSize(t *testing.T) {
+	tests := []struct {
+		name            string
+		targetKind      api.DownloadTargetKind
+		tarballSize     int64
+		contents        string
+		maxDownloadSize int64
+		expectedErr     string
+	}{
+		{
+			name:            "backup contents smaller than the limit",
+			targetKind:      api.DownloadTargetKindBackupContents,
+			tarballSize:     8,
+			contents:        "contents",
+			maxDownloadSize: 8,
+		},
+		{
+			name:            "backup contents larger than the limit",
+			targetKind:      api.DownloadTargetKindBackupContents,
+			tarballSize:     8,
+			contents:        "contents",
+			maxDownloadSize: 7,
+			expectedErr:     "my-backup.tar.gz is larger than the maximum download size of 7 bytes",
+		},
+		{
+			name:            "backup contents without a recorded size are measured",
+			targetKind:      api.DownloadTargetKindBackupContents,
+			contents:        "contents",
+			maxDownloadSize: 7,
+			expectedErr:     "my-backup.tar.gz is larger than the maximum download size of 7 bytes",
+		},
+		{
+			name:            "backup log smaller than the limit",
+			targetKind:      api.DownloadTargetKindBackupLog,
+			contents:        "log",
+			maxDownloadSize: 3,
+		},
+		{
+			name:            "backup log larger than the limit",
+			targetKind:      api.DownloadTargetKindBackupLog,
+			contents:        "a long log",
+			maxDownloadSize: 3,
+			expectedErr:     "my-backup-logs.gz is larger than the maximum download size of 3 bytes",
+		},
+		{
+			name:        "no limit",
+			targetKind:  api.DownloadTargetKindBackupLog,
+			contents:    "a long log",
+			expectedErr: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			harness := newObjectBackupStoreTestHarness("test-bucket", "")
+			harness.maxDownloadSize = test.maxDownloadSize
+
+			backup := &api.Backup{
+				TypeMeta:   metav1.TypeMeta{APIVersion: api.SchemeGroupVersion.String(), Kind: "Backup"},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-backup"},
+				Status:     api.BackupStatus{TarballSizeBytes: test.tarballSize},
+			}
+			backupJSON, err := json.Marshal(backup)
+			require.NoError(t, err)
+			require.NoError(t, harness.objectStore.PutObject("test-bucket", "backups/my-backup/ark-backup.json", bytes.NewReader(backupJSON)))
+			require.NoError(t, harness.objectStore.PutObject("test-bucket", "backups/my-backup/my-backup.tar.gz", newStringReadSeeker(test.contents)))
+			require.NoError(t, harness.objectStore.PutObject("test-bucket", "backups/my-backup/my-backup-logs.gz", newStringReadSeeker(test.contents)))
+
+			url, err := harness.GetDownloadURL(api.DownloadTarget{Kind: test.targetKind, Name: "my-backup"})
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "a-url", url)
+		})
+	}
+}
+
+func TestSignedURLTTL(t *testing.T) {
+	location := &api.BackupStorageLocation{}
+	assert.Equal(t, DownloadURLTTL, SignedURLTTL(location))
+
+	location.Spec.SignedURLTTL = &metav1.Duration{Duration: time.Hour}
+	assert.Equal(t, time.Hour, SignedURLTTL(location))
+}
+
 func encodeToBytes(obj runtime.Object) []byte {
 	res, err := encode.Encode(obj, "json")
 	if err != nil {
@@ -0,0 +1,70 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistence
+
+import (
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// BackupSourceCluster returns the cluster that a backup was synced from, or
+// an empty string if it was created by this cluster.
+func BackupSourceCluster(backup *arkv1api.Backup) string {
+	return backup.Labels[arkv1api.SourceClusterLabel]
+}
+
+// SourceClusterFor returns the configuration for the given source cluster in
+// the backup storage location, or nil if the location doesn't have one.
+func SourceClusterFor(location *arkv1api.BackupStorageLocation, cluster string) *arkv1api.SourceCluster {
+	if cluster == "" {
+		return nil
+	}
+
+	for i := range location.Spec.SourceClusters {
+		if location.Spec.SourceClusters[i].Name == cluster {
+			return &location.Spec.SourceClusters[i]
+		}
+	}
+
+	return nil
+}
+
+// SourceClusterLocation returns the backup storage location to use for the
+// given source cluster's backups. If the location has the source cluster
+// configured, a copy of the location is returned whose prefix points to the
+// cluster's backups. Otherwise, the location itself is returned.
+func SourceClusterLocation(location *arkv1api.BackupStorageLocation, cluster string) *arkv1api.BackupStorageLocation {
+	sourceCluster := SourceClusterFor(location, cluster)
+	if sourceCluster == nil || location.Spec.ObjectStorage == nil {
+		return location
+	}
+
+	clusterLocation := location.DeepCopy()
+	clusterLocation.Spec.ObjectStorage.Prefix = sourceCluster.Prefix
+
+	return clusterLocation
+}
+
+// BackupLocation returns the backup storage location to use for the given
+// backup's files, based on the cluster it was synced from or, for backups
+// created by this cluster, the tenant it belongs to.
+func BackupLocation(location *arkv1api.BackupStorageLocation, backup *arkv1api.Backup) (*arkv1api.BackupStorageLocation, error) {
+	if cluster := BackupSourceCluster(backup); cluster != "" {
+		return SourceClusterLocation(location, cluster), nil
+	}
+
+	return TenantLocation(location, BackupTenant(backup))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestBackupLocation(t *testing.T) {
+	tests := []struct {
+		name           string
+		prefix         string
+		sourceClusters []arkv1api.SourceCluster
+		tenants        []arkv1api.TenantStorage
+		backup         *arkv1api.Backup
+		expectedPrefix string
+	}{
+		{
+			name:           "backup created by this cluster uses the location's prefix",
+			prefix:         "ark",
+			sourceClusters: []arkv1api.SourceCluster{{Name: "cluster-2", Prefix: "cluster-2/ark"}},
+			backup:         arktest.NewTestBackup().Backup,
+			expectedPrefix: "ark",
+		},
+		{
+			name:           "backup synced from a source cluster uses the cluster's prefix",
+			prefix:         "ark",
+			sourceClusters: []arkv1api.SourceCluster{{Name: "cluster-2", Prefix: "cluster-2/ark"}},
+			backup:         arktest.NewTestBackup().WithLabel(arkv1api.SourceClusterLabel, "cluster-2").Backup,
+			expectedPrefix: "cluster-2/ark",
+		},
+		{
+			name:           "backup synced from a source cluster that's no longer configured uses the location's prefix",
+			prefix:         "ark",
+			backup:         arktest.NewTestBackup().WithLabel(arkv1api.SourceClusterLabel, "cluster-2").Backup,
+			expectedPrefix: "ark",
+		},
+		{
+			name:           "tenant backup created by this cluster uses the tenant's prefix",
+			prefix:         "ark",
+			sourceClusters: []arkv1api.SourceCluster{{Name: "cluster-2", Prefix: "cluster-2/ark"}},
+			tenants:        []arkv1api.TenantStorage{{Namespace: "team-a"}},
+			backup:         arktest.NewTestBackup().WithLabel(arkv1api.SelfServiceNamespaceLabel, "team-a").Backup,
+			expectedPrefix: "ark/tenants/team-a/",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location := arktest.NewTestBackupStorageLocation().WithObjectStorage("bucket").BackupStorageLocation
+			location.Spec.ObjectStorage.Prefix = test.prefix
+			location.Spec.SourceClusters = test.sourceClusters
+			location.Spec.Tenants = test.tenants
+
+			res, err := BackupLocation(location, test.backup)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedPrefix, res.Spec.ObjectStorage.Prefix)
+			// the original location must not be modified
+			assert.Equal(t, test.prefix, location.Spec.ObjectStorage.Prefix)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistence
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// BackupTenant returns the tenant that a backup belongs to, or an empty
+// string if it doesn't belong to a tenant.
+func BackupTenant(backup *arkv1api.Backup) string {
+	return backup.Labels[arkv1api.SelfServiceNamespaceLabel]
+}
+
+// TenantStorageFor returns the storage configuration for the given tenant
+// in the backup storage location, or nil if the location doesn't have one.
+func TenantStorageFor(location *arkv1api.BackupStorageLocation, tenant string) *arkv1api.TenantStorage {
+	if tenant == "" {
+		return nil
+	}
+
+	for i := range location.Spec.Tenants {
+		if location.Spec.Tenants[i].Namespace == tenant {
+			return &location.Spec.Tenants[i]
+		}
+	}
+
+	return nil
+}
+
+// TenantLocation returns the backup storage location to use for the given
+// tenant's backups. If the location has storage configured for the tenant,
+// a copy of the location is returned whose prefix points to the tenant's
+// directory within the location. Otherwise, the location itself is returned.
+// An error is returned if the tenant's prefix could point outside of the
+// location's tenants directory.
+func TenantLocation(location *arkv1api.BackupStorageLocation, tenant string) (*arkv1api.BackupStorageLocation, error) {
+	storage := TenantStorageFor(location, tenant)
+	if storage == nil || location.Spec.ObjectStorage == nil {
+		return location, nil
+	}
+
+	prefix := storage.Prefix
+	if prefix == "" {
+		prefix = storage.Namespace
+	}
+
+	// the tenant's prefix is joined to the tenants directory, so it mustn't be
+	// able to reach the location's own backups or another tenant's
+	if path.IsAbs(prefix) || strings.Contains(prefix, "..") {
+		return nil, errors.Errorf("invalid prefix %q for tenant %s in backup storage location %s, must be a relative path without \"..\"", prefix, tenant, location.Name)
+	}
+
+	tenantLocation := location.DeepCopy()
+	tenantLocation.Spec.ObjectStorage.Prefix = NewObjectStoreLayout(location.Spec.ObjectStorage.Prefix).GetTenantDir(prefix)
+
+	return tenantLocation, nil
+}
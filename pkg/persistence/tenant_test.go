@@ -0,0 +1,104 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestTenantLocation(t *testing.T) {
+	tests := []struct {
+		name           string
+		prefix         string
+		tenants        []arkv1api.TenantStorage
+		tenant         string
+		expectedPrefix string
+		expectedErr    bool
+	}{
+		{
+			name:           "no tenant uses the location's prefix",
+			prefix:         "ark",
+			tenants:        []arkv1api.TenantStorage{{Namespace: "team-a"}},
+			expectedPrefix: "ark",
+		},
+		{
+			name:           "tenant without storage configured uses the location's prefix",
+			prefix:         "ark",
+			tenants:        []arkv1api.TenantStorage{{Namespace: "team-a"}},
+			tenant:         "team-b",
+			expectedPrefix: "ark",
+		},
+		{
+			name:           "tenant prefix defaults to its namespace",
+			prefix:         "ark",
+			tenants:        []arkv1api.TenantStorage{{Namespace: "team-a"}},
+			tenant:         "team-a",
+			expectedPrefix: "ark/tenants/team-a/",
+		},
+		{
+			name:           "tenant prefix is used when specified",
+			tenants:        []arkv1api.TenantStorage{{Namespace: "team-a", Prefix: "a/b"}},
+			tenant:         "team-a",
+			expectedPrefix: "tenants/a/b/",
+		},
+		{
+			name:        "tenant prefix outside of the tenants directory is rejected",
+			prefix:      "ark",
+			tenants:     []arkv1api.TenantStorage{{Namespace: "team-a", Prefix: "../backups"}},
+			tenant:      "team-a",
+			expectedErr: true,
+		},
+		{
+			name:        "tenant prefix with .. in the middle is rejected",
+			tenants:     []arkv1api.TenantStorage{{Namespace: "team-a", Prefix: "team-a/../../backups"}},
+			tenant:      "team-a",
+			expectedErr: true,
+		},
+		{
+			name:        "absolute tenant prefix is rejected",
+			tenants:     []arkv1api.TenantStorage{{Namespace: "team-a", Prefix: "/backups"}},
+			tenant:      "team-a",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location := arktest.NewTestBackupStorageLocation().WithObjectStorage("bucket").BackupStorageLocation
+			location.Spec.ObjectStorage.Prefix = test.prefix
+			location.Spec.Tenants = test.tenants
+
+			res, err := TenantLocation(location, test.tenant)
+			if test.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedPrefix, res.Spec.ObjectStorage.Prefix)
+			// the original location must not be modified
+			assert.Equal(t, test.prefix, location.Spec.ObjectStorage.Prefix)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package plugin
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/pkg/errors"
@@ -187,6 +188,69 @@ func (c *BlockStoreGRPCClient) SetVolumeID(pv runtime.Unstructured, volumeID str
 	return &updatedPV, nil
 }
 
+// ListSnapshots returns the snapshots in the block store that have all of the
+// provided tags.
+func (c *BlockStoreGRPCClient) ListSnapshots(tags map[string]string) ([]cloudprovider.SnapshotInfo, error) {
+	res, err := c.grpcClient.ListSnapshots(context.Background(), &proto.ListSnapshotsRequest{Plugin: c.plugin, Tags: tags})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]cloudprovider.SnapshotInfo, 0, len(res.Snapshots))
+	for _, snapshot := range res.Snapshots {
+		snapshots = append(snapshots, *snapshotInfoFromProto(snapshot))
+	}
+
+	return snapshots, nil
+}
+
+// GetSnapshotInfo returns information about the specified volume snapshot, or
+// nil if it does not exist.
+func (c *BlockStoreGRPCClient) GetSnapshotInfo(snapshotID string) (*cloudprovider.SnapshotInfo, error) {
+	res, err := c.grpcClient.GetSnapshotInfo(context.Background(), &proto.GetSnapshotInfoRequest{Plugin: c.plugin, SnapshotID: snapshotID})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Snapshot == nil {
+		return nil, nil
+	}
+
+	return snapshotInfoFromProto(res.Snapshot), nil
+}
+
+func snapshotInfoFromProto(snapshot *proto.SnapshotInfo) *cloudprovider.SnapshotInfo {
+	info := &cloudprovider.SnapshotInfo{
+		ID:       snapshot.SnapshotID,
+		VolumeID: snapshot.VolumeID,
+		SizeGB:   snapshot.SizeGB,
+		Status:   snapshot.Status,
+		Tags:     snapshot.Tags,
+	}
+
+	if snapshot.CreationTimestamp != 0 {
+		info.CreationTimestamp = time.Unix(snapshot.CreationTimestamp, 0).UTC()
+	}
+
+	return info
+}
+
+func snapshotInfoToProto(snapshot *cloudprovider.SnapshotInfo) *proto.SnapshotInfo {
+	res := &proto.SnapshotInfo{
+		SnapshotID: snapshot.ID,
+		VolumeID:   snapshot.VolumeID,
+		SizeGB:     snapshot.SizeGB,
+		Status:     snapshot.Status,
+		Tags:       snapshot.Tags,
+	}
+
+	if !snapshot.CreationTimestamp.IsZero() {
+		res.CreationTimestamp = snapshot.CreationTimestamp.Unix()
+	}
+
+	return res
+}
+
 //////////////////////////////////////////////////////////////////////////////
 // server code
 //////////////////////////////////////////////////////////////////////////////
@@ -356,3 +420,44 @@ func (s *BlockStoreGRPCServer) SetVolumeID(ctx context.Context, req *proto.SetVo
 
 	return &proto.SetVolumeIDResponse{PersistentVolume: updatedPVBytes}, nil
 }
+
+// ListSnapshots returns the snapshots in the block store that have all of the
+// provided tags.
+func (s *BlockStoreGRPCServer) ListSnapshots(ctx context.Context, req *proto.ListSnapshotsRequest) (*proto.ListSnapshotsResponse, error) {
+	impl, err := s.getImpl(req.Plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := impl.ListSnapshots(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.ListSnapshotsResponse{}
+	for i := range snapshots {
+		res.Snapshots = append(res.Snapshots, snapshotInfoToProto(&snapshots[i]))
+	}
+
+	return res, nil
+}
+
+// GetSnapshotInfo returns information about the specified volume snapshot.
+func (s *BlockStoreGRPCServer) GetSnapshotInfo(ctx context.Context, req *proto.GetSnapshotInfoRequest) (*proto.GetSnapshotInfoResponse, error) {
+	impl, err := s.getImpl(req.Plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := impl.GetSnapshotInfo(req.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.GetSnapshotInfoResponse{}
+	if snapshot != nil {
+		res.Snapshot = snapshotInfoToProto(snapshot)
+	}
+
+	return res, nil
+}
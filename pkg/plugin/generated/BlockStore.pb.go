@@ -305,6 +305,142 @@ func (m *SetVolumeIDResponse) GetPersistentVolume() []byte {
 	return nil
 }
 
+type SnapshotInfo struct {
+	SnapshotID        string            `protobuf:"bytes,1,opt,name=snapshotID" json:"snapshotID,omitempty"`
+	VolumeID          string            `protobuf:"bytes,2,opt,name=volumeID" json:"volumeID,omitempty"`
+	SizeGB            int64             `protobuf:"varint,3,opt,name=sizeGB" json:"sizeGB,omitempty"`
+	CreationTimestamp int64             `protobuf:"varint,4,opt,name=creationTimestamp" json:"creationTimestamp,omitempty"`
+	Status            string            `protobuf:"bytes,5,opt,name=status" json:"status,omitempty"`
+	Tags              map[string]string `protobuf:"bytes,6,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SnapshotInfo) Reset()                    { *m = SnapshotInfo{} }
+func (m *SnapshotInfo) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotInfo) ProtoMessage()               {}
+func (*SnapshotInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
+
+func (m *SnapshotInfo) GetSnapshotID() string {
+	if m != nil {
+		return m.SnapshotID
+	}
+	return ""
+}
+
+func (m *SnapshotInfo) GetVolumeID() string {
+	if m != nil {
+		return m.VolumeID
+	}
+	return ""
+}
+
+func (m *SnapshotInfo) GetSizeGB() int64 {
+	if m != nil {
+		return m.SizeGB
+	}
+	return 0
+}
+
+func (m *SnapshotInfo) GetCreationTimestamp() int64 {
+	if m != nil {
+		return m.CreationTimestamp
+	}
+	return 0
+}
+
+func (m *SnapshotInfo) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SnapshotInfo) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type ListSnapshotsRequest struct {
+	Plugin string            `protobuf:"bytes,1,opt,name=plugin" json:"plugin,omitempty"`
+	Tags   map[string]string `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ListSnapshotsRequest) Reset()                    { *m = ListSnapshotsRequest{} }
+func (m *ListSnapshotsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSnapshotsRequest) ProtoMessage()               {}
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+
+func (m *ListSnapshotsRequest) GetPlugin() string {
+	if m != nil {
+		return m.Plugin
+	}
+	return ""
+}
+
+func (m *ListSnapshotsRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type ListSnapshotsResponse struct {
+	Snapshots []*SnapshotInfo `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
+}
+
+func (m *ListSnapshotsResponse) Reset()                    { *m = ListSnapshotsResponse{} }
+func (m *ListSnapshotsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSnapshotsResponse) ProtoMessage()               {}
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+
+func (m *ListSnapshotsResponse) GetSnapshots() []*SnapshotInfo {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type GetSnapshotInfoRequest struct {
+	Plugin     string `protobuf:"bytes,1,opt,name=plugin" json:"plugin,omitempty"`
+	SnapshotID string `protobuf:"bytes,2,opt,name=snapshotID" json:"snapshotID,omitempty"`
+}
+
+func (m *GetSnapshotInfoRequest) Reset()                    { *m = GetSnapshotInfoRequest{} }
+func (m *GetSnapshotInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetSnapshotInfoRequest) ProtoMessage()               {}
+func (*GetSnapshotInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
+
+func (m *GetSnapshotInfoRequest) GetPlugin() string {
+	if m != nil {
+		return m.Plugin
+	}
+	return ""
+}
+
+func (m *GetSnapshotInfoRequest) GetSnapshotID() string {
+	if m != nil {
+		return m.SnapshotID
+	}
+	return ""
+}
+
+type GetSnapshotInfoResponse struct {
+	Snapshot *SnapshotInfo `protobuf:"bytes,1,opt,name=snapshot" json:"snapshot,omitempty"`
+}
+
+func (m *GetSnapshotInfoResponse) Reset()                    { *m = GetSnapshotInfoResponse{} }
+func (m *GetSnapshotInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetSnapshotInfoResponse) ProtoMessage()               {}
+func (*GetSnapshotInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
+
+func (m *GetSnapshotInfoResponse) GetSnapshot() *SnapshotInfo {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CreateVolumeRequest)(nil), "generated.CreateVolumeRequest")
 	proto.RegisterType((*CreateVolumeResponse)(nil), "generated.CreateVolumeResponse")
@@ -317,6 +453,11 @@ func init() {
 	proto.RegisterType((*GetVolumeIDResponse)(nil), "generated.GetVolumeIDResponse")
 	proto.RegisterType((*SetVolumeIDRequest)(nil), "generated.SetVolumeIDRequest")
 	proto.RegisterType((*SetVolumeIDResponse)(nil), "generated.SetVolumeIDResponse")
+	proto.RegisterType((*SnapshotInfo)(nil), "generated.SnapshotInfo")
+	proto.RegisterType((*ListSnapshotsRequest)(nil), "generated.ListSnapshotsRequest")
+	proto.RegisterType((*ListSnapshotsResponse)(nil), "generated.ListSnapshotsResponse")
+	proto.RegisterType((*GetSnapshotInfoRequest)(nil), "generated.GetSnapshotInfoRequest")
+	proto.RegisterType((*GetSnapshotInfoResponse)(nil), "generated.GetSnapshotInfoResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -337,6 +478,8 @@ type BlockStoreClient interface {
 	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetVolumeID(ctx context.Context, in *GetVolumeIDRequest, opts ...grpc.CallOption) (*GetVolumeIDResponse, error)
 	SetVolumeID(ctx context.Context, in *SetVolumeIDRequest, opts ...grpc.CallOption) (*SetVolumeIDResponse, error)
+	ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	GetSnapshotInfo(ctx context.Context, in *GetSnapshotInfoRequest, opts ...grpc.CallOption) (*GetSnapshotInfoResponse, error)
 }
 
 type blockStoreClient struct {
@@ -410,6 +553,24 @@ func (c *blockStoreClient) SetVolumeID(ctx context.Context, in *SetVolumeIDReque
 	return out, nil
 }
 
+func (c *blockStoreClient) ListSnapshots(ctx context.Context, in *ListSnapshotsRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	out := new(ListSnapshotsResponse)
+	err := grpc.Invoke(ctx, "/generated.BlockStore/ListSnapshots", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockStoreClient) GetSnapshotInfo(ctx context.Context, in *GetSnapshotInfoRequest, opts ...grpc.CallOption) (*GetSnapshotInfoResponse, error) {
+	out := new(GetSnapshotInfoResponse)
+	err := grpc.Invoke(ctx, "/generated.BlockStore/GetSnapshotInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for BlockStore service
 
 type BlockStoreServer interface {
@@ -420,6 +581,8 @@ type BlockStoreServer interface {
 	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*Empty, error)
 	GetVolumeID(context.Context, *GetVolumeIDRequest) (*GetVolumeIDResponse, error)
 	SetVolumeID(context.Context, *SetVolumeIDRequest) (*SetVolumeIDResponse, error)
+	ListSnapshots(context.Context, *ListSnapshotsRequest) (*ListSnapshotsResponse, error)
+	GetSnapshotInfo(context.Context, *GetSnapshotInfoRequest) (*GetSnapshotInfoResponse, error)
 }
 
 func RegisterBlockStoreServer(s *grpc.Server, srv BlockStoreServer) {
@@ -552,6 +715,42 @@ func _BlockStore_SetVolumeID_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockStore_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockStoreServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.BlockStore/ListSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockStoreServer).ListSnapshots(ctx, req.(*ListSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockStore_GetSnapshotInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockStoreServer).GetSnapshotInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.BlockStore/GetSnapshotInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockStoreServer).GetSnapshotInfo(ctx, req.(*GetSnapshotInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _BlockStore_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "generated.BlockStore",
 	HandlerType: (*BlockStoreServer)(nil),
@@ -584,6 +783,14 @@ var _BlockStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetVolumeID",
 			Handler:    _BlockStore_SetVolumeID_Handler,
 		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _BlockStore_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "GetSnapshotInfo",
+			Handler:    _BlockStore_GetSnapshotInfo_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "BlockStore.proto",
@@ -592,41 +799,52 @@ var _BlockStore_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("BlockStore.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 566 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x96, 0x63, 0xb7, 0x6a, 0x26, 0xa5, 0x8a, 0x36, 0x3f, 0xb2, 0x2c, 0x11, 0x8c, 0x4f, 0x51,
-	0x25, 0x22, 0x08, 0x07, 0x2a, 0x0e, 0x48, 0x05, 0x17, 0x64, 0x51, 0x09, 0xc9, 0x2e, 0x08, 0xc1,
-	0xc9, 0x90, 0x4d, 0x1a, 0xd5, 0xf1, 0x1a, 0xef, 0xa6, 0x92, 0x1f, 0x86, 0x77, 0xe3, 0x41, 0x38,
-	0x54, 0xb6, 0x37, 0xf1, 0xae, 0xb3, 0x69, 0x72, 0xc9, 0xcd, 0x33, 0xb3, 0xfb, 0xcd, 0x37, 0x3b,
-	0xdf, 0x8c, 0xa1, 0xfd, 0x3e, 0x22, 0xbf, 0xef, 0x02, 0x46, 0x52, 0x3c, 0x4a, 0x52, 0xc2, 0x08,
-	0x6a, 0xce, 0x70, 0x8c, 0xd3, 0x90, 0xe1, 0x89, 0x75, 0x1a, 0xdc, 0x86, 0x29, 0x9e, 0x94, 0x01,
-	0xe7, 0xaf, 0x06, 0x9d, 0x0f, 0x29, 0x0e, 0x19, 0xfe, 0x46, 0xa2, 0xe5, 0x02, 0xfb, 0xf8, 0xcf,
-	0x12, 0x53, 0x86, 0xfa, 0x70, 0x9c, 0x44, 0xcb, 0xd9, 0x3c, 0x36, 0x35, 0x5b, 0x1b, 0x36, 0x7d,
-	0x6e, 0xa1, 0x01, 0x00, 0x8d, 0xc3, 0x84, 0xde, 0x12, 0xe6, 0xb9, 0x66, 0xa3, 0x88, 0x09, 0x9e,
-	0x3c, 0x7e, 0x5f, 0x00, 0xdd, 0x64, 0x09, 0x36, 0xf5, 0x32, 0x5e, 0x79, 0x90, 0x05, 0x27, 0xa5,
-	0x75, 0xf9, 0xc3, 0x34, 0x8a, 0xe8, 0xda, 0x46, 0x08, 0x8c, 0x39, 0x49, 0xa8, 0x79, 0x64, 0x6b,
-	0x43, 0xdd, 0x2f, 0xbe, 0x9d, 0x31, 0x74, 0x65, 0x7a, 0x34, 0x21, 0x31, 0x15, 0x70, 0x3c, 0x97,
-	0x33, 0x5c, 0xdb, 0xce, 0x14, 0xba, 0x9f, 0x30, 0x2b, 0x2f, 0x78, 0xf1, 0x94, 0xec, 0xaa, 0x49,
-	0xc4, 0x6a, 0xc8, 0x58, 0x12, 0x5f, 0x5d, 0xe6, 0xeb, 0x7c, 0x86, 0x5e, 0x2d, 0x0f, 0x27, 0x27,
-	0x3f, 0x82, 0xb6, 0xf1, 0x08, 0xab, 0x42, 0x1b, 0x42, 0xa1, 0x53, 0xe8, 0x7a, 0x74, 0x55, 0x64,
-	0x38, 0xc9, 0x0e, 0x45, 0xfa, 0x05, 0xf4, 0x6a, 0x79, 0x38, 0xe9, 0x2e, 0x1c, 0xa5, 0xb9, 0xa3,
-	0xc8, 0x73, 0xe2, 0x97, 0x86, 0xf3, 0x4f, 0x83, 0x5e, 0xd9, 0x80, 0x80, 0x37, 0xf9, 0x40, 0xc4,
-	0xd0, 0x3b, 0x30, 0x58, 0x38, 0xa3, 0xa6, 0x61, 0xeb, 0xc3, 0xd6, 0xf8, 0x7c, 0xb4, 0x56, 0xec,
-	0x48, 0x99, 0x7f, 0x74, 0x13, 0xce, 0xe8, 0x55, 0xcc, 0xd2, 0xcc, 0x2f, 0xee, 0x59, 0x6f, 0xa0,
-	0xb9, 0x76, 0xa1, 0x36, 0xe8, 0x77, 0x38, 0xe3, 0xcc, 0xf2, 0xcf, 0xbc, 0xbc, 0xfb, 0x30, 0x5a,
-	0x62, 0xce, 0xa9, 0x34, 0xde, 0x36, 0x2e, 0x34, 0xe7, 0x02, 0xfa, 0xf5, 0x0c, 0x55, 0x1f, 0x05,
-	0xb1, 0x6b, 0x75, 0xb1, 0x3b, 0x5f, 0xa0, 0xe7, 0xe2, 0x08, 0xef, 0xff, 0x36, 0x3b, 0xa6, 0xc7,
-	0xf9, 0x0e, 0xa8, 0x52, 0x94, 0xbb, 0x0b, 0xed, 0x1c, 0xda, 0x09, 0x4e, 0xe9, 0x9c, 0x32, 0x1c,
-	0xf3, 0x4b, 0x05, 0xe6, 0xa9, 0xbf, 0xe1, 0x77, 0x5e, 0x41, 0x47, 0x42, 0xde, 0x63, 0x8c, 0x18,
-	0xa0, 0xe0, 0x20, 0x64, 0xa4, 0xac, 0x7a, 0x2d, 0xeb, 0x25, 0x74, 0x02, 0x05, 0x51, 0x15, 0xbc,
-	0xa6, 0x86, 0x1f, 0xff, 0x37, 0x00, 0xaa, 0x0d, 0x88, 0x5e, 0x82, 0xe1, 0xc5, 0x73, 0x86, 0xfa,
-	0x82, 0xa4, 0x72, 0x07, 0xaf, 0xc8, 0x6a, 0x0b, 0xfe, 0xab, 0x45, 0xc2, 0x32, 0xf4, 0x13, 0x4c,
-	0x71, 0xe9, 0x7c, 0x4c, 0xc9, 0x62, 0xd5, 0x61, 0x34, 0xd8, 0x10, 0xa6, 0xb4, 0x38, 0xad, 0x67,
-	0x5b, 0xe3, 0xbc, 0x12, 0x1f, 0x9e, 0x48, 0x5b, 0x03, 0x89, 0x37, 0x54, 0x7b, 0xcb, 0xb2, 0xb7,
-	0x1f, 0xa8, 0x30, 0xa5, 0xa1, 0x96, 0x30, 0x55, 0x6b, 0x45, 0xc2, 0x54, 0xef, 0x83, 0xaf, 0x70,
-	0x26, 0x8f, 0x05, 0xb2, 0x77, 0xcd, 0xa4, 0xf5, 0xfc, 0x91, 0x13, 0x1c, 0xd6, 0x85, 0x33, 0x79,
-	0x66, 0x24, 0x58, 0xe5, 0x38, 0x29, 0x3a, 0x74, 0x0d, 0x2d, 0x41, 0xce, 0xe8, 0xa9, 0xf2, 0x85,
-	0x56, 0x9a, 0xb5, 0x06, 0xdb, 0xc2, 0x9c, 0xd3, 0x35, 0xb4, 0x82, 0x2d, 0x68, 0xc1, 0xe3, 0x68,
-	0x0a, 0xa9, 0xfe, 0x3a, 0x2e, 0xfe, 0xac, 0xaf, 0x1f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa2, 0x65,
-	0xf8, 0xa4, 0x86, 0x07, 0x00, 0x00,
+	// 747 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x96, 0xe3, 0x34, 0x6a, 0xa6, 0x3f, 0x84, 0x6d, 0x92, 0x5a, 0x96, 0x28, 0xae, 0x4f, 0xa5,
+	0x82, 0x08, 0x5a, 0x55, 0x54, 0x48, 0x20, 0xb5, 0xa4, 0x54, 0x11, 0x55, 0x41, 0x76, 0x41, 0x15,
+	0x9c, 0x4c, 0xbb, 0x4d, 0xad, 0x26, 0xb6, 0xf1, 0x6e, 0x2a, 0x85, 0x27, 0xe1, 0xc2, 0x95, 0x03,
+	0x4f, 0xc5, 0xa3, 0x20, 0xaf, 0xd7, 0xc9, 0xae, 0xb3, 0x4e, 0x2a, 0xd1, 0xdc, 0x3c, 0x3b, 0xb3,
+	0xdf, 0x7c, 0xb3, 0xf3, 0x67, 0xa8, 0x1d, 0xf6, 0xc2, 0x8b, 0x1b, 0x97, 0x86, 0x31, 0x6e, 0x45,
+	0x71, 0x48, 0x43, 0x54, 0xed, 0xe2, 0x00, 0xc7, 0x1e, 0xc5, 0x97, 0xe6, 0xb2, 0x7b, 0xed, 0xc5,
+	0xf8, 0x32, 0x55, 0xd8, 0xbf, 0x34, 0x58, 0x7b, 0x1b, 0x63, 0x8f, 0xe2, 0xcf, 0x61, 0x6f, 0xd0,
+	0xc7, 0x0e, 0xfe, 0x3e, 0xc0, 0x84, 0xa2, 0x26, 0x54, 0xa2, 0xde, 0xa0, 0xeb, 0x07, 0x86, 0x66,
+	0x69, 0x5b, 0x55, 0x87, 0x4b, 0x68, 0x03, 0x80, 0x04, 0x5e, 0x44, 0xae, 0x43, 0xda, 0x69, 0x1b,
+	0x25, 0xa6, 0x13, 0x4e, 0x12, 0xfd, 0x2d, 0x03, 0x3a, 0x1b, 0x46, 0xd8, 0xd0, 0x53, 0xfd, 0xf8,
+	0x04, 0x99, 0xb0, 0x98, 0x4a, 0x07, 0x5f, 0x8c, 0x32, 0xd3, 0x8e, 0x64, 0x84, 0xa0, 0xec, 0x87,
+	0x11, 0x31, 0x16, 0x2c, 0x6d, 0x4b, 0x77, 0xd8, 0xb7, 0xbd, 0x03, 0x75, 0x99, 0x1e, 0x89, 0xc2,
+	0x80, 0x08, 0x38, 0x9d, 0x36, 0x67, 0x38, 0x92, 0xed, 0x2b, 0xa8, 0x1f, 0x63, 0x9a, 0x5e, 0xe8,
+	0x04, 0x57, 0xe1, 0xac, 0x98, 0x44, 0xac, 0x92, 0x8c, 0x25, 0xf1, 0xd5, 0x65, 0xbe, 0xf6, 0x7b,
+	0x68, 0xe4, 0xfc, 0x70, 0x72, 0xf2, 0x23, 0x68, 0x13, 0x8f, 0x90, 0x05, 0x5a, 0x12, 0x02, 0xbd,
+	0x82, 0x7a, 0x87, 0x64, 0x41, 0x7a, 0x97, 0xc3, 0x79, 0x91, 0x7e, 0x06, 0x8d, 0x9c, 0x1f, 0x4e,
+	0xba, 0x0e, 0x0b, 0x71, 0x72, 0xc0, 0xfc, 0x2c, 0x3a, 0xa9, 0x60, 0xff, 0xd5, 0xa0, 0x91, 0x26,
+	0xc0, 0xe5, 0x49, 0x9e, 0x13, 0x31, 0xf4, 0x06, 0xca, 0xd4, 0xeb, 0x12, 0xa3, 0x6c, 0xe9, 0x5b,
+	0x4b, 0x3b, 0xdb, 0xad, 0x51, 0xc5, 0xb6, 0x94, 0xfe, 0x5b, 0x67, 0x5e, 0x97, 0x1c, 0x05, 0x34,
+	0x1e, 0x3a, 0xec, 0x9e, 0xf9, 0x12, 0xaa, 0xa3, 0x23, 0x54, 0x03, 0xfd, 0x06, 0x0f, 0x39, 0xb3,
+	0xe4, 0x33, 0x09, 0xef, 0xd6, 0xeb, 0x0d, 0x30, 0xe7, 0x94, 0x0a, 0xaf, 0x4a, 0xfb, 0x9a, 0xbd,
+	0x0f, 0xcd, 0xbc, 0x87, 0x71, 0x1e, 0x85, 0x62, 0xd7, 0xf2, 0xc5, 0x6e, 0x7f, 0x80, 0x46, 0x1b,
+	0xf7, 0xf0, 0xdd, 0xdf, 0x66, 0x46, 0xf7, 0xd8, 0xe7, 0x80, 0xc6, 0x15, 0xd5, 0x9e, 0x85, 0xb6,
+	0x0d, 0xb5, 0x08, 0xc7, 0xc4, 0x27, 0x14, 0x07, 0xfc, 0x12, 0xc3, 0x5c, 0x76, 0x26, 0xce, 0xed,
+	0x17, 0xb0, 0x26, 0x21, 0xdf, 0xa1, 0x8d, 0x28, 0x20, 0x77, 0x2e, 0x64, 0x24, 0xaf, 0x7a, 0xce,
+	0xeb, 0x01, 0xac, 0xb9, 0x0a, 0xa2, 0x2a, 0x78, 0xad, 0x20, 0xd6, 0x9f, 0x25, 0x58, 0xce, 0x32,
+	0x92, 0xf4, 0xe5, 0xac, 0x3c, 0x4e, 0x2d, 0xd9, 0x26, 0x54, 0x88, 0xff, 0x03, 0x1f, 0x1f, 0x32,
+	0xa6, 0xba, 0xc3, 0x25, 0xf4, 0x14, 0x1e, 0x5e, 0x24, 0x55, 0xe3, 0x87, 0xc1, 0x99, 0xdf, 0xc7,
+	0x84, 0x7a, 0xfd, 0x88, 0x4d, 0x34, 0xdd, 0x99, 0x54, 0x30, 0x14, 0xea, 0xd1, 0x41, 0x3a, 0xdc,
+	0xaa, 0x0e, 0x97, 0xd0, 0x1e, 0x2f, 0xfa, 0x0a, 0x2b, 0xfa, 0x4d, 0xa1, 0xe8, 0xc5, 0x00, 0xee,
+	0xaf, 0xd6, 0x7f, 0x6b, 0x50, 0x3f, 0xf1, 0x09, 0xcd, 0xd0, 0xc9, 0xac, 0xb4, 0xbe, 0xe6, 0x04,
+	0x4b, 0x8c, 0xe0, 0x13, 0x81, 0xa0, 0x0a, 0xe6, 0xfe, 0x88, 0x9e, 0x42, 0x23, 0xe7, 0x80, 0x17,
+	0xc2, 0x1e, 0x54, 0xb3, 0xcc, 0x11, 0x43, 0x63, 0xac, 0xd6, 0x0b, 0x9e, 0xcd, 0x19, 0x5b, 0xda,
+	0x1f, 0xa1, 0x79, 0x8c, 0xa9, 0xa4, 0xfd, 0xcf, 0x5e, 0x3d, 0x85, 0xf5, 0x09, 0x44, 0xce, 0x71,
+	0x17, 0x16, 0x33, 0x43, 0x06, 0x3a, 0x85, 0xe2, 0xc8, 0x70, 0xe7, 0x4f, 0x05, 0x60, 0xbc, 0xb7,
+	0xd1, 0x73, 0x28, 0x77, 0x02, 0x9f, 0xa2, 0xa6, 0x70, 0x33, 0x39, 0xe0, 0xb4, 0xcd, 0x9a, 0x70,
+	0x7e, 0xd4, 0x8f, 0xe8, 0x10, 0x7d, 0x05, 0x43, 0x5c, 0x95, 0xef, 0xe2, 0xb0, 0x9f, 0xb9, 0x42,
+	0x1b, 0x13, 0xe3, 0x54, 0x5a, 0xf7, 0xe6, 0xe3, 0x42, 0x3d, 0x0f, 0xc9, 0x81, 0x15, 0x69, 0xd7,
+	0x21, 0xf1, 0x86, 0x6a, 0xdb, 0x9a, 0x56, 0xb1, 0xc1, 0x18, 0x53, 0x5a, 0x45, 0x12, 0xa6, 0x6a,
+	0x19, 0x9a, 0x56, 0xb1, 0x01, 0xc7, 0xfc, 0x04, 0xab, 0xf2, 0x30, 0x47, 0xd6, 0xac, 0x4d, 0x62,
+	0x6e, 0x4e, 0xb1, 0xe0, 0xb0, 0x6d, 0x58, 0x95, 0x27, 0xbd, 0x04, 0xab, 0x5c, 0x02, 0x8a, 0x0c,
+	0x9d, 0xc0, 0x92, 0x30, 0x84, 0xd1, 0x23, 0xe5, 0x0b, 0x65, 0x93, 0xd6, 0xdc, 0x28, 0x52, 0x73,
+	0x4e, 0x27, 0xb0, 0xe4, 0x16, 0xa0, 0xb9, 0xd3, 0xd1, 0x54, 0x03, 0xd6, 0x81, 0x15, 0xa9, 0xe1,
+	0xa4, 0x64, 0xa8, 0x7a, 0xdd, 0xb4, 0x8a, 0x0d, 0x38, 0xe6, 0x39, 0x3c, 0xc8, 0xb5, 0x08, 0xda,
+	0x94, 0x83, 0x52, 0x34, 0xa4, 0x69, 0x4f, 0x33, 0x49, 0x91, 0xbf, 0x55, 0xd8, 0xdf, 0xeb, 0xee,
+	0xbf, 0x01, 0x00, 0xac, 0x4d, 0x31, 0xb7, 0xea, 0x0a, 0x00, 0x00,
 }
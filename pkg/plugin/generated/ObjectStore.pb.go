@@ -18,10 +18,11 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type PutObjectRequest struct {
-	Plugin string `protobuf:"bytes,1,opt,name=plugin" json:"plugin,omitempty"`
-	Bucket string `protobuf:"bytes,2,opt,name=bucket" json:"bucket,omitempty"`
-	Key    string `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
-	Body   []byte `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	Plugin  string            `protobuf:"bytes,1,opt,name=plugin" json:"plugin,omitempty"`
+	Bucket  string            `protobuf:"bytes,2,opt,name=bucket" json:"bucket,omitempty"`
+	Key     string            `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
+	Body    []byte            `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	Options map[string]string `protobuf:"bytes,5,rep,name=options" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *PutObjectRequest) Reset()                    { *m = PutObjectRequest{} }
@@ -57,6 +58,13 @@ func (m *PutObjectRequest) GetBody() []byte {
 	return nil
 }
 
+func (m *PutObjectRequest) GetOptions() map[string]string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 type GetObjectRequest struct {
 	Plugin string `protobuf:"bytes,1,opt,name=plugin" json:"plugin,omitempty"`
 	Bucket string `protobuf:"bytes,2,opt,name=bucket" json:"bucket,omitempty"`
@@ -297,6 +305,88 @@ func (m *CreateSignedURLResponse) GetUrl() string {
 	return ""
 }
 
+type CopyObjectRequest struct {
+	Plugin            string `protobuf:"bytes,1,opt,name=plugin" json:"plugin,omitempty"`
+	SourceBucket      string `protobuf:"bytes,2,opt,name=sourceBucket" json:"sourceBucket,omitempty"`
+	SourceKey         string `protobuf:"bytes,3,opt,name=sourceKey" json:"sourceKey,omitempty"`
+	DestinationBucket string `protobuf:"bytes,4,opt,name=destinationBucket" json:"destinationBucket,omitempty"`
+	DestinationKey    string `protobuf:"bytes,5,opt,name=destinationKey" json:"destinationKey,omitempty"`
+}
+
+func (m *CopyObjectRequest) Reset()                    { *m = CopyObjectRequest{} }
+func (m *CopyObjectRequest) String() string            { return proto.CompactTextString(m) }
+func (*CopyObjectRequest) ProtoMessage()               {}
+func (*CopyObjectRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{10} }
+
+func (m *CopyObjectRequest) GetPlugin() string {
+	if m != nil {
+		return m.Plugin
+	}
+	return ""
+}
+
+func (m *CopyObjectRequest) GetSourceBucket() string {
+	if m != nil {
+		return m.SourceBucket
+	}
+	return ""
+}
+
+func (m *CopyObjectRequest) GetSourceKey() string {
+	if m != nil {
+		return m.SourceKey
+	}
+	return ""
+}
+
+func (m *CopyObjectRequest) GetDestinationBucket() string {
+	if m != nil {
+		return m.DestinationBucket
+	}
+	return ""
+}
+
+func (m *CopyObjectRequest) GetDestinationKey() string {
+	if m != nil {
+		return m.DestinationKey
+	}
+	return ""
+}
+
+type DeleteObjectsWithPrefixRequest struct {
+	Plugin string `protobuf:"bytes,1,opt,name=plugin" json:"plugin,omitempty"`
+	Bucket string `protobuf:"bytes,2,opt,name=bucket" json:"bucket,omitempty"`
+	Prefix string `protobuf:"bytes,3,opt,name=prefix" json:"prefix,omitempty"`
+}
+
+func (m *DeleteObjectsWithPrefixRequest) Reset()         { *m = DeleteObjectsWithPrefixRequest{} }
+func (m *DeleteObjectsWithPrefixRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteObjectsWithPrefixRequest) ProtoMessage()    {}
+func (*DeleteObjectsWithPrefixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor2, []int{11}
+}
+
+func (m *DeleteObjectsWithPrefixRequest) GetPlugin() string {
+	if m != nil {
+		return m.Plugin
+	}
+	return ""
+}
+
+func (m *DeleteObjectsWithPrefixRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *DeleteObjectsWithPrefixRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*PutObjectRequest)(nil), "generated.PutObjectRequest")
 	proto.RegisterType((*GetObjectRequest)(nil), "generated.GetObjectRequest")
@@ -308,6 +398,8 @@ func init() {
 	proto.RegisterType((*DeleteObjectRequest)(nil), "generated.DeleteObjectRequest")
 	proto.RegisterType((*CreateSignedURLRequest)(nil), "generated.CreateSignedURLRequest")
 	proto.RegisterType((*CreateSignedURLResponse)(nil), "generated.CreateSignedURLResponse")
+	proto.RegisterType((*CopyObjectRequest)(nil), "generated.CopyObjectRequest")
+	proto.RegisterType((*DeleteObjectsWithPrefixRequest)(nil), "generated.DeleteObjectsWithPrefixRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -328,6 +420,8 @@ type ObjectStoreClient interface {
 	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
 	DeleteObject(ctx context.Context, in *DeleteObjectRequest, opts ...grpc.CallOption) (*Empty, error)
 	CreateSignedURL(ctx context.Context, in *CreateSignedURLRequest, opts ...grpc.CallOption) (*CreateSignedURLResponse, error)
+	CopyObject(ctx context.Context, in *CopyObjectRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteObjectsWithPrefix(ctx context.Context, in *DeleteObjectsWithPrefixRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type objectStoreClient struct {
@@ -449,6 +543,24 @@ func (c *objectStoreClient) CreateSignedURL(ctx context.Context, in *CreateSigne
 	return out, nil
 }
 
+func (c *objectStoreClient) CopyObject(ctx context.Context, in *CopyObjectRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/generated.ObjectStore/CopyObject", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *objectStoreClient) DeleteObjectsWithPrefix(ctx context.Context, in *DeleteObjectsWithPrefixRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/generated.ObjectStore/DeleteObjectsWithPrefix", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ObjectStore service
 
 type ObjectStoreServer interface {
@@ -459,6 +571,8 @@ type ObjectStoreServer interface {
 	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
 	DeleteObject(context.Context, *DeleteObjectRequest) (*Empty, error)
 	CreateSignedURL(context.Context, *CreateSignedURLRequest) (*CreateSignedURLResponse, error)
+	CopyObject(context.Context, *CopyObjectRequest) (*Empty, error)
+	DeleteObjectsWithPrefix(context.Context, *DeleteObjectsWithPrefixRequest) (*Empty, error)
 }
 
 func RegisterObjectStoreServer(s *grpc.Server, srv ObjectStoreServer) {
@@ -602,6 +716,42 @@ func _ObjectStore_CreateSignedURL_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ObjectStore_CopyObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObjectStoreServer).CopyObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.ObjectStore/CopyObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectStoreServer).CopyObject(ctx, req.(*CopyObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ObjectStore_DeleteObjectsWithPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectsWithPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObjectStoreServer).DeleteObjectsWithPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.ObjectStore/DeleteObjectsWithPrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectStoreServer).DeleteObjectsWithPrefix(ctx, req.(*DeleteObjectsWithPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ObjectStore_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "generated.ObjectStore",
 	HandlerType: (*ObjectStoreServer)(nil),
@@ -626,6 +776,14 @@ var _ObjectStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSignedURL",
 			Handler:    _ObjectStore_CreateSignedURL_Handler,
 		},
+		{
+			MethodName: "CopyObject",
+			Handler:    _ObjectStore_CopyObject_Handler,
+		},
+		{
+			MethodName: "DeleteObjectsWithPrefix",
+			Handler:    _ObjectStore_DeleteObjectsWithPrefix_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -645,35 +803,44 @@ var _ObjectStore_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("ObjectStore.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 468 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x51, 0x8b, 0xd3, 0x40,
-	0x10, 0x26, 0x26, 0x1e, 0x66, 0xae, 0x60, 0x9c, 0x83, 0x1a, 0x73, 0x2a, 0x75, 0x51, 0xa8, 0x08,
-	0xe5, 0xd0, 0x17, 0x1f, 0x7c, 0x10, 0x4f, 0x11, 0xa1, 0xe0, 0x91, 0x2a, 0xfa, 0xe0, 0x4b, 0x7a,
-	0x19, 0x7b, 0xb1, 0x69, 0x12, 0x37, 0x13, 0x30, 0x8f, 0xbe, 0xf9, 0xb3, 0x65, 0x37, 0x6b, 0x6f,
-	0xd3, 0xeb, 0x79, 0x70, 0xf4, 0x6d, 0x66, 0x76, 0xbe, 0x99, 0x2f, 0xbb, 0xdf, 0x17, 0xb8, 0xf3,
-	0x71, 0xfe, 0x83, 0x4e, 0x79, 0xc6, 0xa5, 0xa4, 0x49, 0x25, 0x4b, 0x2e, 0xd1, 0x5f, 0x50, 0x41,
-	0x32, 0x61, 0x4a, 0xa3, 0xc1, 0xec, 0x2c, 0x91, 0x94, 0x76, 0x07, 0xe2, 0x0c, 0x82, 0x93, 0x86,
-	0x3b, 0x40, 0x4c, 0x3f, 0x1b, 0xaa, 0x19, 0x87, 0xb0, 0x57, 0xe5, 0xcd, 0x22, 0x2b, 0x42, 0x67,
-	0xe4, 0x8c, 0xfd, 0xd8, 0x64, 0xaa, 0x3e, 0x6f, 0x4e, 0x97, 0xc4, 0xe1, 0x8d, 0xae, 0xde, 0x65,
-	0x18, 0x80, 0xbb, 0xa4, 0x36, 0x74, 0x75, 0x51, 0x85, 0x88, 0xe0, 0xcd, 0xcb, 0xb4, 0x0d, 0xbd,
-	0x91, 0x33, 0x1e, 0xc4, 0x3a, 0x16, 0x9f, 0x20, 0x78, 0x4f, 0xbb, 0xde, 0x24, 0x0e, 0xe1, 0xe6,
-	0x9b, 0x96, 0xa9, 0x56, 0x2b, 0xd3, 0x84, 0x13, 0x3d, 0x68, 0x10, 0xeb, 0x58, 0xfc, 0x76, 0xe0,
-	0xde, 0x34, 0xab, 0xf9, 0xb8, 0x5c, 0xad, 0xca, 0xe2, 0x44, 0xd2, 0xf7, 0xec, 0x17, 0xd5, 0xd7,
-	0x5d, 0x7e, 0x1f, 0xfc, 0x94, 0xf2, 0x6c, 0x95, 0x31, 0x49, 0x43, 0xe1, 0xbc, 0xa0, 0xa7, 0xe9,
-	0x05, 0xfa, 0xa3, 0xd5, 0x34, 0x9d, 0x89, 0x97, 0x10, 0x6d, 0xa3, 0x50, 0x57, 0x65, 0x51, 0x13,
-	0x46, 0x70, 0xab, 0x32, 0xb5, 0xd0, 0x19, 0xb9, 0x63, 0x3f, 0x5e, 0xe7, 0xe2, 0x1b, 0xa0, 0x42,
-	0x76, 0x37, 0x76, 0x6d, 0xd6, 0xe7, 0xbc, 0xdc, 0x1e, 0xaf, 0xa7, 0x70, 0xd0, 0x9b, 0x6e, 0x08,
-	0x21, 0x78, 0x4b, 0x6a, 0xff, 0x91, 0xd1, 0xb1, 0xf8, 0x02, 0x07, 0x6f, 0x29, 0x27, 0xa6, 0x5d,
-	0x3f, 0x5e, 0x0e, 0xc3, 0x63, 0x49, 0x09, 0xd3, 0x2c, 0x5b, 0x14, 0x94, 0x7e, 0x8e, 0xa7, 0xbb,
-	0x93, 0x60, 0x00, 0x2e, 0x73, 0xae, 0x1f, 0xc3, 0x8d, 0x55, 0x28, 0x9e, 0xc1, 0xdd, 0x0b, 0xdb,
-	0xcc, 0x57, 0x07, 0xe0, 0x36, 0x32, 0x37, 0xbb, 0x54, 0xf8, 0xfc, 0x8f, 0x07, 0xfb, 0x96, 0x8d,
-	0xf0, 0x08, 0xbc, 0x0f, 0x45, 0xc6, 0x38, 0x9c, 0xac, 0x9d, 0x34, 0x51, 0x05, 0x43, 0x38, 0x0a,
-	0xac, 0xfa, 0xbb, 0x55, 0xc5, 0x2d, 0xbe, 0x02, 0x7f, 0xed, 0x2c, 0x3c, 0xb4, 0x8e, 0x37, 0xfd,
-	0x76, 0x11, 0x3b, 0x76, 0x14, 0x7a, 0xed, 0x96, 0x1e, 0x7a, 0xd3, 0x43, 0x3d, 0xb4, 0xb6, 0xc2,
-	0x91, 0x83, 0x49, 0x27, 0x9d, 0xbe, 0xe8, 0xf0, 0xb1, 0xd5, 0x79, 0xa9, 0x2d, 0xa2, 0x27, 0x57,
-	0x74, 0x99, 0x2b, 0x9b, 0xc2, 0xbe, 0xa5, 0x1f, 0x7c, 0xb0, 0x81, 0xea, 0xab, 0x36, 0x7a, 0x78,
-	0xd9, 0xb1, 0x99, 0xf6, 0x1a, 0x06, 0xb6, 0xc4, 0xd0, 0xee, 0xdf, 0xa2, 0xbd, 0x2d, 0xd7, 0xfd,
-	0x15, 0x6e, 0x6f, 0xbc, 0x2e, 0x3e, 0xb2, 0x9a, 0xb6, 0xeb, 0x2c, 0x12, 0xff, 0x6b, 0xe9, 0xb8,
-	0xcd, 0xf7, 0xf4, 0x9f, 0xf2, 0xc5, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x54, 0xac, 0xfe, 0xa7,
-	0x57, 0x05, 0x00, 0x00,
+	// 623 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcf, 0x6e, 0xd3, 0x4e,
+	0x10, 0x96, 0xeb, 0xa4, 0xbf, 0x5f, 0xa6, 0x16, 0xb8, 0x5b, 0xd4, 0x1a, 0xb7, 0x54, 0x61, 0x05,
+	0xc8, 0x15, 0x28, 0xaa, 0xca, 0xa5, 0xaa, 0x7a, 0x40, 0x2d, 0x55, 0x84, 0x88, 0xd4, 0xca, 0x01,
+	0xca, 0x81, 0x8b, 0x13, 0x0f, 0x89, 0x89, 0x63, 0x1b, 0x7b, 0x8d, 0xf0, 0x91, 0x17, 0xe1, 0x65,
+	0x78, 0x19, 0x1e, 0x03, 0x79, 0xbd, 0x49, 0x36, 0xb1, 0x13, 0x50, 0x95, 0xdb, 0xce, 0xb7, 0xf3,
+	0xe7, 0x1b, 0xef, 0x7c, 0x63, 0xd8, 0xbe, 0xee, 0x7d, 0xc1, 0x3e, 0xeb, 0xb2, 0x30, 0xc6, 0x56,
+	0x14, 0x87, 0x2c, 0x24, 0x8d, 0x01, 0x06, 0x18, 0x3b, 0x0c, 0x5d, 0x53, 0xeb, 0x0e, 0x9d, 0x18,
+	0xdd, 0xe2, 0x82, 0xfe, 0x56, 0x40, 0xbf, 0x49, 0x59, 0x11, 0x61, 0xe3, 0xd7, 0x14, 0x13, 0x46,
+	0x76, 0x61, 0x33, 0xf2, 0xd3, 0x81, 0x17, 0x18, 0x4a, 0x53, 0xb1, 0x1a, 0xb6, 0xb0, 0x72, 0xbc,
+	0x97, 0xf6, 0x47, 0xc8, 0x8c, 0x8d, 0x02, 0x2f, 0x2c, 0xa2, 0x83, 0x3a, 0xc2, 0xcc, 0x50, 0x39,
+	0x98, 0x1f, 0x09, 0x81, 0x5a, 0x2f, 0x74, 0x33, 0xa3, 0xd6, 0x54, 0x2c, 0xcd, 0xe6, 0x67, 0x72,
+	0x01, 0xff, 0x85, 0x11, 0xf3, 0xc2, 0x20, 0x31, 0xea, 0x4d, 0xd5, 0xda, 0x3a, 0xb1, 0x5a, 0x53,
+	0x56, 0xad, 0x45, 0x0e, 0xad, 0xeb, 0xc2, 0xf5, 0x2a, 0x60, 0x71, 0x66, 0x4f, 0x02, 0xcd, 0x33,
+	0xd0, 0xe4, 0x8b, 0x49, 0x65, 0x65, 0x56, 0xf9, 0x01, 0xd4, 0xbf, 0x39, 0x7e, 0x8a, 0x82, 0x62,
+	0x61, 0x9c, 0x6d, 0x9c, 0x2a, 0xf4, 0x1d, 0xe8, 0x6d, 0x5c, 0x77, 0xa7, 0x74, 0x1f, 0xea, 0x17,
+	0x19, 0xc3, 0x24, 0x6f, 0xd9, 0x75, 0x98, 0xc3, 0x13, 0x69, 0x36, 0x3f, 0xd3, 0x1f, 0x0a, 0x3c,
+	0xec, 0x78, 0x09, 0xbb, 0x0c, 0xc7, 0xe3, 0x30, 0xb8, 0x89, 0xf1, 0xb3, 0xf7, 0x1d, 0x93, 0xbb,
+	0x16, 0x3f, 0x80, 0x86, 0x8b, 0xbe, 0x37, 0xf6, 0x18, 0xc6, 0x82, 0xc2, 0x0c, 0xe0, 0xd9, 0x78,
+	0x01, 0xa3, 0x26, 0xb2, 0x71, 0x8b, 0x9e, 0x82, 0x59, 0x45, 0x21, 0x89, 0xc2, 0x20, 0x41, 0x62,
+	0xc2, 0xff, 0x91, 0xc0, 0x0c, 0xa5, 0xa9, 0x5a, 0x0d, 0x7b, 0x6a, 0xd3, 0x4f, 0x40, 0xf2, 0xc8,
+	0xe2, 0x8b, 0xdd, 0x99, 0xf5, 0x8c, 0x97, 0x3a, 0xc7, 0xeb, 0x08, 0x76, 0xe6, 0xb2, 0x0b, 0x42,
+	0x04, 0x6a, 0x23, 0xcc, 0x26, 0x64, 0xf8, 0x99, 0xde, 0xc2, 0xce, 0x6b, 0xf4, 0x91, 0xe1, 0xba,
+	0x1f, 0xcf, 0x87, 0xdd, 0xcb, 0x18, 0x1d, 0x86, 0x5d, 0x6f, 0x10, 0xa0, 0xfb, 0xde, 0xee, 0xac,
+	0x4f, 0x02, 0x3a, 0xa8, 0x8c, 0xf9, 0xfc, 0x31, 0x54, 0x3b, 0x3f, 0xd2, 0xe7, 0xb0, 0x57, 0xaa,
+	0x26, 0xba, 0xd6, 0x41, 0x4d, 0x63, 0x7f, 0x32, 0xc7, 0x69, 0xec, 0xd3, 0x5f, 0x0a, 0x6c, 0x5f,
+	0x86, 0x51, 0xf6, 0x6f, 0x2d, 0x53, 0xd0, 0x92, 0x30, 0x8d, 0xfb, 0x78, 0x21, 0x93, 0x9b, 0xc3,
+	0xf2, 0xf1, 0x29, 0xec, 0xb7, 0x53, 0xa2, 0x33, 0x80, 0xbc, 0x80, 0x6d, 0x17, 0x13, 0xe6, 0x05,
+	0x4e, 0x2e, 0x2f, 0x91, 0xa6, 0x98, 0xa4, 0xf2, 0x05, 0x79, 0x06, 0xf7, 0x24, 0x30, 0x4f, 0x58,
+	0xe7, 0xae, 0x0b, 0x28, 0x1d, 0xc2, 0xa1, 0xfc, 0x72, 0xc9, 0xad, 0xc7, 0x86, 0xc5, 0x0c, 0xae,
+	0x79, 0x9c, 0x4e, 0x7e, 0xd6, 0x61, 0x4b, 0xda, 0x7b, 0xe4, 0x18, 0x6a, 0x6f, 0x02, 0x8f, 0x91,
+	0x5d, 0x69, 0xc9, 0xe4, 0x80, 0xa8, 0x6b, 0xea, 0x12, 0x7e, 0x35, 0x8e, 0x58, 0x46, 0xce, 0xa1,
+	0x31, 0xdd, 0x42, 0x64, 0x7f, 0xc5, 0x6e, 0x2a, 0xc7, 0x5a, 0x4a, 0x1e, 0xdd, 0xc6, 0xaa, 0xe8,
+	0x36, 0xae, 0x88, 0xe6, 0xab, 0xe3, 0x58, 0x21, 0x0e, 0x90, 0xb2, 0x48, 0xc9, 0x13, 0xc9, 0x73,
+	0xe9, 0x1a, 0x31, 0x9f, 0xfe, 0xc5, 0x4b, 0x8c, 0x58, 0x07, 0xb6, 0x24, 0xbd, 0x91, 0x47, 0x0b,
+	0x51, 0xf3, 0x2a, 0x37, 0x0f, 0x97, 0x5d, 0x8b, 0x6c, 0xaf, 0x40, 0x93, 0x1f, 0x96, 0xc8, 0xfe,
+	0x15, 0x5a, 0xad, 0xf8, 0xdc, 0x1f, 0xe1, 0xfe, 0x82, 0x1a, 0xc8, 0x63, 0xc9, 0xa9, 0x5a, 0x97,
+	0x26, 0x5d, 0xe5, 0x22, 0xb8, 0x9d, 0x03, 0xcc, 0x94, 0x43, 0x0e, 0xe4, 0x88, 0x45, 0x41, 0x55,
+	0xf0, 0xfa, 0x00, 0x7b, 0x4b, 0x46, 0x96, 0x1c, 0x2d, 0x69, 0xb2, 0x3c, 0xd6, 0xe5, 0xbc, 0xbd,
+	0x4d, 0xfe, 0xc3, 0x7d, 0xf9, 0x67, 0x00, 0xc1, 0xcd, 0xbe, 0xdb, 0x9e, 0x07, 0x00, 0x00,
 }
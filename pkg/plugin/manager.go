@@ -18,11 +18,13 @@ package plugin
 
 import (
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/heptio/ark/pkg/backup"
 	"github.com/heptio/ark/pkg/cloudprovider"
+	"github.com/heptio/ark/pkg/metrics"
 	"github.com/heptio/ark/pkg/restore"
 )
 
@@ -56,6 +58,9 @@ type manager struct {
 	logLevel logrus.Level
 	registry Registry
 
+	blockStoreTimeout time.Duration
+	metrics           *metrics.ServerMetrics
+
 	restartableProcessFactory RestartableProcessFactory
 
 	// lock guards restartableProcesses
@@ -63,13 +68,18 @@ type manager struct {
 	restartableProcesses map[string]RestartableProcess
 }
 
-// NewManager constructs a manager for getting plugins.
-func NewManager(logger logrus.FieldLogger, level logrus.Level, registry Registry) Manager {
+// NewManager constructs a manager for getting plugins. Block stores it returns time
+// out their CreateSnapshot and CreateVolumeFromSnapshot calls after blockStoreTimeout,
+// unless it's zero, and record their durations in metrics, unless it's nil.
+func NewManager(logger logrus.FieldLogger, level logrus.Level, registry Registry, blockStoreTimeout time.Duration, metrics *metrics.ServerMetrics) Manager {
 	return &manager{
 		logger:   logger,
 		logLevel: level,
 		registry: registry,
 
+		blockStoreTimeout: blockStoreTimeout,
+		metrics:           metrics,
+
 		restartableProcessFactory: newRestartableProcessFactory(),
 
 		restartableProcesses: make(map[string]RestartableProcess),
@@ -135,7 +145,8 @@ func (m *manager) GetObjectStore(name string) (cloudprovider.ObjectStore, error)
 	return r, nil
 }
 
-// GetBlockStore returns a restartableBlockStore for name.
+// GetBlockStore returns a restartableBlockStore for name, wrapped in a timedBlockStore
+// if the manager has a block store timeout or metrics.
 func (m *manager) GetBlockStore(name string) (cloudprovider.BlockStore, error) {
 	restartableProcess, err := m.getRestartableProcess(PluginKindBlockStore, name)
 	if err != nil {
@@ -144,6 +155,10 @@ func (m *manager) GetBlockStore(name string) (cloudprovider.BlockStore, error) {
 
 	r := newRestartableBlockStore(name, restartableProcess)
 
+	if m.blockStoreTimeout > 0 || m.metrics != nil {
+		return newTimedBlockStore(r, name, m.blockStoreTimeout, m.metrics), nil
+	}
+
 	return r, nil
 }
 
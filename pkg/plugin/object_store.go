@@ -82,6 +82,13 @@ func (c *ObjectStoreGRPCClient) Init(config map[string]string) error {
 // PutObject creates a new object using the data in body within the specified
 // object storage bucket with the given key.
 func (c *ObjectStoreGRPCClient) PutObject(bucket, key string, body io.Reader) error {
+	return c.PutObjectWithOptions(bucket, key, body, nil)
+}
+
+// PutObjectWithOptions creates a new object using the data in body within the
+// specified object storage bucket with the given key, applying the provided
+// options to it.
+func (c *ObjectStoreGRPCClient) PutObjectWithOptions(bucket, key string, body io.Reader, options map[string]string) error {
 	stream, err := c.grpcClient.PutObject(context.Background())
 	if err != nil {
 		return err
@@ -101,7 +108,7 @@ func (c *ObjectStoreGRPCClient) PutObject(bucket, key string, body io.Reader) er
 			return err
 		}
 
-		if err := stream.Send(&proto.PutObjectRequest{Plugin: c.plugin, Bucket: bucket, Key: key, Body: chunk[0:n]}); err != nil {
+		if err := stream.Send(&proto.PutObjectRequest{Plugin: c.plugin, Bucket: bucket, Key: key, Body: chunk[0:n], Options: options}); err != nil {
 			return err
 		}
 	}
@@ -183,6 +190,28 @@ func (c *ObjectStoreGRPCClient) CreateSignedURL(bucket, key string, ttl time.Dur
 	return res.Url, nil
 }
 
+// CopyObject copies the object with the given source key in the source bucket
+// to the destination key in the destination bucket.
+func (c *ObjectStoreGRPCClient) CopyObject(sourceBucket, sourceKey, destinationBucket, destinationKey string) error {
+	_, err := c.grpcClient.CopyObject(context.Background(), &proto.CopyObjectRequest{
+		Plugin:            c.plugin,
+		SourceBucket:      sourceBucket,
+		SourceKey:         sourceKey,
+		DestinationBucket: destinationBucket,
+		DestinationKey:    destinationKey,
+	})
+
+	return err
+}
+
+// DeleteObjectsWithPrefix removes all objects in the given bucket whose keys
+// start with the specified prefix.
+func (c *ObjectStoreGRPCClient) DeleteObjectsWithPrefix(bucket, prefix string) error {
+	_, err := c.grpcClient.DeleteObjectsWithPrefix(context.Background(), &proto.DeleteObjectsWithPrefixRequest{Plugin: c.plugin, Bucket: bucket, Prefix: prefix})
+
+	return err
+}
+
 //////////////////////////////////////////////////////////////////////////////
 // server code
 //////////////////////////////////////////////////////////////////////////////
@@ -239,13 +268,15 @@ func (s *ObjectStoreGRPCServer) PutObject(stream proto.ObjectStore_PutObjectServ
 		return err
 	}
 
-	impl, err := s.getImpl(firstChunk.Plugin)
+	plugin := firstChunk.Plugin
+	impl, err := s.getImpl(plugin)
 	if err != nil {
 		return err
 	}
 
 	bucket := firstChunk.Bucket
 	key := firstChunk.Key
+	options := firstChunk.Options
 
 	receive := func() ([]byte, error) {
 		if firstChunk != nil {
@@ -265,7 +296,16 @@ func (s *ObjectStoreGRPCServer) PutObject(stream proto.ObjectStore_PutObjectServ
 		return nil
 	}
 
-	if err := impl.PutObject(bucket, key, &StreamReadCloser{receive: receive, close: close}); err != nil {
+	body := &StreamReadCloser{receive: receive, close: close}
+
+	if len(options) == 0 {
+		err = impl.PutObject(bucket, key, body)
+	} else if putter, ok := impl.(cloudprovider.ObjectOptionsPutter); ok {
+		err = putter.PutObjectWithOptions(bucket, key, body, options)
+	} else {
+		err = errors.Errorf("object store %s doesn't support object options", plugin)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -362,3 +402,33 @@ func (s *ObjectStoreGRPCServer) CreateSignedURL(ctx context.Context, req *proto.
 
 	return &proto.CreateSignedURLResponse{Url: url}, nil
 }
+
+// CopyObject copies the object with the given source key in the source bucket
+// to the destination key in the destination bucket.
+func (s *ObjectStoreGRPCServer) CopyObject(ctx context.Context, req *proto.CopyObjectRequest) (*proto.Empty, error) {
+	impl, err := s.getImpl(req.Plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := impl.CopyObject(req.SourceBucket, req.SourceKey, req.DestinationBucket, req.DestinationKey); err != nil {
+		return nil, err
+	}
+
+	return &proto.Empty{}, nil
+}
+
+// DeleteObjectsWithPrefix removes all objects in the given bucket whose keys
+// start with the specified prefix.
+func (s *ObjectStoreGRPCServer) DeleteObjectsWithPrefix(ctx context.Context, req *proto.DeleteObjectsWithPrefixRequest) (*proto.Empty, error) {
+	impl, err := s.getImpl(req.Plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := impl.DeleteObjectsWithPrefix(req.Bucket, req.Prefix); err != nil {
+		return nil, err
+	}
+
+	return &proto.Empty{}, nil
+}
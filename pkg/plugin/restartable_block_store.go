@@ -157,3 +157,21 @@ func (r *restartableBlockStore) DeleteSnapshot(snapshotID string) error {
 	}
 	return delegate.DeleteSnapshot(snapshotID)
 }
+
+// ListSnapshots restarts the plugin's process if needed, then delegates the call.
+func (r *restartableBlockStore) ListSnapshots(tags map[string]string) ([]cloudprovider.SnapshotInfo, error) {
+	delegate, err := r.getDelegate()
+	if err != nil {
+		return nil, err
+	}
+	return delegate.ListSnapshots(tags)
+}
+
+// GetSnapshotInfo restarts the plugin's process if needed, then delegates the call.
+func (r *restartableBlockStore) GetSnapshotInfo(snapshotID string) (*cloudprovider.SnapshotInfo, error) {
+	delegate, err := r.getDelegate()
+	if err != nil {
+		return nil, err
+	}
+	return delegate.GetSnapshotInfo(snapshotID)
+}
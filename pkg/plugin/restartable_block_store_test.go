@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/heptio/ark/pkg/cloudprovider"
 	"github.com/heptio/ark/pkg/cloudprovider/mocks"
 )
 
@@ -242,5 +243,17 @@ func TestRestartableBlockStoreDelegatedFunctions(t *testing.T) {
 			expectedErrorOutputs:    []interface{}{errors.Errorf("reset error")},
 			expectedDelegateOutputs: []interface{}{errors.Errorf("delegate error")},
 		},
+		restartableDelegateTest{
+			function:                "ListSnapshots",
+			inputs:                  []interface{}{map[string]string{"a": "b"}},
+			expectedErrorOutputs:    []interface{}{([]cloudprovider.SnapshotInfo)(nil), errors.Errorf("reset error")},
+			expectedDelegateOutputs: []interface{}{[]cloudprovider.SnapshotInfo{{ID: "snapshot"}}, errors.Errorf("delegate error")},
+		},
+		restartableDelegateTest{
+			function:                "GetSnapshotInfo",
+			inputs:                  []interface{}{"snapshotID"},
+			expectedErrorOutputs:    []interface{}{(*cloudprovider.SnapshotInfo)(nil), errors.Errorf("reset error")},
+			expectedDelegateOutputs: []interface{}{&cloudprovider.SnapshotInfo{ID: "snapshot"}, errors.Errorf("delegate error")},
+		},
 	)
 }
@@ -118,6 +118,20 @@ func (r *restartableObjectStore) PutObject(bucket string, key string, body io.Re
 	return delegate.PutObject(bucket, key, body)
 }
 
+// PutObjectWithOptions restarts the plugin's process if needed, then delegates the call.
+func (r *restartableObjectStore) PutObjectWithOptions(bucket string, key string, body io.Reader, options map[string]string) error {
+	delegate, err := r.getDelegate()
+	if err != nil {
+		return err
+	}
+
+	putter, ok := delegate.(cloudprovider.ObjectOptionsPutter)
+	if !ok {
+		return errors.Errorf("object store %s doesn't support object options", r.key.name)
+	}
+	return putter.PutObjectWithOptions(bucket, key, body, options)
+}
+
 // GetObject restarts the plugin's process if needed, then delegates the call.
 func (r *restartableObjectStore) GetObject(bucket string, key string) (io.ReadCloser, error) {
 	delegate, err := r.getDelegate()
@@ -162,3 +176,21 @@ func (r *restartableObjectStore) CreateSignedURL(bucket string, key string, ttl
 	}
 	return delegate.CreateSignedURL(bucket, key, ttl)
 }
+
+// CopyObject restarts the plugin's process if needed, then delegates the call.
+func (r *restartableObjectStore) CopyObject(sourceBucket string, sourceKey string, destinationBucket string, destinationKey string) error {
+	delegate, err := r.getDelegate()
+	if err != nil {
+		return err
+	}
+	return delegate.CopyObject(sourceBucket, sourceKey, destinationBucket, destinationKey)
+}
+
+// DeleteObjectsWithPrefix restarts the plugin's process if needed, then delegates the call.
+func (r *restartableObjectStore) DeleteObjectsWithPrefix(bucket string, prefix string) error {
+	delegate, err := r.getDelegate()
+	if err != nil {
+		return err
+	}
+	return delegate.DeleteObjectsWithPrefix(bucket, prefix)
+}
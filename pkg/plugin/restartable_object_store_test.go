@@ -201,6 +201,12 @@ func TestRestartableObjectStoreDelegatedFunctions(t *testing.T) {
 			expectedErrorOutputs:    []interface{}{errors.Errorf("reset error")},
 			expectedDelegateOutputs: []interface{}{errors.Errorf("delegate error")},
 		},
+		restartableDelegateTest{
+			function:                "PutObjectWithOptions",
+			inputs:                  []interface{}{"bucket", "key", strings.NewReader("body"), map[string]string{"storageClass": "STANDARD_IA"}},
+			expectedErrorOutputs:    []interface{}{errors.Errorf("reset error")},
+			expectedDelegateOutputs: []interface{}{errors.Errorf("delegate error")},
+		},
 		restartableDelegateTest{
 			function:                "GetObject",
 			inputs:                  []interface{}{"bucket", "key"},
@@ -231,5 +237,17 @@ func TestRestartableObjectStoreDelegatedFunctions(t *testing.T) {
 			expectedErrorOutputs:    []interface{}{"", errors.Errorf("reset error")},
 			expectedDelegateOutputs: []interface{}{"signedURL", errors.Errorf("delegate error")},
 		},
+		restartableDelegateTest{
+			function:                "CopyObject",
+			inputs:                  []interface{}{"bucket", "key", "other-bucket", "other-key"},
+			expectedErrorOutputs:    []interface{}{errors.Errorf("reset error")},
+			expectedDelegateOutputs: []interface{}{errors.Errorf("delegate error")},
+		},
+		restartableDelegateTest{
+			function:                "DeleteObjectsWithPrefix",
+			inputs:                  []interface{}{"bucket", "prefix"},
+			expectedErrorOutputs:    []interface{}{errors.Errorf("reset error")},
+			expectedDelegateOutputs: []interface{}{errors.Errorf("delegate error")},
+		},
 	)
 }
@@ -0,0 +1,104 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/heptio/ark/pkg/cloudprovider"
+	"github.com/heptio/ark/pkg/metrics"
+)
+
+// timedBlockStore is a BlockStore that records how long its provider takes to create
+// snapshots and volumes from snapshots, and gives up waiting for either after a timeout
+// so that a hung cloud API call can't block a backup or restore indefinitely. All other
+// calls go straight to the wrapped BlockStore.
+type timedBlockStore struct {
+	cloudprovider.BlockStore
+
+	provider string
+	timeout  time.Duration
+	metrics  *metrics.ServerMetrics
+}
+
+// newTimedBlockStore returns a timedBlockStore for provider that wraps blockStore. If timeout
+// is zero, calls never time out. If metrics is nil, no metrics are recorded.
+func newTimedBlockStore(blockStore cloudprovider.BlockStore, provider string, timeout time.Duration, metrics *metrics.ServerMetrics) *timedBlockStore {
+	return &timedBlockStore{
+		BlockStore: blockStore,
+		provider:   provider,
+		timeout:    timeout,
+		metrics:    metrics,
+	}
+}
+
+// CreateVolumeFromSnapshot creates a new block volume from snapshotID, timing out after b.timeout.
+func (b *timedBlockStore) CreateVolumeFromSnapshot(snapshotID string, volumeType string, volumeAZ string, iops *int64) (string, error) {
+	return b.run("CreateVolumeFromSnapshot", func() (string, error) {
+		return b.BlockStore.CreateVolumeFromSnapshot(snapshotID, volumeType, volumeAZ, iops)
+	})
+}
+
+// CreateSnapshot creates a snapshot of volumeID, timing out after b.timeout.
+func (b *timedBlockStore) CreateSnapshot(volumeID, volumeAZ string, tags map[string]string) (string, error) {
+	return b.run("CreateSnapshot", func() (string, error) {
+		return b.BlockStore.CreateSnapshot(volumeID, volumeAZ, tags)
+	})
+}
+
+// run calls fn, records its duration and whether it failed as operation, and returns
+// its result, or an error if it hasn't returned within b.timeout. A call that times out
+// is left running in the background and its result is discarded.
+func (b *timedBlockStore) run(operation string, fn func() (string, error)) (string, error) {
+	type result struct {
+		id  string
+		err error
+	}
+
+	results := make(chan result, 1)
+	start := time.Now()
+
+	go func() {
+		id, err := fn()
+		results <- result{id: id, err: err}
+	}()
+
+	var timeout <-chan time.Time
+	if b.timeout > 0 {
+		timer := time.NewTimer(b.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-timeout:
+		res.err = errors.Errorf("timed out after %s waiting for %s plugin to complete %s", b.timeout, b.provider, operation)
+	}
+
+	if b.metrics != nil {
+		b.metrics.RegisterVolumeSnapshotOperation(b.provider, operation, time.Since(start).Seconds())
+		if res.err != nil {
+			b.metrics.RegisterVolumeSnapshotOperationFailure(b.provider, operation)
+		}
+	}
+
+	return res.id, res.err
+}
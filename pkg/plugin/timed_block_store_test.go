@@ -0,0 +1,90 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/heptio/ark/pkg/cloudprovider/mocks"
+	"github.com/heptio/ark/pkg/metrics"
+)
+
+func TestTimedBlockStore(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       time.Duration
+		delay         time.Duration
+		err           error
+		expectedID    string
+		expectedError string
+	}{
+		{
+			name:       "no timeout returns the plugin's result",
+			expectedID: "id-1",
+		},
+		{
+			name:       "call that finishes before the timeout returns the plugin's result",
+			timeout:    time.Minute,
+			expectedID: "id-1",
+		},
+		{
+			name:          "plugin error is returned",
+			timeout:       time.Minute,
+			err:           errors.New("quota exceeded"),
+			expectedError: "quota exceeded",
+		},
+		{
+			name:          "call that doesn't finish before the timeout returns an error",
+			timeout:       10 * time.Millisecond,
+			delay:         time.Second,
+			expectedError: "timed out after 10ms waiting for aws plugin to complete",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blockStore := new(mocks.BlockStore)
+			id := test.expectedID
+			if test.err != nil || test.delay > 0 {
+				id = ""
+			}
+			blockStore.On("CreateSnapshot", "vol-1", "us-east-1a", mock.Anything).After(test.delay).Return(id, test.err)
+			blockStore.On("CreateVolumeFromSnapshot", "snap-1", "gp2", "us-east-1a", (*int64)(nil)).After(test.delay).Return(id, test.err)
+
+			b := newTimedBlockStore(blockStore, "aws", test.timeout, metrics.NewServerMetrics())
+
+			snapshotID, err := b.CreateSnapshot("vol-1", "us-east-1a", nil)
+			volumeID, volumeErr := b.CreateVolumeFromSnapshot("snap-1", "gp2", "us-east-1a", nil)
+
+			if test.expectedError != "" {
+				assert.Contains(t, err.Error(), test.expectedError)
+				assert.Contains(t, volumeErr.Error(), test.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, volumeErr)
+			assert.Equal(t, test.expectedID, snapshotID)
+			assert.Equal(t, test.expectedID, volumeID)
+		})
+	}
+}
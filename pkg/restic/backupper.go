@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -38,9 +39,10 @@ type Backupper interface {
 }
 
 type backupper struct {
-	ctx         context.Context
-	repoManager *repositoryManager
-	repoEnsurer *repositoryEnsurer
+	ctx           context.Context
+	repoManager   *repositoryManager
+	repoEnsurer   *repositoryEnsurer
+	volumeTimeout time.Duration
 
 	results     map[string]chan *arkv1api.PodVolumeBackup
 	resultsLock sync.Mutex
@@ -51,12 +53,14 @@ func newBackupper(
 	repoManager *repositoryManager,
 	repoEnsurer *repositoryEnsurer,
 	podVolumeBackupInformer cache.SharedIndexInformer,
+	volumeTimeout time.Duration,
 	log logrus.FieldLogger,
 ) *backupper {
 	b := &backupper{
-		ctx:         ctx,
-		repoManager: repoManager,
-		repoEnsurer: repoEnsurer,
+		ctx:           ctx,
+		repoManager:   repoManager,
+		repoEnsurer:   repoEnsurer,
+		volumeTimeout: volumeTimeout,
 
 		results: make(map[string]chan *arkv1api.PodVolumeBackup),
 	}
@@ -66,7 +70,7 @@ func newBackupper(
 			UpdateFunc: func(_, obj interface{}) {
 				pvb := obj.(*arkv1api.PodVolumeBackup)
 
-				if pvb.Status.Phase == arkv1api.PodVolumeBackupPhaseCompleted || pvb.Status.Phase == arkv1api.PodVolumeBackupPhaseFailed {
+				if isPodVolumeBackupDone(pvb) {
 					b.resultsLock.Lock()
 					defer b.resultsLock.Unlock()
 
@@ -115,6 +119,8 @@ func (b *backupper) BackupPodVolumes(backup *arkv1api.Backup, pod *corev1api.Pod
 		errs            []error
 		volumeSnapshots = make(map[string]string)
 		podVolumes      = make(map[string]corev1api.Volume)
+		hostPathVolumes = make(map[string]bool)
+		totalTimeout    time.Duration
 	)
 
 	// put the pod's volumes in a map for efficient lookup below
@@ -128,14 +134,35 @@ func (b *backupper) BackupPodVolumes(backup *arkv1api.Backup, pod *corev1api.Pod
 			continue
 		}
 
-		// hostPath volumes are not supported because they're not mounted into /var/lib/kubelet/pods, so our
-		// daemonset pod has no way to access their data.
-		if isHostPathVolume(podVolumes, volumeName) {
-			log.Warnf("Volume %s in pod %s/%s is a hostPath volume which is not supported for restic backup, skipping", volumeName, pod.Namespace, pod.Name)
+		volumeBackup := newPodVolumeBackup(backup, pod, volumeName, repo.Spec.ResticIdentifier)
+
+		includePaths, excludePatterns, err := GetVolumeBackupFilters(pod, volumeName)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error getting backup filters for pod %s/%s", pod.Namespace, pod.Name))
 			continue
 		}
+		volumeBackup.Spec.IncludePaths = includePaths
+		volumeBackup.Spec.ExcludePatterns = excludePatterns
 
-		volumeBackup := newPodVolumeBackup(backup, pod, volumeName, repo.Spec.ResticIdentifier)
+		timeout, err := GetVolumeTimeout(pod, volumeName, b.volumeTimeout)
+		if err != nil {
+			log.WithError(err).Warnf("Invalid timeout annotation for volume %s in pod %s/%s, using %s", volumeName, pod.Namespace, pod.Name, timeout)
+		}
+		volumeBackup.Spec.Timeout = metav1.Duration{Duration: timeout}
+
+		// hostPath volumes aren't mounted into /var/lib/kubelet/pods, so our daemonset pod can only
+		// access their data through the node's root filesystem, which has to be opted into.
+		if isHostPathVolume(podVolumes, volumeName) {
+			if !backup.Spec.ResticHostPathVolumes {
+				log.Warnf("Volume %s in pod %s/%s is a hostPath volume, which is only backed up with restic if the backup enables hostPath volumes, skipping", volumeName, pod.Namespace, pod.Name)
+				continue
+			}
+
+			log.Warnf("Volume %s in pod %s/%s is a hostPath volume: backing up %s on node %s. Its contents may be shared with other pods on the node, and it won't be restored automatically",
+				volumeName, pod.Namespace, pod.Name, podVolumes[volumeName].HostPath.Path, pod.Spec.NodeName)
+			volumeBackup.Spec.HostPath = podVolumes[volumeName].HostPath.Path
+			hostPathVolumes[volumeName] = true
+		}
 
 		if err := errorOnly(b.repoManager.arkClient.ArkV1().PodVolumeBackups(volumeBackup.Namespace).Create(volumeBackup)); err != nil {
 			errs = append(errs, err)
@@ -143,13 +170,27 @@ func (b *backupper) BackupPodVolumes(backup *arkv1api.Backup, pod *corev1api.Pod
 		}
 
 		volumeSnapshots[volumeName] = ""
+		totalTimeout += timeout
 	}
 
+	// each volume's backup is timed out by the restic daemonset, so only give up
+	// on results once the volumes have had time to be backed up one after another.
+	timer := time.NewTimer(totalTimeout + volumeResultGracePeriod)
+	defer timer.Stop()
+
 ForEachVolume:
 	for i, count := 0, len(volumeSnapshots); i < count; i++ {
 		select {
 		case <-b.ctx.Done():
-			errs = append(errs, errors.New("timed out waiting for all PodVolumeBackups to complete"))
+			errs = append(errs, errors.Wrap(b.ctx.Err(), "stopped waiting for PodVolumeBackups to complete"))
+			break ForEachVolume
+		case <-timer.C:
+			for volumeName, snapshotID := range volumeSnapshots {
+				if snapshotID == "" {
+					errs = append(errs, errors.Errorf("timed out waiting for pod volume backup of volume %s to complete", volumeName))
+					delete(volumeSnapshots, volumeName)
+				}
+			}
 			break ForEachVolume
 		case res := <-resultsChan:
 			switch res.Status.Phase {
@@ -158,6 +199,9 @@ ForEachVolume:
 			case arkv1api.PodVolumeBackupPhaseFailed:
 				errs = append(errs, errors.Errorf("pod volume backup failed: %s", res.Status.Message))
 				delete(volumeSnapshots, res.Spec.Volume)
+			case arkv1api.PodVolumeBackupPhaseTimedOut:
+				errs = append(errs, errors.Errorf("pod volume backup of volume %s timed out: %s", res.Spec.Volume, res.Status.Message))
+				delete(volumeSnapshots, res.Spec.Volume)
 			}
 		}
 	}
@@ -166,9 +210,25 @@ ForEachVolume:
 	delete(b.results, resultsKey(pod.Namespace, pod.Name))
 	b.resultsLock.Unlock()
 
+	// hostPath volumes can't be restored into the pod's volume directory, so
+	// their snapshots aren't returned for recording on the pod. They're still
+	// available from the backup's PodVolumeBackups.
+	for volumeName := range hostPathVolumes {
+		delete(volumeSnapshots, volumeName)
+	}
+
 	return volumeSnapshots, errs
 }
 
+func isPodVolumeBackupDone(pvb *arkv1api.PodVolumeBackup) bool {
+	switch pvb.Status.Phase {
+	case arkv1api.PodVolumeBackupPhaseCompleted, arkv1api.PodVolumeBackupPhaseFailed, arkv1api.PodVolumeBackupPhaseTimedOut:
+		return true
+	default:
+		return false
+	}
+}
+
 func volumeExists(podVolumes map[string]corev1api.Volume, volumeName string) bool {
 	_, found := podVolumes[volumeName]
 	return found
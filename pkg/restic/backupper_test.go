@@ -21,6 +21,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1api "k8s.io/api/core/v1"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
 )
 
 func TestVolumeExists(t *testing.T) {
@@ -48,3 +50,27 @@ func TestIsHostPathVolume(t *testing.T) {
 	assert.False(t, isHostPathVolume(podVolumes, "bar"))
 	assert.False(t, isHostPathVolume(podVolumes, "non-existent volume"))
 }
+
+func TestIsPodVolumeBackupDone(t *testing.T) {
+	tests := []struct {
+		phase    arkv1api.PodVolumeBackupPhase
+		expected bool
+	}{
+		{phase: "", expected: false},
+		{phase: arkv1api.PodVolumeBackupPhaseNew, expected: false},
+		{phase: arkv1api.PodVolumeBackupPhaseInProgress, expected: false},
+		{phase: arkv1api.PodVolumeBackupPhaseCompleted, expected: true},
+		{phase: arkv1api.PodVolumeBackupPhaseFailed, expected: true},
+		{phase: arkv1api.PodVolumeBackupPhaseTimedOut, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.phase), func(t *testing.T) {
+			pvb := &arkv1api.PodVolumeBackup{
+				Status: arkv1api.PodVolumeBackupStatus{Phase: test.phase},
+			}
+
+			assert.Equal(t, test.expected, isPodVolumeBackupDone(pvb))
+		})
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package restic
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -45,9 +47,15 @@ func (c *Command) RepoName() string {
 
 // StringSlice returns the command as a slice of strings.
 func (c *Command) StringSlice() []string {
-	res := []string{"restic"}
+	return c.stringSlice(true)
+}
+
+func (c *Command) stringSlice(withRepoFlag bool) []string {
+	res := []string{"restic", c.Command}
 
-	res = append(res, c.Command, repoFlag(c.RepoIdentifier))
+	if withRepoFlag {
+		res = append(res, repoFlag(c.RepoIdentifier))
+	}
 	if c.PasswordFile != "" {
 		res = append(res, passwordFlag(c.PasswordFile))
 	}
@@ -72,17 +80,62 @@ func (c *Command) String() string {
 
 // Cmd returns an exec.Cmd for the command.
 func (c *Command) Cmd() *exec.Cmd {
-	parts := c.StringSlice()
-	cmd := exec.Command(parts[0], parts[1:]...)
+	return c.CmdContext(context.Background())
+}
+
+// CmdContext returns an exec.Cmd for the command that's killed if ctx
+// is done before the command completes.
+func (c *Command) CmdContext(ctx context.Context) *exec.Cmd {
+	repo, hasCredentials := restRepoWithCredentials(c.RepoIdentifier)
+
+	// REST server credentials have to be part of the repository URL, so
+	// pass it through the environment rather than on the command line.
+	parts := c.stringSlice(!hasCredentials)
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
 	cmd.Dir = c.Dir
 
 	if len(c.Env) > 0 {
 		cmd.Env = c.Env
 	}
 
+	if hasCredentials {
+		if len(cmd.Env) == 0 {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", repositoryEnvVar, repo))
+	}
+
 	return cmd
 }
 
+const (
+	repositoryEnvVar   = "RESTIC_REPOSITORY"
+	restUsernameEnvVar = "RESTIC_REST_USERNAME"
+	restPasswordEnvVar = "RESTIC_REST_PASSWORD"
+)
+
+// restRepoWithCredentials returns the repository identifier with the REST server
+// username and password from the environment added to it, and true if repoIdentifier
+// is for a REST server and credentials are set.
+func restRepoWithCredentials(repoIdentifier string) (string, bool) {
+	if !strings.HasPrefix(repoIdentifier, restBackendPrefix) {
+		return "", false
+	}
+
+	username, password := os.Getenv(restUsernameEnvVar), os.Getenv(restPasswordEnvVar)
+	if username == "" {
+		return "", false
+	}
+
+	u, err := url.Parse(strings.TrimPrefix(repoIdentifier, restBackendPrefix))
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	u.User = url.UserPassword(username, password)
+
+	return restBackendPrefix + u.String(), true
+}
+
 func repoFlag(repoIdentifier string) string {
 	return fmt.Sprintf("--repo=%s", repoIdentifier)
 }
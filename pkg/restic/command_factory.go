@@ -21,20 +21,32 @@ import (
 	"strings"
 )
 
-// BackupCommand returns a Command for running a restic backup.
-func BackupCommand(repoIdentifier, passwordFile, path string, tags map[string]string) *Command {
+// BackupCommand returns a Command for running a restic backup. If includePaths
+// is non-empty, only those paths within path are backed up. Files matching any
+// of excludePatterns are skipped.
+func BackupCommand(repoIdentifier, passwordFile, path string, tags map[string]string, includePaths, excludePatterns []string) *Command {
 	// --hostname flag is provided with a generic value because restic uses the hostname
 	// to find a parent snapshot, and by default it will be the name of the daemonset pod
 	// where the `restic backup` command is run. If this pod is recreated, we want to continue
 	// taking incremental backups rather than triggering a full one due to a new pod name.
 
+	args := []string{"."}
+	if len(includePaths) > 0 {
+		args = includePaths
+	}
+
+	extraFlags := append(backupTagFlags(tags), "--hostname=ark")
+	for _, pattern := range excludePatterns {
+		extraFlags = append(extraFlags, fmt.Sprintf("--exclude=%s", pattern))
+	}
+
 	return &Command{
 		Command:        "backup",
 		RepoIdentifier: repoIdentifier,
 		PasswordFile:   passwordFile,
 		Dir:            path,
-		Args:           []string{"."},
-		ExtraFlags:     append(backupTagFlags(tags), "--hostname=ark"),
+		Args:           args,
+		ExtraFlags:     extraFlags,
 	}
 }
 
@@ -58,6 +70,18 @@ func RestoreCommand(repoIdentifier, passwordFile, snapshotID, target string) *Co
 	}
 }
 
+// ListFilesCommand returns a Command for running a restic ls, which lists
+// the contents of a snapshot as JSON.
+func ListFilesCommand(repoIdentifier, passwordFile, snapshotID string) *Command {
+	return &Command{
+		Command:        "ls",
+		RepoIdentifier: repoIdentifier,
+		PasswordFile:   passwordFile,
+		Args:           []string{snapshotID},
+		ExtraFlags:     []string{"--json"},
+	}
+}
+
 // GetSnapshotCommand returns a Command for running a restic (get) snapshots.
 func GetSnapshotCommand(repoIdentifier, passwordFile string, tags map[string]string) *Command {
 	return &Command{
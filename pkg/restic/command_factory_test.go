@@ -24,7 +24,7 @@ import (
 )
 
 func TestBackupCommand(t *testing.T) {
-	c := BackupCommand("repo-id", "password-file", "path", map[string]string{"foo": "bar", "c": "d"})
+	c := BackupCommand("repo-id", "password-file", "path", map[string]string{"foo": "bar", "c": "d"}, nil, nil)
 
 	assert.Equal(t, "backup", c.Command)
 	assert.Equal(t, "repo-id", c.RepoIdentifier)
@@ -38,6 +38,13 @@ func TestBackupCommand(t *testing.T) {
 	assert.Equal(t, expected, c.ExtraFlags)
 }
 
+func TestBackupCommandWithFilters(t *testing.T) {
+	c := BackupCommand("repo-id", "password-file", "path", nil, []string{"data", "config"}, []string{"*.log", "tmp"})
+
+	assert.Equal(t, []string{"data", "config"}, c.Args)
+	assert.Equal(t, []string{"--hostname=ark", "--exclude=*.log", "--exclude=tmp"}, c.ExtraFlags)
+}
+
 func TestRestoreCommand(t *testing.T) {
 	c := RestoreCommand("repo-id", "password-file", "snapshot-id", "target")
 
@@ -49,6 +56,16 @@ func TestRestoreCommand(t *testing.T) {
 	assert.Equal(t, []string{"--target=."}, c.ExtraFlags)
 }
 
+func TestListFilesCommand(t *testing.T) {
+	c := ListFilesCommand("repo-id", "password-file", "snapshot-id")
+
+	assert.Equal(t, "ls", c.Command)
+	assert.Equal(t, "repo-id", c.RepoIdentifier)
+	assert.Equal(t, "password-file", c.PasswordFile)
+	assert.Equal(t, []string{"snapshot-id"}, c.Args)
+	assert.Equal(t, []string{"--json"}, c.ExtraFlags)
+}
+
 func TestGetSnapshotCommand(t *testing.T) {
 	expectedTags := map[string]string{"foo": "bar", "c": "d"}
 	c := GetSnapshotCommand("repo-id", "password-file", expectedTags)
@@ -19,6 +19,7 @@ package restic
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -38,8 +39,25 @@ const (
 	InitContainer               = "restic-wait"
 	DefaultMaintenanceFrequency = 24 * time.Hour
 
+	// volumeResultGracePeriod is how long to wait for the results of a pod's
+	// volume backups or restores beyond the sum of their timeouts, which the
+	// restic daemonset enforces.
+	volumeResultGracePeriod = time.Minute
+
 	podAnnotationPrefix       = "snapshot.ark.heptio.com/"
 	volumesToBackupAnnotation = "backup.ark.heptio.com/backup-volumes"
+
+	// includePathsAnnotationPrefix and excludePatternsAnnotationPrefix are
+	// followed by a volume name, and their values are comma-separated lists
+	// of paths within the volume to back up, and restic exclude patterns,
+	// respectively.
+	includePathsAnnotationPrefix    = "include.backup.ark.heptio.com/"
+	excludePatternsAnnotationPrefix = "exclude.backup.ark.heptio.com/"
+
+	// volumeTimeoutAnnotationPrefix is followed by a volume name, and its
+	// value is how long restic backups and restores of the volume are
+	// allowed to run.
+	volumeTimeoutAnnotationPrefix = "timeout.ark.heptio.com/"
 )
 
 // PodHasSnapshotAnnotation returns true if the object has an annotation
@@ -103,6 +121,56 @@ func GetVolumesToBackup(obj metav1.Object) []string {
 	return strings.Split(backupsValue, ",")
 }
 
+// GetVolumeBackupFilters returns the paths to back up within the specified
+// volume, and the restic patterns for files to exclude from its backup, from
+// the provided pod's annotations. Include paths must be relative to the volume
+// and can't refer outside of it.
+func GetVolumeBackupFilters(obj metav1.Object, volumeName string) (includePaths []string, excludePatterns []string, err error) {
+	annotations := obj.GetAnnotations()
+
+	for _, path := range splitAnnotationList(annotations[includePathsAnnotationPrefix+volumeName]) {
+		cleaned := filepath.Clean(path)
+		if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, nil, errors.Errorf("include path %q for volume %s must be relative to the volume", path, volumeName)
+		}
+		includePaths = append(includePaths, cleaned)
+	}
+
+	excludePatterns = splitAnnotationList(annotations[excludePatternsAnnotationPrefix+volumeName])
+
+	return includePaths, excludePatterns, nil
+}
+
+// GetVolumeTimeout returns how long restic backups and restores of the specified
+// volume are allowed to run, from the provided pod's annotations. If the pod
+// doesn't have a timeout annotation for the volume, defaultTimeout is returned.
+func GetVolumeTimeout(obj metav1.Object, volumeName string, defaultTimeout time.Duration) (time.Duration, error) {
+	val := obj.GetAnnotations()[volumeTimeoutAnnotationPrefix+volumeName]
+	if val == "" {
+		return defaultTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(val)
+	if err != nil {
+		return defaultTimeout, errors.Wrapf(err, "invalid timeout for volume %s", volumeName)
+	}
+	if timeout <= 0 {
+		return defaultTimeout, errors.Errorf("invalid timeout for volume %s: must be positive", volumeName)
+	}
+
+	return timeout, nil
+}
+
+func splitAnnotationList(value string) []string {
+	var res []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
 // SnapshotIdentifier uniquely identifies a restic snapshot
 // taken by Ark.
 type SnapshotIdentifier struct {
@@ -146,17 +214,25 @@ func GetSnapshotsInBackup(backup *arkv1api.Backup, podVolumeBackupLister arkv1li
 }
 
 // TempCredentialsFile creates a temp file containing a restic
-// encryption key for the given repo and returns its path. The
-// caller should generally call os.Remove() to remove the file
-// when done with it.
-func TempCredentialsFile(secretLister corev1listers.SecretLister, arkNamespace, repoName string, fs filesystem.Interface) (string, error) {
+// encryption key for the given repo in the given backup storage
+// location and returns its path. The caller should generally call
+// os.Remove() to remove the file when done with it.
+func TempCredentialsFile(
+	secretLister corev1listers.SecretLister,
+	backupLocationLister arkv1listers.BackupStorageLocationLister,
+	arkNamespace, backupLocation, repoName string,
+	fs filesystem.Interface,
+) (string, error) {
 	secretGetter := NewListerSecretGetter(secretLister)
 
-	// For now, all restic repos share the same key so we don't need the repoName to fetch it.
-	// When we move to full-backup encryption, we'll likely have a separate key per restic repo
-	// (all within the Ark server's namespace) so GetRepositoryKey will need to take the repo
-	// name as an argument as well.
-	repoKey, err := GetRepositoryKey(secretGetter, arkNamespace)
+	// all of a backup storage location's repos share the same key, which is the
+	// common key unless the location selects its own
+	loc, err := backupLocationLister.BackupStorageLocations(arkNamespace).Get(backupLocation)
+	if err != nil {
+		return "", errors.Wrap(err, "error getting backup storage location")
+	}
+
+	repoKey, err := GetRepositoryKey(secretGetter, arkNamespace, loc.Spec.ResticCredentials)
 	if err != nil {
 		return "", err
 	}
@@ -19,6 +19,7 @@ package restic
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -213,6 +214,123 @@ func TestGetVolumesToBackup(t *testing.T) {
 	}
 }
 
+func TestGetVolumeBackupFilters(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		expectedIncludes []string
+		expectedExcludes []string
+		expectedErr      bool
+	}{
+		{
+			name:        "nil annotations",
+			annotations: nil,
+		},
+		{
+			name: "filters for other volumes are ignored",
+			annotations: map[string]string{
+				includePathsAnnotationPrefix + "volume-2":    "data",
+				excludePatternsAnnotationPrefix + "volume-2": "*.log",
+			},
+		},
+		{
+			name: "includes and excludes are split and trimmed",
+			annotations: map[string]string{
+				includePathsAnnotationPrefix + "volume-1":    "data, config/",
+				excludePatternsAnnotationPrefix + "volume-1": "*.log, tmp,",
+			},
+			expectedIncludes: []string{"data", "config"},
+			expectedExcludes: []string{"*.log", "tmp"},
+		},
+		{
+			name: "absolute include path is an error",
+			annotations: map[string]string{
+				includePathsAnnotationPrefix + "volume-1": "/etc",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "include path outside the volume is an error",
+			annotations: map[string]string{
+				includePathsAnnotationPrefix + "volume-1": "data/../../other-volume",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &corev1api.Pod{}
+			pod.Annotations = test.annotations
+
+			includes, excludes, err := GetVolumeBackupFilters(pod, "volume-1")
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedIncludes, includes)
+			assert.Equal(t, test.expectedExcludes, excludes)
+		})
+	}
+}
+
+func TestGetVolumeTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{
+			name:     "no annotation returns the default",
+			expected: time.Hour,
+		},
+		{
+			name: "timeouts for other volumes are ignored",
+			annotations: map[string]string{
+				volumeTimeoutAnnotationPrefix + "volume-2": "5m",
+			},
+			expected: time.Hour,
+		},
+		{
+			name: "annotation overrides the default",
+			annotations: map[string]string{
+				volumeTimeoutAnnotationPrefix + "volume-1": "4h",
+			},
+			expected: 4 * time.Hour,
+		},
+		{
+			name: "invalid duration is an error",
+			annotations: map[string]string{
+				volumeTimeoutAnnotationPrefix + "volume-1": "forever",
+			},
+			expected:    time.Hour,
+			expectedErr: true,
+		},
+		{
+			name: "non-positive duration is an error",
+			annotations: map[string]string{
+				volumeTimeoutAnnotationPrefix + "volume-1": "0s",
+			},
+			expected:    time.Hour,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &corev1api.Pod{}
+			pod.Annotations = test.annotations
+
+			timeout, err := GetVolumeTimeout(pod, "volume-1", time.Hour)
+			assert.Equal(t, test.expectedErr, err != nil)
+			assert.Equal(t, test.expected, timeout)
+		})
+	}
+}
+
 func TestGetSnapshotsInBackup(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -335,10 +453,12 @@ func TestGetSnapshotsInBackup(t *testing.T) {
 
 func TestTempCredentialsFile(t *testing.T) {
 	var (
-		secretInformer = cache.NewSharedIndexInformer(nil, new(corev1api.Secret), 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
-		secretLister   = corev1listers.NewSecretLister(secretInformer.GetIndexer())
-		fs             = arktest.NewFakeFileSystem()
-		secret         = &corev1api.Secret{
+		secretInformer  = cache.NewSharedIndexInformer(nil, new(corev1api.Secret), 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		secretLister    = corev1listers.NewSecretLister(secretInformer.GetIndexer())
+		sharedInformers = informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+		locationLister  = sharedInformers.Ark().V1().BackupStorageLocations().Lister()
+		fs              = arktest.NewFakeFileSystem()
+		secret          = &corev1api.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: "heptio-ark",
 				Name:      CredentialsSecretName,
@@ -347,21 +467,58 @@ func TestTempCredentialsFile(t *testing.T) {
 				CredentialsKey: []byte("passw0rd"),
 			},
 		}
+		prodSecret = &corev1api.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "heptio-ark",
+				Name:      "prod-restic-credentials",
+			},
+			Data: map[string][]byte{
+				"password": []byte("pr0d-passw0rd"),
+			},
+		}
+		location     = arktest.NewTestBackupStorageLocation().WithNamespace("heptio-ark").WithName("default").BackupStorageLocation
+		prodLocation = arktest.NewTestBackupStorageLocation().WithNamespace("heptio-ark").WithName("prod").BackupStorageLocation
 	)
+	prodLocation.Spec.ResticCredentials = &corev1api.SecretKeySelector{
+		LocalObjectReference: corev1api.LocalObjectReference{Name: prodSecret.Name},
+		Key:                  "password",
+	}
+
+	// location not in lister: expect an error
+	_, err := TempCredentialsFile(secretLister, locationLister, "heptio-ark", "default", "ns-1", fs)
+	assert.Error(t, err)
+
+	require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(location))
+	require.NoError(t, sharedInformers.Ark().V1().BackupStorageLocations().Informer().GetStore().Add(prodLocation))
 
 	// secret not in lister: expect an error
-	fileName, err := TempCredentialsFile(secretLister, "heptio-ark", "default", fs)
+	_, err = TempCredentialsFile(secretLister, locationLister, "heptio-ark", "default", "ns-1", fs)
 	assert.Error(t, err)
 
-	// now add secret to lister
+	// now add secrets to lister
 	require.NoError(t, secretInformer.GetStore().Add(secret))
+	require.NoError(t, secretInformer.GetStore().Add(prodSecret))
 
-	// secret in lister: expect temp file to be created with password
-	fileName, err = TempCredentialsFile(secretLister, "heptio-ark", "default", fs)
+	// location without credentials: expect temp file to be created with the common password
+	fileName, err := TempCredentialsFile(secretLister, locationLister, "heptio-ark", "default", "ns-1", fs)
 	require.NoError(t, err)
 
 	contents, err := fs.ReadFile(fileName)
 	require.NoError(t, err)
 
 	assert.Equal(t, "passw0rd", string(contents))
+
+	// location with credentials: expect temp file to be created with the location's password
+	fileName, err = TempCredentialsFile(secretLister, locationLister, "heptio-ark", "prod", "ns-1", fs)
+	require.NoError(t, err)
+
+	contents, err = fs.ReadFile(fileName)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pr0d-passw0rd", string(contents))
+
+	// location whose credentials secret is missing the key: expect an error
+	prodLocation.Spec.ResticCredentials.Key = "missing"
+	_, err = TempCredentialsFile(secretLister, locationLister, "heptio-ark", "prod", "ns-1", fs)
+	assert.EqualError(t, err, `"prod-restic-credentials" secret is missing data for key "missing"`)
 }
@@ -21,6 +21,8 @@ import (
 	"path"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
 	"github.com/heptio/ark/pkg/cloudprovider/aws"
 	"github.com/heptio/ark/pkg/persistence"
@@ -34,6 +36,17 @@ const (
 	GCPBackend   BackendType = "gcp"
 )
 
+const (
+	// ResticRepoPrefixConfigKey is the BackupStorageLocation config key for
+	// storing the location's restic repositories on a restic REST server or
+	// an SFTP server rather than in the location's object storage, e.g.
+	// "rest:https://restic.example.com:8000/ark" or "sftp:user@host:/srv/ark".
+	ResticRepoPrefixConfigKey = "resticRepoPrefix"
+
+	restBackendPrefix = "rest:"
+	sftpBackendPrefix = "sftp:"
+)
+
 // this func is assigned to a package-level variable so it can be
 // replaced when unit-testing
 var getAWSBucketRegion = aws.GetBucketRegion
@@ -43,6 +56,10 @@ var getAWSBucketRegion = aws.GetBucketRegion
 func getRepoPrefix(location *arkv1api.BackupStorageLocation) string {
 	var provider, bucket, prefix, bucketAndPrefix string
 
+	if repoPrefix := location.Spec.Config[ResticRepoPrefixConfigKey]; repoPrefix != "" {
+		return repoPrefix
+	}
+
 	if location.Spec.ObjectStorage != nil {
 		layout := persistence.NewObjectStoreLayout(location.Spec.ObjectStorage.Prefix)
 
@@ -78,6 +95,22 @@ func getRepoPrefix(location *arkv1api.BackupStorageLocation) string {
 	return fmt.Sprintf("%s:%s:/%s", provider, bucket, prefix)
 }
 
+// ValidateRepoPrefix returns an error if the location's restic repository
+// prefix config is set to something other than a restic REST or SFTP
+// repository.
+func ValidateRepoPrefix(location *arkv1api.BackupStorageLocation) error {
+	repoPrefix := location.Spec.Config[ResticRepoPrefixConfigKey]
+	if repoPrefix == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(repoPrefix, restBackendPrefix) && !strings.HasPrefix(repoPrefix, sftpBackendPrefix) {
+		return errors.Errorf("%s must start with %q or %q", ResticRepoPrefixConfigKey, restBackendPrefix, sftpBackendPrefix)
+	}
+
+	return nil
+}
+
 // GetRepoIdentifier returns the string to be used as the value of the --repo flag in
 // restic commands for the given repository.
 func GetRepoIdentifier(location *arkv1api.BackupStorageLocation, name string) string {
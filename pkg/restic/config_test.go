@@ -115,4 +115,62 @@ func TestGetRepoIdentifier(t *testing.T) {
 		},
 	}
 	assert.Equal(t, "gs:bucket-2:/prefix-2/restic/repo-2", GetRepoIdentifier(backupLocation, "repo-2"))
+
+	backupLocation = &arkv1api.BackupStorageLocation{
+		Spec: arkv1api.BackupStorageLocationSpec{
+			Provider: "aws",
+			Config: map[string]string{
+				ResticRepoPrefixConfigKey: "rest:https://restic.example.com:8000/ark",
+			},
+			StorageType: arkv1api.StorageType{
+				ObjectStorage: &arkv1api.ObjectStorageLocation{
+					Bucket: "bucket",
+				},
+			},
+		},
+	}
+	assert.Equal(t, "rest:https://restic.example.com:8000/ark/repo-1", GetRepoIdentifier(backupLocation, "repo-1"))
+
+	backupLocation.Spec.Config[ResticRepoPrefixConfigKey] = "sftp:user@host:/srv/ark/"
+	assert.Equal(t, "sftp:user@host:/srv/ark/repo-1", GetRepoIdentifier(backupLocation, "repo-1"))
+}
+
+func TestValidateRepoPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]string
+		expectedErr bool
+	}{
+		{
+			name:        "no config is valid",
+			config:      nil,
+			expectedErr: false,
+		},
+		{
+			name:        "rest prefix is valid",
+			config:      map[string]string{ResticRepoPrefixConfigKey: "rest:http://host:8000/"},
+			expectedErr: false,
+		},
+		{
+			name:        "sftp prefix is valid",
+			config:      map[string]string{ResticRepoPrefixConfigKey: "sftp:user@host:/srv/ark"},
+			expectedErr: false,
+		},
+		{
+			name:        "other backend is invalid",
+			config:      map[string]string{ResticRepoPrefixConfigKey: "b2:bucket:/ark"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location := &arkv1api.BackupStorageLocation{
+				Spec: arkv1api.BackupStorageLocationSpec{Config: test.config},
+			}
+
+			err := ValidateRepoPrefix(location)
+			assert.Equal(t, test.expectedErr, err != nil)
+		})
+	}
 }
@@ -0,0 +1,169 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"fmt"
+
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/buildinfo"
+)
+
+// InitContainerImage returns the image of the container that waits for
+// the restic restores of a pod's volumes to complete.
+func InitContainerImage() string {
+	tag := buildinfo.Version
+	if tag == "" {
+		tag = "latest"
+	}
+
+	// TODO allow full image URL to be overriden via CLI flag.
+	return fmt.Sprintf("gcr.io/heptio-images/ark-restic-restore-helper:%s", tag)
+}
+
+// SplitPodSnapshotAnnotations returns the restic snapshots for a pod's volumes,
+// as maps of volume name -> snapshot id, split into the snapshots of volumes
+// that are backed by a persistent volume claim and the snapshots of all other
+// volumes. Volumes backed by a claim are restored using a separate data mover
+// pod, before the pod itself is restored, so that the claim can be bound and
+// its data restored without waiting for the pod to be scheduled. All other
+// volumes are restored into the pod itself.
+func SplitPodSnapshotAnnotations(pod *corev1api.Pod) (claimSnapshots, podSnapshots map[string]string) {
+	claims := make(map[string]bool)
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			claims[volume.Name] = true
+		}
+	}
+
+	for volume, snapshot := range GetPodSnapshotAnnotations(pod) {
+		if claims[volume] {
+			if claimSnapshots == nil {
+				claimSnapshots = make(map[string]string)
+			}
+			claimSnapshots[volume] = snapshot
+			continue
+		}
+
+		if podSnapshots == nil {
+			podSnapshots = make(map[string]string)
+		}
+		podSnapshots[volume] = snapshot
+	}
+
+	return claimSnapshots, podSnapshots
+}
+
+// NewRestoreHelperContainer returns a container that waits for the restic
+// restores of the named volumes, by the restore or data download with the
+// given UID, to complete.
+func NewRestoreHelperContainer(name string, uid types.UID, volumes []string) corev1api.Container {
+	container := corev1api.Container{
+		Name:  name,
+		Image: InitContainerImage(),
+		Args:  []string{string(uid)},
+		Env: []corev1api.EnvVar{
+			{
+				Name: "POD_NAMESPACE",
+				ValueFrom: &corev1api.EnvVarSource{
+					FieldRef: &corev1api.ObjectFieldSelector{
+						FieldPath: "metadata.namespace",
+					},
+				},
+			},
+			{
+				Name: "POD_NAME",
+				ValueFrom: &corev1api.EnvVarSource{
+					FieldRef: &corev1api.ObjectFieldSelector{
+						FieldPath: "metadata.name",
+					},
+				},
+			},
+		},
+	}
+
+	for _, volume := range volumes {
+		container.VolumeMounts = append(container.VolumeMounts, corev1api.VolumeMount{
+			Name:      volume,
+			MountPath: "/restores/" + volume,
+		})
+	}
+
+	return container
+}
+
+// NewDataMoverPod returns a pod that mounts the named persistent volume claim
+// as volume, so the volume's data can be restored into it. The pod runs the
+// restic restore helper as its init container, the same as restored pods do,
+// so its volume is restored by the pod volume restore controller once a
+// PodVolumeRestore is created for it. Its only other container exits as soon
+// as the init container completes.
+func NewDataMoverPod(namespace, claimName, volume string, uid types.UID, labels map[string]string) *corev1api.Pod {
+	return &corev1api.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    namespace,
+			GenerateName: "ark-restore-" + claimName + "-",
+			Labels:       labels,
+		},
+		Spec: corev1api.PodSpec{
+			RestartPolicy: corev1api.RestartPolicyNever,
+			Volumes: []corev1api.Volume{
+				{
+					Name: volume,
+					VolumeSource: corev1api.VolumeSource{
+						PersistentVolumeClaim: &corev1api.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				},
+			},
+			InitContainers: []corev1api.Container{
+				NewRestoreHelperContainer(InitContainer, uid, []string{volume}),
+			},
+			Containers: []corev1api.Container{
+				NewRestoreHelperContainer("done", uid, []string{volume}),
+			},
+		},
+	}
+}
+
+// newDataMoverPod returns a data mover pod for the restore of one of pod's
+// volumes, which is backed by a persistent volume claim.
+func newDataMoverPod(restore *arkv1api.Restore, pod *corev1api.Pod, volume corev1api.Volume) *corev1api.Pod {
+	dataMover := NewDataMoverPod(
+		pod.Namespace,
+		volume.PersistentVolumeClaim.ClaimName,
+		volume.Name,
+		restore.UID,
+		map[string]string{
+			arkv1api.RestoreNameLabel: restore.Name,
+			arkv1api.RestoreUIDLabel:  string(restore.UID),
+		},
+	)
+
+	// schedule the pod where the restored pod could run, so the claim's
+	// volume is provisioned somewhere the restored pod can use it
+	dataMover.Spec.NodeSelector = pod.Spec.NodeSelector
+	dataMover.Spec.Affinity = pod.Spec.Affinity
+	dataMover.Spec.Tolerations = pod.Spec.Tolerations
+
+	return dataMover
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+func TestSplitPodSnapshotAnnotations(t *testing.T) {
+	tests := []struct {
+		name                   string
+		annotations            map[string]string
+		volumes                []corev1api.Volume
+		expectedClaimSnapshots map[string]string
+		expectedPodSnapshots   map[string]string
+	}{
+		{
+			name: "no snapshot annotations returns nil maps",
+		},
+		{
+			name: "snapshots are split by whether the volume is backed by a claim",
+			annotations: map[string]string{
+				podAnnotationPrefix + "data":    "snap-1",
+				podAnnotationPrefix + "scratch": "snap-2",
+			},
+			volumes: []corev1api.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1api.VolumeSource{
+						PersistentVolumeClaim: &corev1api.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-1"},
+					},
+				},
+				{
+					Name: "scratch",
+					VolumeSource: corev1api.VolumeSource{
+						EmptyDir: &corev1api.EmptyDirVolumeSource{},
+					},
+				},
+			},
+			expectedClaimSnapshots: map[string]string{"data": "snap-1"},
+			expectedPodSnapshots:   map[string]string{"scratch": "snap-2"},
+		},
+		{
+			name: "snapshots of volumes not in the pod's spec are restored into the pod",
+			annotations: map[string]string{
+				podAnnotationPrefix + "data": "snap-1",
+			},
+			expectedPodSnapshots: map[string]string{"data": "snap-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &corev1api.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations},
+				Spec:       corev1api.PodSpec{Volumes: test.volumes},
+			}
+
+			claimSnapshots, podSnapshots := SplitPodSnapshotAnnotations(pod)
+
+			assert.Equal(t, test.expectedClaimSnapshots, claimSnapshots)
+			assert.Equal(t, test.expectedPodSnapshots, podSnapshots)
+		})
+	}
+}
+
+func TestNewDataMoverPod(t *testing.T) {
+	restore := &arkv1api.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: arkv1api.DefaultNamespace,
+			Name:      "restore-1",
+			UID:       "restore-uid",
+		},
+	}
+
+	pod := &corev1api.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-1",
+			Name:      "pod-1",
+		},
+		Spec: corev1api.PodSpec{
+			NodeSelector: map[string]string{"zone": "a"},
+			Tolerations:  []corev1api.Toleration{{Key: "dedicated", Operator: corev1api.TolerationOpExists}},
+		},
+	}
+
+	volume := corev1api.Volume{
+		Name: "data",
+		VolumeSource: corev1api.VolumeSource{
+			PersistentVolumeClaim: &corev1api.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-1", ReadOnly: true},
+		},
+	}
+
+	res := newDataMoverPod(restore, pod, volume)
+
+	assert.Equal(t, "ns-1", res.Namespace)
+	assert.Equal(t, "ark-restore-pvc-1-", res.GenerateName)
+	assert.Equal(t, "restore-uid", res.Labels[arkv1api.RestoreUIDLabel])
+	assert.Equal(t, pod.Spec.NodeSelector, res.Spec.NodeSelector)
+	assert.Equal(t, pod.Spec.Tolerations, res.Spec.Tolerations)
+	assert.Equal(t, corev1api.RestartPolicyNever, res.Spec.RestartPolicy)
+
+	// the claim must be mounted read-write so its data can be restored
+	require.Len(t, res.Spec.Volumes, 1)
+	assert.Equal(t, "data", res.Spec.Volumes[0].Name)
+	assert.Equal(t, &corev1api.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-1"}, res.Spec.Volumes[0].PersistentVolumeClaim)
+
+	// the pod volume restore controller only restores into pods running the
+	// restic init container
+	require.Len(t, res.Spec.InitContainers, 1)
+	assert.Equal(t, InitContainer, res.Spec.InitContainers[0].Name)
+	assert.Equal(t, []string{"restore-uid"}, res.Spec.InitContainers[0].Args)
+	assert.Equal(t, []corev1api.VolumeMount{{Name: "data", MountPath: "/restores/data"}}, res.Spec.InitContainers[0].VolumeMounts)
+	require.Len(t, res.Spec.Containers, 1)
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package restic
 
 import (
+	"bufio"
 	"encoding/json"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -53,3 +55,55 @@ func GetSnapshotID(repoIdentifier, passwordFile string, tags map[string]string,
 
 	return snapshots[0].ShortID, nil
 }
+
+// ListSnapshotFiles runs a 'restic ls' command to get the regular files
+// contained in the specified snapshot.
+func ListSnapshotFiles(repoIdentifier, passwordFile, snapshotID string, env []string) ([]SnapshotFile, error) {
+	cmd := ListFilesCommand(repoIdentifier, passwordFile, snapshotID)
+	if len(env) > 0 {
+		cmd.Env = env
+	}
+
+	stdout, stderr, err := exec.RunCommand(cmd.Cmd())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error running command, stderr=%s", stderr)
+	}
+
+	return parseSnapshotFiles(stdout)
+}
+
+// parseSnapshotFiles parses the output of 'restic ls --json', which is
+// one JSON object per line: first the snapshot, then each of its nodes.
+func parseSnapshotFiles(output string) ([]SnapshotFile, error) {
+	type node struct {
+		Type string `json:"type"`
+		Path string `json:"path"`
+		Size int64  `json:"size"`
+	}
+
+	var files []SnapshotFile
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var n node
+		if err := json.Unmarshal([]byte(line), &n); err != nil {
+			return nil, errors.Wrap(err, "error unmarshalling restic ls result")
+		}
+
+		if n.Type != "file" {
+			continue
+		}
+
+		files = append(files, SnapshotFile{Path: strings.TrimPrefix(n.Path, "/"), Size: n.Size})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error reading restic ls result")
+	}
+
+	return files, nil
+}
@@ -39,14 +39,27 @@ type repositoryEnsurer struct {
 	repoClient arkv1client.ResticRepositoriesGetter
 
 	readyChansLock sync.Mutex
-	readyChans     map[string]chan *arkv1api.ResticRepository
+	readyChans     map[repoKey]chan *arkv1api.ResticRepository
+
+	// repoLocksMu synchronizes reads/writes to the repoLocks map itself
+	// since maps are not threadsafe.
+	repoLocksMu sync.Mutex
+	repoLocks   map[repoKey]*sync.Mutex
+}
+
+// repoKey uniquely identifies a restic repository: each workload namespace
+// has its own repository in each backup storage location.
+type repoKey struct {
+	volumeNamespace string
+	backupLocation  string
 }
 
 func newRepositoryEnsurer(repoInformer arkv1informers.ResticRepositoryInformer, repoClient arkv1client.ResticRepositoriesGetter, log logrus.FieldLogger) *repositoryEnsurer {
 	r := &repositoryEnsurer{
 		repoLister: repoInformer.Lister(),
 		repoClient: repoClient,
-		readyChans: make(map[string]chan *arkv1api.ResticRepository),
+		readyChans: make(map[repoKey]chan *arkv1api.ResticRepository),
+		repoLocks:  make(map[repoKey]*sync.Mutex),
 	}
 
 	repoInformer.Informer().AddEventHandler(
@@ -59,15 +72,18 @@ func newRepositoryEnsurer(repoInformer arkv1informers.ResticRepositoryInformer,
 					r.readyChansLock.Lock()
 					defer r.readyChansLock.Unlock()
 
-					key := repoLabels(newObj.Spec.VolumeNamespace, newObj.Spec.BackupStorageLocation).String()
+					key := repoKey{
+						volumeNamespace: newObj.Spec.VolumeNamespace,
+						backupLocation:  newObj.Spec.BackupStorageLocation,
+					}
 					readyChan, ok := r.readyChans[key]
 					if !ok {
-						log.Errorf("No ready channel found for repository %s/%s", newObj.Namespace, newObj.Name)
+						log.Debugf("No ready channel found for repository %s/%s", newObj.Namespace, newObj.Name)
 						return
 					}
 
 					readyChan <- newObj
-					delete(r.readyChans, newObj.Name)
+					delete(r.readyChans, key)
 				}
 			},
 		},
@@ -84,6 +100,21 @@ func repoLabels(volumeNamespace, backupLocation string) labels.Set {
 }
 
 func (r *repositoryEnsurer) EnsureRepo(ctx context.Context, namespace, volumeNamespace, backupLocation string) (*arkv1api.ResticRepository, error) {
+	if volumeNamespace == "" || backupLocation == "" {
+		return nil, errors.Errorf("wrong parameters, namespace %q, backup storage location %q", volumeNamespace, backupLocation)
+	}
+
+	key := repoKey{
+		volumeNamespace: volumeNamespace,
+		backupLocation:  backupLocation,
+	}
+
+	// don't let multiple goroutines check for or create the same
+	// repository concurrently.
+	repoMu := r.repoLock(key)
+	repoMu.Lock()
+	defer repoMu.Unlock()
+
 	selector := labels.SelectorFromSet(repoLabels(volumeNamespace, backupLocation))
 
 	repos, err := r.repoLister.ResticRepositories(namespace).List(selector)
@@ -115,8 +146,12 @@ func (r *repositoryEnsurer) EnsureRepo(ctx context.Context, namespace, volumeNam
 		},
 	}
 
-	readyChan := r.getReadyChan(selector.String())
-	defer close(readyChan)
+	readyChan := r.getReadyChan(key)
+	defer func() {
+		r.readyChansLock.Lock()
+		delete(r.readyChans, key)
+		r.readyChansLock.Unlock()
+	}()
 
 	if _, err := r.repoClient.ResticRepositories(namespace).Create(repo); err != nil {
 		return nil, errors.Wrapf(err, "unable to create restic repository resource")
@@ -130,10 +165,77 @@ func (r *repositoryEnsurer) EnsureRepo(ctx context.Context, namespace, volumeNam
 	}
 }
 
-func (r *repositoryEnsurer) getReadyChan(name string) chan *arkv1api.ResticRepository {
+func (r *repositoryEnsurer) getReadyChan(key repoKey) chan *arkv1api.ResticRepository {
 	r.readyChansLock.Lock()
 	defer r.readyChansLock.Unlock()
 
-	r.readyChans[name] = make(chan *arkv1api.ResticRepository)
-	return r.readyChans[name]
+	// buffered so the informer's event handler never blocks on a
+	// caller that has already given up waiting.
+	r.readyChans[key] = make(chan *arkv1api.ResticRepository, 1)
+	return r.readyChans[key]
+}
+
+// repoLock returns a per-repository lock, creating it if it doesn't
+// already exist.
+func (r *repositoryEnsurer) repoLock(key repoKey) *sync.Mutex {
+	r.repoLocksMu.Lock()
+	defer r.repoLocksMu.Unlock()
+
+	if _, ok := r.repoLocks[key]; !ok {
+		r.repoLocks[key] = new(sync.Mutex)
+	}
+
+	return r.repoLocks[key]
+}
+
+// MigrateRepositories labels any ResticRepositories created before repositories
+// were identified by both workload namespace and backup storage location, so
+// that they're found by lookups rather than duplicated. Repositories without a
+// backup storage location are assigned defaultBackupLocation.
+func MigrateRepositories(repoClient arkv1client.ResticRepositoriesGetter, namespace, defaultBackupLocation string, log logrus.FieldLogger) error {
+	repos, err := repoClient.ResticRepositories(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for i := range repos.Items {
+		repo := repos.Items[i].DeepCopy()
+		changed := false
+
+		if repo.Spec.VolumeNamespace == "" {
+			// repositories used to be named after the workload namespace
+			repo.Spec.VolumeNamespace = repo.Name
+			changed = true
+		}
+		if repo.Spec.BackupStorageLocation == "" {
+			repo.Spec.BackupStorageLocation = defaultBackupLocation
+			changed = true
+		}
+
+		if repo.Labels == nil {
+			repo.Labels = make(map[string]string)
+		}
+		for k, v := range repoLabels(repo.Spec.VolumeNamespace, repo.Spec.BackupStorageLocation) {
+			if repo.Labels[k] != v {
+				repo.Labels[k] = v
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		log.WithFields(logrus.Fields{
+			"repository":            repo.Name,
+			"volumeNamespace":       repo.Spec.VolumeNamespace,
+			"backupStorageLocation": repo.Spec.BackupStorageLocation,
+		}).Info("Migrating restic repository")
+
+		if _, err := repoClient.ResticRepositories(namespace).Update(repo); err != nil {
+			return errors.Wrapf(err, "error migrating restic repository %s", repo.Name)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,96 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestMigrateRepositories(t *testing.T) {
+	tests := []struct {
+		name           string
+		repo           *arkv1api.ResticRepository
+		expectedSpec   arkv1api.ResticRepositorySpec
+		expectedLabels map[string]string
+	}{
+		{
+			name: "repo named after its namespace with no location gets both",
+			repo: &arkv1api.ResticRepository{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "heptio-ark", Name: "ns-1"},
+			},
+			expectedSpec: arkv1api.ResticRepositorySpec{VolumeNamespace: "ns-1", BackupStorageLocation: "default"},
+			expectedLabels: map[string]string{
+				arkv1api.ResticVolumeNamespaceLabel: "ns-1",
+				arkv1api.StorageLocationLabel:       "default",
+			},
+		},
+		{
+			name: "repo with spec but no labels gets labels from spec",
+			repo: &arkv1api.ResticRepository{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "heptio-ark", Name: "ns-1-abcde", Labels: map[string]string{"foo": "bar"}},
+				Spec:       arkv1api.ResticRepositorySpec{VolumeNamespace: "ns-1", BackupStorageLocation: "loc-1"},
+			},
+			expectedSpec: arkv1api.ResticRepositorySpec{VolumeNamespace: "ns-1", BackupStorageLocation: "loc-1"},
+			expectedLabels: map[string]string{
+				"foo":                               "bar",
+				arkv1api.ResticVolumeNamespaceLabel: "ns-1",
+				arkv1api.StorageLocationLabel:       "loc-1",
+			},
+		},
+		{
+			name: "already-labeled repo is unchanged",
+			repo: &arkv1api.ResticRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "heptio-ark",
+					Name:      "ns-1-loc-1-abcde",
+					Labels: map[string]string{
+						arkv1api.ResticVolumeNamespaceLabel: "ns-1",
+						arkv1api.StorageLocationLabel:       "loc-1",
+					},
+				},
+				Spec: arkv1api.ResticRepositorySpec{VolumeNamespace: "ns-1", BackupStorageLocation: "loc-1"},
+			},
+			expectedSpec: arkv1api.ResticRepositorySpec{VolumeNamespace: "ns-1", BackupStorageLocation: "loc-1"},
+			expectedLabels: map[string]string{
+				arkv1api.ResticVolumeNamespaceLabel: "ns-1",
+				arkv1api.StorageLocationLabel:       "loc-1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(test.repo)
+
+			require.NoError(t, MigrateRepositories(client.ArkV1(), "heptio-ark", "default", arktest.NewLogger()))
+
+			res, err := client.ArkV1().ResticRepositories("heptio-ark").Get(test.repo.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedSpec, res.Spec)
+			assert.Equal(t, test.expectedLabels, res.Labels)
+		})
+	}
+}
@@ -97,15 +97,22 @@ func (l *listerSecretGetter) GetSecret(namespace, name string) (*corev1api.Secre
 	return secret, nil
 }
 
-func GetRepositoryKey(secretGetter SecretGetter, namespace string) ([]byte, error) {
-	secret, err := secretGetter.GetSecret(namespace, CredentialsSecretName)
+// GetRepositoryKey returns the password of restic repositories from the key of the secret in
+// namespace that credentials selects, or from the common repository key if credentials is nil.
+func GetRepositoryKey(secretGetter SecretGetter, namespace string, credentials *corev1api.SecretKeySelector) ([]byte, error) {
+	secretName, secretKey := CredentialsSecretName, CredentialsKey
+	if credentials != nil {
+		secretName, secretKey = credentials.Name, credentials.Key
+	}
+
+	secret, err := secretGetter.GetSecret(namespace, secretName)
 	if err != nil {
 		return nil, err
 	}
 
-	key, found := secret.Data[CredentialsKey]
+	key, found := secret.Data[secretKey]
 	if !found {
-		return nil, errors.Errorf("%q secret is missing data for key %q", CredentialsSecretName, CredentialsKey)
+		return nil, errors.Errorf("%q secret is missing data for key %q", secretName, secretKey)
 	}
 
 	return key, nil
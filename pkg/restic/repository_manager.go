@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -60,20 +62,23 @@ type RepositoryManager interface {
 // BackupperFactory can construct restic backuppers.
 type BackupperFactory interface {
 	// NewBackupper returns a restic backupper for use during a single
-	// Ark backup.
-	NewBackupper(context.Context, *arkv1api.Backup) (Backupper, error)
+	// Ark backup. Volumes without a timeout annotation are allowed to
+	// run for volumeTimeout.
+	NewBackupper(ctx context.Context, backup *arkv1api.Backup, volumeTimeout time.Duration) (Backupper, error)
 }
 
 // RestorerFactory can construct restic restorers.
 type RestorerFactory interface {
 	// NewRestorer returns a restic restorer for use during a single
-	// Ark restore.
-	NewRestorer(context.Context, *arkv1api.Restore) (Restorer, error)
+	// Ark restore. Volumes without a timeout annotation are allowed to
+	// run for volumeTimeout.
+	NewRestorer(ctx context.Context, restore *arkv1api.Restore, volumeTimeout time.Duration) (Restorer, error)
 }
 
 type repositoryManager struct {
 	namespace                    string
 	arkClient                    clientset.Interface
+	podClient                    corev1client.PodsGetter
 	secretsLister                corev1listers.SecretLister
 	repoLister                   arkv1listers.ResticRepositoryLister
 	repoInformerSynced           cache.InformerSynced
@@ -91,6 +96,7 @@ func NewRepositoryManager(
 	ctx context.Context,
 	namespace string,
 	arkClient clientset.Interface,
+	podClient corev1client.PodsGetter,
 	secretsInformer cache.SharedIndexInformer,
 	repoInformer arkv1informers.ResticRepositoryInformer,
 	repoClient arkv1client.ResticRepositoriesGetter,
@@ -100,6 +106,7 @@ func NewRepositoryManager(
 	rm := &repositoryManager{
 		namespace:                    namespace,
 		arkClient:                    arkClient,
+		podClient:                    podClient,
 		secretsLister:                corev1listers.NewSecretLister(secretsInformer.GetIndexer()),
 		repoLister:                   repoInformer.Lister(),
 		repoInformerSynced:           repoInformer.Informer().HasSynced,
@@ -120,7 +127,7 @@ func NewRepositoryManager(
 	return rm, nil
 }
 
-func (rm *repositoryManager) NewBackupper(ctx context.Context, backup *arkv1api.Backup) (Backupper, error) {
+func (rm *repositoryManager) NewBackupper(ctx context.Context, backup *arkv1api.Backup, volumeTimeout time.Duration) (Backupper, error) {
 	informer := arkv1informers.NewFilteredPodVolumeBackupInformer(
 		rm.arkClient,
 		backup.Namespace,
@@ -131,7 +138,7 @@ func (rm *repositoryManager) NewBackupper(ctx context.Context, backup *arkv1api.
 		},
 	)
 
-	b := newBackupper(ctx, rm, rm.repoEnsurer, informer, rm.log)
+	b := newBackupper(ctx, rm, rm.repoEnsurer, informer, volumeTimeout, rm.log)
 
 	go informer.Run(ctx.Done())
 	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced, rm.repoInformerSynced) {
@@ -141,7 +148,7 @@ func (rm *repositoryManager) NewBackupper(ctx context.Context, backup *arkv1api.
 	return b, nil
 }
 
-func (rm *repositoryManager) NewRestorer(ctx context.Context, restore *arkv1api.Restore) (Restorer, error) {
+func (rm *repositoryManager) NewRestorer(ctx context.Context, restore *arkv1api.Restore, volumeTimeout time.Duration) (Restorer, error) {
 	informer := arkv1informers.NewFilteredPodVolumeRestoreInformer(
 		rm.arkClient,
 		restore.Namespace,
@@ -152,7 +159,7 @@ func (rm *repositoryManager) NewRestorer(ctx context.Context, restore *arkv1api.
 		},
 	)
 
-	r := newRestorer(ctx, rm, rm.repoEnsurer, informer, rm.log)
+	r := newRestorer(ctx, rm, rm.repoEnsurer, informer, volumeTimeout, rm.log)
 
 	go informer.Run(ctx.Done())
 	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced, rm.repoInformerSynced) {
@@ -208,7 +215,12 @@ func (rm *repositoryManager) Forget(ctx context.Context, snapshot SnapshotIdenti
 }
 
 func (rm *repositoryManager) exec(cmd *Command, backupLocation string) error {
-	file, err := TempCredentialsFile(rm.secretsLister, rm.namespace, cmd.RepoName(), rm.fileSystem)
+	// the location is needed to get the repo's password, and its config for azure
+	if !cache.WaitForCacheSync(rm.ctx.Done(), rm.backupLocationInformerSynced) {
+		return errors.New("timed out waiting for cache to sync")
+	}
+
+	file, err := TempCredentialsFile(rm.secretsLister, rm.backupLocationLister, rm.namespace, backupLocation, cmd.RepoName(), rm.fileSystem)
 	if err != nil {
 		return err
 	}
@@ -218,10 +230,6 @@ func (rm *repositoryManager) exec(cmd *Command, backupLocation string) error {
 	cmd.PasswordFile = file
 
 	if strings.HasPrefix(cmd.RepoIdentifier, "azure") {
-		if !cache.WaitForCacheSync(rm.ctx.Done(), rm.backupLocationInformerSynced) {
-			return errors.New("timed out waiting for cache to sync")
-		}
-
 		env, err := AzureCmdEnv(rm.backupLocationLister, rm.namespace, backupLocation)
 		if err != nil {
 			return err
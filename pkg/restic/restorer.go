@@ -19,10 +19,12 @@ package restic
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	corev1api "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -32,14 +34,21 @@ import (
 
 // Restorer can execute restic restores of volumes in a pod.
 type Restorer interface {
-	// RestorePodVolumes restores all annotated volumes in a pod.
+	// RestorePodVolumes restores all annotated volumes in a pod that aren't
+	// backed by a persistent volume claim.
 	RestorePodVolumes(restore *arkv1api.Restore, pod *corev1api.Pod, sourceNamespace, backupLocation string, log logrus.FieldLogger) []error
+
+	// RestoreClaimVolumes restores all annotated volumes in a pod that are
+	// backed by a persistent volume claim, using a data mover pod for each
+	// claim. It must be called before the pod itself is created.
+	RestoreClaimVolumes(restore *arkv1api.Restore, pod *corev1api.Pod, sourceNamespace, backupLocation string, log logrus.FieldLogger) []error
 }
 
 type restorer struct {
-	ctx         context.Context
-	repoManager *repositoryManager
-	repoEnsurer *repositoryEnsurer
+	ctx           context.Context
+	repoManager   *repositoryManager
+	repoEnsurer   *repositoryEnsurer
+	volumeTimeout time.Duration
 
 	resultsLock sync.Mutex
 	results     map[string]chan *arkv1api.PodVolumeRestore
@@ -50,12 +59,14 @@ func newRestorer(
 	rm *repositoryManager,
 	repoEnsurer *repositoryEnsurer,
 	podVolumeRestoreInformer cache.SharedIndexInformer,
+	volumeTimeout time.Duration,
 	log logrus.FieldLogger,
 ) *restorer {
 	r := &restorer{
-		ctx:         ctx,
-		repoManager: rm,
-		repoEnsurer: repoEnsurer,
+		ctx:           ctx,
+		repoManager:   rm,
+		repoEnsurer:   repoEnsurer,
+		volumeTimeout: volumeTimeout,
 
 		results: make(map[string]chan *arkv1api.PodVolumeRestore),
 	}
@@ -65,7 +76,7 @@ func newRestorer(
 			UpdateFunc: func(_, obj interface{}) {
 				pvr := obj.(*arkv1api.PodVolumeRestore)
 
-				if pvr.Status.Phase == arkv1api.PodVolumeRestorePhaseCompleted || pvr.Status.Phase == arkv1api.PodVolumeRestorePhaseFailed {
+				if isPodVolumeRestoreDone(pvr) {
 					r.resultsLock.Lock()
 					defer r.resultsLock.Unlock()
 
@@ -85,11 +96,67 @@ func newRestorer(
 
 func (r *restorer) RestorePodVolumes(restore *arkv1api.Restore, pod *corev1api.Pod, sourceNamespace, backupLocation string, log logrus.FieldLogger) []error {
 	// get volumes to restore from pod's annotations
-	volumesToRestore := GetPodSnapshotAnnotations(pod)
+	_, volumesToRestore := SplitPodSnapshotAnnotations(pod)
 	if len(volumesToRestore) == 0 {
 		return nil
 	}
 
+	return r.restoreVolumes(restore, pod, volumesToRestore, r.volumeTimeouts(pod, volumesToRestore, log), sourceNamespace, backupLocation)
+}
+
+func (r *restorer) RestoreClaimVolumes(restore *arkv1api.Restore, pod *corev1api.Pod, sourceNamespace, backupLocation string, log logrus.FieldLogger) []error {
+	claimSnapshots, _ := SplitPodSnapshotAnnotations(pod)
+	if len(claimSnapshots) == 0 {
+		return nil
+	}
+
+	var errs []error
+
+	for _, volume := range pod.Spec.Volumes {
+		snapshot, ok := claimSnapshots[volume.Name]
+		if !ok {
+			continue
+		}
+
+		claim := volume.PersistentVolumeClaim.ClaimName
+
+		dataMover, err := r.repoManager.podClient.Pods(pod.Namespace).Create(newDataMoverPod(restore, pod, volume))
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error creating data mover pod for persistent volume claim %s/%s", pod.Namespace, claim))
+			continue
+		}
+		log.Infof("Restoring persistent volume claim %s/%s using data mover pod %s", pod.Namespace, claim, dataMover.Name)
+
+		volumesToRestore := map[string]string{volume.Name: snapshot}
+		errs = append(errs, r.restoreVolumes(restore, dataMover, volumesToRestore, r.volumeTimeouts(pod, volumesToRestore, log), sourceNamespace, backupLocation)...)
+
+		if err := r.repoManager.podClient.Pods(dataMover.Namespace).Delete(dataMover.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "error deleting data mover pod %s/%s", dataMover.Namespace, dataMover.Name))
+		}
+	}
+
+	return errs
+}
+
+// volumeTimeouts returns how long the restore of each of volumes is allowed to
+// run, from the pod's timeout annotations.
+func (r *restorer) volumeTimeouts(pod *corev1api.Pod, volumes map[string]string, log logrus.FieldLogger) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(volumes))
+
+	for volume := range volumes {
+		timeout, err := GetVolumeTimeout(pod, volume, r.volumeTimeout)
+		if err != nil {
+			log.WithError(err).Warnf("Invalid timeout annotation for volume %s in pod %s/%s, using %s", volume, pod.Namespace, pod.Name, timeout)
+		}
+		timeouts[volume] = timeout
+	}
+
+	return timeouts
+}
+
+// restoreVolumes creates a PodVolumeRestore for each of the pod's volumes in
+// volumesToRestore, and waits for them all to complete.
+func (r *restorer) restoreVolumes(restore *arkv1api.Restore, pod *corev1api.Pod, volumesToRestore map[string]string, timeouts map[string]time.Duration, sourceNamespace, backupLocation string) []error {
 	repo, err := r.repoEnsurer.EnsureRepo(r.ctx, restore.Namespace, sourceNamespace, backupLocation)
 	if err != nil {
 		return []error{err}
@@ -107,29 +174,47 @@ func (r *restorer) RestorePodVolumes(restore *arkv1api.Restore, pod *corev1api.P
 	r.resultsLock.Unlock()
 
 	var (
-		errs        []error
-		numRestores int
+		errs         []error
+		pending      = make(map[string]bool)
+		totalTimeout time.Duration
 	)
 
 	for volume, snapshot := range volumesToRestore {
 		volumeRestore := newPodVolumeRestore(restore, pod, volume, snapshot, backupLocation, repo.Spec.ResticIdentifier)
+		volumeRestore.Spec.Timeout = metav1.Duration{Duration: timeouts[volume]}
 
 		if err := errorOnly(r.repoManager.arkClient.ArkV1().PodVolumeRestores(volumeRestore.Namespace).Create(volumeRestore)); err != nil {
 			errs = append(errs, errors.WithStack(err))
 			continue
 		}
-		numRestores++
+		pending[volume] = true
+		totalTimeout += timeouts[volume]
 	}
 
+	// each volume's restore is timed out by the restic daemonset, so only give up
+	// on results once the volumes have had time to be restored one after another.
+	timer := time.NewTimer(totalTimeout + volumeResultGracePeriod)
+	defer timer.Stop()
+
 ForEachVolume:
-	for i := 0; i < numRestores; i++ {
+	for i, count := 0, len(pending); i < count; i++ {
 		select {
 		case <-r.ctx.Done():
-			errs = append(errs, errors.New("timed out waiting for all PodVolumeRestores to complete"))
+			errs = append(errs, errors.Wrap(r.ctx.Err(), "stopped waiting for PodVolumeRestores to complete"))
+			break ForEachVolume
+		case <-timer.C:
+			for volume := range pending {
+				errs = append(errs, errors.Errorf("timed out waiting for pod volume restore of volume %s to complete", volume))
+			}
 			break ForEachVolume
 		case res := <-resultsChan:
-			if res.Status.Phase == arkv1api.PodVolumeRestorePhaseFailed {
+			delete(pending, res.Spec.Volume)
+
+			switch res.Status.Phase {
+			case arkv1api.PodVolumeRestorePhaseFailed:
 				errs = append(errs, errors.Errorf("pod volume restore failed: %s", res.Status.Message))
+			case arkv1api.PodVolumeRestorePhaseTimedOut:
+				errs = append(errs, errors.Errorf("pod volume restore of volume %s timed out: %s", res.Spec.Volume, res.Status.Message))
 			}
 		}
 	}
@@ -141,6 +226,15 @@ ForEachVolume:
 	return errs
 }
 
+func isPodVolumeRestoreDone(pvr *arkv1api.PodVolumeRestore) bool {
+	switch pvr.Status.Phase {
+	case arkv1api.PodVolumeRestorePhaseCompleted, arkv1api.PodVolumeRestorePhaseFailed, arkv1api.PodVolumeRestorePhaseTimedOut:
+		return true
+	default:
+		return false
+	}
+}
+
 func newPodVolumeRestore(restore *arkv1api.Restore, pod *corev1api.Pod, volume, snapshot, backupLocation, repoIdentifier string) *arkv1api.PodVolumeRestore {
 	return &arkv1api.PodVolumeRestore{
 		ObjectMeta: metav1.ObjectMeta{
@@ -172,6 +266,7 @@ func newPodVolumeRestore(restore *arkv1api.Restore, pod *corev1api.Pod, volume,
 			SnapshotID:            snapshot,
 			BackupStorageLocation: backupLocation,
 			RepoIdentifier:        repoIdentifier,
+			Verify:                restore.Spec.VerifyPodVolumes,
 		},
 	}
 }
@@ -0,0 +1,117 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	arkexec "github.com/heptio/ark/pkg/util/exec"
+)
+
+const (
+	// maxCommandAttempts is how many times a restic backup or restore that
+	// fails with a transient error is run before giving up.
+	maxCommandAttempts = 3
+
+	// commandRetryBackoff is how long to wait before retrying a restic
+	// command for the first time. It doubles before each further retry.
+	commandRetryBackoff = 10 * time.Second
+)
+
+// transientErrorMessages are parts of restic's error output that indicate a
+// failure which may not happen again if the command is re-run, such as a
+// network error talking to the repository's storage or a stale lock.
+var transientErrorMessages = []string{
+	"connection reset by peer",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"unexpected EOF",
+	"no such host",
+	"server misbehaving",
+	"500 Internal Server Error",
+	"502 Bad Gateway",
+	"503 Service Unavailable",
+	"504 Gateway Timeout",
+	"SlowDown",
+	"RequestTimeout",
+	"repository is already locked",
+}
+
+// IsRetriableError returns true if err, returned from running a restic command
+// whose error output is stderr, is a transient failure that's worth retrying.
+func IsRetriableError(err error, stderr string) bool {
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return false
+	}
+
+	// restic exits with 1 when it fails. Any other status, e.g. from
+	// being killed, isn't retried.
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || status.ExitStatus() != 1 {
+		return false
+	}
+
+	for _, msg := range transientErrorMessages {
+		if strings.Contains(stderr, msg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RunCommandWithRetries runs cmd, running it again after a backoff if it fails with
+// a transient error, up to a limited number of attempts. onRetry is called with the
+// number of the upcoming attempt before each retry. The stdout, stderr and error of
+// the last attempt are returned, along with the number of attempts.
+func RunCommandWithRetries(ctx context.Context, cmd *Command, onRetry func(attempt int)) (string, string, int, error) {
+	return runCommandWithRetries(ctx, cmd, maxCommandAttempts, commandRetryBackoff, arkexec.RunCommand, onRetry)
+}
+
+func runCommandWithRetries(
+	ctx context.Context,
+	cmd *Command,
+	maxAttempts int,
+	backoff time.Duration,
+	run func(*exec.Cmd) (string, string, error),
+	onRetry func(attempt int),
+) (stdout, stderr string, attempt int, err error) {
+	for attempt = 1; ; attempt++ {
+		stdout, stderr, err = run(cmd.CmdContext(ctx))
+		if err == nil || attempt >= maxAttempts || ctx.Err() != nil || !IsRetriableError(err, stderr) {
+			return stdout, stderr, attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return stdout, stderr, attempt, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+
+		onRetry(attempt + 1)
+	}
+}
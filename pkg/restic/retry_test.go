@@ -0,0 +1,147 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exitError returns the error from running a command that exits with code.
+func exitError(t *testing.T, code string) error {
+	err := exec.Command("sh", "-c", "exit "+code).Run()
+	require.IsType(t, &exec.ExitError{}, err)
+	return err
+}
+
+func TestIsRetriableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		stderr   string
+		expected bool
+	}{
+		{
+			name:     "network error is retriable",
+			err:      exitError(t, "1"),
+			stderr:   "Fatal: unable to open repository: read tcp 10.0.0.1:443: connection reset by peer",
+			expected: true,
+		},
+		{
+			name:     "wrapped exit error is retriable",
+			err:      errors.Wrap(exitError(t, "1"), "error running restic"),
+			stderr:   "Fatal: unable to create lock in backend: repository is already locked by PID 12",
+			expected: true,
+		},
+		{
+			name:   "other failures aren't retriable",
+			err:    exitError(t, "1"),
+			stderr: "Fatal: wrong password or no key found",
+		},
+		{
+			name:   "other exit codes aren't retriable",
+			err:    exitError(t, "2"),
+			stderr: "connection reset by peer",
+		},
+		{
+			name:   "errors that aren't exit errors aren't retriable",
+			err:    errors.New("exec: \"restic\": executable file not found in $PATH"),
+			stderr: "connection reset by peer",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsRetriableError(test.err, test.stderr))
+		})
+	}
+}
+
+func TestRunCommandWithRetries(t *testing.T) {
+	transientErr := exitError(t, "1")
+
+	tests := []struct {
+		name             string
+		results          []error
+		stderr           string
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{
+			name:             "success on the first attempt",
+			results:          []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "success after a transient failure",
+			results:          []error{transientErr, nil},
+			stderr:           "503 Service Unavailable",
+			expectedAttempts: 2,
+		},
+		{
+			name:             "transient failures stop at the attempt limit",
+			results:          []error{transientErr, transientErr, transientErr, nil},
+			stderr:           "503 Service Unavailable",
+			expectedAttempts: 3,
+			expectedErr:      true,
+		},
+		{
+			name:             "other failures aren't retried",
+			results:          []error{transientErr, nil},
+			stderr:           "Fatal: wrong password or no key found",
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				runs    int
+				retries []int
+			)
+
+			run := func(*exec.Cmd) (string, string, error) {
+				err := test.results[runs]
+				runs++
+				return "", test.stderr, err
+			}
+
+			_, _, attempts, err := runCommandWithRetries(
+				context.Background(),
+				RestoreCommand("repo-id", "password-file", "snapshot-id", "target"),
+				3,
+				0,
+				run,
+				func(attempt int) { retries = append(retries, attempt) },
+			)
+
+			assert.Equal(t, test.expectedAttempts, attempts)
+			assert.Equal(t, test.expectedAttempts, runs)
+			assert.Equal(t, test.expectedErr, err != nil)
+			for i, attempt := range retries {
+				assert.Equal(t, i+2, attempt)
+			}
+			assert.Len(t, retries, test.expectedAttempts-1)
+		})
+	}
+}
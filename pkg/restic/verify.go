@@ -0,0 +1,76 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/util/filesystem"
+)
+
+// maxVerificationMismatches is the maximum number of mismatched files
+// recorded in a PodVolumeRestoreVerification.
+const maxVerificationMismatches = 10
+
+// SnapshotFile is a regular file contained in a restic snapshot.
+type SnapshotFile struct {
+	// Path is the file's path, relative to the root of the snapshot.
+	Path string
+
+	// Size is the file's size in bytes.
+	Size int64
+}
+
+// VerifyRestoredFiles checks that each of the snapshot's files exists under target
+// with the same size, returning a summary of the result. Files within the snapshot's
+// .ark directory are skipped since it's removed from restored volumes.
+func VerifyRestoredFiles(fileSystem filesystem.Interface, target string, files []SnapshotFile) *arkv1api.PodVolumeRestoreVerification {
+	verification := new(arkv1api.PodVolumeRestoreVerification)
+
+	for _, file := range files {
+		if file.Path == ".ark" || strings.HasPrefix(file.Path, ".ark/") {
+			continue
+		}
+
+		verification.SnapshotFiles++
+		verification.SnapshotBytes += file.Size
+
+		mismatch := ""
+		info, err := fileSystem.Stat(filepath.Join(target, file.Path))
+		switch {
+		case os.IsNotExist(err):
+			mismatch = fmt.Sprintf("%s: missing", file.Path)
+		case err != nil:
+			mismatch = fmt.Sprintf("%s: %v", file.Path, err)
+		case info.Size() != file.Size:
+			mismatch = fmt.Sprintf("%s: expected %d bytes, got %d", file.Path, file.Size, info.Size())
+		default:
+			verification.VerifiedFiles++
+			verification.VerifiedBytes += info.Size()
+		}
+
+		if mismatch != "" && len(verification.Mismatches) < maxVerificationMismatches {
+			verification.Mismatches = append(verification.Mismatches, mismatch)
+		}
+	}
+
+	return verification
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestParseSnapshotFiles(t *testing.T) {
+	output := `{"time":"2018-10-01T12:00:00Z","tree":"abc","paths":["/"],"hostname":"ark","id":"123","short_id":"123"}
+{"name":"data","type":"dir","path":"/data","size":0}
+{"name":"a.txt","type":"file","path":"/data/a.txt","size":5}
+{"name":"link","type":"symlink","path":"/data/link"}
+{"name":"b.txt","type":"file","path":"/b.txt","size":10}
+`
+
+	files, err := parseSnapshotFiles(output)
+	require.NoError(t, err)
+	assert.Equal(t, []SnapshotFile{
+		{Path: "data/a.txt", Size: 5},
+		{Path: "b.txt", Size: 10},
+	}, files)
+
+	_, err = parseSnapshotFiles("not json")
+	assert.Error(t, err)
+}
+
+func TestVerifyRestoredFiles(t *testing.T) {
+	fileSystem := arktest.NewFakeFileSystem().
+		WithFile("/target/data/a.txt", []byte("hello")).
+		WithFile("/target/b.txt", []byte("short"))
+
+	files := []SnapshotFile{
+		{Path: "data/a.txt", Size: 5},
+		{Path: "b.txt", Size: 10},
+		{Path: "c.txt", Size: 1},
+		{Path: ".ark/done", Size: 0},
+	}
+
+	verification := VerifyRestoredFiles(fileSystem, "/target", files)
+
+	assert.Equal(t, &arkv1api.PodVolumeRestoreVerification{
+		SnapshotFiles: 3,
+		SnapshotBytes: 16,
+		VerifiedFiles: 1,
+		VerifiedBytes: 5,
+		Mismatches: []string{
+			"b.txt: expected 10 bytes, got 5",
+			"c.txt: missing",
+		},
+	}, verification)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// fieldDiffs returns the fields whose values differ between the in-cluster and
+// backed up versions of an object, sorted by path. It's computed from the JSON
+// merge patch that would update the in-cluster version to the backed up one, so
+// lists are compared as a whole rather than item by item.
+func fieldDiffs(fromCluster, fromBackup *unstructured.Unstructured) ([]api.FieldDiff, error) {
+	patchBytes, err := generatePatch(fromCluster, fromBackup)
+	if err != nil {
+		return nil, err
+	}
+	if patchBytes == nil {
+		return nil, nil
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal(patchBytes, &patch); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal patch")
+	}
+
+	var diffs []api.FieldDiff
+	if err := collectFieldDiffs(nil, patch, fromCluster.Object, &diffs); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+
+	return diffs, nil
+}
+
+// collectFieldDiffs walks a JSON merge patch, adding a FieldDiff for each field it
+// changes. Nested objects in the patch are descended into; anything else replaces
+// the in-cluster value entirely, and a null value removes it.
+func collectFieldDiffs(path []string, patch map[string]interface{}, fromCluster map[string]interface{}, diffs *[]api.FieldDiff) error {
+	for key, patchVal := range patch {
+		fieldPath := append(append([]string(nil), path...), key)
+		clusterVal, inCluster := fromCluster[key]
+
+		if nestedPatch, ok := patchVal.(map[string]interface{}); ok {
+			if nestedCluster, ok := clusterVal.(map[string]interface{}); ok {
+				if err := collectFieldDiffs(fieldPath, nestedPatch, nestedCluster, diffs); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		diff := api.FieldDiff{Path: strings.Join(fieldPath, ".")}
+
+		if patchVal != nil {
+			val, err := json.Marshal(patchVal)
+			if err != nil {
+				return errors.Wrapf(err, "unable to marshal backed up value of %s", diff.Path)
+			}
+			diff.Backup = string(val)
+		}
+
+		if inCluster {
+			val, err := json.Marshal(clusterVal)
+			if err != nil {
+				return errors.Wrapf(err, "unable to marshal in-cluster value of %s", diff.Path)
+			}
+			diff.Cluster = string(val)
+		}
+
+		*diffs = append(*diffs, diff)
+	}
+
+	return nil
+}
+
+// fieldPaths returns the paths of the given field diffs.
+func fieldPaths(diffs []api.FieldDiff) []string {
+	var paths []string
+	for _, diff := range diffs {
+		paths = append(paths, diff.Path)
+	}
+	return paths
+}
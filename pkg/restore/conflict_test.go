@@ -0,0 +1,69 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestFieldDiffs(t *testing.T) {
+	tests := []struct {
+		name        string
+		fromCluster string
+		fromBackup  string
+		expected    []api.FieldDiff
+	}{
+		{
+			name:        "equal objects have no diffs",
+			fromCluster: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"},"data":{"a":"1"}}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"},"data":{"a":"1"}}`,
+		},
+		{
+			name:        "changed, added, and removed fields are reported",
+			fromCluster: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1","labels":{"foo":"bar"}},"data":{"a":"1","b":"2"}}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"},"data":{"a":"3","c":"4"}}`,
+			expected: []api.FieldDiff{
+				{Path: "data.a", Backup: `"3"`, Cluster: `"1"`},
+				{Path: "data.b", Cluster: `"2"`},
+				{Path: "data.c", Backup: `"4"`},
+				{Path: "metadata.labels", Cluster: `{"foo":"bar"}`},
+			},
+		},
+		{
+			name:        "lists are compared as a whole",
+			fromCluster: `{"apiVersion":"v1","kind":"Service","metadata":{"name":"svc-1"},"spec":{"ports":[{"port":80}]}}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"Service","metadata":{"name":"svc-1"},"spec":{"ports":[{"port":8080}]}}`,
+			expected: []api.FieldDiff{
+				{Path: "spec.ports", Backup: `[{"port":8080}]`, Cluster: `[{"port":80}]`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diffs, err := fieldDiffs(arktest.UnstructuredOrDie(test.fromCluster), arktest.UnstructuredOrDie(test.fromBackup))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, diffs)
+		})
+	}
+}
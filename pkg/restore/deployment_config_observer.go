@@ -0,0 +1,92 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+)
+
+var deploymentConfigsGVR = kuberesource.DeploymentConfigs.WithVersion("v1")
+
+// deploymentConfigRollouts is an Observer that rolls out the OpenShift deployment
+// configs that restores create, if OpenShift won't roll them out itself.
+type deploymentConfigRollouts struct {
+	dynamicClient dynamic.Interface
+	log           logrus.FieldLogger
+}
+
+// NewDeploymentConfigRolloutObserver returns an Observer that starts a rollout of
+// each OpenShift deployment config that a restore creates without a config change
+// trigger, which would otherwise only be rolled out once an image it's triggered by
+// changes, or never if it has no triggers.
+func NewDeploymentConfigRolloutObserver(dynamicClient dynamic.Interface, logger logrus.FieldLogger) Observer {
+	return &deploymentConfigRollouts{
+		dynamicClient: dynamicClient,
+		log:           logger,
+	}
+}
+
+func (o *deploymentConfigRollouts) OnPhaseChange(restore *api.Restore, phase api.RestorePhase) {}
+
+func (o *deploymentConfigRollouts) OnItemRestored(restore *api.Restore, groupResource schema.GroupResource, namespace, name string) {
+	// OpenShift 3 also serves deployment configs in the core group
+	if groupResource != kuberesource.DeploymentConfigs && groupResource != (schema.GroupResource{Resource: kuberesource.DeploymentConfigs.Resource}) {
+		return
+	}
+
+	log := o.log.WithField("restore", restore.Namespace+"/"+restore.Name).WithField("deploymentConfig", namespace+"/"+name)
+	client := o.dynamicClient.Resource(deploymentConfigsGVR).Namespace(namespace)
+
+	deploymentConfig, err := client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.WithError(err).Warn("Unable to get restored deployment config to roll it out")
+		return
+	}
+
+	// the deployment config was restored over an existing one that's been rolled out
+	if latestVersion, _, _ := unstructured.NestedInt64(deploymentConfig.Object, "status", "latestVersion"); latestVersion > 0 {
+		return
+	}
+
+	triggers, _, _ := unstructured.NestedSlice(deploymentConfig.Object, "spec", "triggers")
+	for _, trigger := range triggers {
+		if t, ok := trigger.(map[string]interface{}); ok && t["type"] == "ConfigChange" {
+			return
+		}
+	}
+
+	request := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": deploymentConfigsGVR.GroupVersion().String(),
+		"kind":       "DeploymentRequest",
+		"name":       name,
+		"latest":     true,
+		"force":      true,
+	}}
+	if _, err := client.Create(request, "instantiate"); err != nil {
+		log.WithError(err).Warn("Unable to roll out restored deployment config")
+		return
+	}
+
+	log.Info("Rolled out restored deployment config")
+}
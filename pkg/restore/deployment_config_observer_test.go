@@ -0,0 +1,132 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestDeploymentConfigRolloutObserver(t *testing.T) {
+	newDeploymentConfig := func(latestVersion int64, triggers ...string) *unstructured.Unstructured {
+		var specTriggers []interface{}
+		for _, trigger := range triggers {
+			specTriggers = append(specTriggers, map[string]interface{}{"type": trigger})
+		}
+
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": "ns-1", "name": "dc-1"},
+			"spec":     map[string]interface{}{"triggers": specTriggers},
+			"status":   map[string]interface{}{"latestVersion": latestVersion},
+		}}
+	}
+
+	tests := []struct {
+		name             string
+		groupResource    schema.GroupResource
+		deploymentConfig *unstructured.Unstructured
+		expectRollout    bool
+	}{
+		{
+			name:             "deployment config without a config change trigger is rolled out",
+			groupResource:    kuberesource.DeploymentConfigs,
+			deploymentConfig: newDeploymentConfig(0, "ImageChange"),
+			expectRollout:    true,
+		},
+		{
+			name:             "legacy deployment config without triggers is rolled out",
+			groupResource:    schema.GroupResource{Resource: "deploymentconfigs"},
+			deploymentConfig: newDeploymentConfig(0),
+			expectRollout:    true,
+		},
+		{
+			name:             "deployment config with a config change trigger isn't rolled out",
+			groupResource:    kuberesource.DeploymentConfigs,
+			deploymentConfig: newDeploymentConfig(0, "ConfigChange", "ImageChange"),
+		},
+		{
+			name:             "deployment config that's been rolled out isn't rolled out again",
+			groupResource:    kuberesource.DeploymentConfigs,
+			deploymentConfig: newDeploymentConfig(3),
+		},
+		{
+			name:             "other resources are ignored",
+			groupResource:    kuberesource.Pods,
+			deploymentConfig: newDeploymentConfig(0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakeDeploymentConfigClient{deploymentConfig: test.deploymentConfig}
+			observer := NewDeploymentConfigRolloutObserver(client, arktest.NewLogger())
+
+			observer.OnItemRestored(&api.Restore{}, test.groupResource, "ns-1", "dc-1")
+
+			if test.expectRollout {
+				if assert.NotNil(t, client.instantiated) {
+					assert.Equal(t, "DeploymentRequest", client.instantiated.GetKind())
+					assert.Equal(t, "dc-1", client.instantiated.Object["name"])
+					assert.Equal(t, true, client.instantiated.Object["latest"])
+				}
+			} else {
+				assert.Nil(t, client.instantiated)
+			}
+		})
+	}
+}
+
+// fakeDeploymentConfigClient is a dynamic client for a single deployment config
+// that records the request to instantiate it.
+type fakeDeploymentConfigClient struct {
+	deploymentConfig *unstructured.Unstructured
+	instantiated     *unstructured.Unstructured
+
+	dynamic.NamespaceableResourceInterface
+}
+
+func (c *fakeDeploymentConfigClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return c
+}
+
+func (c *fakeDeploymentConfigClient) Namespace(namespace string) dynamic.ResourceInterface {
+	return c
+}
+
+func (c *fakeDeploymentConfigClient) Get(name string, opts metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	if name != c.deploymentConfig.GetName() {
+		return nil, apierrors.NewNotFound(deploymentConfigsGVR.GroupResource(), name)
+	}
+	return c.deploymentConfig, nil
+}
+
+func (c *fakeDeploymentConfigClient) Create(obj *unstructured.Unstructured, subresources ...string) (*unstructured.Unstructured, error) {
+	if len(subresources) == 1 && subresources[0] == "instantiate" {
+		c.instantiated = obj
+	}
+	return obj, nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// externalDNSHostnameAnnotation is the annotation that external-dns reads
+// additional, comma-separated hostnames from.
+const externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+
+type hostnameRewriteAction struct {
+	log logrus.FieldLogger
+}
+
+// NewHostnameRewriteAction returns an ItemAction that rewrites the hostnames of
+// ingresses and routes according to the restore's hostname rewrites.
+func NewHostnameRewriteAction(logger logrus.FieldLogger) ItemAction {
+	return &hostnameRewriteAction{log: logger}
+}
+
+func (a *hostnameRewriteAction) AppliesTo() (ResourceSelector, error) {
+	return ResourceSelector{
+		IncludedResources: []string{"ingresses", "routes"},
+	}, nil
+}
+
+func (a *hostnameRewriteAction) Execute(obj runtime.Unstructured, restore *api.Restore) (runtime.Unstructured, error, error) {
+	if len(restore.Spec.HostnameRewrites) == 0 {
+		return obj, nil, nil
+	}
+
+	rewriter, err := newHostnameRewriter(restore.Spec.HostnameRewrites)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item := &unstructured.Unstructured{Object: obj.UnstructuredContent()}
+	log := a.log.WithField("name", item.GetName()).WithField("namespace", item.GetNamespace())
+
+	rewrite := func(host string) string {
+		rewritten := rewriter.rewrite(host)
+		if rewritten != host {
+			log.Infof("Rewriting hostname %s to %s", host, rewritten)
+		}
+		return rewritten
+	}
+
+	switch item.GetKind() {
+	case "Ingress":
+		if err := rewriteIngressHostnames(item, rewrite); err != nil {
+			return nil, nil, err
+		}
+	case "Route":
+		if host, ok, _ := unstructured.NestedString(item.Object, "spec", "host"); ok && host != "" {
+			if err := unstructured.SetNestedField(item.Object, rewrite(host), "spec", "host"); err != nil {
+				return nil, nil, errors.WithStack(err)
+			}
+		}
+	default:
+		return obj, nil, nil
+	}
+
+	if annotations := item.GetAnnotations(); annotations[externalDNSHostnameAnnotation] != "" {
+		hosts := strings.Split(annotations[externalDNSHostnameAnnotation], ",")
+		for i := range hosts {
+			hosts[i] = rewrite(strings.TrimSpace(hosts[i]))
+		}
+		annotations[externalDNSHostnameAnnotation] = strings.Join(hosts, ",")
+		item.SetAnnotations(annotations)
+	}
+
+	return item, nil, nil
+}
+
+// rewriteIngressHostnames rewrites the hosts of ingress's rules and TLS entries.
+func rewriteIngressHostnames(ingress *unstructured.Unstructured, rewrite func(string) string) error {
+	if rules, ok, _ := unstructured.NestedSlice(ingress.Object, "spec", "rules"); ok {
+		for _, rule := range rules {
+			r, ok := rule.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if host, ok := r["host"].(string); ok && host != "" {
+				r["host"] = rewrite(host)
+			}
+		}
+		if err := unstructured.SetNestedSlice(ingress.Object, rules, "spec", "rules"); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if tls, ok, _ := unstructured.NestedSlice(ingress.Object, "spec", "tls"); ok {
+		for _, entry := range tls {
+			e, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			hosts, ok := e["hosts"].([]interface{})
+			if !ok {
+				continue
+			}
+			for i := range hosts {
+				if host, ok := hosts[i].(string); ok {
+					hosts[i] = rewrite(host)
+				}
+			}
+		}
+		if err := unstructured.SetNestedSlice(ingress.Object, tls, "spec", "tls"); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// hostnameRewriter rewrites hostnames with the first of a list of hostname
+// rewrites that matches them.
+type hostnameRewriter struct {
+	rules []hostnameRewriteRule
+}
+
+type hostnameRewriteRule struct {
+	suffix      string
+	regex       *regexp.Regexp
+	replacement string
+}
+
+// newHostnameRewriter returns a hostnameRewriter for rewrites, or an error if
+// any of them is invalid.
+func newHostnameRewriter(rewrites []api.HostnameRewrite) (*hostnameRewriter, error) {
+	rewriter := new(hostnameRewriter)
+
+	for _, rewrite := range rewrites {
+		if err := ValidateHostnameRewrite(rewrite); err != nil {
+			return nil, err
+		}
+
+		rule := hostnameRewriteRule{
+			suffix:      rewrite.Suffix,
+			replacement: rewrite.Replacement,
+		}
+		if rewrite.Regex != "" {
+			// the regex has been validated so it compiles
+			rule.regex = regexp.MustCompile(rewrite.Regex)
+		}
+
+		rewriter.rules = append(rewriter.rules, rule)
+	}
+
+	return rewriter, nil
+}
+
+// rewrite returns host rewritten by the first rule that matches it, or host
+// unchanged if none does.
+func (r *hostnameRewriter) rewrite(host string) string {
+	for _, rule := range r.rules {
+		if rule.regex != nil {
+			if rule.regex.MatchString(host) {
+				return rule.regex.ReplaceAllString(host, rule.replacement)
+			}
+			continue
+		}
+
+		if host == rule.suffix {
+			return rule.replacement
+		}
+		if strings.HasSuffix(host, "."+rule.suffix) {
+			return strings.TrimSuffix(host, rule.suffix) + rule.replacement
+		}
+	}
+
+	return host
+}
+
+// ValidateHostnameRewrite returns an error if rewrite doesn't specify exactly one
+// of a suffix and a regular expression, or its regular expression doesn't compile.
+func ValidateHostnameRewrite(rewrite api.HostnameRewrite) error {
+	if (rewrite.Suffix == "") == (rewrite.Regex == "") {
+		return errors.New("hostname rewrite must specify exactly one of suffix and regex")
+	}
+
+	if rewrite.Regex != "" {
+		if _, err := regexp.Compile(rewrite.Regex); err != nil {
+			return errors.Wrapf(err, "hostname rewrite has invalid regex %q", rewrite.Regex)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestHostnameRewriteActionExecute(t *testing.T) {
+	newIngress := func(hosts ...string) *testUnstructured {
+		var rules, tlsHosts []interface{}
+		for _, host := range hosts {
+			rules = append(rules, map[string]interface{}{"host": host})
+			tlsHosts = append(tlsHosts, host)
+		}
+
+		return NewTestUnstructured().WithKind("Ingress").WithName("ingress-1").
+			WithSpecField("rules", rules).
+			WithSpecField("tls", []interface{}{map[string]interface{}{"hosts": tlsHosts}})
+	}
+
+	newRoute := func(host string) *testUnstructured {
+		return NewTestUnstructured().WithKind("Route").WithName("route-1").WithSpecField("host", host)
+	}
+
+	suffixRewrites := []api.HostnameRewrite{
+		{Suffix: "prod.example.com", Replacement: "staging.example.com"},
+		{Suffix: "example.com", Replacement: "example.dev"},
+	}
+
+	tests := []struct {
+		name        string
+		obj         runtime.Unstructured
+		rewrites    []api.HostnameRewrite
+		expectedErr bool
+		expectedRes runtime.Unstructured
+	}{
+		{
+			name:        "no rewrites leaves ingress unchanged",
+			obj:         newIngress("app.prod.example.com").Unstructured,
+			expectedRes: newIngress("app.prod.example.com").Unstructured,
+		},
+		{
+			name:        "ingress rules and TLS hosts are rewritten by the first matching suffix",
+			obj:         newIngress("app.prod.example.com", "www.example.com", "example.com", "notexample.com").Unstructured,
+			rewrites:    suffixRewrites,
+			expectedRes: newIngress("app.staging.example.com", "www.example.dev", "example.dev", "notexample.com").Unstructured,
+		},
+		{
+			name:        "route host is rewritten by regex",
+			obj:         newRoute("app-prod.apps.example.com").Unstructured,
+			rewrites:    []api.HostnameRewrite{{Regex: `^(.*)-prod\.`, Replacement: "$1-staging."}},
+			expectedRes: newRoute("app-staging.apps.example.com").Unstructured,
+		},
+		{
+			name: "external-dns hostname annotation is rewritten",
+			obj: newRoute("app.example.com").
+				WithAnnotationValues(map[string]string{externalDNSHostnameAnnotation: "a.example.com, b.prod.example.com"}).
+				Unstructured,
+			rewrites: suffixRewrites,
+			expectedRes: newRoute("app.example.dev").
+				WithAnnotationValues(map[string]string{externalDNSHostnameAnnotation: "a.example.dev,b.staging.example.com"}).
+				Unstructured,
+		},
+		{
+			name:        "invalid regex returns an error",
+			obj:         newRoute("app.example.com").Unstructured,
+			rewrites:    []api.HostnameRewrite{{Regex: "(", Replacement: "x"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			action := NewHostnameRewriteAction(arktest.NewLogger())
+
+			res, _, err := action.Execute(test.obj, &api.Restore{Spec: api.RestoreSpec{HostnameRewrites: test.rewrites}})
+
+			if assert.Equal(t, test.expectedErr, err != nil) && !test.expectedErr {
+				require.NotNil(t, res)
+				assert.Equal(t, test.expectedRes.UnstructuredContent(), res.UnstructuredContent())
+			}
+		})
+	}
+}
+
+func TestValidateHostnameRewrite(t *testing.T) {
+	tests := []struct {
+		name        string
+		rewrite     api.HostnameRewrite
+		expectedErr bool
+	}{
+		{
+			name:    "suffix is valid",
+			rewrite: api.HostnameRewrite{Suffix: "example.com", Replacement: "example.dev"},
+		},
+		{
+			name:    "regex is valid",
+			rewrite: api.HostnameRewrite{Regex: `\.prod\.`, Replacement: ".staging."},
+		},
+		{
+			name:        "neither suffix nor regex is invalid",
+			rewrite:     api.HostnameRewrite{Replacement: "example.dev"},
+			expectedErr: true,
+		},
+		{
+			name:        "both suffix and regex is invalid",
+			rewrite:     api.HostnameRewrite{Suffix: "example.com", Regex: "example", Replacement: "example.dev"},
+			expectedErr: true,
+		},
+		{
+			name:        "regex that doesn't compile is invalid",
+			rewrite:     api.HostnameRewrite{Regex: "[", Replacement: "example.dev"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedErr, ValidateHostnameRewrite(test.rewrite) != nil)
+		})
+	}
+}
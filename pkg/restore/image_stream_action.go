@@ -0,0 +1,144 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+type imageStreamAction struct {
+	log logrus.FieldLogger
+}
+
+// NewImageStreamAction returns an ItemAction that prepares the tags of OpenShift
+// image streams for restore: references to image streams in namespaces that the
+// restore maps are changed to the mapped namespaces, and tags that only exist in
+// the image stream's status, such as tags of images that were pushed to the
+// integrated registry, are added to its spec as references to their current image
+// so that they're restored too.
+func NewImageStreamAction(logger logrus.FieldLogger) ItemAction {
+	return &imageStreamAction{log: logger}
+}
+
+func (a *imageStreamAction) AppliesTo() (ResourceSelector, error) {
+	return ResourceSelector{
+		IncludedResources: []string{"imagestreams"},
+	}, nil
+}
+
+func (a *imageStreamAction) Execute(obj runtime.Unstructured, restore *api.Restore) (runtime.Unstructured, error, error) {
+	imageStream := &unstructured.Unstructured{Object: obj.UnstructuredContent()}
+	log := a.log.WithField("imageStream", fmt.Sprintf("%s/%s", imageStream.GetNamespace(), imageStream.GetName()))
+
+	specTags, _, err := unstructured.NestedSlice(imageStream.Object, "spec", "tags")
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	specTagNames := make(map[string]bool)
+	for _, tag := range specTags {
+		t, ok := tag.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := t["name"].(string); ok {
+			specTagNames[name] = true
+		}
+
+		from, ok := t["from"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if namespace, ok := from["namespace"].(string); ok && namespace != "" {
+			if mapped, ok := restore.Spec.NamespaceMapping[namespace]; ok {
+				log.Infof("Changing namespace of tag %v's reference to %s %s from %s to %s", t["name"], from["kind"], from["name"], namespace, mapped)
+				from["namespace"] = mapped
+			}
+		}
+	}
+
+	registryRepository, _, _ := unstructured.NestedString(imageStream.Object, "status", "dockerImageRepository")
+
+	statusTags, _, _ := unstructured.NestedSlice(imageStream.Object, "status", "tags")
+
+	var warnings []string
+	for _, tag := range statusTags {
+		t, ok := tag.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := t["tag"].(string)
+		if name == "" || specTagNames[name] {
+			continue
+		}
+
+		// the first item is the tag's current image
+		items, _ := t["items"].([]interface{})
+		if len(items) == 0 {
+			continue
+		}
+		item, _ := items[0].(map[string]interface{})
+		reference, _ := item["dockerImageReference"].(string)
+		if reference == "" {
+			continue
+		}
+
+		log.Infof("Adding tag %s, which only exists in the image stream's status, as a reference to %s", name, reference)
+		specTags = append(specTags, map[string]interface{}{
+			"name": name,
+			"from": map[string]interface{}{
+				"kind": "DockerImage",
+				"name": reference,
+			},
+			"referencePolicy": map[string]interface{}{
+				"type": "Source",
+			},
+		})
+
+		if registryRepository != "" && strings.HasPrefix(reference, registryHost(registryRepository)+"/") {
+			warnings = append(warnings, fmt.Sprintf("tag %s references %s in the integrated registry of the cluster that was backed up, which may not be reachable from this cluster", name, reference))
+		}
+	}
+
+	if len(specTags) > 0 {
+		if err := unstructured.SetNestedSlice(imageStream.Object, specTags, "spec", "tags"); err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+
+	if len(warnings) > 0 {
+		return imageStream, errors.New(strings.Join(warnings, "; ")), nil
+	}
+	return imageStream, nil, nil
+}
+
+// registryHost returns the registry host of an image repository such as
+// docker-registry.default.svc:5000/ns/name.
+func registryHost(repository string) string {
+	if i := strings.Index(repository, "/"); i >= 0 {
+		return repository[:i]
+	}
+	return repository
+}
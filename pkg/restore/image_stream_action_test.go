@@ -0,0 +1,83 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestImageStreamActionExecute(t *testing.T) {
+	imageStream := NewTestUnstructured().WithKind("ImageStream").WithNamespace("ns-1").WithName("app").
+		WithSpecField("tags", []interface{}{
+			map[string]interface{}{
+				"name": "base",
+				"from": map[string]interface{}{"kind": "ImageStreamTag", "namespace": "shared", "name": "base:latest"},
+			},
+			map[string]interface{}{
+				"name": "upstream",
+				"from": map[string]interface{}{"kind": "DockerImage", "name": "docker.io/library/nginx:1.15"},
+			},
+		}).
+		WithStatusField("dockerImageRepository", "docker-registry.default.svc:5000/ns-1/app").
+		WithStatusField("tags", []interface{}{
+			map[string]interface{}{
+				"tag": "base",
+				"items": []interface{}{
+					map[string]interface{}{"dockerImageReference": "docker-registry.default.svc:5000/shared/base@sha256:aaa"},
+				},
+			},
+			map[string]interface{}{
+				"tag": "v1",
+				"items": []interface{}{
+					map[string]interface{}{"dockerImageReference": "docker-registry.default.svc:5000/ns-1/app@sha256:bbb"},
+					map[string]interface{}{"dockerImageReference": "docker-registry.default.svc:5000/ns-1/app@sha256:ccc"},
+				},
+			},
+		})
+
+	action := NewImageStreamAction(arktest.NewLogger())
+	restore := &api.Restore{Spec: api.RestoreSpec{NamespaceMapping: map[string]string{"shared": "shared-staging"}}}
+
+	res, warning, err := action.Execute(imageStream.Unstructured, restore)
+	require.NoError(t, err)
+
+	// the pushed tag references the backed-up cluster's integrated registry
+	assert.Error(t, warning)
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"name": "base",
+			"from": map[string]interface{}{"kind": "ImageStreamTag", "namespace": "shared-staging", "name": "base:latest"},
+		},
+		map[string]interface{}{
+			"name": "upstream",
+			"from": map[string]interface{}{"kind": "DockerImage", "name": "docker.io/library/nginx:1.15"},
+		},
+		map[string]interface{}{
+			"name":            "v1",
+			"from":            map[string]interface{}{"kind": "DockerImage", "name": "docker-registry.default.svc:5000/ns-1/app@sha256:bbb"},
+			"referencePolicy": map[string]interface{}{"type": "Source"},
+		},
+	}
+	assert.Equal(t, expected, res.UnstructuredContent()["spec"].(map[string]interface{})["tags"])
+}
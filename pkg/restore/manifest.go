@@ -0,0 +1,124 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ManifestEntry identifies an object that a restore created.
+type ManifestEntry struct {
+	// Resource is the group-qualified resource of the object, e.g. deployments.apps.
+	Resource string `json:"resource"`
+	// APIVersion is the group version the object was created with.
+	APIVersion string    `json:"apiVersion"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid"`
+	// ResourceVersion is the object's resourceVersion when it was created.
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// Manifest records the namespaces and objects created by a restore, in the order they
+// were created, so that the restore can later be undone by deleting exactly those
+// objects. Objects that already existed and were left alone or patched are not recorded.
+type Manifest struct {
+	lock sync.Mutex
+
+	Namespaces []ManifestEntry `json:"namespaces,omitempty"`
+	Items      []ManifestEntry `json:"items,omitempty"`
+}
+
+// NewManifest returns an empty Manifest.
+func NewManifest() *Manifest {
+	return &Manifest{}
+}
+
+func newManifestEntry(groupResource schema.GroupResource, apiVersion string, obj metav1.Object) ManifestEntry {
+	return ManifestEntry{
+		Resource:        groupResource.String(),
+		APIVersion:      apiVersion,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		UID:             obj.GetUID(),
+		ResourceVersion: obj.GetResourceVersion(),
+	}
+}
+
+// addItem records an object created by the restore. It's safe to call concurrently,
+// since pods whose claims' data is restored first are created in the background.
+func (m *Manifest) addItem(groupResource schema.GroupResource, obj *unstructured.Unstructured) {
+	if m == nil || obj == nil {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.Items = append(m.Items, newManifestEntry(groupResource, obj.GetAPIVersion(), obj))
+}
+
+// addNamespace records a namespace created by the restore.
+func (m *Manifest) addNamespace(ns *v1.Namespace) {
+	if m == nil || ns == nil {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.Namespaces = append(m.Namespaces, newManifestEntry(schema.GroupResource{Resource: "namespaces"}, "v1", ns))
+}
+
+// Encode writes the manifest to w as gzipped JSON.
+func (m *Manifest) Encode(w io.Writer) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	gzw := gzip.NewWriter(w)
+	if err := json.NewEncoder(gzw).Encode(m); err != nil {
+		return errors.Wrap(err, "error encoding restore manifest")
+	}
+
+	return errors.Wrap(gzw.Close(), "error closing gzip writer")
+}
+
+// DecodeManifest reads a manifest written by Encode from r.
+func DecodeManifest(r io.Reader) (*Manifest, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating gzip reader")
+	}
+	defer gzr.Close()
+
+	manifest := NewManifest()
+	if err := json.NewDecoder(gzr).Decode(manifest); err != nil {
+		return nil, errors.Wrap(err, "error decoding restore manifest")
+	}
+
+	return manifest, nil
+}
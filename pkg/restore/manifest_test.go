@@ -0,0 +1,103 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"bytes"
+	go_context "context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestManifestEncodeDecode(t *testing.T) {
+	manifest := NewManifest()
+
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1", UID: "ns-uid", ResourceVersion: "1"}}
+	manifest.addNamespace(ns)
+
+	deployment := arktest.UnstructuredOrDie(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"namespace":"ns-1","name":"deploy-1","uid":"deploy-uid","resourceVersion":"2"}}`)
+	manifest.addItem(schema.GroupResource{Group: "apps", Resource: "deployments"}, deployment)
+
+	var buf bytes.Buffer
+	require.NoError(t, manifest.Encode(&buf))
+
+	decoded, err := DecodeManifest(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ManifestEntry{{Resource: "namespaces", APIVersion: "v1", Name: "ns-1", UID: "ns-uid", ResourceVersion: "1"}}, decoded.Namespaces)
+	assert.Equal(t, []ManifestEntry{{Resource: "deployments.apps", APIVersion: "apps/v1", Namespace: "ns-1", Name: "deploy-1", UID: "deploy-uid", ResourceVersion: "2"}}, decoded.Items)
+}
+
+func TestNilManifestIgnoresObjects(t *testing.T) {
+	var manifest *Manifest
+
+	manifest.addItem(kuberesource.Pods, arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1"}}`))
+	manifest.addNamespace(&v1.Namespace{})
+}
+
+func TestRestoreResourceRecordsCreatedObjects(t *testing.T) {
+	resourceClient := &arktest.FakeDynamicClient{}
+	defer resourceClient.AssertExpectations(t)
+
+	hasName := func(name string) interface{} {
+		return mock.MatchedBy(func(obj *unstructured.Unstructured) bool { return obj.GetName() == name })
+	}
+
+	created := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1","uid":"cm-1-uid","resourceVersion":"10"}}`)
+	resourceClient.On("Create", hasName("cm-1")).Return(created, nil)
+	resourceClient.On("Create", hasName("cm-2")).Return((*unstructured.Unstructured)(nil), errors.New("forbidden"))
+
+	dynamicFactory := &arktest.FakeDynamicFactory{}
+	resource := metav1.APIResource{Name: "configmaps", Namespaced: true}
+	dynamicFactory.On("ClientForGroupVersionResource", schema.GroupVersion{Version: "v1"}, resource, "ns-1").Return(resourceClient, nil)
+
+	manifest := NewManifest()
+	ctx := &context{
+		goContext:      go_context.Background(),
+		dynamicFactory: dynamicFactory,
+		fileSystem: arktest.NewFakeFileSystem().
+			WithFile("foo/resources/configmaps/namespaces/ns-1/cm-1.json", []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1"}}`)).
+			WithFile("foo/resources/configmaps/namespaces/ns-1/cm-2.json", []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-2"}}`)),
+		selector: labels.NewSelector(),
+		restore: &api.Restore{
+			ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: "my-restore"},
+			Spec:       api.RestoreSpec{BackupName: "my-backup"},
+		},
+		backup:          &api.Backup{},
+		log:             arktest.NewLogger(),
+		mergeStrategies: newMergeStrategyRegistry(),
+		manifest:        manifest,
+	}
+
+	_, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+	assert.Len(t, errs.Namespaces["ns-1"], 1)
+
+	assert.Equal(t, []ManifestEntry{{Resource: "configmaps", APIVersion: "v1", Namespace: "ns-1", Name: "cm-1", UID: "cm-1-uid", ResourceVersion: "10"}}, manifest.Items)
+}
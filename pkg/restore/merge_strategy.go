@@ -0,0 +1,184 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/heptio/ark/pkg/kuberesource"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+// mergeFunc merges a backed up object into the version of it that already exists
+// in the cluster, returning the desired state of the in-cluster object.
+type mergeFunc func(fromCluster, fromBackup *unstructured.Unstructured) (*unstructured.Unstructured, error)
+
+// mergeStrategyRegistry holds the merge functions used to reconcile objects that
+// already exist in the cluster, keyed by group-resource. Objects of resources
+// without a merge function aren't restored if they already exist.
+type mergeStrategyRegistry map[schema.GroupResource]mergeFunc
+
+// newMergeStrategyRegistry returns a registry containing the built-in merge functions.
+func newMergeStrategyRegistry() mergeStrategyRegistry {
+	return mergeStrategyRegistry{
+		kuberesource.ServiceAccounts:           mergeServiceAccounts,
+		kuberesource.ConfigMaps:                mergeConfigMaps,
+		kuberesource.Secrets:                   mergeSecrets,
+		kuberesource.CustomResourceDefinitions: mergeCustomResourceDefinitions,
+	}
+}
+
+// register adds a merge function for a group-resource, replacing any existing one.
+func (r mergeStrategyRegistry) register(groupResource schema.GroupResource, merge mergeFunc) {
+	r[groupResource] = merge
+}
+
+// get returns the merge function for a group-resource, if there is one.
+func (r mergeStrategyRegistry) get(groupResource schema.GroupResource) (mergeFunc, bool) {
+	merge, ok := r[groupResource]
+	return merge, ok
+}
+
+// mergeConfigMaps adds data keys, labels, and annotations from the backed up config map
+// that aren't in the in-cluster version. If a key is in both, the in-cluster value is retained.
+func mergeConfigMaps(fromCluster, fromBackup *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	desired := fromCluster.DeepCopy()
+	mergeLabelsAndAnnotations(desired, fromBackup)
+
+	for _, field := range []string{"data", "binaryData"} {
+		if err := mergeMissingKeys(desired, fromBackup, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return desired, nil
+}
+
+// mergeSecrets adds data keys, labels, and annotations from the backed up secret that
+// aren't in the in-cluster version. If a key is in both, the in-cluster value is retained.
+func mergeSecrets(fromCluster, fromBackup *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	desired := fromCluster.DeepCopy()
+	mergeLabelsAndAnnotations(desired, fromBackup)
+
+	if err := mergeMissingKeys(desired, fromBackup, "data"); err != nil {
+		return nil, err
+	}
+
+	return desired, nil
+}
+
+// mergeCustomResourceDefinitions adds versions, labels, and annotations from the backed up
+// custom resource definition that aren't in the in-cluster version. Added versions are never
+// the storage version, since the in-cluster definition already has one.
+func mergeCustomResourceDefinitions(fromCluster, fromBackup *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	desired := fromCluster.DeepCopy()
+	mergeLabelsAndAnnotations(desired, fromBackup)
+
+	backupVersions, found, err := unstructured.NestedSlice(fromBackup.Object, "spec", "versions")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get versions of backed up custom resource definition")
+	}
+	if !found {
+		return desired, nil
+	}
+
+	versions, _, err := unstructured.NestedSlice(desired.Object, "spec", "versions")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get versions of in-cluster custom resource definition")
+	}
+
+	names := make(map[string]bool)
+	for _, version := range versions {
+		if version, ok := version.(map[string]interface{}); ok {
+			name, _ := version["name"].(string)
+			names[name] = true
+		}
+	}
+
+	var added bool
+	for _, version := range backupVersions {
+		version, ok := version.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, _ := version["name"].(string)
+		if names[name] {
+			continue
+		}
+
+		version["storage"] = false
+		versions = append(versions, version)
+		names[name] = true
+		added = true
+	}
+
+	if added {
+		if err := unstructured.SetNestedSlice(desired.Object, versions, "spec", "versions"); err != nil {
+			return nil, errors.Wrap(err, "unable to set versions of custom resource definition")
+		}
+	}
+
+	return desired, nil
+}
+
+// mergeLabelsAndAnnotations adds labels and annotations from fromBackup that aren't on desired.
+func mergeLabelsAndAnnotations(desired, fromBackup *unstructured.Unstructured) {
+	if labels := fromBackup.GetLabels(); len(labels) > 0 {
+		desired.SetLabels(collections.MergeMaps(desired.GetLabels(), labels))
+	}
+
+	if annotations := fromBackup.GetAnnotations(); len(annotations) > 0 {
+		desired.SetAnnotations(collections.MergeMaps(desired.GetAnnotations(), annotations))
+	}
+}
+
+// mergeMissingKeys adds the keys of the map at the given path in fromBackup that aren't
+// in the map at the same path in desired.
+func mergeMissingKeys(desired, fromBackup *unstructured.Unstructured, fields ...string) error {
+	backupVals, found, err := unstructured.NestedMap(fromBackup.Object, fields...)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get %v of backed up object", fields)
+	}
+	if !found {
+		return nil
+	}
+
+	vals, _, err := unstructured.NestedMap(desired.Object, fields...)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get %v of in-cluster object", fields)
+	}
+	if vals == nil {
+		vals = make(map[string]interface{})
+	}
+
+	var added bool
+	for key, val := range backupVals {
+		if _, ok := vals[key]; !ok {
+			vals[key] = val
+			added = true
+		}
+	}
+
+	if !added {
+		return nil
+	}
+
+	return errors.Wrapf(unstructured.SetNestedMap(desired.Object, vals, fields...), "unable to set %v of object", fields)
+}
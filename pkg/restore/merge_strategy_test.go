@@ -0,0 +1,100 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestMergeStrategies(t *testing.T) {
+	tests := []struct {
+		name        string
+		merge       mergeFunc
+		fromCluster string
+		fromBackup  string
+		expected    string
+	}{
+		{
+			name:        "config map data, labels, and annotations not in the cluster are added",
+			merge:       mergeConfigMaps,
+			fromCluster: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1","labels":{"a":"cluster"}},"data":{"a":"cluster"}}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1","labels":{"a":"backup","b":"backup"},"annotations":{"c":"backup"}},"data":{"a":"backup","b":"backup"},"binaryData":{"c":"YmFja3Vw"}}`,
+			expected:    `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1","labels":{"a":"cluster","b":"backup"},"annotations":{"c":"backup"}},"data":{"a":"cluster","b":"backup"},"binaryData":{"c":"YmFja3Vw"}}`,
+		},
+		{
+			name:        "config map without anything to add is unchanged",
+			merge:       mergeConfigMaps,
+			fromCluster: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"},"data":{"a":"cluster"}}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"},"data":{"a":"backup"}}`,
+			expected:    `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"},"data":{"a":"cluster"}}`,
+		},
+		{
+			name:        "secret data not in the cluster is added",
+			merge:       mergeSecrets,
+			fromCluster: `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"secret-1"},"type":"Opaque"}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"secret-1"},"type":"Opaque","data":{"a":"YmFja3Vw"}}`,
+			expected:    `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"secret-1"},"type":"Opaque","data":{"a":"YmFja3Vw"}}`,
+		},
+		{
+			name:        "custom resource definition versions not in the cluster are added without being the storage version",
+			merge:       mergeCustomResourceDefinitions,
+			fromCluster: `{"apiVersion":"apiextensions.k8s.io/v1beta1","kind":"CustomResourceDefinition","metadata":{"name":"foos.example.com"},"spec":{"versions":[{"name":"v1","served":true,"storage":true}]}}`,
+			fromBackup:  `{"apiVersion":"apiextensions.k8s.io/v1beta1","kind":"CustomResourceDefinition","metadata":{"name":"foos.example.com"},"spec":{"versions":[{"name":"v1","served":false,"storage":false},{"name":"v2","served":true,"storage":true}]}}`,
+			expected:    `{"apiVersion":"apiextensions.k8s.io/v1beta1","kind":"CustomResourceDefinition","metadata":{"name":"foos.example.com"},"spec":{"versions":[{"name":"v1","served":true,"storage":true},{"name":"v2","served":true,"storage":false}]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fromCluster := arktest.UnstructuredOrDie(test.fromCluster)
+			original := fromCluster.DeepCopy()
+
+			desired, err := test.merge(fromCluster, arktest.UnstructuredOrDie(test.fromBackup))
+			require.NoError(t, err)
+
+			assert.Equal(t, arktest.UnstructuredOrDie(test.expected), desired)
+			// the in-cluster object must not be modified
+			assert.Equal(t, original, fromCluster)
+		})
+	}
+}
+
+func TestMergeStrategyRegistry(t *testing.T) {
+	registry := newMergeStrategyRegistry()
+
+	for _, groupResource := range []string{"serviceaccounts", "configmaps", "secrets", "customresourcedefinitions.apiextensions.k8s.io"} {
+		_, ok := registry.get(schema.ParseGroupResource(groupResource))
+		assert.True(t, ok, "expected a merge strategy for %s", groupResource)
+	}
+
+	_, ok := registry.get(kuberesource.Pods)
+	assert.False(t, ok)
+
+	registry.register(kuberesource.Pods, func(fromCluster, fromBackup *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		return fromCluster, nil
+	})
+	_, ok = registry.get(kuberesource.Pods)
+	assert.True(t, ok)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// Observer is notified of the progress of the restores run by a Restorer.
+// Its methods are called synchronously while the restore runs, so they should
+// return quickly, and they may be called concurrently, both for different
+// restores and for items of the same restore.
+type Observer interface {
+	// OnPhaseChange is called with api.RestorePhaseInProgress when a restore
+	// starts, and with api.RestorePhaseCompleted when it finishes.
+	OnPhaseChange(restore *api.Restore, phase api.RestorePhase)
+
+	// OnItemRestored is called after an item is created in the cluster, or an
+	// existing item is updated to match the backed-up version.
+	OnItemRestored(restore *api.Restore, groupResource schema.GroupResource, namespace, name string)
+}
+
+// observers notifies each of a list of Observers in turn.
+type observers []Observer
+
+func (o observers) OnPhaseChange(restore *api.Restore, phase api.RestorePhase) {
+	for _, observer := range o {
+		observer.OnPhaseChange(restore, phase)
+	}
+}
+
+func (o observers) OnItemRestored(restore *api.Restore, groupResource schema.GroupResource, namespace, name string) {
+	for _, observer := range o {
+		observer.OnItemRestored(restore, groupResource, namespace, name)
+	}
+}
+
+// observerRegistry is the list of Observers registered on a Restorer.
+type observerRegistry struct {
+	lock      sync.Mutex
+	observers observers
+}
+
+func (r *observerRegistry) add(observer Observer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.observers = append(r.observers, observer)
+}
+
+// list returns the Observers registered so far. Observers registered while
+// a restore runs aren't notified of its progress.
+func (r *observerRegistry) list() observers {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append(observers(nil), r.observers...)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	go_context "context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+// recordingObserver records the notifications it receives.
+type recordingObserver struct {
+	phases []api.RestorePhase
+	items  []string
+}
+
+func (o *recordingObserver) OnPhaseChange(restore *api.Restore, phase api.RestorePhase) {
+	o.phases = append(o.phases, phase)
+}
+
+func (o *recordingObserver) OnItemRestored(restore *api.Restore, groupResource schema.GroupResource, namespace, name string) {
+	o.items = append(o.items, groupResource.String()+"/"+namespace+"/"+name)
+}
+
+func TestRestoreNotifiesObserversOfPhaseChanges(t *testing.T) {
+	kr := &kubernetesRestorer{}
+	observer := &recordingObserver{}
+	kr.AddObserver(observer)
+
+	// an invalid label selector ends the restore before any items are restored
+	restore := &api.Restore{
+		Spec: api.RestoreSpec{
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "invalid"}},
+			},
+		},
+	}
+
+	_, errs := kr.Restore(go_context.Background(), arktest.NewLogger(), restore, &api.Backup{}, nil, nil, nil, nil, nil, nil, nil)
+
+	assert.NotEmpty(t, errs.Ark)
+	assert.Equal(t, []api.RestorePhase{api.RestorePhaseInProgress, api.RestorePhaseCompleted}, observer.phases)
+	assert.Empty(t, observer.items)
+}
+
+func TestRestoreResourceNotifiesObserversOfRestoredItems(t *testing.T) {
+	resourceClient := &arktest.FakeDynamicClient{}
+	resourceClient.On("Create", mock.MatchedBy(func(obj *unstructured.Unstructured) bool { return obj.GetName() == "cm-1" })).Return(new(unstructured.Unstructured), nil)
+	resourceClient.On("Create", mock.Anything).Return(new(unstructured.Unstructured), assert.AnError)
+
+	ctx := newConfigMapsRestoreContext(resourceClient, 2)
+	observer := &recordingObserver{}
+	ctx.observers = observers{observer}
+
+	ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+
+	// only the config map that was created is reported
+	assert.Equal(t, []string{"configmaps/ns-1/cm-1"}, observer.items)
+}
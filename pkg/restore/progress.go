@@ -0,0 +1,124 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// Progress records which item a restore is working on and how long it has spent on
+// each resource, so that a slow restore can report where it is while it runs. It's
+// safe to call concurrently, since namespaces may be restored in parallel and pods
+// whose claims' data is restored first are created in the background.
+type Progress struct {
+	lock sync.Mutex
+
+	current       *api.RestoreProgress
+	itemsRestored int
+	timings       map[string]time.Duration
+}
+
+// NewProgress returns a Progress for a restore that hasn't started yet.
+func NewProgress() *Progress {
+	return &Progress{timings: make(map[string]time.Duration)}
+}
+
+// itemStarted records that the restore is working on the named item of groupResource.
+func (p *Progress) itemStarted(groupResource schema.GroupResource, namespace, name string) {
+	if p == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.current = &api.RestoreProgress{
+		Resource:  groupResource.String(),
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// itemRestored counts an item that was created or updated.
+func (p *Progress) itemRestored() {
+	if p == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.itemsRestored++
+}
+
+// addResourceTime adds duration to the time spent restoring resource.
+func (p *Progress) addResourceTime(resource string, duration time.Duration) {
+	if p == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.timings[resource] += duration
+}
+
+// Current returns the item the restore is working on, with a count of the items
+// restored so far, or nil if it hasn't started on any item yet. Its UpdateTimestamp
+// isn't set.
+func (p *Progress) Current() *api.RestoreProgress {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.current == nil {
+		return nil
+	}
+
+	current := *p.current
+	current.ItemsRestored = p.itemsRestored
+	return &current
+}
+
+// ResourceTimings returns the time spent restoring each resource, slowest first.
+func (p *Progress) ResourceTimings() []api.RestoreResourceTiming {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var timings []api.RestoreResourceTiming
+	for resource, duration := range p.timings {
+		timings = append(timings, api.RestoreResourceTiming{
+			Resource: resource,
+			Duration: metav1.Duration{Duration: duration},
+		})
+	}
+
+	sort.Slice(timings, func(i, j int) bool {
+		if timings[i].Duration.Duration != timings[j].Duration.Duration {
+			return timings[i].Duration.Duration > timings[j].Duration.Duration
+		}
+		return timings[i].Resource < timings[j].Resource
+	})
+
+	return timings
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+)
+
+func TestProgress(t *testing.T) {
+	progress := NewProgress()
+	assert.Nil(t, progress.Current())
+	assert.Empty(t, progress.ResourceTimings())
+
+	progress.itemStarted(kuberesource.Namespaces, "", "ns-1")
+	progress.itemRestored()
+	progress.itemStarted(kuberesource.Pods, "ns-1", "pod-1")
+	assert.Equal(t, &api.RestoreProgress{Resource: "pods", Namespace: "ns-1", Name: "pod-1", ItemsRestored: 1}, progress.Current())
+
+	// times are added up across namespaces, and the slowest resource is first
+	progress.addResourceTime("configmaps", time.Second)
+	progress.addResourceTime("pods", 2*time.Second)
+	progress.addResourceTime("configmaps", 2*time.Second)
+	progress.addResourceTime("secrets", 2*time.Second)
+	assert.Equal(t, []api.RestoreResourceTiming{
+		{Resource: "configmaps", Duration: metav1.Duration{Duration: 3 * time.Second}},
+		{Resource: "pods", Duration: metav1.Duration{Duration: 2 * time.Second}},
+		{Resource: "secrets", Duration: metav1.Duration{Duration: 2 * time.Second}},
+	}, progress.ResourceTimings())
+
+	// a nil Progress records nothing
+	var nilProgress *Progress
+	nilProgress.itemStarted(kuberesource.Pods, "ns-1", "pod-1")
+	nilProgress.itemRestored()
+	nilProgress.addResourceTime("pods", time.Second)
+}
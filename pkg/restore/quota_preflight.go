@@ -0,0 +1,324 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+// quotaExceeded describes a resource quota of a target namespace that the items
+// restored into the namespace would exceed.
+type quotaExceeded struct {
+	namespace string
+	message   string
+}
+
+// checkResourceQuotas compares the resources that the restore's items would use in each
+// target namespace, such as pods' CPU and memory requests and object counts, with the
+// hard limits of the namespace's resource quotas, and returns the quotas that would be
+// exceeded, sorted by namespace. Only target namespaces that already have resource
+// quotas are checked, and quotas with scopes are ignored since they only apply to some
+// of a namespace's pods. Items that are already in the cluster, and so won't be
+// restored, are counted too, so the check may report quotas that won't be exceeded.
+func (ctx *context) checkResourceQuotas(resourcesDir string, resourceDirsMap map[string]os.FileInfo, namespaceFilter *collections.IncludesExcludes) ([]quotaExceeded, error) {
+	// the backup's namespace directories for each target namespace
+	nsPaths := make(map[string][]string)
+	for _, resource := range ctx.prioritizedResources {
+		if resource == kuberesource.Namespaces || resourceDirsMap[resource.String()] == nil {
+			continue
+		}
+
+		nsSubDir := filepath.Join(resourcesDir, resource.String(), api.NamespaceScopedDir)
+		nsSubDirExists, err := ctx.fileSystem.DirExists(nsSubDir)
+		if err != nil {
+			return nil, err
+		}
+		if !nsSubDirExists {
+			continue
+		}
+
+		nsDirs, err := ctx.fileSystem.ReadDir(nsSubDir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, nsDir := range nsDirs {
+			if !nsDir.IsDir() || !namespaceFilter.ShouldInclude(nsDir.Name()) {
+				continue
+			}
+
+			target := nsDir.Name()
+			if mapped, ok := ctx.restore.Spec.NamespaceMapping[target]; ok {
+				target = mapped
+			}
+
+			nsPaths[target] = append(nsPaths[target], filepath.Join(nsSubDir, nsDir.Name()))
+		}
+	}
+
+	targets := make([]string, 0, len(nsPaths))
+	for target := range nsPaths {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var exceeded []quotaExceeded
+	for _, target := range targets {
+		quotas, err := ctx.resourceQuotaClient.ResourceQuotas(target).List(metav1.ListOptions{})
+		if err != nil {
+			return exceeded, errors.Wrapf(err, "error listing resource quotas in namespace %s", target)
+		}
+		if len(quotas.Items) == 0 {
+			continue
+		}
+
+		usage := corev1api.ResourceList{}
+		for _, path := range nsPaths[target] {
+			if err := ctx.addQuotaUsage(usage, path); err != nil {
+				return exceeded, err
+			}
+		}
+
+		for _, quota := range quotas.Items {
+			for _, msg := range exceededQuotaLimits(&quota, usage) {
+				exceeded = append(exceeded, quotaExceeded{namespace: target, message: msg})
+			}
+		}
+	}
+
+	return exceeded, nil
+}
+
+// addQuotaUsage adds the quota usage of the items in the backup's resource namespace
+// directory nsPath that the restore includes to usage.
+func (ctx *context) addQuotaUsage(usage corev1api.ResourceList, nsPath string) error {
+	groupResource := schema.ParseGroupResource(filepath.Base(filepath.Dir(filepath.Dir(nsPath))))
+
+	files, err := ctx.fileSystem.ReadDir(nsPath)
+	if err != nil {
+		return errors.Wrapf(err, "error reading %s", nsPath)
+	}
+
+	for _, file := range files {
+		obj, err := ctx.unmarshal(filepath.Join(nsPath, file.Name()))
+		if err != nil {
+			return err
+		}
+		if !ctx.selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+
+		var itemUsage corev1api.ResourceList
+		switch groupResource {
+		case kuberesource.Pods:
+			pod := new(corev1api.Pod)
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), pod); err != nil {
+				return errors.WithStack(err)
+			}
+			// pods that have terminated don't count against quotas
+			if pod.Status.Phase == corev1api.PodSucceeded || pod.Status.Phase == corev1api.PodFailed {
+				continue
+			}
+			itemUsage = podQuotaUsage(pod)
+		case kuberesource.PersistentVolumeClaims:
+			pvc := new(corev1api.PersistentVolumeClaim)
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), pvc); err != nil {
+				return errors.WithStack(err)
+			}
+			itemUsage = pvcQuotaUsage(pvc)
+		case kuberesource.Services:
+			svc := new(corev1api.Service)
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), svc); err != nil {
+				return errors.WithStack(err)
+			}
+			itemUsage = serviceQuotaUsage(svc)
+		}
+
+		addResources(usage, itemUsage)
+		addResources(usage, objectCountUsage(groupResource))
+	}
+
+	return nil
+}
+
+// exceededQuotaLimits returns a message for each hard limit of quota that usage,
+// added to the quota's current usage, would exceed.
+func exceededQuotaLimits(quota *corev1api.ResourceQuota, usage corev1api.ResourceList) []string {
+	if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+		return nil
+	}
+
+	hard := quota.Status.Hard
+	if len(hard) == 0 {
+		hard = quota.Spec.Hard
+	}
+
+	names := make([]string, 0, len(hard))
+	for name := range hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var msgs []string
+	for _, name := range names {
+		needed, ok := usage[corev1api.ResourceName(name)]
+		if !ok || needed.IsZero() {
+			continue
+		}
+
+		limit := hard[corev1api.ResourceName(name)]
+		total := quota.Status.Used[corev1api.ResourceName(name)]
+		total.Add(needed)
+
+		if total.Cmp(limit) > 0 {
+			used := quota.Status.Used[corev1api.ResourceName(name)]
+			msgs = append(msgs, fmt.Sprintf("restoring items that use %s of %s would exceed resource quota %s, which has %s used of its %s limit",
+				needed.String(), name, quota.Name, used.String(), limit.String()))
+		}
+	}
+
+	return msgs
+}
+
+// objectCountUsage returns the object count quota usage of an item of groupResource.
+func objectCountUsage(groupResource schema.GroupResource) corev1api.ResourceList {
+	usage := corev1api.ResourceList{
+		corev1api.ResourceName("count/" + groupResource.String()): *resource.NewQuantity(1, resource.DecimalSI),
+	}
+
+	// core resources also have object count quotas without the count/ prefix
+	switch groupResource {
+	case kuberesource.Pods:
+		usage[corev1api.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	case kuberesource.Services:
+		usage[corev1api.ResourceServices] = *resource.NewQuantity(1, resource.DecimalSI)
+	case kuberesource.PersistentVolumeClaims:
+		usage[corev1api.ResourcePersistentVolumeClaims] = *resource.NewQuantity(1, resource.DecimalSI)
+	case kuberesource.Secrets:
+		usage[corev1api.ResourceSecrets] = *resource.NewQuantity(1, resource.DecimalSI)
+	case kuberesource.ConfigMaps:
+		usage[corev1api.ResourceConfigMaps] = *resource.NewQuantity(1, resource.DecimalSI)
+	case kuberesource.ReplicationControllers:
+		usage[corev1api.ResourceReplicationControllers] = *resource.NewQuantity(1, resource.DecimalSI)
+	case kuberesource.ResourceQuotas:
+		usage[corev1api.ResourceQuotas] = *resource.NewQuantity(1, resource.DecimalSI)
+	}
+
+	return usage
+}
+
+// podQuotaUsage returns the compute resource quota usage of pod, which is the
+// larger of the sum of its containers' requests and limits, and the largest
+// of its init containers'.
+func podQuotaUsage(pod *corev1api.Pod) corev1api.ResourceList {
+	requests, limits := corev1api.ResourceList{}, corev1api.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+		addResources(limits, container.Resources.Limits)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		maxResources(requests, container.Resources.Requests)
+		maxResources(limits, container.Resources.Limits)
+	}
+
+	usage := corev1api.ResourceList{}
+	for name, quantity := range requests {
+		usage[corev1api.ResourceName("requests."+string(name))] = quantity
+
+		// requests of the standard compute resources can also be limited by
+		// quotas of the resource itself
+		switch name {
+		case corev1api.ResourceCPU, corev1api.ResourceMemory, corev1api.ResourceEphemeralStorage:
+			usage[name] = quantity
+		}
+	}
+	for name, quantity := range limits {
+		usage[corev1api.ResourceName("limits."+string(name))] = quantity
+	}
+
+	return usage
+}
+
+// pvcQuotaUsage returns the storage resource quota usage of pvc, in total and for
+// its storage class.
+func pvcQuotaUsage(pvc *corev1api.PersistentVolumeClaim) corev1api.ResourceList {
+	usage := corev1api.ResourceList{}
+
+	storage, ok := pvc.Spec.Resources.Requests[corev1api.ResourceStorage]
+	if ok {
+		usage[corev1api.ResourceRequestsStorage] = storage
+	}
+
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		prefix := *pvc.Spec.StorageClassName + ".storageclass.storage.k8s.io/"
+		usage[corev1api.ResourceName(prefix+string(corev1api.ResourcePersistentVolumeClaims))] = *resource.NewQuantity(1, resource.DecimalSI)
+		if ok {
+			usage[corev1api.ResourceName(prefix+string(corev1api.ResourceRequestsStorage))] = storage
+		}
+	}
+
+	return usage
+}
+
+// serviceQuotaUsage returns the load balancer and node port quota usage of svc.
+func serviceQuotaUsage(svc *corev1api.Service) corev1api.ResourceList {
+	switch svc.Spec.Type {
+	case corev1api.ServiceTypeLoadBalancer:
+		return corev1api.ResourceList{
+			corev1api.ResourceServicesLoadBalancers: *resource.NewQuantity(1, resource.DecimalSI),
+		}
+	case corev1api.ServiceTypeNodePort:
+		return corev1api.ResourceList{
+			corev1api.ResourceServicesNodePorts: *resource.NewQuantity(1, resource.DecimalSI),
+		}
+	}
+
+	return nil
+}
+
+// addResources adds the quantities of b to a.
+func addResources(a, b corev1api.ResourceList) {
+	for name, quantity := range b {
+		total := a[name]
+		total.Add(quantity)
+		a[name] = total
+	}
+}
+
+// maxResources sets the quantities of a to the larger of each quantity of a and b.
+func maxResources(a, b corev1api.ResourceList) {
+	for name, quantity := range b {
+		if current, ok := a[name]; !ok || quantity.Cmp(current) > 0 {
+			a[name] = quantity
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	go_context "context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/util/collections"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestCheckResourceQuotas(t *testing.T) {
+	newPod := func(name, cpu, memory string, phase corev1api.PodPhase) []byte {
+		pod := &corev1api.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: name},
+			Spec: corev1api.PodSpec{
+				Containers: []corev1api.Container{{
+					Name: "app",
+					Resources: corev1api.ResourceRequirements{
+						Requests: corev1api.ResourceList{
+							corev1api.ResourceCPU:    resource.MustParse(cpu),
+							corev1api.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				}},
+			},
+			Status: corev1api.PodStatus{Phase: phase},
+		}
+		data, err := json.Marshal(pod)
+		require.NoError(t, err)
+		return data
+	}
+
+	newQuota := func(namespace string, hard, used corev1api.ResourceList) *corev1api.ResourceQuota {
+		return &corev1api.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "quota"},
+			Status:     corev1api.ResourceQuotaStatus{Hard: hard, Used: used},
+		}
+	}
+
+	fileSystem := arktest.NewFakeFileSystem().
+		WithFile("bak/resources/pods/namespaces/ns-1/pod-1.json", newPod("pod-1", "500m", "1Gi", corev1api.PodRunning)).
+		WithFile("bak/resources/pods/namespaces/ns-1/pod-2.json", newPod("pod-2", "500m", "1Gi", corev1api.PodRunning)).
+		WithFile("bak/resources/pods/namespaces/ns-1/pod-3.json", newPod("pod-3", "4", "8Gi", corev1api.PodSucceeded))
+
+	tests := []struct {
+		name             string
+		namespaceMapping map[string]string
+		quotas           []*corev1api.ResourceQuota
+		expected         []quotaExceeded
+	}{
+		{
+			name: "namespace without quotas isn't checked",
+		},
+		{
+			name: "items within quota",
+			quotas: []*corev1api.ResourceQuota{
+				newQuota("ns-1",
+					corev1api.ResourceList{corev1api.ResourceRequestsCPU: resource.MustParse("2"), corev1api.ResourcePods: resource.MustParse("2")},
+					corev1api.ResourceList{corev1api.ResourceRequestsCPU: resource.MustParse("1")},
+				),
+			},
+		},
+		{
+			name: "items that exceed quota",
+			quotas: []*corev1api.ResourceQuota{
+				newQuota("ns-1",
+					corev1api.ResourceList{corev1api.ResourceMemory: resource.MustParse("4Gi"), corev1api.ResourcePods: resource.MustParse("3")},
+					corev1api.ResourceList{corev1api.ResourceMemory: resource.MustParse("3Gi"), corev1api.ResourcePods: resource.MustParse("2")},
+				),
+			},
+			expected: []quotaExceeded{
+				{namespace: "ns-1", message: "restoring items that use 2Gi of memory would exceed resource quota quota, which has 3Gi used of its 4Gi limit"},
+				{namespace: "ns-1", message: "restoring items that use 2 of pods would exceed resource quota quota, which has 2 used of its 3 limit"},
+			},
+		},
+		{
+			name:             "quotas of mapped namespace are checked",
+			namespaceMapping: map[string]string{"ns-1": "ns-2"},
+			quotas: []*corev1api.ResourceQuota{
+				newQuota("ns-1", corev1api.ResourceList{corev1api.ResourcePods: resource.MustParse("1")}, nil),
+				newQuota("ns-2", corev1api.ResourceList{corev1api.ResourcePods: resource.MustParse("1")}, nil),
+			},
+			expected: []quotaExceeded{
+				{namespace: "ns-2", message: "restoring items that use 2 of pods would exceed resource quota quota, which has 0 used of its 1 limit"},
+			},
+		},
+		{
+			name: "scoped quotas are ignored",
+			quotas: []*corev1api.ResourceQuota{
+				func() *corev1api.ResourceQuota {
+					quota := newQuota("ns-1", corev1api.ResourceList{corev1api.ResourcePods: resource.MustParse("1")}, nil)
+					quota.Spec.Scopes = []corev1api.ResourceQuotaScope{corev1api.ResourceQuotaScopeBestEffort}
+					return quota
+				}(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &context{
+				restore:              &api.Restore{Spec: api.RestoreSpec{NamespaceMapping: test.namespaceMapping}},
+				prioritizedResources: []schema.GroupResource{{Resource: "pods"}},
+				selector:             labels.Everything(),
+				fileSystem:           fileSystem,
+				resourceQuotaClient:  &fakeResourceQuotaClient{quotas: test.quotas},
+				log:                  arktest.NewLogger(),
+			}
+
+			resourceDirsMap := make(map[string]os.FileInfo)
+			resourceDirs, err := fileSystem.ReadDir("bak/resources")
+			require.NoError(t, err)
+			for _, dir := range resourceDirs {
+				resourceDirsMap[dir.Name()] = dir
+			}
+
+			exceeded, err := ctx.checkResourceQuotas("bak/resources", resourceDirsMap, collections.NewIncludesExcludes())
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, exceeded)
+		})
+	}
+}
+
+func TestRestoreFromDirFailsStrictQuotaPreflight(t *testing.T) {
+	pod := &corev1api.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "pod-1"},
+	}
+	data, err := json.Marshal(pod)
+	require.NoError(t, err)
+
+	ctx := &context{
+		goContext:            go_context.Background(),
+		restore:              &api.Restore{Spec: api.RestoreSpec{Strict: true}},
+		prioritizedResources: []schema.GroupResource{{Resource: "pods"}},
+		selector:             labels.Everything(),
+		fileSystem:           arktest.NewFakeFileSystem().WithFile("bak/resources/pods/namespaces/ns-1/pod-1.json", data),
+		namespaceClient:      &fakeNamespaceClient{},
+		resourceQuotaClient: &fakeResourceQuotaClient{quotas: []*corev1api.ResourceQuota{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "quota"},
+			Status: corev1api.ResourceQuotaStatus{
+				Hard: corev1api.ResourceList{corev1api.ResourcePods: resource.MustParse("0")},
+			},
+		}}},
+		log: arktest.NewLogger(),
+	}
+
+	warnings, errs := ctx.restoreFromDir("bak")
+
+	assert.Empty(t, warnings.Namespaces)
+	assert.Equal(t, []string{"restoring items that use 1 of pods would exceed resource quota quota, which has 0 used of its 0 limit"}, errs.Namespaces["ns-1"])
+	assert.Len(t, errs.Ark, 1)
+}
+
+type fakeResourceQuotaClient struct {
+	quotas []*corev1api.ResourceQuota
+
+	corev1.ResourceQuotaInterface
+}
+
+func (c *fakeResourceQuotaClient) ResourceQuotas(namespace string) corev1.ResourceQuotaInterface {
+	return &fakeResourceQuotaClient{quotas: c.quotasIn(namespace)}
+}
+
+func (c *fakeResourceQuotaClient) List(opts metav1.ListOptions) (*corev1api.ResourceQuotaList, error) {
+	list := new(corev1api.ResourceQuotaList)
+	for _, quota := range c.quotas {
+		list.Items = append(list.Items, *quota)
+	}
+	return list, nil
+}
+
+func (c *fakeResourceQuotaClient) quotasIn(namespace string) []*corev1api.ResourceQuota {
+	var quotas []*corev1api.ResourceQuota
+	for _, quota := range c.quotas {
+		if quota.Namespace == namespace {
+			quotas = append(quotas, quota)
+		}
+	}
+	return quotas
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/discovery"
+	"github.com/heptio/ark/pkg/kuberesource"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+// defaultWaitForReadyTimeout is how long to wait for a resource's restored items
+// to become ready, if the restore waits for them and doesn't set a timeout.
+const defaultWaitForReadyTimeout = 5 * time.Minute
+
+// ReadinessCheck is a custom check of whether a resource's restored items are
+// ready: an item is ready once JSONPath evaluates to Value for it.
+type ReadinessCheck struct {
+	// Resource is the resource whose items are checked, e.g. widgets.example.com.
+	Resource string `json:"resource"`
+
+	// JSONPath is a JSONPath template, as used by kubectl, evaluated against
+	// each item, e.g. {.status.conditions[?(@.type=="Ready")].status}.
+	JSONPath string `json:"jsonPath"`
+
+	// Value is what JSONPath evaluates to once an item is ready.
+	Value string `json:"value"`
+}
+
+// ParseReadinessChecks parses a YAML list of readiness checks, as stored in the
+// server ConfigMap, and returns an error if any of them is invalid.
+func ParseReadinessChecks(data string) ([]ReadinessCheck, error) {
+	var checks []ReadinessCheck
+	if err := yaml.Unmarshal([]byte(data), &checks); err != nil {
+		return nil, errors.Wrap(err, "error parsing readiness checks")
+	}
+
+	for _, check := range checks {
+		if check.Resource == "" {
+			return nil, errors.New("readiness check must have a resource")
+		}
+		if err := jsonpath.New(check.Resource).Parse(check.JSONPath); err != nil {
+			return nil, errors.Wrapf(err, "invalid JSONPath for readiness check of %s", check.Resource)
+		}
+	}
+
+	return checks, nil
+}
+
+// readinessRegistry maps group-resources to the functions that check whether
+// their restored items are ready.
+type readinessRegistry map[schema.GroupResource]func(runtime.Unstructured) bool
+
+// newReadinessRegistry returns a readinessRegistry with the built-in readiness checks
+// for persistent volumes, deployments, jobs and custom resource definitions, plus the
+// custom checks, which replace the built-in check for the same resource. Custom checks
+// of resources that aren't served by the cluster are skipped.
+func newReadinessRegistry(helper discovery.Helper, checks []ReadinessCheck, log logrus.FieldLogger) readinessRegistry {
+	registry := readinessRegistry{
+		kuberesource.PersistentVolumes:         isPVReady,
+		kuberesource.Deployments:               isDeploymentAvailable,
+		kuberesource.Jobs:                      isJobComplete,
+		kuberesource.CustomResourceDefinitions: isCRDEstablished,
+	}
+
+	for _, check := range checks {
+		gvr, _, err := helper.ResourceFor(schema.ParseGroupResource(check.Resource).WithVersion(""))
+		if err != nil {
+			log.WithError(err).Warnf("Skipping readiness check of resource %s", check.Resource)
+			continue
+		}
+
+		registry[gvr.GroupResource()] = jsonPathReadyFunc(check)
+	}
+
+	return registry
+}
+
+// getWaitForReadyTimeout returns how long to wait for each resource's restored items to
+// become ready, or zero if the restore doesn't wait for them.
+func getWaitForReadyTimeout(restore *api.Restore) time.Duration {
+	switch {
+	case !restore.Spec.WaitForReady:
+		return 0
+	case restore.Spec.WaitForReadyTimeout != nil:
+		return restore.Spec.WaitForReadyTimeout.Duration
+	default:
+		return defaultWaitForReadyTimeout
+	}
+}
+
+// jsonPathReadyFunc returns a function that checks whether check's JSONPath evaluates to
+// its value for an item. The template is parsed for each item, since a parsed template
+// can't be evaluated concurrently.
+func jsonPathReadyFunc(check ReadinessCheck) func(runtime.Unstructured) bool {
+	return func(obj runtime.Unstructured) bool {
+		template := jsonpath.New(check.Resource).AllowMissingKeys(true)
+		if err := template.Parse(check.JSONPath); err != nil {
+			return false
+		}
+
+		buf := new(bytes.Buffer)
+		if err := template.Execute(buf, obj.UnstructuredContent()); err != nil {
+			return false
+		}
+
+		return buf.String() == check.Value
+	}
+}
+
+// isDeploymentAvailable returns whether a deployment's controller has observed its
+// current spec and reports it as available.
+func isDeploymentAvailable(obj runtime.Unstructured) bool {
+	content := obj.UnstructuredContent()
+
+	generation, _ := collections.GetValue(content, "metadata.generation")
+	observedGeneration, _ := collections.GetValue(content, "status.observedGeneration")
+	if toInt64(observedGeneration) < toInt64(generation) {
+		return false
+	}
+
+	return hasTrueCondition(content, "Available")
+}
+
+// isJobComplete returns whether a job has completed.
+func isJobComplete(obj runtime.Unstructured) bool {
+	return hasTrueCondition(obj.UnstructuredContent(), "Complete")
+}
+
+// isCRDEstablished returns whether a custom resource definition is established, i.e.
+// whether its custom resources can be created.
+func isCRDEstablished(obj runtime.Unstructured) bool {
+	return hasTrueCondition(obj.UnstructuredContent(), "Established")
+}
+
+// hasTrueCondition returns whether obj has a status condition of conditionType whose
+// status is True.
+func hasTrueCondition(obj map[string]interface{}, conditionType string) bool {
+	conditions, err := collections.GetSlice(obj, "status.conditions")
+	if err != nil {
+		return false
+	}
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType && condition["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toInt64 converts a number from an unstructured object, which may have been
+// decoded from JSON as a float64, to an int64.
+func toInt64(val interface{}) int64 {
+	switch v := val.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
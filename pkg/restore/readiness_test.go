@@ -0,0 +1,270 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	go_context "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestParseReadinessChecks(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expected      []ReadinessCheck
+		expectedError string
+	}{
+		{
+			name: "valid checks are parsed",
+			data: `
+- resource: widgets.example.com
+  jsonPath: '{.status.conditions[?(@.type=="Ready")].status}'
+  value: "True"
+- resource: gadgets.example.com
+  jsonPath: '{.status.phase}'
+  value: Running
+`,
+			expected: []ReadinessCheck{
+				{Resource: "widgets.example.com", JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`, Value: "True"},
+				{Resource: "gadgets.example.com", JSONPath: "{.status.phase}", Value: "Running"},
+			},
+		},
+		{
+			name:          "check without a resource is invalid",
+			data:          "- jsonPath: '{.status.phase}'\n  value: Running",
+			expectedError: "readiness check must have a resource",
+		},
+		{
+			name:          "check with an invalid JSONPath is invalid",
+			data:          "- resource: widgets.example.com\n  jsonPath: '{.status.phase'\n  value: Running",
+			expectedError: "invalid JSONPath for readiness check of widgets.example.com",
+		},
+		{
+			name:          "data that isn't a list is invalid",
+			data:          "resource: widgets.example.com",
+			expectedError: "error parsing readiness checks",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checks, err := ParseReadinessChecks(test.data)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, checks)
+		})
+	}
+}
+
+func TestReadinessRegistry(t *testing.T) {
+	widgets := schema.GroupResource{Group: "example.com", Resource: "widgets"}
+	helper := arktest.NewFakeDiscoveryHelper(false, map[schema.GroupVersionResource]schema.GroupVersionResource{
+		widgets.WithVersion(""): widgets.WithVersion("v1"),
+	})
+	checks := []ReadinessCheck{
+		{Resource: "widgets.example.com", JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`, Value: "True"},
+		{Resource: "gadgets.example.com", JSONPath: "{.status.phase}", Value: "Running"},
+	}
+
+	registry := newReadinessRegistry(helper, checks, arktest.NewLogger())
+
+	_, ok := registry[schema.GroupResource{Group: "example.com", Resource: "gadgets"}]
+	assert.False(t, ok, "check of a resource the cluster doesn't serve should be skipped")
+
+	_, ok = registry[kuberesource.ConfigMaps]
+	assert.False(t, ok, "resources without a check shouldn't have one")
+
+	conditions := func(conditionType, status string) []interface{} {
+		return []interface{}{map[string]interface{}{"type": conditionType, "status": status}}
+	}
+
+	tests := []struct {
+		name          string
+		groupResource schema.GroupResource
+		obj           *unstructured.Unstructured
+		expected      bool
+	}{
+		{
+			name:          "available PV is ready",
+			groupResource: kuberesource.PersistentVolumes,
+			obj:           NewTestUnstructured().WithStatusField("phase", "Available").Unstructured,
+			expected:      true,
+		},
+		{
+			name:          "available deployment is ready",
+			groupResource: kuberesource.Deployments,
+			obj:           NewTestUnstructured().WithMetadataField("generation", int64(2)).WithStatusField("observedGeneration", int64(2)).WithStatusField("conditions", conditions("Available", "True")).Unstructured,
+			expected:      true,
+		},
+		{
+			name:          "deployment whose spec hasn't been observed isn't ready",
+			groupResource: kuberesource.Deployments,
+			obj:           NewTestUnstructured().WithMetadataField("generation", int64(2)).WithStatusField("observedGeneration", int64(1)).WithStatusField("conditions", conditions("Available", "True")).Unstructured,
+			expected:      false,
+		},
+		{
+			name:          "unavailable deployment isn't ready",
+			groupResource: kuberesource.Deployments,
+			obj:           NewTestUnstructured().WithStatusField("conditions", conditions("Available", "False")).Unstructured,
+			expected:      false,
+		},
+		{
+			name:          "complete job is ready",
+			groupResource: kuberesource.Jobs,
+			obj:           NewTestUnstructured().WithStatusField("conditions", conditions("Complete", "True")).Unstructured,
+			expected:      true,
+		},
+		{
+			name:          "job without conditions isn't ready",
+			groupResource: kuberesource.Jobs,
+			obj:           NewTestUnstructured().WithStatus().Unstructured,
+			expected:      false,
+		},
+		{
+			name:          "established CRD is ready",
+			groupResource: kuberesource.CustomResourceDefinitions,
+			obj:           NewTestUnstructured().WithStatusField("conditions", conditions("Established", "True")).Unstructured,
+			expected:      true,
+		},
+		{
+			name:          "CRD with only other conditions isn't ready",
+			groupResource: kuberesource.CustomResourceDefinitions,
+			obj:           NewTestUnstructured().WithStatusField("conditions", conditions("NamesAccepted", "True")).Unstructured,
+			expected:      false,
+		},
+		{
+			name:          "custom resource whose JSONPath has the value is ready",
+			groupResource: widgets,
+			obj:           NewTestUnstructured().WithStatusField("conditions", conditions("Ready", "True")).Unstructured,
+			expected:      true,
+		},
+		{
+			name:          "custom resource whose JSONPath has another value isn't ready",
+			groupResource: widgets,
+			obj:           NewTestUnstructured().WithStatusField("conditions", conditions("Ready", "False")).Unstructured,
+			expected:      false,
+		},
+		{
+			name:          "custom resource without the JSONPath's fields isn't ready",
+			groupResource: widgets,
+			obj:           NewTestUnstructured().Unstructured,
+			expected:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ready, ok := registry[test.groupResource]
+			require.True(t, ok)
+			assert.Equal(t, test.expected, ready(test.obj))
+		})
+	}
+}
+
+func TestGetWaitForReadyTimeout(t *testing.T) {
+	restore := arktest.NewTestRestore("heptio-ark", "restore-1", api.RestorePhaseInProgress).Restore
+	assert.Equal(t, time.Duration(0), getWaitForReadyTimeout(restore))
+
+	restore.Spec.WaitForReady = true
+	assert.Equal(t, defaultWaitForReadyTimeout, getWaitForReadyTimeout(restore))
+
+	restore.Spec.WaitForReadyTimeout = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, getWaitForReadyTimeout(restore))
+}
+
+func TestWaitForItemsReady(t *testing.T) {
+	job := func(name, status string) *unstructured.Unstructured {
+		return NewTestUnstructured().WithName(name).WithStatusField("conditions", []interface{}{
+			map[string]interface{}{"type": "Complete", "status": status},
+		}).Unstructured
+	}
+
+	resourceWatch := watch.NewFakeWithChanSize(4, false)
+	resourceWatch.Add(job("job-1", "False"))
+	resourceWatch.Add(job("job-1", "True"))
+	resourceWatch.Add(job("other-job", "True"))
+	resourceWatch.Modify(job("job-2", "False"))
+
+	resourceClient := &arktest.FakeDynamicClient{}
+	resourceClient.On("Watch", metav1.ListOptions{LabelSelector: "ark.heptio.com/restore-name=restore-1"}).Return(resourceWatch, nil)
+
+	ctx := &context{
+		goContext:           go_context.Background(),
+		log:                 arktest.NewLogger(),
+		restore:             arktest.NewTestRestore("heptio-ark", "restore-1", api.RestorePhaseInProgress).Restore,
+		readiness:           readinessRegistry{kuberesource.Jobs: isJobComplete},
+		waitForReadyTimeout: 100 * time.Millisecond,
+		watchSlots:          make(chan struct{}, 1),
+	}
+
+	warnings := ctx.waitForItemsReady(resourceClient, kuberesource.Jobs, "ns-1", []string{"job-1", "job-2"})
+
+	require.Len(t, warnings.Items, 1)
+	assert.Equal(t, "job-2", warnings.Items[0].Name)
+	assert.Contains(t, warnings.Items[0].Message, "didn't become ready within 100ms")
+	assert.True(t, resourceWatch.IsStopped())
+	assert.Len(t, ctx.watchSlots, 0, "watch's slot should be released once it's stopped")
+
+	// resources without a readiness check aren't waited on
+	warnings = ctx.waitForItemsReady(resourceClient, kuberesource.ConfigMaps, "ns-1", []string{"cm-1"})
+	assert.Empty(t, warnings.Items)
+	resourceClient.AssertNumberOfCalls(t, "Watch", 1)
+}
+
+func TestWatchUntilReadyWaitsForAWatchSlot(t *testing.T) {
+	goContext, cancel := go_context.WithCancel(go_context.Background())
+
+	ctx := &context{
+		goContext:  goContext,
+		log:        arktest.NewLogger(),
+		restore:    arktest.NewTestRestore("heptio-ark", "restore-1", api.RestorePhaseInProgress).Restore,
+		watchSlots: make(chan struct{}, 1),
+	}
+	// the only slot is taken by another watch
+	ctx.watchSlots <- struct{}{}
+
+	resourceClient := &arktest.FakeDynamicClient{}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	notReady, err := ctx.watchUntilReady(resourceClient, kuberesource.Jobs, sets.NewString("job-1"), isJobComplete, time.Minute)
+	require.Error(t, err)
+	assert.Equal(t, []string{"job-1"}, notReady.List())
+	resourceClient.AssertNotCalled(t, "Watch", mock.Anything)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/client"
+	"github.com/heptio/ark/pkg/discovery"
+	"github.com/heptio/ark/pkg/util/kube"
+)
+
+const (
+	// defaultRecreateTimeout is how long to wait for an existing object to be
+	// deleted before recreating it, if the restore doesn't set a timeout.
+	defaultRecreateTimeout = time.Minute
+
+	// recreatePollInterval is how often to check whether an existing object
+	// has been deleted.
+	recreatePollInterval = time.Second
+)
+
+// getRecreateResources resolves the restore's RecreateResources to group-resource
+// strings, and returns them along with how long to wait for existing objects to be
+// deleted. Restores whose conflict policy isn't Recreate don't recreate anything.
+func getRecreateResources(helper discovery.Helper, restore *api.Restore) (sets.String, time.Duration, error) {
+	if restore.Spec.ConflictPolicy != api.RestoreConflictPolicyRecreate {
+		return nil, 0, nil
+	}
+
+	resources := sets.NewString()
+	for _, resource := range restore.Spec.RecreateResources {
+		gvr, _, err := helper.ResourceFor(schema.ParseGroupResource(resource).WithVersion(""))
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "error resolving resource %s to recreate", resource)
+		}
+		gr := gvr.GroupResource()
+		resources.Insert(gr.String())
+	}
+
+	timeout := defaultRecreateTimeout
+	if restore.Spec.RecreateTimeout != nil {
+		timeout = restore.Spec.RecreateTimeout.Duration
+	}
+
+	return resources, timeout, nil
+}
+
+// recreate deletes the existing object with the same name as obj and creates obj in
+// its place. The existing object is deleted in the foreground, so its dependents are
+// deleted first, and obj isn't created until the existing object is gone, including
+// once its finalizers have run. If the existing object is the same as obj, it's kept,
+// and alreadyExists is returned so that it's handled like any other existing object.
+func (ctx *context) recreate(resourceClient client.Dynamic, obj *unstructured.Unstructured, alreadyExists error) (*unstructured.Unstructured, error) {
+	name := obj.GetName()
+
+	existing, err := resourceClient.Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// it was deleted since we tried to create obj
+		return resourceClient.Create(obj)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting existing %s to recreate it", kube.NamespaceAndName(obj))
+	}
+	uid := existing.GetUID()
+
+	// compare the existing object with obj like objects that aren't recreated
+	// are, so identical objects aren't needlessly recreated
+	comparable, err := resetMetadataAndStatus(existing.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	ctx.addRestoreMetadata(comparable)
+	if equality.Semantic.DeepEqual(comparable, obj) {
+		return nil, alreadyExists
+	}
+
+	ctx.log.Infof("Deleting existing %s %s to recreate it from the backup", obj.GetKind(), kube.NamespaceAndName(obj))
+	propagation := metav1.DeletePropagationForeground
+	err = resourceClient.Delete(name, &metav1.DeleteOptions{
+		Preconditions:     &metav1.Preconditions{UID: &uid},
+		PropagationPolicy: &propagation,
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "error deleting existing %s to recreate it", kube.NamespaceAndName(obj))
+	}
+
+	err = wait.PollImmediate(recreatePollInterval, ctx.recreateTimeout, func() (bool, error) {
+		current, err := resourceClient.Get(name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		// an object with a different UID was created in its place, so creating
+		// obj fails with the usual already exists error
+		return current.GetUID() != uid, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, errors.Errorf("existing %s wasn't deleted within %s, so it wasn't recreated; check its finalizers", kube.NamespaceAndName(obj), ctx.recreateTimeout)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error waiting for existing %s to be deleted", kube.NamespaceAndName(obj))
+	}
+
+	return resourceClient.Create(obj)
+}
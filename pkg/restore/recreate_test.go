@@ -0,0 +1,143 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/kuberesource"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestRecreate(t *testing.T) {
+	newConfigMap := func(uid, data string, labels map[string]string) *unstructured.Unstructured {
+		obj := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1"},"data":{"key":"` + data + `"}}`)
+		if uid != "" {
+			obj.SetUID(types.UID(uid))
+		}
+		if labels != nil {
+			obj.SetLabels(labels)
+		}
+		return obj
+	}
+	notFound := k8serrors.NewNotFound(kuberesource.ConfigMaps, "cm-1")
+	alreadyExists := k8serrors.NewAlreadyExists(kuberesource.ConfigMaps, "cm-1")
+	previousRestoreLabels := map[string]string{api.RestoreNameLabel: "previous-restore", api.BackupNameLabel: "backup-1"}
+
+	tests := []struct {
+		name            string
+		existing        *unstructured.Unstructured
+		afterDelete     *unstructured.Unstructured
+		expectDelete    bool
+		expectCreate    bool
+		expectedErr     error
+		expectedErrText string
+	}{
+		{
+			name:         "object deleted since the create failed is just created",
+			expectCreate: true,
+		},
+		{
+			name:        "identical existing object is kept",
+			existing:    newConfigMap("uid-1", "value", previousRestoreLabels),
+			expectedErr: alreadyExists,
+		},
+		{
+			name:         "different existing object is deleted and recreated",
+			existing:     newConfigMap("uid-1", "changed", previousRestoreLabels),
+			expectDelete: true,
+			expectCreate: true,
+		},
+		{
+			name:         "existing object replaced by another one is created once it's gone",
+			existing:     newConfigMap("uid-1", "changed", nil),
+			afterDelete:  newConfigMap("uid-2", "changed", nil),
+			expectDelete: true,
+			expectCreate: true,
+		},
+		{
+			name:            "existing object that isn't deleted in time isn't recreated",
+			existing:        newConfigMap("uid-1", "changed", nil),
+			afterDelete:     newConfigMap("uid-1", "changed", nil),
+			expectDelete:    true,
+			expectedErrText: "existing ns-1/cm-1 wasn't deleted within 10ms, so it wasn't recreated; check its finalizers",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &context{
+				restore: &api.Restore{
+					ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: "restore-1"},
+					Spec:       api.RestoreSpec{BackupName: "backup-1"},
+				},
+				log:             arktest.NewLogger(),
+				recreateTimeout: 10 * time.Millisecond,
+			}
+			obj := newConfigMap("", "value", nil)
+			ctx.addRestoreMetadata(obj)
+
+			resourceClient := &arktest.FakeDynamicClient{}
+			defer resourceClient.AssertExpectations(t)
+
+			if test.existing == nil {
+				resourceClient.On("Get", "cm-1", metav1.GetOptions{}).Return((*unstructured.Unstructured)(nil), notFound)
+			} else {
+				resourceClient.On("Get", "cm-1", metav1.GetOptions{}).Return(test.existing, nil).Once()
+			}
+
+			if test.expectDelete {
+				resourceClient.On("Delete", "cm-1", mock.MatchedBy(func(opts *metav1.DeleteOptions) bool {
+					return *opts.Preconditions.UID == test.existing.GetUID() && *opts.PropagationPolicy == metav1.DeletePropagationForeground
+				})).Return(nil)
+
+				if test.afterDelete == nil {
+					resourceClient.On("Get", "cm-1", metav1.GetOptions{}).Return((*unstructured.Unstructured)(nil), notFound)
+				} else {
+					resourceClient.On("Get", "cm-1", metav1.GetOptions{}).Return(test.afterDelete, nil)
+				}
+			}
+
+			if test.expectCreate {
+				resourceClient.On("Create", obj).Return(obj, nil)
+			}
+
+			created, err := ctx.recreate(resourceClient, obj, alreadyExists)
+
+			switch {
+			case test.expectedErr != nil:
+				assert.Equal(t, test.expectedErr, err)
+			case test.expectedErrText != "":
+				require.Error(t, err)
+				assert.Equal(t, test.expectedErrText, err.Error())
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, obj, created)
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ limitations under the License.
 package restore
 
 import (
-	"fmt"
+	"sort"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -26,33 +26,20 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
-	"github.com/heptio/ark/pkg/buildinfo"
 	"github.com/heptio/ark/pkg/restic"
 	"github.com/heptio/ark/pkg/util/kube"
 )
 
 type resticRestoreAction struct {
-	logger             logrus.FieldLogger
-	initContainerImage string
+	logger logrus.FieldLogger
 }
 
 func NewResticRestoreAction(logger logrus.FieldLogger) ItemAction {
 	return &resticRestoreAction{
-		logger:             logger,
-		initContainerImage: initContainerImage(),
+		logger: logger,
 	}
 }
 
-func initContainerImage() string {
-	tag := buildinfo.Version
-	if tag == "" {
-		tag = "latest"
-	}
-
-	// TODO allow full image URL to be overriden via CLI flag.
-	return fmt.Sprintf("gcr.io/heptio-images/ark-restic-restore-helper:%s", tag)
-}
-
 func (a *resticRestoreAction) AppliesTo() (ResourceSelector, error) {
 	return ResourceSelector{
 		IncludedResources: []string{"pods"},
@@ -70,50 +57,37 @@ func (a *resticRestoreAction) Execute(obj runtime.Unstructured, restore *api.Res
 
 	log := a.logger.WithField("pod", kube.NamespaceAndName(&pod))
 
-	volumeSnapshots := restic.GetPodSnapshotAnnotations(&pod)
-	if len(volumeSnapshots) == 0 {
+	claimSnapshots, volumeSnapshots := restic.SplitPodSnapshotAnnotations(&pod)
+	if len(claimSnapshots) == 0 && len(volumeSnapshots) == 0 {
 		log.Debug("No restic snapshot ID annotations found")
 		return obj, nil, nil
 	}
 
 	log.Info("Restic snapshot ID annotations found")
 
-	initContainer := corev1.Container{
-		Name:  restic.InitContainer,
-		Image: a.initContainerImage,
-		Args:  []string{string(restore.UID)},
-		Env: []corev1.EnvVar{
-			{
-				Name: "POD_NAMESPACE",
-				ValueFrom: &corev1.EnvVarSource{
-					FieldRef: &corev1.ObjectFieldSelector{
-						FieldPath: "metadata.namespace",
-					},
-				},
-			},
-			{
-				Name: "POD_NAME",
-				ValueFrom: &corev1.EnvVarSource{
-					FieldRef: &corev1.ObjectFieldSelector{
-						FieldPath: "metadata.name",
-					},
-				},
-			},
-		},
-	}
+	hasInitContainer := len(pod.Spec.InitContainers) > 0 && pod.Spec.InitContainers[0].Name == restic.InitContainer
 
-	for volumeName := range volumeSnapshots {
-		mount := corev1.VolumeMount{
-			Name:      volumeName,
-			MountPath: "/restores/" + volumeName,
+	// volumes backed by persistent volume claims are restored before the pod
+	// is created, so the pod only needs to wait for its other volumes.
+	if len(volumeSnapshots) == 0 {
+		if !hasInitContainer {
+			return obj, nil, nil
 		}
-		initContainer.VolumeMounts = append(initContainer.VolumeMounts, mount)
-	}
-
-	if len(pod.Spec.InitContainers) == 0 || pod.Spec.InitContainers[0].Name != "restic-wait" {
-		pod.Spec.InitContainers = append([]corev1.Container{initContainer}, pod.Spec.InitContainers...)
+		pod.Spec.InitContainers = pod.Spec.InitContainers[1:]
 	} else {
-		pod.Spec.InitContainers[0] = initContainer
+		var volumes []string
+		for volumeName := range volumeSnapshots {
+			volumes = append(volumes, volumeName)
+		}
+		sort.Strings(volumes)
+
+		initContainer := restic.NewRestoreHelperContainer(restic.InitContainer, restore.UID, volumes)
+
+		if hasInitContainer {
+			pod.Spec.InitContainers[0] = initContainer
+		} else {
+			pod.Spec.InitContainers = append([]corev1.Container{initContainer}, pod.Spec.InitContainers...)
+		}
 	}
 
 	res, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&pod)
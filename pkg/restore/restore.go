@@ -18,15 +18,17 @@ package restore
 
 import (
 	"archive/tar"
-	"compress/gzip"
 	go_context "context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -42,14 +44,20 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kubeerrs "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
 	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/cloudprovider"
 	"github.com/heptio/ark/pkg/discovery"
 	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+	"github.com/heptio/ark/pkg/httphook"
+	"github.com/heptio/ark/pkg/itemhash"
 	"github.com/heptio/ark/pkg/kuberesource"
 	"github.com/heptio/ark/pkg/restic"
 	"github.com/heptio/ark/pkg/util/boolptr"
@@ -67,7 +75,11 @@ type BlockStoreGetter interface {
 // Restorer knows how to restore a backup.
 type Restorer interface {
 	// Restore restores the backup data from backupReader, returning warnings and errors.
-	Restore(log logrus.FieldLogger,
+	// The namespaces and objects that are created are recorded in manifest, if it's not
+	// nil. The item the restore is working on, and the time spent on each resource, are
+	// recorded in progress, if it's not nil. Once ctx is done, no further items are restored.
+	Restore(ctx go_context.Context,
+		log logrus.FieldLogger,
 		restore *api.Restore,
 		backup *api.Backup,
 		volumeSnapshots []*volume.Snapshot,
@@ -75,7 +87,17 @@ type Restorer interface {
 		actions []ItemAction,
 		snapshotLocationLister listers.VolumeSnapshotLocationLister,
 		blockStoreGetter BlockStoreGetter,
+		manifest *Manifest,
+		progress *Progress,
 	) (api.RestoreResult, api.RestoreResult)
+
+	// AddObserver registers an Observer to be notified of the progress of
+	// restores that start after it's added.
+	AddObserver(observer Observer)
+
+	// SetItemHasher replaces the Hasher used to compare items in the cluster
+	// with the content hashes recorded in backups' archive indexes.
+	SetItemHasher(hasher itemhash.Hasher)
 }
 
 type gvString string
@@ -86,35 +108,74 @@ type kubernetesRestorer struct {
 	discoveryHelper       discovery.Helper
 	dynamicFactory        client.DynamicFactory
 	namespaceClient       corev1.NamespaceInterface
+	resourceQuotaClient   corev1.ResourceQuotasGetter
 	resticRestorerFactory restic.RestorerFactory
-	resticTimeout         time.Duration
-	resourcePriorities    []string
+	resticTimeout         func() time.Duration
+	resourcePriorities    func() []string
+	restorePriorityLister listers.RestorePriorityLister
 	fileSystem            filesystem.Interface
+	mergeStrategies       mergeStrategyRegistry
 	logger                logrus.FieldLogger
+	clientConfig          *rest.Config
+	maxExtractedSize      int64
+	scratchDir            string
+	resourceTimeouts      map[string]time.Duration
+	itemTimeout           time.Duration
+	failureThreshold      int
+	createWorkers         int
+	readinessChecks       func() []ReadinessCheck
+	watchSlots            chan struct{}
+	observers             observerRegistry
+	itemHasher            itemhash.Hasher
+	httpHookCaller        httphook.Caller
+
+	newDynamicFactory                func(config *rest.Config) (client.DynamicFactory, error)
+	newServiceAccountDynamicFactory  func(config *rest.Config, namespace, name string) (client.DynamicFactory, error)
+	newServiceAccountNamespaceClient func(config *rest.Config, namespace, name string) (corev1.NamespaceInterface, error)
 }
 
 // prioritizeResources returns an ordered, fully-resolved list of resources to restore based on
-// the provided discovery helper, resource priorities, and included/excluded resources.
-func prioritizeResources(helper discovery.Helper, priorities []string, includedResources *collections.IncludesExcludes, logger logrus.FieldLogger) ([]schema.GroupResource, error) {
-	var ret []schema.GroupResource
-
+// the provided discovery helper, resource priorities, and included/excluded resources. Resources
+// in first are restored first and resources in last are restored last, in the order listed; all
+// other resources are restored in between, sorted by name.
+func prioritizeResources(helper discovery.Helper, first, last []string, includedResources *collections.IncludesExcludes, logger logrus.FieldLogger) ([]schema.GroupResource, error) {
 	// set keeps track of resolved GroupResource names
 	set := sets.NewString()
 
-	// start by resolving priorities into GroupResources and adding them to ret
-	for _, r := range priorities {
-		gvr, _, err := helper.ResourceFor(schema.ParseGroupResource(r).WithVersion(""))
-		if err != nil {
-			return nil, err
-		}
-		gr := gvr.GroupResource()
+	// resolve resources into GroupResources, skipping any that are
+	// excluded or already resolved
+	resolve := func(resources []string) ([]schema.GroupResource, error) {
+		var res []schema.GroupResource
 
-		if !includedResources.ShouldInclude(gr.String()) {
-			logger.WithField("groupResource", gr).Info("Not including resource")
-			continue
+		for _, r := range resources {
+			gvr, _, err := helper.ResourceFor(schema.ParseGroupResource(r).WithVersion(""))
+			if err != nil {
+				return nil, err
+			}
+			gr := gvr.GroupResource()
+
+			if !includedResources.ShouldInclude(gr.String()) {
+				logger.WithField("groupResource", gr).Info("Not including resource")
+				continue
+			}
+			if set.Has(gr.String()) {
+				continue
+			}
+			res = append(res, gr)
+			set.Insert(gr.String())
 		}
-		ret = append(ret, gr)
-		set.Insert(gr.String())
+
+		return res, nil
+	}
+
+	ret, err := resolve(first)
+	if err != nil {
+		return nil, err
+	}
+
+	lastResources, err := resolve(last)
+	if err != nil {
+		return nil, err
 	}
 
 	// go through everything we got from discovery and add anything not in "set" to byName
@@ -145,38 +206,152 @@ func prioritizeResources(helper discovery.Helper, priorities []string, includedR
 		return byName[i].String() < byName[j].String()
 	})
 
-	// combine prioritized with by-name
+	// combine prioritized with by-name, followed by the ones to restore last
 	ret = append(ret, byName...)
+	ret = append(ret, lastResources...)
 
 	return ret, nil
 }
 
+// resourcePrioritiesFor returns the resources to restore first and last for the restore. If the
+// restore names a RestorePriority, it's used; otherwise the RestorePriority named "default" is used
+// if it exists. The server's resource priorities are always restored first, since resources such
+// as namespaces, PVs and PVCs have to be restored before the resources that use them, followed by
+// the RestorePriority's restoreFirst resources.
+func (kr *kubernetesRestorer) resourcePrioritiesFor(restore *api.Restore) ([]string, []string, error) {
+	name := restore.Spec.RestorePriorityName
+	if name == "" {
+		name = api.DefaultRestorePriorityName
+	}
+
+	if kr.restorePriorityLister != nil {
+		priority, err := kr.restorePriorityLister.RestorePriorities(restore.Namespace).Get(name)
+		switch {
+		case err == nil:
+			// prioritizeResources skips resources that are listed more than once, so
+			// the server's priorities keep their place even if the RestorePriority
+			// lists them later on, or to be restored last
+			first := append(append([]string(nil), kr.resourcePriorities()...), priority.Spec.RestoreFirst...)
+			return first, priority.Spec.RestoreLast, nil
+		case !apierrors.IsNotFound(err):
+			return nil, nil, errors.Wrapf(err, "error getting restore priority %s", name)
+		}
+	}
+
+	if restore.Spec.RestorePriorityName != "" {
+		return nil, nil, errors.Errorf("restore priority %s not found", restore.Spec.RestorePriorityName)
+	}
+
+	return kr.resourcePriorities(), nil, nil
+}
+
 // NewKubernetesRestorer creates a new kubernetesRestorer.
 func NewKubernetesRestorer(
 	discoveryHelper discovery.Helper,
 	dynamicFactory client.DynamicFactory,
-	resourcePriorities []string,
+	clientConfig *rest.Config,
+	resourcePriorities func() []string,
+	restorePriorityLister listers.RestorePriorityLister,
 	namespaceClient corev1.NamespaceInterface,
+	resourceQuotaClient corev1.ResourceQuotasGetter,
 	resticRestorerFactory restic.RestorerFactory,
-	resticTimeout time.Duration,
+	resticTimeout func() time.Duration,
+	maxExtractedSize int64,
+	scratchDir string,
+	resourceTimeouts map[string]time.Duration,
+	itemTimeout time.Duration,
+	failureThreshold int,
+	createWorkers int,
+	readinessChecks func() []ReadinessCheck,
 	logger logrus.FieldLogger,
 ) (Restorer, error) {
 	return &kubernetesRestorer{
 		discoveryHelper:       discoveryHelper,
 		dynamicFactory:        dynamicFactory,
 		namespaceClient:       namespaceClient,
+		resourceQuotaClient:   resourceQuotaClient,
 		resticRestorerFactory: resticRestorerFactory,
 		resticTimeout:         resticTimeout,
 		resourcePriorities:    resourcePriorities,
+		restorePriorityLister: restorePriorityLister,
 		logger:                logger,
 		fileSystem:            filesystem.NewFileSystem(),
+		mergeStrategies:       newMergeStrategyRegistry(),
+		clientConfig:          clientConfig,
+		maxExtractedSize:      maxExtractedSize,
+		scratchDir:            scratchDir,
+		resourceTimeouts:      resourceTimeouts,
+		itemTimeout:           itemTimeout,
+		failureThreshold:      failureThreshold,
+		createWorkers:         createWorkers,
+		readinessChecks:       readinessChecks,
+		watchSlots:            make(chan struct{}, maxReadinessWatches),
+		itemHasher:            itemhash.NewDefaultHasher(),
+		httpHookCaller:        httphook.NewCaller(),
+
+		newDynamicFactory:                client.NewDynamicFactoryForConfig,
+		newServiceAccountDynamicFactory:  client.NewServiceAccountDynamicFactory,
+		newServiceAccountNamespaceClient: newServiceAccountNamespaceClient,
 	}, nil
 }
 
+// newServiceAccountNamespaceClient returns a namespace client that impersonates the
+// given service account, so that namespaces are only created and updated if the
+// service account is allowed to.
+func newServiceAccountNamespaceClient(config *rest.Config, namespace, name string) (corev1.NamespaceInterface, error) {
+	kubeClient, err := kubernetes.NewForConfig(client.ServiceAccountConfig(config, namespace, name))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return kubeClient.CoreV1().Namespaces(), nil
+}
+
+const (
+	// pvReadyTimeout is how long to wait for the PVs restored from snapshots
+	// to become available.
+	pvReadyTimeout = time.Minute
+
+	// maxReadinessWatches is how many watches can be open at once, across all
+	// restores, while waiting for restored items to become ready, so that big
+	// restores don't use up the API server's capacity for watches.
+	maxReadinessWatches = 10
+
+	// createBatchItemsPerWorker is how many of a resource's items are batched for
+	// each worker that creates them, when items are created concurrently.
+	createBatchItemsPerWorker = 10
+
+	// minThrottleDelay and maxThrottleDelay bound how long each of a restore's
+	// requests to the API server is delayed once the API server starts
+	// throttling them.
+	minThrottleDelay = 100 * time.Millisecond
+	maxThrottleDelay = 30 * time.Second
+)
+
+// serviceAccountNamespace returns the namespace of the service account to impersonate
+// for the restore. Self-service restores use service accounts from the namespace they
+// were created in.
+func serviceAccountNamespace(restore *api.Restore) string {
+	if ns := restore.Labels[api.SelfServiceNamespaceLabel]; ns != "" {
+		return ns
+	}
+	return restore.Namespace
+}
+
+func (kr *kubernetesRestorer) AddObserver(observer Observer) {
+	kr.observers.add(observer)
+}
+
+func (kr *kubernetesRestorer) SetItemHasher(hasher itemhash.Hasher) {
+	kr.itemHasher = hasher
+}
+
 // Restore executes a restore into the target Kubernetes cluster according to the restore spec
 // and using data from the provided backup/backup reader. Returns a warnings and errors RestoreResult,
-// respectively, summarizing info about the restore.
+// respectively, summarizing info about the restore. If ctx is done before the restore completes,
+// the remaining items are skipped and an error is returned.
 func (kr *kubernetesRestorer) Restore(
+	goContext go_context.Context,
 	log logrus.FieldLogger,
 	restore *api.Restore,
 	backup *api.Backup,
@@ -185,7 +360,12 @@ func (kr *kubernetesRestorer) Restore(
 	actions []ItemAction,
 	snapshotLocationLister listers.VolumeSnapshotLocationLister,
 	blockStoreGetter BlockStoreGetter,
+	manifest *Manifest,
+	progress *Progress,
 ) (api.RestoreResult, api.RestoreResult) {
+	observers := kr.observers.list()
+	observers.OnPhaseChange(restore, api.RestorePhaseInProgress)
+	defer observers.OnPhaseChange(restore, api.RestorePhaseCompleted)
 
 	// metav1.LabelSelectorAsSelector converts a nil LabelSelector to a
 	// Nothing Selector, i.e. a selector that matches nothing. We want
@@ -203,17 +383,81 @@ func (kr *kubernetesRestorer) Restore(
 
 	// get resource includes-excludes
 	resourceIncludesExcludes := getResourceIncludesExcludes(kr.discoveryHelper, restore.Spec.IncludedResources, restore.Spec.ExcludedResources)
-	prioritizedResources, err := prioritizeResources(kr.discoveryHelper, kr.resourcePriorities, resourceIncludesExcludes, log)
+	restoreFirst, restoreLast, err := kr.resourcePrioritiesFor(restore)
+	if err != nil {
+		return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
+	}
+
+	prioritizedResources, err := prioritizeResources(kr.discoveryHelper, restoreFirst, restoreLast, resourceIncludesExcludes, log)
+	if err != nil {
+		return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
+	}
+
+	securityPolicyTranslator, err := newSecurityPolicyTranslator(kr.discoveryHelper, restore.Spec.SecurityPolicyTranslation, restore.Spec.SecurityPolicyMapping)
 	if err != nil {
 		return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
 	}
+	if securityPolicyTranslator != nil {
+		prioritizedResources = securityPolicyTranslator.prioritize(prioritizedResources, resourceIncludesExcludes)
+	}
 
 	resolvedActions, err := resolveActions(actions, kr.discoveryHelper)
 	if err != nil {
 		return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
 	}
 
-	podVolumeTimeout := kr.resticTimeout
+	hooks, err := getRestoreHooks(restore.Spec.Hooks.Resources, kr.discoveryHelper)
+	if err != nil {
+		return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
+	}
+
+	recreateResources, recreateTimeout, err := getRecreateResources(kr.discoveryHelper, restore)
+	if err != nil {
+		return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
+	}
+
+	var resourceTimeout time.Duration
+	if restore.Spec.ResourceTimeout != nil {
+		resourceTimeout = restore.Spec.ResourceTimeout.Duration
+	}
+
+	var readiness readinessRegistry
+	waitForReadyTimeout := getWaitForReadyTimeout(restore)
+	if waitForReadyTimeout > 0 {
+		var checks []ReadinessCheck
+		if kr.readinessChecks != nil {
+			checks = kr.readinessChecks()
+		}
+		readiness = newReadinessRegistry(kr.discoveryHelper, checks, log)
+	}
+
+	clientConfig, lowered := client.LowerRateLimits(kr.clientConfig, restore.Spec.ClientQPS, restore.Spec.ClientBurst)
+
+	dynamicFactory, namespaceClient := kr.dynamicFactory, kr.namespaceClient
+	switch name := restore.Spec.ServiceAccountName; {
+	case name != "":
+		namespace := serviceAccountNamespace(restore)
+		log.Infof("Restoring objects as service account %s/%s", namespace, name)
+
+		if dynamicFactory, err = kr.newServiceAccountDynamicFactory(clientConfig, namespace, name); err != nil {
+			return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
+		}
+		if namespaceClient, err = kr.newServiceAccountNamespaceClient(clientConfig, namespace, name); err != nil {
+			return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
+		}
+	case lowered:
+		log.Infof("Restoring objects with client QPS %v and burst %d", clientConfig.QPS, clientConfig.Burst)
+
+		if dynamicFactory, err = kr.newDynamicFactory(clientConfig); err != nil {
+			return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
+		}
+	}
+
+	// all of the restore's requests share a throttle, so that once the API
+	// server starts rejecting them, the restore as a whole slows down
+	dynamicFactory = client.NewThrottledDynamicFactory(dynamicFactory, client.NewAdaptiveThrottle(minThrottleDelay, maxThrottleDelay))
+
+	podVolumeTimeout := kr.resticTimeout()
 	if val := restore.Annotations[api.PodVolumeOperationTimeoutAnnotation]; val != "" {
 		parsed, err := time.ParseDuration(val)
 		if err != nil {
@@ -223,12 +467,14 @@ func (kr *kubernetesRestorer) Restore(
 		}
 	}
 
-	ctx, cancelFunc := go_context.WithTimeout(go_context.Background(), podVolumeTimeout)
+	// the timeout applies to each pod volume restore individually, so the
+	// restorer's context only needs to be cancelled once the restore is done.
+	podVolumeCtx, cancelFunc := go_context.WithCancel(goContext)
 	defer cancelFunc()
 
 	var resticRestorer restic.Restorer
 	if kr.resticRestorerFactory != nil {
-		resticRestorer, err = kr.resticRestorerFactory.NewRestorer(ctx, restore)
+		resticRestorer, err = kr.resticRestorerFactory.NewRestorer(podVolumeCtx, restore, podVolumeTimeout)
 		if err != nil {
 			return api.RestoreResult{}, api.RestoreResult{Ark: []string{err.Error()}}
 		}
@@ -239,27 +485,52 @@ func (kr *kubernetesRestorer) Restore(
 		backup:                 backup,
 		snapshotVolumes:        backup.Spec.SnapshotVolumes,
 		restorePVs:             restore.Spec.RestorePVs,
+		volumeIDMapping:        restore.Spec.VolumeIDMapping,
+		volumeOverrides:        restore.Spec.VolumeOverrides,
 		volumeSnapshots:        volumeSnapshots,
 		blockStoreGetter:       blockStoreGetter,
 		snapshotLocationLister: snapshotLocationLister,
 	}
 
 	restoreCtx := &context{
-		backup:               backup,
-		backupReader:         backupReader,
-		restore:              restore,
-		prioritizedResources: prioritizedResources,
-		selector:             selector,
-		log:                  log,
-		dynamicFactory:       kr.dynamicFactory,
-		fileSystem:           kr.fileSystem,
-		namespaceClient:      kr.namespaceClient,
-		actions:              resolvedActions,
-		blockStoreGetter:     blockStoreGetter,
-		resticRestorer:       resticRestorer,
-		pvsToProvision:       sets.NewString(),
-		pvRestorer:           pvRestorer,
-		volumeSnapshots:      volumeSnapshots,
+		goContext:                goContext,
+		backup:                   backup,
+		backupReader:             backupReader,
+		restore:                  restore,
+		prioritizedResources:     prioritizedResources,
+		selector:                 selector,
+		log:                      log,
+		dynamicFactory:           dynamicFactory,
+		fileSystem:               kr.fileSystem,
+		maxExtractedSize:         kr.maxExtractedSize,
+		scratchDir:               kr.scratchDir,
+		resourceTimeouts:         kr.resourceTimeouts,
+		resourceTimeout:          resourceTimeout,
+		itemTimeout:              kr.itemTimeout,
+		failureThreshold:         kr.failureThreshold,
+		createWorkers:            kr.createWorkers,
+		namespaceClient:          namespaceClient,
+		resourceQuotaClient:      kr.resourceQuotaClient,
+		actions:                  resolvedActions,
+		blockStoreGetter:         blockStoreGetter,
+		resticRestorer:           resticRestorer,
+		pvsToProvision:           sets.NewString(),
+		renamedPVs:               make(map[string]string),
+		pvRestorer:               pvRestorer,
+		volumeSnapshots:          volumeSnapshots,
+		mergeStrategies:          kr.mergeStrategies,
+		observers:                observers,
+		itemHasher:               kr.itemHasher,
+		securityPolicyTranslator: securityPolicyTranslator,
+		hooks:                    hooks,
+		httpHookCaller:           kr.httpHookCaller,
+		manifest:                 manifest,
+		progress:                 progress,
+		recreateResources:        recreateResources,
+		recreateTimeout:          recreateTimeout,
+		readiness:                readiness,
+		waitForReadyTimeout:      waitForReadyTimeout,
+		watchSlots:               kr.watchSlots,
 	}
 
 	return restoreCtx.execute()
@@ -327,6 +598,7 @@ func resolveActions(actions []ItemAction, helper discovery.Helper) ([]resolvedAc
 }
 
 type context struct {
+	goContext            go_context.Context
 	backup               *api.Backup
 	backupReader         io.Reader
 	restore              *api.Restore
@@ -335,16 +607,52 @@ type context struct {
 	log                  logrus.FieldLogger
 	dynamicFactory       client.DynamicFactory
 	fileSystem           filesystem.Interface
+	maxExtractedSize     int64
+	scratchDir           string
+	resourceTimeouts     map[string]time.Duration
+	resourceTimeout      time.Duration
+	resourceContexts     map[string]go_context.Context
+	resourceCancels      []go_context.CancelFunc
+	itemTimeout          time.Duration
+	failureThreshold     int
+	createWorkers        int
 	namespaceClient      corev1.NamespaceInterface
+	resourceQuotaClient  corev1.ResourceQuotasGetter
 	actions              []resolvedAction
 	blockStoreGetter     BlockStoreGetter
 	resticRestorer       restic.Restorer
 	globalWaitGroup      arksync.ErrorGroup
-	resourceWaitGroup    sync.WaitGroup
-	resourceWatches      []watch.Interface
 	pvsToProvision       sets.String
+	renamedPVs           map[string]string
 	pvRestorer           PVRestorer
 	volumeSnapshots      []*volume.Snapshot
+	mergeStrategies      mergeStrategyRegistry
+	observers            observers
+	itemHasher           itemhash.Hasher
+	itemHashes           map[string]string
+	// securityPolicyTranslator translates the backup's pod security policies or
+	// security context constraints, if the restore translates them.
+	securityPolicyTranslator *securityPolicyTranslator
+	// hooks are the restore's resolved hook specs, which are called by httpHookCaller.
+	hooks          []restoreHook
+	httpHookCaller httphook.Caller
+	// manifest records the namespaces and objects created by the restore.
+	manifest *Manifest
+	// progress records the item the restore is working on and the time spent on each resource.
+	progress *Progress
+	// recreateResources are the group-resources whose existing objects are
+	// deleted and recreated, waiting up to recreateTimeout for them to be deleted.
+	recreateResources sets.String
+	recreateTimeout   time.Duration
+	// readiness has the readiness checks of the resources whose restored items are
+	// waited on for up to waitForReadyTimeout, if it's not zero, before the next
+	// resource is restored.
+	readiness           readinessRegistry
+	waitForReadyTimeout time.Duration
+	// watchSlots bounds the number of watches that are open at once, across all
+	// restores, while waiting for restored items to become ready. If nil, there's
+	// no bound.
+	watchSlots chan struct{}
 }
 
 func (ctx *context) execute() (api.RestoreResult, api.RestoreResult) {
@@ -387,6 +695,15 @@ func (ctx *context) restoreFromDir(dir string) (api.RestoreResult, api.RestoreRe
 		return warnings, errs
 	}
 
+	// with the three-way merge conflict policy, items that are identical to
+	// their in-cluster versions are skipped without any API calls, using
+	// the content hashes recorded in the backup's index
+	if ctx.restore.Spec.ConflictPolicy == api.RestoreConflictPolicyThreeWayMerge && ctx.itemHasher != nil {
+		if ctx.itemHashes, err = ctx.readItemHashes(dir); err != nil {
+			ctx.log.WithError(err).Warn("Unable to read item hashes from backup's index, comparing all items with their in-cluster versions")
+		}
+	}
+
 	resourceDirsMap := make(map[string]os.FileInfo)
 
 	for _, rscDir := range resourceDirs {
@@ -396,17 +713,188 @@ func (ctx *context) restoreFromDir(dir string) (api.RestoreResult, api.RestoreRe
 
 	existingNamespaces := sets.NewString()
 
-	// TODO this is not optimal since it'll keep watches open for all resources/namespaces
-	// until the very end of the restore. This should be done per resource type. Deferring
-	// refactoring for now since this may be able to be removed entirely if we eliminate
-	// waiting for PV snapshot restores.
+	// fail before restoring anything if a namespace that objects would be restored
+	// into doesn't exist and the restore isn't allowed to create it
+	checkedNamespaces := sets.NewString()
+	if err := ctx.checkTargetNamespaces(resourcesDir, resourceDirsMap, namespaceFilter, checkedNamespaces); err != nil {
+		addArkError(&errs, err)
+		return warnings, errs
+	}
+
+	// check that the items restored into each namespace won't exceed its resource
+	// quotas, rather than failing to create them one by one mid-restore
+	if ctx.resourceQuotaClient != nil {
+		exceeded, err := ctx.checkResourceQuotas(resourcesDir, resourceDirsMap, namespaceFilter)
+		if err != nil {
+			ctx.log.WithError(err).Warn("Unable to check resource quotas of target namespaces")
+			addArkError(&warnings, errors.Wrap(err, "unable to check resource quotas of target namespaces"))
+		}
+
+		for _, e := range exceeded {
+			if ctx.restore.Spec.Strict {
+				addToResult(&errs, e.namespace, errors.New(e.message))
+			} else {
+				addToResult(&warnings, e.namespace, errors.New(e.message))
+			}
+		}
+		if len(exceeded) > 0 && ctx.restore.Spec.Strict {
+			addArkError(&errs, errors.New("restore would exceed resource quotas of target namespaces and is strict"))
+			return warnings, errs
+		}
+	}
+
 	defer func() {
-		for _, watch := range ctx.resourceWatches {
-			watch.Stop()
+		for _, cancel := range ctx.resourceCancels {
+			cancel()
 		}
 	}()
 
+	passes, err := ctx.restorePasses(resourcesDir, resourceDirsMap)
+	if err != nil {
+		addArkError(&errs, err)
+		return warnings, errs
+	}
+
+	for _, pass := range passes {
+		w, e, err := ctx.restorePass(dir, resourceDirsMap, pass, namespaceFilter, existingNamespaces, checkedNamespaces)
+		merge(&warnings, &w)
+		merge(&errs, &e)
+		if err != nil {
+			addArkError(&errs, err)
+			return warnings, errs
+		}
+
+		if err := ctx.goContext.Err(); err != nil {
+			addArkError(&errs, errors.Wrap(err, "restore stopped before all resources were restored"))
+			break
+		}
+	}
+
+	// TODO timeout?
+	ctx.log.Debug("Waiting on global wait group")
+	waitErrs := ctx.globalWaitGroup.Wait()
+	ctx.log.Debug("Done waiting on global wait group")
+
+	for _, err := range waitErrs {
+		// TODO not ideal to be adding these to Ark-level errors
+		// rather than a specific namespace, but don't have a way
+		// to track the namespace right now.
+		errs.Ark = append(errs.Ark, err.Error())
+	}
+
+	return warnings, errs
+}
+
+// restorePass is a set of the backup's items that are restored together, resource by
+// resource in priority order.
+type restorePass struct {
+	// clusterScoped is whether the pass restores cluster-scoped items.
+	clusterScoped bool
+
+	// includesNamespace returns whether the pass restores the items of a namespace in
+	// the backup. If nil, the pass restores the items of all namespaces.
+	includesNamespace func(string) bool
+}
+
+// restorePasses returns the passes in which the restore's items are restored. By default,
+// all items are restored in a single pass. Otherwise, the cluster-scoped items are restored
+// first, then the items of each namespace in the restore's namespace order in its own pass,
+// and then the items of all other namespaces, in one pass or, if the restore iterates by
+// namespace, in a pass for each namespace.
+func (ctx *context) restorePasses(resourcesDir string, resourceDirsMap map[string]os.FileInfo) ([]restorePass, error) {
+	byNamespace := ctx.restore.Spec.IterationMode == api.RestoreIterationModeByNamespace
+
+	if len(ctx.restore.Spec.NamespaceOrder) == 0 && !byNamespace {
+		return []restorePass{{clusterScoped: true}}, nil
+	}
+
+	passes := []restorePass{{
+		clusterScoped:     true,
+		includesNamespace: func(string) bool { return false },
+	}}
+
+	namespacePass := func(namespace string) restorePass {
+		return restorePass{
+			includesNamespace: func(name string) bool { return name == namespace },
+		}
+	}
+
+	ordered := sets.NewString(ctx.restore.Spec.NamespaceOrder...)
+	for _, namespace := range ctx.restore.Spec.NamespaceOrder {
+		passes = append(passes, namespacePass(namespace))
+	}
+
+	if !byNamespace {
+		passes = append(passes, restorePass{
+			includesNamespace: func(name string) bool { return !ordered.Has(name) },
+		})
+		return passes, nil
+	}
+
+	namespaces, err := ctx.backupNamespaces(resourcesDir, resourceDirsMap)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces {
+		if !ordered.Has(namespace) {
+			passes = append(passes, namespacePass(namespace))
+		}
+	}
+
+	return passes, nil
+}
+
+// backupNamespaces returns the sorted names of the namespaces that the backup has items of.
+func (ctx *context) backupNamespaces(resourcesDir string, resourceDirsMap map[string]os.FileInfo) ([]string, error) {
+	namespaces := sets.NewString()
+
+	for _, resource := range ctx.prioritizedResources {
+		if resource == kuberesource.Namespaces || resourceDirsMap[resource.String()] == nil {
+			continue
+		}
+
+		nsSubDir := filepath.Join(resourcesDir, resource.String(), api.NamespaceScopedDir)
+		nsSubDirExists, err := ctx.fileSystem.DirExists(nsSubDir)
+		if err != nil {
+			return nil, err
+		}
+		if !nsSubDirExists {
+			continue
+		}
+
+		nsDirs, err := ctx.fileSystem.ReadDir(nsSubDir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, nsDir := range nsDirs {
+			if nsDir.IsDir() {
+				namespaces.Insert(nsDir.Name())
+			}
+		}
+	}
+
+	return namespaces.List(), nil
+}
+
+// restorePass restores the items of pass that the restore includes, resource by resource
+// in priority order. It stops early, without an error, if the restore's context is done.
+// The returned error is for failures that keep the restore from going on.
+func (ctx *context) restorePass(
+	dir string,
+	resourceDirsMap map[string]os.FileInfo,
+	pass restorePass,
+	namespaceFilter *collections.IncludesExcludes,
+	existingNamespaces, checkedNamespaces sets.String,
+) (api.RestoreResult, api.RestoreResult, error) {
+	warnings, errs := api.RestoreResult{}, api.RestoreResult{}
+	resourcesDir := filepath.Join(dir, api.ResourcesDir)
+
 	for _, resource := range ctx.prioritizedResources {
+		if ctx.goContext.Err() != nil {
+			break
+		}
+
 		// we don't want to explicitly restore namespace API objs because we'll handle
 		// them as a special case prior to restoring anything into them
 		if resource == kuberesource.Namespaces {
@@ -423,21 +911,21 @@ func (ctx *context) restoreFromDir(dir string) (api.RestoreResult, api.RestoreRe
 		clusterSubDir := filepath.Join(resourcePath, api.ClusterScopedDir)
 		clusterSubDirExists, err := ctx.fileSystem.DirExists(clusterSubDir)
 		if err != nil {
-			addArkError(&errs, err)
-			return warnings, errs
+			return warnings, errs, err
 		}
 		if clusterSubDirExists {
-			w, e := ctx.restoreResource(resource.String(), "", clusterSubDir)
-			merge(&warnings, &w)
-			merge(&errs, &e)
+			if pass.clusterScoped {
+				w, e := ctx.restoreResource(resource.String(), "", clusterSubDir)
+				merge(&warnings, &w)
+				merge(&errs, &e)
+			}
 			continue
 		}
 
 		nsSubDir := filepath.Join(resourcePath, api.NamespaceScopedDir)
 		nsSubDirExists, err := ctx.fileSystem.DirExists(nsSubDir)
 		if err != nil {
-			addArkError(&errs, err)
-			return warnings, errs
+			return warnings, errs, err
 		}
 		if !nsSubDirExists {
 			continue
@@ -445,8 +933,7 @@ func (ctx *context) restoreFromDir(dir string) (api.RestoreResult, api.RestoreRe
 
 		nsDirs, err := ctx.fileSystem.ReadDir(nsSubDir)
 		if err != nil {
-			addArkError(&errs, err)
-			return warnings, errs
+			return warnings, errs, err
 		}
 
 		for _, nsDir := range nsDirs {
@@ -456,6 +943,10 @@ func (ctx *context) restoreFromDir(dir string) (api.RestoreResult, api.RestoreRe
 			nsName := nsDir.Name()
 			nsPath := filepath.Join(nsSubDir, nsName)
 
+			if pass.includesNamespace != nil && !pass.includesNamespace(nsName) {
+				continue
+			}
+
 			if !namespaceFilter.ShouldInclude(nsName) {
 				ctx.log.Infof("Skipping namespace %s", nsName)
 				continue
@@ -473,10 +964,32 @@ func (ctx *context) restoreFromDir(dir string) (api.RestoreResult, api.RestoreRe
 			// create a blank one.
 			if !existingNamespaces.Has(mappedNsName) {
 				logger := ctx.log.WithField("namespace", nsName)
-				ns := getNamespace(logger, filepath.Join(dir, api.ResourcesDir, "namespaces", api.ClusterScopedDir, nsName+".json"), mappedNsName)
-				if _, err := kube.EnsureNamespaceExists(ns, ctx.namespaceClient); err != nil {
-					addArkError(&errs, err)
-					continue
+				ns := getNamespace(logger, ctx.fileSystem, filepath.Join(dir, api.ResourcesDir, "namespaces", api.ClusterScopedDir, nsName+".json"), mappedNsName)
+
+				// namespaces that were checked against the namespace creation policy
+				// are known to exist, so don't try to create them
+				created := false
+				if !checkedNamespaces.Has(mappedNsName) {
+					if created, err = kube.EnsureNamespaceExists(ns, ctx.namespaceClient); err != nil {
+						addArkError(&errs, err)
+						continue
+					}
+				}
+
+				if created && ctx.manifest != nil {
+					if createdNs, err := ctx.namespaceClient.Get(mappedNsName, metav1.GetOptions{}); err != nil {
+						logger.WithError(errors.WithStack(err)).Warn("Unable to get created namespace, so it won't be recorded in the restore's manifest")
+					} else {
+						ctx.manifest.addNamespace(createdNs)
+					}
+				}
+
+				if !created && ctx.restore.Spec.MergeNamespaceMetadata {
+					logger.Info("Merging backed-up labels and annotations into existing namespace")
+					if err := kube.MergeNamespaceMetadata(ns, ctx.namespaceClient); err != nil {
+						addArkError(&errs, err)
+						continue
+					}
 				}
 
 				// keep track of namespaces that we know exist so we don't
@@ -488,75 +1001,219 @@ func (ctx *context) restoreFromDir(dir string) (api.RestoreResult, api.RestoreRe
 			merge(&warnings, &w)
 			merge(&errs, &e)
 		}
-
-		// TODO timeout?
-		ctx.log.Debugf("Waiting on resource wait group for resource=%s", resource.String())
-		ctx.resourceWaitGroup.Wait()
-		ctx.log.Debugf("Done waiting on resource wait group for resource=%s", resource.String())
 	}
 
-	// TODO timeout?
-	ctx.log.Debug("Waiting on global wait group")
-	waitErrs := ctx.globalWaitGroup.Wait()
-	ctx.log.Debug("Done waiting on global wait group")
+	return warnings, errs, nil
+}
 
-	for _, err := range waitErrs {
-		// TODO not ideal to be adding these to Ark-level errors
-		// rather than a specific namespace, but don't have a way
-		// to track the namespace right now.
-		errs.Ark = append(errs.Ark, err.Error())
+// readItemHashes returns the content hashes of the backed-up items that were recorded
+// in the index of the backup extracted to dir, keyed by the path each item was
+// extracted to. Backups without an index have no hashes.
+func (ctx *context) readItemHashes(dir string) (map[string]string, error) {
+	data, err := ctx.fileSystem.ReadFile(filepath.Join(dir, api.ArchiveIndexFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	return warnings, errs
-}
-
-// getNamespace returns a namespace API object that we should attempt to
-// create before restoring anything into it. It will come from the backup
-// tarball if it exists, else will be a new one. If from the tarball, it
-// will retain its labels, annotations, and spec.
-func getNamespace(logger logrus.FieldLogger, path, remappedName string) *v1.Namespace {
-	var nsBytes []byte
-	var err error
+	var index archive.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrapf(err, "error decoding %s", api.ArchiveIndexFile)
+	}
 
-	if nsBytes, err = ioutil.ReadFile(path); err != nil {
-		return &v1.Namespace{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: remappedName,
-			},
+	hashes := make(map[string]string)
+	for _, entry := range index.Items {
+		if entry.ItemHash == "" {
+			continue
 		}
-	}
 
-	var backupNS v1.Namespace
-	if err := json.Unmarshal(nsBytes, &backupNS); err != nil {
-		logger.Warnf("Error unmarshalling namespace from backup, creating new one.")
-		return &v1.Namespace{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: remappedName,
-			},
+		path, err := extractPath(dir, entry.Path)
+		if err != nil {
+			return nil, err
 		}
+		hashes[path] = entry.ItemHash
 	}
 
-	return &v1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        remappedName,
-			Labels:      backupNS.Labels,
-			Annotations: backupNS.Annotations,
-		},
-		Spec: backupNS.Spec,
-	}
+	return hashes, nil
 }
 
-// merge combines two RestoreResult objects into one
-// by appending the corresponding lists to one another.
+// clusterItemHashes lists the items of groupResource in the cluster using resourceClient
+// and returns their content hashes, keyed by name. The items' metadata is reset the same
+// way backed-up items' is before they're restored, and their namespace is set to
+// originalNamespace, so that an item that's unchanged since it was restored, possibly
+// into a different namespace, hashes the same as its backed-up version.
+func (ctx *context) clusterItemHashes(resourceClient client.Dynamic, groupResource schema.GroupResource, originalNamespace string) (map[string]string, error) {
+	res, err := resourceClient.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	list, ok := res.(*unstructured.UnstructuredList)
+	if !ok {
+		return nil, errors.Errorf("unexpected list type %T", res)
+	}
+
+	hashes := make(map[string]string, len(list.Items))
+	for i := range list.Items {
+		item, err := resetMetadataAndStatus(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		if originalNamespace != "" {
+			item.SetNamespace(originalNamespace)
+		}
+
+		hash, err := ctx.itemHasher.Hash(groupResource.String(), item.UnstructuredContent())
+		if err != nil {
+			return nil, errors.Wrapf(err, "error hashing %s", kube.NamespaceAndName(item))
+		}
+		hashes[item.GetName()] = hash
+	}
+
+	return hashes, nil
+}
+
+// checkTargetNamespaces returns an error if any namespace that objects would be restored
+// into doesn't exist and the restore's namespace creation policy doesn't allow creating
+// it. The namespaces found to exist are added to checkedNamespaces.
+func (ctx *context) checkTargetNamespaces(resourcesDir string, resourceDirsMap map[string]os.FileInfo, namespaceFilter *collections.IncludesExcludes, checkedNamespaces sets.String) error {
+	policy := ctx.restore.Spec.CreateNamespaces
+	if policy == "" || policy == api.RestoreNamespaceCreationPolicyAlways {
+		return nil
+	}
+
+	targetNamespaces := sets.NewString()
+	for _, resource := range ctx.prioritizedResources {
+		if resource == kuberesource.Namespaces || resourceDirsMap[resource.String()] == nil {
+			continue
+		}
+
+		nsSubDir := filepath.Join(resourcesDir, resource.String(), api.NamespaceScopedDir)
+		nsSubDirExists, err := ctx.fileSystem.DirExists(nsSubDir)
+		if err != nil {
+			return err
+		}
+		if !nsSubDirExists {
+			continue
+		}
+
+		nsDirs, err := ctx.fileSystem.ReadDir(nsSubDir)
+		if err != nil {
+			return err
+		}
+
+		for _, nsDir := range nsDirs {
+			if !nsDir.IsDir() || !namespaceFilter.ShouldInclude(nsDir.Name()) {
+				continue
+			}
+
+			target, mapped := ctx.restore.Spec.NamespaceMapping[nsDir.Name()]
+			if !mapped {
+				target = nsDir.Name()
+			} else if policy == api.RestoreNamespaceCreationPolicyIfMappedOnly {
+				continue
+			}
+
+			targetNamespaces.Insert(target)
+		}
+	}
+
+	var missing []string
+	for _, namespace := range targetNamespaces.List() {
+		_, err := ctx.namespaceClient.Get(namespace, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			missing = append(missing, namespace)
+		case err != nil:
+			return errors.Wrapf(err, "error getting namespace %s", namespace)
+		default:
+			checkedNamespaces.Insert(namespace)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.Errorf("namespaces %s don't exist and the restore's namespace creation policy is %s", strings.Join(missing, ", "), policy)
+	}
+
+	return nil
+}
+
+// getNamespace returns a namespace API object that we should attempt to
+// create before restoring anything into it. It will come from the backup
+// tarball if it exists, else will be a new one. If from the tarball, it
+// will retain its labels, annotations, and spec.
+func getNamespace(logger logrus.FieldLogger, fileSystem filesystem.Interface, path, remappedName string) *v1.Namespace {
+	var nsBytes []byte
+	var err error
+
+	if nsBytes, err = fileSystem.ReadFile(path); err != nil {
+		return &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: remappedName,
+			},
+		}
+	}
+
+	var backupNS v1.Namespace
+	if err := json.Unmarshal(nsBytes, &backupNS); err != nil {
+		logger.Warnf("Error unmarshalling namespace from backup, creating new one.")
+		return &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: remappedName,
+			},
+		}
+	}
+
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        remappedName,
+			Labels:      backupNS.Labels,
+			Annotations: backupNS.Annotations,
+		},
+		Spec: backupNS.Spec,
+	}
+}
+
+// merge combines two RestoreResult objects into one
+// by appending the corresponding lists to one another.
 func merge(a, b *api.RestoreResult) {
 	a.Cluster = append(a.Cluster, b.Cluster...)
 	a.Ark = append(a.Ark, b.Ark...)
+	a.Conflicts = append(a.Conflicts, b.Conflicts...)
+	a.Items = append(a.Items, b.Items...)
 	for k, v := range b.Namespaces {
 		if a.Namespaces == nil {
 			a.Namespaces = make(map[string][]string)
 		}
 		a.Namespaces[k] = append(a.Namespaces[k], v...)
 	}
+	for _, summary := range b.Summaries {
+		mergeSummary(a, summary)
+	}
+}
+
+// mergeSummary adds summary to the provided RestoreResult, combining it with the result's
+// summary of the same message, if it has one.
+func mergeSummary(r *api.RestoreResult, summary api.RestoreResultSummary) {
+	for i := range r.Summaries {
+		existing := &r.Summaries[i]
+		if existing.Resource != summary.Resource || existing.Namespace != summary.Namespace || existing.Category != summary.Category || existing.Message != summary.Message {
+			continue
+		}
+
+		existing.Count += summary.Count
+		for _, name := range summary.SampleNames {
+			if len(existing.SampleNames) >= maxSummarySampleNames {
+				break
+			}
+			existing.SampleNames = append(existing.SampleNames, name)
+		}
+		return
+	}
+
+	r.Summaries = append(r.Summaries, summary)
 }
 
 // addArkError appends an error to the provided RestoreResult's Ark list.
@@ -578,6 +1235,164 @@ func addToResult(r *api.RestoreResult, ns string, e error) {
 	}
 }
 
+// addRetriedItemToResult is like addItemToResult, but also records the number of attempts
+// made at the step that failed.
+func addRetriedItemToResult(r *api.RestoreResult, category api.RestoreResultCategory, groupResource schema.GroupResource, ns, name string, attempts int, e error) {
+	if attempts > 1 {
+		e = errors.Wrapf(e, "failed after %d attempts", attempts)
+	}
+	addResultItem(r, api.RestoreResultItem{
+		Resource:  groupResource.String(),
+		Namespace: ns,
+		Name:      name,
+		Category:  category,
+		Message:   e.Error(),
+		Attempts:  attempts,
+	})
+}
+
+// restoreRetryBackoff controls how create and patch requests that fail with
+// a transient error are retried.
+var restoreRetryBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+	Steps:    5,
+}
+
+// isRetriable returns true if err is a transient API server error, e.g. due to
+// throttling, that's likely to succeed if the request is retried.
+func isRetriable(err error) bool {
+	return apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err)
+}
+
+// withRetries calls fn until it succeeds, returns an error that isn't retriable, or
+// restoreRetryBackoff's steps are used up. It returns the number of times fn was called
+// and fn's last error.
+func withRetries(log logrus.FieldLogger, fn func() error) (int, error) {
+	var (
+		attempts int
+		lastErr  error
+	)
+
+	err := wait.ExponentialBackoff(restoreRetryBackoff, func() (bool, error) {
+		attempts++
+
+		lastErr = fn()
+		switch {
+		case lastErr == nil:
+			return true, nil
+		case isRetriable(lastErr):
+			log.WithError(lastErr).Infof("Attempt %d failed with a transient error", attempts)
+			return false, nil
+		default:
+			return false, lastErr
+		}
+	})
+	if err == wait.ErrWaitTimeout {
+		err = lastErr
+	}
+
+	return attempts, err
+}
+
+// timeoutFor returns how long restoring the items of groupResource can take: the server's
+// timeout for the resource if it has one, or else the restore's, if it has one.
+func (ctx *context) timeoutFor(groupResource schema.GroupResource) time.Duration {
+	if timeout, ok := ctx.resourceTimeouts[groupResource.String()]; ok {
+		return timeout
+	}
+	return ctx.resourceTimeout
+}
+
+// resourceContext returns the context that items of groupResource are restored with. It's
+// done when the restore's context is, or when the resource's timeout, if it has one, expires.
+// The timeout starts when the resource is first restored, and applies to all namespaces.
+func (ctx *context) resourceContext(groupResource schema.GroupResource) go_context.Context {
+	timeout := ctx.timeoutFor(groupResource)
+	if timeout <= 0 {
+		return ctx.goContext
+	}
+
+	if resourceCtx, ok := ctx.resourceContexts[groupResource.String()]; ok {
+		return resourceCtx
+	}
+
+	resourceCtx, cancel := go_context.WithTimeout(ctx.goContext, timeout)
+	if ctx.resourceContexts == nil {
+		ctx.resourceContexts = make(map[string]go_context.Context)
+	}
+	ctx.resourceContexts[groupResource.String()] = resourceCtx
+	ctx.resourceCancels = append(ctx.resourceCancels, cancel)
+
+	return resourceCtx
+}
+
+// addItemToResult appends an error about a single object to the provided RestoreResult,
+// both as a string (as with addToResult) and as a structured item recording the object
+// and the step of restoring it that failed.
+func addItemToResult(r *api.RestoreResult, category api.RestoreResultCategory, groupResource schema.GroupResource, ns, name string, e error) {
+	addResultItem(r, api.RestoreResultItem{
+		Resource:  groupResource.String(),
+		Namespace: ns,
+		Name:      name,
+		Category:  category,
+		Message:   e.Error(),
+	})
+}
+
+const (
+	// maxRepeatedResultItems is the number of objects of the same resource in the same
+	// namespace that a message is recorded for before it's only counted in a summary.
+	maxRepeatedResultItems = 5
+
+	// maxSummarySampleNames is the number of names of objects that a summary records.
+	maxSummarySampleNames = 5
+)
+
+// addResultItem appends item to the provided RestoreResult, both as a string and as a
+// structured item, unless its message has already been recorded for maxRepeatedResultItems
+// other objects of the same resource in the same namespace. In that case, the item is only
+// counted in the result's summary of the message, so that the result's size stays bounded
+// when many objects fail in the same way.
+func addResultItem(r *api.RestoreResult, item api.RestoreResultItem) {
+	message := resultMessageTemplate(item)
+
+	var repeats int
+	for _, existing := range r.Items {
+		if existing.Resource == item.Resource && existing.Namespace == item.Namespace && existing.Category == item.Category && resultMessageTemplate(existing) == message {
+			repeats++
+		}
+	}
+
+	if repeats >= maxRepeatedResultItems {
+		mergeSummary(r, api.RestoreResultSummary{
+			Resource:    item.Resource,
+			Namespace:   item.Namespace,
+			Category:    item.Category,
+			Message:     message,
+			Count:       1,
+			SampleNames: []string{item.Name},
+		})
+		return
+	}
+
+	addToResult(r, item.Namespace, errors.New(item.Message))
+	r.Items = append(r.Items, item)
+}
+
+// resultMessageTemplate returns item's message with its name replaced by "<name>", so that
+// the same message about different objects can be grouped.
+func resultMessageTemplate(item api.RestoreResultItem) string {
+	if item.Name == "" {
+		return item.Message
+	}
+	return strings.Replace(item.Message, item.Name, "<name>", -1)
+}
+
 // restoreResource restores the specified cluster or namespace scoped resource. If namespace is
 // empty we are restoring a cluster level resource, otherwise into the specified namespace.
 func (ctx *context) restoreResource(resource, namespace, resourcePath string) (api.RestoreResult, api.RestoreResult) {
@@ -594,6 +1409,11 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 		ctx.log.Infof("Restoring cluster level resource '%s' from: %s", resource, resourcePath)
 	}
 
+	start := time.Now()
+	defer func() {
+		ctx.progress.addResourceTime(resource, time.Since(start))
+	}()
+
 	files, err := ctx.fileSystem.ReadDir(resourcePath)
 	if err != nil {
 		addToResult(&errs, namespace, fmt.Errorf("error reading %q resource directory: %v", resource, err))
@@ -607,9 +1427,22 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 		resourceClient    client.Dynamic
 		groupResource     = schema.ParseGroupResource(resource)
 		applicableActions []resolvedAction
-		resourceWatch     watch.Interface
+		// pvsToWaitFor are the PVs restored from snapshots, which are waited on to
+		// become available once they've all been created.
+		pvsToWaitFor = sets.NewString()
+		// clusterHashes are the content hashes of the items in the cluster,
+		// keyed by name. They're only listed if an item to restore has a hash.
+		clusterHashes map[string]string
 	)
 
+	// translated items are restored as items of the resource they're translated
+	// into, so that's the resource they're prepared and restored as
+	translatePolicies := ctx.securityPolicyTranslator != nil && ctx.securityPolicyTranslator.translates(groupResource)
+	if translatePolicies {
+		groupResource = ctx.securityPolicyTranslator.target.GroupResource()
+		ctx.log.Infof("Translating %s into %s", resource, &groupResource)
+	}
+
 	// pre-filter the actions based on namespace & resource includes/excludes since
 	// these will be the same for all items being restored below
 	for _, action := range ctx.actions {
@@ -624,11 +1457,77 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 		applicableActions = append(applicableActions, action)
 	}
 
-	for _, file := range files {
+	resourceCtx := ctx.resourceContext(groupResource)
+
+	var stripList *annotationStripList
+	if len(ctx.restore.Spec.StripAnnotations) > 0 {
+		stripList = newAnnotationStripList(ctx.restore.Spec.StripAnnotations)
+	}
+
+	// consecutiveFailures counts the items that failed to be created since the last one
+	// that didn't, so that the rest of the items are skipped once it reaches
+	// ctx.failureThreshold.
+	var consecutiveFailures int
+
+	// created are the names of the items that were created, which are waited on
+	// to become ready once they've all been created.
+	var created []string
+
+	// batch holds the prepared items that are waiting to be created
+	var batch []*pendingCreate
+
+	// flush creates the batched items and then handles the result of creating each of
+	// them, in order. It returns false if the rest of the resource's items are skipped
+	// because too many consecutive items failed to be created.
+	flush := func() bool {
+		ctx.createItems(resourceCtx, resourceClient, groupResource, batch)
+
+		var lastErr error
+		for _, item := range batch {
+			ctx.handleCreateResult(resourceClient, groupResource, namespace, item, &warnings, &errs)
+
+			switch {
+			case item.hookErr != nil:
+				// the item wasn't created because a pre hook failed
+			case item.err == nil:
+				consecutiveFailures = 0
+				created = append(created, item.name)
+			case apierrors.IsAlreadyExists(item.err):
+				consecutiveFailures = 0
+			default:
+				consecutiveFailures++
+				lastErr = item.err
+			}
+		}
+
+		last := batch[len(batch)-1].index
+		batch = nil
+
+		if ctx.failureThreshold > 0 && consecutiveFailures >= ctx.failureThreshold && last < len(files)-1 {
+			ctx.log.Errorf("Skipping the remaining %d items of resource %s after %d consecutive failures", len(files)-last-1, &groupResource, consecutiveFailures)
+			addToResult(&errs, namespace, errors.Errorf("skipped restoring the remaining %d items of %s after %d consecutive failures, the last of which was: %v", len(files)-last-1, &groupResource, consecutiveFailures, lastErr))
+			return false
+		}
+
+		return true
+	}
+
+	for i, file := range files {
+		if err := ctx.goContext.Err(); err != nil {
+			addToResult(&errs, namespace, errors.Wrapf(err, "restore of %s stopped", &groupResource))
+			return warnings, errs
+		}
+
+		if resourceCtx.Err() != nil {
+			ctx.log.Errorf("Timed out restoring resource %s after %s", &groupResource, ctx.timeoutFor(groupResource))
+			addToResult(&errs, namespace, errors.Errorf("timed out restoring %s after %s", &groupResource, ctx.timeoutFor(groupResource)))
+			return warnings, errs
+		}
+
 		fullPath := filepath.Join(resourcePath, file.Name())
 		obj, err := ctx.unmarshal(fullPath)
 		if err != nil {
-			addToResult(&errs, namespace, fmt.Errorf("error decoding %q: %v", fullPath, err))
+			addItemToResult(&errs, api.RestoreResultCategoryDecode, groupResource, namespace, strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())), fmt.Errorf("error decoding %q: %v", fullPath, err))
 			continue
 		}
 
@@ -636,9 +1535,21 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 			continue
 		}
 
+		if translatePolicies {
+			translated, translateWarnings, err := ctx.securityPolicyTranslator.translate(obj)
+			if err != nil {
+				addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, obj.GetName(), fmt.Errorf("error translating %s: %v", fullPath, err))
+				continue
+			}
+			for _, warning := range translateWarnings {
+				addItemToResult(&warnings, api.RestoreResultCategoryPrepare, groupResource, namespace, translated.GetName(), fmt.Errorf("%s: %s", fullPath, warning))
+			}
+			obj = translated
+		}
+
 		complete, err := isCompleted(obj, groupResource)
 		if err != nil {
-			addToResult(&errs, namespace, fmt.Errorf("error checking completion %q: %v", fullPath, err))
+			addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, obj.GetName(), fmt.Errorf("error checking completion %q: %v", fullPath, err))
 			continue
 		}
 		if complete {
@@ -665,6 +1576,7 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 		}
 
 		name := obj.GetName()
+		ctx.progress.itemStarted(groupResource, namespace, name)
 
 		// TODO: move to restore item action if/when we add a ShouldRestore() method to the interface
 		if groupResource == kuberesource.Pods && obj.GetAnnotations()[v1.MirrorPodAnnotationKey] != "" {
@@ -672,6 +1584,33 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 			continue
 		}
 
+		// PVs may be renamed or restored from snapshots, so they're always
+		// compared with their in-cluster versions
+		if _, ok := ctx.itemHashes[fullPath]; ok && groupResource != kuberesource.PersistentVolumes {
+			if clusterHashes == nil {
+				if clusterHashes, err = ctx.clusterItemHashes(resourceClient, groupResource, obj.GetNamespace()); err != nil {
+					ctx.log.WithError(err).Infof("Unable to hash in-cluster %s, comparing them with their backed-up versions", &groupResource)
+					clusterHashes = map[string]string{}
+				}
+			}
+
+			// the in-cluster version has the labels and annotations that were added by the
+			// restore that created it, so it's compared with the backed-up item with this
+			// restore's added, like it is when it already exists
+			restored := obj.DeepCopy()
+			ctx.addRestoreMetadata(restored)
+			itemHash, err := ctx.itemHasher.Hash(groupResource.String(), restored.UnstructuredContent())
+			if err != nil {
+				ctx.log.WithError(err).Infof("Unable to hash %s, comparing it with its backed-up version", fullPath)
+			}
+
+			if err == nil && clusterHashes[name] == itemHash {
+				ctx.log.Infof("Skipping %s %s because it's identical to its in-cluster version", obj.GroupVersionKind().Kind, kube.NamespaceAndName(obj))
+				consecutiveFailures = 0
+				continue
+			}
+		}
+
 		if groupResource == kuberesource.PersistentVolumes {
 			var hasSnapshot bool
 
@@ -688,46 +1627,58 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 				}
 			}
 
-			if !hasSnapshot && hasDeleteReclaimPolicy(obj.Object) {
+			// PVs mapped to an existing volume are restored with it whether or
+			// not they have a snapshot
+			_, mapped := ctx.restore.Spec.VolumeIDMapping[name]
+
+			if !hasSnapshot && !mapped && hasDeleteReclaimPolicy(obj.Object) {
 				ctx.log.Infof("Not restoring PV because it doesn't have a snapshot and its reclaim policy is Delete.")
 				ctx.pvsToProvision.Insert(name)
 				continue
 			}
 
+			// check whether the PV needs a new name before its claim ref is removed
+			newName, err := ctx.renamedPVName(obj, hasSnapshot, resourceClient)
+			if err != nil {
+				addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("error checking for existing PV for %s: %v", fullPath, err))
+				continue
+			}
+			claimRef, _ := collections.GetMap(obj.UnstructuredContent(), "spec.claimRef")
+
 			// restore the PV from snapshot (if applicable)
 			updatedObj, err := ctx.pvRestorer.executePVAction(obj)
 			if err != nil {
-				addToResult(&errs, namespace, fmt.Errorf("error executing PVAction for %s: %v", fullPath, err))
+				addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("error executing PVAction for %s: %v", fullPath, err))
 				continue
 			}
 			obj = updatedObj
 
-			if resourceWatch == nil {
-				resourceWatch, err = resourceClient.Watch(metav1.ListOptions{})
-				if err != nil {
-					addToResult(&errs, namespace, fmt.Errorf("error watching for namespace %q, resource %q: %v", namespace, &groupResource, err))
-					return warnings, errs
+			if newName != "" {
+				ctx.log.Infof("Restoring PV %s as %s because it already exists in the cluster", name, newName)
+
+				if err := renamePV(obj, newName, claimRef, ctx.restore.Spec.NamespaceMapping); err != nil {
+					addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("error renaming PV for %s: %v", fullPath, err))
+					continue
 				}
-				ctx.resourceWatches = append(ctx.resourceWatches, resourceWatch)
-				ctx.resourceWaitGroup.Add(1)
-				go func() {
-					defer ctx.resourceWaitGroup.Done()
-
-					if _, err := waitForReady(resourceWatch.ResultChan(), name, isPVReady, time.Minute, ctx.log); err != nil {
-						ctx.log.Warnf("Timeout reached waiting for persistent volume %s to become ready", name)
-						addArkError(&warnings, fmt.Errorf("timeout reached waiting for persistent volume %s to become ready", name))
-					}
-				}()
+				ctx.renamedPVs[name] = newName
+				name = newName
 			}
+
+			pvsToWaitFor.Insert(name)
 		}
 
 		if groupResource == kuberesource.PersistentVolumeClaims {
 			spec, err := collections.GetMap(obj.UnstructuredContent(), "spec")
 			if err != nil {
-				addToResult(&errs, namespace, err)
+				addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, err)
 				continue
 			}
 
+			if volumeName, exists := spec["volumeName"]; exists && ctx.renamedPVs[volumeName.(string)] != "" {
+				ctx.log.Infof("Updating PersistentVolumeClaim %s/%s to use PV %s, which %v was restored as", namespace, name, ctx.renamedPVs[volumeName.(string)], volumeName)
+				spec["volumeName"] = ctx.renamedPVs[volumeName.(string)]
+			}
+
 			if volumeName, exists := spec["volumeName"]; exists && ctx.pvsToProvision.Has(volumeName.(string)) {
 				ctx.log.Infof("Resetting PersistentVolumeClaim %s/%s for dynamic provisioning because its PV %v has a reclaim policy of Delete", namespace, name, volumeName)
 
@@ -749,16 +1700,16 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 
 			updatedObj, warning, err := action.Execute(obj, ctx.restore)
 			if warning != nil {
-				addToResult(&warnings, namespace, fmt.Errorf("warning preparing %s: %v", fullPath, warning))
+				addItemToResult(&warnings, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("warning preparing %s: %v", fullPath, warning))
 			}
 			if err != nil {
-				addToResult(&errs, namespace, fmt.Errorf("error preparing %s: %v", fullPath, err))
+				addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("error preparing %s: %v", fullPath, err))
 				continue
 			}
 
 			unstructuredObj, ok := updatedObj.(*unstructured.Unstructured)
 			if !ok {
-				addToResult(&errs, namespace, fmt.Errorf("%s: unexpected type %T", fullPath, updatedObj))
+				addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("%s: unexpected type %T", fullPath, updatedObj))
 				continue
 			}
 
@@ -767,10 +1718,16 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 
 		// clear out non-core metadata fields & status
 		if obj, err = resetMetadataAndStatus(obj); err != nil {
-			addToResult(&errs, namespace, err)
+			addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, err)
 			continue
 		}
 
+		if stripList != nil {
+			if stripped := stripList.strip(obj); len(stripped) > 0 {
+				ctx.log.Infof("Removing annotations %s from %s %s", strings.Join(stripped, ", "), &groupResource, name)
+			}
+		}
+
 		// necessary because we may have remapped the namespace
 		// if the namespace is blank, don't create the key
 		originalNamespace := obj.GetNamespace()
@@ -781,95 +1738,500 @@ func (ctx *context) restoreResource(resource, namespace, resourcePath string) (a
 		// label the resource with the restore's name and the restored backup's name
 		// for easy identification of all cluster resources created by this restore
 		// and which backup they came from
-		addRestoreLabels(obj, ctx.restore.Name, ctx.restore.Spec.BackupName)
+		ctx.addRestoreMetadata(obj)
 
-		ctx.log.Infof("Restoring %s: %v", obj.GroupVersionKind().Kind, name)
-		createdObj, restoreErr := resourceClient.Create(obj)
-		if apierrors.IsAlreadyExists(restoreErr) {
-			fromCluster, err := resourceClient.Get(name, metav1.GetOptions{})
-			if err != nil {
-				ctx.log.Infof("Error retrieving cluster version of %s: %v", kube.NamespaceAndName(obj), err)
-				addToResult(&warnings, namespace, err)
+		if groupResource == kuberesource.Pods && ctx.resticRestorer != nil {
+			pod := new(v1.Pod)
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), pod); err != nil {
+				addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("error converting unstructured pod %s: %v", fullPath, err))
 				continue
 			}
-			// Remove insubstantial metadata
-			fromCluster, err = resetMetadataAndStatus(fromCluster)
-			if err != nil {
-				ctx.log.Infof("Error trying to reset metadata for %s: %v", kube.NamespaceAndName(obj), err)
-				addToResult(&warnings, namespace, err)
-				continue
-			}
-
-			// We know the object from the cluster won't have the backup/restore name labels, so
-			// copy them from the object we attempted to restore.
-			labels := obj.GetLabels()
-			addRestoreLabels(fromCluster, labels[api.RestoreNameLabel], labels[api.BackupNameLabel])
 
-			if !equality.Semantic.DeepEqual(fromCluster, obj) {
-				switch groupResource {
-				case kuberesource.ServiceAccounts:
-					desired, err := mergeServiceAccounts(fromCluster, obj)
-					if err != nil {
-						ctx.log.Infof("error merging secrets for ServiceAccount %s: %v", kube.NamespaceAndName(obj), err)
-						addToResult(&warnings, namespace, err)
-						continue
+			if claimSnapshots, _ := restic.SplitPodSnapshotAnnotations(pod); len(claimSnapshots) > 0 {
+				// only restore the claims' data if the pod is going to be created, otherwise
+				// fall through so the existing pod is handled like any other existing object
+				_, err := resourceClient.Get(name, metav1.GetOptions{})
+				switch {
+				case apierrors.IsNotFound(err):
+					// the pod's pre hooks are run before its claims' data is restored,
+					// since that's what starts restoring the pod
+					hookWarnings, err := ctx.runRestoreHooks(groupResource, obj, originalNamespace, restoreHookPhasePre)
+					for _, warning := range hookWarnings {
+						addItemToResult(&warnings, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("pre hook for %s: %v", fullPath, warning))
 					}
-
-					patchBytes, err := generatePatch(fromCluster, desired)
 					if err != nil {
-						ctx.log.Infof("error generating patch for ServiceAccount %s: %v", kube.NamespaceAndName(obj), err)
-						addToResult(&warnings, namespace, err)
+						addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("not restored, pre hook for %s failed: %v", fullPath, err))
 						continue
 					}
 
-					if patchBytes == nil {
-						// In-cluster and desired state are the same, so move on to the next item
-						continue
-					}
-
-					_, err = resourceClient.Patch(name, patchBytes)
-					if err != nil {
-						addToResult(&warnings, namespace, err)
-					} else {
-						ctx.log.Infof("ServiceAccount %s successfully updated", kube.NamespaceAndName(obj))
-					}
-				default:
-					e := errors.Errorf("not restored: %s and is different from backed up version.", restoreErr)
-					addToResult(&warnings, namespace, e)
+					ctx.restorePodWithClaimVolumes(resourceClient, obj, pod, originalNamespace)
+					continue
+				case err != nil:
+					addItemToResult(&errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("error checking for existing pod %s: %v", fullPath, err))
+					continue
 				}
 			}
-			continue
 		}
-		// Error was something other than an AlreadyExists
-		if restoreErr != nil {
-			ctx.log.Infof("error restoring %s: %v", name, err)
-			addToResult(&errs, namespace, fmt.Errorf("error restoring %s: %v", fullPath, restoreErr))
+
+		batch = append(batch, &pendingCreate{
+			index:             i,
+			fullPath:          fullPath,
+			name:              name,
+			obj:               obj,
+			originalNamespace: originalNamespace,
+		})
+		if len(batch) < ctx.createBatchSize() {
 			continue
 		}
+		if !flush() {
+			return warnings, errs
+		}
+	}
+
+	if len(batch) > 0 && !flush() {
+		return warnings, errs
+	}
+
+	if resourceClient != nil {
+		// only the PVs that were created are waited on, in case creating some of them failed
+		var pvs []string
+		for _, name := range created {
+			if pvsToWaitFor.Has(name) {
+				pvs = append(pvs, name)
+			}
+		}
+		if len(pvs) > 0 {
+			notReady, err := ctx.watchUntilReady(resourceClient, groupResource, sets.NewString(pvs...), isPVReady, pvReadyTimeout)
+			for _, name := range notReady.List() {
+				ctx.log.WithError(err).Warnf("Timeout reached waiting for persistent volume %s to become ready", name)
+				addArkError(&warnings, fmt.Errorf("timeout reached waiting for persistent volume %s to become ready", name))
+			}
+		}
+
+		readyWarnings := ctx.waitForItemsReady(resourceClient, groupResource, namespace, created)
+		merge(&warnings, &readyWarnings)
+	}
+
+	return warnings, errs
+}
+
+// pendingCreate is an item of a resource that's been prepared to be restored and is
+// waiting to be created.
+type pendingCreate struct {
+	// index is the index of the item's file in the resource's directory.
+	index             int
+	fullPath          string
+	name              string
+	obj               *unstructured.Unstructured
+	originalNamespace string
+
+	// hookWarnings and hookErr are the result of running the item's pre hooks,
+	// which are run right before it's created. It isn't created if hookErr is set.
+	hookWarnings []error
+	hookErr      error
+
+	// createdObj, attempts and err are the result of creating the item.
+	createdObj *unstructured.Unstructured
+	attempts   int
+	err        error
+}
+
+// handleCreateResult handles the result of creating a prepared item of groupResource,
+// adding its warnings and errors to warnings and errs. Existing items are recreated,
+// merged or compared with their backed-up versions, and created items have their post
+// hooks run and their volumes restored. The item's createdObj and err are updated with
+// the result of recreating it.
+func (ctx *context) handleCreateResult(resourceClient client.Dynamic, groupResource schema.GroupResource, namespace string, item *pendingCreate, warnings, errs *api.RestoreResult) {
+	var (
+		obj               = item.obj
+		name              = item.name
+		fullPath          = item.fullPath
+		originalNamespace = item.originalNamespace
+	)
+
+	for _, warning := range item.hookWarnings {
+		addItemToResult(warnings, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("pre hook for %s: %v", fullPath, warning))
+	}
+	if item.hookErr != nil {
+		addItemToResult(errs, api.RestoreResultCategoryPrepare, groupResource, namespace, name, fmt.Errorf("not restored, pre hook for %s failed: %v", fullPath, item.hookErr))
+		return
+	}
 
-		if groupResource == kuberesource.Pods && len(restic.GetPodSnapshotAnnotations(obj)) > 0 {
-			if ctx.resticRestorer == nil {
-				ctx.log.Warn("No restic restorer, not restoring pod's volumes")
+	if apierrors.IsAlreadyExists(item.err) && ctx.recreateResources.Has(groupResource.String()) {
+		item.createdObj, item.err = ctx.recreate(resourceClient, obj, item.err)
+	}
+	createdObj, restoreErr := item.createdObj, item.err
+
+	if restoreErr == nil {
+		ctx.manifest.addItem(groupResource, createdObj)
+		ctx.observers.OnItemRestored(ctx.restore, groupResource, namespace, name)
+		ctx.progress.itemRestored()
+	}
+
+	if apierrors.IsAlreadyExists(restoreErr) {
+		fromCluster, err := resourceClient.Get(name, metav1.GetOptions{})
+		if err != nil {
+			ctx.log.Infof("Error retrieving cluster version of %s: %v", kube.NamespaceAndName(obj), err)
+			addItemToResult(warnings, api.RestoreResultCategoryConflict, groupResource, namespace, name, err)
+			return
+		}
+		// Remove insubstantial metadata
+		fromCluster, err = resetMetadataAndStatus(fromCluster)
+		if err != nil {
+			ctx.log.Infof("Error trying to reset metadata for %s: %v", kube.NamespaceAndName(obj), err)
+			addItemToResult(warnings, api.RestoreResultCategoryConflict, groupResource, namespace, name, err)
+			return
+		}
+
+		// We know the object from the cluster won't have the backup/restore name labels or
+		// the restore's additional labels and annotations, so add them like they were added
+		// to the object we attempted to restore.
+		ctx.addRestoreMetadata(fromCluster)
+
+		if !equality.Semantic.DeepEqual(fromCluster, obj) {
+			merge, ok := ctx.mergeStrategies.get(groupResource)
+			if !ok && ctx.restore.Spec.ConflictPolicy == api.RestoreConflictPolicyThreeWayMerge {
+				merge, ok = threeWayMerge, true
+			}
+
+			if ok {
+				desired, err := merge(fromCluster, obj)
+				if err != nil {
+					ctx.log.Infof("error merging %s %s: %v", obj.GroupVersionKind().Kind, kube.NamespaceAndName(obj), err)
+					addItemToResult(warnings, api.RestoreResultCategoryConflict, groupResource, namespace, name, err)
+					return
+				}
+
+				patchBytes, err := generatePatch(fromCluster, desired)
+				if err != nil {
+					ctx.log.Infof("error generating patch for %s %s: %v", obj.GroupVersionKind().Kind, kube.NamespaceAndName(obj), err)
+					addItemToResult(warnings, api.RestoreResultCategoryConflict, groupResource, namespace, name, err)
+					return
+				}
+
+				if patchBytes == nil {
+					// In-cluster and desired state are the same, so move on to the next item
+					return
+				}
+
+				attempts, err := withRetries(ctx.log, func() error {
+					_, err := resourceClient.Patch(name, patchBytes)
+					return err
+				})
+				if err != nil {
+					addRetriedItemToResult(warnings, api.RestoreResultCategoryConflict, groupResource, namespace, name, attempts, err)
+				} else {
+					ctx.log.Infof("%s %s successfully updated", obj.GroupVersionKind().Kind, kube.NamespaceAndName(obj))
+					ctx.observers.OnItemRestored(ctx.restore, groupResource, namespace, name)
+					ctx.progress.itemRestored()
+				}
 			} else {
-				ctx.globalWaitGroup.GoErrorSlice(func() []error {
-					pod := new(v1.Pod)
-					if err := runtime.DefaultUnstructuredConverter.FromUnstructured(createdObj.UnstructuredContent(), &pod); err != nil {
-						ctx.log.WithError(err).Error("error converting unstructured pod")
-						return []error{err}
-					}
+				diffs, err := fieldDiffs(fromCluster, obj)
+				if err != nil {
+					ctx.log.Infof("error comparing %s with backed up version: %v", kube.NamespaceAndName(obj), err)
+				}
 
-					if errs := ctx.resticRestorer.RestorePodVolumes(ctx.restore, pod, originalNamespace, ctx.backup.Spec.StorageLocation, ctx.log); errs != nil {
-						ctx.log.WithError(kubeerrs.NewAggregate(errs)).Error("unable to successfully complete restic restores of pod's volumes")
-						return errs
-					}
+				if len(diffs) == 0 {
+					e := errors.Errorf("not restored: %s and is different from backed up version.", restoreErr)
+					addItemToResult(warnings, api.RestoreResultCategoryConflict, groupResource, namespace, name, e)
+					return
+				}
 
-					return nil
+				e := errors.Errorf("not restored: %s and is different from backed up version in fields: %s.", restoreErr, strings.Join(fieldPaths(diffs), ", "))
+				addItemToResult(warnings, api.RestoreResultCategoryConflict, groupResource, namespace, name, e)
+				warnings.Conflicts = append(warnings.Conflicts, api.RestoreConflict{
+					Resource:  groupResource.String(),
+					Namespace: namespace,
+					Name:      name,
+					Fields:    diffs,
 				})
 			}
 		}
+		return
+	}
+	// Error was something other than an AlreadyExists
+	if restoreErr != nil {
+		ctx.log.Infof("error restoring %s: %v", name, restoreErr)
+		addRetriedItemToResult(errs, api.RestoreResultCategoryCreate, groupResource, namespace, name, item.attempts, fmt.Errorf("error restoring %s: %v", fullPath, restoreErr))
+		return
 	}
 
-	return warnings, errs
+	hookWarnings, err := ctx.runRestoreHooks(groupResource, obj, originalNamespace, restoreHookPhasePost)
+	for _, warning := range hookWarnings {
+		addItemToResult(warnings, api.RestoreResultCategoryCreate, groupResource, namespace, name, fmt.Errorf("post hook for %s: %v", fullPath, warning))
+	}
+	if err != nil {
+		addItemToResult(errs, api.RestoreResultCategoryCreate, groupResource, namespace, name, fmt.Errorf("post hook for %s failed: %v", fullPath, err))
+	}
+
+	if groupResource == kuberesource.Pods && len(restic.GetPodSnapshotAnnotations(obj)) > 0 {
+		if ctx.resticRestorer == nil {
+			ctx.log.Warn("No restic restorer, not restoring pod's volumes")
+		} else {
+			ctx.globalWaitGroup.GoErrorSlice(func() []error {
+				return ctx.restorePodVolumes(createdObj, originalNamespace)
+			})
+		}
+	}
+}
+
+// createBatchSize returns how many of a resource's prepared items are created together.
+// If items are created one at a time, each one is created as soon as it's prepared.
+func (ctx *context) createBatchSize() int {
+	if ctx.createWorkers <= 1 {
+		return 1
+	}
+	return ctx.createWorkers * createBatchItemsPerWorker
+}
+
+// createItems creates items of groupResource through resourceClient, up to
+// ctx.createWorkers at a time, and records the result of creating each of them in it.
+// The requests share resourceClient's connections to the API server.
+func (ctx *context) createItems(resourceCtx go_context.Context, resourceClient client.Dynamic, groupResource schema.GroupResource, items []*pendingCreate) {
+	workers := ctx.createWorkers
+	if workers > len(items) {
+		workers = len(items)
+	}
+	if workers <= 1 {
+		for _, item := range items {
+			ctx.createItem(resourceCtx, resourceClient, groupResource, item)
+		}
+		return
+	}
+
+	queue := make(chan *pendingCreate)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				ctx.createItem(resourceCtx, resourceClient, groupResource, item)
+			}
+		}()
+	}
+
+	for _, item := range items {
+		queue <- item
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// createItem runs item's pre hooks and then creates it through resourceClient, retrying
+// transient failures for up to ctx.itemTimeout, and records the results in item.
+func (ctx *context) createItem(resourceCtx go_context.Context, resourceClient client.Dynamic, groupResource schema.GroupResource, item *pendingCreate) {
+	item.hookWarnings, item.hookErr = ctx.runRestoreHooks(groupResource, item.obj, item.originalNamespace, restoreHookPhasePre)
+	if item.hookErr != nil {
+		return
+	}
+
+	ctx.log.Infof("Restoring %s: %v", item.obj.GroupVersionKind().Kind, item.name)
+
+	createCtx, cancelCreate := resourceCtx, go_context.CancelFunc(func() {})
+	if ctx.itemTimeout > 0 {
+		createCtx, cancelCreate = go_context.WithTimeout(resourceCtx, ctx.itemTimeout)
+	}
+	defer cancelCreate()
+
+	// a create that times out is abandoned rather than cancelled, so it may still
+	// create the item, which is then recorded in the manifest so that undoing the
+	// restore deletes it
+	created := &abandonedCreate{}
+	item.attempts, item.err = withRetries(ctx.log, func() error {
+		return arksync.RunWithContext(createCtx, func() error {
+			createdObj, err := resourceClient.Create(item.obj)
+			if err == nil {
+				created.set(ctx, groupResource, createdObj)
+			}
+			return err
+		})
+	})
+	if item.err == nil {
+		item.createdObj = created.obj
+		return
+	}
+	if item.err != createCtx.Err() {
+		return
+	}
+
+	if createCtx.Err() == go_context.DeadlineExceeded && resourceCtx.Err() == nil {
+		item.err = errors.Errorf("timed out after %s", ctx.itemTimeout)
+	}
+
+	if created.abandon(ctx, groupResource) {
+		return
+	}
+
+	// the create may have been made by the API server even though it didn't respond
+	// in time, in which case the item exists with this restore's labels
+	getCtx, cancelGet := go_context.WithTimeout(ctx.goContext, createdItemGetTimeout)
+	defer cancelGet()
+
+	var existing *unstructured.Unstructured
+	err := arksync.RunWithContext(getCtx, func() error {
+		var err error
+		existing, err = resourceClient.Get(item.name, metav1.GetOptions{})
+		return err
+	})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		ctx.log.WithError(err).Warnf("Unable to check whether %s was created after its create timed out, so it may not be recorded in the restore's manifest", item.name)
+	case existing.GetLabels()[api.RestoreNameLabel] == ctx.restore.Name:
+		created.set(ctx, groupResource, existing)
+	}
+}
+
+// createdItemGetTimeout is how long getting an item whose create timed out can take.
+const createdItemGetTimeout = 30 * time.Second
+
+// abandonedCreate records the item created by a create that may be abandoned because
+// it timed out, in which case the item is added to the restore's manifest when it's
+// found to have been created.
+type abandonedCreate struct {
+	lock      sync.Mutex
+	obj       *unstructured.Unstructured
+	abandoned bool
+	recorded  bool
+}
+
+// set records that the item was created as obj, and adds it to the manifest if the
+// create has been abandoned.
+func (c *abandonedCreate) set(ctx *context, groupResource schema.GroupResource, obj *unstructured.Unstructured) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.obj = obj
+	if c.abandoned {
+		c.record(ctx, groupResource)
+	}
+}
+
+// abandon marks the create as abandoned, adding the item to the manifest if it's already
+// been created. It returns true if the item has been recorded in the manifest.
+func (c *abandonedCreate) abandon(ctx *context, groupResource schema.GroupResource) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.abandoned = true
+	if c.obj != nil {
+		c.record(ctx, groupResource)
+	}
+	return c.recorded
+}
+
+func (c *abandonedCreate) record(ctx *context, groupResource schema.GroupResource) {
+	if c.recorded {
+		return
+	}
+
+	ctx.log.Infof("%s %s was created after its create timed out, recording it in the restore's manifest", &groupResource, kube.NamespaceAndName(c.obj))
+	ctx.manifest.addItem(groupResource, c.obj)
+	c.recorded = true
+}
+
+// restorePodWithClaimVolumes restores the data of a pod's volumes that are backed by
+// persistent volume claims, and then creates the pod and restores its other volumes.
+// The claims' data is restored by data mover pods rather than by the pod itself, so
+// that claims whose volumes aren't provisioned until they're used by a pod can be
+// bound without waiting on the pod, which would otherwise wait on the data.
+func (ctx *context) restorePodWithClaimVolumes(resourceClient client.Dynamic, obj *unstructured.Unstructured, pod *v1.Pod, originalNamespace string) {
+	ctx.globalWaitGroup.GoErrorSlice(func() []error {
+		errs := ctx.resticRestorer.RestoreClaimVolumes(ctx.restore, pod, originalNamespace, ctx.backup.Spec.StorageLocation, ctx.log)
+		if errs != nil {
+			ctx.log.WithError(kubeerrs.NewAggregate(errs)).Error("unable to successfully complete restic restores of pod's persistent volume claims")
+		}
+
+		ctx.log.Infof("Restoring %s: %v", obj.GroupVersionKind().Kind, obj.GetName())
+		var createdObj *unstructured.Unstructured
+		_, err := withRetries(ctx.log, func() error {
+			var err error
+			createdObj, err = resourceClient.Create(obj)
+			return err
+		})
+		if err != nil {
+			ctx.log.Infof("error restoring %s: %v", obj.GetName(), err)
+			return append(errs, errors.Wrapf(err, "error restoring pod %s", kube.NamespaceAndName(obj)))
+		}
+		ctx.manifest.addItem(kuberesource.Pods, createdObj)
+		ctx.observers.OnItemRestored(ctx.restore, kuberesource.Pods, obj.GetNamespace(), obj.GetName())
+		ctx.progress.itemRestored()
+
+		return append(errs, ctx.restorePodVolumes(createdObj, originalNamespace)...)
+	})
+}
+
+// restorePodVolumes restores the data of a created pod's volumes that aren't backed by
+// persistent volume claims.
+func (ctx *context) restorePodVolumes(createdObj *unstructured.Unstructured, originalNamespace string) []error {
+	pod := new(v1.Pod)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(createdObj.UnstructuredContent(), &pod); err != nil {
+		ctx.log.WithError(err).Error("error converting unstructured pod")
+		return []error{err}
+	}
+
+	if errs := ctx.resticRestorer.RestorePodVolumes(ctx.restore, pod, originalNamespace, ctx.backup.Spec.StorageLocation, ctx.log); errs != nil {
+		ctx.log.WithError(kubeerrs.NewAggregate(errs)).Error("unable to successfully complete restic restores of pod's volumes")
+		return errs
+	}
+
+	return nil
+}
+
+// renamedPVName returns the name to restore a PV as, if it can't be restored with its
+// original name because it already exists in the cluster, or an empty string otherwise.
+// PVs are only renamed if they're restored from a snapshot or mapped to an existing
+// volume, so that the renamed PV has its own volume, and their claim is being restored
+// into a different namespace, so that the original PV and claim can be used alongside
+// the restored ones.
+func (ctx *context) renamedPVName(obj *unstructured.Unstructured, hasSnapshot bool, pvClient client.Dynamic) (string, error) {
+	_, mapped := ctx.restore.Spec.VolumeIDMapping[obj.GetName()]
+	if !mapped && (!hasSnapshot || boolptr.IsSetToFalse(ctx.restore.Spec.RestorePVs)) {
+		return "", nil
+	}
+
+	claimNamespace, err := collections.GetString(obj.UnstructuredContent(), "spec.claimRef.namespace")
+	if err != nil {
+		return "", nil
+	}
+	if _, ok := ctx.restore.Spec.NamespaceMapping[claimNamespace]; !ok {
+		return "", nil
+	}
+
+	_, err = pvClient.Get(obj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", errors.WithStack(err)
+	}
+
+	// use a suffix based on the restore so the name is the same if it's restored again
+	hash := sha256.Sum256([]byte(ctx.restore.Namespace + "/" + ctx.restore.Name))
+	return fmt.Sprintf("%s-%s", obj.GetName(), hex.EncodeToString(hash[:])[:8]), nil
+}
+
+// renamePV sets a PV's name, and binds it to its original claim in the namespace the
+// claim is being restored into.
+func renamePV(obj *unstructured.Unstructured, newName string, claimRef map[string]interface{}, namespaceMapping map[string]string) error {
+	obj.SetName(newName)
+
+	claimNamespace, _ := claimRef["namespace"].(string)
+	claimName, _ := claimRef["name"].(string)
+	if claimName == "" {
+		return nil
+	}
+	if target, ok := namespaceMapping[claimNamespace]; ok {
+		claimNamespace = target
+	}
+
+	return unstructured.SetNestedMap(obj.UnstructuredContent(), map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"namespace":  claimNamespace,
+		"name":       claimName,
+	}, "spec", "claimRef")
 }
 
 func hasDeleteReclaimPolicy(obj map[string]interface{}) bool {
@@ -881,13 +2243,17 @@ func hasDeleteReclaimPolicy(obj map[string]interface{}) bool {
 	return reclaimPolicy == "Delete"
 }
 
+// waitForReady waits for all of the items named names to be observed on watchChan as
+// ready, according to ready. If the timeout, unless it's zero, expires or goContext is
+// done first, it returns the names of the items that weren't observed as ready and an error.
 func waitForReady(
+	goContext go_context.Context,
 	watchChan <-chan watch.Event,
-	name string,
+	names sets.String,
 	ready func(runtime.Unstructured) bool,
 	timeout time.Duration,
 	log logrus.FieldLogger,
-) (*unstructured.Unstructured, error) {
+) (sets.String, error) {
 	var timeoutChan <-chan time.Time
 	if timeout != 0 {
 		timeoutChan = time.After(timeout)
@@ -895,7 +2261,9 @@ func waitForReady(
 		timeoutChan = make(chan time.Time)
 	}
 
-	for {
+	notReady := sets.NewString(names.UnsortedList()...)
+
+	for notReady.Len() > 0 {
 		select {
 		case event := <-watchChan:
 			if event.Type != watch.Added && event.Type != watch.Modified {
@@ -907,18 +2275,69 @@ func waitForReady(
 			case !ok:
 				log.Errorf("Unexpected type %T", event.Object)
 				continue
-			case obj.GetName() != name:
+			case !notReady.Has(obj.GetName()):
 				continue
 			case !ready(obj):
-				log.Debugf("Item %s is not ready yet", name)
+				log.Debugf("Item %s is not ready yet", obj.GetName())
 				continue
 			default:
-				return obj, nil
+				notReady.Delete(obj.GetName())
 			}
 		case <-timeoutChan:
-			return nil, errors.New("failed to observe item becoming ready within the timeout")
+			return notReady, errors.New("failed to observe items becoming ready within the timeout")
+		case <-goContext.Done():
+			return notReady, errors.Wrap(goContext.Err(), "stopped waiting for items to become ready")
 		}
 	}
+
+	return nil, nil
+}
+
+// watchUntilReady waits up to timeout for the restored items of groupResource named names
+// to become ready, and returns the names of the ones that don't. It watches only the items
+// labelled with the restore's name, and stops watching as soon as all of them are ready.
+// The watch takes one of ctx.watchSlots, if there are any, for as long as it's open.
+func (ctx *context) watchUntilReady(resourceClient client.Dynamic, groupResource schema.GroupResource, names sets.String, ready func(runtime.Unstructured) bool, timeout time.Duration) (sets.String, error) {
+	if ctx.watchSlots != nil {
+		select {
+		case ctx.watchSlots <- struct{}{}:
+			defer func() { <-ctx.watchSlots }()
+		case <-ctx.goContext.Done():
+			return names, errors.Wrap(ctx.goContext.Err(), "stopped waiting to watch items")
+		}
+	}
+
+	resourceWatch, err := resourceClient.Watch(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{api.RestoreNameLabel: ctx.restore.Name}).String(),
+	})
+	if err != nil {
+		return names, errors.Wrapf(err, "error watching %s", &groupResource)
+	}
+	defer resourceWatch.Stop()
+
+	return waitForReady(ctx.goContext, resourceWatch.ResultChan(), names, ready, timeout, ctx.log)
+}
+
+// waitForItemsReady waits up to ctx.waitForReadyTimeout for the restored items of groupResource
+// named names to become ready, if the restore waits for them and the resource has a readiness
+// check, and returns a warning for each item that doesn't.
+func (ctx *context) waitForItemsReady(resourceClient client.Dynamic, groupResource schema.GroupResource, namespace string, names []string) api.RestoreResult {
+	warnings := api.RestoreResult{}
+
+	ready, ok := ctx.readiness[groupResource]
+	if ctx.waitForReadyTimeout == 0 || !ok || len(names) == 0 {
+		return warnings
+	}
+
+	ctx.log.Infof("Waiting up to %s for %d restored %s to become ready", ctx.waitForReadyTimeout, len(names), &groupResource)
+
+	notReady, err := ctx.watchUntilReady(resourceClient, groupResource, sets.NewString(names...), ready, ctx.waitForReadyTimeout)
+	for _, name := range notReady.List() {
+		ctx.log.Warnf("%s %s didn't become ready: %v", &groupResource, name, err)
+		addItemToResult(&warnings, api.RestoreResultCategoryCreate, groupResource, namespace, name, errors.Errorf("didn't become ready within %s", ctx.waitForReadyTimeout))
+	}
+
+	return warnings
 }
 
 type PVRestorer interface {
@@ -930,9 +2349,39 @@ type pvRestorer struct {
 	backup                 *api.Backup
 	snapshotVolumes        *bool
 	restorePVs             *bool
+	volumeIDMapping        map[string]string
+	volumeOverrides        []api.RestoreVolumeOverride
 	volumeSnapshots        []*volume.Snapshot
 	blockStoreGetter       BlockStoreGetter
 	snapshotLocationLister listers.VolumeSnapshotLocationLister
+
+	snapshotLocationBlockStores map[string]cloudprovider.BlockStore
+}
+
+// blockStore instantiates and initializes a BlockStore given a VolumeSnapshotLocation,
+// or returns an existing one if one's already been initialized for the location. Each
+// persistent volume is restored using the block store for the location its snapshot
+// is stored in, so a backup's volumes can come from more than one provider.
+func (r *pvRestorer) blockStore(snapshotLocation *api.VolumeSnapshotLocation) (cloudprovider.BlockStore, error) {
+	if bs, ok := r.snapshotLocationBlockStores[snapshotLocation.Name]; ok {
+		return bs, nil
+	}
+
+	bs, err := r.blockStoreGetter.GetBlockStore(snapshotLocation.Spec.Provider)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := bs.Init(snapshotLocation.Spec.Config); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if r.snapshotLocationBlockStores == nil {
+		r.snapshotLocationBlockStores = make(map[string]cloudprovider.BlockStore)
+	}
+	r.snapshotLocationBlockStores[snapshotLocation.Name] = bs
+
+	return bs, nil
 }
 
 type snapshotInfo struct {
@@ -1006,9 +2455,15 @@ func (r *pvRestorer) executePVAction(obj *unstructured.Unstructured) (*unstructu
 		return nil, errors.WithStack(err)
 	}
 
+	storageClassName, _ := spec["storageClassName"].(string)
+
 	delete(spec, "claimRef")
 	delete(spec, "storageClassName")
 
+	if volumeID, ok := r.volumeIDMapping[pvName]; ok {
+		return r.setMappedVolumeID(obj, volumeID)
+	}
+
 	if boolptr.IsSetToFalse(r.snapshotVolumes) {
 		// The backup had snapshots disabled, so we can return early
 		return obj, nil
@@ -1030,16 +2485,33 @@ func (r *pvRestorer) executePVAction(obj *unstructured.Unstructured) (*unstructu
 		return obj, nil
 	}
 
-	blockStore, err := r.blockStoreGetter.GetBlockStore(snapshotInfo.location.Spec.Provider)
+	log = log.WithFields(logrus.Fields{
+		"volumeSnapshotLocation": snapshotInfo.location.Name,
+		"provider":               snapshotInfo.location.Spec.Provider,
+	})
+
+	blockStore, err := r.blockStore(snapshotInfo.location)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	if err := blockStore.Init(snapshotInfo.location.Spec.Config); err != nil {
-		return nil, errors.WithStack(err)
+	volumeType, volumeIOPS := snapshotInfo.volumeType, snapshotInfo.volumeIOPS
+	if override := volumeOverrideFor(r.volumeOverrides, storageClassName); override != nil {
+		if override.VolumeType != "" {
+			// the backed-up IOPS may not be valid for the new type
+			volumeType, volumeIOPS = override.VolumeType, nil
+		}
+		if override.IOPS != nil {
+			volumeIOPS = override.IOPS
+		}
+		fields := logrus.Fields{"volumeType": volumeType}
+		if volumeIOPS != nil {
+			fields["volumeIOPS"] = *volumeIOPS
+		}
+		log.WithFields(fields).Info("Overriding the type and IOPS of the restored volume")
 	}
 
-	volumeID, err := blockStore.CreateVolumeFromSnapshot(snapshotInfo.providerSnapshotID, snapshotInfo.volumeType, snapshotInfo.volumeAZ, snapshotInfo.volumeIOPS)
+	volumeID, err := blockStore.CreateVolumeFromSnapshot(snapshotInfo.providerSnapshotID, volumeType, snapshotInfo.volumeAZ, volumeIOPS)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -1057,6 +2529,87 @@ func (r *pvRestorer) executePVAction(obj *unstructured.Unstructured) (*unstructu
 	return updated2, nil
 }
 
+// volumeOverrideFor returns the override for the volumes of PVs with the given storage
+// class: the one that selects the storage class, or else the one that doesn't select a
+// storage class, if any.
+func volumeOverrideFor(overrides []api.RestoreVolumeOverride, storageClassName string) *api.RestoreVolumeOverride {
+	var res *api.RestoreVolumeOverride
+	for i := range overrides {
+		switch overrides[i].StorageClassName {
+		case storageClassName:
+			return &overrides[i]
+		case "":
+			res = &overrides[i]
+		}
+	}
+
+	return res
+}
+
+// setMappedVolumeID restores a PV with the existing volume the restore maps it to, rather
+// than with a volume created from its snapshot. Since the restore didn't create the volume,
+// the PV's reclaim policy is set to Retain so that deleting the PV doesn't delete it.
+func (r *pvRestorer) setMappedVolumeID(obj *unstructured.Unstructured, volumeID string) (*unstructured.Unstructured, error) {
+	log := r.logger.WithFields(logrus.Fields{"persistentVolume": obj.GetName(), "volumeID": volumeID})
+
+	blockStore, err := r.mappedVolumeBlockStore(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	updated1, err := blockStore.SetVolumeID(obj, volumeID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	updated2, ok := updated1.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.Errorf("unexpected type %T", updated1)
+	}
+
+	if err := unstructured.SetNestedField(updated2.Object, string(v1.PersistentVolumeReclaimRetain), "spec", "persistentVolumeReclaimPolicy"); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	log.Info("Restoring persistent volume with the existing volume it's mapped to")
+	return updated2, nil
+}
+
+// mappedVolumeBlockStore returns the block store that sets the volume ID of a PV mapped to
+// an existing volume: the one for the location of the PV's snapshot if it has one, or else
+// the one for the first location, by name, whose provider recognizes the PV's volume.
+func (r *pvRestorer) mappedVolumeBlockStore(obj *unstructured.Unstructured) (cloudprovider.BlockStore, error) {
+	snapshotInfo, err := getSnapshotInfo(obj.GetName(), r.backup, r.volumeSnapshots, r.snapshotLocationLister)
+	if err != nil {
+		return nil, err
+	}
+	if snapshotInfo != nil {
+		return r.blockStore(snapshotInfo.location)
+	}
+
+	locations, err := r.snapshotLocationLister.VolumeSnapshotLocations(r.backup.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	sort.Slice(locations, func(i, j int) bool { return locations[i].Name < locations[j].Name })
+
+	for _, location := range locations {
+		blockStore, err := r.blockStore(location)
+		if err != nil {
+			return nil, err
+		}
+
+		volumeID, err := blockStore.GetVolumeID(obj)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if volumeID != "" {
+			return blockStore, nil
+		}
+	}
+
+	return nil, errors.Errorf("no volume snapshot location's provider recognizes the volume of persistent volume %s, so it can't be mapped to volume %s", obj.GetName(), r.volumeIDMapping[obj.GetName()])
+}
+
 func isPVReady(obj runtime.Unstructured) bool {
 	phase, err := collections.GetString(obj.UnstructuredContent(), "status.phase")
 	if err != nil {
@@ -1106,6 +2659,34 @@ func addRestoreLabels(obj metav1.Object, restoreName, backupName string) {
 	obj.SetLabels(labels)
 }
 
+// addRestoreMetadata labels obj with the restore's name and the restored backup's
+// name, and adds the restore's additional labels and annotations to it.
+func (ctx *context) addRestoreMetadata(obj metav1.Object) {
+	addRestoreLabels(obj, ctx.restore.Name, ctx.restore.Spec.BackupName)
+
+	labels := obj.GetLabels()
+	if ctx.restore.Spec.OmitLegacyRestoreLabel {
+		delete(labels, api.RestoreLabelKey)
+	}
+	for key, val := range ctx.restore.Spec.AdditionalLabels {
+		labels[key] = val
+	}
+	obj.SetLabels(labels)
+
+	if len(ctx.restore.Spec.AdditionalAnnotations) == 0 {
+		return
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for key, val := range ctx.restore.Spec.AdditionalAnnotations {
+		annotations[key] = val
+	}
+	obj.SetAnnotations(annotations)
+}
+
 // hasControllerOwner returns whether or not an object has a controller
 // owner ref. Used to identify whether or not an object should be explicitly
 // recreated during a restore.
@@ -1162,27 +2743,32 @@ func (ctx *context) unmarshal(filePath string) (*unstructured.Unstructured, erro
 	return &obj, nil
 }
 
-// unzipAndExtractBackup extracts a reader on a gzipped tarball to a local temp directory
+// unzipAndExtractBackup extracts a reader on a backup archive, either a gzipped
+// tarball or a zip file, to a local temp directory
 func (ctx *context) unzipAndExtractBackup(src io.Reader) (string, error) {
-	gzr, err := gzip.NewReader(src)
+	archiveRdr, err := archive.NewReader(src)
 	if err != nil {
-		ctx.log.Infof("error creating gzip reader: %v", err)
+		ctx.log.Infof("error creating archive reader: %v", err)
 		return "", err
 	}
-	defer gzr.Close()
 
-	return ctx.readBackup(tar.NewReader(gzr))
+	return ctx.readBackup(archiveRdr)
 }
 
-// readBackup extracts a tar reader to a local directory/file tree within a
-// temp directory.
-func (ctx *context) readBackup(tarRdr *tar.Reader) (string, error) {
-	dir, err := ctx.fileSystem.TempDir("", "")
+// readBackup extracts an archive reader to a local directory/file tree within a
+// temp directory in ctx.scratchDir. Entries' paths are cleaned before they're used, and
+// entries whose paths are absolute or would be extracted outside of the directory, and
+// links that point outside of it, are rejected. Links inside the directory are skipped,
+// since backups don't contain them. If ctx.maxExtractedSize is positive, extraction
+// fails once the files' total size exceeds it.
+func (ctx *context) readBackup(tarRdr archive.Reader) (string, error) {
+	dir, err := ctx.fileSystem.TempDir(ctx.scratchDir, "")
 	if err != nil {
 		ctx.log.Infof("error creating temp dir: %v", err)
 		return "", err
 	}
 
+	var extracted int64
 	for {
 		header, err := tarRdr.Next()
 
@@ -1194,7 +2780,20 @@ func (ctx *context) readBackup(tarRdr *tar.Reader) (string, error) {
 			return "", err
 		}
 
-		target := filepath.Join(dir, header.Name)
+		// archives without a version file have format version 1, which
+		// is always readable. The name is cleaned so that the version file
+		// can't be extracted without being checked by naming it differently.
+		if path.Clean(header.Name) == api.ArchiveVersionFile {
+			if _, err := archive.ReadFormatVersion(tarRdr); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		target, err := extractPath(dir, header.Name)
+		if err != nil {
+			return "", err
+		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
@@ -1212,19 +2811,74 @@ func (ctx *context) readBackup(tarRdr *tar.Reader) (string, error) {
 				return "", err
 			}
 
-			// create the file
-			file, err := ctx.fileSystem.Create(target)
+			n, err := ctx.extractFile(target, tarRdr, extracted)
+			extracted += n
 			if err != nil {
 				return "", err
 			}
-			defer file.Close()
 
-			if _, err := io.Copy(file, tarRdr); err != nil {
-				ctx.log.Infof("error copying: %v", err)
-				return "", err
+		case tar.TypeSymlink, tar.TypeLink:
+			linkTarget := header.Linkname
+			if header.Typeflag == tar.TypeSymlink && !filepath.IsAbs(linkTarget) {
+				// symlink targets are relative to the link's directory, and
+				// hard link targets are relative to the root of the archive
+				linkTarget = filepath.Join(filepath.Dir(header.Name), linkTarget)
+			}
+
+			if _, err := extractPath(dir, linkTarget); err != nil {
+				return "", errors.Errorf("link %s points outside of the backup archive", header.Name)
 			}
+
+			ctx.log.Infof("Skipping link %s in backup archive", header.Name)
 		}
 	}
 
 	return dir, nil
 }
+
+// extractFile copies the current file in an archive to target, returning the number
+// of bytes copied. extracted is the number of bytes already extracted from the
+// archive, which is used to enforce ctx.maxExtractedSize.
+func (ctx *context) extractFile(target string, r io.Reader, extracted int64) (int64, error) {
+	file, err := ctx.fileSystem.Create(target)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	if ctx.maxExtractedSize <= 0 {
+		n, err := io.Copy(file, r)
+		if err != nil {
+			ctx.log.Infof("error copying: %v", err)
+		}
+		return n, err
+	}
+
+	// copy one byte more than the remaining limit to find out whether the
+	// file exceeds it
+	n, err := io.CopyN(file, r, ctx.maxExtractedSize-extracted+1)
+	if err != nil && err != io.EOF {
+		ctx.log.Infof("error copying: %v", err)
+		return n, err
+	}
+	if extracted+n > ctx.maxExtractedSize {
+		return n, errors.Errorf("backup archive exceeds the maximum extracted size of %d bytes", ctx.maxExtractedSize)
+	}
+
+	return n, nil
+}
+
+// extractPath returns the path in dir that the archive entry name is extracted to,
+// or an error if name is absolute or would be extracted outside of dir.
+func extractPath(dir, name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return "", errors.Errorf("invalid path %s in backup archive: path is absolute", name)
+	}
+
+	cleaned := filepath.Clean(name)
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid path %s in backup archive: path is outside of the archive", name)
+	}
+
+	return filepath.Join(dir, cleaned), nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/discovery"
+	"github.com/heptio/ark/pkg/httphook"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+const (
+	restoreHookPhasePre  = "pre"
+	restoreHookPhasePost = "post"
+)
+
+// restoreHook is a restore hook spec with its namespaces, resources and label
+// selector resolved.
+type restoreHook struct {
+	name          string
+	namespaces    *collections.IncludesExcludes
+	resources     *collections.IncludesExcludes
+	labelSelector labels.Selector
+	pre           []api.RestoreResourceHook
+	post          []api.RestoreResourceHook
+}
+
+// getRestoreHooks resolves the restore's hook specs, returning an error if any of
+// them are invalid.
+func getRestoreHooks(hookSpecs []api.RestoreResourceHookSpec, helper discovery.Helper) ([]restoreHook, error) {
+	hooks := make([]restoreHook, 0, len(hookSpecs))
+
+	for _, spec := range hookSpecs {
+		for _, specHooks := range [][]api.RestoreResourceHook{spec.PreHooks, spec.PostHooks} {
+			for _, hook := range specHooks {
+				if hook.HTTP == nil {
+					return nil, errors.Errorf("invalid hook %s: a hook must specify http", spec.Name)
+				}
+				if err := httphook.Validate(hook.HTTP); err != nil {
+					return nil, errors.Wrapf(err, "invalid hook %s", spec.Name)
+				}
+			}
+		}
+
+		h := restoreHook{
+			name:       spec.Name,
+			namespaces: collections.NewIncludesExcludes().Includes(spec.IncludedNamespaces...).Excludes(spec.ExcludedNamespaces...),
+			resources:  getResourceIncludesExcludes(helper, spec.IncludedResources, spec.ExcludedResources),
+			pre:        spec.PreHooks,
+			post:       spec.PostHooks,
+		}
+
+		if spec.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(spec.LabelSelector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid hook %s", spec.Name)
+			}
+			h.labelSelector = selector
+		}
+
+		hooks = append(hooks, h)
+	}
+
+	return hooks, nil
+}
+
+// applicableTo returns whether the hook applies to an item of groupResource that was
+// backed up from namespace with the given labels.
+func (h restoreHook) applicableTo(groupResource schema.GroupResource, namespace string, labels labels.Set) bool {
+	if h.namespaces != nil && !h.namespaces.ShouldInclude(namespace) {
+		return false
+	}
+	if h.resources != nil && !h.resources.ShouldInclude(groupResource.String()) {
+		return false
+	}
+	if h.labelSelector != nil && !h.labelSelector.Matches(labels) {
+		return false
+	}
+	return true
+}
+
+// runRestoreHooks calls the restore's hooks for the phase that apply to obj, which was
+// backed up from originalNamespace. Errors from hooks whose onError mode is Continue are
+// returned as warnings, while errors from other hooks are returned.
+func (ctx *context) runRestoreHooks(groupResource schema.GroupResource, obj *unstructured.Unstructured, originalNamespace, phase string) (warnings []error, err error) {
+	if len(ctx.hooks) == 0 {
+		return nil, nil
+	}
+
+	var item *httphook.Item
+	for _, hook := range ctx.hooks {
+		if !hook.applicableTo(groupResource, originalNamespace, labels.Set(obj.GetLabels())) {
+			continue
+		}
+
+		specHooks := hook.pre
+		if phase == restoreHookPhasePost {
+			specHooks = hook.post
+		}
+
+		for _, specHook := range specHooks {
+			if item == nil {
+				i, err := httphook.NewItem(groupResource, obj)
+				if err != nil {
+					return warnings, err
+				}
+				item = &i
+			}
+
+			hookLog := ctx.log.WithFields(logrus.Fields{
+				"hookSource": "restoreSpec",
+				"hookType":   "http",
+				"hookPhase":  phase,
+			})
+			payload := &httphook.Payload{
+				Operation: "restore",
+				Phase:     phase,
+				Backup:    ctx.restore.Spec.BackupName,
+				Restore:   ctx.restore.Name,
+				Hook:      hook.name,
+				Item:      *item,
+			}
+
+			if err := ctx.httpHookCaller.Call(hookLog, specHook.HTTP, payload); err != nil {
+				hookLog.WithError(err).Error("Error calling hook")
+				if specHook.HTTP.OnError != api.HookErrorModeContinue {
+					return warnings, err
+				}
+				warnings = append(warnings, err)
+			}
+		}
+	}
+
+	return warnings, nil
+}
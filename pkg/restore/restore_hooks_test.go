@@ -0,0 +1,211 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/httphook"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestGetRestoreHooks(t *testing.T) {
+	validHook := api.RestoreResourceHook{HTTP: &api.HTTPHook{URL: "https://example.com/hook"}}
+
+	tests := []struct {
+		name        string
+		specs       []api.RestoreResourceHookSpec
+		expectError bool
+	}{
+		{
+			name: "valid hooks",
+			specs: []api.RestoreResourceHookSpec{
+				{
+					Name:          "hook1",
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+					PreHooks:      []api.RestoreResourceHook{validHook},
+					PostHooks:     []api.RestoreResourceHook{validHook},
+				},
+			},
+		},
+		{
+			name: "hook without http",
+			specs: []api.RestoreResourceHookSpec{
+				{Name: "hook1", PreHooks: []api.RestoreResourceHook{{}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "hook with invalid URL",
+			specs: []api.RestoreResourceHookSpec{
+				{Name: "hook1", PostHooks: []api.RestoreResourceHook{{HTTP: &api.HTTPHook{URL: "example.com"}}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid label selector",
+			specs: []api.RestoreResourceHookSpec{
+				{
+					Name: "hook1",
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "bad"}},
+					},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hooks, err := getRestoreHooks(test.specs, arktest.NewFakeDiscoveryHelper(true, nil))
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, hooks, len(test.specs))
+		})
+	}
+}
+
+func TestRunRestoreHooks(t *testing.T) {
+	continueHook := &api.HTTPHook{URL: "http://example.com/continue", OnError: api.HookErrorModeContinue}
+	// hooks default to failing
+	failHook := &api.HTTPHook{URL: "http://example.com/fail"}
+
+	specs := []api.RestoreResourceHookSpec{
+		{
+			Name:               "db",
+			IncludedNamespaces: []string{"ns-1"},
+			IncludedResources:  []string{"configmaps"},
+			LabelSelector:      &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			PreHooks:           []api.RestoreResourceHook{{HTTP: continueHook}},
+			PostHooks:          []api.RestoreResourceHook{{HTTP: failHook}},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		originalNamespace string
+		labels            map[string]interface{}
+		phase             string
+		hookErrors        map[string]error
+		expectedCalls     []*api.HTTPHook
+		expectedWarnings  int
+		expectError       bool
+	}{
+		{
+			name:              "pre hooks are called for matching items",
+			originalNamespace: "ns-1",
+			labels:            map[string]interface{}{"app": "db"},
+			phase:             restoreHookPhasePre,
+			expectedCalls:     []*api.HTTPHook{continueHook},
+		},
+		{
+			name:              "post hooks are called for matching items",
+			originalNamespace: "ns-1",
+			labels:            map[string]interface{}{"app": "db"},
+			phase:             restoreHookPhasePost,
+			expectedCalls:     []*api.HTTPHook{failHook},
+		},
+		{
+			name:              "hooks match the item's backed-up namespace",
+			originalNamespace: "ns-2",
+			labels:            map[string]interface{}{"app": "db"},
+			phase:             restoreHookPhasePre,
+		},
+		{
+			name:              "hooks match the item's labels",
+			originalNamespace: "ns-1",
+			labels:            map[string]interface{}{"app": "web"},
+			phase:             restoreHookPhasePre,
+		},
+		{
+			name:              "errors from continue hooks are warnings",
+			originalNamespace: "ns-1",
+			labels:            map[string]interface{}{"app": "db"},
+			phase:             restoreHookPhasePre,
+			hookErrors:        map[string]error{continueHook.URL: errors.New("continue error")},
+			expectedCalls:     []*api.HTTPHook{continueHook},
+			expectedWarnings:  1,
+		},
+		{
+			name:              "errors from fail hooks are returned",
+			originalNamespace: "ns-1",
+			labels:            map[string]interface{}{"app": "db"},
+			phase:             restoreHookPhasePost,
+			hookErrors:        map[string]error{failHook.URL: errors.New("fail error")},
+			expectedCalls:     []*api.HTTPHook{failHook},
+			expectError:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hooks, err := getRestoreHooks(specs, arktest.NewFakeDiscoveryHelper(true, nil))
+			require.NoError(t, err)
+
+			caller := &arktest.MockHTTPHookCaller{}
+			defer caller.AssertExpectations(t)
+			for _, hook := range test.expectedCalls {
+				caller.On("Call", mock.Anything, hook, mock.Anything).Return(test.hookErrors[hook.URL]).Once()
+			}
+
+			ctx := &context{
+				log:            arktest.NewLogger(),
+				restore:        arktest.NewTestRestore("ark", "restore-1", api.RestorePhaseInProgress).WithBackup("backup-1").Restore,
+				hooks:          hooks,
+				httpHookCaller: caller,
+			}
+
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"namespace": "restored-ns",
+					"name":      "cm",
+					"labels":    test.labels,
+				},
+			}}
+
+			warnings, err := ctx.runRestoreHooks(schema.GroupResource{Resource: "configmaps"}, obj, test.originalNamespace, test.phase)
+			assert.Equal(t, test.expectError, err != nil)
+			assert.Len(t, warnings, test.expectedWarnings)
+
+			for _, call := range caller.Calls {
+				payload := call.Arguments.Get(2).(*httphook.Payload)
+				assert.Equal(t, "restore", payload.Operation)
+				assert.Equal(t, test.phase, payload.Phase)
+				assert.Equal(t, "backup-1", payload.Backup)
+				assert.Equal(t, "restore-1", payload.Restore)
+				assert.Equal(t, "db", payload.Hook)
+				assert.Equal(t, "restored-ns", payload.Item.Namespace)
+				assert.Equal(t, "cm", payload.Item.Name)
+			}
+		})
+	}
+}
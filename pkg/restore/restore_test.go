@@ -17,7 +17,16 @@ limitations under the License.
 package restore
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	go_context "context"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,17 +42,25 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/scheme"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/archive"
+	"github.com/heptio/ark/pkg/client"
 	"github.com/heptio/ark/pkg/cloudprovider"
 	cloudprovidermocks "github.com/heptio/ark/pkg/cloudprovider/mocks"
 	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
 	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	"github.com/heptio/ark/pkg/httphook"
+	"github.com/heptio/ark/pkg/itemhash"
 	"github.com/heptio/ark/pkg/kuberesource"
+	"github.com/heptio/ark/pkg/util/boolptr"
 	"github.com/heptio/ark/pkg/util/collections"
 	"github.com/heptio/ark/pkg/util/logging"
 	arktest "github.com/heptio/ark/pkg/util/test"
@@ -55,6 +72,7 @@ func TestPrioritizeResources(t *testing.T) {
 		name         string
 		apiResources map[string][]string
 		priorities   []string
+		last         []string
 		includes     []string
 		excludes     []string
 		expected     []string
@@ -87,6 +105,26 @@ func TestPrioritizeResources(t *testing.T) {
 			excludes:   []string{"ooo", "pods"},
 			expected:   []string{"namespaces", "configmaps", "aaa", "bbb", "ddd", "sss"},
 		},
+		{
+			name: "resources to restore last are correctly applied",
+			apiResources: map[string][]string{
+				"v1": {"aaa", "bbb", "configmaps", "ddd", "namespaces", "ooo", "pods", "sss"},
+			},
+			priorities: []string{"namespaces", "configmaps"},
+			last:       []string{"sss", "pods"},
+			includes:   []string{"*"},
+			expected:   []string{"namespaces", "configmaps", "aaa", "bbb", "ddd", "ooo", "sss", "pods"},
+		},
+		{
+			name: "resources listed more than once keep their first place",
+			apiResources: map[string][]string{
+				"v1": {"aaa", "configmaps", "namespaces", "pods"},
+			},
+			priorities: []string{"namespaces", "configmaps", "namespaces"},
+			last:       []string{"namespaces", "pods"},
+			includes:   []string{"*"},
+			expected:   []string{"namespaces", "configmaps", "aaa", "pods"},
+		},
 	}
 
 	logger := arktest.NewLogger()
@@ -108,7 +146,7 @@ func TestPrioritizeResources(t *testing.T) {
 
 			includesExcludes := collections.NewIncludesExcludes().Includes(test.includes...).Excludes(test.excludes...)
 
-			result, err := prioritizeResources(helper, test.priorities, includesExcludes, logger)
+			result, err := prioritizeResources(helper, test.priorities, test.last, includesExcludes, logger)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -189,6 +227,7 @@ func TestRestoreNamespaceFiltering(t *testing.T) {
 			log := arktest.NewLogger()
 
 			ctx := &context{
+				goContext:            go_context.Background(),
 				restore:              test.restore,
 				namespaceClient:      &fakeNamespaceClient{},
 				fileSystem:           test.fileSystem,
@@ -209,6 +248,106 @@ func TestRestoreNamespaceFiltering(t *testing.T) {
 	}
 }
 
+func TestRestoreNamespaceOrder(t *testing.T) {
+	fileSystem := arktest.NewFakeFileSystem().WithDirectories(
+		"bak/resources/nodes/cluster",
+		"bak/resources/secrets/namespaces/a",
+		"bak/resources/secrets/namespaces/b",
+		"bak/resources/secrets/namespaces/c",
+		"bak/resources/configmaps/namespaces/a",
+		"bak/resources/configmaps/namespaces/b",
+		"bak/resources/configmaps/namespaces/c",
+	)
+
+	ctx := &context{
+		goContext:       go_context.Background(),
+		restore:         &api.Restore{Spec: api.RestoreSpec{IncludedNamespaces: []string{"*"}, NamespaceOrder: []string{"c", "a"}}},
+		namespaceClient: &fakeNamespaceClient{},
+		fileSystem:      fileSystem,
+		log:             arktest.NewLogger(),
+		prioritizedResources: []schema.GroupResource{
+			{Resource: "nodes"},
+			{Resource: "secrets"},
+			{Resource: "configmaps"},
+		},
+	}
+
+	warnings, errs := ctx.restoreFromDir("bak")
+
+	assert.Empty(t, warnings.Ark)
+	assert.Empty(t, errs.Ark)
+
+	// cluster-scoped items are restored first, then all of c's items, then all of
+	// a's, and then the items of the namespaces that aren't ordered
+	var restoredDirs []string
+	for _, dir := range fileSystem.ReadDirCalls {
+		if strings.HasSuffix(dir, "/cluster") || strings.Count(dir, "/") == 4 {
+			restoredDirs = append(restoredDirs, dir)
+		}
+	}
+	expected := []string{
+		"bak/resources/nodes/cluster",
+		"bak/resources/secrets/namespaces/c",
+		"bak/resources/configmaps/namespaces/c",
+		"bak/resources/secrets/namespaces/a",
+		"bak/resources/configmaps/namespaces/a",
+		"bak/resources/secrets/namespaces/b",
+		"bak/resources/configmaps/namespaces/b",
+	}
+	assert.Equal(t, expected, restoredDirs)
+}
+
+func TestRestoreByNamespace(t *testing.T) {
+	fileSystem := arktest.NewFakeFileSystem().WithDirectories(
+		"bak/resources/nodes/cluster",
+		"bak/resources/secrets/namespaces/a",
+		"bak/resources/secrets/namespaces/b",
+		"bak/resources/configmaps/namespaces/a",
+		"bak/resources/configmaps/namespaces/b",
+		"bak/resources/configmaps/namespaces/c",
+	)
+
+	ctx := &context{
+		goContext: go_context.Background(),
+		restore: &api.Restore{Spec: api.RestoreSpec{
+			IncludedNamespaces: []string{"*"},
+			NamespaceOrder:     []string{"b"},
+			IterationMode:      api.RestoreIterationModeByNamespace,
+		}},
+		namespaceClient: &fakeNamespaceClient{},
+		fileSystem:      fileSystem,
+		log:             arktest.NewLogger(),
+		prioritizedResources: []schema.GroupResource{
+			{Resource: "nodes"},
+			{Resource: "secrets"},
+			{Resource: "configmaps"},
+		},
+	}
+
+	warnings, errs := ctx.restoreFromDir("bak")
+
+	assert.Empty(t, warnings.Ark)
+	assert.Empty(t, errs.Ark)
+
+	// cluster-scoped items are restored first, then all of b's items since it's
+	// ordered, and then all of the items of each other namespace by name
+	var restoredDirs []string
+	for _, dir := range fileSystem.ReadDirCalls {
+		if strings.HasSuffix(dir, "/cluster") || strings.Count(dir, "/") == 4 {
+			restoredDirs = append(restoredDirs, dir)
+		}
+	}
+	expected := []string{
+		"bak/resources/nodes/cluster",
+		"bak/resources/secrets/namespaces/b",
+		"bak/resources/configmaps/namespaces/b",
+		"bak/resources/secrets/namespaces/a",
+		"bak/resources/configmaps/namespaces/a",
+		"bak/resources/configmaps/namespaces/c",
+	}
+	assert.Equal(t, expected, restoredDirs)
+}
+
 func TestRestorePriority(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -271,6 +410,15 @@ func TestRestorePriority(t *testing.T) {
 				Namespaces: map[string][]string{
 					"ns-1": {"error decoding \"bak/resources/a/namespaces/ns-1/invalid-json.json\": invalid character 'i' looking for beginning of value"},
 				},
+				Items: []api.RestoreResultItem{
+					{
+						Resource:  "a",
+						Namespace: "ns-1",
+						Name:      "invalid-json",
+						Category:  api.RestoreResultCategoryDecode,
+						Message:   "error decoding \"bak/resources/a/namespaces/ns-1/invalid-json.json\": invalid character 'i' looking for beginning of value",
+					},
+				},
 			},
 			expectedReadDirs: []string{"bak/resources", "bak/resources/a/namespaces", "bak/resources/a/namespaces/ns-1", "bak/resources/c/namespaces", "bak/resources/c/namespaces/ns-1"},
 		},
@@ -281,6 +429,7 @@ func TestRestorePriority(t *testing.T) {
 			log := arktest.NewLogger()
 
 			ctx := &context{
+				goContext:            go_context.Background(),
 				restore:              test.restore,
 				namespaceClient:      &fakeNamespaceClient{},
 				fileSystem:           test.fileSystem,
@@ -327,6 +476,7 @@ func TestNamespaceRemapping(t *testing.T) {
 	namespaceClient := &fakeNamespaceClient{}
 
 	ctx := &context{
+		goContext:            go_context.Background(),
 		dynamicFactory:       dynamicFactory,
 		fileSystem:           fileSystem,
 		selector:             labelSelector,
@@ -357,6 +507,197 @@ func TestNamespaceRemapping(t *testing.T) {
 	resourceClient.AssertExpectations(t)
 }
 
+func TestRestoreFromDirNamespaceCreationPolicy(t *testing.T) {
+	tests := []struct {
+		name               string
+		policy             api.RestoreNamespaceCreationPolicy
+		existingNamespaces sets.String
+		expectedErrs       []string
+		expectedCreated    []string
+	}{
+		{
+			name:            "Always creates namespaces that don't exist",
+			policy:          api.RestoreNamespaceCreationPolicyAlways,
+			expectedCreated: []string{"ns-1", "ns-3"},
+		},
+		{
+			name:               "Never fails if any namespace doesn't exist",
+			policy:             api.RestoreNamespaceCreationPolicyNever,
+			existingNamespaces: sets.NewString("ns-1"),
+			expectedErrs:       []string{"namespaces ns-3 don't exist and the restore's namespace creation policy is Never"},
+		},
+		{
+			name:               "Never doesn't create namespaces that exist",
+			policy:             api.RestoreNamespaceCreationPolicyNever,
+			existingNamespaces: sets.NewString("ns-1", "ns-3"),
+		},
+		{
+			name:               "IfMappedOnly creates mapped namespaces",
+			policy:             api.RestoreNamespaceCreationPolicyIfMappedOnly,
+			existingNamespaces: sets.NewString("ns-1"),
+			expectedCreated:    []string{"ns-3"},
+		},
+		{
+			name:               "IfMappedOnly fails if an unmapped namespace doesn't exist",
+			policy:             api.RestoreNamespaceCreationPolicyIfMappedOnly,
+			existingNamespaces: sets.NewString("ns-3"),
+			expectedErrs:       []string{"namespaces ns-1 don't exist and the restore's namespace creation policy is IfMappedOnly"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				restore = &api.Restore{Spec: api.RestoreSpec{
+					IncludedNamespaces: []string{"*"},
+					NamespaceMapping:   map[string]string{"ns-2": "ns-3"},
+					CreateNamespaces:   test.policy,
+				}}
+				fileSystem = arktest.NewFakeFileSystem().
+						WithFile("bak/resources/configmaps/namespaces/ns-1/cm-1.json", newTestConfigMap().WithNamespace("ns-1").ToJSON()).
+						WithFile("bak/resources/configmaps/namespaces/ns-2/cm-1.json", newTestConfigMap().WithNamespace("ns-2").ToJSON())
+				resourceClient  = &arktest.FakeDynamicClient{}
+				dynamicFactory  = &arktest.FakeDynamicFactory{}
+				namespaceClient = &fakeNamespaceClient{existingNamespaces: test.existingNamespaces}
+			)
+
+			resourceClient.On("Create", mock.Anything).Return(&unstructured.Unstructured{}, nil)
+			dynamicFactory.On("ClientForGroupVersionResource", mock.Anything, mock.Anything, mock.Anything).Return(resourceClient, nil)
+
+			ctx := &context{
+				goContext:            go_context.Background(),
+				dynamicFactory:       dynamicFactory,
+				fileSystem:           fileSystem,
+				selector:             labels.NewSelector(),
+				namespaceClient:      namespaceClient,
+				prioritizedResources: []schema.GroupResource{{Resource: "namespaces"}, {Resource: "configmaps"}},
+				restore:              restore,
+				backup:               &api.Backup{},
+				log:                  arktest.NewLogger(),
+			}
+
+			_, errs := ctx.restoreFromDir("bak")
+
+			assert.Equal(t, test.expectedErrs, errs.Ark)
+
+			var created []string
+			for _, ns := range namespaceClient.createdNamespaces {
+				created = append(created, ns.Name)
+			}
+			assert.Equal(t, test.expectedCreated, created)
+
+			if len(test.expectedErrs) > 0 {
+				// nothing is restored if the policy check fails
+				resourceClient.AssertNotCalled(t, "Create", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRestoreFromDirMergeNamespaceMetadata(t *testing.T) {
+	tests := []struct {
+		name            string
+		merge           bool
+		policy          api.RestoreNamespaceCreationPolicy
+		expectedPatches map[string]string
+	}{
+		{
+			name: "existing namespaces aren't patched by default",
+		},
+		{
+			name:  "existing namespaces get backed-up labels and annotations merged in",
+			merge: true,
+			expectedPatches: map[string]string{
+				"ns-1": `{"metadata":{"annotations":{"a":"b"},"labels":{"istio-injection":"enabled"}}}`,
+			},
+		},
+		{
+			name:   "namespaces checked against the creation policy get backed-up labels and annotations merged in",
+			merge:  true,
+			policy: api.RestoreNamespaceCreationPolicyNever,
+			expectedPatches: map[string]string{
+				"ns-1": `{"metadata":{"annotations":{"a":"b"},"labels":{"istio-injection":"enabled"}}}`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				restore = &api.Restore{Spec: api.RestoreSpec{
+					IncludedNamespaces:     []string{"*"},
+					CreateNamespaces:       test.policy,
+					MergeNamespaceMetadata: test.merge,
+				}}
+				fileSystem = arktest.NewFakeFileSystem().
+						WithFile("bak/resources/configmaps/namespaces/ns-1/cm-1.json", newTestConfigMap().WithNamespace("ns-1").ToJSON()).
+						WithFile("bak/resources/configmaps/namespaces/ns-2/cm-1.json", newTestConfigMap().WithNamespace("ns-2").ToJSON()).
+						WithFile("bak/resources/namespaces/cluster/ns-1.json", newTestNamespace("ns-1").WithLabel("istio-injection", "enabled").WithAnnotation("a", "b").ToJSON()).
+						WithFile("bak/resources/namespaces/cluster/ns-2.json", newTestNamespace("ns-2").ToJSON())
+				resourceClient  = &arktest.FakeDynamicClient{}
+				dynamicFactory  = &arktest.FakeDynamicFactory{}
+				namespaceClient = &fakeNamespaceClient{existingNamespaces: sets.NewString("ns-1", "ns-2")}
+			)
+
+			resourceClient.On("Create", mock.Anything).Return(&unstructured.Unstructured{}, nil)
+			dynamicFactory.On("ClientForGroupVersionResource", mock.Anything, mock.Anything, mock.Anything).Return(resourceClient, nil)
+
+			ctx := &context{
+				goContext:            go_context.Background(),
+				dynamicFactory:       dynamicFactory,
+				fileSystem:           fileSystem,
+				selector:             labels.NewSelector(),
+				namespaceClient:      namespaceClient,
+				prioritizedResources: []schema.GroupResource{{Resource: "namespaces"}, {Resource: "configmaps"}},
+				restore:              restore,
+				backup:               &api.Backup{},
+				log:                  arktest.NewLogger(),
+			}
+
+			_, errs := ctx.restoreFromDir("bak")
+
+			assert.Empty(t, errs.Ark)
+			assert.Empty(t, errs.Namespaces)
+			// ns-2 has no labels or annotations in the backup, so it's never patched
+			assert.Equal(t, test.expectedPatches, namespaceClient.patches)
+			assert.Empty(t, namespaceClient.createdNamespaces)
+		})
+	}
+}
+
+func TestRestoreFromDirStopsWhenContextIsDone(t *testing.T) {
+	var (
+		restore              = &api.Restore{Spec: api.RestoreSpec{IncludedNamespaces: []string{"*"}}}
+		prioritizedResources = []schema.GroupResource{{Resource: "configmaps"}}
+		fileSystem           = arktest.NewFakeFileSystem().
+					WithFile("bak/resources/configmaps/namespaces/ns-1/cm-1.json", newTestConfigMap().WithNamespace("ns-1").ToJSON())
+		dynamicFactory = &arktest.FakeDynamicFactory{}
+	)
+
+	goContext, cancel := go_context.WithCancel(go_context.Background())
+	cancel()
+
+	ctx := &context{
+		goContext:            goContext,
+		dynamicFactory:       dynamicFactory,
+		fileSystem:           fileSystem,
+		selector:             labels.NewSelector(),
+		namespaceClient:      &fakeNamespaceClient{},
+		prioritizedResources: prioritizedResources,
+		restore:              restore,
+		backup:               &api.Backup{},
+		log:                  arktest.NewLogger(),
+	}
+
+	_, errs := ctx.restoreFromDir("bak")
+
+	require.Len(t, errs.Ark, 1)
+	assert.Contains(t, errs.Ark[0], go_context.Canceled.Error())
+
+	// no items should have been restored
+	dynamicFactory.AssertNotCalled(t, "ClientForGroupVersionResource", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestRestoreResourceForNamespace(t *testing.T) {
 	var (
 		trueVal  = true
@@ -418,6 +759,15 @@ func TestRestoreResourceForNamespace(t *testing.T) {
 				Namespaces: map[string][]string{
 					"ns-1": {"error decoding \"configmaps/cm-1-invalid.json\": invalid character 'h' in literal true (expecting 'r')"},
 				},
+				Items: []api.RestoreResultItem{
+					{
+						Resource:  "configmaps",
+						Namespace: "ns-1",
+						Name:      "cm-1-invalid",
+						Category:  api.RestoreResultCategoryDecode,
+						Message:   "error decoding \"configmaps/cm-1-invalid.json\": invalid character 'h' in literal true (expecting 'r')",
+					},
+				},
 			},
 			expectedObjs: toUnstructured(newNamedTestConfigMap("cm-2").ConfigMap),
 		},
@@ -605,7 +955,15 @@ func TestRestoreResourceForNamespace(t *testing.T) {
 
 			pvResource := metav1.APIResource{Name: "persistentvolumes", Namespaced: false}
 			dynamicFactory.On("ClientForGroupVersionResource", gv, pvResource, test.namespace).Return(resourceClient, nil)
-			resourceClient.On("Watch", metav1.ListOptions{}).Return(&fakeWatch{}, nil)
+
+			// restored PVs are waited on until they're available
+			pvWatch := watch.NewFakeWithChanSize(len(test.expectedObjs), false)
+			for i := range test.expectedObjs {
+				availablePV := test.expectedObjs[i].DeepCopy()
+				require.NoError(t, unstructured.SetNestedField(availablePV.Object, string(v1.VolumeAvailable), "status", "phase"))
+				pvWatch.Add(availablePV)
+			}
+			resourceClient.On("Watch", metav1.ListOptions{LabelSelector: "ark.heptio.com/restore-name=my-restore"}).Return(pvWatch, nil)
 
 			saResource := metav1.APIResource{Name: "serviceaccounts", Namespaced: true}
 			dynamicFactory.On("ClientForGroupVersionResource", gv, saResource, test.namespace).Return(resourceClient, nil)
@@ -614,6 +972,7 @@ func TestRestoreResourceForNamespace(t *testing.T) {
 			dynamicFactory.On("ClientForGroupVersionResource", gv, podResource, test.namespace).Return(resourceClient, nil)
 
 			ctx := &context{
+				goContext:      go_context.Background(),
 				dynamicFactory: dynamicFactory,
 				actions:        test.actions,
 				fileSystem:     test.fileSystem,
@@ -651,6 +1010,153 @@ func TestRestoreResourceForNamespace(t *testing.T) {
 	}
 }
 
+func TestAddItemToResult(t *testing.T) {
+	var res api.RestoreResult
+
+	addItemToResult(&res, api.RestoreResultCategoryCreate, kuberesource.Pods, "ns-1", "pod-1", errors.New("create failed"))
+	addItemToResult(&res, api.RestoreResultCategoryPrepare, kuberesource.PersistentVolumes, "", "pv-1", errors.New("snapshot restore failed"))
+
+	other := api.RestoreResult{}
+	addItemToResult(&other, api.RestoreResultCategoryDecode, kuberesource.Pods, "ns-2", "pod-2", errors.New("decode failed"))
+	merge(&res, &other)
+
+	expected := api.RestoreResult{
+		Cluster: []string{"snapshot restore failed"},
+		Namespaces: map[string][]string{
+			"ns-1": {"create failed"},
+			"ns-2": {"decode failed"},
+		},
+		Items: []api.RestoreResultItem{
+			{Resource: "pods", Namespace: "ns-1", Name: "pod-1", Category: api.RestoreResultCategoryCreate, Message: "create failed"},
+			{Resource: "persistentvolumes", Name: "pv-1", Category: api.RestoreResultCategoryPrepare, Message: "snapshot restore failed"},
+			{Resource: "pods", Namespace: "ns-2", Name: "pod-2", Category: api.RestoreResultCategoryDecode, Message: "decode failed"},
+		},
+	}
+
+	assert.Equal(t, expected, res)
+}
+
+func TestWithRetries(t *testing.T) {
+	defer func(backoff wait.Backoff) { restoreRetryBackoff = backoff }(restoreRetryBackoff)
+	restoreRetryBackoff = wait.Backoff{Steps: 3}
+
+	var (
+		throttled   = k8serrors.NewTooManyRequests("throttled", 1)
+		unavailable = k8serrors.NewServiceUnavailable("unavailable")
+		invalid     = k8serrors.NewBadRequest("invalid")
+	)
+
+	tests := []struct {
+		name             string
+		errs             []error
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{
+			name:             "success on first attempt",
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "success after transient errors",
+			errs:             []error{throttled, unavailable, nil},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "non-retriable error isn't retried",
+			errs:             []error{invalid},
+			expectedAttempts: 1,
+			expectedErr:      invalid,
+		},
+		{
+			name:             "non-retriable error after a transient one stops retries",
+			errs:             []error{throttled, invalid},
+			expectedAttempts: 2,
+			expectedErr:      invalid,
+		},
+		{
+			name:             "transient errors until steps are used up returns the last error",
+			errs:             []error{throttled, throttled, unavailable},
+			expectedAttempts: 3,
+			expectedErr:      unavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls int
+			attempts, err := withRetries(arktest.NewLogger(), func() error {
+				err := test.errs[calls]
+				calls++
+				return err
+			})
+
+			assert.Equal(t, test.expectedAttempts, attempts)
+			assert.Equal(t, test.expectedAttempts, calls)
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestAddRetriedItemToResult(t *testing.T) {
+	var res api.RestoreResult
+
+	addRetriedItemToResult(&res, api.RestoreResultCategoryCreate, kuberesource.Pods, "ns-1", "pod-1", 1, errors.New("create failed"))
+	addRetriedItemToResult(&res, api.RestoreResultCategoryCreate, kuberesource.Pods, "ns-1", "pod-2", 5, errors.New("create failed"))
+
+	assert.Equal(t, []string{"create failed", "failed after 5 attempts: create failed"}, res.Namespaces["ns-1"])
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, 1, res.Items[0].Attempts)
+	assert.Equal(t, 5, res.Items[1].Attempts)
+	assert.Equal(t, "failed after 5 attempts: create failed", res.Items[1].Message)
+}
+
+func TestAddItemToResultSummarizesRepeatedMessages(t *testing.T) {
+	var res api.RestoreResult
+
+	for i := 1; i <= 8; i++ {
+		name := fmt.Sprintf("cm-%d", i)
+		addItemToResult(&res, api.RestoreResultCategoryCreate, kuberesource.ConfigMaps, "ns-1", name, errors.Errorf("error restoring %s: exceeded quota", name))
+	}
+	// a different message, resource or namespace isn't summarized with the others
+	addItemToResult(&res, api.RestoreResultCategoryCreate, kuberesource.ConfigMaps, "ns-1", "cm-9", errors.New("error restoring cm-9: invalid"))
+	addItemToResult(&res, api.RestoreResultCategoryCreate, kuberesource.Secrets, "ns-1", "secret-1", errors.New("error restoring secret-1: exceeded quota"))
+	addItemToResult(&res, api.RestoreResultCategoryCreate, kuberesource.ConfigMaps, "ns-2", "cm-1", errors.New("error restoring cm-1: exceeded quota"))
+
+	assert.Len(t, res.Namespaces["ns-1"], maxRepeatedResultItems+2)
+	assert.Len(t, res.Namespaces["ns-2"], 1)
+	assert.Len(t, res.Items, maxRepeatedResultItems+3)
+
+	expectedSummary := api.RestoreResultSummary{
+		Resource:    "configmaps",
+		Namespace:   "ns-1",
+		Category:    api.RestoreResultCategoryCreate,
+		Message:     "error restoring <name>: exceeded quota",
+		Count:       3,
+		SampleNames: []string{"cm-6", "cm-7", "cm-8"},
+	}
+	assert.Equal(t, []api.RestoreResultSummary{expectedSummary}, res.Summaries)
+
+	// merging combines summaries of the same message, keeping a bounded number of names
+	other := api.RestoreResult{
+		Summaries: []api.RestoreResultSummary{
+			{
+				Resource:    "configmaps",
+				Namespace:   "ns-1",
+				Category:    api.RestoreResultCategoryCreate,
+				Message:     "error restoring <name>: exceeded quota",
+				Count:       4,
+				SampleNames: []string{"cm-10", "cm-11", "cm-12", "cm-13"},
+			},
+		},
+	}
+	merge(&res, &other)
+
+	require.Len(t, res.Summaries, 1)
+	assert.Equal(t, 7, res.Summaries[0].Count)
+	assert.Equal(t, []string{"cm-6", "cm-7", "cm-8", "cm-10", "cm-11"}, res.Summaries[0].SampleNames)
+}
+
 func TestRestoringExistingServiceAccount(t *testing.T) {
 	fromCluster := newTestServiceAccount()
 	fromClusterUnstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(fromCluster.ServiceAccount)
@@ -707,6 +1213,7 @@ func TestRestoringExistingServiceAccount(t *testing.T) {
 			fromBackupJSON, err := json.Marshal(test.fromBackup)
 			require.NoError(t, err)
 			ctx := &context{
+				goContext:      go_context.Background(),
 				dynamicFactory: dynamicFactory,
 				actions:        []resolvedAction{},
 				fileSystem: arktest.NewFakeFileSystem().
@@ -722,8 +1229,9 @@ func TestRestoringExistingServiceAccount(t *testing.T) {
 						BackupName:              "my-backup",
 					},
 				},
-				backup: &api.Backup{},
-				log:    arktest.NewLogger(),
+				backup:          &api.Backup{},
+				log:             arktest.NewLogger(),
+				mergeStrategies: newMergeStrategyRegistry(),
 			}
 			warnings, errors := ctx.restoreResource("serviceaccounts", "ns-1", "foo/resources/serviceaccounts/namespaces/ns-1/")
 
@@ -735,95 +1243,487 @@ func TestRestoringExistingServiceAccount(t *testing.T) {
 	}
 }
 
-func TestRestoringPVsWithoutSnapshots(t *testing.T) {
-	pv := `apiVersion: v1
-kind: PersistentVolume
-metadata:
-  annotations:
-    EXPORT_block: "\nEXPORT\n{\n\tExport_Id = 1;\n\tPath = /export/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce;\n\tPseudo
-      = /export/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce;\n\tAccess_Type = RW;\n\tSquash
-      = no_root_squash;\n\tSecType = sys;\n\tFilesystem_id = 1.1;\n\tFSAL {\n\t\tName
-      = VFS;\n\t}\n}\n"
-    Export_Id: "1"
-    Project_Id: "0"
-    Project_block: ""
-    Provisioner_Id: 5fdf4025-78a5-11e8-9ece-0242ac110004
-    kubernetes.io/createdby: nfs-dynamic-provisioner
-    pv.kubernetes.io/provisioned-by: example.com/nfs
-    volume.beta.kubernetes.io/mount-options: vers=4.1
-  creationTimestamp: 2018-06-25T18:27:35Z
-  finalizers:
-  - kubernetes.io/pv-protection
-  name: pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
-  resourceVersion: "2576"
-  selfLink: /api/v1/persistentvolumes/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
-  uid: 6ecd24e4-78a5-11e8-a0d8-e2ad1e9734ce
-spec:
-  accessModes:
-  - ReadWriteMany
-  capacity:
-    storage: 1Mi
-  claimRef:
-    apiVersion: v1
-    kind: PersistentVolumeClaim
-    name: nfs
-    namespace: default
-    resourceVersion: "2565"
-    uid: 6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
-  nfs:
-    path: /export/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
-    server: 10.103.235.254
-  storageClassName: example-nfs
-status:
-  phase: Bound`
-
-	pvc := `apiVersion: v1
-kind: PersistentVolumeClaim
-metadata:
-  annotations:
-    control-plane.alpha.kubernetes.io/leader: '{"holderIdentity":"5fdf5572-78a5-11e8-9ece-0242ac110004","leaseDurationSeconds":15,"acquireTime":"2018-06-25T18:27:35Z","renewTime":"2018-06-25T18:27:37Z","leaderTransitions":0}'
-    kubectl.kubernetes.io/last-applied-configuration: |
-      {"apiVersion":"v1","kind":"PersistentVolumeClaim","metadata":{"annotations":{},"name":"nfs","namespace":"default"},"spec":{"accessModes":["ReadWriteMany"],"resources":{"requests":{"storage":"1Mi"}},"storageClassName":"example-nfs"}}
-    pv.kubernetes.io/bind-completed: "yes"
-    pv.kubernetes.io/bound-by-controller: "yes"
-    volume.beta.kubernetes.io/storage-provisioner: example.com/nfs
-  creationTimestamp: 2018-06-25T18:27:28Z
-  finalizers:
-  - kubernetes.io/pvc-protection
-  name: nfs
-  namespace: default
-  resourceVersion: "2578"
-  selfLink: /api/v1/namespaces/default/persistentvolumeclaims/nfs
-  uid: 6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
-spec:
-  accessModes:
-  - ReadWriteMany
-  resources:
-    requests:
-      storage: 1Mi
-  storageClassName: example-nfs
-  volumeName: pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
-status:
-  accessModes:
-  - ReadWriteMany
-  capacity:
-    storage: 1Mi
-  phase: Bound`
-
+func TestRestoreResourceTimeout(t *testing.T) {
 	tests := []struct {
-		name                          string
-		haveSnapshot                  bool
-		legacyBackup                  bool
-		reclaimPolicy                 string
-		expectPVCVolumeName           bool
-		expectedPVCAnnotationsMissing sets.String
-		expectPVCreation              bool
+		name             string
+		resourceTimeouts map[string]time.Duration
+		resourceTimeout  time.Duration
+		expectedErr      string
 	}{
 		{
-			name:                "legacy backup, have snapshot, reclaim policy delete",
-			haveSnapshot:        true,
-			legacyBackup:        true,
-			reclaimPolicy:       "Delete",
+			name:             "server timeout for the resource",
+			resourceTimeouts: map[string]time.Duration{"configmaps": 10 * time.Millisecond},
+			expectedErr:      "timed out restoring configmaps after 10ms",
+		},
+		{
+			name:             "restore timeout for a resource without a server timeout",
+			resourceTimeouts: map[string]time.Duration{"secrets": time.Hour},
+			resourceTimeout:  20 * time.Millisecond,
+			expectedErr:      "timed out restoring configmaps after 20ms",
+		},
+		{
+			name:             "server timeout takes precedence over the restore timeout",
+			resourceTimeouts: map[string]time.Duration{"configmaps": 10 * time.Millisecond},
+			resourceTimeout:  time.Hour,
+			expectedErr:      "timed out restoring configmaps after 10ms",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// creates hang until the test is done
+			unblock := make(chan time.Time)
+			defer close(unblock)
+
+			resourceClient := &arktest.FakeDynamicClient{}
+			resourceClient.On("Create", mock.Anything).WaitUntil(unblock).Return(new(unstructured.Unstructured), nil)
+			resourceClient.On("Get", mock.Anything, metav1.GetOptions{}).Return((*unstructured.Unstructured)(nil), k8serrors.NewNotFound(kuberesource.ConfigMaps, ""))
+
+			ctx := newConfigMapsRestoreContext(resourceClient, 2)
+			ctx.resourceTimeouts = test.resourceTimeouts
+			ctx.resourceTimeout = test.resourceTimeout
+
+			_, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+
+			// the first config map's create times out, and the second one isn't attempted
+			resourceClient.AssertNumberOfCalls(t, "Create", 1)
+			require.Len(t, errs.Namespaces["ns-1"], 2)
+			assert.Contains(t, errs.Namespaces["ns-1"][1], test.expectedErr)
+		})
+	}
+}
+
+func TestRestoreResourceRecordsProgress(t *testing.T) {
+	resourceClient := &arktest.FakeDynamicClient{}
+	resourceClient.On("Create", mock.Anything).Return(new(unstructured.Unstructured), nil)
+
+	ctx := newConfigMapsRestoreContext(resourceClient, 3)
+	ctx.progress = NewProgress()
+	assert.Nil(t, ctx.progress.Current())
+
+	_, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+	require.Equal(t, api.RestoreResult{}, errs)
+
+	assert.Equal(t, &api.RestoreProgress{Resource: "configmaps", Namespace: "ns-1", Name: "cm-3", ItemsRestored: 3}, ctx.progress.Current())
+
+	timings := ctx.progress.ResourceTimings()
+	require.Len(t, timings, 1)
+	assert.Equal(t, "configmaps", timings[0].Resource)
+	assert.True(t, timings[0].Duration.Duration > 0)
+}
+
+func TestRestoreResourceItemTimeout(t *testing.T) {
+	// creates hang until the test is done
+	unblock := make(chan time.Time)
+	defer close(unblock)
+
+	resourceClient := &arktest.FakeDynamicClient{}
+	resourceClient.On("Create", mock.Anything).WaitUntil(unblock).Return(new(unstructured.Unstructured), nil)
+	resourceClient.On("Get", mock.Anything, metav1.GetOptions{}).Return((*unstructured.Unstructured)(nil), k8serrors.NewNotFound(kuberesource.ConfigMaps, ""))
+
+	ctx := newConfigMapsRestoreContext(resourceClient, 2)
+	ctx.itemTimeout = 10 * time.Millisecond
+
+	_, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+
+	// each config map's create times out, without stopping the others from being attempted
+	resourceClient.AssertNumberOfCalls(t, "Create", 2)
+	require.Len(t, errs.Namespaces["ns-1"], 2)
+	for _, err := range errs.Namespaces["ns-1"] {
+		assert.Contains(t, err, "timed out after 10ms")
+	}
+}
+
+func TestCreateItemRecordsTimedOutCreates(t *testing.T) {
+	restoredConfigMap := func(restoreName string) *unstructured.Unstructured {
+		return arktest.UnstructuredOrDie(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1","uid":"uid-1","labels":{"ark.heptio.com/restore-name":%q}}}`, restoreName))
+	}
+
+	tests := []struct {
+		name             string
+		existing         *unstructured.Unstructured
+		createFinishes   bool
+		expectedManifest []string
+	}{
+		{
+			name:             "item that isn't created isn't recorded",
+			expectedManifest: nil,
+		},
+		{
+			name:             "item created by the restore is recorded",
+			existing:         restoredConfigMap("my-restore"),
+			expectedManifest: []string{"uid-1"},
+		},
+		{
+			name:             "item that already existed isn't recorded",
+			existing:         restoredConfigMap("other-restore"),
+			expectedManifest: nil,
+		},
+		{
+			name:             "item created after the create is abandoned is recorded",
+			createFinishes:   true,
+			expectedManifest: []string{"uid-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unblock := make(chan time.Time)
+			defer func() {
+				if !test.createFinishes {
+					close(unblock)
+				}
+			}()
+
+			resourceClient := &arktest.FakeDynamicClient{}
+			resourceClient.On("Create", mock.Anything).WaitUntil(unblock).Return(restoredConfigMap("my-restore"), nil)
+			if test.existing != nil {
+				resourceClient.On("Get", "cm-1", metav1.GetOptions{}).Return(test.existing, nil)
+			} else {
+				resourceClient.On("Get", "cm-1", metav1.GetOptions{}).Return((*unstructured.Unstructured)(nil), k8serrors.NewNotFound(kuberesource.ConfigMaps, "cm-1"))
+			}
+
+			ctx := newConfigMapsRestoreContext(resourceClient, 1)
+			ctx.itemTimeout = 10 * time.Millisecond
+			ctx.manifest = NewManifest()
+
+			item := &pendingCreate{name: "cm-1", obj: restoredConfigMap("my-restore")}
+			ctx.createItem(go_context.Background(), resourceClient, kuberesource.ConfigMaps, item)
+			assert.EqualError(t, item.err, "timed out after 10ms")
+
+			if test.createFinishes {
+				close(unblock)
+				require.NoError(t, wait.PollImmediate(time.Millisecond, time.Second, func() (bool, error) {
+					ctx.manifest.lock.Lock()
+					defer ctx.manifest.lock.Unlock()
+					return len(ctx.manifest.Items) > 0, nil
+				}))
+			}
+
+			ctx.manifest.lock.Lock()
+			defer ctx.manifest.lock.Unlock()
+			var uids []string
+			for _, entry := range ctx.manifest.Items {
+				uids = append(uids, string(entry.UID))
+			}
+			assert.Equal(t, test.expectedManifest, uids)
+		})
+	}
+}
+
+func TestRestoreResourceFailureThreshold(t *testing.T) {
+	tests := []struct {
+		name                string
+		failureThreshold    int
+		createWorkers       int
+		expectedCreateCalls int
+		expectedErrs        int
+		expectedSummary     string
+	}{
+		{
+			name:                "no threshold attempts all items",
+			failureThreshold:    0,
+			expectedCreateCalls: 4,
+			expectedErrs:        4,
+		},
+		{
+			name:                "reaching the threshold skips the remaining items",
+			failureThreshold:    2,
+			expectedCreateCalls: 2,
+			expectedErrs:        3,
+			expectedSummary:     "skipped restoring the remaining 2 items of configmaps after 2 consecutive failures, the last of which was: ",
+		},
+		{
+			name:                "reaching the threshold on the last item doesn't add a summary",
+			failureThreshold:    4,
+			expectedCreateCalls: 4,
+			expectedErrs:        4,
+		},
+		{
+			name:                "items created concurrently are all attempted before the threshold is checked",
+			failureThreshold:    2,
+			createWorkers:       2,
+			expectedCreateCalls: 4,
+			expectedErrs:        4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resourceClient := &arktest.FakeDynamicClient{}
+			resourceClient.On("Create", mock.Anything).Return(new(unstructured.Unstructured), k8serrors.NewForbidden(kuberesource.ConfigMaps, "", errors.New("exceeded quota")))
+
+			ctx := newConfigMapsRestoreContext(resourceClient, 4)
+			ctx.failureThreshold = test.failureThreshold
+			ctx.createWorkers = test.createWorkers
+
+			_, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+
+			resourceClient.AssertNumberOfCalls(t, "Create", test.expectedCreateCalls)
+			require.Len(t, errs.Namespaces["ns-1"], test.expectedErrs)
+			if test.expectedSummary != "" {
+				assert.Contains(t, errs.Namespaces["ns-1"][test.expectedErrs-1], test.expectedSummary)
+			}
+		})
+	}
+}
+
+func TestRestoreResourceConcurrentCreates(t *testing.T) {
+	var (
+		lock                    sync.Mutex
+		inFlight, maxInFlight   int
+		resourceClient          = &arktest.FakeDynamicClient{}
+		createdConfigMapsByName = sets.NewString()
+	)
+	resourceClient.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		lock.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		createdConfigMapsByName.Insert(args.Get(0).(*unstructured.Unstructured).GetName())
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		inFlight--
+		lock.Unlock()
+	}).Return(new(unstructured.Unstructured), nil)
+
+	ctx := newConfigMapsRestoreContext(resourceClient, 25)
+	ctx.createWorkers = 4
+
+	warnings, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+
+	assert.Equal(t, api.RestoreResult{}, warnings)
+	assert.Equal(t, api.RestoreResult{}, errs)
+	resourceClient.AssertNumberOfCalls(t, "Create", 25)
+	assert.Equal(t, 25, createdConfigMapsByName.Len())
+	assert.True(t, maxInFlight > 1, "config maps should be created concurrently")
+	assert.True(t, maxInFlight <= 4, "no more than 4 config maps should be created at once")
+}
+
+func TestRestoreResourceRunsPreHooksBeforeEachCreate(t *testing.T) {
+	failHook := &api.HTTPHook{URL: "http://example.com/fail"}
+	hooks, err := getRestoreHooks([]api.RestoreResourceHookSpec{
+		{
+			Name:              "configmaps",
+			IncludedResources: []string{"configmaps"},
+			PreHooks:          []api.RestoreResourceHook{{HTTP: failHook}},
+		},
+	}, arktest.NewFakeDiscoveryHelper(true, nil))
+	require.NoError(t, err)
+
+	var (
+		lock                    sync.Mutex
+		hookCalls               int
+		hookCallsAtFirstCreate  = -1
+		resourceClient          = &arktest.FakeDynamicClient{}
+		caller                  = &arktest.MockHTTPHookCaller{}
+		createdConfigMapsByName = sets.NewString()
+		isConfigMap4            = func(payload *httphook.Payload) bool { return payload.Item.Name == "cm-4" }
+		isNotConfigMap4         = func(payload *httphook.Payload) bool { return payload.Item.Name != "cm-4" }
+		recordHookCall          = func(mock.Arguments) { lock.Lock(); hookCalls++; lock.Unlock() }
+	)
+	caller.On("Call", mock.Anything, failHook, mock.MatchedBy(isConfigMap4)).Run(recordHookCall).Return(errors.New("hook failed"))
+	caller.On("Call", mock.Anything, failHook, mock.MatchedBy(isNotConfigMap4)).Run(recordHookCall).Return(nil)
+	resourceClient.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		lock.Lock()
+		defer lock.Unlock()
+		if hookCallsAtFirstCreate < 0 {
+			hookCallsAtFirstCreate = hookCalls
+		}
+		createdConfigMapsByName.Insert(args.Get(0).(*unstructured.Unstructured).GetName())
+	}).Return(new(unstructured.Unstructured), nil)
+
+	ctx := newConfigMapsRestoreContext(resourceClient, 4)
+	ctx.createWorkers = 2
+	ctx.hooks = hooks
+	ctx.httpHookCaller = caller
+
+	_, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+
+	// the config map whose pre hook failed isn't created
+	assert.Equal(t, sets.NewString("cm-1", "cm-2", "cm-3"), createdConfigMapsByName)
+	require.Len(t, errs.Namespaces["ns-1"], 1)
+	assert.Contains(t, errs.Namespaces["ns-1"][0], "not restored, pre hook for foo/resources/configmaps/namespaces/ns-1/cm-4.json failed: hook failed")
+
+	// each worker runs an item's pre hooks right before creating it, rather than the
+	// pre hooks of every item in the batch being run before any of them are created
+	assert.Equal(t, 4, hookCalls)
+	assert.True(t, hookCallsAtFirstCreate <= 2, "no more than 2 pre hooks should run before the first config map is created")
+}
+
+func TestRestoreResourceSkipsIdenticalItems(t *testing.T) {
+	resourceClient := &arktest.FakeDynamicClient{}
+	defer resourceClient.AssertExpectations(t)
+
+	ctx := newConfigMapsRestoreContext(resourceClient, 2)
+	ctx.restore.Spec.ConflictPolicy = api.RestoreConflictPolicyThreeWayMerge
+	ctx.itemHasher = itemhash.NewDefaultHasher()
+
+	// the backup's index records hashes of the backed-up config maps
+	index := archive.Index{Version: 1}
+	for _, name := range []string{"cm-1", "cm-2"} {
+		path := fmt.Sprintf("resources/configmaps/namespaces/ns-1/%s.json", name)
+		obj, err := ctx.unmarshal(filepath.Join("foo", path))
+		require.NoError(t, err)
+
+		hash, err := ctx.itemHasher.Hash("configmaps", obj.UnstructuredContent())
+		require.NoError(t, err)
+		index.Items = append(index.Items, archive.IndexEntry{Path: path, ItemHash: hash})
+	}
+	indexJSON, err := json.Marshal(index)
+	require.NoError(t, err)
+	ctx.fileSystem.(*arktest.FakeFileSystem).WithFile("foo/index.json", indexJSON)
+
+	ctx.itemHashes, err = ctx.readItemHashes("foo")
+	require.NoError(t, err)
+	require.Len(t, ctx.itemHashes, 2)
+
+	// cm-1 is unchanged since it was restored by an earlier restore with the same
+	// additional labels and annotations, and cm-2 has been modified
+	ctx.restore.Spec.AdditionalLabels = map[string]string{"team": "a"}
+	ctx.restore.Spec.AdditionalAnnotations = map[string]string{"restored-by": "ark"}
+	identical := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1","uid":"uid-1","resourceVersion":"1","labels":{"ark.heptio.com/restore-name":"earlier-restore","ark.heptio.com/backup-name":"my-backup","ark-restore":"earlier-restore","team":"a"},"annotations":{"restored-by":"ark"}}}`)
+	modified := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-2"},"data":{"a":"b"}}`)
+	resourceClient.On("List", metav1.ListOptions{}).Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{*identical, *modified}}, nil).Once()
+	resourceClient.On("Create", mock.Anything).Return(new(unstructured.Unstructured), nil).Once()
+
+	warnings, errs := ctx.restoreResource("configmaps", "ns-1", "foo/resources/configmaps/namespaces/ns-1/")
+
+	assert.Equal(t, api.RestoreResult{}, warnings)
+	assert.Equal(t, api.RestoreResult{}, errs)
+
+	// only cm-2 is created, and the in-cluster config maps are listed once
+	require.Len(t, resourceClient.Calls, 2)
+	assert.Equal(t, "cm-2", resourceClient.Calls[1].Arguments.Get(0).(*unstructured.Unstructured).GetName())
+}
+
+// newConfigMapsRestoreContext returns a context for restoring count config maps in
+// namespace ns-1 using resourceClient.
+func newConfigMapsRestoreContext(resourceClient *arktest.FakeDynamicClient, count int) *context {
+	dynamicFactory := &arktest.FakeDynamicFactory{}
+	dynamicFactory.On("ClientForGroupVersionResource", schema.GroupVersion{Version: "v1"}, metav1.APIResource{Name: "configmaps", Namespaced: true}, "ns-1").Return(resourceClient, nil)
+
+	fileSystem := arktest.NewFakeFileSystem()
+	for i := 1; i <= count; i++ {
+		fileSystem.WithFile(
+			fmt.Sprintf("foo/resources/configmaps/namespaces/ns-1/cm-%d.json", i),
+			[]byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-%d"}}`, i)),
+		)
+	}
+
+	return &context{
+		goContext:      go_context.Background(),
+		dynamicFactory: dynamicFactory,
+		actions:        []resolvedAction{},
+		fileSystem:     fileSystem,
+		selector:       labels.NewSelector(),
+		restore: &api.Restore{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: api.DefaultNamespace,
+				Name:      "my-restore",
+			},
+			Spec: api.RestoreSpec{
+				BackupName: "my-backup",
+			},
+		},
+		backup: &api.Backup{},
+		log:    arktest.NewLogger(),
+	}
+}
+
+func TestRestoringPVsWithoutSnapshots(t *testing.T) {
+	pv := `apiVersion: v1
+kind: PersistentVolume
+metadata:
+  annotations:
+    EXPORT_block: "\nEXPORT\n{\n\tExport_Id = 1;\n\tPath = /export/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce;\n\tPseudo
+      = /export/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce;\n\tAccess_Type = RW;\n\tSquash
+      = no_root_squash;\n\tSecType = sys;\n\tFilesystem_id = 1.1;\n\tFSAL {\n\t\tName
+      = VFS;\n\t}\n}\n"
+    Export_Id: "1"
+    Project_Id: "0"
+    Project_block: ""
+    Provisioner_Id: 5fdf4025-78a5-11e8-9ece-0242ac110004
+    kubernetes.io/createdby: nfs-dynamic-provisioner
+    pv.kubernetes.io/provisioned-by: example.com/nfs
+    volume.beta.kubernetes.io/mount-options: vers=4.1
+  creationTimestamp: 2018-06-25T18:27:35Z
+  finalizers:
+  - kubernetes.io/pv-protection
+  name: pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
+  resourceVersion: "2576"
+  selfLink: /api/v1/persistentvolumes/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
+  uid: 6ecd24e4-78a5-11e8-a0d8-e2ad1e9734ce
+spec:
+  accessModes:
+  - ReadWriteMany
+  capacity:
+    storage: 1Mi
+  claimRef:
+    apiVersion: v1
+    kind: PersistentVolumeClaim
+    name: nfs
+    namespace: default
+    resourceVersion: "2565"
+    uid: 6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
+  nfs:
+    path: /export/pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
+    server: 10.103.235.254
+  storageClassName: example-nfs
+status:
+  phase: Bound`
+
+	pvc := `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  annotations:
+    control-plane.alpha.kubernetes.io/leader: '{"holderIdentity":"5fdf5572-78a5-11e8-9ece-0242ac110004","leaseDurationSeconds":15,"acquireTime":"2018-06-25T18:27:35Z","renewTime":"2018-06-25T18:27:37Z","leaderTransitions":0}'
+    kubectl.kubernetes.io/last-applied-configuration: |
+      {"apiVersion":"v1","kind":"PersistentVolumeClaim","metadata":{"annotations":{},"name":"nfs","namespace":"default"},"spec":{"accessModes":["ReadWriteMany"],"resources":{"requests":{"storage":"1Mi"}},"storageClassName":"example-nfs"}}
+    pv.kubernetes.io/bind-completed: "yes"
+    pv.kubernetes.io/bound-by-controller: "yes"
+    volume.beta.kubernetes.io/storage-provisioner: example.com/nfs
+  creationTimestamp: 2018-06-25T18:27:28Z
+  finalizers:
+  - kubernetes.io/pvc-protection
+  name: nfs
+  namespace: default
+  resourceVersion: "2578"
+  selfLink: /api/v1/namespaces/default/persistentvolumeclaims/nfs
+  uid: 6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
+spec:
+  accessModes:
+  - ReadWriteMany
+  resources:
+    requests:
+      storage: 1Mi
+  storageClassName: example-nfs
+  volumeName: pvc-6a74b5af-78a5-11e8-a0d8-e2ad1e9734ce
+status:
+  accessModes:
+  - ReadWriteMany
+  capacity:
+    storage: 1Mi
+  phase: Bound`
+
+	tests := []struct {
+		name                          string
+		haveSnapshot                  bool
+		legacyBackup                  bool
+		reclaimPolicy                 string
+		expectPVCVolumeName           bool
+		expectedPVCAnnotationsMissing sets.String
+		expectPVCreation              bool
+	}{
+		{
+			name:                "legacy backup, have snapshot, reclaim policy delete",
+			haveSnapshot:        true,
+			legacyBackup:        true,
+			reclaimPolicy:       "Delete",
 			expectPVCVolumeName: true,
 			expectPVCreation:    true,
 		},
@@ -911,6 +1811,7 @@ status:
 			defer pvRestorer.AssertExpectations(t)
 
 			ctx := &context{
+				goContext:      go_context.Background(),
 				dynamicFactory: dynamicFactory,
 				actions:        []resolvedAction{},
 				fileSystem: arktest.NewFakeFileSystem().
@@ -968,7 +1869,8 @@ status:
 				createdPV := unstructuredPV.DeepCopy()
 				pvClient.On("Create", unstructuredPV).Return(createdPV, nil)
 
-				pvClient.On("Watch", metav1.ListOptions{}).Return(pvWatch, nil)
+				pvClient.On("Watch", metav1.ListOptions{LabelSelector: "ark.heptio.com/restore-name=" + ctx.restore.Name}).Return(pvWatch, nil)
+				pvWatch.On("Stop")
 				pvWatchChan := make(chan watch.Event, 1)
 				readyPV := restoredPV.DeepCopy()
 				readyStatus, err := collections.GetMap(readyPV.Object, "status")
@@ -1018,8 +1920,6 @@ status:
 			assert.Empty(t, warnings.Cluster)
 			assert.Empty(t, warnings.Namespaces)
 			assert.Equal(t, api.RestoreResult{}, errors)
-
-			ctx.resourceWaitGroup.Wait()
 		})
 	}
 }
@@ -1046,14 +1946,6 @@ func (w *mockWatch) ResultChan() <-chan watch.Event {
 	return args.Get(0).(chan watch.Event)
 }
 
-type fakeWatch struct{}
-
-func (w *fakeWatch) Stop() {}
-
-func (w *fakeWatch) ResultChan() <-chan watch.Event {
-	return make(chan watch.Event)
-}
-
 func TestHasControllerOwner(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1393,6 +2285,7 @@ func TestExecutePVAction_SnapshotRestores(t *testing.T) {
 		backup             *api.Backup
 		volumeSnapshots    []*volume.Snapshot
 		locations          []*api.VolumeSnapshotLocation
+		volumeOverrides    []api.RestoreVolumeOverride
 		expectedProvider   string
 		expectedSnapshotID string
 		expectedVolumeType string
@@ -1436,6 +2329,47 @@ func TestExecutePVAction_SnapshotRestores(t *testing.T) {
 			expectedVolumeAZ:   "az-1",
 			expectedVolumeIOPS: int64Ptr(1),
 		},
+		{
+			name:    "volume type override for the PV's storage class replaces the type and drops the IOPS",
+			obj:     NewTestUnstructured().WithName("pv-1").WithSpecField("storageClassName", "fast").Unstructured,
+			restore: arktest.NewDefaultTestRestore().WithRestorePVs(true).Restore,
+			backup:  arktest.NewTestBackup().WithName("backup-1").Backup,
+			locations: []*api.VolumeSnapshotLocation{
+				arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("provider-1").VolumeSnapshotLocation,
+			},
+			volumeSnapshots: []*volume.Snapshot{
+				newSnapshot("pv-1", "loc-1", "io1", "az-1", "snap-1", 1000),
+			},
+			volumeOverrides: []api.RestoreVolumeOverride{
+				{VolumeType: "standard"},
+				{StorageClassName: "fast", VolumeType: "gp2"},
+			},
+			expectedProvider:   "provider-1",
+			expectedSnapshotID: "snap-1",
+			expectedVolumeType: "gp2",
+			expectedVolumeAZ:   "az-1",
+		},
+		{
+			name:    "IOPS override for all storage classes keeps the type",
+			obj:     NewTestUnstructured().WithName("pv-1").WithSpecField("storageClassName", "slow").Unstructured,
+			restore: arktest.NewDefaultTestRestore().WithRestorePVs(true).Restore,
+			backup:  arktest.NewTestBackup().WithName("backup-1").Backup,
+			locations: []*api.VolumeSnapshotLocation{
+				arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("provider-1").VolumeSnapshotLocation,
+			},
+			volumeSnapshots: []*volume.Snapshot{
+				newSnapshot("pv-1", "loc-1", "io1", "az-1", "snap-1", 1000),
+			},
+			volumeOverrides: []api.RestoreVolumeOverride{
+				{StorageClassName: "fast", VolumeType: "gp2"},
+				{IOPS: int64Ptr(100)},
+			},
+			expectedProvider:   "provider-1",
+			expectedSnapshotID: "snap-1",
+			expectedVolumeType: "io1",
+			expectedVolumeAZ:   "az-1",
+			expectedVolumeIOPS: int64Ptr(100),
+		},
 	}
 
 	for _, tc := range tests {
@@ -1455,6 +2389,7 @@ func TestExecutePVAction_SnapshotRestores(t *testing.T) {
 			r := &pvRestorer{
 				logger:                 arktest.NewLogger(),
 				backup:                 tc.backup,
+				volumeOverrides:        tc.volumeOverrides,
 				volumeSnapshots:        tc.volumeSnapshots,
 				snapshotLocationLister: locationsInformer.Lister(),
 				blockStoreGetter:       blockStoreGetter,
@@ -1472,6 +2407,168 @@ func TestExecutePVAction_SnapshotRestores(t *testing.T) {
 	}
 }
 
+func TestExecutePVAction_MultipleProviders(t *testing.T) {
+	var (
+		backup            = arktest.NewTestBackup().WithName("backup-1").Backup
+		ebs               = new(cloudprovidermocks.BlockStore)
+		portworx          = new(cloudprovidermocks.BlockStore)
+		locationsInformer = informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0).Ark().V1().VolumeSnapshotLocations()
+	)
+
+	locations := []*api.VolumeSnapshotLocation{
+		arktest.NewTestVolumeSnapshotLocation().WithName("ebs-1").WithProvider("aws").VolumeSnapshotLocation,
+		arktest.NewTestVolumeSnapshotLocation().WithName("px-1").WithProvider("portworx").VolumeSnapshotLocation,
+	}
+	for _, loc := range locations {
+		require.NoError(t, locationsInformer.Informer().GetStore().Add(loc))
+	}
+
+	r := &pvRestorer{
+		logger: arktest.NewLogger(),
+		backup: backup,
+		volumeSnapshots: []*volume.Snapshot{
+			newSnapshot("pv-1", "ebs-1", "gp2", "az-1", "snap-1", 1),
+			newSnapshot("pv-2", "px-1", "px", "", "snap-2", 2),
+			newSnapshot("pv-3", "ebs-1", "gp2", "az-1", "snap-3", 3),
+		},
+		snapshotLocationLister: locationsInformer.Lister(),
+		blockStoreGetter: providerToBlockStoreMap(map[string]cloudprovider.BlockStore{
+			"aws":      ebs,
+			"portworx": portworx,
+		}),
+	}
+
+	pvs := map[string]*unstructured.Unstructured{
+		"pv-1": NewTestUnstructured().WithName("pv-1").WithSpec().Unstructured,
+		"pv-2": NewTestUnstructured().WithName("pv-2").WithSpec().Unstructured,
+		"pv-3": NewTestUnstructured().WithName("pv-3").WithSpec().Unstructured,
+	}
+
+	// each block store is only initialized once, no matter how many of the
+	// backup's volumes it restores
+	ebs.On("Init", mock.Anything).Return(nil).Once()
+	ebs.On("CreateVolumeFromSnapshot", "snap-1", "gp2", "az-1", int64Ptr(1)).Return("vol-1", nil)
+	ebs.On("SetVolumeID", pvs["pv-1"], "vol-1").Return(pvs["pv-1"], nil)
+	ebs.On("CreateVolumeFromSnapshot", "snap-3", "gp2", "az-1", int64Ptr(3)).Return("vol-3", nil)
+	ebs.On("SetVolumeID", pvs["pv-3"], "vol-3").Return(pvs["pv-3"], nil)
+
+	portworx.On("Init", mock.Anything).Return(nil).Once()
+	portworx.On("CreateVolumeFromSnapshot", "snap-2", "px", "", int64Ptr(2)).Return("vol-2", nil)
+	portworx.On("SetVolumeID", pvs["pv-2"], "vol-2").Return(pvs["pv-2"], nil)
+
+	for _, name := range []string{"pv-1", "pv-2", "pv-3"} {
+		_, err := r.executePVAction(pvs[name])
+		require.NoError(t, err, name)
+	}
+
+	ebs.AssertExpectations(t)
+	portworx.AssertExpectations(t)
+}
+
+func TestExecutePVAction_VolumeIDMapping(t *testing.T) {
+	tests := []struct {
+		name             string
+		volumeSnapshots  []*volume.Snapshot
+		restorePVs       *bool
+		recognizedBy     string
+		expectedProvider string
+		expectedErr      bool
+	}{
+		{
+			name:             "PV with a snapshot uses its location's block store instead of restoring the snapshot",
+			volumeSnapshots:  []*volume.Snapshot{newSnapshot("pv-1", "loc-2", "type-1", "az-1", "snap-1", 1)},
+			expectedProvider: "provider-2",
+		},
+		{
+			name:             "PV without a snapshot uses the block store that recognizes its volume",
+			recognizedBy:     "provider-2",
+			expectedProvider: "provider-2",
+		},
+		{
+			name:             "PV is mapped even if restorePVs is false",
+			restorePVs:       boolptr.False(),
+			recognizedBy:     "provider-1",
+			expectedProvider: "provider-1",
+		},
+		{
+			name:        "PV whose volume no block store recognizes is an error",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				blockStores = map[string]*cloudprovidermocks.BlockStore{
+					"provider-1": new(cloudprovidermocks.BlockStore),
+					"provider-2": new(cloudprovidermocks.BlockStore),
+				}
+				locationsInformer = informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0).Ark().V1().VolumeSnapshotLocations()
+				obj               = NewTestUnstructured().WithName("pv-1").WithSpec().Unstructured
+			)
+
+			for _, loc := range []*api.VolumeSnapshotLocation{
+				arktest.NewTestVolumeSnapshotLocation().WithName("loc-1").WithProvider("provider-1").VolumeSnapshotLocation,
+				arktest.NewTestVolumeSnapshotLocation().WithName("loc-2").WithProvider("provider-2").VolumeSnapshotLocation,
+			} {
+				require.NoError(t, locationsInformer.Informer().GetStore().Add(loc))
+			}
+
+			for provider, blockStore := range blockStores {
+				blockStore.On("Init", mock.Anything).Return(nil)
+				if tc.volumeSnapshots == nil {
+					var volumeID string
+					if provider == tc.recognizedBy {
+						volumeID = "original-volume"
+					}
+					blockStore.On("GetVolumeID", obj).Return(volumeID, nil)
+				}
+			}
+			if tc.expectedProvider != "" {
+				blockStores[tc.expectedProvider].On("SetVolumeID", obj, "existing-volume").Return(obj, nil)
+			}
+
+			r := &pvRestorer{
+				logger:                 arktest.NewLogger(),
+				backup:                 arktest.NewTestBackup().WithName("backup-1").Backup,
+				restorePVs:             tc.restorePVs,
+				volumeIDMapping:        map[string]string{"pv-1": "existing-volume"},
+				volumeSnapshots:        tc.volumeSnapshots,
+				snapshotLocationLister: locationsInformer.Lister(),
+				blockStoreGetter: providerToBlockStoreMap(map[string]cloudprovider.BlockStore{
+					"provider-1": blockStores["provider-1"],
+					"provider-2": blockStores["provider-2"],
+				}),
+			}
+
+			res, err := r.executePVAction(obj)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			reclaimPolicy, err := collections.GetString(res.UnstructuredContent(), "spec.persistentVolumeReclaimPolicy")
+			require.NoError(t, err)
+			assert.Equal(t, "Retain", reclaimPolicy)
+
+			blockStores[tc.expectedProvider].AssertCalled(t, "SetVolumeID", obj, "existing-volume")
+			for _, blockStore := range blockStores {
+				blockStore.AssertNotCalled(t, "CreateVolumeFromSnapshot", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestWaitForReadyStopsWhenContextIsDone(t *testing.T) {
+	goContext, cancel := go_context.WithCancel(go_context.Background())
+	cancel()
+
+	_, err := waitForReady(goContext, make(chan watch.Event), sets.NewString("pv-1"), isPVReady, 0, arktest.NewLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), go_context.Canceled.Error())
+}
+
 func TestIsPVReady(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1728,6 +2825,24 @@ func newTestNamespace(name string) *testNamespace {
 	}
 }
 
+func (ns *testNamespace) WithLabel(key, value string) *testNamespace {
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	ns.Labels[key] = value
+
+	return ns
+}
+
+func (ns *testNamespace) WithAnnotation(key, value string) *testNamespace {
+	if ns.Annotations == nil {
+		ns.Annotations = make(map[string]string)
+	}
+	ns.Annotations[key] = value
+
+	return ns
+}
+
 func (ns *testNamespace) ToJSON() []byte {
 	bytes, _ := json.Marshal(ns.Namespace)
 	return bytes
@@ -1840,12 +2955,518 @@ func (r *fakeAction) Execute(obj runtime.Unstructured, restore *api.Restore) (ru
 }
 
 type fakeNamespaceClient struct {
-	createdNamespaces []*v1.Namespace
+	createdNamespaces  []*v1.Namespace
+	existingNamespaces sets.String
+	patches            map[string]string
 
 	corev1.NamespaceInterface
 }
 
+func (nsc *fakeNamespaceClient) Get(name string, opts metav1.GetOptions) (*v1.Namespace, error) {
+	if !nsc.existingNamespaces.Has(name) {
+		return nil, k8serrors.NewNotFound(v1.Resource("namespaces"), name)
+	}
+
+	return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+}
+
 func (nsc *fakeNamespaceClient) Create(ns *v1.Namespace) (*v1.Namespace, error) {
+	if nsc.existingNamespaces.Has(ns.Name) {
+		return nil, k8serrors.NewAlreadyExists(v1.Resource("namespaces"), ns.Name)
+	}
+
 	nsc.createdNamespaces = append(nsc.createdNamespaces, ns)
 	return ns, nil
 }
+
+func (nsc *fakeNamespaceClient) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v1.Namespace, error) {
+	if nsc.patches == nil {
+		nsc.patches = make(map[string]string)
+	}
+	nsc.patches[name] = string(data)
+
+	return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+}
+
+func TestServiceAccountNamespace(t *testing.T) {
+	restore := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseNew).Restore
+	assert.Equal(t, api.DefaultNamespace, serviceAccountNamespace(restore))
+
+	restore.Labels = map[string]string{api.SelfServiceNamespaceLabel: "team-a"}
+	assert.Equal(t, "team-a", serviceAccountNamespace(restore))
+}
+
+func TestRestoreAsServiceAccount(t *testing.T) {
+	// a backup containing a single config map in a namespace that doesn't exist yet
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	item := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns-1","name":"cm-1"}}`)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "resources/configmaps/namespaces/ns-1/cm-1.json", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(item))}))
+	_, err := tw.Write(item)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	var (
+		// the server's clients have no expectations, so using them fails the test
+		serverDynamicFactory  = &arktest.FakeDynamicFactory{}
+		serverNamespaceClient = &fakeNamespaceClient{}
+
+		saDynamicFactory  = &arktest.FakeDynamicFactory{}
+		saNamespaceClient = &fakeNamespaceClient{}
+		configMapsClient  = &arktest.FakeDynamicClient{}
+	)
+	saDynamicFactory.On("ClientForGroupVersionResource", mock.Anything, mock.Anything, "ns-1").Return(configMapsClient, nil)
+	configMapsClient.On("Create", mock.Anything).Return(new(unstructured.Unstructured), nil)
+
+	var impersonated []string
+	kr := &kubernetesRestorer{
+		discoveryHelper: arktest.NewFakeDiscoveryHelper(true, map[schema.GroupVersionResource]schema.GroupVersionResource{
+			{Resource: "configmaps"}: {Version: "v1", Resource: "configmaps"},
+		}),
+		clientConfig:       &rest.Config{},
+		dynamicFactory:     serverDynamicFactory,
+		namespaceClient:    serverNamespaceClient,
+		resourcePriorities: func() []string { return nil },
+		resticTimeout:      func() time.Duration { return time.Minute },
+		fileSystem:         arktest.NewFakeFileSystem(),
+		newServiceAccountDynamicFactory: func(config *rest.Config, namespace, name string) (client.DynamicFactory, error) {
+			impersonated = append(impersonated, "dynamic:"+namespace+"/"+name)
+			return saDynamicFactory, nil
+		},
+		newServiceAccountNamespaceClient: func(config *rest.Config, namespace, name string) (corev1.NamespaceInterface, error) {
+			impersonated = append(impersonated, "namespaces:"+namespace+"/"+name)
+			return saNamespaceClient, nil
+		},
+	}
+
+	restore := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseNew).
+		WithIncludedNamespace("*").
+		Restore
+	restore.Labels = map[string]string{api.SelfServiceNamespaceLabel: "team-a"}
+	restore.Spec.ServiceAccountName = "restorer"
+
+	_, errs := kr.Restore(go_context.Background(), arktest.NewLogger(), restore, &api.Backup{}, nil, buf, nil, nil, nil, nil, nil)
+
+	assert.Empty(t, errs.Ark)
+	assert.Empty(t, errs.Namespaces)
+	assert.Equal(t, []string{"dynamic:team-a/restorer", "namespaces:team-a/restorer"}, impersonated)
+
+	// the namespace is created and the config map is restored with the service
+	// account's clients, and the server's clients aren't used at all
+	require.Len(t, saNamespaceClient.createdNamespaces, 1)
+	assert.Equal(t, "ns-1", saNamespaceClient.createdNamespaces[0].Name)
+	configMapsClient.AssertNumberOfCalls(t, "Create", 1)
+	assert.Empty(t, serverNamespaceClient.createdNamespaces)
+	serverDynamicFactory.AssertNotCalled(t, "ClientForGroupVersionResource", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestResourcePrioritiesFor(t *testing.T) {
+	tests := []struct {
+		name          string
+		priorityName  string
+		priorities    []*api.RestorePriority
+		expectedFirst []string
+		expectedLast  []string
+		expectedErr   bool
+	}{
+		{
+			name:          "no restore priorities uses the server's priorities",
+			expectedFirst: []string{"namespaces"},
+		},
+		{
+			name: "default restore priority is used when none is specified",
+			priorities: []*api.RestorePriority{
+				newRestorePriority(api.DefaultRestorePriorityName, []string{"configmaps"}, []string{"pods"}),
+			},
+			expectedFirst: []string{"namespaces", "configmaps"},
+			expectedLast:  []string{"pods"},
+		},
+		{
+			name:         "specified restore priority is used",
+			priorityName: "custom",
+			priorities: []*api.RestorePriority{
+				newRestorePriority(api.DefaultRestorePriorityName, []string{"configmaps"}, []string{"pods"}),
+				newRestorePriority("custom", []string{"secrets"}, nil),
+			},
+			expectedFirst: []string{"namespaces", "secrets"},
+		},
+		{
+			name: "server's priorities are restored first even if the restore priority leaves them out or moves them",
+			priorities: []*api.RestorePriority{
+				newRestorePriority(api.DefaultRestorePriorityName, []string{"configmaps", "namespaces"}, []string{"namespaces"}),
+			},
+			expectedFirst: []string{"namespaces", "configmaps", "namespaces"},
+			expectedLast:  []string{"namespaces"},
+		},
+		{
+			name:         "specified restore priority that doesn't exist returns an error",
+			priorityName: "custom",
+			priorities: []*api.RestorePriority{
+				newRestorePriority(api.DefaultRestorePriorityName, []string{"configmaps"}, []string{"pods"}),
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sharedInformers := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+			for _, priority := range test.priorities {
+				require.NoError(t, sharedInformers.Ark().V1().RestorePriorities().Informer().GetStore().Add(priority))
+			}
+
+			kr := &kubernetesRestorer{
+				resourcePriorities:    func() []string { return []string{"namespaces"} },
+				restorePriorityLister: sharedInformers.Ark().V1().RestorePriorities().Lister(),
+			}
+
+			restore := arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseNew).Restore
+			restore.Spec.RestorePriorityName = test.priorityName
+
+			first, last, err := kr.resourcePrioritiesFor(restore)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFirst, first)
+			assert.Equal(t, test.expectedLast, last)
+		})
+	}
+}
+
+func newRestorePriority(name string, first, last []string) *api.RestorePriority {
+	return &api.RestorePriority{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: api.DefaultNamespace,
+			Name:      name,
+		},
+		Spec: api.RestorePrioritySpec{
+			RestoreFirst: first,
+			RestoreLast:  last,
+		},
+	}
+}
+
+func TestRenamedPVName(t *testing.T) {
+	pv := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"PersistentVolume","metadata":{"name":"pv-1"},"spec":{"claimRef":{"namespace":"ns-1","name":"pvc-1"}}}`)
+
+	tests := []struct {
+		name             string
+		hasSnapshot      bool
+		restorePVs       *bool
+		namespaceMapping map[string]string
+		volumeIDMapping  map[string]string
+		getErr           error
+		expectGet        bool
+		expectRename     bool
+		expectedErr      bool
+	}{
+		{
+			name:             "PV without a snapshot is not renamed",
+			namespaceMapping: map[string]string{"ns-1": "ns-2"},
+		},
+		{
+			name:        "PV whose claim isn't remapped is not renamed",
+			hasSnapshot: true,
+		},
+		{
+			name:             "PV is not renamed when restorePVs is false",
+			hasSnapshot:      true,
+			restorePVs:       boolptr.False(),
+			namespaceMapping: map[string]string{"ns-1": "ns-2"},
+		},
+		{
+			name:             "PV that doesn't exist in the cluster is not renamed",
+			hasSnapshot:      true,
+			namespaceMapping: map[string]string{"ns-1": "ns-2"},
+			getErr:           k8serrors.NewNotFound(schema.GroupResource{Resource: "persistentvolumes"}, "pv-1"),
+			expectGet:        true,
+		},
+		{
+			name:             "PV that exists in the cluster is renamed",
+			hasSnapshot:      true,
+			namespaceMapping: map[string]string{"ns-1": "ns-2"},
+			expectGet:        true,
+			expectRename:     true,
+		},
+		{
+			name:             "PV mapped to an existing volume that exists in the cluster is renamed",
+			restorePVs:       boolptr.False(),
+			namespaceMapping: map[string]string{"ns-1": "ns-2"},
+			volumeIDMapping:  map[string]string{"pv-1": "vol-1"},
+			expectGet:        true,
+			expectRename:     true,
+		},
+		{
+			name:             "error getting PV is returned",
+			hasSnapshot:      true,
+			namespaceMapping: map[string]string{"ns-1": "ns-2"},
+			getErr:           errors.New("get error"),
+			expectGet:        true,
+			expectedErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pvClient := &arktest.FakeDynamicClient{}
+			defer pvClient.AssertExpectations(t)
+
+			if test.expectGet {
+				pvClient.On("Get", "pv-1", metav1.GetOptions{}).Return(pv, test.getErr)
+			}
+
+			ctx := &context{
+				restore: arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseInProgress).Restore,
+			}
+			ctx.restore.Spec.RestorePVs = test.restorePVs
+			ctx.restore.Spec.NamespaceMapping = test.namespaceMapping
+			ctx.restore.Spec.VolumeIDMapping = test.volumeIDMapping
+
+			newName, err := ctx.renamedPVName(pv, test.hasSnapshot, pvClient)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if !test.expectRename {
+				assert.Empty(t, newName)
+				return
+			}
+
+			assert.Regexp(t, "^pv-1-[0-9a-f]{8}$", newName)
+
+			// the name must be the same if the restore is retried
+			secondName, err := ctx.renamedPVName(pv, test.hasSnapshot, pvClient)
+			require.NoError(t, err)
+			assert.Equal(t, newName, secondName)
+		})
+	}
+}
+
+func TestRenamePV(t *testing.T) {
+	pv := arktest.UnstructuredOrDie(`{"apiVersion":"v1","kind":"PersistentVolume","metadata":{"name":"pv-1"},"spec":{}}`)
+	claimRef := map[string]interface{}{"namespace": "ns-1", "name": "pvc-1", "uid": "123"}
+
+	require.NoError(t, renamePV(pv, "pv-1-abc", claimRef, map[string]string{"ns-1": "ns-2"}))
+
+	assert.Equal(t, "pv-1-abc", pv.GetName())
+	res, err := collections.GetMap(pv.UnstructuredContent(), "spec.claimRef")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"namespace":  "ns-2",
+		"name":       "pvc-1",
+	}, res)
+}
+
+func TestReadBackup(t *testing.T) {
+	tests := []struct {
+		name             string
+		headers          []*tar.Header
+		maxExtractedSize int64
+		expectedErr      string
+		expectedFiles    []string
+	}{
+		{
+			name: "files are extracted",
+			headers: []*tar.Header{
+				{Name: "resources", Typeflag: tar.TypeDir, Mode: 0755},
+				{Name: "resources/pods/namespaces/ns-1/pod-1.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+				{Name: "resources/../metadata/other", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			expectedFiles: []string{"resources/pods/namespaces/ns-1/pod-1.json", "metadata/other"},
+		},
+		{
+			name: "paths outside of the archive are rejected",
+			headers: []*tar.Header{
+				{Name: "resources/../../pod-1.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			expectedErr: "invalid path resources/../../pod-1.json in backup archive: path is outside of the archive",
+		},
+		{
+			name: "absolute paths are rejected",
+			headers: []*tar.Header{
+				{Name: "/etc/pod-1.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			expectedErr: "invalid path /etc/pod-1.json in backup archive: path is absolute",
+		},
+		{
+			name: "symlinks outside of the archive are rejected",
+			headers: []*tar.Header{
+				{Name: "resources/pods/link", Typeflag: tar.TypeSymlink, Linkname: "../../../etc"},
+			},
+			expectedErr: "link resources/pods/link points outside of the backup archive",
+		},
+		{
+			name: "hard links outside of the archive are rejected",
+			headers: []*tar.Header{
+				{Name: "resources/pods/link", Typeflag: tar.TypeLink, Linkname: "/etc/passwd"},
+			},
+			expectedErr: "link resources/pods/link points outside of the backup archive",
+		},
+		{
+			name: "links inside the archive are skipped",
+			headers: []*tar.Header{
+				{Name: "resources/pods/pod-1.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+				{Name: "resources/pods/link", Typeflag: tar.TypeSymlink, Linkname: "pod-1.json"},
+			},
+			expectedFiles: []string{"resources/pods/pod-1.json"},
+		},
+		{
+			name: "files within the max extracted size are extracted",
+			headers: []*tar.Header{
+				{Name: "pod-1.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+				{Name: "pod-2.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			maxExtractedSize: 8,
+			expectedFiles:    []string{"pod-1.json", "pod-2.json"},
+		},
+		{
+			name: "files exceeding the max extracted size are rejected",
+			headers: []*tar.Header{
+				{Name: "pod-1.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+				{Name: "pod-2.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			maxExtractedSize: 7,
+			expectedErr:      "backup archive exceeds the maximum extracted size of 7 bytes",
+		},
+		{
+			name: "invalid version file is rejected",
+			headers: []*tar.Header{
+				{Name: "metadata/version", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+				{Name: "pod-1.json", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			expectedErr: `invalid format version "data" in metadata/version`,
+		},
+		{
+			name: "invalid version file with a path that isn't clean is rejected",
+			headers: []*tar.Header{
+				{Name: "resources/../metadata/version", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			expectedErr: `invalid format version "data" in metadata/version`,
+		},
+		{
+			name: "invalid version file with a path containing . is rejected",
+			headers: []*tar.Header{
+				{Name: "metadata/./version", Typeflag: tar.TypeReg, Mode: 0755, Size: 4},
+			},
+			expectedErr: `invalid format version "data" in metadata/version`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tw := tar.NewWriter(buf)
+			for _, hdr := range test.headers {
+				require.NoError(t, tw.WriteHeader(hdr))
+				if hdr.Typeflag == tar.TypeReg {
+					_, err := tw.Write([]byte("data"))
+					require.NoError(t, err)
+				}
+			}
+			require.NoError(t, tw.Close())
+
+			fileSystem := arktest.NewFakeFileSystem()
+			ctx := &context{
+				fileSystem:       fileSystem,
+				maxExtractedSize: test.maxExtractedSize,
+				log:              arktest.NewLogger(),
+			}
+
+			dir, err := ctx.readBackup(tar.NewReader(buf))
+			arktest.AssertErrorMatches(t, test.expectedErr, err)
+			if test.expectedErr != "" {
+				return
+			}
+
+			for _, file := range test.expectedFiles {
+				data, err := fileSystem.ReadFile(filepath.Join(dir, file))
+				require.NoError(t, err)
+				assert.Equal(t, "data", string(data))
+			}
+
+			_, err = fileSystem.Stat(filepath.Join(dir, "resources/pods/link"))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestAddRestoreMetadata(t *testing.T) {
+	tests := []struct {
+		name                string
+		spec                api.RestoreSpec
+		labels              map[string]string
+		annotations         map[string]string
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:   "restore and backup labels are added",
+			spec:   api.RestoreSpec{BackupName: "my-backup"},
+			labels: map[string]string{"app": "db"},
+			expectedLabels: map[string]string{
+				"app":                "db",
+				api.BackupNameLabel:  "my-backup",
+				api.RestoreNameLabel: "my-restore",
+				api.RestoreLabelKey:  "my-restore",
+			},
+		},
+		{
+			name: "legacy label is omitted",
+			spec: api.RestoreSpec{BackupName: "my-backup", OmitLegacyRestoreLabel: true},
+			expectedLabels: map[string]string{
+				api.BackupNameLabel:  "my-backup",
+				api.RestoreNameLabel: "my-restore",
+			},
+		},
+		{
+			name: "additional labels and annotations are added",
+			spec: api.RestoreSpec{
+				BackupName:            "my-backup",
+				AdditionalLabels:      map[string]string{"app": "db-clone", "restored": "true"},
+				AdditionalAnnotations: map[string]string{"cost-center": "qa"},
+			},
+			labels:      map[string]string{"app": "db"},
+			annotations: map[string]string{"a": "b"},
+			expectedLabels: map[string]string{
+				"app":                "db-clone",
+				"restored":           "true",
+				api.BackupNameLabel:  "my-backup",
+				api.RestoreNameLabel: "my-restore",
+				api.RestoreLabelKey:  "my-restore",
+			},
+			expectedAnnotations: map[string]string{"a": "b", "cost-center": "qa"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &context{
+				restore: &api.Restore{
+					ObjectMeta: metav1.ObjectMeta{Namespace: api.DefaultNamespace, Name: "my-restore"},
+					Spec:       test.spec,
+				},
+			}
+
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetLabels(test.labels)
+			obj.SetAnnotations(test.annotations)
+
+			ctx.addRestoreMetadata(obj)
+
+			assert.Equal(t, test.expectedLabels, obj.GetLabels())
+			if test.expectedAnnotations == nil {
+				assert.Empty(t, obj.GetAnnotations())
+			} else {
+				assert.Equal(t, test.expectedAnnotations, obj.GetAnnotations())
+			}
+		})
+	}
+}
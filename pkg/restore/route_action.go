@@ -0,0 +1,74 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// routeHostGeneratedAnnotation is the annotation that OpenShift sets on routes
+// whose host it generated from the route's name and namespace.
+const routeHostGeneratedAnnotation = "openshift.io/host.generated"
+
+type routeAction struct {
+	log logrus.FieldLogger
+}
+
+// NewRouteAction returns an ItemAction that removes the hosts of OpenShift routes
+// that were generated by the cluster they were backed up from, or of all routes
+// if the restore has the reset route hosts annotation, so that the cluster
+// they're restored into generates its own.
+func NewRouteAction(logger logrus.FieldLogger) ItemAction {
+	return &routeAction{log: logger}
+}
+
+func (a *routeAction) AppliesTo() (ResourceSelector, error) {
+	return ResourceSelector{
+		IncludedResources: []string{"routes"},
+	}, nil
+}
+
+func (a *routeAction) Execute(obj runtime.Unstructured, restore *api.Restore) (runtime.Unstructured, error, error) {
+	route := &unstructured.Unstructured{Object: obj.UnstructuredContent()}
+
+	resetAll, _ := strconv.ParseBool(restore.Annotations[api.ResetRouteHostsAnnotation])
+	generated, _ := strconv.ParseBool(route.GetAnnotations()[routeHostGeneratedAnnotation])
+	if !resetAll && !generated {
+		return obj, nil, nil
+	}
+
+	if host, _, _ := unstructured.NestedString(route.Object, "spec", "host"); host != "" {
+		a.log.Infof("Removing host %s from route %s/%s", host, route.GetNamespace(), route.GetName())
+		unstructured.RemoveNestedField(route.Object, "spec", "host")
+	}
+
+	// the restored route's host will be generated by the cluster, so the
+	// annotation will be set again if it applies
+	annotations := route.GetAnnotations()
+	if _, ok := annotations[routeHostGeneratedAnnotation]; ok {
+		delete(annotations, routeHostGeneratedAnnotation)
+		route.SetAnnotations(annotations)
+	}
+
+	return route, nil, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestRouteActionExecute(t *testing.T) {
+	newRoute := func(annotations map[string]string) *testUnstructured {
+		return NewTestUnstructured().WithKind("Route").WithName("route-1").
+			WithAnnotationValues(annotations).
+			WithSpecField("host", "route-1-ns-1.apps.example.com").
+			WithSpecField("to", map[string]interface{}{"kind": "Service", "name": "svc-1"})
+	}
+
+	tests := []struct {
+		name               string
+		route              *testUnstructured
+		restoreAnnotations map[string]string
+		expectHost         bool
+	}{
+		{
+			name:       "host that wasn't generated is kept",
+			route:      newRoute(map[string]string{}),
+			expectHost: true,
+		},
+		{
+			name:  "generated host is removed",
+			route: newRoute(map[string]string{routeHostGeneratedAnnotation: "true"}),
+		},
+		{
+			name:               "all hosts are removed if the restore requests it",
+			route:              newRoute(map[string]string{}),
+			restoreAnnotations: map[string]string{api.ResetRouteHostsAnnotation: "true"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			action := NewRouteAction(arktest.NewLogger())
+			restore := &api.Restore{ObjectMeta: metav1.ObjectMeta{Annotations: test.restoreAnnotations}}
+
+			res, warning, err := action.Execute(test.route.Unstructured, restore)
+			require.NoError(t, err)
+			assert.NoError(t, warning)
+
+			spec := res.UnstructuredContent()["spec"].(map[string]interface{})
+			if test.expectHost {
+				assert.Equal(t, "route-1-ns-1.apps.example.com", spec["host"])
+			} else {
+				assert.NotContains(t, spec, "host")
+			}
+			assert.NotContains(t, test.route.GetAnnotations(), routeHostGeneratedAnnotation)
+			assert.Equal(t, map[string]interface{}{"kind": "Service", "name": "svc-1"}, spec["to"])
+		})
+	}
+}
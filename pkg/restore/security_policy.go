@@ -0,0 +1,364 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/discovery"
+	"github.com/heptio/ark/pkg/util/collections"
+)
+
+// seccompAllowedProfilesAnnotation is the pod security policy annotation that
+// lists the seccomp profiles pods may use, which security context constraints
+// have a field for.
+const seccompAllowedProfilesAnnotation = "seccomp.security.alpha.kubernetes.io/allowedProfileNames"
+
+var (
+	podSecurityPolicyResources = []schema.GroupResource{
+		{Group: "policy", Resource: "podsecuritypolicies"},
+		{Group: "extensions", Resource: "podsecuritypolicies"},
+	}
+
+	securityContextConstraintsResources = []schema.GroupResource{
+		{Group: "security.openshift.io", Resource: "securitycontextconstraints"},
+		// OpenShift 3 also serves security context constraints in the core group
+		{Group: "", Resource: "securitycontextconstraints"},
+	}
+)
+
+// policyFieldMappings are the pod security policy spec fields and the security
+// context constraints fields that have the same values.
+var policyFieldMappings = []struct {
+	psp, scc string
+}{
+	{"privileged", "allowPrivilegedContainer"},
+	{"defaultAddCapabilities", "defaultAddCapabilities"},
+	{"requiredDropCapabilities", "requiredDropCapabilities"},
+	{"allowedCapabilities", "allowedCapabilities"},
+	{"volumes", "volumes"},
+	{"allowedFlexVolumes", "allowedFlexVolumes"},
+	{"hostNetwork", "allowHostNetwork"},
+	{"hostPID", "allowHostPID"},
+	{"hostIPC", "allowHostIPC"},
+	{"readOnlyRootFilesystem", "readOnlyRootFilesystem"},
+	{"defaultAllowPrivilegeEscalation", "defaultAllowPrivilegeEscalation"},
+	{"allowPrivilegeEscalation", "allowPrivilegeEscalation"},
+}
+
+// policyStrategyMappings are the pod security policy spec strategies and the
+// security context constraints strategies that only differ in the name of
+// their rule field, which is "rule" in pod security policies and "type" in
+// security context constraints.
+var policyStrategyMappings = []struct {
+	psp, scc string
+}{
+	{"seLinux", "seLinuxContext"},
+	{"supplementalGroups", "supplementalGroups"},
+	{"fsGroup", "fsGroup"},
+}
+
+// securityPolicyTranslator translates the pod security policies in a backup into
+// security context constraints, or the security context constraints into pod
+// security policies.
+type securityPolicyTranslator struct {
+	mode    api.RestoreSecurityPolicyTranslation
+	sources []schema.GroupResource
+	target  schema.GroupVersionResource
+	names   map[string]string
+}
+
+// newSecurityPolicyTranslator returns a securityPolicyTranslator for mode, or nil
+// if mode is empty. It returns an error if the cluster doesn't have the resource
+// that policies are translated into.
+func newSecurityPolicyTranslator(helper discovery.Helper, mode api.RestoreSecurityPolicyTranslation, names map[string]string) (*securityPolicyTranslator, error) {
+	translator := &securityPolicyTranslator{
+		mode:  mode,
+		names: names,
+	}
+
+	var target string
+	switch mode {
+	case "":
+		return nil, nil
+	case api.RestoreSecurityPolicyTranslationPSPToSCC:
+		translator.sources = podSecurityPolicyResources
+		target = "securitycontextconstraints"
+	case api.RestoreSecurityPolicyTranslationSCCToPSP:
+		translator.sources = securityContextConstraintsResources
+		target = "podsecuritypolicies"
+	default:
+		return nil, errors.Errorf("invalid security policy translation %s", mode)
+	}
+
+	gvr, _, err := helper.ResourceFor(schema.GroupVersionResource{Resource: target})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding %s in the cluster to translate security policies into", target)
+	}
+	translator.target = gvr
+
+	return translator, nil
+}
+
+// translates returns whether the items of groupResource are translated.
+func (t *securityPolicyTranslator) translates(groupResource schema.GroupResource) bool {
+	for _, source := range t.sources {
+		if source == groupResource {
+			return true
+		}
+	}
+	return false
+}
+
+// prioritize returns resources with the included resources that are translated
+// moved, or added if the cluster doesn't have them, to just before the resource
+// they're translated into, so that the translated items are restored when the
+// items of that resource would be. If resources doesn't include that resource,
+// neither are the resources that are translated into it.
+func (t *securityPolicyTranslator) prioritize(resources []schema.GroupResource, includedResources *collections.IncludesExcludes) []schema.GroupResource {
+	var res []schema.GroupResource
+	for _, resource := range resources {
+		if t.translates(resource) {
+			continue
+		}
+
+		if resource == t.target.GroupResource() {
+			for _, source := range t.sources {
+				if includedResources.ShouldInclude(source.String()) {
+					res = append(res, source)
+				}
+			}
+		}
+
+		res = append(res, resource)
+	}
+
+	return res
+}
+
+// translate returns obj translated into an item of the translator's target
+// resource, along with warnings about the parts of obj that have no
+// equivalent in the target resource and so aren't translated.
+func (t *securityPolicyTranslator) translate(obj *unstructured.Unstructured) (*unstructured.Unstructured, []string, error) {
+	translated := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if metadata, ok := obj.Object["metadata"]; ok {
+		translated.Object["metadata"] = runtime.DeepCopyJSONValue(metadata)
+	}
+	translated.SetAPIVersion(t.target.GroupVersion().String())
+
+	if name, ok := t.names[obj.GetName()]; ok {
+		translated.SetName(name)
+	}
+
+	var (
+		warnings []string
+		err      error
+	)
+	switch t.mode {
+	case api.RestoreSecurityPolicyTranslationPSPToSCC:
+		translated.SetKind("SecurityContextConstraints")
+		warnings, err = podSecurityPolicyToSCC(obj.Object, translated)
+	case api.RestoreSecurityPolicyTranslationSCCToPSP:
+		translated.SetKind("PodSecurityPolicy")
+		warnings, err = sccToPodSecurityPolicy(obj.Object, translated)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return translated, warnings, nil
+}
+
+// podSecurityPolicyToSCC sets the fields of scc from the pod security policy psp.
+func podSecurityPolicyToSCC(psp map[string]interface{}, scc *unstructured.Unstructured) ([]string, error) {
+	spec, _, err := unstructured.NestedMap(psp, "spec")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var warnings []string
+
+	for _, mapping := range policyFieldMappings {
+		if val, ok := spec[mapping.psp]; ok {
+			scc.Object[mapping.scc] = val
+		}
+	}
+
+	for _, mapping := range policyStrategyMappings {
+		if strategy, ok := spec[mapping.psp].(map[string]interface{}); ok {
+			scc.Object[mapping.scc] = renameField(strategy, "rule", "type")
+		}
+	}
+
+	if runAsUser, ok := spec["runAsUser"].(map[string]interface{}); ok {
+		sccRunAsUser := map[string]interface{}{"type": runAsUser["rule"]}
+
+		ranges, _ := runAsUser["ranges"].([]interface{})
+		if runAsUser["rule"] == "MustRunAs" && len(ranges) > 0 {
+			r, _ := ranges[0].(map[string]interface{})
+			if r["min"] == r["max"] {
+				sccRunAsUser["uid"] = r["min"]
+			} else {
+				sccRunAsUser["type"] = "MustRunAsRange"
+				sccRunAsUser["uidRangeMin"] = r["min"]
+				sccRunAsUser["uidRangeMax"] = r["max"]
+			}
+			if len(ranges) > 1 {
+				warnings = append(warnings, "security context constraints only allow one range of user IDs, so only the first of the pod security policy's is translated")
+			}
+		}
+
+		scc.Object["runAsUser"] = sccRunAsUser
+	}
+
+	if hostPorts, ok := spec["hostPorts"].([]interface{}); ok && len(hostPorts) > 0 {
+		scc.Object["allowHostPorts"] = true
+		warnings = append(warnings, "security context constraints allow all host ports or none, so all host ports are allowed")
+	}
+
+	if volumes, ok := spec["volumes"].([]interface{}); ok {
+		for _, volume := range volumes {
+			if volume == "hostPath" || volume == "*" {
+				scc.Object["allowHostDirVolumePlugin"] = true
+			}
+		}
+	}
+
+	if _, ok := spec["allowedHostPaths"]; ok {
+		warnings = append(warnings, "security context constraints have no equivalent of allowed host paths, so they aren't translated")
+	}
+
+	annotations := scc.GetAnnotations()
+	if profiles, ok := annotations[seccompAllowedProfilesAnnotation]; ok {
+		var sccProfiles []interface{}
+		for _, profile := range strings.Split(profiles, ",") {
+			sccProfiles = append(sccProfiles, strings.TrimSpace(profile))
+		}
+		scc.Object["seccompProfiles"] = sccProfiles
+
+		delete(annotations, seccompAllowedProfilesAnnotation)
+		scc.SetAnnotations(annotations)
+	}
+
+	return warnings, nil
+}
+
+// sccToPodSecurityPolicy sets the fields of psp from the security context
+// constraints scc.
+func sccToPodSecurityPolicy(scc map[string]interface{}, psp *unstructured.Unstructured) ([]string, error) {
+	spec := make(map[string]interface{})
+	var warnings []string
+
+	for _, mapping := range policyFieldMappings {
+		if val, ok := scc[mapping.scc]; ok {
+			spec[mapping.psp] = runtime.DeepCopyJSONValue(val)
+		}
+	}
+
+	for _, mapping := range policyStrategyMappings {
+		if strategy, ok := scc[mapping.scc].(map[string]interface{}); ok {
+			spec[mapping.psp] = renameField(strategy, "type", "rule")
+		}
+	}
+
+	if runAsUser, ok := scc["runAsUser"].(map[string]interface{}); ok {
+		pspRunAsUser := map[string]interface{}{"rule": runAsUser["type"]}
+
+		switch runAsUser["type"] {
+		case "MustRunAs":
+			if uid, ok := runAsUser["uid"]; ok {
+				pspRunAsUser["ranges"] = []interface{}{map[string]interface{}{"min": uid, "max": uid}}
+			}
+		case "MustRunAsRange":
+			pspRunAsUser["rule"] = "MustRunAs"
+
+			min, hasMin := runAsUser["uidRangeMin"]
+			max, hasMax := runAsUser["uidRangeMax"]
+			if hasMin && hasMax {
+				pspRunAsUser["ranges"] = []interface{}{map[string]interface{}{"min": min, "max": max}}
+			} else {
+				// the range comes from the namespace of each pod, which pod
+				// security policies can't do
+				pspRunAsUser["rule"] = "MustRunAsNonRoot"
+				warnings = append(warnings, "security context constraints' user ID range comes from each pod's namespace, which pod security policies don't support, so pods are only required to run as non-root")
+			}
+		}
+
+		spec["runAsUser"] = pspRunAsUser
+	}
+
+	if allow, _ := scc["allowHostPorts"].(bool); allow {
+		spec["hostPorts"] = []interface{}{map[string]interface{}{"min": int64(0), "max": int64(65535)}}
+	}
+
+	if allow, _ := scc["allowHostDirVolumePlugin"].(bool); allow {
+		volumes, _ := spec["volumes"].([]interface{})
+		if !containsValue(volumes, "hostPath") && !containsValue(volumes, "*") {
+			spec["volumes"] = append(volumes, "hostPath")
+		}
+	}
+
+	if profiles, ok := scc["seccompProfiles"].([]interface{}); ok && len(profiles) > 0 {
+		var names []string
+		for _, profile := range profiles {
+			names = append(names, fmt.Sprintf("%v", profile))
+		}
+
+		annotations := psp.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[seccompAllowedProfilesAnnotation] = strings.Join(names, ",")
+		psp.SetAnnotations(annotations)
+	}
+
+	users, _ := scc["users"].([]interface{})
+	groups, _ := scc["groups"].([]interface{})
+	if len(users) > 0 || len(groups) > 0 {
+		warnings = append(warnings, "pod security policies are granted to users and groups with RBAC, so the security context constraints' users and groups aren't translated")
+	}
+
+	psp.Object["spec"] = spec
+
+	return warnings, nil
+}
+
+// renameField returns a copy of m with the field from renamed to to.
+func renameField(m map[string]interface{}, from, to string) map[string]interface{} {
+	res := runtime.DeepCopyJSONValue(m).(map[string]interface{})
+	if val, ok := res[from]; ok {
+		delete(res, from)
+		res[to] = val
+	}
+	return res
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/util/collections"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+var (
+	sccGVR = schema.GroupVersionResource{Group: "security.openshift.io", Version: "v1", Resource: "securitycontextconstraints"}
+	pspGVR = schema.GroupVersionResource{Group: "policy", Version: "v1beta1", Resource: "podsecuritypolicies"}
+)
+
+func TestNewSecurityPolicyTranslator(t *testing.T) {
+	helper := arktest.NewFakeDiscoveryHelper(false, map[schema.GroupVersionResource]schema.GroupVersionResource{
+		{Resource: "podsecuritypolicies"}: pspGVR,
+	})
+
+	translator, err := newSecurityPolicyTranslator(helper, "", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, translator)
+
+	translator, err = newSecurityPolicyTranslator(helper, api.RestoreSecurityPolicyTranslationSCCToPSP, nil)
+	require.NoError(t, err)
+	assert.Equal(t, pspGVR, translator.target)
+	assert.True(t, translator.translates(schema.GroupResource{Group: "security.openshift.io", Resource: "securitycontextconstraints"}))
+	assert.False(t, translator.translates(pspGVR.GroupResource()))
+
+	// the cluster doesn't have security context constraints
+	_, err = newSecurityPolicyTranslator(helper, api.RestoreSecurityPolicyTranslationPSPToSCC, nil)
+	assert.Error(t, err)
+}
+
+func TestSecurityPolicyTranslatorPrioritize(t *testing.T) {
+	translator := &securityPolicyTranslator{
+		mode:    api.RestoreSecurityPolicyTranslationPSPToSCC,
+		sources: podSecurityPolicyResources,
+		target:  sccGVR,
+	}
+
+	resources := []schema.GroupResource{
+		{Resource: "namespaces"},
+		{Group: "extensions", Resource: "podsecuritypolicies"},
+		sccGVR.GroupResource(),
+		{Resource: "pods"},
+	}
+
+	expected := []schema.GroupResource{
+		{Resource: "namespaces"},
+		{Group: "extensions", Resource: "podsecuritypolicies"},
+		sccGVR.GroupResource(),
+		{Resource: "pods"},
+	}
+	assert.Equal(t, expected, translator.prioritize(resources, collections.NewIncludesExcludes().Excludes("podsecuritypolicies.policy")))
+
+	// the resource that's translated into isn't restored, so neither are the
+	// resources translated into it
+	resources = []schema.GroupResource{
+		{Resource: "namespaces"},
+		{Group: "extensions", Resource: "podsecuritypolicies"},
+		{Resource: "pods"},
+	}
+	expected = []schema.GroupResource{
+		{Resource: "namespaces"},
+		{Resource: "pods"},
+	}
+	assert.Equal(t, expected, translator.prioritize(resources, collections.NewIncludesExcludes().Excludes("securitycontextconstraints.security.openshift.io")))
+}
+
+func TestSecurityPolicyTranslate(t *testing.T) {
+	psp := map[string]interface{}{
+		"apiVersion": "policy/v1beta1",
+		"kind":       "PodSecurityPolicy",
+		"metadata": map[string]interface{}{
+			"name": "restricted",
+			"annotations": map[string]interface{}{
+				seccompAllowedProfilesAnnotation: "docker/default,runtime/default",
+			},
+		},
+		"spec": map[string]interface{}{
+			"privileged":               false,
+			"allowPrivilegeEscalation": false,
+			"requiredDropCapabilities": []interface{}{"ALL"},
+			"volumes":                  []interface{}{"configMap", "secret", "hostPath"},
+			"hostNetwork":              false,
+			"hostPorts":                []interface{}{map[string]interface{}{"min": int64(8000), "max": int64(8080)}},
+			"seLinux":                  map[string]interface{}{"rule": "RunAsAny"},
+			"runAsUser": map[string]interface{}{
+				"rule":   "MustRunAs",
+				"ranges": []interface{}{map[string]interface{}{"min": int64(1000), "max": int64(2000)}},
+			},
+			"supplementalGroups": map[string]interface{}{
+				"rule":   "MustRunAs",
+				"ranges": []interface{}{map[string]interface{}{"min": int64(1), "max": int64(65535)}},
+			},
+			"fsGroup": map[string]interface{}{"rule": "RunAsAny"},
+		},
+	}
+
+	scc := map[string]interface{}{
+		"apiVersion": "security.openshift.io/v1",
+		"kind":       "SecurityContextConstraints",
+		"metadata": map[string]interface{}{
+			"name": "restricted-psp",
+		},
+		"allowPrivilegedContainer": false,
+		"allowPrivilegeEscalation": false,
+		"requiredDropCapabilities": []interface{}{"ALL"},
+		"volumes":                  []interface{}{"configMap", "secret", "hostPath"},
+		"allowHostNetwork":         false,
+		"allowHostPorts":           true,
+		"allowHostDirVolumePlugin": true,
+		"seLinuxContext":           map[string]interface{}{"type": "RunAsAny"},
+		"runAsUser": map[string]interface{}{
+			"type":        "MustRunAsRange",
+			"uidRangeMin": int64(1000),
+			"uidRangeMax": int64(2000),
+		},
+		"supplementalGroups": map[string]interface{}{
+			"type":   "MustRunAs",
+			"ranges": []interface{}{map[string]interface{}{"min": int64(1), "max": int64(65535)}},
+		},
+		"fsGroup":         map[string]interface{}{"type": "RunAsAny"},
+		"seccompProfiles": []interface{}{"docker/default", "runtime/default"},
+	}
+
+	t.Run("pod security policy to security context constraints", func(t *testing.T) {
+		translator := &securityPolicyTranslator{
+			mode:   api.RestoreSecurityPolicyTranslationPSPToSCC,
+			target: sccGVR,
+			names:  map[string]string{"restricted": "restricted-psp"},
+		}
+
+		res, warnings, err := translator.translate(&unstructured.Unstructured{Object: psp})
+		require.NoError(t, err)
+
+		expected := &unstructured.Unstructured{Object: scc}
+		expected.SetAnnotations(map[string]string{})
+		assert.Equal(t, expected, res)
+		assert.Len(t, warnings, 1)
+	})
+
+	t.Run("security context constraints to pod security policy", func(t *testing.T) {
+		translator := &securityPolicyTranslator{
+			mode:   api.RestoreSecurityPolicyTranslationSCCToPSP,
+			target: pspGVR,
+		}
+
+		withUsers := (&unstructured.Unstructured{Object: scc}).DeepCopy()
+		withUsers.Object["users"] = []interface{}{"system:serviceaccount:ns-1:app"}
+
+		res, warnings, err := translator.translate(withUsers)
+		require.NoError(t, err)
+
+		spec, _, err := unstructured.NestedMap(res.Object, "spec")
+		require.NoError(t, err)
+
+		assert.Equal(t, "PodSecurityPolicy", res.GetKind())
+		assert.Equal(t, "policy/v1beta1", res.GetAPIVersion())
+		assert.Equal(t, "restricted-psp", res.GetName())
+		assert.Equal(t, "docker/default,runtime/default", res.GetAnnotations()[seccompAllowedProfilesAnnotation])
+		assert.Equal(t, map[string]interface{}{
+			"rule":   "MustRunAs",
+			"ranges": []interface{}{map[string]interface{}{"min": int64(1000), "max": int64(2000)}},
+		}, spec["runAsUser"])
+		assert.Equal(t, map[string]interface{}{"rule": "RunAsAny"}, spec["seLinux"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"min": int64(0), "max": int64(65535)}}, spec["hostPorts"])
+		assert.Equal(t, []interface{}{"configMap", "secret", "hostPath"}, spec["volumes"])
+		assert.Equal(t, false, spec["privileged"])
+		assert.Len(t, warnings, 1)
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultStripAnnotations are the annotations that the "default" entry of a
+// restore's strip annotations stands for: annotations that make cloud providers
+// provision load balancers or static IPs, or make external-dns create DNS records.
+var DefaultStripAnnotations = []string{
+	"external-dns.alpha.kubernetes.io/*",
+	"service.beta.kubernetes.io/*",
+	"service.kubernetes.io/*",
+	"cloud.google.com/load-balancer-type",
+	"networking.gke.io/load-balancer-type",
+	"kubernetes.io/ingress.global-static-ip-name",
+}
+
+// stripAnnotationsDefault is the strip annotations entry that stands for
+// DefaultStripAnnotations.
+const stripAnnotationsDefault = "default"
+
+// annotationStripList matches annotation keys against a restore's strip annotations.
+type annotationStripList struct {
+	keys     map[string]bool
+	prefixes []string
+}
+
+// newAnnotationStripList returns an annotationStripList for entries, which are
+// annotation keys, key prefixes ending in "*", or "default".
+func newAnnotationStripList(entries []string) *annotationStripList {
+	list := &annotationStripList{keys: make(map[string]bool)}
+
+	for _, entry := range entries {
+		if entry == stripAnnotationsDefault {
+			for _, defaultEntry := range DefaultStripAnnotations {
+				list.add(defaultEntry)
+			}
+			continue
+		}
+		list.add(entry)
+	}
+
+	return list
+}
+
+func (l *annotationStripList) add(entry string) {
+	if strings.HasSuffix(entry, "*") {
+		l.prefixes = append(l.prefixes, strings.TrimSuffix(entry, "*"))
+		return
+	}
+	l.keys[entry] = true
+}
+
+func (l *annotationStripList) matches(key string) bool {
+	if l.keys[key] {
+		return true
+	}
+	for _, prefix := range l.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// strip removes the annotations that match the list from obj, and returns the
+// sorted keys of the annotations it removed.
+func (l *annotationStripList) strip(obj *unstructured.Unstructured) []string {
+	annotations := obj.GetAnnotations()
+
+	var stripped []string
+	for key := range annotations {
+		if l.matches(key) {
+			stripped = append(stripped, key)
+			delete(annotations, key)
+		}
+	}
+
+	if len(stripped) == 0 {
+		return nil
+	}
+
+	obj.SetAnnotations(annotations)
+	sort.Strings(stripped)
+	return stripped
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotationStripList(t *testing.T) {
+	annotations := map[string]string{
+		"external-dns.alpha.kubernetes.io/hostname":             "app.example.com",
+		"service.beta.kubernetes.io/aws-load-balancer-internal": "0.0.0.0/0",
+		"cloud.google.com/load-balancer-type":                   "Internal",
+		"kubectl.kubernetes.io/last-applied-configuration":      "{}",
+		"example.com/owner":                                     "team-a",
+		"example.com/owner-contact":                             "team-a@example.com",
+	}
+
+	tests := []struct {
+		name             string
+		entries          []string
+		expectedStripped []string
+		expectedKept     []string
+	}{
+		{
+			name:    "default entry strips known load balancer and external-dns annotations",
+			entries: []string{"default"},
+			expectedStripped: []string{
+				"cloud.google.com/load-balancer-type",
+				"external-dns.alpha.kubernetes.io/hostname",
+				"service.beta.kubernetes.io/aws-load-balancer-internal",
+			},
+			expectedKept: []string{"example.com/owner", "example.com/owner-contact", "kubectl.kubernetes.io/last-applied-configuration"},
+		},
+		{
+			name:             "keys match exactly",
+			entries:          []string{"example.com/owner"},
+			expectedStripped: []string{"example.com/owner"},
+			expectedKept:     []string{"example.com/owner-contact"},
+		},
+		{
+			name:             "prefixes match keys that start with them",
+			entries:          []string{"example.com/*"},
+			expectedStripped: []string{"example.com/owner", "example.com/owner-contact"},
+			expectedKept:     []string{"external-dns.alpha.kubernetes.io/hostname"},
+		},
+		{
+			name:         "no matches strips nothing",
+			entries:      []string{"example.org/*"},
+			expectedKept: []string{"example.com/owner"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := NewTestUnstructured().WithName("svc-1").WithAnnotationValues(annotations).Unstructured
+
+			stripped := newAnnotationStripList(test.entries).strip(obj)
+
+			assert.Equal(t, test.expectedStripped, stripped)
+			res := obj.GetAnnotations()
+			for _, key := range test.expectedStripped {
+				assert.NotContains(t, res, key)
+			}
+			for _, key := range test.expectedKept {
+				assert.Contains(t, res, key)
+			}
+		})
+	}
+}
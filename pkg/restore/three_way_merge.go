@@ -0,0 +1,152 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// lastAppliedConfigAnnotation is the annotation kubectl apply uses to store
+// the configuration it last applied to an object.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// threeWayMerge returns the desired state of an in-cluster object after merging
+// the backed up version into it, the same way kubectl apply does: fields set in the
+// backed up version overwrite those in the cluster, and fields in the in-cluster
+// object's last applied configuration that aren't in the backed up version are
+// removed. If there's no last applied configuration, no fields are removed.
+// Built-in types are merged with a strategic merge patch, so lists are merged
+// by key; other types are merged with a JSON merge patch.
+func threeWayMerge(fromCluster, fromBackup *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current, err := json.Marshal(fromCluster.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal in-cluster object")
+	}
+
+	modified, err := json.Marshal(fromBackup.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal backed up object")
+	}
+
+	original := modified
+	if lastApplied := fromCluster.GetAnnotations()[lastAppliedConfigAnnotation]; lastApplied != "" {
+		original = []byte(lastApplied)
+	}
+
+	var desired []byte
+	if typed, err := scheme.Scheme.New(fromCluster.GroupVersionKind()); err == nil {
+		patchMeta, err := strategicpatch.NewPatchMetaFromStruct(typed)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get strategic merge patch metadata")
+		}
+
+		patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, current, patchMeta, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create three-way strategic merge patch")
+		}
+
+		if desired, err = strategicpatch.StrategicMergePatch(current, patch, typed); err != nil {
+			return nil, errors.Wrap(err, "unable to apply three-way strategic merge patch")
+		}
+	} else {
+		patch, err := createThreeWayJSONMergePatch(original, modified, current)
+		if err != nil {
+			return nil, err
+		}
+
+		if desired, err = jsonpatch.MergePatch(current, patch); err != nil {
+			return nil, errors.Wrap(err, "unable to apply three-way JSON merge patch")
+		}
+	}
+
+	res := new(unstructured.Unstructured)
+	if err := json.Unmarshal(desired, &res.Object); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal merged object")
+	}
+
+	return res, nil
+}
+
+// createThreeWayJSONMergePatch returns a JSON merge patch that updates current with
+// the fields that were changed or added in modified, and removes the fields that
+// were removed between original and modified.
+func createThreeWayJSONMergePatch(original, modified, current []byte) ([]byte, error) {
+	addAndChangePatch, err := jsonpatch.CreateMergePatch(current, modified)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create merge patch for added and changed fields")
+	}
+	addAndChange := make(map[string]interface{})
+	if err := json.Unmarshal(addAndChangePatch, &addAndChange); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal merge patch")
+	}
+	filterNulls(addAndChange, false)
+
+	deletionPatch, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create merge patch for deleted fields")
+	}
+	deletions := make(map[string]interface{})
+	if err := json.Unmarshal(deletionPatch, &deletions); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal merge patch")
+	}
+	filterNulls(deletions, true)
+
+	addAndChangePatch, err = json.Marshal(addAndChange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal merge patch")
+	}
+	deletionPatch, err = json.Marshal(deletions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal merge patch")
+	}
+
+	patch, err := jsonpatch.MergeMergePatches(deletionPatch, addAndChangePatch)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to combine merge patches")
+	}
+
+	return patch, nil
+}
+
+// filterNulls removes fields from a JSON merge patch so that, if keepNulls is true,
+// only the deletions (null values) remain, or if it's false, only the additions and
+// changes remain. Nested objects left empty are removed.
+func filterNulls(patch map[string]interface{}, keepNulls bool) {
+	for key, val := range patch {
+		switch typed := val.(type) {
+		case nil:
+			if !keepNulls {
+				delete(patch, key)
+			}
+		case map[string]interface{}:
+			filterNulls(typed, keepNulls)
+			if len(typed) == 0 {
+				delete(patch, key)
+			}
+		default:
+			if keepNulls {
+				delete(patch, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func TestThreeWayMerge(t *testing.T) {
+	tests := []struct {
+		name        string
+		fromCluster string
+		fromBackup  string
+		expected    string
+	}{
+		{
+			name:        "built-in type without last applied configuration adds and changes fields, and merges lists by key",
+			fromCluster: `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","labels":{"a":"cluster","b":"cluster"}},"spec":{"containers":[{"name":"c1","image":"cluster"},{"name":"c2","image":"cluster"}]}}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","labels":{"a":"backup"}},"spec":{"containers":[{"name":"c1","image":"backup"}]}}`,
+			expected:    `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","labels":{"a":"backup","b":"cluster"}},"spec":{"containers":[{"name":"c1","image":"backup"},{"name":"c2","image":"cluster"}]}}`,
+		},
+		{
+			name:        "built-in type with last applied configuration removes fields that are no longer applied",
+			fromCluster: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"cm-1\"},\"data\":{\"a\":\"1\",\"b\":\"2\"}}"}},"data":{"a":"1","b":"2","c":"3"}}`,
+			fromBackup:  `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"},"data":{"a":"4"}}`,
+			expected:    `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"cm-1\"},\"data\":{\"a\":\"1\",\"b\":\"2\"}}"}},"data":{"a":"4","c":"3"}}`,
+		},
+		{
+			name:        "custom resource without last applied configuration adds and changes fields",
+			fromCluster: `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo-1"},"spec":{"a":"cluster","b":"cluster","list":["cluster"]}}`,
+			fromBackup:  `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo-1"},"spec":{"a":"backup","list":["backup"]}}`,
+			expected:    `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo-1"},"spec":{"a":"backup","b":"cluster","list":["backup"]}}`,
+		},
+		{
+			name:        "custom resource with last applied configuration removes fields that are no longer applied",
+			fromCluster: `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo-1","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"apiVersion\":\"example.com/v1\",\"kind\":\"Foo\",\"metadata\":{\"name\":\"foo-1\"},\"spec\":{\"a\":\"applied\",\"b\":\"applied\"}}"}},"spec":{"a":"cluster","b":"cluster","c":"cluster"}}`,
+			fromBackup:  `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo-1"},"spec":{"a":"backup"}}`,
+			expected:    `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo-1","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"apiVersion\":\"example.com/v1\",\"kind\":\"Foo\",\"metadata\":{\"name\":\"foo-1\"},\"spec\":{\"a\":\"applied\",\"b\":\"applied\"}}"}},"spec":{"a":"backup","c":"cluster"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			desired, err := threeWayMerge(arktest.UnstructuredOrDie(test.fromCluster), arktest.UnstructuredOrDie(test.fromBackup))
+			require.NoError(t, err)
+			assert.Equal(t, arktest.UnstructuredOrDie(test.expected), desired)
+		})
+	}
+}
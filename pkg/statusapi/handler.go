@@ -0,0 +1,338 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statusapi serves a read-only JSON API of the Ark server's backups,
+// restores, schedules and storage locations, for dashboards and on-call
+// operators that don't have access to the cluster.
+package statusapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	listers "github.com/heptio/ark/pkg/generated/listers/ark/v1"
+)
+
+// pathPrefix is the prefix of the paths of all the API's endpoints.
+const pathPrefix = "/api/v1/"
+
+// LogStreamer writes the log of the named backup or restore, as identified by
+// kind, to w.
+type LogStreamer func(name string, kind api.DownloadTargetKind, w io.Writer) error
+
+// backupEntry is a backup and the API paths of its related files.
+type backupEntry struct {
+	*api.Backup
+	Links map[string]string `json:"links"`
+}
+
+// restoreEntry is a restore and the API paths of its related files.
+type restoreEntry struct {
+	*api.Restore
+	Links map[string]string `json:"links"`
+}
+
+// progress lists the backups and restores that are in progress.
+type progress struct {
+	Backups  []backupEntry  `json:"backups"`
+	Restores []restoreEntry `json:"restores"`
+}
+
+type handler struct {
+	namespace                    string
+	token                        []byte
+	backupLister                 listers.BackupLister
+	restoreLister                listers.RestoreLister
+	scheduleLister               listers.ScheduleLister
+	backupStorageLocationLister  listers.BackupStorageLocationLister
+	volumeSnapshotLocationLister listers.VolumeSnapshotLocationLister
+	streamLog                    LogStreamer
+	logger                       logrus.FieldLogger
+}
+
+// NewHandler returns an http.Handler that serves the API for the Ark objects in
+// namespace. Requests must have an "Authorization: Bearer <token>" header.
+func NewHandler(
+	namespace string,
+	token string,
+	backupLister listers.BackupLister,
+	restoreLister listers.RestoreLister,
+	scheduleLister listers.ScheduleLister,
+	backupStorageLocationLister listers.BackupStorageLocationLister,
+	volumeSnapshotLocationLister listers.VolumeSnapshotLocationLister,
+	streamLog LogStreamer,
+	logger logrus.FieldLogger,
+) http.Handler {
+	return &handler{
+		namespace:                    namespace,
+		token:                        []byte(token),
+		backupLister:                 backupLister,
+		restoreLister:                restoreLister,
+		scheduleLister:               scheduleLister,
+		backupStorageLocationLister:  backupStorageLocationLister,
+		volumeSnapshotLocationLister: volumeSnapshotLocationLister,
+		streamLog:                    streamLog,
+		logger:                       logger,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, pathPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+
+	// paths are <collection>, <collection>/<name> or <collection>/<name>/logs
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, pathPrefix), "/"), "/")
+
+	var (
+		obj interface{}
+		err error
+	)
+	switch {
+	case len(parts) == 1:
+		obj, err = h.list(parts[0])
+	case len(parts) == 2:
+		obj, err = h.get(parts[0], parts[1])
+	case len(parts) == 3 && parts[2] == "logs":
+		h.serveLog(w, r, parts[0], parts[1])
+		return
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		http.NotFound(w, r)
+	case err != nil:
+		h.logger.WithError(err).WithField("path", r.URL.Path).Error("Error serving status API request")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	case obj == nil:
+		http.NotFound(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(obj); err != nil {
+			h.logger.WithError(err).WithField("path", r.URL.Path).Error("Error writing status API response")
+		}
+	}
+}
+
+// authorized returns true if r has the handler's bearer token.
+func (h *handler) authorized(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+
+	return len(h.token) > 0 && subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), h.token) == 1
+}
+
+// list returns the objects of the given collection, or nil if there's no such
+// collection.
+func (h *handler) list(collection string) (interface{}, error) {
+	switch collection {
+	case "backups":
+		backups, err := h.backupLister.Backups(h.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"items": backupEntries(backups)}, nil
+	case "restores":
+		restores, err := h.restoreLister.Restores(h.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"items": restoreEntries(restores)}, nil
+	case "schedules":
+		schedules, err := h.scheduleLister.Schedules(h.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+		return map[string]interface{}{"items": schedules}, nil
+	case "backupstoragelocations":
+		locations, err := h.backupStorageLocationLister.BackupStorageLocations(h.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(locations, func(i, j int) bool { return locations[i].Name < locations[j].Name })
+		return map[string]interface{}{"items": locations}, nil
+	case "volumesnapshotlocations":
+		locations, err := h.volumeSnapshotLocationLister.VolumeSnapshotLocations(h.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(locations, func(i, j int) bool { return locations[i].Name < locations[j].Name })
+		return map[string]interface{}{"items": locations}, nil
+	case "progress":
+		return h.progress()
+	default:
+		return nil, nil
+	}
+}
+
+// get returns the named object of the given collection, or nil if there's no
+// such collection.
+func (h *handler) get(collection, name string) (interface{}, error) {
+	switch collection {
+	case "backups":
+		backup, err := h.backupLister.Backups(h.namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return newBackupEntry(backup), nil
+	case "restores":
+		restore, err := h.restoreLister.Restores(h.namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return newRestoreEntry(restore), nil
+	case "schedules":
+		return h.scheduleLister.Schedules(h.namespace).Get(name)
+	case "backupstoragelocations":
+		return h.backupStorageLocationLister.BackupStorageLocations(h.namespace).Get(name)
+	case "volumesnapshotlocations":
+		return h.volumeSnapshotLocationLister.VolumeSnapshotLocations(h.namespace).Get(name)
+	default:
+		return nil, nil
+	}
+}
+
+// progress returns the backups and restores that are in progress.
+func (h *handler) progress() (*progress, error) {
+	backups, err := h.backupLister.Backups(h.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	restores, err := h.restoreLister.Restores(h.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &progress{Backups: []backupEntry{}, Restores: []restoreEntry{}}
+	for _, entry := range backupEntries(backups) {
+		if entry.Status.Phase == api.BackupPhaseInProgress {
+			res.Backups = append(res.Backups, entry)
+		}
+	}
+	for _, entry := range restoreEntries(restores) {
+		if entry.Status.Phase == api.RestorePhaseInProgress {
+			res.Restores = append(res.Restores, entry)
+		}
+	}
+
+	return res, nil
+}
+
+// serveLog writes the log of the named backup or restore as plain text.
+func (h *handler) serveLog(w http.ResponseWriter, r *http.Request, collection, name string) {
+	var (
+		kind api.DownloadTargetKind
+		err  error
+	)
+	switch collection {
+	case "backups":
+		kind = api.DownloadTargetKindBackupLog
+		_, err = h.backupLister.Backups(h.namespace).Get(name)
+	case "restores":
+		kind = api.DownloadTargetKindRestoreLog
+		_, err = h.restoreLister.Restores(h.namespace).Get(name)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if apierrors.IsNotFound(err) {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err == nil {
+		err = h.streamLog(name, kind, w)
+	}
+	if err != nil {
+		// the log may have been partially written, so the status can't be changed
+		h.logger.WithError(err).WithField("path", r.URL.Path).Error("Error streaming log")
+	}
+}
+
+func newBackupEntry(backup *api.Backup) backupEntry {
+	return backupEntry{
+		Backup: backup,
+		Links:  map[string]string{"logs": pathPrefix + "backups/" + backup.Name + "/logs"},
+	}
+}
+
+// backupEntries returns entries for backups, newest first.
+func backupEntries(backups []*api.Backup) []backupEntry {
+	sort.Slice(backups, func(i, j int) bool {
+		return newerThan(backups[i].CreationTimestamp.Time, backups[j].CreationTimestamp.Time, backups[i].Name, backups[j].Name)
+	})
+
+	entries := make([]backupEntry, 0, len(backups))
+	for _, backup := range backups {
+		entries = append(entries, newBackupEntry(backup))
+	}
+	return entries
+}
+
+func newRestoreEntry(restore *api.Restore) restoreEntry {
+	return restoreEntry{
+		Restore: restore,
+		Links:   map[string]string{"logs": pathPrefix + "restores/" + restore.Name + "/logs"},
+	}
+}
+
+// restoreEntries returns entries for restores, newest first.
+func restoreEntries(restores []*api.Restore) []restoreEntry {
+	sort.Slice(restores, func(i, j int) bool {
+		return newerThan(restores[i].CreationTimestamp.Time, restores[j].CreationTimestamp.Time, restores[i].Name, restores[j].Name)
+	})
+
+	entries := make([]restoreEntry, 0, len(restores))
+	for _, restore := range restores {
+		entries = append(entries, newRestoreEntry(restore))
+	}
+	return entries
+}
+
+// newerThan orders objects by creation time, newest first, and then by name.
+func newerThan(a, b time.Time, aName, bName string) bool {
+	if !a.Equal(b) {
+		return a.After(b)
+	}
+	return aName < bName
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/generated/clientset/versioned/fake"
+	informers "github.com/heptio/ark/pkg/generated/informers/externalversions"
+	arktest "github.com/heptio/ark/pkg/util/test"
+)
+
+func newTestHandler(t *testing.T) http.Handler {
+	sharedInformers := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+
+	backups := sharedInformers.Ark().V1().Backups().Informer().GetStore()
+	require.NoError(t, backups.Add(arktest.NewTestBackup().WithNamespace(api.DefaultNamespace).WithName("backup-1").WithPhase(api.BackupPhaseCompleted).Backup))
+	require.NoError(t, backups.Add(arktest.NewTestBackup().WithNamespace(api.DefaultNamespace).WithName("backup-2").WithPhase(api.BackupPhaseInProgress).Backup))
+	require.NoError(t, backups.Add(arktest.NewTestBackup().WithNamespace("other-ns").WithName("backup-3").Backup))
+
+	restores := sharedInformers.Ark().V1().Restores().Informer().GetStore()
+	require.NoError(t, restores.Add(arktest.NewTestRestore(api.DefaultNamespace, "restore-1", api.RestorePhaseInProgress).Restore))
+
+	return NewHandler(
+		api.DefaultNamespace,
+		"secret",
+		sharedInformers.Ark().V1().Backups().Lister(),
+		sharedInformers.Ark().V1().Restores().Lister(),
+		sharedInformers.Ark().V1().Schedules().Lister(),
+		sharedInformers.Ark().V1().BackupStorageLocations().Lister(),
+		sharedInformers.Ark().V1().VolumeSnapshotLocations().Lister(),
+		func(name string, kind api.DownloadTargetKind, w io.Writer) error {
+			_, err := io.WriteString(w, string(kind)+" of "+name)
+			return err
+		},
+		arktest.NewLogger(),
+	)
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		expectedCode  int
+	}{
+		{
+			name:         "no token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong token",
+			authorization: "Bearer wrong",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "not a bearer token",
+			authorization: "Basic secret",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "correct token",
+			authorization: "Bearer secret",
+			expectedCode:  http.StatusOK,
+		},
+	}
+
+	handler := newTestHandler(t)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/backups", nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		expectedBody string
+		expectedJSON map[string]interface{}
+	}{
+		{
+			name:         "backups are listed newest first, with links to their logs",
+			path:         "/api/v1/backups",
+			expectedCode: http.StatusOK,
+			expectedJSON: map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"name": "backup-1", "phase": "Completed", "logs": "/api/v1/backups/backup-1/logs"},
+					map[string]interface{}{"name": "backup-2", "phase": "InProgress", "logs": "/api/v1/backups/backup-2/logs"},
+				},
+			},
+		},
+		{
+			name:         "a backup can be fetched by name",
+			path:         "/api/v1/backups/backup-2",
+			expectedCode: http.StatusOK,
+			expectedJSON: map[string]interface{}{"name": "backup-2", "phase": "InProgress", "logs": "/api/v1/backups/backup-2/logs"},
+		},
+		{
+			name:         "backups in other namespaces aren't found",
+			path:         "/api/v1/backups/backup-3",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "a restore's log is streamed",
+			path:         "/api/v1/restores/restore-1/logs",
+			expectedCode: http.StatusOK,
+			expectedBody: "RestoreLog of restore-1",
+		},
+		{
+			name:         "logs of missing backups aren't found",
+			path:         "/api/v1/backups/missing/logs",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "unknown collections aren't found",
+			path:         "/api/v1/widgets",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "only GET requests are supported",
+			method:       http.MethodDelete,
+			path:         "/api/v1/backups/backup-1",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	handler := newTestHandler(t)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, test.path, nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			require.Equal(t, test.expectedCode, res.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, res.Body.String())
+			}
+			if test.expectedJSON != nil {
+				var actual map[string]interface{}
+				require.NoError(t, json.Unmarshal(res.Body.Bytes(), &actual))
+				assert.Equal(t, test.expectedJSON, summarize(actual))
+			}
+		})
+	}
+}
+
+func TestHandlerProgress(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/progress", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+
+	newTestHandler(t).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code)
+
+	var actual progress
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &actual))
+	require.Len(t, actual.Backups, 1)
+	assert.Equal(t, "backup-2", actual.Backups[0].Name)
+	require.Len(t, actual.Restores, 1)
+	assert.Equal(t, "restore-1", actual.Restores[0].Name)
+}
+
+// summarize reduces a decoded backup, or list of backups, to its name, phase
+// and log link.
+func summarize(obj map[string]interface{}) map[string]interface{} {
+	if items, ok := obj["items"].([]interface{}); ok {
+		var summaries []interface{}
+		for _, item := range items {
+			summaries = append(summaries, summarize(item.(map[string]interface{})))
+		}
+		return map[string]interface{}{"items": summaries}
+	}
+
+	return map[string]interface{}{
+		"name":  obj["metadata"].(map[string]interface{})["name"],
+		"phase": obj["status"].(map[string]interface{})["phase"],
+		"logs":  obj["links"].(map[string]interface{})["logs"],
+	}
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystem
+
+import "github.com/pkg/errors"
+
+// FreeSpace returns an error, since getting the free space of a file system isn't
+// supported on Windows.
+func FreeSpace(path string) (int64, error) {
+	return 0, errors.Errorf("error getting free space of %s: not supported on windows", path)
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package kube
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
 	corev1api "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
@@ -49,6 +51,44 @@ func EnsureNamespaceExists(namespace *corev1api.Namespace, client corev1client.N
 	}
 }
 
+// MergeNamespaceMetadata adds the labels and annotations of the provided Kubernetes namespace to
+// the existing namespace with the same name, replacing the existing values of any keys they have
+// in common. Labels and annotations that only the existing namespace has are kept.
+func MergeNamespaceMetadata(namespace *corev1api.Namespace, client corev1client.NamespaceInterface) error {
+	metadata := make(map[string]interface{})
+	if len(namespace.Labels) > 0 {
+		metadata["labels"] = namespace.Labels
+	}
+	if len(namespace.Annotations) > 0 {
+		metadata["annotations"] = namespace.Annotations
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	patchBytes, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling patch for namespace %s", namespace.Name)
+	}
+
+	if _, err := client.Patch(namespace.Name, types.MergePatchType, patchBytes); err != nil {
+		return errors.Wrapf(err, "error patching namespace %s", namespace.Name)
+	}
+
+	return nil
+}
+
+// ClusterUID returns the UID of the kube-system namespace, which identifies the cluster, since
+// the namespace is created along with the cluster and can't be deleted.
+func ClusterUID(client corev1client.NamespaceInterface) (string, error) {
+	namespace, err := client.Get(metav1.NamespaceSystem, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "error getting namespace %s", metav1.NamespaceSystem)
+	}
+
+	return string(namespace.UID), nil
+}
+
 // GetVolumeDirectory gets the name of the directory on the host, under /var/lib/kubelet/pods/<podUID>/volumes/,
 // where the specified volume lives.
 func GetVolumeDirectory(pod *corev1api.Pod, volumeName string, pvcLister corev1listers.PersistentVolumeClaimLister) (string, error) {
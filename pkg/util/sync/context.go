@@ -0,0 +1,42 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import "context"
+
+// RunWithContext runs action in a goroutine and returns its error, or ctx's
+// error if ctx is done before action returns. In that case, action keeps
+// running in the background and its result is discarded, so it must not
+// modify any state that the caller reads afterwards.
+func RunWithContext(ctx context.Context, action func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// buffered so that the goroutine can exit even if its result is discarded
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- action()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
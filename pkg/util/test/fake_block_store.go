@@ -23,12 +23,16 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	api "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/cloudprovider"
 )
 
 type FakeBlockStore struct {
 	// SnapshotID->VolumeID
 	SnapshotsTaken sets.String
 
+	// SnapshotID -> tags
+	SnapshotTags map[string]map[string]string
+
 	// VolumeID -> (SnapshotID, Type, Iops)
 	SnapshottableVolumes map[string]api.VolumeBackupInfo
 
@@ -59,6 +63,11 @@ func (bs *FakeBlockStore) CreateSnapshot(volumeID, volumeAZ string, tags map[str
 	}
 	bs.SnapshotsTaken.Insert(bs.SnapshottableVolumes[volumeID].SnapshotID)
 
+	if bs.SnapshotTags == nil {
+		bs.SnapshotTags = make(map[string]map[string]string)
+	}
+	bs.SnapshotTags[bs.SnapshottableVolumes[volumeID].SnapshotID] = tags
+
 	return bs.SnapshottableVolumes[volumeID].SnapshotID, nil
 }
 
@@ -111,3 +120,40 @@ func (bs *FakeBlockStore) SetVolumeID(pv runtime.Unstructured, volumeID string)
 	bs.VolumeIDSet = volumeID
 	return pv, bs.Error
 }
+
+func (bs *FakeBlockStore) ListSnapshots(tags map[string]string) ([]cloudprovider.SnapshotInfo, error) {
+	if bs.Error != nil {
+		return nil, bs.Error
+	}
+
+	var snapshots []cloudprovider.SnapshotInfo
+	for _, snapshotID := range bs.SnapshotsTaken.List() {
+		snapshotTags := bs.SnapshotTags[snapshotID]
+
+		matches := true
+		for k, v := range tags {
+			if snapshotTags[k] != v {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			snapshots = append(snapshots, cloudprovider.SnapshotInfo{ID: snapshotID, Tags: snapshotTags})
+		}
+	}
+
+	return snapshots, nil
+}
+
+func (bs *FakeBlockStore) GetSnapshotInfo(snapshotID string) (*cloudprovider.SnapshotInfo, error) {
+	if bs.Error != nil {
+		return nil, bs.Error
+	}
+
+	if !bs.SnapshotsTaken.Has(snapshotID) {
+		return nil, nil
+	}
+
+	return &cloudprovider.SnapshotInfo{ID: snapshotID, Tags: bs.SnapshotTags[snapshotID]}, nil
+}
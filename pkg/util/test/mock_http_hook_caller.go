@@ -0,0 +1,34 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/heptio/ark/pkg/httphook"
+)
+
+type MockHTTPHookCaller struct {
+	mock.Mock
+}
+
+func (c *MockHTTPHookCaller) Call(log logrus.FieldLogger, hook *v1.HTTPHook, payload *httphook.Payload) error {
+	args := c.Called(log, hook, payload)
+	return args.Error(0)
+}
@@ -57,6 +57,20 @@ func (b *TestBackup) WithLabel(key, value string) *TestBackup {
 	return b
 }
 
+func (b *TestBackup) WithAnnotation(key, value string) *TestBackup {
+	if b.Annotations == nil {
+		b.Annotations = make(map[string]string)
+	}
+	b.Annotations[key] = value
+
+	return b
+}
+
+func (b *TestBackup) WithHold(hold bool) *TestBackup {
+	b.Spec.Hold = hold
+	return b
+}
+
 func (b *TestBackup) WithPhase(phase v1.BackupPhase) *TestBackup {
 	b.Status.Phase = phase
 	return b
@@ -97,6 +111,11 @@ func (b *TestBackup) WithVersion(version int) *TestBackup {
 	return b
 }
 
+func (b *TestBackup) WithServerVersion(version string) *TestBackup {
+	b.Status.ServerVersion = version
+	return b
+}
+
 func (b *TestBackup) WithSnapshot(pv string, snapshot string) *TestBackup {
 	if b.Status.VolumeBackups == nil {
 		b.Status.VolumeBackups = make(map[string]*v1.VolumeBackupInfo)
@@ -156,7 +175,49 @@ func (b *TestBackup) WithStorageLocation(location string) *TestBackup {
 	return b
 }
 
+func (b *TestBackup) WithFilterProfile(name string) *TestBackup {
+	b.Spec.FilterProfile = name
+	return b
+}
+
+func (b *TestBackup) WithClientLimits(qps, burst, pageSize int) *TestBackup {
+	b.Spec.ClientQPS = qps
+	b.Spec.ClientBurst = burst
+	b.Spec.PageSize = pageSize
+	return b
+}
+
+func (b *TestBackup) WithLabelSelector(selector *metav1.LabelSelector) *TestBackup {
+	b.Spec.LabelSelector = selector
+	return b
+}
+
+func (b *TestBackup) WithHelmReleases(releases ...string) *TestBackup {
+	b.Spec.HelmReleases = releases
+	return b
+}
+
+func (b *TestBackup) WithArchiveFormat(format v1.ArchiveFormat) *TestBackup {
+	b.Spec.ArchiveFormat = format
+	return b
+}
+
 func (b *TestBackup) WithVolumeSnapshotLocations(locations ...string) *TestBackup {
 	b.Spec.VolumeSnapshotLocations = locations
 	return b
 }
+
+func (b *TestBackup) WithFollowReferences(value bool) *TestBackup {
+	b.Spec.FollowReferences = value
+	return b
+}
+
+func (b *TestBackup) WithServiceAccountName(name string) *TestBackup {
+	b.Spec.ServiceAccountName = name
+	return b
+}
+
+func (b *TestBackup) WithResticHostPathVolumes(value bool) *TestBackup {
+	b.Spec.ResticHostPathVolumes = value
+	return b
+}
@@ -66,3 +66,8 @@ func (b *TestBackupStorageLocation) WithObjectStorage(bucketName string) *TestBa
 	b.Spec.ObjectStorage.Bucket = bucketName
 	return b
 }
+
+func (b *TestBackupStorageLocation) WithDeletionProtection(value bool) *TestBackupStorageLocation {
+	b.Spec.DeletionProtection = value
+	return b
+}
@@ -70,6 +70,57 @@ func (r *TestRestore) WithSchedule(name string) *TestRestore {
 	return r
 }
 
+func (r *TestRestore) WithApplication(name string) *TestRestore {
+	r.Spec.ApplicationName = name
+	return r
+}
+
+func (r *TestRestore) WithRestorePriority(name string) *TestRestore {
+	r.Spec.RestorePriorityName = name
+	return r
+}
+
+func (r *TestRestore) WithConflictPolicy(policy api.RestoreConflictPolicy) *TestRestore {
+	r.Spec.ConflictPolicy = policy
+	return r
+}
+
+func (r *TestRestore) WithCreateNamespaces(policy api.RestoreNamespaceCreationPolicy) *TestRestore {
+	r.Spec.CreateNamespaces = policy
+	return r
+}
+
+func (r *TestRestore) WithNamespaceOrder(namespaces ...string) *TestRestore {
+	r.Spec.NamespaceOrder = namespaces
+	return r
+}
+
+func (r *TestRestore) WithStripAnnotations(entries ...string) *TestRestore {
+	r.Spec.StripAnnotations = entries
+	return r
+}
+
+func (r *TestRestore) WithSecurityPolicyTranslation(translation api.RestoreSecurityPolicyTranslation) *TestRestore {
+	r.Spec.SecurityPolicyTranslation = translation
+	return r
+}
+
+func (r *TestRestore) WithIterationMode(mode api.RestoreIterationMode) *TestRestore {
+	r.Spec.IterationMode = mode
+	return r
+}
+
+func (r *TestRestore) WithClientLimits(qps, burst int) *TestRestore {
+	r.Spec.ClientQPS = qps
+	r.Spec.ClientBurst = burst
+	return r
+}
+
+func (r *TestRestore) WithWaitForNamespaceLock(wait bool) *TestRestore {
+	r.Spec.WaitForNamespaceLock = wait
+	return r
+}
+
 func (r *TestRestore) WithErrors(i int) *TestRestore {
 	r.Status.Errors = i
 	return r
@@ -97,3 +148,22 @@ func (r *TestRestore) WithExcludedResource(resource string) *TestRestore {
 	r.Spec.ExcludedResources = append(r.Spec.ExcludedResources, resource)
 	return r
 }
+
+func (r *TestRestore) WithServiceAccountName(name string) *TestRestore {
+	r.Spec.ServiceAccountName = name
+	return r
+}
+
+func (r *TestRestore) WithHook(hook api.RestoreResourceHookSpec) *TestRestore {
+	r.Spec.Hooks.Resources = append(r.Spec.Hooks.Resources, hook)
+	return r
+}
+
+func (r *TestRestore) WithLabel(key, value string) *TestRestore {
+	if r.Labels == nil {
+		r.Labels = make(map[string]string)
+	}
+	r.Labels[key] = value
+
+	return r
+}
@@ -59,3 +59,9 @@ func (s *TestSchedule) WithLastBackupTime(timeString string) *TestSchedule {
 	s.Status.LastBackup = metav1.Time{Time: t}
 	return s
 }
+
+func (s *TestSchedule) WithLastBackup(name string, sequence int64) *TestSchedule {
+	s.Status.LastBackupName = name
+	s.Status.LastBackupSequence = sequence
+	return s
+}
@@ -50,6 +50,12 @@ func (location *TestVolumeSnapshotLocation) WithProvider(name string) *TestVolum
 	return location
 }
 
+func (location *TestVolumeSnapshotLocation) WithPhase(phase v1.VolumeSnapshotLocationPhase, message string) *TestVolumeSnapshotLocation {
+	location.Status.Phase = phase
+	location.Status.Message = message
+	return location
+}
+
 func (location *TestVolumeSnapshotLocation) WithProviderConfig(info []LocationInfo) []*TestVolumeSnapshotLocation {
 	var locations []*TestVolumeSnapshotLocation
 
@@ -0,0 +1,37 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+// PodVolumeSnapshot stores information about a restic snapshot of a pod
+// volume taken as part of an Ark backup.
+type PodVolumeSnapshot struct {
+	// PodNamespace is the namespace of the pod whose volume was backed up.
+	PodNamespace string `json:"podNamespace"`
+
+	// PodName is the name of the pod whose volume was backed up.
+	PodName string `json:"podName"`
+
+	// Volume is the name of the volume within the pod.
+	Volume string `json:"volume"`
+
+	// PersistentVolumeClaim is the name of the persistent volume claim
+	// backing the volume, if any.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+
+	// SnapshotID is the ID of the restic snapshot of the volume.
+	SnapshotID string `json:"snapshotID"`
+}
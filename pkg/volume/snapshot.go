@@ -46,6 +46,9 @@ type SnapshotSpec struct {
 	// API.
 	VolumeType string `json:"volumeType"`
 
+	// VolumeSize is the capacity of the persistent volume, e.g. "10Gi".
+	VolumeSize string `json:"volumeSize,omitempty"`
+
 	// VolumeAZ is the where the volume is provisioned
 	// in the cloud provider.
 	VolumeAZ string `json:"volumeAZ,omitempty"`
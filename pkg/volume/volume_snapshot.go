@@ -0,0 +1,82 @@
+/*
+Copyright 2018 the Heptio Ark contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1api "github.com/heptio/ark/pkg/apis/ark/v1"
+)
+
+// VolumeSnapshotName returns the name of the VolumeSnapshot record
+// for the snapshot of the named persistent volume taken by a backup.
+func VolumeSnapshotName(backupName, pvName string) string {
+	return fmt.Sprintf("%s-%s", backupName, pvName)
+}
+
+// NewVolumeSnapshot returns a VolumeSnapshot record, in the given namespace,
+// for snapshot. The record is labeled with the name of the snapshot's backup.
+func NewVolumeSnapshot(namespace string, snapshot *Snapshot) *arkv1api.VolumeSnapshot {
+	return &arkv1api.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      VolumeSnapshotName(snapshot.Spec.BackupName, snapshot.Spec.PersistentVolumeName),
+			Labels: map[string]string{
+				arkv1api.BackupNameLabel: snapshot.Spec.BackupName,
+			},
+		},
+		Spec: arkv1api.VolumeSnapshotSpec{
+			BackupName:           snapshot.Spec.BackupName,
+			BackupUID:            snapshot.Spec.BackupUID,
+			Location:             snapshot.Spec.Location,
+			PersistentVolumeName: snapshot.Spec.PersistentVolumeName,
+			ProviderVolumeID:     snapshot.Spec.ProviderVolumeID,
+			VolumeType:           snapshot.Spec.VolumeType,
+			VolumeSize:           snapshot.Spec.VolumeSize,
+			VolumeAZ:             snapshot.Spec.VolumeAZ,
+			VolumeIOPS:           snapshot.Spec.VolumeIOPS,
+		},
+		Status: arkv1api.VolumeSnapshotStatus{
+			ProviderSnapshotID: snapshot.Status.ProviderSnapshotID,
+			Phase:              arkv1api.VolumeSnapshotPhase(snapshot.Status.Phase),
+		},
+	}
+}
+
+// SnapshotFromVolumeSnapshot returns the snapshot described by a VolumeSnapshot
+// record.
+func SnapshotFromVolumeSnapshot(volumeSnapshot *arkv1api.VolumeSnapshot) *Snapshot {
+	return &Snapshot{
+		Spec: SnapshotSpec{
+			BackupName:           volumeSnapshot.Spec.BackupName,
+			BackupUID:            volumeSnapshot.Spec.BackupUID,
+			Location:             volumeSnapshot.Spec.Location,
+			PersistentVolumeName: volumeSnapshot.Spec.PersistentVolumeName,
+			ProviderVolumeID:     volumeSnapshot.Spec.ProviderVolumeID,
+			VolumeType:           volumeSnapshot.Spec.VolumeType,
+			VolumeSize:           volumeSnapshot.Spec.VolumeSize,
+			VolumeAZ:             volumeSnapshot.Spec.VolumeAZ,
+			VolumeIOPS:           volumeSnapshot.Spec.VolumeIOPS,
+		},
+		Status: SnapshotStatus{
+			ProviderSnapshotID: volumeSnapshot.Status.ProviderSnapshotID,
+			Phase:              SnapshotPhase(volumeSnapshot.Status.Phase),
+		},
+	}
+}